@@ -25,6 +25,43 @@ type ToolRule struct {
 	Name          string         `json:"name"`
 	Decision      PolicyDecision `json:"decision"`
 	RequiredTrust TrustLevel     `json:"requiredTrust,omitempty"`
+	// ArgumentConstraints restrict the tools/call arguments an allow rule
+	// accepts. Every constraint must hold; a violation is denied with
+	// argument_constraint_violated.
+	ArgumentConstraints []ArgumentConstraint `json:"argumentConstraints,omitempty"`
+}
+
+// PromptRule controls access to an individual MCP prompt via prompts/get.
+// +kubebuilder:object:generate=true
+type PromptRule struct {
+	Name          string         `json:"name"`
+	Decision      PolicyDecision `json:"decision"`
+	RequiredTrust TrustLevel     `json:"requiredTrust,omitempty"`
+}
+
+// ResourceRule controls access via resources/read and resources/subscribe to
+// MCP resources whose URI matches a glob ("*" matches any run of characters).
+// +kubebuilder:object:generate=true
+type ResourceRule struct {
+	URI           string         `json:"uri"`
+	Decision      PolicyDecision `json:"decision"`
+	RequiredTrust TrustLevel     `json:"requiredTrust,omitempty"`
+}
+
+// ArgumentConstraint restricts one top-level key of tools/call params.arguments.
+// When the argument is an array, every element must satisfy the constraint.
+// +kubebuilder:object:generate=true
+type ArgumentConstraint struct {
+	Argument string `json:"argument"`
+	// OneOf lists the allowed values, compared as strings.
+	OneOf []string `json:"oneOf,omitempty"`
+	// Pattern is a glob string values must match; "*" matches any run of characters.
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum and Maximum bound numeric values inclusively.
+	Minimum *int64 `json:"minimum,omitempty"`
+	Maximum *int64 `json:"maximum,omitempty"`
+	// Optional allows calls that omit the argument.
+	Optional bool `json:"optional,omitempty"`
 }
 
 // MCPAccessGrantSpec defines who can use which MCP server and with what trust ceiling.
@@ -37,14 +74,43 @@ type MCPAccessGrantSpec struct {
 	PolicyVersion      string           `json:"policyVersion,omitempty"`
 	Disabled           bool             `json:"disabled,omitempty"`
 	ToolRules          []ToolRule       `json:"toolRules,omitempty"`
+	// PromptRules and ResourceRules restrict prompts and resources the way
+	// toolRules restrict tools. When empty, the grant does not restrict them
+	// by name or URI; prompts and resources are reads, so the grant must still
+	// allow the read side effect.
+	PromptRules   []PromptRule   `json:"promptRules,omitempty"`
+	ResourceRules []ResourceRule `json:"resourceRules,omitempty"`
+	// RateLimits cap how often each subject matched by this grant may call
+	// tools. Calls over a limit are denied with HTTP 429 and rate_limited.
+	RateLimits []RateLimit `json:"rateLimits,omitempty"`
+}
+
+// RateLimit caps tool calls per subject. Tool and SideEffect narrow which
+// calls count; with both empty every tool call allowed by the grant counts.
+// +kubebuilder:object:generate=true
+type RateLimit struct {
+	Tool       string         `json:"tool,omitempty"`
+	SideEffect ToolSideEffect `json:"sideEffect,omitempty"`
+	// CallsPerMinute is enforced over fixed one-minute windows.
+	// +kubebuilder:validation:Minimum=0
+	CallsPerMinute int32 `json:"callsPerMinute,omitempty"`
+	// CallsPerDay is a rolling 24-hour quota.
+	// +kubebuilder:validation:Minimum=0
+	CallsPerDay int32 `json:"callsPerDay,omitempty"`
 }
 
-// MCPAccessGrantStatus captures observed grant state.
+// MCPAccessGrantStatus captures observed grant state. Phase is Active once the
+// grant is in the server's rendered gateway policy, Pending before that, and
+// Disabled while spec.disabled is set.
 // +kubebuilder:object:generate=true
 type MCPAccessGrantStatus struct {
 	Phase      string             `json:"phase,omitempty"`
 	Message    string             `json:"message,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// PolicyRevision is the gateway policy revision that includes this grant.
+	PolicyRevision string `json:"policyRevision,omitempty"`
+	// ObservedGeneration is the generation the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -55,6 +121,7 @@ type MCPAccessGrantStatus struct {
 // +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".spec.subject.teamID"
 // +kubebuilder:printcolumn:name="Trust",type="string",JSONPath=".spec.maxTrust"
 // +kubebuilder:printcolumn:name="Disabled",type="boolean",JSONPath=".spec.disabled"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcpaccessgrant,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcpaccessgrants,verbs=create;update,versions=v1alpha1,name=vmcpaccessgrant.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
 
@@ -88,12 +155,22 @@ type MCPAgentSessionSpec struct {
 	PolicyVersion          string          `json:"policyVersion,omitempty"`
 }
 
-// MCPAgentSessionStatus captures observed session state.
+// MCPAgentSessionStatus captures observed session state. Phase is Active once
+// the session is in the server's rendered gateway policy, Pending before that,
+// and Revoked or Expired when the gateway no longer accepts it.
 // +kubebuilder:object:generate=true
 type MCPAgentSessionStatus struct {
 	Phase      string             `json:"phase,omitempty"`
 	Message    string             `json:"message,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// PolicyRevision is the gateway policy revision that includes this session.
+	PolicyRevision string `json:"policyRevision,omitempty"`
+	// ObservedGeneration is the generation the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastActiveAt is when a gateway of the server last recorded a governed
+	// request for the session, to within a minute. Every gateway replica
+	// reads it to enforce the server's session.idleTimeout.
+	LastActiveAt *metav1.Time `json:"lastActiveAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -105,6 +182,7 @@ type MCPAgentSessionStatus struct {
 // +kubebuilder:printcolumn:name="Trust",type="string",JSONPath=".spec.consentedTrust"
 // +kubebuilder:printcolumn:name="Revoked",type="boolean",JSONPath=".spec.revoked"
 // +kubebuilder:printcolumn:name="Expires",type="string",JSONPath=".spec.expiresAt"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcpagentsession,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcpagentsessions,verbs=create;update,versions=v1alpha1,name=vmcpagentsession.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
 
@@ -0,0 +1,118 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ApprovalKeyLabel carries a digest of the server, subject, session, tool, and
+// arguments of the call an MCPToolApproval was requested for. Gateways find
+// the approval for a retried call by it.
+const ApprovalKeyLabel = "mcpruntime.org/approval-key"
+
+// ApprovalServerLabel carries the name of the server an MCPToolApproval was
+// requested on, for listing one server's approvals.
+const ApprovalServerLabel = "mcpruntime.org/server"
+
+// ApprovalRequestedByAnnotation carries the username that created an
+// MCPToolApproval. The admission webhook only admits the creator's own
+// username, and the operator only honors approvals that name the operator.
+const ApprovalRequestedByAnnotation = "mcpruntime.org/requested-by"
+
+// +kubebuilder:validation:Enum=approved;denied
+type ApprovalDecision string
+
+const (
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+	ApprovalDecisionDenied   ApprovalDecision = "denied"
+)
+
+// Phases reported on MCPToolApproval status.
+const (
+	ApprovalPhasePending  = "Pending"
+	ApprovalPhaseApproved = "Approved"
+	ApprovalPhaseDenied   = "Denied"
+	ApprovalPhaseExpired  = "Expired"
+)
+
+// MCPToolApprovalSpec is a held tool call and the decision on it. The gateway
+// fills in everything but the decision fields, which approvers set.
+// +kubebuilder:object:generate=true
+type MCPToolApprovalSpec struct {
+	ServerRef ServerReference `json:"serverRef"`
+	Subject   SubjectRef      `json:"subject"`
+	// SessionID is the agent session the call was made in, if any.
+	SessionID string `json:"sessionID,omitempty"`
+	// Tool is the name of the called tool.
+	Tool string `json:"tool"`
+	// Arguments is the call's params.arguments JSON as the caller sent it,
+	// truncated to 4 KiB for display.
+	Arguments string `json:"arguments,omitempty"`
+	// ArgumentsDigest is the SHA-256 of the call's full params.arguments
+	// JSON as the caller sent it. An approval only releases a call with the
+	// same arguments.
+	ArgumentsDigest string `json:"argumentsDigest,omitempty"`
+	// RiskLevel is the tool's risk level when the call was held.
+	RiskLevel ToolRiskLevel `json:"riskLevel,omitempty"`
+	// MatchedGrant is the grant, as <namespace>/<name>, that allowed the call
+	// subject to approval.
+	MatchedGrant string `json:"matchedGrant,omitempty"`
+	// ExpiresAt is when a pending request is denied.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Decision is set by an approver. Once set it cannot change.
+	Decision ApprovalDecision `json:"decision,omitempty"`
+	// DecidedBy identifies the approver. The admission webhook requires the
+	// username recording the decision, unless that user is a configured
+	// delegate that authenticates approvers itself.
+	DecidedBy string `json:"decidedBy,omitempty"`
+	// DecidedAt is when the decision was made.
+	DecidedAt *metav1.Time `json:"decidedAt,omitempty"`
+	// Comment is the approver's note, shown to the caller on a denial.
+	Comment string `json:"comment,omitempty"`
+}
+
+// MCPToolApprovalStatus captures observed approval state. Phase is Pending
+// until an approver decides or ExpiresAt passes, then Approved, Denied, or
+// Expired.
+// +kubebuilder:object:generate=true
+type MCPToolApprovalStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	// ConsumedAt is when a gateway acted on the decision: released the
+	// approved call or returned the denial. A consumed approval is not
+	// matched again, so each approval releases a single call.
+	ConsumedAt *metav1.Time `json:"consumedAt,omitempty"`
+	// ObservedGeneration is the generation the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mcpapproval
+// +kubebuilder:printcolumn:name="Server",type="string",JSONPath=".spec.serverRef.name"
+// +kubebuilder:printcolumn:name="Tool",type="string",JSONPath=".spec.tool"
+// +kubebuilder:printcolumn:name="Human",type="string",JSONPath=".spec.subject.humanID"
+// +kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".spec.subject.agentID"
+// +kubebuilder:printcolumn:name="Risk",type="string",JSONPath=".spec.riskLevel"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Decided By",type="string",JSONPath=".spec.decidedBy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcptoolapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcptoolapprovals,verbs=create;update,versions=v1alpha1,name=vmcptoolapproval.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
+
+// MCPToolApproval is a request for a human decision on a tool call the
+// gateway held under a requires_approval rule or the server's approval risk
+// level.
+type MCPToolApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPToolApprovalSpec   `json:"spec,omitempty"`
+	Status MCPToolApprovalStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MCPToolApprovalList contains a list of MCPToolApproval.
+type MCPToolApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPToolApproval `json:"items"`
+}
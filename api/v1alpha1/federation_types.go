@@ -0,0 +1,85 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// FederationNameSeparator joins a member's prefix and an entry name in the
+// federated tools, prompts, and resources lists, e.g. search__query.
+// Kubernetes names cannot contain it, so the first occurrence always ends
+// the prefix.
+const FederationNameSeparator = "__"
+
+// MCPFederationSpec defines the members and route of a federation endpoint.
+// +kubebuilder:object:generate=true
+type MCPFederationSpec struct {
+	// Selector selects the member MCPServers in the federation's namespace.
+	// An empty selector selects every server in the namespace.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// IngressHost is the host of the federation route. Defaults to the
+	// operator's default ingress host.
+	IngressHost string `json:"ingressHost,omitempty"`
+
+	// IngressPath is the path of the federated MCP endpoint. Defaults to
+	// /federations/<name>/mcp.
+	IngressPath string `json:"ingressPath,omitempty"`
+
+	// IngressClass is the ingress class of the federation route. Defaults to
+	// traefik.
+	IngressClass string `json:"ingressClass,omitempty"`
+
+	// Replicas is the number of federation gateway replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// MCPFederationMember is one selected server as reported on status.
+// +kubebuilder:object:generate=true
+type MCPFederationMember struct {
+	// Name is the MCPServer name.
+	Name string `json:"name"`
+	// Prefix is prepended, with FederationNameSeparator, to the member's tool,
+	// prompt, and resource names.
+	Prefix string `json:"prefix,omitempty"`
+	// Excluded explains why a selected server is not federated, e.g. because
+	// its gateway is disabled. Empty for federated members.
+	Excluded string `json:"excluded,omitempty"`
+}
+
+// MCPFederationStatus captures the observed members and endpoint.
+// +kubebuilder:object:generate=true
+type MCPFederationStatus struct {
+	// Members are the selected servers, sorted by name.
+	Members []MCPFederationMember `json:"members,omitempty"`
+	// URL is the federated MCP endpoint.
+	URL        string             `json:"url,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the generation the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mcpfed
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='DeploymentReady')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcpfederation,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcpfederations,verbs=create;update,versions=v1alpha1,name=vmcpfederation.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
+
+// MCPFederation serves the tools, prompts, and resources of the MCPServers
+// it selects behind one MCP endpoint, applying each member's gateway policy.
+type MCPFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPFederationSpec   `json:"spec,omitempty"`
+	Status MCPFederationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MCPFederationList contains a list of MCPFederation.
+type MCPFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPFederation `json:"items"`
+}
@@ -18,6 +18,8 @@ const (
 	MCPAccessGrantResource = "mcpaccessgrants"
 	// MCPAgentSessionResource is the plural resource name for MCPAgentSession objects.
 	MCPAgentSessionResource = "mcpagentsessions"
+	// MCPToolApprovalResource is the plural resource name for MCPToolApproval objects.
+	MCPToolApprovalResource = "mcptoolapprovals"
 )
 
 var (
@@ -37,6 +39,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&MCPServer{}, &MCPServerList{},
 		&MCPAccessGrant{}, &MCPAccessGrantList{},
 		&MCPAgentSession{}, &MCPAgentSessionList{},
+		&MCPServerClass{}, &MCPServerClassList{},
+		&MCPPolicyBaseline{}, &MCPPolicyBaselineList{},
+		&MCPFederation{}, &MCPFederationList{},
+		&MCPToolApproval{}, &MCPToolApprovalList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
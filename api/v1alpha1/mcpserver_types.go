@@ -14,6 +14,14 @@ const (
 	AuthModeMTLS   AuthMode = "mtls"
 )
 
+// +kubebuilder:validation:Enum=ingress;gateway-api
+type RoutingMode string
+
+const (
+	RoutingModeIngress    RoutingMode = "ingress"
+	RoutingModeGatewayAPI RoutingMode = "gateway-api"
+)
+
 // +kubebuilder:validation:Enum=allow-list;observe
 type PolicyMode string
 
@@ -22,12 +30,15 @@ const (
 	PolicyModeObserve   PolicyMode = "observe"
 )
 
-// +kubebuilder:validation:Enum=allow;deny
+// +kubebuilder:validation:Enum=allow;deny;requires_approval
 type PolicyDecision string
 
 const (
 	PolicyDecisionAllow PolicyDecision = "allow"
 	PolicyDecisionDeny  PolicyDecision = "deny"
+	// PolicyDecisionRequiresApproval allows a tool call only once a human
+	// approves it. It is only valid on tool rules.
+	PolicyDecisionRequiresApproval PolicyDecision = "requires_approval"
 )
 
 // +kubebuilder:validation:Enum=low;medium;high
@@ -66,6 +77,32 @@ const (
 	RolloutStrategyCanary        RolloutStrategy = "Canary"
 )
 
+// +kubebuilder:validation:Enum=tcp;http;exec;mcp
+type ProbeType string
+
+const (
+	ProbeTypeTCP  ProbeType = "tcp"
+	ProbeTypeHTTP ProbeType = "http"
+	ProbeTypeExec ProbeType = "exec"
+	ProbeTypeMCP  ProbeType = "mcp"
+)
+
+// +kubebuilder:validation:Enum=http;stdio
+type ServerTransport string
+
+const (
+	ServerTransportHTTP  ServerTransport = "http"
+	ServerTransportStdio ServerTransport = "stdio"
+)
+
+// +kubebuilder:validation:Enum=per-session;shared
+type StdioSessionMode string
+
+const (
+	StdioSessionModePerSession StdioSessionMode = "per-session"
+	StdioSessionModeShared     StdioSessionMode = "shared"
+)
+
 // MCPServerSpec defines the desired state of MCPServer.
 // +kubebuilder:object:generate=true
 type MCPServerSpec struct {
@@ -73,6 +110,10 @@ type MCPServerSpec struct {
 	// The operator renders it into gateway policy and analytics events.
 	TeamID string `json:"teamID,omitempty"`
 
+	// ClassName selects the MCPServerClass whose defaults and constraints
+	// apply. Empty selects the class annotated as the default, if any.
+	ClassName string `json:"className,omitempty"`
+
 	// Description is a human-readable summary of what the MCP server provides.
 	Description string `json:"description,omitempty"`
 
@@ -91,6 +132,11 @@ type MCPServerSpec struct {
 	// ImagePullSecrets are secrets to use for pulling the image.
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
+	// ImageVerification pins the image to the digest its tag resolves to and
+	// can require a cosign signature before the operator rolls it out.
+	// Servers that declare write or destructive tools are always pinned.
+	ImageVerification *ImageVerificationConfig `json:"imageVerification,omitempty"`
+
 	// Replicas is the number of desired replicas (defaults to 1).
 	Replicas *int32 `json:"replicas,omitempty"`
 
@@ -100,6 +146,14 @@ type MCPServerSpec struct {
 	// ServicePort is the port exposed by the service (defaults to 80).
 	ServicePort int32 `json:"servicePort,omitempty"`
 
+	// Transport is how the server container speaks MCP. http (the default)
+	// serves Streamable HTTP on Port. stdio runs stdio.command behind the
+	// operator's stdio bridge, which serves Streamable HTTP on Port.
+	Transport ServerTransport `json:"transport,omitempty"`
+
+	// Stdio is the server command for transport stdio.
+	Stdio *StdioConfig `json:"stdio,omitempty"`
+
 	// IngressPath is the path for the ingress route (defaults to /{name}/mcp).
 	IngressPath string `json:"ingressPath,omitempty"`
 
@@ -116,9 +170,34 @@ type MCPServerSpec struct {
 	// IngressAnnotations are additional annotations for the ingress controller.
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects how the server is exposed: through an Ingress (and
+	// Traefik resources for mtls) or a Gateway API HTTPRoute. Unset uses the
+	// operator's MCP_ROUTING_MODE.
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
 	// Resources defines resource limits and requests.
 	Resources ResourceRequirements `json:"resources,omitempty"`
 
+	// Volumes are pod volumes the server container can mount through
+	// VolumeMounts.
+	Volumes []Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts mount Volumes into the server container.
+	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
+
+	// InitContainers run to completion, in order, before the server and
+	// gateway containers start, e.g. to fetch secrets into an emptyDir.
+	InitContainers []Container `json:"initContainers,omitempty"`
+
+	// ExtraContainers run next to the server container in every pod, e.g. a
+	// database proxy or a log shipper.
+	ExtraContainers []Container `json:"extraContainers,omitempty"`
+
+	// Probes overrides the server container's liveness, readiness, and
+	// startup probes. Probes left unset keep the defaults: TCP checks on Port
+	// and no startup probe.
+	Probes *ProbesConfig `json:"probes,omitempty"`
+
 	// EnvVars are literal environment variables to pass to the container.
 	EnvVars []EnvVar `json:"envVars,omitempty"`
 
@@ -146,6 +225,11 @@ type MCPServerSpec struct {
 	// Session configures server-side agent session behavior.
 	Session *SessionConfig `json:"session,omitempty"`
 
+	// Approval holds tool calls at or above a risk level for a human
+	// decision. Tool rules with decision requires_approval are held
+	// regardless of it.
+	Approval *ApprovalConfig `json:"approval,omitempty"`
+
 	// Gateway configures an optional MCP proxy sidecar in front of the server container.
 	Gateway *GatewayConfig `json:"gateway,omitempty"`
 
@@ -157,6 +241,21 @@ type MCPServerSpec struct {
 
 	// Rollout configures deployment rollout behavior for this server.
 	Rollout *RolloutConfig `json:"rollout,omitempty"`
+
+	// Autoscaling hands the stable Deployment's replica count to a
+	// HorizontalPodAutoscaler. Replicas is ignored while it is set.
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Scheduling places the stable and canary pods on nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// DisruptionBudget has the operator manage a PodDisruptionBudget for the
+	// stable pods.
+	DisruptionBudget *DisruptionBudgetConfig `json:"disruptionBudget,omitempty"`
+
+	// Network restricts the pods' network traffic with an operator-managed
+	// NetworkPolicy.
+	Network *NetworkConfig `json:"network,omitempty"`
 }
 
 // ResourceRequirements defines resource limits and requests.
@@ -173,6 +272,297 @@ type ResourceList struct {
 	Memory string `json:"memory,omitempty"`
 }
 
+// Volume is a pod volume for the server container. Exactly one source must be
+// set.
+// +kubebuilder:object:generate=true
+type Volume struct {
+	// Name identifies the volume in VolumeMounts. It must be a DNS label.
+	Name string `json:"name"`
+
+	// ConfigMap mounts the keys of a ConfigMap as files.
+	ConfigMap *ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// Secret mounts the keys of a Secret as files.
+	Secret *SecretVolumeSource `json:"secret,omitempty"`
+
+	// EmptyDir is scratch space that lives as long as the pod.
+	EmptyDir *EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// PersistentVolumeClaim mounts an existing claim or one the operator
+	// creates from a template.
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// ConfigMapVolumeSource selects a ConfigMap in the server's namespace.
+// +kubebuilder:object:generate=true
+type ConfigMapVolumeSource struct {
+	// Name is the ConfigMap's name.
+	Name string `json:"name"`
+
+	// Items mounts only the listed keys. All keys are mounted when empty.
+	Items []KeyToPath `json:"items,omitempty"`
+
+	// Optional lets the pod start when the ConfigMap does not exist.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// SecretVolumeSource selects a Secret in the server's namespace.
+// +kubebuilder:object:generate=true
+type SecretVolumeSource struct {
+	// SecretName is the Secret's name.
+	SecretName string `json:"secretName"`
+
+	// Items mounts only the listed keys. All keys are mounted when empty.
+	Items []KeyToPath `json:"items,omitempty"`
+
+	// Optional lets the pod start when the Secret does not exist.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// KeyToPath maps a ConfigMap or Secret key to a file in the volume.
+// +kubebuilder:object:generate=true
+type KeyToPath struct {
+	Key string `json:"key"`
+
+	// Path is the file's path relative to the mount. It must not contain "..".
+	Path string `json:"path"`
+}
+
+// EmptyDirVolumeSource configures pod scratch space.
+// +kubebuilder:object:generate=true
+type EmptyDirVolumeSource struct {
+	// Medium is "" for node disk or Memory for tmpfs.
+	// +kubebuilder:validation:Enum="";Memory
+	Medium string `json:"medium,omitempty"`
+
+	// SizeLimit caps the volume, such as 1Gi.
+	SizeLimit string `json:"sizeLimit,omitempty"`
+}
+
+// PersistentVolumeClaimVolumeSource mounts persistent storage. Set exactly one
+// of ClaimName and Template.
+// +kubebuilder:object:generate=true
+type PersistentVolumeClaimVolumeSource struct {
+	// ClaimName mounts an existing claim in the server's namespace.
+	ClaimName string `json:"claimName,omitempty"`
+
+	// Template has the operator create a claim named <server>-<volume>.
+	Template *PersistentVolumeClaimTemplate `json:"template,omitempty"`
+}
+
+// PersistentVolumeClaimTemplate describes a claim the operator creates and
+// owns. The claim is deleted with the MCPServer unless Retain is set. Only
+// the requested size can change after the claim is created.
+// +kubebuilder:object:generate=true
+type PersistentVolumeClaimTemplate struct {
+	// Size is the requested storage, such as 10Gi.
+	Size string `json:"size"`
+
+	// StorageClassName selects the storage class. The cluster default is
+	// used when unset.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes defaults to ReadWriteOnce.
+	AccessModes []string `json:"accessModes,omitempty"`
+
+	// Retain keeps the claim when the MCPServer is deleted.
+	Retain bool `json:"retain,omitempty"`
+}
+
+// VolumeMount mounts a Volume into the server container.
+// +kubebuilder:object:generate=true
+type VolumeMount struct {
+	// Name is the Volume's name.
+	Name string `json:"name"`
+
+	// MountPath is the absolute path in the container. It must not be under
+	// or above GatewayMountRoot.
+	MountPath string `json:"mountPath"`
+
+	// SubPath mounts a single path inside the volume.
+	SubPath string `json:"subPath,omitempty"`
+
+	// ReadOnly mounts the volume read-only.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// GatewayMountRoot is the directory under which the operator mounts gateway
+// policy and certificates.
+const GatewayMountRoot = "/var/run/mcp-runtime"
+
+// Volume names the operator adds to MCPServer pods. Spec volumes cannot use
+// them.
+const (
+	GatewayPolicyVolumeName         = "gateway-policy"
+	GatewayTLSVolumeName            = "gateway-mtls"
+	GatewayAnalyticsSpoolVolumeName = "gateway-analytics-spool"
+	GatewayOperatorTokenVolumeName  = "gateway-operator-token"
+	StdioBridgeVolumeName           = "stdio-bridge"
+)
+
+// StdioBridgeContainerName is the init container that installs the stdio
+// bridge into servers with transport stdio.
+const StdioBridgeContainerName = "mcp-stdio-bridge"
+
+// StdioConfig is the command line of a stdio-only MCP server. The bridge runs
+// it in the server container, so the command must exist in spec.image.
+// +kubebuilder:object:generate=true
+type StdioConfig struct {
+	// Command is the executable and its leading arguments, for example
+	// ["npx", "-y", "@modelcontextprotocol/server-filesystem"].
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Args are appended to Command.
+	Args []string `json:"args,omitempty"`
+
+	// SessionMode is per-session (the default), which starts one process per
+	// MCP session, or shared, which multiplexes every session onto one
+	// process.
+	SessionMode StdioSessionMode `json:"sessionMode,omitempty"`
+
+	// MaxSessions caps concurrent sessions per replica in per-session mode.
+	// Zero means no cap.
+	// +kubebuilder:validation:Minimum=0
+	MaxSessions int32 `json:"maxSessions,omitempty"`
+}
+
+// GatewayContainerName is the name of the gateway sidecar container, and
+// GatewayMetricsPort the port it serves metrics on. Init and extra containers
+// cannot use them.
+const (
+	GatewayContainerName = "mcp-gateway"
+	GatewayMetricsPort   = 9103
+)
+
+// Container is an init or extra container in the server pods. It runs with
+// the same restricted security context as the server container.
+// +kubebuilder:object:generate=true
+type Container struct {
+	// Name must be a DNS label, unique among the pod's containers.
+	Name string `json:"name"`
+
+	// Image is the full container image reference.
+	Image string `json:"image"`
+
+	// Command overrides the image entrypoint.
+	Command []string `json:"command,omitempty"`
+
+	// Args are passed to the entrypoint.
+	Args []string `json:"args,omitempty"`
+
+	// EnvVars are literal environment variables.
+	EnvVars []EnvVar `json:"envVars,omitempty"`
+
+	// SecretEnvVars are secret-backed environment variables.
+	SecretEnvVars []SecretEnvVar `json:"secretEnvVars,omitempty"`
+
+	// Resources defines resource limits and requests, with the same defaults
+	// as the server container.
+	Resources ResourceRequirements `json:"resources,omitempty"`
+
+	// VolumeMounts mount spec Volumes into this container.
+	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Ports the container listens on. Only extra containers may set ports.
+	Ports []ContainerPort `json:"ports,omitempty"`
+}
+
+// ContainerPort is a port an extra container listens on. It is reachable only
+// inside the pod unless Expose is set.
+// +kubebuilder:object:generate=true
+type ContainerPort struct {
+	// Name must be an IANA service name (at most 15 characters).
+	Name string `json:"name"`
+
+	// ContainerPort is the port number.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ContainerPort int32 `json:"containerPort"`
+
+	// Protocol is TCP (default) or UDP.
+	// +kubebuilder:validation:Enum=TCP;UDP
+	Protocol string `json:"protocol,omitempty"`
+
+	// Expose adds the port to the server's Service under the same number.
+	Expose bool `json:"expose,omitempty"`
+}
+
+// ProbesConfig configures the server container's probes.
+// +kubebuilder:object:generate=true
+type ProbesConfig struct {
+	// Liveness restarts the container when it fails.
+	Liveness *ProbeConfig `json:"liveness,omitempty"`
+
+	// Readiness removes the pod from the Service while it fails.
+	Readiness *ProbeConfig `json:"readiness,omitempty"`
+
+	// Startup holds off liveness and readiness until it first succeeds. Use
+	// it for servers that take long to warm up.
+	Startup *ProbeConfig `json:"startup,omitempty"`
+}
+
+// ProbeConfig describes one probe. Timing fields left unset keep the
+// operator's defaults for that probe.
+// +kubebuilder:object:generate=true
+type ProbeConfig struct {
+	// Type selects the check (defaults to tcp). mcp runs an initialize and
+	// ping handshake against the server through the gateway sidecar and
+	// requires the gateway.
+	// +kubebuilder:default=tcp
+	Type ProbeType `json:"type,omitempty"`
+
+	// Path is the request path for http probes (defaults to /) and the
+	// server's MCP endpoint for mcp probes (defaults to /mcp).
+	Path string `json:"path,omitempty"`
+
+	// Port is the port for tcp and http probes (defaults to Port).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// Command is run in the server container for exec probes.
+	Command []string `json:"command,omitempty"`
+
+	// InitialDelaySeconds is the delay before the first check.
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is the interval between checks.
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is how long one check may take.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures that fail the
+	// probe.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// ImageVerificationConfig configures digest pinning and signature checks for
+// the server image.
+// +kubebuilder:object:generate=true
+type ImageVerificationConfig struct {
+	// PinDigest resolves the image tag to a manifest digest in the registry
+	// and deploys image@digest. The tag is resolved again when the spec
+	// changes.
+	PinDigest bool `json:"pinDigest,omitempty"`
+
+	// RequireSignature refuses to roll out a digest without a cosign
+	// signature, stored in the same repository, from one of the keys in the
+	// operator's image verification key Secret. It implies PinDigest.
+	RequireSignature bool `json:"requireSignature,omitempty"`
+
+	// PublicKeysSecret is no longer supported and must be empty. A Secret in
+	// the server's namespace can be written by the team the signature
+	// requirement constrains, so trusted keys only come from the operator's
+	// MCP_IMAGE_VERIFICATION_KEYS_SECRET.
+	PublicKeysSecret string `json:"publicKeysSecret,omitempty"`
+}
+
 // EnvVar represents a literal environment variable.
 // +kubebuilder:object:generate=true
 type EnvVar struct {
@@ -204,6 +594,13 @@ type InventoryItem struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
+	// URI is a glob matched against resource URIs ("*" matches any run of
+	// characters). It only applies to mcpResources entries, which the gateway
+	// ignores without it.
+	URI string `json:"uri,omitempty"`
+	// RequiredTrust is the minimum trust needed to fetch the prompt or read
+	// the resource. It does not apply to tasks.
+	RequiredTrust TrustLevel `json:"requiredTrust,omitempty"`
 }
 
 // AuthConfig configures how identities are extracted at the gateway.
@@ -229,6 +626,11 @@ type PolicyConfig struct {
 	DefaultDecision PolicyDecision `json:"defaultDecision,omitempty"`
 	EnforceOn       string         `json:"enforceOn,omitempty"`
 	PolicyVersion   string         `json:"policyVersion,omitempty"`
+
+	// FilterListResponses rewrites tools/list, prompts/list, and
+	// resources/list responses so each caller only sees entries its grants
+	// and session would allow it to use.
+	FilterListResponses bool `json:"filterListResponses,omitempty"`
 }
 
 // SessionConfig configures server-side agent session behavior.
@@ -242,6 +644,25 @@ type SessionConfig struct {
 	UpstreamTokenHeader string `json:"upstreamTokenHeader,omitempty"`
 }
 
+// ApprovalConfig configures human approval of tool calls at the gateway.
+// +kubebuilder:object:generate=true
+type ApprovalConfig struct {
+	// RiskLevel holds every tool call whose tool is at or above this risk
+	// level for approval. Empty holds only calls allowed by tool rules with
+	// decision requires_approval.
+	RiskLevel ToolRiskLevel `json:"riskLevel,omitempty"`
+
+	// Timeout is how long an approval request stays pending before it is
+	// denied, such as 15m. Defaults to 15m.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Wait is how long the gateway holds a tools/call open for the decision,
+	// such as 30s. When it runs out, or when unset, the call is answered with
+	// a pending JSON-RPC error carrying the approval ID, and the client
+	// retries the same call once it is approved.
+	Wait string `json:"wait,omitempty"`
+}
+
 // GatewayConfig configures an optional MCP proxy sidecar for a server.
 // +kubebuilder:object:generate=true
 type GatewayConfig struct {
@@ -284,6 +705,46 @@ type AnalyticsConfig struct {
 
 	// APIKeySecretRef points to a secret key containing the analytics API key.
 	APIKeySecretRef *SecretKeyRef `json:"apiKeySecretRef,omitempty"`
+
+	// Spool writes analytics and audit events to an on-disk spool before
+	// sending them, so events survive an ingest outage and gateway restarts
+	// instead of being dropped when the in-memory queue fills.
+	Spool *AnalyticsSpoolConfig `json:"spool,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=always;interval;never
+type SpoolFsyncPolicy string
+
+const (
+	// SpoolFsyncAlways flushes every event to disk before the request that
+	// produced it completes.
+	SpoolFsyncAlways SpoolFsyncPolicy = "always"
+	// SpoolFsyncInterval flushes once a second; a node crash loses at most
+	// that window.
+	SpoolFsyncInterval SpoolFsyncPolicy = "interval"
+	// SpoolFsyncNever leaves flushing to the kernel; events survive a gateway
+	// crash but not a node crash.
+	SpoolFsyncNever SpoolFsyncPolicy = "never"
+)
+
+// AnalyticsSpoolConfig configures the gateway's on-disk analytics spool.
+// Spooled events are delivered in order with retries and replayed after a
+// restart.
+// +kubebuilder:object:generate=true
+type AnalyticsSpoolConfig struct {
+	// Volume names an emptyDir or persistentVolumeClaim entry of spec.volumes
+	// to hold the spool. When empty the operator adds an emptyDir, which
+	// survives gateway restarts but not pod deletion; use a claim to keep the
+	// backlog across rescheduling.
+	Volume string `json:"volume,omitempty"`
+
+	// MaxSize caps the spool on disk (defaults to 256Mi). Events arriving
+	// while it is full are dropped and counted.
+	MaxSize string `json:"maxSize,omitempty"`
+
+	// Fsync sets when spooled events are flushed to disk (defaults to
+	// interval).
+	Fsync SpoolFsyncPolicy `json:"fsync,omitempty"`
 }
 
 // RolloutConfig configures deployment rollout behavior.
@@ -293,8 +754,367 @@ type RolloutConfig struct {
 	MaxUnavailable string          `json:"maxUnavailable,omitempty"`
 	MaxSurge       string          `json:"maxSurge,omitempty"`
 	CanaryReplicas *int32          `json:"canaryReplicas,omitempty"`
+
+	// Analysis enables automated canary analysis for the Canary strategy. The
+	// stable Deployment keeps the last promoted image while the canary runs
+	// spec.image; the canary is promoted once every step passes and rolled
+	// back on the first failing step.
+	Analysis *CanaryAnalysis `json:"analysis,omitempty"`
+}
+
+// CanaryAnalysis configures the steps a canary must pass before promotion.
+// +kubebuilder:object:generate=true
+type CanaryAnalysis struct {
+	// Steps run in order. Each step waits for its duration with the canary
+	// ready, then compares gateway metrics of the canary and stable tracks
+	// over that duration.
+	// +kubebuilder:validation:MinItems=1
+	Steps []CanaryAnalysisStep `json:"steps"`
+}
+
+// CanaryAnalysisStep is one analysis interval and its thresholds. Thresholds
+// left empty are not checked.
+// +kubebuilder:object:generate=true
+type CanaryAnalysisStep struct {
+	// Duration is how long the step observes the canary, such as 5m.
+	Duration string `json:"duration"`
+
+	// MaxErrorRate is the highest fraction of canary requests that may end in
+	// a 5xx response, such as "0.01".
+	MaxErrorRate string `json:"maxErrorRate,omitempty"`
+
+	// MaxP95Latency is the highest p95 canary request duration, such as 500ms.
+	MaxP95Latency string `json:"maxP95Latency,omitempty"`
+
+	// MaxDenialRateDelta is how much higher the canary's policy denial rate
+	// may be than the stable track's, as a fraction such as "0.05".
+	MaxDenialRateDelta string `json:"maxDenialRateDelta,omitempty"`
+}
+
+// RolloutPhase summarizes automated canary analysis.
+type RolloutPhase string
+
+const (
+	// RolloutPhaseStable means no canary image is under analysis.
+	RolloutPhaseStable RolloutPhase = "Stable"
+	// RolloutPhaseProgressing means the canary image is being analysed.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoted means the canary image became the stable image.
+	RolloutPhasePromoted RolloutPhase = "Promoted"
+	// RolloutPhaseRolledBack means the canary image failed analysis.
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+	// RolloutPhaseAborted means a human aborted the canary.
+	RolloutPhaseAborted RolloutPhase = "Aborted"
+)
+
+// RolloutActionAnnotation requests a manual rollout action on an MCPServer.
+// The operator performs it and removes the annotation.
+const RolloutActionAnnotation = "mcpruntime.org/rollout-action"
+
+// Values accepted in RolloutActionAnnotation.
+const (
+	RolloutActionPromote = "promote"
+	RolloutActionAbort   = "abort"
+)
+
+// AutoscalingConfig configures a HorizontalPodAutoscaler for the stable
+// Deployment. At least one target must be set.
+// +kubebuilder:object:generate=true
+type AutoscalingConfig struct {
+	// MinReplicas is the autoscaler's lower bound (defaults to 1). Use
+	// ScaleToZero to go below one replica.
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the autoscaler's upper bound.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilization is the average CPU utilization to hold, as a
+	// percentage of the containers' CPU requests.
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// TargetMemoryUtilization is the average memory utilization to hold, as a
+	// percentage of the containers' memory requests.
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// TargetInFlightRequests is the average number of requests in flight
+	// through each pod's gateway, such as "10". It is read from the custom
+	// metrics API and requires the gateway.
+	TargetInFlightRequests string `json:"targetInFlightRequests,omitempty"`
+
+	// TargetRequestsPerSecond is the average gateway request rate per pod,
+	// such as "50" or "500m". It is read from the custom metrics API and
+	// requires the gateway.
+	TargetRequestsPerSecond string `json:"targetRequestsPerSecond,omitempty"`
+
+	// ScaleToZero scales the Deployment to zero replicas after an idle period
+	// and back up on the next request.
+	ScaleToZero *ScaleToZeroConfig `json:"scaleToZero,omitempty"`
+}
+
+// ScaleToZeroConfig configures idle scale-down. While the server has no ready
+// replicas its Service routes to an activator that holds requests and asks
+// the operator to scale the Deployment back up.
+// +kubebuilder:object:generate=true
+type ScaleToZeroConfig struct {
+	// IdleAfter is how long the gateway must see no requests before the
+	// Deployment is scaled to zero, such as 30m (defaults to 15m).
+	IdleAfter string `json:"idleAfter,omitempty"`
+
+	// ColdStartTimeout is how long the activator holds a request while the
+	// server starts, such as 90s (defaults to 60s).
+	ColdStartTimeout string `json:"coldStartTimeout,omitempty"`
+}
+
+// SchedulingConfig sets where the server's pods run. Pod affinity terms and
+// topology spread constraints without labels select the server's own pods.
+// +kubebuilder:object:generate=true
+type SchedulingConfig struct {
+	// NodeSelector limits the pods to nodes with these labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the pods run on tainted nodes.
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+
+	// Affinity sets node, pod, and pod anti-affinity rules.
+	Affinity *Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints spread the pods across topology domains.
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName sets the pods' PriorityClass.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// Toleration tolerates a node taint.
+// +kubebuilder:object:generate=true
+type Toleration struct {
+	// Key is the taint key. An empty key with operator Exists matches every
+	// taint.
+	Key string `json:"key,omitempty"`
+
+	// Operator is Equal (the default) or Exists.
+	// +kubebuilder:validation:Enum=Equal;Exists
+	Operator string `json:"operator,omitempty"`
+
+	// Value is the taint value for the Equal operator.
+	Value string `json:"value,omitempty"`
+
+	// Effect matches one taint effect; all effects when empty.
+	// +kubebuilder:validation:Enum="";NoSchedule;PreferNoSchedule;NoExecute
+	Effect string `json:"effect,omitempty"`
+
+	// TolerationSeconds bounds how long a NoExecute taint is tolerated.
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
+
+// Affinity groups the pods' affinity rules.
+// +kubebuilder:object:generate=true
+type Affinity struct {
+	NodeAffinity    *NodeAffinity `json:"nodeAffinity,omitempty"`
+	PodAffinity     *PodAffinity  `json:"podAffinity,omitempty"`
+	PodAntiAffinity *PodAffinity  `json:"podAntiAffinity,omitempty"`
+}
+
+// NodeAffinity selects nodes by label. Required terms are ORed; the pod is
+// only scheduled on a node matching one of them.
+// +kubebuilder:object:generate=true
+type NodeAffinity struct {
+	Required  []NodeSelectorTerm        `json:"required,omitempty"`
+	Preferred []PreferredSchedulingTerm `json:"preferred,omitempty"`
+}
+
+// NodeSelectorTerm matches nodes whose labels satisfy every expression.
+// +kubebuilder:object:generate=true
+type NodeSelectorTerm struct {
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions"`
+}
+
+// PreferredSchedulingTerm weights a node selector term.
+// +kubebuilder:object:generate=true
+type PreferredSchedulingTerm struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight     int32            `json:"weight"`
+	Preference NodeSelectorTerm `json:"preference"`
+}
+
+// PodAffinity co-locates pods with, or (as anti-affinity) keeps them away
+// from, pods matching its terms.
+// +kubebuilder:object:generate=true
+type PodAffinity struct {
+	Required  []PodAffinityTerm         `json:"required,omitempty"`
+	Preferred []WeightedPodAffinityTerm `json:"preferred,omitempty"`
+}
+
+// PodAffinityTerm matches pods in the same TopologyKey domain.
+// +kubebuilder:object:generate=true
+type PodAffinityTerm struct {
+	// MatchLabels and MatchExpressions select the pods. Without either, the
+	// term selects this server's pods.
+	MatchLabels      map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// TopologyKey is the node label that defines a domain, such as
+	// kubernetes.io/hostname.
+	TopologyKey string `json:"topologyKey"`
+
+	// Namespaces to match pods in. Defaults to the server's namespace.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// WeightedPodAffinityTerm weights a pod affinity term.
+// +kubebuilder:object:generate=true
+type WeightedPodAffinityTerm struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight          int32           `json:"weight"`
+	PodAffinityTerm PodAffinityTerm `json:"podAffinityTerm"`
+}
+
+// LabelSelectorRequirement matches a label against values.
+// +kubebuilder:object:generate=true
+type LabelSelectorRequirement struct {
+	Key string `json:"key"`
+
+	// Operator is In, NotIn, Exists, or DoesNotExist. Node selector terms
+	// also accept Gt and Lt.
+	// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist;Gt;Lt
+	Operator string `json:"operator"`
+
+	Values []string `json:"values,omitempty"`
+}
+
+// TopologySpreadConstraint spreads pods across the domains of TopologyKey.
+// +kubebuilder:object:generate=true
+type TopologySpreadConstraint struct {
+	// MaxSkew is the largest allowed difference in pod count between domains.
+	// +kubebuilder:validation:Minimum=1
+	MaxSkew int32 `json:"maxSkew"`
+
+	// TopologyKey is the node label that defines a domain, such as
+	// topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey"`
+
+	// WhenUnsatisfiable is DoNotSchedule (the default) or ScheduleAnyway.
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	WhenUnsatisfiable string `json:"whenUnsatisfiable,omitempty"`
+
+	// MatchLabels selects the pods to count. Without it, the server's own
+	// pods are counted.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// DisruptionBudgetConfig configures the operator-managed PodDisruptionBudget.
+// The budget only exists while the server runs more than one replica, since
+// with one replica it would either block node drains or protect nothing.
+// +kubebuilder:object:generate=true
+type DisruptionBudgetConfig struct {
+	// Enabled creates the PodDisruptionBudget.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is how many stable pods an eviction may take down at
+	// once, as a count or a percentage such as 25% (defaults to 1).
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkConfig configures the server pods' NetworkPolicy.
+// +kubebuilder:object:generate=true
+type NetworkConfig struct {
+	// Egress limits where the pods may connect.
+	Egress *EgressConfig `json:"egress,omitempty"`
+}
+
+// EgressConfig is materialized as the <name>-egress NetworkPolicy. Once it
+// has a rule or DefaultDeny is set, the pods may only reach the destinations
+// its rules allow, plus cluster DNS and the in-cluster analytics and OTLP
+// endpoints the gateway sidecar reports to. NetworkPolicy matches addresses,
+// not host names: external services such as an OAuth issuer need a CIDR
+// rule.
+// +kubebuilder:object:generate=true
+type EgressConfig struct {
+	// DefaultDeny blocks all other egress even when Rules is empty.
+	DefaultDeny bool `json:"defaultDeny,omitempty"`
+
+	// Rules allow egress. A connection is allowed when it matches any rule.
+	Rules []EgressRule `json:"rules,omitempty"`
+}
+
+// EgressRule allows connections to any of To on any of Ports. Without To it
+// allows every destination on Ports; without Ports, every port of To.
+// +kubebuilder:object:generate=true
+type EgressRule struct {
+	To    []EgressPeer  `json:"to,omitempty"`
+	Ports []NetworkPort `json:"ports,omitempty"`
+}
+
+// EgressPeer is an IP block or a set of pods. CIDR cannot be combined with
+// the selectors. A PodSelector alone selects pods in the server's namespace;
+// a NamespaceSelector alone selects every pod in the matching namespaces.
+// +kubebuilder:object:generate=true
+type EgressPeer struct {
+	// CIDR is an IP block such as 10.20.0.0/16 or 203.0.113.7/32.
+	CIDR string `json:"cidr,omitempty"`
+
+	// Except excludes blocks inside CIDR.
+	Except []string `json:"except,omitempty"`
+
+	// NamespaceSelector selects namespaces by label, such as
+	// kubernetes.io/metadata.name: databases.
+	NamespaceSelector map[string]string `json:"namespaceSelector,omitempty"`
+
+	// PodSelector selects pods by label.
+	PodSelector map[string]string `json:"podSelector,omitempty"`
 }
 
+// NetworkPort is a port, or a range of ports from Port to EndPort.
+// +kubebuilder:object:generate=true
+type NetworkPort struct {
+	// Protocol is TCP (the default), UDP, or SCTP.
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	Protocol string `json:"protocol,omitempty"`
+
+	// Port is the destination port. Zero matches every port.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// EndPort ends an inclusive range starting at Port.
+	// +kubebuilder:validation:Maximum=65535
+	EndPort int32 `json:"endPort,omitempty"`
+}
+
+// RoutingConfig selects the routing mode for one server.
+// +kubebuilder:object:generate=true
+type RoutingConfig struct {
+	// Mode overrides the operator's default routing mode.
+	Mode RoutingMode `json:"mode,omitempty"`
+
+	// ParentRef is the Gateway the HTTPRoute attaches to in gateway-api mode
+	// (defaults to the operator's MCP_GATEWAY_API_PARENT).
+	ParentRef *GatewayParentRef `json:"parentRef,omitempty"`
+}
+
+// GatewayParentRef references a Gateway API Gateway, and optionally one of
+// its listeners.
+// +kubebuilder:object:generate=true
+type GatewayParentRef struct {
+	// Name is the Gateway name.
+	Name string `json:"name"`
+
+	// Namespace is the Gateway namespace (defaults to the server namespace).
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the listener to attach to (defaults to every listener
+	// that allows the route).
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ActivationRequestedAnnotation records when an activator last asked the
+// operator to scale a server up from zero, as an RFC 3339 time.
+const ActivationRequestedAnnotation = "mcpruntime.org/activation-requested-at"
+
 // SecretKeyRef points to a single key in a Kubernetes Secret.
 // +kubebuilder:object:generate=true
 type SecretKeyRef struct {
@@ -331,6 +1151,136 @@ type MCPServerStatus struct {
 
 	// CanaryReady indicates if the canary deployment, when configured, is ready.
 	CanaryReady bool `json:"canaryReady,omitempty"`
+
+	// Rollout reports automated canary analysis when spec.rollout.analysis is
+	// set.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Autoscaling reports scale-to-zero state when
+	// spec.autoscaling.scaleToZero is set.
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// Image reports the digest the image resolved to and whether its
+	// signature was verified, when the image is pinned.
+	Image *ImageStatus `json:"image,omitempty"`
+
+	// Inventory is the tool, prompt, and resource inventory the running server
+	// advertised when the operator last probed it, and how its tools differ
+	// from spec.tools.
+	Inventory *InventoryStatus `json:"inventory,omitempty"`
+}
+
+// ImageStatus is the pinned image of a server.
+// +kubebuilder:object:generate=true
+type ImageStatus struct {
+	// Reference is the image reference the digest was resolved from, after
+	// imageTag and registry overrides are applied.
+	Reference string `json:"reference,omitempty"`
+
+	// Digest is the manifest digest the server runs, such as sha256:...
+	Digest string `json:"digest,omitempty"`
+
+	// Verified is true when a signature from a trusted key covers Digest.
+	Verified bool `json:"verified,omitempty"`
+
+	// ResolvedAt is when the operator resolved Reference to Digest.
+	ResolvedAt *metav1.Time `json:"resolvedAt,omitempty"`
+
+	// ObservedGeneration is the spec generation Digest was resolved for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// InventoryStatus is the live MCP inventory of a Ready server.
+// +kubebuilder:object:generate=true
+type InventoryStatus struct {
+	// ProbedAt is when the operator last listed the server's inventory.
+	ProbedAt *metav1.Time `json:"probedAt,omitempty"`
+
+	// ProtocolVersion is the MCP protocol version the server negotiated.
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+
+	// Tools are the tools from tools/list, sorted by name.
+	Tools []DiscoveredTool `json:"tools,omitempty"`
+
+	// Prompts are the prompt names from prompts/list.
+	Prompts []string `json:"prompts,omitempty"`
+
+	// Resources are the resource URIs from resources/list.
+	Resources []string `json:"resources,omitempty"`
+
+	// UndeclaredTools are served but missing from spec.tools; the gateway
+	// denies calls to them as tool_side_effect_unknown.
+	UndeclaredTools []string `json:"undeclaredTools,omitempty"`
+
+	// MissingTools are declared in spec.tools but not served.
+	MissingTools []string `json:"missingTools,omitempty"`
+
+	// SuggestedPatch is a JSON patch (RFC 6902) that reconciles spec.tools
+	// with the live inventory, for `mcp-runtime server patch --type json`.
+	// Undeclared tools are added as destructive until someone reviews them.
+	SuggestedPatch string `json:"suggestedPatch,omitempty"`
+}
+
+// DiscoveredTool is one tool a running server advertised.
+// +kubebuilder:object:generate=true
+type DiscoveredTool struct {
+	Name string `json:"name"`
+
+	// InputSchemaHash is "sha256:" and the hex digest of the tool's input
+	// schema as canonical JSON, so schema changes show up as status changes.
+	InputSchemaHash string `json:"inputSchemaHash,omitempty"`
+}
+
+// AutoscalingStatus is the scale-to-zero state of a server.
+// +kubebuilder:object:generate=true
+type AutoscalingStatus struct {
+	// ScaledToZero is true while the operator holds the Deployment at zero
+	// replicas.
+	ScaledToZero bool `json:"scaledToZero,omitempty"`
+
+	// LastScaledToZeroTime is when the server was last scaled to zero.
+	LastScaledToZeroTime *metav1.Time `json:"lastScaledToZeroTime,omitempty"`
+
+	// LastActivationTime is when the server was last scaled up from zero.
+	LastActivationTime *metav1.Time `json:"lastActivationTime,omitempty"`
+}
+
+// RolloutStatus is the state of automated canary analysis.
+// +kubebuilder:object:generate=true
+type RolloutStatus struct {
+	// Phase is Stable, Progressing, Promoted, RolledBack, or Aborted.
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// StableImage is the image the stable Deployment runs.
+	StableImage string `json:"stableImage,omitempty"`
+
+	// CanaryImage is the image last put under analysis.
+	CanaryImage string `json:"canaryImage,omitempty"`
+
+	// Step is the index of the analysis step in progress, or the number of
+	// steps passed once the rollout finished.
+	Step int32 `json:"step,omitempty"`
+
+	// StepStartedAt is when the current step began observing a ready canary.
+	StepStartedAt *metav1.Time `json:"stepStartedAt,omitempty"`
+
+	// LastAnalysis is the outcome of the most recent metric comparison.
+	LastAnalysis *CanaryAnalysisResult `json:"lastAnalysis,omitempty"`
+
+	// Message explains the phase.
+	Message string `json:"message,omitempty"`
+}
+
+// CanaryAnalysisResult records the metrics one analysis step observed. Values
+// are formatted decimals; empty means no samples.
+// +kubebuilder:object:generate=true
+type CanaryAnalysisResult struct {
+	Step            int32       `json:"step"`
+	Passed          bool        `json:"passed"`
+	ErrorRate       string      `json:"errorRate,omitempty"`
+	P95Latency      string      `json:"p95Latency,omitempty"`
+	DenialRateDelta string      `json:"denialRateDelta,omitempty"`
+	Time            metav1.Time `json:"time"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// BaselineSubject selects which authenticated callers a baseline rule applies to.
+// +kubebuilder:validation:Enum=any;agent-only;human
+type BaselineSubject string
+
+const (
+	// BaselineSubjectAny matches every caller.
+	BaselineSubjectAny BaselineSubject = "any"
+	// BaselineSubjectAgentOnly matches callers with an agent ID and no human ID.
+	BaselineSubjectAgentOnly BaselineSubject = "agent-only"
+	// BaselineSubjectHuman matches callers with a human ID.
+	BaselineSubjectHuman BaselineSubject = "human"
+)
+
+// BaselineEffect is what a matching baseline rule does to a tool call.
+// +kubebuilder:validation:Enum=deny;require-trust
+type BaselineEffect string
+
+const (
+	// BaselineEffectDeny denies the call whatever the grants allow.
+	BaselineEffectDeny BaselineEffect = "deny"
+	// BaselineEffectRequireTrust denies the call unless the caller's
+	// effective trust is at least requiredTrust.
+	BaselineEffectRequireTrust BaselineEffect = "require-trust"
+)
+
+// MCPPolicyBaselineRule matches tool calls by caller and tool metadata. Every
+// selector that is set must match; an empty selector matches anything.
+// +kubebuilder:object:generate=true
+type MCPPolicyBaselineRule struct {
+	// Name identifies the rule in audit events as <baseline>/<name>.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Subject limits the rule to a kind of caller. Defaults to any.
+	Subject BaselineSubject `json:"subject,omitempty"`
+
+	// Tools limits the rule to the named tools.
+	Tools []string `json:"tools,omitempty"`
+
+	// SideEffects limits the rule to tools declaring one of these side effects.
+	SideEffects []ToolSideEffect `json:"sideEffects,omitempty"`
+
+	// ToolLabels limits the rule to tools carrying all of these labels.
+	ToolLabels map[string]string `json:"toolLabels,omitempty"`
+
+	// Effect is deny or require-trust.
+	Effect BaselineEffect `json:"effect"`
+
+	// RequiredTrust is the minimum effective trust for require-trust rules.
+	RequiredTrust TrustLevel `json:"requiredTrust,omitempty"`
+}
+
+// MCPPolicyBaselineSpec defines the rules of a policy baseline.
+// +kubebuilder:object:generate=true
+type MCPPolicyBaselineSpec struct {
+	// Rules are applied to every server's tool calls after its grants allow
+	// them. A matching rule can only turn an allow into a deny.
+	// +kubebuilder:validation:MinItems=1
+	Rules []MCPPolicyBaselineRule `json:"rules"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=mcppb
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcppolicybaseline,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcppolicybaselines,verbs=create;update,versions=v1alpha1,name=vmcppolicybaseline.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
+
+// MCPPolicyBaseline holds cluster-wide policy rules that the operator merges
+// into the rendered gateway policy of every MCPServer.
+type MCPPolicyBaseline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MCPPolicyBaselineSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MCPPolicyBaselineList contains a list of MCPPolicyBaseline.
+type MCPPolicyBaselineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPPolicyBaseline `json:"items"`
+}
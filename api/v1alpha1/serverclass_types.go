@@ -0,0 +1,90 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MCPServerClassDefaultAnnotation marks the class that applies to servers
+// without spec.className, like storageclass.kubernetes.io/is-default-class.
+const MCPServerClassDefaultAnnotation = "mcpruntime.org/is-default-class"
+
+// MCPServerClassSpec defines the defaults and constraints of a server class.
+// +kubebuilder:object:generate=true
+type MCPServerClassSpec struct {
+	// Defaults fill MCPServer spec fields the server leaves unset.
+	Defaults *MCPServerClassDefaults `json:"defaults,omitempty"`
+
+	// Constraints reject servers of the class at admission and stop their
+	// reconcile when violated.
+	Constraints *MCPServerClassConstraints `json:"constraints,omitempty"`
+}
+
+// MCPServerClassDefaults are applied per top-level field: a field the
+// MCPServer sets is kept as is, an unset one is copied from the class.
+// +kubebuilder:object:generate=true
+type MCPServerClassDefaults struct {
+	Gateway           *GatewayConfig           `json:"gateway,omitempty"`
+	Auth              *AuthConfig              `json:"auth,omitempty"`
+	Policy            *PolicyConfig            `json:"policy,omitempty"`
+	Session           *SessionConfig           `json:"session,omitempty"`
+	Analytics         *AnalyticsConfig         `json:"analytics,omitempty"`
+	Resources         *ResourceRequirements    `json:"resources,omitempty"`
+	ImagePullSecrets  []string                 `json:"imagePullSecrets,omitempty"`
+	ImageVerification *ImageVerificationConfig `json:"imageVerification,omitempty"`
+	Scheduling        *SchedulingConfig        `json:"scheduling,omitempty"`
+	Network           *NetworkConfig           `json:"network,omitempty"`
+}
+
+// MCPServerClassConstraints are hard limits on the effective spec of a
+// server, after class and built-in defaults are applied.
+// +kubebuilder:object:generate=true
+type MCPServerClassConstraints struct {
+	// RequireGateway requires gateway.enabled.
+	RequireGateway bool `json:"requireGateway,omitempty"`
+
+	// AllowedAuthModes limits auth.mode. Empty allows any mode.
+	AllowedAuthModes []AuthMode `json:"allowedAuthModes,omitempty"`
+
+	// AllowedPolicyModes limits policy.mode. Empty allows any mode.
+	AllowedPolicyModes []PolicyMode `json:"allowedPolicyModes,omitempty"`
+
+	// MaxCPU caps the server container's CPU requests and limits, e.g. "2".
+	MaxCPU string `json:"maxCPU,omitempty"`
+
+	// MaxMemory caps the server container's memory requests and limits,
+	// e.g. "2Gi".
+	MaxMemory string `json:"maxMemory,omitempty"`
+
+	// MaxReplicas caps replicas and autoscaling.maxReplicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// AllowedRegistries limits the registry host of spec.image (after
+	// registryOverride). Empty allows any registry.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// RequireSignedImages requires imageVerification.requireSignature.
+	RequireSignedImages bool `json:"requireSignedImages,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=mcpsc
+// +kubebuilder:printcolumn:name="Default",type="string",JSONPath=".metadata.annotations.mcpruntime\\.org/is-default-class"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:webhook:path=/validate-mcpruntime-org-v1alpha1-mcpserverclass,mutating=false,failurePolicy=fail,sideEffects=None,groups=mcpruntime.org,resources=mcpserverclasses,verbs=create;update,versions=v1alpha1,name=vmcpserverclass.kb.io,admissionReviewVersions=v1,serviceName=mcp-runtime-operator-webhook-service,serviceNamespace=mcp-runtime,servicePort=443
+
+// MCPServerClass holds cluster-wide defaults and guardrails that MCPServers
+// select with spec.className.
+type MCPServerClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MCPServerClassSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MCPServerClassList contains a list of MCPServerClass.
+type MCPServerClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPServerClass `json:"items"`
+}
@@ -3,22 +3,35 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
-	_ admission.Defaulter[*MCPServer]       = mcpServerWebhook{}
-	_ admission.Validator[*MCPServer]       = mcpServerWebhook{}
-	_ admission.Validator[*MCPAccessGrant]  = mcpAccessGrantValidator{}
-	_ admission.Validator[*MCPAgentSession] = mcpAgentSessionValidator{}
+	_ admission.Defaulter[*MCPServer]         = mcpServerWebhook{}
+	_ admission.Validator[*MCPServer]         = mcpServerWebhook{}
+	_ admission.Validator[*MCPAccessGrant]    = mcpAccessGrantValidator{}
+	_ admission.Validator[*MCPAgentSession]   = mcpAgentSessionValidator{}
+	_ admission.Validator[*MCPServerClass]    = mcpServerClassValidator{}
+	_ admission.Validator[*MCPPolicyBaseline] = mcpPolicyBaselineValidator{}
+	_ admission.Validator[*MCPFederation]     = mcpFederationValidator{}
+	_ admission.Validator[*MCPToolApproval]   = mcpToolApprovalValidator{}
 )
 
 const (
@@ -235,33 +248,70 @@ func (r *MCPServer) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *MCPServer) SetupWebhookWithManagerWithOptions(mgr ctrl.Manager, options MCPServerDefaultOptions) error {
+	webhook := mcpServerWebhook{defaultOptions: options, classes: mgr.GetAPIReader()}
 	return ctrl.NewWebhookManagedBy(mgr, r).
-		WithDefaulter(mcpServerWebhook{defaultOptions: options}).
-		WithValidator(mcpServerWebhook{defaultOptions: options}).
+		WithDefaulter(webhook).
+		WithValidator(webhook).
 		Complete()
 }
 
 type mcpServerWebhook struct {
 	defaultOptions MCPServerDefaultOptions
+	// classes reads MCPServerClasses; nil skips class defaults and
+	// constraints.
+	classes client.Reader
 }
 
-func (w mcpServerWebhook) Default(_ context.Context, obj *MCPServer) error {
+// Default applies the server's class defaults, then the built-in ones. A
+// class that cannot be read is left to validation to report.
+func (w mcpServerWebhook) Default(ctx context.Context, obj *MCPServer) error {
+	if w.classes != nil {
+		if class, err := ResolveMCPServerClass(ctx, w.classes, obj); err == nil {
+			class.ApplyDefaults(obj)
+		}
+	}
 	obj.DefaultWithOptions(w.defaultOptions)
 	return nil
 }
 
-func (mcpServerWebhook) ValidateCreate(_ context.Context, obj *MCPServer) (admission.Warnings, error) {
-	return obj.ValidateCreate()
+func (w mcpServerWebhook) ValidateCreate(ctx context.Context, obj *MCPServer) (admission.Warnings, error) {
+	if _, err := obj.ValidateCreate(); err != nil {
+		return nil, err
+	}
+	return nil, w.validateClass(ctx, obj)
 }
 
-func (mcpServerWebhook) ValidateUpdate(_ context.Context, oldObj *MCPServer, newObj *MCPServer) (admission.Warnings, error) {
-	return newObj.ValidateUpdate(oldObj)
+func (w mcpServerWebhook) ValidateUpdate(ctx context.Context, oldObj *MCPServer, newObj *MCPServer) (admission.Warnings, error) {
+	if _, err := newObj.ValidateUpdate(oldObj); err != nil {
+		return nil, err
+	}
+	return nil, w.validateClass(ctx, newObj)
 }
 
 func (mcpServerWebhook) ValidateDelete(_ context.Context, obj *MCPServer) (admission.Warnings, error) {
 	return obj.ValidateDelete()
 }
 
+// validateClass enforces the constraints of the server's class.
+func (w mcpServerWebhook) validateClass(ctx context.Context, obj *MCPServer) error {
+	if w.classes == nil {
+		return nil
+	}
+	class, err := ResolveMCPServerClass(ctx, w.classes, obj)
+	if apierrors.IsNotFound(err) {
+		return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPServer"}, obj.Name, field.ErrorList{
+			field.NotFound(field.NewPath("spec", "className"), obj.Spec.ClassName),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if class == nil {
+		return nil
+	}
+	return class.ValidateServer(effectiveServerForClass(obj, class, w.defaultOptions))
+}
+
 func (r *MCPServer) ValidateCreate() (admission.Warnings, error) {
 	return nil, r.validate()
 }
@@ -274,6 +324,770 @@ func (r *MCPServer) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validateCanaryAnalysis checks that analysis is used with the Canary strategy
+// and that every step has a positive duration and parsable thresholds. Metric
+// thresholds require the gateway, whose request metrics carry rollout_track.
+func validateCanaryAnalysis(path *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	rollout := spec.Rollout
+	if rollout.Strategy != RolloutStrategyCanary {
+		allErrs = append(allErrs, field.Invalid(path, "analysis", "analysis requires rollout strategy Canary"))
+	}
+	if len(rollout.Analysis.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("steps"), "at least one analysis step is required"))
+	}
+	for i, step := range rollout.Analysis.Steps {
+		stepPath := path.Child("steps").Index(i)
+		if duration, err := time.ParseDuration(strings.TrimSpace(step.Duration)); err != nil || duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("duration"), step.Duration, "must be a positive duration such as 5m"))
+		}
+		if value := strings.TrimSpace(step.MaxP95Latency); value != "" {
+			if latency, err := time.ParseDuration(value); err != nil || latency <= 0 {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child("maxP95Latency"), step.MaxP95Latency, "must be a positive duration such as 500ms"))
+			}
+		}
+		for _, threshold := range []struct {
+			name  string
+			value string
+		}{
+			{"maxErrorRate", step.MaxErrorRate},
+			{"maxDenialRateDelta", step.MaxDenialRateDelta},
+		} {
+			value := strings.TrimSpace(threshold.value)
+			if value == "" {
+				continue
+			}
+			if fraction, err := strconv.ParseFloat(value, 64); err != nil || fraction < 0 || fraction > 1 {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child(threshold.name), threshold.value, "must be a fraction between 0 and 1 such as 0.05"))
+			}
+		}
+		if !gatewayEnabled(spec) && (strings.TrimSpace(step.MaxErrorRate) != "" || strings.TrimSpace(step.MaxP95Latency) != "" || strings.TrimSpace(step.MaxDenialRateDelta) != "") {
+			allErrs = append(allErrs, field.Forbidden(stepPath, "metric thresholds require gateway.enabled; the gateway records the rollout_track metrics analysis reads"))
+		}
+	}
+	return allErrs
+}
+
+// validateAutoscaling checks the HPA bounds and targets, and that scale to
+// zero is used where the activator can stand in for the server: behind the
+// gateway, without mtls, and without a fixed-size canary.
+func validateAutoscaling(path *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	autoscaling := spec.Autoscaling
+	if autoscaling.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxReplicas"), autoscaling.MaxReplicas, "must be at least 1"))
+	}
+	if autoscaling.MinReplicas != nil {
+		if *autoscaling.MinReplicas < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), *autoscaling.MinReplicas, "must be at least 1; use scaleToZero to scale below one replica"))
+		} else if *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), *autoscaling.MinReplicas, "must not exceed maxReplicas"))
+		}
+	}
+
+	targets := 0
+	for _, target := range []struct {
+		name  string
+		value *int32
+	}{
+		{"targetCPUUtilization", autoscaling.TargetCPUUtilization},
+		{"targetMemoryUtilization", autoscaling.TargetMemoryUtilization},
+	} {
+		if target.value == nil {
+			continue
+		}
+		targets++
+		if *target.value < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child(target.name), *target.value, "must be a positive percentage"))
+		}
+	}
+	for _, target := range []struct {
+		name  string
+		value string
+	}{
+		{"targetInFlightRequests", autoscaling.TargetInFlightRequests},
+		{"targetRequestsPerSecond", autoscaling.TargetRequestsPerSecond},
+	} {
+		value := strings.TrimSpace(target.value)
+		if value == "" {
+			continue
+		}
+		targets++
+		if quantity, err := resource.ParseQuantity(value); err != nil || quantity.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(target.name), target.value, "must be a positive quantity such as 10 or 500m"))
+		}
+		if !gatewayEnabled(spec) {
+			allErrs = append(allErrs, field.Forbidden(path.Child(target.name), "gateway metric targets require gateway.enabled"))
+		}
+	}
+	if targets == 0 {
+		allErrs = append(allErrs, field.Required(path, "at least one of targetCPUUtilization, targetMemoryUtilization, targetInFlightRequests, or targetRequestsPerSecond is required"))
+	}
+
+	if scaleToZero := autoscaling.ScaleToZero; scaleToZero != nil {
+		scalePath := path.Child("scaleToZero")
+		if !gatewayEnabled(spec) {
+			allErrs = append(allErrs, field.Forbidden(scalePath, "scaleToZero requires gateway.enabled"))
+		}
+		if spec.Auth != nil && spec.Auth.Mode == AuthModeMTLS {
+			allErrs = append(allErrs, field.Forbidden(scalePath, "scaleToZero is not supported with auth.mode mtls"))
+		}
+		if spec.Rollout != nil && spec.Rollout.Strategy == RolloutStrategyCanary {
+			allErrs = append(allErrs, field.Forbidden(scalePath, "scaleToZero is not supported with rollout strategy Canary"))
+		}
+		for _, setting := range []struct {
+			name  string
+			value string
+		}{
+			{"idleAfter", scaleToZero.IdleAfter},
+			{"coldStartTimeout", scaleToZero.ColdStartTimeout},
+		} {
+			value := strings.TrimSpace(setting.value)
+			if value == "" {
+				continue
+			}
+			if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(scalePath.Child(setting.name), setting.value, "must be a positive duration such as 15m"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateVolumes checks that each volume has one well-formed source and that
+// mounts reference declared volumes without shadowing GatewayMountRoot.
+func validateVolumes(specPath *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	declared := make(map[string]bool, len(spec.Volumes))
+	for i, volume := range spec.Volumes {
+		volumePath := specPath.Child("volumes").Index(i)
+		name := strings.TrimSpace(volume.Name)
+		switch {
+		case name == "":
+			allErrs = append(allErrs, field.Required(volumePath.Child("name"), "volume name is required"))
+		case len(validation.IsDNS1123Label(name)) > 0:
+			allErrs = append(allErrs, field.Invalid(volumePath.Child("name"), volume.Name, "must be a DNS label"))
+		case name == GatewayPolicyVolumeName || name == GatewayTLSVolumeName || name == GatewayAnalyticsSpoolVolumeName || name == GatewayOperatorTokenVolumeName:
+			allErrs = append(allErrs, field.Invalid(volumePath.Child("name"), volume.Name, "is reserved for the gateway sidecar"))
+		case name == StdioBridgeVolumeName:
+			allErrs = append(allErrs, field.Invalid(volumePath.Child("name"), volume.Name, "is reserved for the stdio bridge"))
+		case declared[name]:
+			allErrs = append(allErrs, field.Duplicate(volumePath.Child("name"), volume.Name))
+		}
+		declared[name] = true
+
+		sources := 0
+		if volume.ConfigMap != nil {
+			sources++
+			if strings.TrimSpace(volume.ConfigMap.Name) == "" {
+				allErrs = append(allErrs, field.Required(volumePath.Child("configMap", "name"), "configMap name is required"))
+			}
+			allErrs = append(allErrs, validateKeyToPaths(volumePath.Child("configMap", "items"), volume.ConfigMap.Items)...)
+		}
+		if volume.Secret != nil {
+			sources++
+			if strings.TrimSpace(volume.Secret.SecretName) == "" {
+				allErrs = append(allErrs, field.Required(volumePath.Child("secret", "secretName"), "secretName is required"))
+			}
+			allErrs = append(allErrs, validateKeyToPaths(volumePath.Child("secret", "items"), volume.Secret.Items)...)
+		}
+		if volume.EmptyDir != nil {
+			sources++
+			if limit := strings.TrimSpace(volume.EmptyDir.SizeLimit); limit != "" {
+				if quantity, err := resource.ParseQuantity(limit); err != nil || quantity.Sign() <= 0 {
+					allErrs = append(allErrs, field.Invalid(volumePath.Child("emptyDir", "sizeLimit"), volume.EmptyDir.SizeLimit, "must be a positive quantity such as 1Gi"))
+				}
+			}
+		}
+		if claim := volume.PersistentVolumeClaim; claim != nil {
+			sources++
+			allErrs = append(allErrs, validateClaimVolume(volumePath.Child("persistentVolumeClaim"), claim, spec)...)
+		}
+		if sources != 1 {
+			allErrs = append(allErrs, field.Invalid(volumePath, volume.Name, "exactly one of configMap, secret, emptyDir, or persistentVolumeClaim is required"))
+		}
+	}
+
+	allErrs = append(allErrs, validateVolumeMounts(specPath.Child("volumeMounts"), spec.VolumeMounts, declared)...)
+	return allErrs
+}
+
+// validateAnalyticsSpool checks the spool size and that a named spool volume
+// is a writable emptyDir or claim from spec.volumes.
+func validateAnalyticsSpool(spoolPath *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	spool := spec.Analytics.Spool
+	if maxSize := strings.TrimSpace(spool.MaxSize); maxSize != "" {
+		if quantity, err := resource.ParseQuantity(maxSize); err != nil || quantity.Value() < 1<<20 {
+			allErrs = append(allErrs, field.Invalid(spoolPath.Child("maxSize"), spool.MaxSize, "must be a quantity of at least 1Mi such as 256Mi"))
+		}
+	}
+	switch spool.Fsync {
+	case "", SpoolFsyncAlways, SpoolFsyncInterval, SpoolFsyncNever:
+	default:
+		allErrs = append(allErrs, field.NotSupported(spoolPath.Child("fsync"), spool.Fsync, []SpoolFsyncPolicy{SpoolFsyncAlways, SpoolFsyncInterval, SpoolFsyncNever}))
+	}
+	name := strings.TrimSpace(spool.Volume)
+	if name == "" {
+		return allErrs
+	}
+	for _, volume := range spec.Volumes {
+		if strings.TrimSpace(volume.Name) != name {
+			continue
+		}
+		if volume.EmptyDir == nil && volume.PersistentVolumeClaim == nil {
+			allErrs = append(allErrs, field.Invalid(spoolPath.Child("volume"), spool.Volume, "must name an emptyDir or persistentVolumeClaim volume"))
+		}
+		return allErrs
+	}
+	return append(allErrs, field.NotFound(spoolPath.Child("volume"), spool.Volume))
+}
+
+// validateVolumeMounts checks one container's mounts against the declared
+// spec volumes.
+func validateVolumeMounts(mountsPath *field.Path, mounts []VolumeMount, declared map[string]bool) field.ErrorList {
+	var allErrs field.ErrorList
+	mountPaths := make(map[string]bool, len(mounts))
+	for i, mount := range mounts {
+		mountPath := mountsPath.Index(i)
+		switch name := strings.TrimSpace(mount.Name); {
+		case name == GatewayPolicyVolumeName || name == GatewayTLSVolumeName || name == GatewayAnalyticsSpoolVolumeName || name == GatewayOperatorTokenVolumeName:
+			allErrs = append(allErrs, field.Invalid(mountPath.Child("name"), mount.Name, "is reserved for the gateway sidecar"))
+		case name == StdioBridgeVolumeName:
+			allErrs = append(allErrs, field.Invalid(mountPath.Child("name"), mount.Name, "is reserved for the stdio bridge"))
+		case !declared[name]:
+			allErrs = append(allErrs, field.NotFound(mountPath.Child("name"), mount.Name))
+		}
+		target := strings.TrimSpace(mount.MountPath)
+		if !path.IsAbs(target) {
+			allErrs = append(allErrs, field.Invalid(mountPath.Child("mountPath"), mount.MountPath, "must be an absolute path"))
+			continue
+		}
+		target = path.Clean(target)
+		if target == GatewayMountRoot || strings.HasPrefix(target+"/", GatewayMountRoot+"/") || strings.HasPrefix(GatewayMountRoot, strings.TrimSuffix(target, "/")+"/") {
+			allErrs = append(allErrs, field.Invalid(mountPath.Child("mountPath"), mount.MountPath, "must not shadow "+GatewayMountRoot+", which holds gateway policy and certificates"))
+		}
+		if mountPaths[target] {
+			allErrs = append(allErrs, field.Duplicate(mountPath.Child("mountPath"), mount.MountPath))
+		}
+		mountPaths[target] = true
+		if subPath := strings.TrimSpace(mount.SubPath); subPath != "" && !relativePathInside(subPath) {
+			allErrs = append(allErrs, field.Invalid(mountPath.Child("subPath"), mount.SubPath, "must be a relative path without .."))
+		}
+	}
+	return allErrs
+}
+
+// validateContainers checks init and extra containers. Their names, ports,
+// and mounts must not collide with the server container or the gateway
+// sidecar, and only extra containers may declare ports.
+func validateContainers(specPath *field.Path, serverName string, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	declared := make(map[string]bool, len(spec.Volumes))
+	for _, volume := range spec.Volumes {
+		declared[strings.TrimSpace(volume.Name)] = true
+	}
+	reservedPorts := map[int32]string{spec.Port: "spec.port"}
+	if gatewayEnabled(spec) {
+		reservedPorts[spec.Gateway.Port] = "gateway.port"
+		reservedPorts[GatewayMetricsPort] = "the gateway metrics port"
+	}
+	names := map[string]bool{serverName: true, GatewayContainerName: true}
+	portNames := map[string]bool{"http": true, "metrics": true}
+	ports := map[string]bool{}
+
+	for _, group := range []struct {
+		field      string
+		containers []Container
+	}{
+		{"initContainers", spec.InitContainers},
+		{"extraContainers", spec.ExtraContainers},
+	} {
+		for i, container := range group.containers {
+			containerPath := specPath.Child(group.field).Index(i)
+			name := strings.TrimSpace(container.Name)
+			switch {
+			case name == "":
+				allErrs = append(allErrs, field.Required(containerPath.Child("name"), "container name is required"))
+			case len(validation.IsDNS1123Label(name)) > 0:
+				allErrs = append(allErrs, field.Invalid(containerPath.Child("name"), container.Name, "must be a DNS label"))
+			case name == GatewayContainerName:
+				allErrs = append(allErrs, field.Invalid(containerPath.Child("name"), container.Name, "is reserved for the gateway sidecar"))
+			case name == StdioBridgeContainerName:
+				allErrs = append(allErrs, field.Invalid(containerPath.Child("name"), container.Name, "is reserved for the stdio bridge"))
+			case names[name]:
+				allErrs = append(allErrs, field.Duplicate(containerPath.Child("name"), container.Name))
+			}
+			names[name] = true
+			if strings.TrimSpace(container.Image) == "" {
+				allErrs = append(allErrs, field.Required(containerPath.Child("image"), "container image is required"))
+			}
+			allErrs = append(allErrs, validateVolumeMounts(containerPath.Child("volumeMounts"), container.VolumeMounts, declared)...)
+
+			if group.field == "initContainers" {
+				if len(container.Ports) > 0 {
+					allErrs = append(allErrs, field.Forbidden(containerPath.Child("ports"), "init containers cannot declare ports"))
+				}
+				continue
+			}
+			for j, port := range container.Ports {
+				portPath := containerPath.Child("ports").Index(j)
+				if errs := validation.IsValidPortName(port.Name); len(errs) > 0 {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("name"), port.Name, strings.Join(errs, "; ")))
+				} else if portNames[port.Name] {
+					allErrs = append(allErrs, field.Duplicate(portPath.Child("name"), port.Name))
+				}
+				portNames[port.Name] = true
+				if port.ContainerPort < 1 || port.ContainerPort > 65535 {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("containerPort"), port.ContainerPort, "must be between 1 and 65535"))
+					continue
+				}
+				if owner, reserved := reservedPorts[port.ContainerPort]; reserved {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("containerPort"), port.ContainerPort, "is already used by "+owner))
+					continue
+				}
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = "TCP"
+				}
+				if protocol != "TCP" && protocol != "UDP" {
+					allErrs = append(allErrs, field.NotSupported(portPath.Child("protocol"), port.Protocol, []string{"TCP", "UDP"}))
+				}
+				key := fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+				if ports[key] {
+					allErrs = append(allErrs, field.Duplicate(portPath.Child("containerPort"), port.ContainerPort))
+				}
+				ports[key] = true
+				if port.Expose && port.ContainerPort == spec.ServicePort {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("containerPort"), port.ContainerPort, "an exposed port must differ from spec.servicePort"))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateTransport checks that stdio settings appear only with transport
+// stdio, which requires a command.
+func validateTransport(specPath *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	stdioPath := specPath.Child("stdio")
+	switch spec.Transport {
+	case "", ServerTransportHTTP:
+		if spec.Stdio != nil {
+			allErrs = append(allErrs, field.Forbidden(stdioPath, "stdio requires transport stdio"))
+		}
+		return allErrs
+	case ServerTransportStdio:
+	default:
+		return append(allErrs, field.NotSupported(specPath.Child("transport"), spec.Transport, []ServerTransport{ServerTransportHTTP, ServerTransportStdio}))
+	}
+	if spec.Stdio == nil || len(spec.Stdio.Command) == 0 || strings.TrimSpace(spec.Stdio.Command[0]) == "" {
+		return append(allErrs, field.Required(stdioPath.Child("command"), "stdio.command is required when transport is stdio"))
+	}
+	switch spec.Stdio.SessionMode {
+	case "", StdioSessionModePerSession:
+	case StdioSessionModeShared:
+		if spec.Stdio.MaxSessions != 0 {
+			allErrs = append(allErrs, field.Forbidden(stdioPath.Child("maxSessions"), "maxSessions applies only to the per-session mode"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(stdioPath.Child("sessionMode"), spec.Stdio.SessionMode, []StdioSessionMode{StdioSessionModePerSession, StdioSessionModeShared}))
+	}
+	if spec.Stdio.MaxSessions < 0 {
+		allErrs = append(allErrs, field.Invalid(stdioPath.Child("maxSessions"), spec.Stdio.MaxSessions, "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateKeyToPaths(itemsPath *field.Path, items []KeyToPath) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, item := range items {
+		if strings.TrimSpace(item.Key) == "" {
+			allErrs = append(allErrs, field.Required(itemsPath.Index(i).Child("key"), "key is required"))
+		}
+		if !relativePathInside(item.Path) {
+			allErrs = append(allErrs, field.Invalid(itemsPath.Index(i).Child("path"), item.Path, "must be a relative path without .."))
+		}
+	}
+	return allErrs
+}
+
+// relativePathInside reports whether value is a non-empty relative path that
+// stays inside its volume.
+func relativePathInside(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || path.IsAbs(value) {
+		return false
+	}
+	for _, part := range strings.Split(value, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func validateClaimVolume(claimPath *field.Path, claim *PersistentVolumeClaimVolumeSource, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	hasName := strings.TrimSpace(claim.ClaimName) != ""
+	if hasName == (claim.Template != nil) {
+		return append(allErrs, field.Invalid(claimPath, "", "exactly one of claimName or template is required"))
+	}
+	if hasName {
+		if len(validation.IsDNS1123Subdomain(strings.TrimSpace(claim.ClaimName))) > 0 {
+			allErrs = append(allErrs, field.Invalid(claimPath.Child("claimName"), claim.ClaimName, "must be a valid resource name"))
+		}
+		return allErrs
+	}
+
+	template := claim.Template
+	if quantity, err := resource.ParseQuantity(strings.TrimSpace(template.Size)); err != nil || quantity.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(claimPath.Child("template", "size"), template.Size, "must be a positive quantity such as 10Gi"))
+	}
+	singleNode := len(template.AccessModes) == 0
+	for i, mode := range template.AccessModes {
+		switch mode {
+		case "ReadWriteOnce", "ReadWriteOncePod":
+			singleNode = true
+		case "ReadOnlyMany", "ReadWriteMany":
+		default:
+			allErrs = append(allErrs, field.NotSupported(claimPath.Child("template", "accessModes").Index(i), mode, []string{"ReadWriteOnce", "ReadWriteOncePod", "ReadOnlyMany", "ReadWriteMany"}))
+		}
+	}
+	// All replicas share the one claim, which a single-node access mode
+	// cannot serve across nodes.
+	if singleNode && ((spec.Replicas != nil && *spec.Replicas > 1) || (spec.Autoscaling != nil && spec.Autoscaling.MaxReplicas > 1)) {
+		allErrs = append(allErrs, field.Forbidden(claimPath.Child("template", "accessModes"), "ReadWriteOnce claims support a single replica; use ReadWriteMany or one replica"))
+	}
+	return allErrs
+}
+
+// validateProbes checks each configured probe against its type.
+func validateProbes(path *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, probe := range []struct {
+		name   string
+		config *ProbeConfig
+	}{
+		{"liveness", spec.Probes.Liveness},
+		{"readiness", spec.Probes.Readiness},
+		{"startup", spec.Probes.Startup},
+	} {
+		if probe.config == nil {
+			continue
+		}
+		probePath := path.Child(probe.name)
+		config := probe.config
+		switch config.Type {
+		case "", ProbeTypeTCP, ProbeTypeHTTP:
+		case ProbeTypeExec:
+			if len(config.Command) == 0 {
+				allErrs = append(allErrs, field.Required(probePath.Child("command"), "command is required for exec probes"))
+			}
+		case ProbeTypeMCP:
+			if !gatewayEnabled(spec) {
+				allErrs = append(allErrs, field.Forbidden(probePath.Child("type"), "mcp probes require gateway.enabled"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(probePath.Child("type"), config.Type, []ProbeType{ProbeTypeTCP, ProbeTypeHTTP, ProbeTypeExec, ProbeTypeMCP}))
+		}
+		if len(config.Command) > 0 && config.Type != ProbeTypeExec {
+			allErrs = append(allErrs, field.Forbidden(probePath.Child("command"), "command is only used by exec probes"))
+		}
+		if value := strings.TrimSpace(config.Path); value != "" && !strings.HasPrefix(value, "/") {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("path"), config.Path, "must start with /"))
+		}
+		if config.Port != nil && (*config.Port < 1 || *config.Port > 65535) {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("port"), *config.Port, "must be between 1 and 65535"))
+		}
+		if config.InitialDelaySeconds != nil && *config.InitialDelaySeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("initialDelaySeconds"), *config.InitialDelaySeconds, "must not be negative"))
+		}
+		for _, timing := range []struct {
+			name  string
+			value *int32
+		}{
+			{"periodSeconds", config.PeriodSeconds},
+			{"timeoutSeconds", config.TimeoutSeconds},
+			{"failureThreshold", config.FailureThreshold},
+		} {
+			if timing.value != nil && *timing.value < 1 {
+				allErrs = append(allErrs, field.Invalid(probePath.Child(timing.name), *timing.value, "must be at least 1"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateScheduling checks label keys and selector operators, which the API
+// server would otherwise only reject when it creates the Deployment.
+func validateScheduling(path *field.Path, scheduling *SchedulingConfig) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateLabels(path.Child("nodeSelector"), scheduling.NodeSelector)...)
+	for i, toleration := range scheduling.Tolerations {
+		tolerationPath := path.Child("tolerations").Index(i)
+		if toleration.Key != "" {
+			for _, msg := range validation.IsQualifiedName(toleration.Key) {
+				allErrs = append(allErrs, field.Invalid(tolerationPath.Child("key"), toleration.Key, msg))
+			}
+		}
+		switch toleration.Operator {
+		case "", "Equal":
+			if toleration.Key == "" {
+				allErrs = append(allErrs, field.Required(tolerationPath.Child("key"), "key is required unless operator is Exists"))
+			}
+		case "Exists":
+			if toleration.Value != "" {
+				allErrs = append(allErrs, field.Forbidden(tolerationPath.Child("value"), "value must be empty when operator is Exists"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(tolerationPath.Child("operator"), toleration.Operator, []string{"Equal", "Exists"}))
+		}
+		if toleration.TolerationSeconds != nil && toleration.Effect != "NoExecute" {
+			allErrs = append(allErrs, field.Forbidden(tolerationPath.Child("tolerationSeconds"), "tolerationSeconds only applies to the NoExecute effect"))
+		}
+	}
+	if affinity := scheduling.Affinity; affinity != nil {
+		affinityPath := path.Child("affinity")
+		if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+			nodePath := affinityPath.Child("nodeAffinity")
+			for i, term := range nodeAffinity.Required {
+				allErrs = append(allErrs, validateNodeSelectorTerm(nodePath.Child("required").Index(i), term)...)
+			}
+			for i, term := range nodeAffinity.Preferred {
+				allErrs = append(allErrs, validateNodeSelectorTerm(nodePath.Child("preferred").Index(i).Child("preference"), term.Preference)...)
+			}
+		}
+		for _, podAffinity := range []struct {
+			name   string
+			config *PodAffinity
+		}{
+			{"podAffinity", affinity.PodAffinity},
+			{"podAntiAffinity", affinity.PodAntiAffinity},
+		} {
+			if podAffinity.config == nil {
+				continue
+			}
+			podPath := affinityPath.Child(podAffinity.name)
+			for i, term := range podAffinity.config.Required {
+				allErrs = append(allErrs, validatePodAffinityTerm(podPath.Child("required").Index(i), term)...)
+			}
+			for i, term := range podAffinity.config.Preferred {
+				allErrs = append(allErrs, validatePodAffinityTerm(podPath.Child("preferred").Index(i).Child("podAffinityTerm"), term.PodAffinityTerm)...)
+			}
+		}
+	}
+	for i, constraint := range scheduling.TopologySpreadConstraints {
+		constraintPath := path.Child("topologySpreadConstraints").Index(i)
+		if constraint.MaxSkew < 1 {
+			allErrs = append(allErrs, field.Invalid(constraintPath.Child("maxSkew"), constraint.MaxSkew, "must be at least 1"))
+		}
+		allErrs = append(allErrs, validateTopologyKey(constraintPath.Child("topologyKey"), constraint.TopologyKey)...)
+		allErrs = append(allErrs, validateLabels(constraintPath.Child("matchLabels"), constraint.MatchLabels)...)
+	}
+	if name := scheduling.PriorityClassName; name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("priorityClassName"), name, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateNodeSelectorTerm(termPath *field.Path, term NodeSelectorTerm) field.ErrorList {
+	if len(term.MatchExpressions) == 0 {
+		return field.ErrorList{field.Required(termPath.Child("matchExpressions"), "at least one expression is required")}
+	}
+	var allErrs field.ErrorList
+	for i, requirement := range term.MatchExpressions {
+		allErrs = append(allErrs, validateLabelSelectorRequirement(termPath.Child("matchExpressions").Index(i), requirement, true)...)
+	}
+	return allErrs
+}
+
+func validatePodAffinityTerm(termPath *field.Path, term PodAffinityTerm) field.ErrorList {
+	allErrs := validateTopologyKey(termPath.Child("topologyKey"), term.TopologyKey)
+	allErrs = append(allErrs, validateLabels(termPath.Child("matchLabels"), term.MatchLabels)...)
+	for i, requirement := range term.MatchExpressions {
+		allErrs = append(allErrs, validateLabelSelectorRequirement(termPath.Child("matchExpressions").Index(i), requirement, false)...)
+	}
+	for i, namespace := range term.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(termPath.Child("namespaces").Index(i), namespace, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateLabelSelectorRequirement checks one expression. Gt and Lt compare
+// a single integer and are only valid for node labels.
+func validateLabelSelectorRequirement(path *field.Path, requirement LabelSelectorRequirement, node bool) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsQualifiedName(requirement.Key) {
+		allErrs = append(allErrs, field.Invalid(path.Child("key"), requirement.Key, msg))
+	}
+	switch requirement.Operator {
+	case "In", "NotIn":
+		if len(requirement.Values) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("values"), "values are required for In and NotIn"))
+		}
+	case "Exists", "DoesNotExist":
+		if len(requirement.Values) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("values"), "values must be empty for Exists and DoesNotExist"))
+		}
+	case "Gt", "Lt":
+		if !node {
+			allErrs = append(allErrs, field.NotSupported(path.Child("operator"), requirement.Operator, []string{"In", "NotIn", "Exists", "DoesNotExist"}))
+			break
+		}
+		if len(requirement.Values) != 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("values"), requirement.Values, "Gt and Lt take exactly one value"))
+		} else if _, err := strconv.ParseInt(requirement.Values[0], 10, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("values").Index(0), requirement.Values[0], "must be an integer"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("operator"), requirement.Operator, []string{"In", "NotIn", "Exists", "DoesNotExist", "Gt", "Lt"}))
+	}
+	return allErrs
+}
+
+func validateTopologyKey(path *field.Path, key string) field.ErrorList {
+	if strings.TrimSpace(key) == "" {
+		return field.ErrorList{field.Required(path, "topologyKey is required")}
+	}
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsQualifiedName(key) {
+		allErrs = append(allErrs, field.Invalid(path, key, msg))
+	}
+	return allErrs
+}
+
+func validateLabels(path *field.Path, labels map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	for key, value := range labels {
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), value, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateEgress checks the rules the operator renders into the egress
+// NetworkPolicy, which the API server would otherwise only reject on create.
+func validateEgress(path *field.Path, egress *EgressConfig) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range egress.Rules {
+		rulePath := path.Child("rules").Index(i)
+		if len(rule.To) == 0 && len(rule.Ports) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath, "a rule needs to or ports; an empty rule allows all egress"))
+		}
+		for j, peer := range rule.To {
+			allErrs = append(allErrs, validateEgressPeer(rulePath.Child("to").Index(j), peer)...)
+		}
+		for j, port := range rule.Ports {
+			portPath := rulePath.Child("ports").Index(j)
+			switch port.Protocol {
+			case "", "TCP", "UDP", "SCTP":
+			default:
+				allErrs = append(allErrs, field.NotSupported(portPath.Child("protocol"), port.Protocol, []string{"TCP", "UDP", "SCTP"}))
+			}
+			if port.Port < 0 || port.Port > 65535 {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port, "must be between 0 (every port) and 65535"))
+			}
+			if port.EndPort != 0 {
+				if port.Port == 0 {
+					allErrs = append(allErrs, field.Required(portPath.Child("port"), "port is required with endPort"))
+				} else if port.EndPort < port.Port || port.EndPort > 65535 {
+					allErrs = append(allErrs, field.Invalid(portPath.Child("endPort"), port.EndPort, "must be between port and 65535"))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateEgressPeer(path *field.Path, peer EgressPeer) field.ErrorList {
+	var allErrs field.ErrorList
+	hasSelector := len(peer.NamespaceSelector) > 0 || len(peer.PodSelector) > 0
+	cidr := strings.TrimSpace(peer.CIDR)
+	switch {
+	case cidr != "" && hasSelector:
+		allErrs = append(allErrs, field.Forbidden(path.Child("cidr"), "cidr cannot be combined with namespaceSelector or podSelector"))
+	case cidr == "" && !hasSelector:
+		allErrs = append(allErrs, field.Required(path, "cidr, namespaceSelector, or podSelector is required"))
+	}
+	if cidr != "" {
+		_, block, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("cidr"), peer.CIDR, "must be a CIDR such as 10.0.0.0/8"))
+		}
+		for i, except := range peer.Except {
+			ip, exceptBlock, err := net.ParseCIDR(strings.TrimSpace(except))
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("except").Index(i), except, "must be a CIDR such as 10.0.0.0/24"))
+				continue
+			}
+			if block == nil {
+				continue
+			}
+			blockOnes, _ := block.Mask.Size()
+			exceptOnes, _ := exceptBlock.Mask.Size()
+			if !block.Contains(ip) || exceptOnes <= blockOnes {
+				allErrs = append(allErrs, field.Invalid(path.Child("except").Index(i), except, "must be a smaller block inside cidr"))
+			}
+		}
+	} else if len(peer.Except) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("except"), "except only applies to cidr"))
+	}
+	allErrs = append(allErrs, validateLabels(path.Child("namespaceSelector"), peer.NamespaceSelector)...)
+	allErrs = append(allErrs, validateLabels(path.Child("podSelector"), peer.PodSelector)...)
+	return allErrs
+}
+
+// usesGatewayAPIRouting reports whether the spec explicitly selects
+// gateway-api routing. The operator default is not known here.
+func usesGatewayAPIRouting(spec MCPServerSpec) bool {
+	return spec.Routing != nil && spec.Routing.Mode == RoutingModeGatewayAPI
+}
+
+func validateRouting(path *field.Path, routing *RoutingConfig) field.ErrorList {
+	var allErrs field.ErrorList
+	switch routing.Mode {
+	case "", RoutingModeIngress, RoutingModeGatewayAPI:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), routing.Mode, []string{string(RoutingModeIngress), string(RoutingModeGatewayAPI)}))
+	}
+	parent := routing.ParentRef
+	if parent == nil {
+		return allErrs
+	}
+	parentPath := path.Child("parentRef")
+	if routing.Mode == RoutingModeIngress {
+		allErrs = append(allErrs, field.Forbidden(parentPath, "parentRef only applies to gateway-api routing"))
+	}
+	if strings.TrimSpace(parent.Name) == "" {
+		allErrs = append(allErrs, field.Required(parentPath.Child("name"), "Gateway name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(parent.Name) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("name"), parent.Name, msg))
+		}
+	}
+	if parent.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(parent.Namespace) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("namespace"), parent.Namespace, msg))
+		}
+	}
+	if parent.SectionName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(parent.SectionName) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("sectionName"), parent.SectionName, msg))
+		}
+	}
+	return allErrs
+}
+
 func (r *MCPServer) validate() error {
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
@@ -316,8 +1130,10 @@ func (r *MCPServer) validate() error {
 		// Path-based routing is supported in mtls mode: Traefik terminates the
 		// client mTLS, injects the verified SPIFFE identity header, and routes by
 		// path to the gateway over a re-encrypted mTLS hop.
+		// In gateway-api mode the parent Gateway terminates client mTLS and
+		// the ingress class is unused.
 		ingressClass := strings.TrimSpace(r.Spec.IngressClass)
-		if ingressClass != "" && ingressClass != "traefik" {
+		if ingressClass != "" && ingressClass != "traefik" && !usesGatewayAPIRouting(r.Spec) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("ingressClass"), r.Spec.IngressClass, "auth.mode mtls currently requires the traefik ingress class"))
 		}
 	}
@@ -326,10 +1142,14 @@ func (r *MCPServer) validate() error {
 			(strings.TrimSpace(r.Spec.Analytics.IngestURL) != "" ||
 				strings.TrimSpace(r.Spec.Analytics.Source) != "" ||
 				strings.TrimSpace(r.Spec.Analytics.EventType) != "" ||
-				r.Spec.Analytics.APIKeySecretRef != nil) {
+				r.Spec.Analytics.APIKeySecretRef != nil ||
+				r.Spec.Analytics.Spool != nil) {
 			allErrs = append(allErrs, field.Forbidden(specPath.Child("analytics"), "analytics emission requires gateway.enabled; set spec.analytics.disabled to true or enable the gateway"))
 		}
 	}
+	if verification := r.Spec.ImageVerification; verification != nil && strings.TrimSpace(verification.PublicKeysSecret) != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("imageVerification", "publicKeysSecret"), "signatures are verified against the operator's image verification keys; servers cannot name their own"))
+	}
 	if r.Spec.Rollout != nil && r.Spec.Rollout.Strategy == RolloutStrategyCanary {
 		if r.Spec.Rollout.CanaryReplicas == nil || *r.Spec.Rollout.CanaryReplicas <= 0 {
 			allErrs = append(allErrs, field.Required(specPath.Child("rollout", "canaryReplicas"), "canaryReplicas must be greater than zero for canary strategy"))
@@ -348,6 +1168,67 @@ func (r *MCPServer) validate() error {
 		if err := validateRolloutValue(specPath.Child("rollout", "maxSurge"), r.Spec.Rollout.MaxSurge); err != nil {
 			allErrs = append(allErrs, err)
 		}
+		if r.Spec.Rollout.Analysis != nil {
+			allErrs = append(allErrs, validateCanaryAnalysis(specPath.Child("rollout", "analysis"), r.Spec)...)
+		}
+	}
+
+	if r.Spec.Autoscaling != nil {
+		allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), r.Spec)...)
+	}
+	if r.Spec.Probes != nil {
+		allErrs = append(allErrs, validateProbes(specPath.Child("probes"), r.Spec)...)
+	}
+	if r.Spec.Routing != nil {
+		allErrs = append(allErrs, validateRouting(specPath.Child("routing"), r.Spec.Routing)...)
+	}
+	allErrs = append(allErrs, validateVolumes(specPath, r.Spec)...)
+	if r.Spec.Analytics != nil && r.Spec.Analytics.Spool != nil {
+		allErrs = append(allErrs, validateAnalyticsSpool(specPath.Child("analytics", "spool"), r.Spec)...)
+	}
+	allErrs = append(allErrs, validateContainers(specPath, r.Name, r.Spec)...)
+	allErrs = append(allErrs, validateTransport(specPath, r.Spec)...)
+	if r.Spec.Scheduling != nil {
+		allErrs = append(allErrs, validateScheduling(specPath.Child("scheduling"), r.Spec.Scheduling)...)
+	}
+	if r.Spec.Network != nil && r.Spec.Network.Egress != nil {
+		allErrs = append(allErrs, validateEgress(specPath.Child("network", "egress"), r.Spec.Network.Egress)...)
+	}
+	if r.Spec.DisruptionBudget != nil {
+		budgetPath := specPath.Child("disruptionBudget", "maxUnavailable")
+		maxUnavailable := strings.TrimSpace(r.Spec.DisruptionBudget.MaxUnavailable)
+		if err := validateRolloutValue(budgetPath, maxUnavailable); err != nil {
+			allErrs = append(allErrs, err)
+		} else if strings.TrimSuffix(maxUnavailable, "%") == "0" {
+			allErrs = append(allErrs, field.Invalid(budgetPath, maxUnavailable, "must be greater than zero; a zero budget blocks every node drain"))
+		}
+	}
+
+	if r.Spec.Session != nil {
+		for _, setting := range []struct {
+			name  string
+			value string
+		}{
+			{"maxLifetime", r.Spec.Session.MaxLifetime},
+			{"idleTimeout", r.Spec.Session.IdleTimeout},
+		} {
+			if strings.TrimSpace(setting.value) == "" {
+				continue
+			}
+			if duration, err := time.ParseDuration(strings.TrimSpace(setting.value)); err != nil || duration < 0 {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("session", setting.name), setting.value, "must be a non-negative duration such as 1h or 30m"))
+			}
+		}
+	}
+
+	if r.Spec.Policy != nil && r.Spec.Policy.DefaultDecision == PolicyDecisionRequiresApproval {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("policy", "defaultDecision"), r.Spec.Policy.DefaultDecision, []string{
+			string(PolicyDecisionAllow),
+			string(PolicyDecisionDeny),
+		}))
+	}
+	if r.Spec.Approval != nil {
+		allErrs = append(allErrs, validateApproval(specPath.Child("approval"), r.Spec)...)
 	}
 
 	if r.Spec.Analytics != nil && !r.Spec.Analytics.Disabled {
@@ -407,6 +1288,28 @@ func (r *MCPServer) validate() error {
 	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPServer"}, r.Name, allErrs)
 }
 
+func validateApproval(path *field.Path, spec MCPServerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if !gatewayEnabled(spec) {
+		allErrs = append(allErrs, field.Forbidden(path, "approval requires gateway.enabled"))
+	}
+	for _, setting := range []struct {
+		name  string
+		value string
+	}{
+		{"timeout", spec.Approval.Timeout},
+		{"wait", spec.Approval.Wait},
+	} {
+		if strings.TrimSpace(setting.value) == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(strings.TrimSpace(setting.value)); err != nil || duration < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(setting.name), setting.value, "must be a non-negative duration such as 15m or 30s"))
+		}
+	}
+	return allErrs
+}
+
 func validateRolloutValue(fieldPath *field.Path, value string) *field.Error {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -516,6 +1419,69 @@ func (r *MCPAccessGrant) validate() error {
 			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
 		}
 		toolNames[rule.Name] = struct{}{}
+		allErrs = append(allErrs, validateArgumentConstraints(rulePath, rule)...)
+	}
+
+	promptNames := make(map[string]struct{}, len(r.Spec.PromptRules))
+	for i, rule := range r.Spec.PromptRules {
+		rulePath := specPath.Child("promptRules").Index(i)
+		if strings.TrimSpace(rule.Name) == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("name"), "prompt rule name is required"))
+			continue
+		}
+		if strings.TrimSpace(string(rule.Decision)) == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("decision"), "prompt rule decision is required"))
+		} else if rule.Decision == PolicyDecisionRequiresApproval {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("decision"), rule.Decision, "requires_approval only applies to tool rules"))
+		}
+		if _, exists := promptNames[rule.Name]; exists {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		promptNames[rule.Name] = struct{}{}
+	}
+
+	resourceURIs := make(map[string]struct{}, len(r.Spec.ResourceRules))
+	for i, rule := range r.Spec.ResourceRules {
+		rulePath := specPath.Child("resourceRules").Index(i)
+		if strings.TrimSpace(rule.URI) == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("uri"), "resource rule uri is required"))
+			continue
+		}
+		if strings.TrimSpace(string(rule.Decision)) == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("decision"), "resource rule decision is required"))
+		} else if rule.Decision == PolicyDecisionRequiresApproval {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("decision"), rule.Decision, "requires_approval only applies to tool rules"))
+		}
+		if _, exists := resourceURIs[rule.URI]; exists {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("uri"), rule.URI))
+		}
+		resourceURIs[rule.URI] = struct{}{}
+	}
+
+	limitScopes := make(map[string]struct{}, len(r.Spec.RateLimits))
+	for i, limit := range r.Spec.RateLimits {
+		limitPath := specPath.Child("rateLimits").Index(i)
+		if limit.SideEffect != "" && !validToolSideEffect(limit.SideEffect) {
+			allErrs = append(allErrs, field.NotSupported(limitPath.Child("sideEffect"), limit.SideEffect, []string{
+				string(ToolSideEffectRead),
+				string(ToolSideEffectWrite),
+				string(ToolSideEffectDestructive),
+			}))
+		}
+		if limit.CallsPerMinute < 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("callsPerMinute"), limit.CallsPerMinute, "must not be negative"))
+		}
+		if limit.CallsPerDay < 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("callsPerDay"), limit.CallsPerDay, "must not be negative"))
+		}
+		if limit.CallsPerMinute == 0 && limit.CallsPerDay == 0 {
+			allErrs = append(allErrs, field.Required(limitPath, "callsPerMinute or callsPerDay is required"))
+		}
+		scope := limit.Tool + "\x00" + string(limit.SideEffect)
+		if _, exists := limitScopes[scope]; exists {
+			allErrs = append(allErrs, field.Duplicate(limitPath, limit.Tool+"/"+string(limit.SideEffect)))
+		}
+		limitScopes[scope] = struct{}{}
 	}
 
 	if len(allErrs) == 0 {
@@ -524,6 +1490,33 @@ func (r *MCPAccessGrant) validate() error {
 	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPAccessGrant"}, r.Name, allErrs)
 }
 
+func validateArgumentConstraints(rulePath *field.Path, rule ToolRule) field.ErrorList {
+	var allErrs field.ErrorList
+	constraintsPath := rulePath.Child("argumentConstraints")
+	if len(rule.ArgumentConstraints) > 0 && rule.Decision == PolicyDecisionDeny {
+		allErrs = append(allErrs, field.Invalid(constraintsPath, len(rule.ArgumentConstraints), "argument constraints only apply to allow rules"))
+	}
+	arguments := make(map[string]struct{}, len(rule.ArgumentConstraints))
+	for i, constraint := range rule.ArgumentConstraints {
+		constraintPath := constraintsPath.Index(i)
+		if strings.TrimSpace(constraint.Argument) == "" {
+			allErrs = append(allErrs, field.Required(constraintPath.Child("argument"), "argument is required"))
+			continue
+		}
+		if _, exists := arguments[constraint.Argument]; exists {
+			allErrs = append(allErrs, field.Duplicate(constraintPath.Child("argument"), constraint.Argument))
+		}
+		arguments[constraint.Argument] = struct{}{}
+		if len(constraint.OneOf) == 0 && constraint.Pattern == "" && constraint.Minimum == nil && constraint.Maximum == nil {
+			allErrs = append(allErrs, field.Required(constraintPath, "one of oneOf, pattern, minimum, or maximum is required"))
+		}
+		if constraint.Minimum != nil && constraint.Maximum != nil && *constraint.Minimum > *constraint.Maximum {
+			allErrs = append(allErrs, field.Invalid(constraintPath.Child("minimum"), *constraint.Minimum, "minimum must not exceed maximum"))
+		}
+	}
+	return allErrs
+}
+
 func (r *MCPAgentSession) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).
 		WithValidator(mcpAgentSessionValidator{}).
@@ -584,6 +1577,468 @@ func (r *MCPAgentSession) validate() error {
 	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPAgentSession"}, r.Name, allErrs)
 }
 
+// ResolveMCPServerClass returns the class that applies to server:
+// spec.className, or the class annotated with MCPServerClassDefaultAnnotation
+// when className is empty (the newest one if several are). It returns nil
+// when no class applies and a NotFound error when the named class is missing.
+func ResolveMCPServerClass(ctx context.Context, reader client.Reader, server *MCPServer) (*MCPServerClass, error) {
+	if name := strings.TrimSpace(server.Spec.ClassName); name != "" {
+		class := &MCPServerClass{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: name}, class); err != nil {
+			return nil, err
+		}
+		return class, nil
+	}
+	classes := &MCPServerClassList{}
+	if err := reader.List(ctx, classes); err != nil {
+		return nil, err
+	}
+	var selected *MCPServerClass
+	for i := range classes.Items {
+		class := &classes.Items[i]
+		if class.Annotations[MCPServerClassDefaultAnnotation] != "true" {
+			continue
+		}
+		if selected == nil || class.CreationTimestamp.After(selected.CreationTimestamp.Time) ||
+			(class.CreationTimestamp.Equal(&selected.CreationTimestamp) && class.Name < selected.Name) {
+			selected = class
+		}
+	}
+	return selected, nil
+}
+
+// ApplyDefaults copies the class defaults into the top-level spec fields the
+// server leaves unset. It runs before the built-in defaults, so a class can
+// replace them.
+func (c *MCPServerClass) ApplyDefaults(server *MCPServer) {
+	if c == nil || c.Spec.Defaults == nil {
+		return
+	}
+	defaults := c.Spec.Defaults
+	spec := &server.Spec
+	if spec.Gateway == nil && defaults.Gateway != nil {
+		spec.Gateway = defaults.Gateway.DeepCopy()
+	}
+	if spec.Auth == nil && defaults.Auth != nil {
+		spec.Auth = defaults.Auth.DeepCopy()
+	}
+	if spec.Policy == nil && defaults.Policy != nil {
+		spec.Policy = defaults.Policy.DeepCopy()
+	}
+	if spec.Session == nil && defaults.Session != nil {
+		spec.Session = defaults.Session.DeepCopy()
+	}
+	if spec.Analytics == nil && defaults.Analytics != nil {
+		spec.Analytics = defaults.Analytics.DeepCopy()
+	}
+	if spec.Resources.Limits == nil && spec.Resources.Requests == nil && defaults.Resources != nil {
+		spec.Resources = *defaults.Resources.DeepCopy()
+	}
+	if len(spec.ImagePullSecrets) == 0 && len(defaults.ImagePullSecrets) > 0 {
+		spec.ImagePullSecrets = append([]string(nil), defaults.ImagePullSecrets...)
+	}
+	if spec.ImageVerification == nil && defaults.ImageVerification != nil {
+		spec.ImageVerification = defaults.ImageVerification.DeepCopy()
+	}
+	if spec.Scheduling == nil && defaults.Scheduling != nil {
+		spec.Scheduling = defaults.Scheduling.DeepCopy()
+	}
+	if spec.Network == nil && defaults.Network != nil {
+		spec.Network = defaults.Network.DeepCopy()
+	}
+}
+
+// ValidateServer checks the class constraints against the effective spec of
+// server, which should already carry the class and built-in defaults.
+func (c *MCPServerClass) ValidateServer(server *MCPServer) error {
+	if c == nil || c.Spec.Constraints == nil {
+		return nil
+	}
+	constraints := c.Spec.Constraints
+	specPath := field.NewPath("spec")
+	spec := server.Spec
+	var allErrs field.ErrorList
+	detail := func(msg string) string {
+		return fmt.Sprintf("MCPServerClass %s %s", c.Name, msg)
+	}
+
+	if constraints.RequireGateway && !gatewayEnabled(spec) {
+		allErrs = append(allErrs, field.Required(specPath.Child("gateway", "enabled"), detail("requires the gateway")))
+	}
+	if len(constraints.AllowedAuthModes) > 0 && spec.Auth != nil && !slices.Contains(constraints.AllowedAuthModes, spec.Auth.Mode) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("auth", "mode"), spec.Auth.Mode, constraints.AllowedAuthModes))
+	}
+	if len(constraints.AllowedPolicyModes) > 0 && spec.Policy != nil && !slices.Contains(constraints.AllowedPolicyModes, spec.Policy.Mode) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("policy", "mode"), spec.Policy.Mode, constraints.AllowedPolicyModes))
+	}
+	resourcesPath := specPath.Child("resources")
+	for _, limit := range []struct {
+		name     string
+		max      string
+		limit    func(*ResourceList) string
+		setLimit bool
+	}{
+		{name: "cpu", max: constraints.MaxCPU, limit: func(list *ResourceList) string { return list.CPU }},
+		{name: "memory", max: constraints.MaxMemory, limit: func(list *ResourceList) string { return list.Memory }},
+	} {
+		maxQuantity, err := resource.ParseQuantity(strings.TrimSpace(limit.max))
+		if err != nil {
+			continue
+		}
+		var limitValue string
+		if spec.Resources.Limits != nil {
+			limitValue = strings.TrimSpace(limit.limit(spec.Resources.Limits))
+		}
+		if limitValue == "" {
+			allErrs = append(allErrs, field.Required(resourcesPath.Child("limits", limit.name), detail("caps "+limit.name+" at "+limit.max+"; set a limit")))
+		} else if quantity, err := resource.ParseQuantity(limitValue); err == nil && quantity.Cmp(maxQuantity) > 0 {
+			allErrs = append(allErrs, field.Invalid(resourcesPath.Child("limits", limit.name), limitValue, detail("allows at most "+limit.max)))
+		}
+		if spec.Resources.Requests != nil {
+			requestValue := strings.TrimSpace(limit.limit(spec.Resources.Requests))
+			if quantity, err := resource.ParseQuantity(requestValue); err == nil && quantity.Cmp(maxQuantity) > 0 {
+				allErrs = append(allErrs, field.Invalid(resourcesPath.Child("requests", limit.name), requestValue, detail("allows at most "+limit.max)))
+			}
+		}
+	}
+	if maxReplicas := constraints.MaxReplicas; maxReplicas != nil {
+		if spec.Replicas != nil && *spec.Replicas > *maxReplicas {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), *spec.Replicas, detail(fmt.Sprintf("allows at most %d replicas", *maxReplicas))))
+		}
+		if spec.Autoscaling != nil && spec.Autoscaling.MaxReplicas > *maxReplicas {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("autoscaling", "maxReplicas"), spec.Autoscaling.MaxReplicas, detail(fmt.Sprintf("allows at most %d replicas", *maxReplicas))))
+		}
+	}
+	if len(constraints.AllowedRegistries) > 0 && !spec.UseProvisionedRegistry {
+		if registry := imageRegistryHost(spec); !slices.Contains(constraints.AllowedRegistries, registry) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("image"), spec.Image, detail(fmt.Sprintf("does not allow registry %s; allowed: %s", registry, strings.Join(constraints.AllowedRegistries, ", ")))))
+		}
+	}
+	if constraints.RequireSignedImages && (spec.ImageVerification == nil || !spec.ImageVerification.RequireSignature) {
+		allErrs = append(allErrs, field.Required(specPath.Child("imageVerification", "requireSignature"), detail("requires signed images")))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPServer"}, server.Name, allErrs)
+}
+
+// imageRegistryHost returns the registry host spec.image is pulled from,
+// after spec.registryOverride. Images without a registry host come from
+// docker.io.
+func imageRegistryHost(spec MCPServerSpec) string {
+	if override := strings.TrimSpace(spec.RegistryOverride); override != "" {
+		return strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(override, "https://"), "http://"), "/")
+	}
+	first, _, found := strings.Cut(strings.TrimSpace(spec.Image), "/")
+	if found && (strings.Contains(first, ".") || strings.Contains(first, ":") || first == "localhost") {
+		return first
+	}
+	return "docker.io"
+}
+
+// effectiveServerForClass returns a copy of server with the class and
+// built-in defaults applied, the spec the class constraints judge.
+func effectiveServerForClass(server *MCPServer, class *MCPServerClass, options MCPServerDefaultOptions) *MCPServer {
+	effective := server.DeepCopy()
+	class.ApplyDefaults(effective)
+	effective.DefaultWithOptions(options)
+	return effective
+}
+
+func (r *MCPServerClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithValidator(mcpServerClassValidator{}).
+		Complete()
+}
+
+type mcpServerClassValidator struct{}
+
+func (mcpServerClassValidator) ValidateCreate(_ context.Context, obj *MCPServerClass) (admission.Warnings, error) {
+	return nil, obj.validate()
+}
+
+func (mcpServerClassValidator) ValidateUpdate(_ context.Context, _ *MCPServerClass, newObj *MCPServerClass) (admission.Warnings, error) {
+	return nil, newObj.validate()
+}
+
+func (mcpServerClassValidator) ValidateDelete(context.Context, *MCPServerClass) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *MCPServerClass) validate() error {
+	var allErrs field.ErrorList
+	constraintsPath := field.NewPath("spec", "constraints")
+
+	if constraints := r.Spec.Constraints; constraints != nil {
+		for i, mode := range constraints.AllowedAuthModes {
+			if !slices.Contains([]AuthMode{AuthModeNone, AuthModeHeader, AuthModeOAuth, AuthModeMTLS}, mode) {
+				allErrs = append(allErrs, field.NotSupported(constraintsPath.Child("allowedAuthModes").Index(i), mode,
+					[]AuthMode{AuthModeNone, AuthModeHeader, AuthModeOAuth, AuthModeMTLS}))
+			}
+		}
+		for i, mode := range constraints.AllowedPolicyModes {
+			if mode != PolicyModeAllowList && mode != PolicyModeObserve {
+				allErrs = append(allErrs, field.NotSupported(constraintsPath.Child("allowedPolicyModes").Index(i), mode,
+					[]PolicyMode{PolicyModeAllowList, PolicyModeObserve}))
+			}
+		}
+		for name, value := range map[string]string{"maxCPU": constraints.MaxCPU, "maxMemory": constraints.MaxMemory} {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			if _, err := resource.ParseQuantity(strings.TrimSpace(value)); err != nil {
+				allErrs = append(allErrs, field.Invalid(constraintsPath.Child(name), value, "must be a resource quantity such as 2 or 2Gi"))
+			}
+		}
+		for i, registry := range constraints.AllowedRegistries {
+			if strings.TrimSpace(registry) == "" || strings.Contains(registry, "/") {
+				allErrs = append(allErrs, field.Invalid(constraintsPath.Child("allowedRegistries").Index(i), registry, "must be a registry host such as ghcr.io or registry.example.com:5000"))
+			}
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPServerClass"}, r.Name, allErrs)
+}
+
+func (r *MCPPolicyBaseline) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithValidator(mcpPolicyBaselineValidator{}).
+		Complete()
+}
+
+type mcpPolicyBaselineValidator struct{}
+
+func (mcpPolicyBaselineValidator) ValidateCreate(_ context.Context, obj *MCPPolicyBaseline) (admission.Warnings, error) {
+	return nil, obj.validate()
+}
+
+func (mcpPolicyBaselineValidator) ValidateUpdate(_ context.Context, _ *MCPPolicyBaseline, newObj *MCPPolicyBaseline) (admission.Warnings, error) {
+	return nil, newObj.validate()
+}
+
+func (mcpPolicyBaselineValidator) ValidateDelete(context.Context, *MCPPolicyBaseline) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *MCPPolicyBaseline) validate() error {
+	var allErrs field.ErrorList
+	rulesPath := field.NewPath("spec", "rules")
+
+	if len(r.Spec.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, "at least one rule is required"))
+	}
+	seen := make(map[string]struct{}, len(r.Spec.Rules))
+	for i, rule := range r.Spec.Rules {
+		rulePath := rulesPath.Index(i)
+		name := strings.TrimSpace(rule.Name)
+		if name == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("name"), "rule name is required"))
+		} else if _, dup := seen[name]; dup {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		seen[name] = struct{}{}
+		switch rule.Subject {
+		case "", BaselineSubjectAny, BaselineSubjectAgentOnly, BaselineSubjectHuman:
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("subject"), rule.Subject,
+				[]BaselineSubject{BaselineSubjectAny, BaselineSubjectAgentOnly, BaselineSubjectHuman}))
+		}
+		for j, sideEffect := range rule.SideEffects {
+			if !validToolSideEffect(sideEffect) {
+				allErrs = append(allErrs, field.NotSupported(rulePath.Child("sideEffects").Index(j), sideEffect,
+					[]ToolSideEffect{ToolSideEffectRead, ToolSideEffectWrite, ToolSideEffectDestructive}))
+			}
+		}
+		switch rule.Effect {
+		case BaselineEffectDeny:
+			if rule.RequiredTrust != "" {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("requiredTrust"), "requiredTrust is only valid with effect require-trust"))
+			}
+		case BaselineEffectRequireTrust:
+			if rule.RequiredTrust == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("requiredTrust"), "requiredTrust is required with effect require-trust"))
+			} else if trustLevels := []TrustLevel{TrustLevelLow, TrustLevelMedium, TrustLevelHigh}; !slices.Contains(trustLevels, rule.RequiredTrust) {
+				allErrs = append(allErrs, field.NotSupported(rulePath.Child("requiredTrust"), rule.RequiredTrust, trustLevels))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("effect"), rule.Effect,
+				[]BaselineEffect{BaselineEffectDeny, BaselineEffectRequireTrust}))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPPolicyBaseline"}, r.Name, allErrs)
+}
+
+func (r *MCPFederation) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithValidator(mcpFederationValidator{}).
+		Complete()
+}
+
+type mcpFederationValidator struct{}
+
+func (mcpFederationValidator) ValidateCreate(_ context.Context, obj *MCPFederation) (admission.Warnings, error) {
+	return nil, obj.validate()
+}
+
+func (mcpFederationValidator) ValidateUpdate(_ context.Context, _ *MCPFederation, newObj *MCPFederation) (admission.Warnings, error) {
+	return nil, newObj.validate()
+}
+
+func (mcpFederationValidator) ValidateDelete(context.Context, *MCPFederation) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *MCPFederation) validate() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if _, err := metav1.LabelSelectorAsSelector(&r.Spec.Selector); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("selector"), r.Spec.Selector, err.Error()))
+	}
+	if ingressPath := strings.TrimSpace(r.Spec.IngressPath); ingressPath != "" && !strings.HasPrefix(ingressPath, "/") {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("ingressPath"), r.Spec.IngressPath, "ingressPath must start with /"))
+	}
+	if r.Spec.Replicas != nil && *r.Spec.Replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), *r.Spec.Replicas, "replicas must be at least 1"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPFederation"}, r.Name, allErrs)
+}
+
+func (r *MCPToolApproval) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return r.SetupWebhookWithManagerWithOptions(mgr, MCPToolApprovalWebhookOptions{})
+}
+
+// MCPToolApprovalWebhookOptions configures the MCPToolApproval webhook.
+type MCPToolApprovalWebhookOptions struct {
+	// Delegates are usernames that record decisions for approvers they
+	// authenticate themselves, such as the runtime API's service account.
+	// Anyone else records decisions under their own username.
+	Delegates []string
+}
+
+func (r *MCPToolApproval) SetupWebhookWithManagerWithOptions(mgr ctrl.Manager, options MCPToolApprovalWebhookOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithValidator(mcpToolApprovalValidator{delegates: options.Delegates}).
+		Complete()
+}
+
+type mcpToolApprovalValidator struct {
+	delegates []string
+}
+
+func (v mcpToolApprovalValidator) ValidateCreate(ctx context.Context, obj *MCPToolApproval) (admission.Warnings, error) {
+	if err := obj.validate(nil); err != nil {
+		return nil, err
+	}
+	username, err := admissionUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nil, obj.validateCaller(nil, username, v.delegates)
+}
+
+func (v mcpToolApprovalValidator) ValidateUpdate(ctx context.Context, oldObj *MCPToolApproval, newObj *MCPToolApproval) (admission.Warnings, error) {
+	if err := newObj.validate(oldObj); err != nil {
+		return nil, err
+	}
+	username, err := admissionUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nil, newObj.validateCaller(oldObj, username, v.delegates)
+}
+
+func (mcpToolApprovalValidator) ValidateDelete(context.Context, *MCPToolApproval) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks an approval: it is created undecided and, on update, only
+// an undecided approval gets a decision and the held call itself never
+// changes.
+func (r *MCPToolApproval) validate(old *MCPToolApproval) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if strings.TrimSpace(r.Spec.ServerRef.Name) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("serverRef", "name"), "serverRef.name is required"))
+	}
+	if strings.TrimSpace(r.Spec.Tool) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("tool"), "tool is required"))
+	}
+	if old == nil && (r.Spec.Decision != "" || r.Spec.DecidedBy != "" || r.Spec.DecidedAt != nil) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("decision"), "an approval is created undecided"))
+	}
+	if r.Spec.Decision != "" && strings.TrimSpace(r.Spec.DecidedBy) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("decidedBy"), "decidedBy is required with a decision"))
+	}
+	if r.Spec.Decision != "" && (old == nil || old.Spec.Decision == "") {
+		if requester := strings.TrimSpace(r.Spec.Subject.HumanID); requester != "" && strings.TrimSpace(r.Spec.DecidedBy) == requester {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("decidedBy"), "approvers cannot decide their own tool calls"))
+		}
+	}
+	if old != nil {
+		if old.Spec.Decision != "" && (r.Spec.Decision != old.Spec.Decision || r.Spec.DecidedBy != old.Spec.DecidedBy) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("decision"), "a decided approval cannot change"))
+		}
+		held, oldHeld := r.Spec, old.Spec
+		held.Decision, held.DecidedBy, held.DecidedAt, held.Comment = "", "", nil, ""
+		oldHeld.Decision, oldHeld.DecidedBy, oldHeld.DecidedAt, oldHeld.Comment = "", "", nil, ""
+		if !equality.Semantic.DeepEqual(held, oldHeld) {
+			allErrs = append(allErrs, field.Forbidden(specPath, "only decision, decidedBy, decidedAt, and comment may change"))
+		}
+		if r.Annotations[ApprovalRequestedByAnnotation] != old.Annotations[ApprovalRequestedByAnnotation] {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(ApprovalRequestedByAnnotation), "the requester cannot change"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPToolApproval"}, r.Name, allErrs)
+}
+
+// validateCaller checks an approval against the user making the admission
+// request: a creator can only name themselves in
+// ApprovalRequestedByAnnotation, and a decision is recorded under the
+// decider's own username unless the decider is one of delegates.
+func (r *MCPToolApproval) validateCaller(old *MCPToolApproval, username string, delegates []string) error {
+	var allErrs field.ErrorList
+	if requestedBy := r.Annotations[ApprovalRequestedByAnnotation]; old == nil && requestedBy != "" && requestedBy != username {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "annotations").Key(ApprovalRequestedByAnnotation), "must name the user creating the approval"))
+	}
+	if old != nil && old.Spec.Decision == "" && r.Spec.Decision != "" && !slices.Contains(delegates, username) &&
+		strings.TrimSpace(r.Spec.DecidedBy) != username {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "decidedBy"), fmt.Sprintf("must be %q, the user recording the decision", username)))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "MCPToolApproval"}, r.Name, allErrs)
+}
+
+// admissionUsername returns the user making the admission request in ctx.
+func admissionUsername(ctx context.Context) (string, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return "", apierrors.NewForbidden(schema.GroupResource{Group: GroupVersion.Group, Resource: "mcptoolapprovals"}, "", err)
+	}
+	return req.UserInfo.Username, nil
+}
+
 func validToolSideEffect(sideEffect ToolSideEffect) bool {
 	switch sideEffect {
 	case ToolSideEffectRead, ToolSideEffectWrite, ToolSideEffectDestructive:
@@ -1,11 +1,17 @@
 package v1alpha1
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestMCPAccessGrantValidateRequiresToolDecision(t *testing.T) {
@@ -48,6 +54,82 @@ func TestMCPAccessGrantValidateRejectsInvalidAllowedSideEffect(t *testing.T) {
 	}
 }
 
+func TestMCPAccessGrantValidateRejectsInvalidArgumentConstraints(t *testing.T) {
+	minimum, maximum := int64(10), int64(1)
+	grant := &MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+		Spec: MCPAccessGrantSpec{
+			ServerRef: ServerReference{Name: "payments"},
+			Subject:   SubjectRef{HumanID: "user-1"},
+			ToolRules: []ToolRule{{
+				Name:     "query_db",
+				Decision: PolicyDecisionAllow,
+				ArgumentConstraints: []ArgumentConstraint{
+					{Argument: "database"},
+					{Argument: "limit", Minimum: &minimum, Maximum: &maximum},
+				},
+			}},
+		},
+	}
+
+	err := grant.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid argument constraints")
+	}
+	for _, want := range []string{"argumentConstraints[0]", "argumentConstraints[1].minimum"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s validation error, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPAccessGrantValidateRejectsInvalidRateLimits(t *testing.T) {
+	grant := &MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+		Spec: MCPAccessGrantSpec{
+			ServerRef: ServerReference{Name: "payments"},
+			Subject:   SubjectRef{HumanID: "user-1"},
+			RateLimits: []RateLimit{
+				{Tool: "query_db", CallsPerMinute: 10},
+				{Tool: "query_db", CallsPerDay: 100},
+				{SideEffect: "unknown"},
+			},
+		},
+	}
+
+	err := grant.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid rate limits")
+	}
+	for _, want := range []string{"rateLimits[1]", "rateLimits[2].sideEffect", "rateLimits[2]: Required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s validation error, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPAccessGrantValidateRejectsInvalidPromptAndResourceRules(t *testing.T) {
+	grant := &MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+		Spec: MCPAccessGrantSpec{
+			ServerRef:     ServerReference{Name: "payments"},
+			Subject:       SubjectRef{HumanID: "user-1"},
+			PromptRules:   []PromptRule{{Name: "summary"}},
+			ResourceRules: []ResourceRule{{Decision: PolicyDecisionAllow}},
+		},
+	}
+
+	err := grant.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid prompt and resource rules")
+	}
+	for _, want := range []string{"promptRules[0].decision", "resourceRules[0].uri"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s validation error, got %v", want, err)
+		}
+	}
+}
+
 func TestMCPAccessGrantValidateAllowsTeamOnlySubject(t *testing.T) {
 	grant := &MCPAccessGrant{
 		ObjectMeta: metav1.ObjectMeta{Name: "grant"},
@@ -168,6 +250,867 @@ func TestMCPServerValidatePublicPathPrefix(t *testing.T) {
 	}
 }
 
+func TestMCPServerValidateSessionDurations(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Session:          &SessionConfig{MaxLifetime: "one day", IdleTimeout: "30m"},
+		},
+	}
+
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid session maxLifetime")
+	}
+	if !strings.Contains(err.Error(), "session.maxLifetime") || strings.Contains(err.Error(), "idleTimeout") {
+		t.Fatalf("expected only a session.maxLifetime validation error, got %v", err)
+	}
+}
+
+func TestMCPServerValidateCanaryAnalysis(t *testing.T) {
+	replicas, canaryReplicas := int32(3), int32(1)
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Replicas:         &replicas,
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Rollout: &RolloutConfig{
+				Strategy:       RolloutStrategyCanary,
+				CanaryReplicas: &canaryReplicas,
+				Analysis: &CanaryAnalysis{Steps: []CanaryAnalysisStep{
+					{Duration: "5m", MaxErrorRate: "0.01", MaxP95Latency: "500ms", MaxDenialRateDelta: "0.05"},
+				}},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.Gateway = nil
+	if err := server.validate(); err == nil || !strings.Contains(err.Error(), "metric thresholds require gateway.enabled") {
+		t.Fatalf("expected gateway error for thresholds without the gateway, got %v", err)
+	}
+	server.Spec.Rollout.Analysis.Steps = []CanaryAnalysisStep{{Duration: "5m"}}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error for a timed step without the gateway: %v", err)
+	}
+	server.Spec.Gateway = &GatewayConfig{Enabled: true, Port: 8091}
+	server.Spec.Rollout.Analysis.Steps = []CanaryAnalysisStep{
+		{Duration: "5m", MaxErrorRate: "0.01", MaxP95Latency: "500ms", MaxDenialRateDelta: "0.05"},
+	}
+
+	server.Spec.Rollout.Analysis.Steps = append(server.Spec.Rollout.Analysis.Steps, CanaryAnalysisStep{Duration: "0s", MaxErrorRate: "5%"})
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid analysis step")
+	}
+	for _, want := range []string{"steps[1].duration", "steps[1].maxErrorRate"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+
+	server.Spec.Rollout.Analysis.Steps = server.Spec.Rollout.Analysis.Steps[:1]
+	server.Spec.Rollout.Strategy = RolloutStrategyRollingUpdate
+	if err := server.validate(); err == nil || !strings.Contains(err.Error(), "requires rollout strategy Canary") {
+		t.Fatalf("expected strategy error, got %v", err)
+	}
+}
+
+func TestMCPServerValidateAutoscaling(t *testing.T) {
+	minReplicas, cpu := int32(2), int32(70)
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Autoscaling: &AutoscalingConfig{
+				MinReplicas:             &minReplicas,
+				MaxReplicas:             5,
+				TargetCPUUtilization:    &cpu,
+				TargetRequestsPerSecond: "500m",
+				ScaleToZero:             &ScaleToZeroConfig{IdleAfter: "30m", ColdStartTimeout: "90s"},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.Autoscaling.MaxReplicas = 1
+	server.Spec.Autoscaling.TargetRequestsPerSecond = "-1"
+	server.Spec.Autoscaling.ScaleToZero.IdleAfter = "soon"
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid autoscaling")
+	}
+	for _, want := range []string{"autoscaling.minReplicas", "autoscaling.targetRequestsPerSecond", "scaleToZero.idleAfter"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s error, got %v", want, err)
+		}
+	}
+
+	server.Spec.Autoscaling = &AutoscalingConfig{MaxReplicas: 3, ScaleToZero: &ScaleToZeroConfig{}}
+	server.Spec.Gateway = nil
+	err = server.validate()
+	if err == nil {
+		t.Fatal("expected validation error without targets or gateway")
+	}
+	for _, want := range []string{"at least one of targetCPUUtilization", "scaleToZero requires gateway.enabled"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateProbes(t *testing.T) {
+	period, threshold := int32(10), int32(30)
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Probes: &ProbesConfig{
+				Liveness:  &ProbeConfig{Type: ProbeTypeHTTP, Path: "/healthz"},
+				Readiness: &ProbeConfig{Type: ProbeTypeMCP},
+				Startup:   &ProbeConfig{Type: ProbeTypeExec, Command: []string{"cat", "/tmp/ready"}, PeriodSeconds: &period, FailureThreshold: &threshold},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	zero := int32(0)
+	server.Spec.Gateway = nil
+	server.Spec.Probes.Liveness = &ProbeConfig{Type: ProbeTypeHTTP, Path: "healthz", Command: []string{"true"}}
+	server.Spec.Probes.Startup = &ProbeConfig{Type: ProbeTypeExec, PeriodSeconds: &zero}
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid probes")
+	}
+	for _, want := range []string{
+		"probes.liveness.path",
+		"probes.liveness.command",
+		"mcp probes require gateway.enabled",
+		"probes.startup.command",
+		"probes.startup.periodSeconds",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateVolumes(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Volumes: []Volume{
+				{Name: "config", ConfigMap: &ConfigMapVolumeSource{Name: "server-config", Items: []KeyToPath{{Key: "config.yaml", Path: "config.yaml"}}}},
+				{Name: "ca", Secret: &SecretVolumeSource{SecretName: "corp-ca"}},
+				{Name: "scratch", EmptyDir: &EmptyDirVolumeSource{SizeLimit: "1Gi"}},
+				{Name: "index", PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{Template: &PersistentVolumeClaimTemplate{Size: "10Gi"}}},
+			},
+			VolumeMounts: []VolumeMount{
+				{Name: "config", MountPath: "/etc/server", ReadOnly: true},
+				{Name: "ca", MountPath: "/etc/ssl/corp"},
+				{Name: "scratch", MountPath: "/tmp"},
+				{Name: "index", MountPath: "/var/lib/index"},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	replicas := int32(2)
+	server.Spec.Replicas = &replicas
+	server.Spec.Volumes = append(server.Spec.Volumes,
+		Volume{Name: GatewayPolicyVolumeName, EmptyDir: &EmptyDirVolumeSource{}},
+		Volume{Name: "both", EmptyDir: &EmptyDirVolumeSource{}, Secret: &SecretVolumeSource{SecretName: "s"}},
+		Volume{Name: "escape", ConfigMap: &ConfigMapVolumeSource{Name: "cm", Items: []KeyToPath{{Key: "k", Path: "../k"}}}},
+	)
+	server.Spec.VolumeMounts = append(server.Spec.VolumeMounts,
+		VolumeMount{Name: "scratch", MountPath: "/var/run/mcp-runtime/policy"},
+		VolumeMount{Name: "scratch", MountPath: "/var/run"},
+		VolumeMount{Name: "missing", MountPath: "/data"},
+		VolumeMount{Name: "scratch", MountPath: "relative"},
+	)
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid volumes")
+	}
+	for _, want := range []string{
+		"ReadWriteOnce claims support a single replica",
+		"spec.volumes[4].name",
+		"exactly one of configMap, secret, emptyDir, or persistentVolumeClaim",
+		"spec.volumes[6].configMap.items[0].path",
+		"spec.volumeMounts[4].mountPath",
+		"spec.volumeMounts[5].mountPath",
+		"spec.volumeMounts[6].name",
+		"spec.volumeMounts[7].mountPath",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateAnalyticsSpool(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Analytics:        &AnalyticsConfig{Spool: &AnalyticsSpoolConfig{Volume: "spool", MaxSize: "1Gi", Fsync: SpoolFsyncAlways}},
+			Volumes: []Volume{
+				{Name: "spool", PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{ClaimName: "server-spool"}},
+				{Name: "config", ConfigMap: &ConfigMapVolumeSource{Name: "server-config"}},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		spool AnalyticsSpoolConfig
+		want  string
+	}{
+		{"small", AnalyticsSpoolConfig{MaxSize: "64Ki"}, "spec.analytics.spool.maxSize"},
+		{"fsync", AnalyticsSpoolConfig{Fsync: "sometimes"}, "spec.analytics.spool.fsync"},
+		{"missing volume", AnalyticsSpoolConfig{Volume: "absent"}, "spec.analytics.spool.volume: Not found"},
+		{"configMap volume", AnalyticsSpoolConfig{Volume: "config"}, "must name an emptyDir or persistentVolumeClaim volume"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			invalid := server.DeepCopy()
+			invalid.Spec.Analytics.Spool = &tc.spool
+			if err := invalid.validate(); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("validate() = %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	server.Spec.Gateway = nil
+	if err := server.validate(); err == nil || !strings.Contains(err.Error(), "analytics emission requires gateway.enabled") {
+		t.Fatalf("validate() = %v, want the spool rejected without a gateway", err)
+	}
+	server.Spec.Gateway = &GatewayConfig{Enabled: true, Port: 8091}
+	server.Spec.Volumes = append(server.Spec.Volumes, Volume{Name: GatewayAnalyticsSpoolVolumeName, EmptyDir: &EmptyDirVolumeSource{}})
+	if err := server.validate(); err == nil || !strings.Contains(err.Error(), "is reserved for the gateway sidecar") {
+		t.Fatalf("validate() = %v, want the spool volume name reserved", err)
+	}
+}
+
+func TestMCPServerValidateContainers(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			ServicePort:      80,
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Volumes:          []Volume{{Name: "secrets", EmptyDir: &EmptyDirVolumeSource{}}},
+			InitContainers: []Container{
+				{Name: "fetch-secrets", Image: "example.com/vault-agent", VolumeMounts: []VolumeMount{{Name: "secrets", MountPath: "/secrets"}}},
+			},
+			ExtraContainers: []Container{
+				{Name: "cloud-sql-proxy", Image: "example.com/cloud-sql-proxy", Ports: []ContainerPort{{Name: "sql", ContainerPort: 5432}}},
+				{Name: "log-shipper", Image: "example.com/fluent-bit", Ports: []ContainerPort{{Name: "shipper-metrics", ContainerPort: 2020, Expose: true}}},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.InitContainers = append(server.Spec.InitContainers,
+		Container{Name: GatewayContainerName, Image: "example.com/init"},
+		Container{Name: "warmup", Image: "example.com/warmup", Ports: []ContainerPort{{Name: "warm", ContainerPort: 7000}}},
+	)
+	server.Spec.ExtraContainers = append(server.Spec.ExtraContainers,
+		Container{Name: "server", Image: "example.com/shadow"},
+		Container{Name: "sidecar", VolumeMounts: []VolumeMount{{Name: GatewayPolicyVolumeName, MountPath: "/policy"}}, Ports: []ContainerPort{
+			{Name: "gw", ContainerPort: 8091},
+			{Name: "scrape", ContainerPort: GatewayMetricsPort},
+			{Name: "web", ContainerPort: 80, Expose: true},
+			{Name: "sql", ContainerPort: 5433},
+		}},
+	)
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid containers")
+	}
+	for _, want := range []string{
+		"spec.initContainers[1].name",
+		"spec.initContainers[2].ports",
+		"spec.extraContainers[2].name",
+		"spec.extraContainers[3].image",
+		"spec.extraContainers[3].volumeMounts[0].name",
+		"spec.extraContainers[3].ports[0].containerPort: Invalid value: 8091: is already used by gateway.port",
+		"spec.extraContainers[3].ports[1].containerPort",
+		"spec.extraContainers[3].ports[2].containerPort",
+		"spec.extraContainers[3].ports[3].name",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateTransport(t *testing.T) {
+	newServer := func(transport ServerTransport, stdio *StdioConfig) *MCPServer {
+		return &MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "filesystem"},
+			Spec: MCPServerSpec{
+				Image:            "node:22-alpine",
+				PublicPathPrefix: "filesystem",
+				Port:             8088,
+				Transport:        transport,
+				Stdio:            stdio,
+			},
+		}
+	}
+	if err := newServer(ServerTransportStdio, &StdioConfig{Command: []string{"npx", "-y", "@modelcontextprotocol/server-filesystem"}, MaxSessions: 20}).validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		server *MCPServer
+		want   string
+	}{
+		{"missing command", newServer(ServerTransportStdio, nil), "spec.stdio.command: Required value"},
+		{"empty command", newServer(ServerTransportStdio, &StdioConfig{Command: []string{" "}}), "spec.stdio.command: Required value"},
+		{"stdio without transport", newServer("", &StdioConfig{Command: []string{"server"}}), "spec.stdio: Forbidden"},
+		{"shared with max sessions", newServer(ServerTransportStdio, &StdioConfig{Command: []string{"server"}, SessionMode: StdioSessionModeShared, MaxSessions: 2}), "spec.stdio.maxSessions: Forbidden"},
+		{"unknown session mode", newServer(ServerTransportStdio, &StdioConfig{Command: []string{"server"}, SessionMode: "pooled"}), "spec.stdio.sessionMode: Unsupported value"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.server.validate()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("validate() = %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	server := newServer("", nil)
+	server.Spec.Volumes = []Volume{{Name: StdioBridgeVolumeName, EmptyDir: &EmptyDirVolumeSource{}}}
+	server.Spec.InitContainers = []Container{{Name: StdioBridgeContainerName, Image: "example.com/init"}}
+	err := server.validate()
+	if err == nil || !strings.Contains(err.Error(), "spec.volumes[0].name") || !strings.Contains(err.Error(), "spec.initContainers[0].name") {
+		t.Fatalf("validate() = %v, want the stdio bridge names rejected", err)
+	}
+}
+
+func TestMCPServerValidateScheduling(t *testing.T) {
+	seconds := int64(300)
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			Scheduling: &SchedulingConfig{
+				NodeSelector: map[string]string{"node.kubernetes.io/pool": "mcp"},
+				Tolerations: []Toleration{
+					{Key: "dedicated", Value: "mcp", Effect: "NoSchedule"},
+					{Key: "node.kubernetes.io/unreachable", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: &seconds},
+				},
+				Affinity: &Affinity{
+					NodeAffinity: &NodeAffinity{Required: []NodeSelectorTerm{{MatchExpressions: []LabelSelectorRequirement{
+						{Key: "gpu-count", Operator: "Gt", Values: []string{"0"}},
+					}}}},
+					PodAntiAffinity: &PodAffinity{Preferred: []WeightedPodAffinityTerm{
+						{Weight: 100, PodAffinityTerm: PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+					}},
+				},
+				TopologySpreadConstraints: []TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone"}},
+				PriorityClassName:         "mcp-critical",
+			},
+			DisruptionBudget: &DisruptionBudgetConfig{Enabled: true, MaxUnavailable: "25%"},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.Scheduling = &SchedulingConfig{
+		NodeSelector: map[string]string{"pool": "not a value"},
+		Tolerations:  []Toleration{{Operator: "Equal", Value: "x"}, {Key: "k", TolerationSeconds: &seconds}},
+		Affinity: &Affinity{
+			NodeAffinity: &NodeAffinity{Required: []NodeSelectorTerm{{}}},
+			PodAffinity: &PodAffinity{Required: []PodAffinityTerm{
+				{MatchExpressions: []LabelSelectorRequirement{{Key: "app", Operator: "Gt", Values: []string{"1"}}}},
+			}},
+		},
+		TopologySpreadConstraints: []TopologySpreadConstraint{{TopologyKey: "zone"}},
+	}
+	server.Spec.DisruptionBudget.MaxUnavailable = "0"
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid scheduling")
+	}
+	for _, want := range []string{
+		"spec.scheduling.nodeSelector[pool]",
+		"spec.scheduling.tolerations[0].key",
+		"spec.scheduling.tolerations[1].tolerationSeconds",
+		"spec.scheduling.affinity.nodeAffinity.required[0].matchExpressions",
+		"spec.scheduling.affinity.podAffinity.required[0].topologyKey",
+		"spec.scheduling.affinity.podAffinity.required[0].matchExpressions[0].operator",
+		"spec.scheduling.topologySpreadConstraints[0].maxSkew",
+		"spec.disruptionBudget.maxUnavailable",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateRouting(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Port:             8088,
+			IngressClass:     "nginx",
+			Gateway:          &GatewayConfig{Enabled: true, Port: 8091},
+			Auth:             &AuthConfig{Mode: AuthModeMTLS, TrustDomain: "mcpruntime.org"},
+			Routing: &RoutingConfig{
+				Mode:      RoutingModeGatewayAPI,
+				ParentRef: &GatewayParentRef{Name: "public", Namespace: "gateway-system", SectionName: "https"},
+			},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.Routing = &RoutingConfig{
+		Mode:      RoutingModeIngress,
+		ParentRef: &GatewayParentRef{Namespace: "Gateway System"},
+	}
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid routing")
+	}
+	for _, want := range []string{
+		"spec.ingressClass",
+		"spec.routing.parentRef: Forbidden",
+		"spec.routing.parentRef.name",
+		"spec.routing.parentRef.namespace",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateNetworkEgress(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:            "example.com/server",
+			PublicPathPrefix: "server",
+			Network: &NetworkConfig{Egress: &EgressConfig{
+				DefaultDeny: true,
+				Rules: []EgressRule{
+					{
+						To:    []EgressPeer{{CIDR: "10.20.0.0/16", Except: []string{"10.20.9.0/24"}}},
+						Ports: []NetworkPort{{Port: 5432}},
+					},
+					{
+						To:    []EgressPeer{{NamespaceSelector: map[string]string{"kubernetes.io/metadata.name": "jira"}}},
+						Ports: []NetworkPort{{Protocol: "TCP", Port: 8000, EndPort: 8100}},
+					},
+				},
+			}},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.Network.Egress.Rules = []EgressRule{
+		{},
+		{
+			To: []EgressPeer{
+				{CIDR: "10.0.0.0/8", PodSelector: map[string]string{"app": "db"}},
+				{CIDR: "postgres.internal"},
+				{CIDR: "10.0.0.0/16", Except: []string{"192.168.0.0/24"}},
+				{Except: []string{"10.0.0.0/24"}, PodSelector: map[string]string{"app": "db"}},
+				{},
+			},
+			Ports: []NetworkPort{{Protocol: "ICMP", Port: 70000}, {Port: 9000, EndPort: 8000}, {EndPort: 80}},
+		},
+	}
+	err := server.validate()
+	if err == nil {
+		t.Fatal("expected validation error for invalid egress rules")
+	}
+	for _, want := range []string{
+		"spec.network.egress.rules[0]: Required",
+		"spec.network.egress.rules[1].to[0].cidr: Forbidden",
+		"spec.network.egress.rules[1].to[1].cidr: Invalid",
+		"spec.network.egress.rules[1].to[2].except[0]",
+		"spec.network.egress.rules[1].to[3].except: Forbidden",
+		"spec.network.egress.rules[1].to[4]: Required",
+		"spec.network.egress.rules[1].ports[0].protocol",
+		"spec.network.egress.rules[1].ports[0].port",
+		"spec.network.egress.rules[1].ports[1].endPort",
+		"spec.network.egress.rules[1].ports[2].port: Required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestMCPServerValidateImageVerification(t *testing.T) {
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "server"},
+		Spec: MCPServerSpec{
+			Image:             "example.com/server",
+			PublicPathPrefix:  "server",
+			ImageVerification: &ImageVerificationConfig{RequireSignature: true},
+		},
+	}
+	if err := server.validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	server.Spec.ImageVerification.PublicKeysSecret = "cosign-keys"
+	err := server.validate()
+	if err == nil || !strings.Contains(err.Error(), "spec.imageVerification.publicKeysSecret: Forbidden") {
+		t.Fatalf("expected a server's own publicKeysSecret to be rejected, got %v", err)
+	}
+}
+
+func restrictedServerClass() *MCPServerClass {
+	maxReplicas := int32(3)
+	return &MCPServerClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "restricted",
+			Annotations: map[string]string{MCPServerClassDefaultAnnotation: "true"},
+		},
+		Spec: MCPServerClassSpec{
+			Defaults: &MCPServerClassDefaults{
+				Gateway:   &GatewayConfig{Enabled: true},
+				Resources: &ResourceRequirements{Limits: &ResourceList{CPU: "500m", Memory: "1Gi"}},
+			},
+			Constraints: &MCPServerClassConstraints{
+				RequireGateway:     true,
+				AllowedPolicyModes: []PolicyMode{PolicyModeAllowList},
+				MaxMemory:          "2Gi",
+				MaxReplicas:        &maxReplicas,
+				AllowedRegistries:  []string{"registry.example.com"},
+			},
+		},
+	}
+}
+
+func TestMCPServerClassDefaultsAndConstraints(t *testing.T) {
+	class := restrictedServerClass()
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec:       MCPServerSpec{Image: "registry.example.com/payments", PublicPathPrefix: "payments"},
+	}
+	effective := effectiveServerForClass(server, class, MCPServerDefaultOptions{})
+	if !gatewayEnabled(effective.Spec) || effective.Spec.Gateway.Port != defaultGatewayPort || effective.Spec.Resources.Limits.Memory != "1Gi" {
+		t.Fatalf("effective spec = %#v", effective.Spec)
+	}
+	if effective.Spec.Policy == nil || effective.Spec.Policy.Mode != PolicyModeAllowList {
+		t.Fatalf("policy = %#v, want the built-in allow-list default after the class gateway", effective.Spec.Policy)
+	}
+	if err := class.ValidateServer(effective); err != nil {
+		t.Fatalf("ValidateServer: %v", err)
+	}
+	if server.Spec.Gateway != nil {
+		t.Fatal("effectiveServerForClass must not modify the server")
+	}
+
+	replicas := int32(5)
+	server.Spec.Image = "ghcr.io/acme/payments"
+	server.Spec.Replicas = &replicas
+	server.Spec.Gateway = &GatewayConfig{Enabled: false}
+	server.Spec.Policy = &PolicyConfig{Mode: PolicyModeObserve}
+	server.Spec.Resources = ResourceRequirements{Limits: &ResourceList{Memory: "4Gi"}}
+	err := class.ValidateServer(effectiveServerForClass(server, class, MCPServerDefaultOptions{}))
+	if err == nil {
+		t.Fatal("expected class constraint errors")
+	}
+	for _, want := range []string{
+		"spec.gateway.enabled: Required value: MCPServerClass restricted requires the gateway",
+		"spec.policy.mode: Unsupported value: \"observe\"",
+		"spec.resources.limits.memory: Invalid value: \"4Gi\": MCPServerClass restricted allows at most 2Gi",
+		"spec.replicas: Invalid value: 5",
+		"does not allow registry ghcr.io",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestMCPServerWebhookAppliesClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	webhook := mcpServerWebhook{classes: fake.NewClientBuilder().WithScheme(scheme).WithObjects(restrictedServerClass()).Build()}
+	ctx := context.Background()
+
+	server := &MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec:       MCPServerSpec{Image: "registry.example.com/payments"},
+	}
+	if err := webhook.Default(ctx, server); err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if !gatewayEnabled(server.Spec) || server.Spec.Auth == nil {
+		t.Fatalf("defaulted spec = %#v, want the default class gateway", server.Spec)
+	}
+	if _, err := webhook.ValidateCreate(ctx, server); err != nil {
+		t.Fatalf("ValidateCreate: %v", err)
+	}
+
+	server.Spec.Image = "docker.io/acme/payments"
+	if _, err := webhook.ValidateUpdate(ctx, server, server); err == nil || !strings.Contains(err.Error(), "does not allow registry docker.io") {
+		t.Fatalf("ValidateUpdate = %v, want a registry violation", err)
+	}
+	server.Spec.ClassName = "missing"
+	if _, err := webhook.ValidateCreate(ctx, server); err == nil || !strings.Contains(err.Error(), "spec.className: Not found") {
+		t.Fatalf("ValidateCreate = %v, want a missing class error", err)
+	}
+}
+
+func TestMCPServerClassValidate(t *testing.T) {
+	class := restrictedServerClass()
+	if err := class.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	class.Spec.Constraints.MaxCPU = "two"
+	class.Spec.Constraints.AllowedAuthModes = []AuthMode{"kerberos"}
+	class.Spec.Constraints.AllowedRegistries = []string{"ghcr.io/acme"}
+	err := class.validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"spec.constraints.maxCPU", "spec.constraints.allowedAuthModes[0]", "spec.constraints.allowedRegistries[0]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestMCPPolicyBaselineValidate(t *testing.T) {
+	baseline := &MCPPolicyBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "guardrails"},
+		Spec: MCPPolicyBaselineSpec{Rules: []MCPPolicyBaselineRule{
+			{Name: "no-agent-destructive", Subject: BaselineSubjectAgentOnly, SideEffects: []ToolSideEffect{ToolSideEffectDestructive}, Effect: BaselineEffectDeny},
+			{Name: "pii-high-trust", ToolLabels: map[string]string{"pii": "true"}, Effect: BaselineEffectRequireTrust, RequiredTrust: TrustLevelHigh},
+		}},
+	}
+	if err := baseline.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	baseline.Spec.Rules[0].RequiredTrust = TrustLevelHigh
+	baseline.Spec.Rules[1].Name = "no-agent-destructive"
+	baseline.Spec.Rules[1].RequiredTrust = ""
+	err := baseline.validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"spec.rules[0].requiredTrust", "spec.rules[1].name", "spec.rules[1].requiredTrust"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestMCPFederationValidate(t *testing.T) {
+	federation := &MCPFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-tools"},
+		Spec: MCPFederationSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+		},
+	}
+	if err := federation.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	replicas := int32(0)
+	federation.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}}
+	federation.Spec.IngressPath = "federations/platform-tools/mcp"
+	federation.Spec.Replicas = &replicas
+	err := federation.validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"spec.selector", "spec.ingressPath", "spec.replicas"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestMCPToolApprovalValidate(t *testing.T) {
+	pending := &MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "refund-1"},
+		Spec: MCPToolApprovalSpec{
+			ServerRef: ServerReference{Name: "payments"},
+			Subject:   SubjectRef{HumanID: "alice"},
+			Tool:      "refund",
+			Arguments: `{"amount":10}`,
+		},
+	}
+	if err := pending.validate(nil); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	approved := pending.DeepCopy()
+	approved.Spec.Decision = ApprovalDecisionApproved
+	approved.Spec.DecidedBy = "bob"
+	if err := approved.validate(pending); err != nil {
+		t.Fatalf("deciding a pending approval: %v", err)
+	}
+
+	flipped := approved.DeepCopy()
+	flipped.Spec.Decision = ApprovalDecisionDenied
+	flipped.Spec.Arguments = `{"amount":10000}`
+	err := flipped.validate(approved)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"spec.decision", "only decision"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+
+	anonymous := pending.DeepCopy()
+	anonymous.Spec.Decision = ApprovalDecisionApproved
+	if err := anonymous.validate(pending); err == nil || !strings.Contains(err.Error(), "spec.decidedBy") {
+		t.Fatalf("decision without decidedBy: error = %v", err)
+	}
+
+	selfApproved := pending.DeepCopy()
+	selfApproved.Spec.Decision = ApprovalDecisionApproved
+	selfApproved.Spec.DecidedBy = "alice"
+	if err := selfApproved.validate(pending); err == nil || !strings.Contains(err.Error(), "their own tool calls") {
+		t.Fatalf("requester deciding their own call: error = %v", err)
+	}
+
+	if err := approved.validate(nil); err == nil || !strings.Contains(err.Error(), "created undecided") {
+		t.Fatalf("creating a decided approval: error = %v", err)
+	}
+}
+
+func TestMCPToolApprovalWebhookBindsCallers(t *testing.T) {
+	validator := mcpToolApprovalValidator{delegates: []string{"system:serviceaccount:mcp-sentinel:mcp-runtime-api"}}
+	as := func(username string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username},
+		}})
+	}
+	operator := "system:serviceaccount:mcp-runtime:mcp-runtime-operator-controller-manager"
+	pending := &MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "refund-1", Annotations: map[string]string{ApprovalRequestedByAnnotation: operator}},
+		Spec: MCPToolApprovalSpec{
+			ServerRef: ServerReference{Name: "payments"},
+			Subject:   SubjectRef{HumanID: "alice"},
+			Tool:      "refund",
+		},
+	}
+	if _, err := validator.ValidateCreate(as(operator), pending); err != nil {
+		t.Fatalf("operator creating its approval: %v", err)
+	}
+	if _, err := validator.ValidateCreate(as("mallory"), pending); err == nil || !strings.Contains(err.Error(), ApprovalRequestedByAnnotation) {
+		t.Fatalf("creating an approval in the operator's name: error = %v", err)
+	}
+	if _, err := validator.ValidateCreate(context.Background(), pending); err == nil {
+		t.Fatal("creating an approval without an admission request: error = nil")
+	}
+
+	decided := func(decidedBy string) *MCPToolApproval {
+		approval := pending.DeepCopy()
+		approval.Spec.Decision = ApprovalDecisionApproved
+		approval.Spec.DecidedBy = decidedBy
+		return approval
+	}
+	for _, tc := range []struct {
+		name      string
+		username  string
+		decidedBy string
+		wantErr   string
+	}{
+		{name: "approver under their own name", username: "bob", decidedBy: "bob"},
+		{name: "approver under another name", username: "bob", decidedBy: "carol", wantErr: "the user recording the decision"},
+		{name: "requester under another name", username: "alice", decidedBy: "carol", wantErr: "the user recording the decision"},
+		{name: "requester under their own name", username: "alice", decidedBy: "alice", wantErr: "their own tool calls"},
+		{name: "delegate for an approver", username: "system:serviceaccount:mcp-sentinel:mcp-runtime-api", decidedBy: "carol@example.com"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validator.ValidateUpdate(as(tc.username), pending, decided(tc.decidedBy))
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("ValidateUpdate() error = %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("ValidateUpdate() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	relabeled := pending.DeepCopy()
+	relabeled.Annotations[ApprovalRequestedByAnnotation] = "mallory"
+	if _, err := validator.ValidateUpdate(as("mallory"), pending, relabeled); err == nil || !strings.Contains(err.Error(), "requester cannot change") {
+		t.Fatalf("changing the requester: error = %v", err)
+	}
+}
+
+func TestRequiresApprovalOnlyOnToolRules(t *testing.T) {
+	grant := &MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-payments"},
+		Spec: MCPAccessGrantSpec{
+			ServerRef:     ServerReference{Name: "payments"},
+			Subject:       SubjectRef{HumanID: "alice"},
+			ToolRules:     []ToolRule{{Name: "refund", Decision: PolicyDecisionRequiresApproval}},
+			PromptRules:   []PromptRule{{Name: "summary", Decision: PolicyDecisionRequiresApproval}},
+			ResourceRules: []ResourceRule{{URI: "file:///*", Decision: PolicyDecisionRequiresApproval}},
+		},
+	}
+	err := grant.validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"spec.promptRules[0].decision", "spec.resourceRules[0].decision"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+	if strings.Contains(err.Error(), "spec.toolRules") {
+		t.Fatalf("error %q rejects the tool rule", err.Error())
+	}
+}
+
 func TestMCPServerDefault(t *testing.T) {
 	server := &MCPServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-server"},
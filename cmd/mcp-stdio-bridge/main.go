@@ -0,0 +1,94 @@
+// Command mcp-stdio-bridge serves a stdio-only MCP server over Streamable
+// HTTP. The operator copies it into MCPServer pods with transport: stdio and
+// runs it in place of the server command.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"mcp-runtime/internal/agentadapter"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "mcp-stdio-bridge:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "install" {
+		if len(args) != 2 {
+			return errors.New("usage: mcp-stdio-bridge install <path>")
+		}
+		return install(args[1])
+	}
+	cfg, err := parseConfig(args)
+	if err != nil {
+		return err
+	}
+	return agentadapter.RunStdioBridge(ctx, cfg)
+}
+
+// parseConfig reads flags up to "--"; everything after it is the stdio
+// server's command line.
+func parseConfig(args []string) (agentadapter.StdioBridgeConfig, error) {
+	cfg := agentadapter.StdioBridgeConfig{Stderr: os.Stderr}
+	flags := flag.NewFlagSet("mcp-stdio-bridge", flag.ContinueOnError)
+	flags.StringVar(&cfg.ListenAddr, "listen", ":8088", "Address to serve Streamable HTTP on")
+	flags.StringVar(&cfg.SessionMode, "session-mode", agentadapter.StdioSessionModePerSession, "per-session or shared")
+	flags.IntVar(&cfg.MaxSessions, "max-sessions", 0, "Maximum concurrent sessions in per-session mode (0 = no limit)")
+	flags.DurationVar(&cfg.IdleTimeout, "session-idle-timeout", agentadapter.DefaultStdioBridgeIdleTimeout, "End sessions idle for this long")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+	cfg.Command = flags.Args()
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("%w (usage: mcp-stdio-bridge [flags] -- command [args...])", err)
+	}
+	return cfg, nil
+}
+
+// install copies the running binary to path. The operator's init container
+// uses it to place the bridge on a volume shared with the server container,
+// which works in images without a shell or cp.
+func install(path string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(self)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, time.Now().UnixNano())
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
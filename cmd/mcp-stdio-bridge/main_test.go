@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mcp-runtime/internal/agentadapter"
+)
+
+func TestParseConfigSplitsCommand(t *testing.T) {
+	cfg, err := parseConfig([]string{"--listen", ":9000", "--session-mode", "shared", "--", "npx", "-y", "@modelcontextprotocol/server-everything"})
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if cfg.ListenAddr != ":9000" || cfg.SessionMode != agentadapter.StdioSessionModeShared {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+	if len(cfg.Command) != 3 || cfg.Command[0] != "npx" || cfg.Command[2] != "@modelcontextprotocol/server-everything" {
+		t.Fatalf("command = %q", cfg.Command)
+	}
+
+	if _, err := parseConfig([]string{"--listen", ":9000"}); err == nil {
+		t.Fatal("parseConfig() accepted a missing command")
+	}
+	if _, err := parseConfig([]string{"--session-mode", "pooled", "--", "server"}); err == nil {
+		t.Fatal("parseConfig() accepted an unknown session mode")
+	}
+}
+
+func TestInstallCopiesExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bin", "mcp-stdio-bridge")
+	if err := install(path); err != nil {
+		t.Fatalf("install() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat installed binary: %v", err)
+	}
+	if info.Mode().Perm()&0o111 == 0 || info.Size() == 0 {
+		t.Fatalf("installed binary mode = %v, size = %d", info.Mode(), info.Size())
+	}
+}
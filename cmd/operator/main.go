@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	_ "go.uber.org/automaxprocs" // align GOMAXPROCS with container CPU quota
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -19,6 +27,16 @@ import (
 	"mcp-runtime/internal/operator"
 )
 
+// eventRecorderName is the reporting controller on Events the operator emits.
+const eventRecorderName = "mcp-runtime-operator"
+
+// leaderElectionID names the leader election lease.
+const leaderElectionID = "mcp-runtime-operator.mcpruntime.org"
+
+// serviceAccountNamespaceFile holds the namespace the operator runs in, where
+// controller-runtime keeps the leader election lease.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -53,6 +71,10 @@ func main() {
 	if !ingressReadinessModeValid {
 		setupLog.Info("Invalid MCP_INGRESS_READINESS_MODE; defaulting to strict", "value", os.Getenv("MCP_INGRESS_READINESS_MODE"))
 	}
+	routingMode, routingModeValid := routingModeFromEnv(os.Getenv)
+	if !routingModeValid {
+		setupLog.Info("Invalid MCP_ROUTING_MODE; defaulting to ingress", "value", os.Getenv("MCP_ROUTING_MODE"))
+	}
 
 	if err = (&operator.MCPServerReconciler{
 		Client:                           mgr.GetClient(),
@@ -63,17 +85,88 @@ func main() {
 		DefaultIngressTLSSecret:          strings.TrimSpace(os.Getenv("MCP_DEFAULT_INGRESS_TLS_SECRET")),
 		DefaultIngressTLSSecretNamespace: strings.TrimSpace(os.Getenv("MCP_DEFAULT_INGRESS_TLS_SECRET_NAMESPACE")),
 		IngressReadinessMode:             ingressReadinessMode,
+		RoutingMode:                      routingMode,
+		GatewayAPIParent:                 operator.GatewayAPIParentFromEnv(os.Getenv),
+		GatewayAPIProxyNamespace:         strings.TrimSpace(os.Getenv("MCP_GATEWAY_API_PROXY_NAMESPACE")),
+		GatewayAPIProxyServiceAccount:    strings.TrimSpace(os.Getenv("MCP_GATEWAY_API_PROXY_SERVICE_ACCOUNT")),
 		ProvisionedRegistry:              registryConfig,
 		GatewayProxyImage:                gatewayProxyImageFromEnv(os.Getenv),
+		StdioBridgeImage:                 stdioBridgeImageFromEnv(os.Getenv),
 		GatewayOTLPEndpoint:              gatewayOTLPEndpointFromEnv(os.Getenv),
 		DefaultAnalyticsIngestURL:        analyticsIngestURLFromEnv(os.Getenv),
 		ClusterName:                      clusterNameFromEnv(os.Getenv),
 		MTLSClusterIssuer:                strings.TrimSpace(os.Getenv("MCP_MTLS_CLUSTER_ISSUER")),
+		Recorder:                         mgr.GetEventRecorder(eventRecorderName),
+		Metrics:                          prometheusMetricsFromEnv(os.Getenv),
+		Registry:                         imageRegistryFromEnv(os.Getenv),
+		ImageVerificationKeys:            imageVerificationKeysFromEnv(os.Getenv, operatorNamespace()),
+		ActivationURL:                    activationURLFromEnv(os.Getenv, cfg.activationAddr),
+		ApprovalURL:                      approvalURLFromEnv(os.Getenv, cfg.activationAddr),
+		SessionActivityURL:               sessionActivityURLFromEnv(os.Getenv, cfg.activationAddr),
+		RateLimitURL:                     rateLimitURLFromEnv(os.Getenv, cfg.activationAddr),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MCPServer")
 		os.Exit(1)
 	}
 
+	if err = (&operator.MCPAccessGrantReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         mgr.GetEventRecorder(eventRecorderName),
+		SharedRateLimits: rateLimitURLFromEnv(os.Getenv, cfg.activationAddr) != "",
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MCPAccessGrant")
+		os.Exit(1)
+	}
+	sessionRetention, sessionRetentionValid := sessionGCRetentionFromEnv(os.Getenv)
+	if !sessionRetentionValid {
+		setupLog.Info("Invalid MCP_SESSION_GC_RETENTION; expired sessions are kept", "value", os.Getenv("MCP_SESSION_GC_RETENTION"))
+	}
+	if err = (&operator.MCPAgentSessionReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                mgr.GetEventRecorder(eventRecorderName),
+		ExpiredSessionRetention: sessionRetention,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MCPAgentSession")
+		os.Exit(1)
+	}
+
+	if err = (&operator.MCPToolApprovalReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder(eventRecorderName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MCPToolApproval")
+		os.Exit(1)
+	}
+
+	if err = (&operator.MCPFederationReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GatewayProxyImage:  gatewayProxyImageFromEnv(os.Getenv),
+		DefaultIngressHost: os.Getenv("MCP_DEFAULT_INGRESS_HOST"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MCPFederation")
+		os.Exit(1)
+	}
+
+	inventoryInterval, inventoryIntervalValid := inventoryProbeIntervalFromEnv(os.Getenv)
+	if !inventoryIntervalValid {
+		setupLog.Info("Invalid MCP_INVENTORY_PROBE_INTERVAL; using the default", "value", os.Getenv("MCP_INVENTORY_PROBE_INTERVAL"), "default", operator.DefaultInventoryProbeInterval)
+	}
+	if inventoryInterval > 0 {
+		if err = (&operator.MCPInventoryReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorder(eventRecorderName),
+			Interval: inventoryInterval,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "MCPServerInventory")
+			os.Exit(1)
+		}
+	}
+
 	if webhooksEnabledFromEnv(os.Getenv) {
 		mcpServerWebhookOptions := mcpv1alpha1.MCPServerDefaultOptions{
 			DefaultIngressHost:        os.Getenv("MCP_DEFAULT_INGRESS_HOST"),
@@ -88,12 +181,39 @@ func main() {
 		}{
 			&mcpv1alpha1.MCPAccessGrant{},
 			&mcpv1alpha1.MCPAgentSession{},
+			&mcpv1alpha1.MCPServerClass{},
+			&mcpv1alpha1.MCPPolicyBaseline{},
+			&mcpv1alpha1.MCPFederation{},
 		} {
 			if err := resource.SetupWebhookWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create webhook")
 				os.Exit(1)
 			}
 		}
+		approvalWebhookOptions := mcpv1alpha1.MCPToolApprovalWebhookOptions{Delegates: approvalDelegatesFromEnv(os.Getenv)}
+		if err := (&mcpv1alpha1.MCPToolApproval{}).SetupWebhookWithManagerWithOptions(mgr, approvalWebhookOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.activationAddr != "0" {
+		identity, err := operatorIdentity(context.Background(), mgr.GetConfig(), os.Getenv)
+		if err != nil {
+			setupLog.Error(err, "unable to determine the operator's username for tool call approvals")
+			os.Exit(1)
+		}
+		activation := &operator.ActivationServer{Client: mgr.GetClient(), APIReader: mgr.GetAPIReader(), Addr: cfg.activationAddr, Identity: identity}
+		if cfg.enableLeaderElection {
+			// Rate limit counters live on the leader; the other replicas
+			// forward to it.
+			activation.Elected = mgr.Elected()
+			activation.LeaderLease = types.NamespacedName{Name: leaderElectionID, Namespace: operatorNamespace()}
+		}
+		if err := mgr.Add(activation); err != nil {
+			setupLog.Error(err, "unable to set up activation server")
+			os.Exit(1)
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -115,6 +235,7 @@ func main() {
 type operatorConfig struct {
 	metricsAddr          string
 	probeAddr            string
+	activationAddr       string
 	enableLeaderElection bool
 	zapOptions           zap.Options
 }
@@ -128,6 +249,7 @@ func parseConfig(fs *flag.FlagSet, args []string) (*operatorConfig, error) {
 
 	fs.StringVar(&cfg.metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	fs.StringVar(&cfg.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	fs.StringVar(&cfg.activationAddr, "activation-bind-address", ":8082", "The address the scale-to-zero activation, tool call approval, session activity, and rate limit endpoints bind to. Set to 0 to disable.")
 	fs.BoolVar(&cfg.enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
 	cfg.zapOptions.BindFlags(fs)
 
@@ -144,7 +266,7 @@ func newManagerOptions(cfg *operatorConfig) ctrl.Options {
 		Metrics:                server.Options{BindAddress: cfg.metricsAddr},
 		HealthProbeBindAddress: cfg.probeAddr,
 		LeaderElection:         cfg.enableLeaderElection,
-		LeaderElectionID:       "mcp-runtime-operator.mcpruntime.org",
+		LeaderElectionID:       leaderElectionID,
 	}
 }
 
@@ -166,10 +288,180 @@ func gatewayProxyImageFromEnv(getenv func(string) string) string {
 	return getenv("MCP_GATEWAY_PROXY_IMAGE")
 }
 
+func stdioBridgeImageFromEnv(getenv func(string) string) string {
+	return strings.TrimSpace(getenv("MCP_STDIO_BRIDGE_IMAGE"))
+}
+
 func gatewayOTLPEndpointFromEnv(getenv func(string) string) string {
 	return getenv("MCP_GATEWAY_OTEL_EXPORTER_OTLP_ENDPOINT")
 }
 
+// prometheusMetricsFromEnv returns the Prometheus source for canary analysis
+// and scale-to-zero, or nil when MCP_PROMETHEUS_URL is unset.
+func prometheusMetricsFromEnv(getenv func(string) string) operator.MetricsSource {
+	prometheusURL := strings.TrimSpace(getenv("MCP_PROMETHEUS_URL"))
+	if prometheusURL == "" {
+		return nil
+	}
+	return &operator.PrometheusMetrics{URL: prometheusURL}
+}
+
+// imageRegistryFromEnv returns the registry client of image verification.
+// The in-cluster registry (MCP_REGISTRY_ENDPOINT) and its pull host
+// (MCP_REGISTRY_PULL_HOST) are reached over plain HTTP at the endpoint, as
+// are the hosts in the comma-separated MCP_INSECURE_REGISTRIES.
+func imageRegistryFromEnv(getenv func(string) string) *operator.OCIRegistry {
+	endpoint := strings.TrimSpace(getenv("MCP_REGISTRY_ENDPOINT"))
+	if endpoint == "" {
+		endpoint = "registry.registry.svc.cluster.local:5000"
+	}
+	pullHost := strings.TrimSpace(getenv("MCP_REGISTRY_PULL_HOST"))
+	if pullHost == "" {
+		pullHost = endpoint
+	}
+	registry := &operator.OCIRegistry{InsecureHosts: []string{endpoint}}
+	if pullHost != endpoint {
+		registry.InsecureHosts = append(registry.InsecureHosts, pullHost)
+		registry.Endpoints = map[string]string{pullHost: endpoint}
+	}
+	for _, host := range strings.Split(getenv("MCP_INSECURE_REGISTRIES"), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			registry.InsecureHosts = append(registry.InsecureHosts, host)
+		}
+	}
+	return registry
+}
+
+// defaultImageVerificationKeysSecret is the Secret in the operator's namespace
+// holding the public keys trusted for image signatures.
+const defaultImageVerificationKeysSecret = "mcp-runtime-image-verification-keys"
+
+// imageVerificationKeysFromEnv returns the Secret whose public keys image
+// signatures are verified against: MCP_IMAGE_VERIFICATION_KEYS_SECRET as
+// namespace/name, or a name in the operator's namespace.
+func imageVerificationKeysFromEnv(getenv func(string) string, namespace string) types.NamespacedName {
+	value := strings.TrimSpace(getenv("MCP_IMAGE_VERIFICATION_KEYS_SECRET"))
+	if value == "" {
+		value = defaultImageVerificationKeysSecret
+	}
+	if secretNamespace, name, found := strings.Cut(value, "/"); found {
+		return types.NamespacedName{Name: strings.TrimSpace(name), Namespace: strings.TrimSpace(secretNamespace)}
+	}
+	return types.NamespacedName{Name: value, Namespace: namespace}
+}
+
+// activationURLFromEnv returns the URL scale-to-zero activators call to wake a
+// server. MCP_ACTIVATION_URL overrides the in-cluster Service default; the URL
+// is empty when the activation endpoint is disabled, which turns scale-to-zero
+// off.
+func activationURLFromEnv(getenv func(string) string, activationAddr string) string {
+	if activationAddr == "0" {
+		return ""
+	}
+	if value := strings.TrimSpace(getenv("MCP_ACTIVATION_URL")); value != "" {
+		return value
+	}
+	return "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082" + operator.ActivationPath
+}
+
+// approvalURLFromEnv returns the URL gateways call to request and poll tool
+// call approvals. MCP_APPROVAL_URL overrides the in-cluster Service default;
+// the URL is empty when the activation endpoint, which also serves approvals,
+// is disabled.
+func approvalURLFromEnv(getenv func(string) string, activationAddr string) string {
+	if activationAddr == "0" {
+		return ""
+	}
+	if value := strings.TrimSpace(getenv("MCP_APPROVAL_URL")); value != "" {
+		return value
+	}
+	return "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082" + operator.ApprovalPath
+}
+
+// sessionActivityURLFromEnv returns the URL gateways share agent session
+// activity through. MCP_SESSION_ACTIVITY_URL overrides the in-cluster Service
+// default; the URL is empty when the activation endpoint is disabled.
+func sessionActivityURLFromEnv(getenv func(string) string, activationAddr string) string {
+	if activationAddr == "0" {
+		return ""
+	}
+	if value := strings.TrimSpace(getenv("MCP_SESSION_ACTIVITY_URL")); value != "" {
+		return value
+	}
+	return "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082" + operator.SessionActivityPath
+}
+
+// rateLimitURLFromEnv returns the URL gateways share MCPAccessGrant rate limit
+// counters through. MCP_RATE_LIMIT_URL overrides the in-cluster Service
+// default; the URL is empty when the activation endpoint is disabled.
+func rateLimitURLFromEnv(getenv func(string) string, activationAddr string) string {
+	if activationAddr == "0" {
+		return ""
+	}
+	if value := strings.TrimSpace(getenv("MCP_RATE_LIMIT_URL")); value != "" {
+		return value
+	}
+	return "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082" + operator.RateLimitPath
+}
+
+// operatorNamespace returns the namespace the operator runs in, or
+// mcp-runtime outside a cluster.
+func operatorNamespace() string {
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(data)); namespace != "" {
+			return namespace
+		}
+	}
+	return "mcp-runtime"
+}
+
+// defaultApprovalDelegate is the runtime API's service account, which records
+// approval decisions for the platform users it authenticates.
+const defaultApprovalDelegate = "system:serviceaccount:mcp-sentinel:mcp-runtime-api"
+
+// approvalDelegatesFromEnv returns the usernames the approval webhook lets
+// record decisions for other approvers. MCP_APPROVAL_DELEGATES is a
+// comma-separated list that replaces the runtime API default; "none" allows
+// no delegates.
+func approvalDelegatesFromEnv(getenv func(string) string) []string {
+	value := strings.TrimSpace(getenv("MCP_APPROVAL_DELEGATES"))
+	switch value {
+	case "":
+		return []string{defaultApprovalDelegate}
+	case "none":
+		return nil
+	}
+	var delegates []string
+	for _, delegate := range strings.Split(value, ",") {
+		if delegate = strings.TrimSpace(delegate); delegate != "" {
+			delegates = append(delegates, delegate)
+		}
+	}
+	return delegates
+}
+
+// operatorIdentity returns the username the operator authenticates to the
+// API server as, which the approval webhook records as the creator of the
+// approvals the operator requests. MCP_OPERATOR_USERNAME overrides the
+// SelfSubjectReview lookup.
+func operatorIdentity(ctx context.Context, config *rest.Config, getenv func(string) string) (string, error) {
+	if value := strings.TrimSpace(getenv("MCP_OPERATOR_USERNAME")); value != "" {
+		return value, nil
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	review, err := clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	if review.Status.UserInfo.Username == "" {
+		return "", errors.New("the API server reported no username")
+	}
+	return review.Status.UserInfo.Username, nil
+}
+
 func analyticsIngestURLFromEnv(getenv func(string) string) string {
 	if value := getenv("MCP_SENTINEL_INGEST_URL"); value != "" {
 		return value
@@ -188,6 +480,44 @@ func ingressReadinessModeFromEnv(getenv func(string) string) (string, bool) {
 	return operator.NormalizeIngressReadinessMode(getenv("MCP_INGRESS_READINESS_MODE"))
 }
 
+func routingModeFromEnv(getenv func(string) string) (string, bool) {
+	return operator.NormalizeRoutingMode(getenv("MCP_ROUTING_MODE"))
+}
+
+// sessionGCRetentionFromEnv parses MCP_SESSION_GC_RETENTION, how long an
+// expired MCPAgentSession is kept before the operator deletes it. Unset, zero,
+// or invalid values disable the cleanup; the bool is false for invalid values.
+func sessionGCRetentionFromEnv(getenv func(string) string) (time.Duration, bool) {
+	value := strings.TrimSpace(getenv("MCP_SESSION_GC_RETENTION"))
+	if value == "" {
+		return 0, true
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil || retention < 0 {
+		return 0, false
+	}
+	return retention, true
+}
+
+// inventoryProbeIntervalFromEnv parses MCP_INVENTORY_PROBE_INTERVAL, how
+// often Ready servers are probed for tool inventory drift. Unset or invalid
+// values use the default; "0" turns the probe off. The bool is false for
+// invalid values.
+func inventoryProbeIntervalFromEnv(getenv func(string) string) (time.Duration, bool) {
+	value := strings.TrimSpace(getenv("MCP_INVENTORY_PROBE_INTERVAL"))
+	if value == "" {
+		return operator.DefaultInventoryProbeInterval, true
+	}
+	if value == "0" {
+		return 0, true
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return operator.DefaultInventoryProbeInterval, false
+	}
+	return interval, true
+}
+
 func webhooksEnabledFromEnv(getenv func(string) string) bool {
 	value := getenv("MCP_ENABLE_WEBHOOKS")
 	return value == "true" || value == "1"
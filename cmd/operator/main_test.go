@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"mcp-runtime/internal/operator"
 )
@@ -81,6 +85,134 @@ func TestGatewayOTLPEndpointFromEnv(t *testing.T) {
 	})
 }
 
+func TestPrometheusMetricsFromEnv(t *testing.T) {
+	if got := prometheusMetricsFromEnv(func(string) string { return "" }); got != nil {
+		t.Fatalf("expected no metrics source when unset, got %#v", got)
+	}
+
+	env := map[string]string{"MCP_PROMETHEUS_URL": " http://prometheus.monitoring:9090 "}
+	got, ok := prometheusMetricsFromEnv(func(key string) string { return env[key] }).(*operator.PrometheusMetrics)
+	if !ok || got.URL != "http://prometheus.monitoring:9090" {
+		t.Fatalf("unexpected metrics source: %#v", got)
+	}
+}
+
+func TestImageRegistryFromEnv(t *testing.T) {
+	defaults := imageRegistryFromEnv(func(string) string { return "" })
+	if len(defaults.InsecureHosts) != 1 || defaults.InsecureHosts[0] != "registry.registry.svc.cluster.local:5000" || defaults.Endpoints != nil {
+		t.Fatalf("default registry = %#v", defaults)
+	}
+
+	env := map[string]string{
+		"MCP_REGISTRY_ENDPOINT":   "registry.registry.svc:5000",
+		"MCP_REGISTRY_PULL_HOST":  "localhost:32000",
+		"MCP_INSECURE_REGISTRIES": " harbor.lab:8080, ,",
+	}
+	got := imageRegistryFromEnv(func(key string) string { return env[key] })
+	if strings.Join(got.InsecureHosts, ",") != "registry.registry.svc:5000,localhost:32000,harbor.lab:8080" {
+		t.Fatalf("insecure hosts = %v", got.InsecureHosts)
+	}
+	if got.Endpoints["localhost:32000"] != "registry.registry.svc:5000" {
+		t.Fatalf("endpoints = %v", got.Endpoints)
+	}
+}
+
+func TestImageVerificationKeysFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: "mcp-runtime/mcp-runtime-image-verification-keys"},
+		{value: " cosign-keys ", want: "mcp-runtime/cosign-keys"},
+		{value: "security/cosign-keys", want: "security/cosign-keys"},
+	} {
+		got := imageVerificationKeysFromEnv(func(string) string { return tc.value }, "mcp-runtime")
+		if got.String() != tc.want {
+			t.Fatalf("imageVerificationKeysFromEnv(%q) = %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestActivationURLFromEnv(t *testing.T) {
+	empty := func(string) string { return "" }
+	if got := activationURLFromEnv(empty, ":8082"); got != "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082/activate" {
+		t.Fatalf("unexpected default activation URL: %q", got)
+	}
+	if got := activationURLFromEnv(empty, "0"); got != "" {
+		t.Fatalf("expected no activation URL when disabled, got %q", got)
+	}
+	env := map[string]string{"MCP_ACTIVATION_URL": " http://activator.example:9000/activate "}
+	if got := activationURLFromEnv(func(key string) string { return env[key] }, ":8082"); got != "http://activator.example:9000/activate" {
+		t.Fatalf("unexpected activation URL override: %q", got)
+	}
+}
+
+func TestApprovalURLFromEnv(t *testing.T) {
+	empty := func(string) string { return "" }
+	if got := approvalURLFromEnv(empty, ":8082"); got != "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082/approvals" {
+		t.Fatalf("unexpected default approval URL: %q", got)
+	}
+	if got := approvalURLFromEnv(empty, "0"); got != "" {
+		t.Fatalf("expected no approval URL when disabled, got %q", got)
+	}
+	env := map[string]string{"MCP_APPROVAL_URL": " http://approvals.example:9000/approvals "}
+	if got := approvalURLFromEnv(func(key string) string { return env[key] }, ":8082"); got != "http://approvals.example:9000/approvals" {
+		t.Fatalf("unexpected approval URL override: %q", got)
+	}
+}
+
+func TestSessionActivityURLFromEnv(t *testing.T) {
+	empty := func(string) string { return "" }
+	if got := sessionActivityURLFromEnv(empty, ":8082"); got != "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082/session-activity" {
+		t.Fatalf("unexpected default session activity URL: %q", got)
+	}
+	if got := sessionActivityURLFromEnv(empty, "0"); got != "" {
+		t.Fatalf("expected no session activity URL when disabled, got %q", got)
+	}
+	env := map[string]string{"MCP_SESSION_ACTIVITY_URL": " http://sessions.example:9000/session-activity "}
+	if got := sessionActivityURLFromEnv(func(key string) string { return env[key] }, ":8082"); got != "http://sessions.example:9000/session-activity" {
+		t.Fatalf("unexpected session activity URL override: %q", got)
+	}
+}
+
+func TestRateLimitURLFromEnv(t *testing.T) {
+	empty := func(string) string { return "" }
+	if got := rateLimitURLFromEnv(empty, ":8082"); got != "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082/rate-limits" {
+		t.Fatalf("unexpected default rate limit URL: %q", got)
+	}
+	if got := rateLimitURLFromEnv(empty, "0"); got != "" {
+		t.Fatalf("expected no rate limit URL when disabled, got %q", got)
+	}
+	env := map[string]string{"MCP_RATE_LIMIT_URL": " http://limits.example:9000/rate-limits "}
+	if got := rateLimitURLFromEnv(func(key string) string { return env[key] }, ":8082"); got != "http://limits.example:9000/rate-limits" {
+		t.Fatalf("unexpected rate limit URL override: %q", got)
+	}
+}
+
+func TestApprovalDelegatesFromEnv(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: "[system:serviceaccount:mcp-sentinel:mcp-runtime-api]"},
+		{value: "none", want: "[]"},
+		{value: " system:serviceaccount:a:api, ,bob ", want: "[system:serviceaccount:a:api bob]"},
+	} {
+		got := approvalDelegatesFromEnv(func(string) string { return tc.value })
+		if fmt.Sprint(got) != tc.want {
+			t.Fatalf("approvalDelegatesFromEnv(%q) = %v, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestOperatorIdentityOverride(t *testing.T) {
+	env := map[string]string{"MCP_OPERATOR_USERNAME": " system:serviceaccount:ops:operator "}
+	got, err := operatorIdentity(context.Background(), nil, func(key string) string { return env[key] })
+	if err != nil || got != "system:serviceaccount:ops:operator" {
+		t.Fatalf("operatorIdentity() = %q, %v", got, err)
+	}
+}
+
 func TestAnalyticsIngestURLFromEnv(t *testing.T) {
 	t.Run("returns empty when unset", func(t *testing.T) {
 		getenv := func(string) string { return "" }
@@ -142,6 +274,60 @@ func TestIngressReadinessModeFromEnv(t *testing.T) {
 	})
 }
 
+func TestRoutingModeFromEnv(t *testing.T) {
+	for value, want := range map[string]struct {
+		mode  string
+		valid bool
+	}{
+		"":            {"ingress", true},
+		"gateway-api": {"gateway-api", true},
+		"mesh":        {"ingress", false},
+	} {
+		getenv := func(string) string { return value }
+		got, valid := routingModeFromEnv(getenv)
+		if got != want.mode || valid != want.valid {
+			t.Fatalf("routingModeFromEnv(%q) = %q, %v; want %q, %v", value, got, valid, want.mode, want.valid)
+		}
+	}
+}
+
+func TestSessionGCRetentionFromEnv(t *testing.T) {
+	for value, want := range map[string]struct {
+		retention time.Duration
+		valid     bool
+	}{
+		"":     {0, true},
+		"168h": {168 * time.Hour, true},
+		"-1h":  {0, false},
+		"week": {0, false},
+	} {
+		getenv := func(string) string { return value }
+		got, valid := sessionGCRetentionFromEnv(getenv)
+		if got != want.retention || valid != want.valid {
+			t.Fatalf("sessionGCRetentionFromEnv(%q) = %v, %v; want %v, %v", value, got, valid, want.retention, want.valid)
+		}
+	}
+}
+
+func TestInventoryProbeIntervalFromEnv(t *testing.T) {
+	for value, want := range map[string]struct {
+		interval time.Duration
+		valid    bool
+	}{
+		"":      {operator.DefaultInventoryProbeInterval, true},
+		"0":     {0, true},
+		"90s":   {90 * time.Second, true},
+		"-1m":   {operator.DefaultInventoryProbeInterval, false},
+		"often": {operator.DefaultInventoryProbeInterval, false},
+	} {
+		getenv := func(string) string { return value }
+		got, valid := inventoryProbeIntervalFromEnv(getenv)
+		if got != want.interval || valid != want.valid {
+			t.Fatalf("inventoryProbeIntervalFromEnv(%q) = %v, %v; want %v, %v", value, got, valid, want.interval, want.valid)
+		}
+	}
+}
+
 func TestBoolFromEnv(t *testing.T) {
 	if !boolFromEnv(" true ") {
 		t.Fatal("expected true value")
@@ -167,6 +353,9 @@ func TestParseConfig(t *testing.T) {
 		if cfg.probeAddr != ":8081" {
 			t.Fatalf("unexpected probeAddr: %q", cfg.probeAddr)
 		}
+		if cfg.activationAddr != ":8082" {
+			t.Fatalf("unexpected activationAddr: %q", cfg.activationAddr)
+		}
 		if cfg.enableLeaderElection {
 			t.Fatalf("expected leader election disabled by default")
 		}
@@ -182,6 +371,7 @@ func TestParseConfig(t *testing.T) {
 		args := []string{
 			"--metrics-bind-address=localhost:9090",
 			"--health-probe-bind-address=localhost:9091",
+			"--activation-bind-address=0",
 			"--leader-elect",
 		}
 		cfg, err := parseConfig(fs, args)
@@ -195,6 +385,9 @@ func TestParseConfig(t *testing.T) {
 		if cfg.probeAddr != "localhost:9091" {
 			t.Fatalf("unexpected probeAddr: %q", cfg.probeAddr)
 		}
+		if cfg.activationAddr != "0" {
+			t.Fatalf("unexpected activationAddr: %q", cfg.activationAddr)
+		}
 		if !cfg.enableLeaderElection {
 			t.Fatalf("expected leader election enabled")
 		}
@@ -1,3 +1,5 @@
 // Package agentadapter implements optional agent-side HTTP and stdio adapters
-// that forward MCP traffic to governed MCP Runtime routes.
+// that forward MCP traffic to governed MCP Runtime routes, and the
+// server-side stdio bridge that serves stdio-only MCP servers over Streamable
+// HTTP.
 package agentadapter
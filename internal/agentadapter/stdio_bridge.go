@@ -0,0 +1,860 @@
+package agentadapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stdio bridge session modes.
+const (
+	// StdioSessionModePerSession spawns one child process per MCP session.
+	StdioSessionModePerSession = "per-session"
+	// StdioSessionModeShared multiplexes every MCP session onto one child.
+	StdioSessionModeShared = "shared"
+
+	DefaultStdioBridgeIdleTimeout = 30 * time.Minute
+
+	stdioBridgeStreamBuffer    = 64
+	stdioBridgeProcessGrace    = 5 * time.Second
+	stdioBridgeShutdownTimeout = 10 * time.Second
+)
+
+var errStdioProcessExited = errors.New("stdio server exited")
+
+// StdioBridgeConfig configures the server-side bridge that exposes a stdio MCP
+// server over Streamable HTTP.
+type StdioBridgeConfig struct {
+	// Command is the argv of the stdio MCP server.
+	Command []string
+	// SessionMode is per-session (default) or shared.
+	SessionMode string
+	// MaxSessions caps concurrent sessions in per-session mode. Zero means no cap.
+	MaxSessions int
+	// IdleTimeout ends sessions that sent no request for this long.
+	IdleTimeout time.Duration
+	// ListenAddr is the address RunStdioBridge listens on.
+	ListenAddr string
+	// Stderr receives the child processes' stderr. Defaults to io.Discard.
+	Stderr io.Writer
+}
+
+// Validate checks the bridge configuration and fills in defaults.
+func (c *StdioBridgeConfig) Validate() error {
+	if len(c.Command) == 0 || strings.TrimSpace(c.Command[0]) == "" {
+		return errors.New("stdio bridge command is required")
+	}
+	switch c.SessionMode {
+	case "":
+		c.SessionMode = StdioSessionModePerSession
+	case StdioSessionModePerSession, StdioSessionModeShared:
+	default:
+		return fmt.Errorf("invalid stdio bridge session mode %q (want %s or %s)", c.SessionMode, StdioSessionModePerSession, StdioSessionModeShared)
+	}
+	if c.MaxSessions < 0 {
+		return fmt.Errorf("stdio bridge max sessions must not be negative, got %d", c.MaxSessions)
+	}
+	if c.IdleTimeout <= 0 {
+		c.IdleTimeout = DefaultStdioBridgeIdleTimeout
+	}
+	if c.Stderr == nil {
+		c.Stderr = io.Discard
+	}
+	return nil
+}
+
+// StdioBridge serves Streamable HTTP MCP and forwards each session to a stdio
+// child process. It is the reverse of the stdio shim: JSON-RPC messages are
+// read from HTTP and written to the child's stdin as newline-delimited JSON,
+// and the child's stdout is returned as JSON or SSE responses.
+type StdioBridge struct {
+	cfg StdioBridgeConfig
+
+	mu       sync.Mutex
+	sessions map[string]*bridgeSession
+	shared   *stdioProcess
+	closed   bool
+
+	stopJanitor chan struct{}
+	janitorDone chan struct{}
+}
+
+// bridgeSession is one Mcp-Session-Id. In shared mode every session points at
+// the same process.
+type bridgeSession struct {
+	id       string
+	proc     *stdioProcess
+	lastUsed time.Time
+	done     chan struct{}
+
+	streamsMu sync.Mutex
+	get       chan []byte
+	posts     []chan []byte
+}
+
+// stdioProcess owns one child process. Requests are written with
+// bridge-assigned IDs so concurrent sessions can reuse their own IDs.
+type stdioProcess struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[string]chan []byte
+	done    chan struct{}
+
+	// Shared mode only: the child is initialized once and later initialize
+	// requests are answered from the cached result.
+	initMu      sync.Mutex
+	initResult  json.RawMessage
+	initialized bool
+}
+
+// NewStdioBridge validates cfg and returns a bridge. Child processes start
+// lazily on initialize. Call Close to stop them.
+func NewStdioBridge(cfg StdioBridgeConfig) (*StdioBridge, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	bridge := &StdioBridge{
+		cfg:         cfg,
+		sessions:    make(map[string]*bridgeSession),
+		stopJanitor: make(chan struct{}),
+		janitorDone: make(chan struct{}),
+	}
+	go bridge.runJanitor()
+	return bridge, nil
+}
+
+// RunStdioBridge serves the bridge on cfg.ListenAddr until the context is
+// cancelled, then stops every child process.
+func RunStdioBridge(ctx context.Context, cfg StdioBridgeConfig) error {
+	bridge, err := NewStdioBridge(cfg)
+	if err != nil {
+		return err
+	}
+	defer bridge.Close()
+	server := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           bridge,
+		ReadHeaderTimeout: proxyReadHeaderTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), stdioBridgeShutdownTimeout)
+		defer cancel()
+		// Open SSE streams never drain on their own; closing the sessions
+		// first ends them so Shutdown can return.
+		bridge.Close()
+		if sErr := server.Shutdown(shutdownCtx); sErr != nil {
+			_ = server.Close()
+		}
+		svrErr := <-errCh
+		if errors.Is(svrErr, http.ErrServerClosed) {
+			return nil
+		}
+		return svrErr
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// Close ends every session and stops every child process.
+func (b *StdioBridge) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	sessions := b.sessions
+	b.sessions = make(map[string]*bridgeSession)
+	shared := b.shared
+	b.shared = nil
+	b.mu.Unlock()
+
+	close(b.stopJanitor)
+	<-b.janitorDone
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		close(session.done)
+		if session.proc != shared {
+			wg.Add(1)
+			go func(proc *stdioProcess) {
+				defer wg.Done()
+				proc.stop()
+			}(session.proc)
+		}
+	}
+	if shared != nil {
+		shared.stop()
+	}
+	wg.Wait()
+}
+
+// ServeHTTP implements the Streamable HTTP transport: POST carries client
+// messages, GET opens a stream for server-initiated messages, and DELETE ends
+// the session.
+func (b *StdioBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		b.handlePost(w, r)
+	case http.MethodGet:
+		b.handleGet(w, r)
+	case http.MethodDelete:
+		b.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *StdioBridge) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxStdioMessageBytes+1))
+	if err != nil {
+		http.Error(w, "read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxStdioMessageBytes {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+	var messages []map[string]json.RawMessage
+	if batch {
+		err = json.Unmarshal(body, &messages)
+	} else {
+		var message map[string]json.RawMessage
+		err = json.Unmarshal(body, &message)
+		messages = append(messages, message)
+	}
+	if err != nil || len(messages) == 0 {
+		detail := "empty batch"
+		if err != nil {
+			detail = err.Error()
+		}
+		writeBridgeJSON(w, http.StatusBadRequest, jsonRPCParseError(detail))
+		return
+	}
+
+	sessionID := strings.TrimSpace(r.Header.Get(MCPSessionHeader))
+	if sessionID == "" {
+		if batch || rpcMessageMethod(messages[0]) != "initialize" {
+			http.Error(w, "missing "+MCPSessionHeader+" header", http.StatusBadRequest)
+			return
+		}
+		b.handleInitialize(w, r, messages[0])
+		return
+	}
+	session, ok := b.touchSession(sessionID)
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	if !batch {
+		message := messages[0]
+		if !isRPCRequest(message) {
+			if err := b.send(session, message); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if acceptsEventStream(r) {
+			b.streamRequest(w, r, session, message)
+			return
+		}
+		writeBridgeJSON(w, http.StatusOK, b.request(r.Context(), session, message))
+		return
+	}
+
+	var responses []json.RawMessage
+	for _, message := range messages {
+		if !isRPCRequest(message) {
+			if err := b.send(session, message); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			continue
+		}
+		responses = append(responses, b.request(r.Context(), session, message))
+	}
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	encoded, err := json.Marshal(responses)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeBridgeJSON(w, http.StatusOK, encoded)
+}
+
+// handleInitialize starts a session. In per-session mode the session gets its
+// own child process, which is stopped again if initialize fails.
+func (b *StdioBridge) handleInitialize(w http.ResponseWriter, r *http.Request, message map[string]json.RawMessage) {
+	session, status, err := b.newSession()
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	response := b.request(r.Context(), session, message)
+	if looksLikeJSONRPCError(response) {
+		b.endSession(session.id)
+	} else {
+		w.Header().Set(MCPSessionHeader, session.id)
+	}
+	writeBridgeJSON(w, http.StatusOK, response)
+}
+
+// streamRequest answers one request as an SSE stream. Server-initiated
+// messages that arrive while the request is pending are written to the same
+// stream when the session has no GET stream open.
+func (b *StdioBridge) streamRequest(w http.ResponseWriter, r *http.Request, session *bridgeSession, message map[string]json.RawMessage) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeBridgeJSON(w, http.StatusOK, b.request(r.Context(), session, message))
+		return
+	}
+	stream := session.addPostStream()
+	defer session.removePostStream(stream)
+
+	result := make(chan []byte, 1)
+	go func() {
+		result <- b.request(r.Context(), session, message)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case event := <-stream:
+			if writeEvent(w, event) != nil {
+				return
+			}
+			flusher.Flush()
+		case response := <-result:
+			_ = writeEvent(w, response)
+			flusher.Flush()
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (b *StdioBridge) handleGet(w http.ResponseWriter, r *http.Request) {
+	if !acceptsEventStream(r) {
+		http.Error(w, "GET requires Accept: text/event-stream", http.StatusNotAcceptable)
+		return
+	}
+	sessionID := strings.TrimSpace(r.Header.Get(MCPSessionHeader))
+	if sessionID == "" {
+		http.Error(w, "missing "+MCPSessionHeader+" header", http.StatusBadRequest)
+		return
+	}
+	session, ok := b.touchSession(sessionID)
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	stream, ok := session.openGetStream()
+	if !ok {
+		http.Error(w, "session already has a GET stream", http.StatusConflict)
+		return
+	}
+	defer func() {
+		session.closeGetStream(stream)
+		b.touchSession(sessionID)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case event := <-stream:
+			if writeEvent(w, event) != nil {
+				return
+			}
+			flusher.Flush()
+		case <-session.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (b *StdioBridge) handleDelete(w http.ResponseWriter, r *http.Request) {
+	sessionID := strings.TrimSpace(r.Header.Get(MCPSessionHeader))
+	if sessionID == "" {
+		http.Error(w, "missing "+MCPSessionHeader+" header", http.StatusBadRequest)
+		return
+	}
+	if !b.endSession(sessionID) {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// request forwards one JSON-RPC request and always returns a JSON-RPC
+// response, turning process failures into error responses.
+func (b *StdioBridge) request(ctx context.Context, session *bridgeSession, message map[string]json.RawMessage) []byte {
+	id := message["id"]
+	var (
+		response []byte
+		err      error
+	)
+	if b.cfg.SessionMode == StdioSessionModeShared && rpcMessageMethod(message) == "initialize" {
+		response, err = session.proc.initialize(ctx, message)
+	} else {
+		response, err = session.proc.request(ctx, message)
+	}
+	if err != nil {
+		return jsonRPCHTTPError(id, http.StatusBadGateway, err.Error(), nil)
+	}
+	return response
+}
+
+// send forwards a notification or a response to a server-initiated request.
+// In shared mode only the first notifications/initialized reaches the child.
+func (b *StdioBridge) send(session *bridgeSession, message map[string]json.RawMessage) error {
+	if b.cfg.SessionMode == StdioSessionModeShared && rpcMessageMethod(message) == "notifications/initialized" && !session.proc.markInitialized() {
+		return nil
+	}
+	return session.proc.write(message)
+}
+
+func (b *StdioBridge) newSession() (*bridgeSession, int, error) {
+	id, err := newBridgeSessionID()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	session := &bridgeSession{id: id, lastUsed: time.Now(), done: make(chan struct{})}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, http.StatusServiceUnavailable, errors.New("bridge is shutting down")
+	}
+	if b.cfg.SessionMode == StdioSessionModeShared {
+		if b.shared == nil {
+			proc, err := b.startProcess(func(message []byte) { b.broadcast(message) })
+			if err != nil {
+				return nil, http.StatusBadGateway, err
+			}
+			b.shared = proc
+			go b.watchShared(proc)
+		}
+		session.proc = b.shared
+	} else {
+		if b.cfg.MaxSessions > 0 && len(b.sessions) >= b.cfg.MaxSessions {
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("session limit of %d reached", b.cfg.MaxSessions)
+		}
+		proc, err := b.startProcess(session.deliver)
+		if err != nil {
+			return nil, http.StatusBadGateway, err
+		}
+		session.proc = proc
+		go func() {
+			<-proc.done
+			b.endSession(id)
+		}()
+	}
+	b.sessions[id] = session
+	return session, 0, nil
+}
+
+func (b *StdioBridge) startProcess(onMessage func([]byte)) (*stdioProcess, error) {
+	cmd := exec.Command(b.cfg.Command[0], b.cfg.Command[1:]...)
+	cmd.Stderr = b.cfg.Stderr
+	return startStdioProcess(cmd, onMessage)
+}
+
+// watchShared drops every session when the shared child exits, so clients
+// re-initialize against a fresh process.
+func (b *StdioBridge) watchShared(proc *stdioProcess) {
+	<-proc.done
+	b.mu.Lock()
+	if b.shared != proc {
+		b.mu.Unlock()
+		return
+	}
+	b.shared = nil
+	sessions := b.sessions
+	b.sessions = make(map[string]*bridgeSession)
+	b.mu.Unlock()
+	for _, session := range sessions {
+		close(session.done)
+	}
+}
+
+func (b *StdioBridge) broadcast(message []byte) {
+	b.mu.Lock()
+	sessions := make([]*bridgeSession, 0, len(b.sessions))
+	for _, session := range b.sessions {
+		sessions = append(sessions, session)
+	}
+	b.mu.Unlock()
+	for _, session := range sessions {
+		session.deliver(message)
+	}
+}
+
+func (b *StdioBridge) touchSession(id string) (*bridgeSession, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	session, ok := b.sessions[id]
+	if ok {
+		session.lastUsed = time.Now()
+	}
+	return session, ok
+}
+
+// endSession removes a session and, in per-session mode, stops its process.
+func (b *StdioBridge) endSession(id string) bool {
+	b.mu.Lock()
+	session, ok := b.sessions[id]
+	if ok {
+		delete(b.sessions, id)
+	}
+	b.mu.Unlock()
+	if !ok {
+		return false
+	}
+	close(session.done)
+	if b.cfg.SessionMode != StdioSessionModeShared {
+		go session.proc.stop()
+	}
+	return true
+}
+
+// runJanitor ends sessions that stayed idle for IdleTimeout. A session with an
+// open GET stream is never idle.
+func (b *StdioBridge) runJanitor() {
+	defer close(b.janitorDone)
+	ticker := time.NewTicker(max(b.cfg.IdleTimeout/4, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stopJanitor:
+			return
+		case now := <-ticker.C:
+			b.mu.Lock()
+			var idle []string
+			for id, session := range b.sessions {
+				if now.Sub(session.lastUsed) >= b.cfg.IdleTimeout && !session.hasGetStream() {
+					idle = append(idle, id)
+				}
+			}
+			b.mu.Unlock()
+			for _, id := range idle {
+				b.endSession(id)
+			}
+		}
+	}
+}
+
+// deliver routes a server-initiated message to the session's GET stream, or
+// to its most recent pending POST stream. Messages with no open stream, or
+// that would block a slow stream, are dropped.
+func (s *bridgeSession) deliver(message []byte) {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	target := s.get
+	if target == nil && len(s.posts) > 0 {
+		target = s.posts[len(s.posts)-1]
+	}
+	if target == nil {
+		return
+	}
+	select {
+	case target <- message:
+	default:
+	}
+}
+
+func (s *bridgeSession) addPostStream() chan []byte {
+	stream := make(chan []byte, stdioBridgeStreamBuffer)
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	s.posts = append(s.posts, stream)
+	return stream
+}
+
+func (s *bridgeSession) removePostStream(stream chan []byte) {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	for i, candidate := range s.posts {
+		if candidate == stream {
+			s.posts = append(s.posts[:i], s.posts[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *bridgeSession) openGetStream() (chan []byte, bool) {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	if s.get != nil {
+		return nil, false
+	}
+	s.get = make(chan []byte, stdioBridgeStreamBuffer)
+	return s.get, true
+}
+
+func (s *bridgeSession) closeGetStream(stream chan []byte) {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	if s.get == stream {
+		s.get = nil
+	}
+}
+
+func (s *bridgeSession) hasGetStream() bool {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+	return s.get != nil
+}
+
+// startStdioProcess starts cmd and reads its stdout. Responses are matched to
+// pending requests; every other message goes to onMessage.
+func startStdioProcess(cmd *exec.Cmd, onMessage func([]byte)) (*stdioProcess, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start stdio server: %w", err)
+	}
+	proc := &stdioProcess{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[string]chan []byte),
+		done:    make(chan struct{}),
+	}
+	go proc.readLoop(stdout, onMessage)
+	return proc, nil
+}
+
+func (p *stdioProcess) readLoop(stdout io.Reader, onMessage func([]byte)) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessageBytes)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		message := append([]byte(nil), line...)
+		envelope, hasID, err := parseRPCEnvelope(message)
+		if err != nil {
+			continue
+		}
+		if hasID && envelope.Method == "" {
+			p.resolve(string(envelope.ID), message)
+			continue
+		}
+		onMessage(message)
+	}
+	_ = p.cmd.Wait()
+
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+	for _, ch := range pending {
+		close(ch)
+	}
+	close(p.done)
+}
+
+func (p *stdioProcess) resolve(key string, message []byte) {
+	p.mu.Lock()
+	ch, ok := p.pending[key]
+	delete(p.pending, key)
+	p.mu.Unlock()
+	if ok {
+		ch <- message
+	}
+}
+
+// request writes message with a bridge-assigned ID, waits for the matching
+// response, and restores the caller's ID on it.
+func (p *stdioProcess) request(ctx context.Context, message map[string]json.RawMessage) ([]byte, error) {
+	originalID := message["id"]
+	p.mu.Lock()
+	if p.pending == nil {
+		p.mu.Unlock()
+		return nil, errStdioProcessExited
+	}
+	p.nextID++
+	key := strconv.FormatInt(p.nextID, 10)
+	ch := make(chan []byte, 1)
+	p.pending[key] = ch
+	p.mu.Unlock()
+
+	outbound := make(map[string]json.RawMessage, len(message))
+	for field, value := range message {
+		outbound[field] = value
+	}
+	outbound["id"] = json.RawMessage(key)
+	if err := p.write(outbound); err != nil {
+		p.forget(key)
+		return nil, err
+	}
+
+	select {
+	case response, ok := <-ch:
+		if !ok {
+			return nil, errStdioProcessExited
+		}
+		return rebindResponseID(response, originalID), nil
+	case <-ctx.Done():
+		p.forget(key)
+		return nil, ctx.Err()
+	}
+}
+
+// initialize forwards the first initialize to the shared child and answers
+// later ones from its cached result.
+func (p *stdioProcess) initialize(ctx context.Context, message map[string]json.RawMessage) ([]byte, error) {
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+	if p.initResult != nil {
+		return json.Marshal(map[string]json.RawMessage{
+			"jsonrpc": json.RawMessage(`"2.0"`),
+			"id":      message["id"],
+			"result":  p.initResult,
+		})
+	}
+	response, err := p.request(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if json.Unmarshal(response, &decoded) == nil && len(decoded.Result) > 0 && !looksLikeJSONRPCError(response) {
+		p.initResult = decoded.Result
+	}
+	return response, nil
+}
+
+// markInitialized reports whether this is the first notifications/initialized.
+func (p *stdioProcess) markInitialized() bool {
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+	first := !p.initialized
+	p.initialized = true
+	return first
+}
+
+func (p *stdioProcess) forget(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, key)
+}
+
+func (p *stdioProcess) write(message map[string]json.RawMessage) error {
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-p.done:
+		return errStdioProcessExited
+	default:
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if _, err := p.stdin.Write(append(encoded, '\n')); err != nil {
+		return fmt.Errorf("write to stdio server: %w", err)
+	}
+	return nil
+}
+
+// stop closes stdin, which stdio MCP servers treat as shutdown, and kills the
+// process if it has not exited within the grace period.
+func (p *stdioProcess) stop() {
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+		return
+	case <-time.After(stdioBridgeProcessGrace):
+	}
+	_ = p.cmd.Process.Kill()
+	<-p.done
+}
+
+func rpcMessageMethod(message map[string]json.RawMessage) string {
+	var method string
+	_ = json.Unmarshal(message["method"], &method)
+	return method
+}
+
+func isRPCRequest(message map[string]json.RawMessage) bool {
+	return len(message["id"]) > 0 && rpcMessageMethod(message) != ""
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(strings.ToLower(r.Header.Get("Accept")), "text/event-stream")
+}
+
+func writeEvent(w io.Writer, message []byte) error {
+	_, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", message)
+	return err
+}
+
+func writeBridgeJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func newBridgeSessionID() (string, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw[:]), nil
+}
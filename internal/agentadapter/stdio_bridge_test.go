@@ -0,0 +1,201 @@
+package agentadapter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestStdioBridgeHelperProcess is the stdio MCP server the bridge tests spawn.
+// It answers every request with its PID, and "progress" with a notification
+// before the result.
+func TestStdioBridgeHelperProcess(t *testing.T) {
+	if os.Getenv("MCP_RUNTIME_STDIO_BRIDGE_HELPER") != "1" {
+		t.Skip("helper process for stdio bridge tests")
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	initializeCount := 0
+	for scanner.Scan() {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &message) != nil || len(message.ID) == 0 || message.Method == "" {
+			continue
+		}
+		if message.Method == "initialize" {
+			initializeCount++
+		}
+		if message.Method == "progress" {
+			fmt.Println(`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progress":1}}`)
+		}
+		fmt.Printf(`{"jsonrpc":"2.0","id":%s,"result":{"method":%q,"pid":%d,"initializeCount":%d}}`+"\n", message.ID, message.Method, os.Getpid(), initializeCount)
+	}
+	os.Exit(0)
+}
+
+type bridgeTestResult struct {
+	ID     json.RawMessage `json:"id"`
+	Result struct {
+		Method          string `json:"method"`
+		PID             int    `json:"pid"`
+		InitializeCount int    `json:"initializeCount"`
+	} `json:"result"`
+	Error *rpcError `json:"error"`
+}
+
+func newTestStdioBridge(t *testing.T, mode string) *httptest.Server {
+	t.Helper()
+	t.Setenv("MCP_RUNTIME_STDIO_BRIDGE_HELPER", "1")
+	bridge, err := NewStdioBridge(StdioBridgeConfig{
+		Command:     []string{os.Args[0], "-test.run=^TestStdioBridgeHelperProcess$"},
+		SessionMode: mode,
+	})
+	if err != nil {
+		t.Fatalf("NewStdioBridge() error = %v", err)
+	}
+	server := httptest.NewServer(bridge)
+	t.Cleanup(func() {
+		server.Close()
+		bridge.Close()
+	})
+	return server
+}
+
+func postBridge(t *testing.T, server *httptest.Server, sessionID, accept, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/mcp", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	if sessionID != "" {
+		req.Header.Set(MCPSessionHeader, sessionID)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func decodeBridgeResult(t *testing.T, resp *http.Response) bridgeTestResult {
+	t.Helper()
+	var result bridgeTestResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.Error != nil {
+		t.Fatalf("response error = %+v", result.Error)
+	}
+	return result
+}
+
+func initializeBridgeSession(t *testing.T, server *httptest.Server) (string, bridgeTestResult) {
+	t.Helper()
+	resp := postBridge(t, server, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	sessionID := resp.Header.Get(MCPSessionHeader)
+	if resp.StatusCode != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize status = %d, session = %q", resp.StatusCode, sessionID)
+	}
+	result := decodeBridgeResult(t, resp)
+	if resp := postBridge(t, server, sessionID, "application/json", `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("notifications/initialized status = %d, want 202", resp.StatusCode)
+	}
+	return sessionID, result
+}
+
+func TestStdioBridgePerSessionProcesses(t *testing.T) {
+	server := newTestStdioBridge(t, StdioSessionModePerSession)
+
+	first, firstInit := initializeBridgeSession(t, server)
+	second, secondInit := initializeBridgeSession(t, server)
+	if firstInit.Result.PID == secondInit.Result.PID {
+		t.Fatalf("sessions share PID %d, want one process each", firstInit.Result.PID)
+	}
+
+	resp := postBridge(t, server, first, "application/json", `{"jsonrpc":"2.0","id":"call-1","method":"tools/list"}`)
+	result := decodeBridgeResult(t, resp)
+	if string(result.ID) != `"call-1"` || result.Result.Method != "tools/list" || result.Result.PID != firstInit.Result.PID {
+		t.Fatalf("tools/list result = %+v, want the caller's ID from the session's process", result)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/mcp", nil)
+	req.Header.Set(MCPSessionHeader, second)
+	deleted, err := server.Client().Do(req)
+	if err != nil || deleted.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE = %v, %v; want 204", deleted, err)
+	}
+	_ = deleted.Body.Close()
+	if resp := postBridge(t, server, second, "application/json", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("deleted session status = %d, want 404", resp.StatusCode)
+	}
+	if resp := postBridge(t, server, "", "application/json", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("missing session status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestStdioBridgeSharedProcessMultiplexesSessions(t *testing.T) {
+	server := newTestStdioBridge(t, StdioSessionModeShared)
+
+	first, firstInit := initializeBridgeSession(t, server)
+	second, secondInit := initializeBridgeSession(t, server)
+	if firstInit.Result.PID != secondInit.Result.PID || secondInit.Result.InitializeCount != 1 {
+		t.Fatalf("second initialize = %+v, want the cached result of the shared process %d", secondInit, firstInit.Result.PID)
+	}
+
+	// Both sessions use the same JSON-RPC ID at the same time.
+	var wg sync.WaitGroup
+	results := make([]bridgeTestResult, 2)
+	for i, sessionID := range []string{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := postBridge(t, server, sessionID, "application/json", `{"jsonrpc":"2.0","id":7,"method":"tools/list"}`)
+			body, _ := io.ReadAll(resp.Body)
+			_ = json.Unmarshal(body, &results[i])
+		}()
+	}
+	wg.Wait()
+	for i, result := range results {
+		if string(result.ID) != "7" || result.Result.Method != "tools/list" {
+			t.Fatalf("session %d result = %+v, want id 7", i, result)
+		}
+	}
+
+	batch := postBridge(t, server, first, "application/json", `[{"jsonrpc":"2.0","id":"a","method":"ping"},{"jsonrpc":"2.0","method":"notifications/cancelled","params":{}},{"jsonrpc":"2.0","id":"b","method":"tools/list"}]`)
+	var responses []bridgeTestResult
+	if err := json.NewDecoder(batch.Body).Decode(&responses); err != nil {
+		t.Fatalf("decode batch: %v", err)
+	}
+	if len(responses) != 2 || string(responses[0].ID) != `"a"` || string(responses[1].ID) != `"b"` {
+		t.Fatalf("batch responses = %+v, want a and b", responses)
+	}
+}
+
+func TestStdioBridgeStreamsNotificationsBeforeResponse(t *testing.T) {
+	server := newTestStdioBridge(t, StdioSessionModePerSession)
+	sessionID, _ := initializeBridgeSession(t, server)
+
+	resp := postBridge(t, server, sessionID, "application/json, text/event-stream", `{"jsonrpc":"2.0","id":3,"method":"progress"}`)
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("content type = %q, want SSE", resp.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read SSE: %v", err)
+	}
+	messages := decodeStreamableHTTPEventMessages(body)
+	if len(messages) != 2 || !strings.Contains(string(messages[0]), "notifications/progress") || !strings.Contains(string(messages[1]), `"id":3`) {
+		t.Fatalf("SSE messages = %q, want the progress notification and then the response", messages)
+	}
+}
@@ -16,8 +16,9 @@ func New(runtime *core.Runtime) *cobra.Command {
 func NewWithManager(mgr *AccessManager) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "access",
-		Short: "Manage grants and agent sessions",
-		Long: `Commands for managing MCPAccessGrant and MCPAgentSession resources that feed the gateway policy layer.
+		Short: "Manage grants, agent sessions, and tool call approvals",
+		Long: `Commands for managing MCPAccessGrant and MCPAgentSession resources that feed the gateway policy layer,
+and for deciding MCPToolApproval requests for tool calls held under requires_approval rules.
 
 With mcp-runtime auth login, commands use the platform API by default for normal
 user and admin workflows. Use --use-kube only for admin/dev/test direct
@@ -28,7 +29,9 @@ RBAC access. For platform workflows, run mcp-runtime auth login --api-url
 
 	mgr.BindUseKubeFlag(cmd)
 
-	cmd.AddCommand(newGrantCmd(mgr), newSessionCmd(mgr))
+	cmd.AddCommand(newGrantCmd(mgr), newSessionCmd(mgr), newApprovalCmd(mgr))
+	cmd.AddCommand(newDecideCmd(mgr, "approve", "Approve a tool call held for approval", true))
+	cmd.AddCommand(newDecideCmd(mgr, "deny", "Deny a tool call held for approval", false))
 	return cmd
 }
 
@@ -62,6 +65,44 @@ func newSessionCmd(mgr *AccessManager) *cobra.Command {
 	return cmd
 }
 
+func newApprovalCmd(mgr *AccessManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approval",
+		Short: "Inspect MCPToolApproval resources",
+	}
+	var namespace, phase string
+	var allNamespaces bool
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List tool calls held for approval",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.ListApprovals(namespace, phase, allNamespaces)
+		},
+	}
+	list.Flags().StringVar(&namespace, "namespace", "", "Namespace to inspect")
+	list.Flags().BoolVar(&allNamespaces, "all-namespaces", true, "List approvals across all namespaces when no namespace is specified")
+	list.Flags().StringVar(&phase, "phase", "Pending", "Only list approvals in this phase: Pending, Approved, Denied, or Expired; empty lists all")
+	cmd.AddCommand(list)
+	cmd.AddCommand(newGetCmd(mgr, ApprovalResource, "approval"))
+	return cmd
+}
+
+func newDecideCmd(mgr *AccessManager, use, short string, approve bool) *cobra.Command {
+	var namespace, comment string
+	cmd := &cobra.Command{
+		Use:   use + " [id]",
+		Short: short,
+		Long:  short + ". The id is the MCPToolApproval name reported to the caller and by access approval list. Only owners of the approval's server can decide, and each approval releases or denies a single call.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.DecideApproval(args[0], namespace, approve, comment)
+		},
+	}
+	cmd.Flags().StringVar(&namespace, "namespace", core.NamespaceMCPServers, "Namespace of the approval")
+	cmd.Flags().StringVar(&comment, "comment", "", "Note recorded on the approval and shown to the caller on a denial")
+	return cmd
+}
+
 func newGrantInitCmd(mgr *AccessManager) *cobra.Command {
 	opts := accessManifestInitOptions{}
 	cmd := &cobra.Command{
@@ -77,7 +118,7 @@ func newGrantInitCmd(mgr *AccessManager) *cobra.Command {
 	bindAccessInitCommonFlags(cmd, &opts)
 	cmd.Flags().StringArrayVar(&opts.SideEffects, "side-effect", []string{"read"}, "Allowed side effect class: read, write, or destructive; repeat for multiple")
 	cmd.Flags().StringArrayVar(&opts.Tools, "tool", nil, "Tool name to allow; repeat for multiple tools")
-	cmd.Flags().StringArrayVar(&opts.ToolRules, "tool-rule", nil, "Tool rule as name:allow|deny:low|medium|high; repeat for mixed trust or deny rules")
+	cmd.Flags().StringArrayVar(&opts.ToolRules, "tool-rule", nil, "Tool rule as name:allow|deny|requires_approval:low|medium|high; repeat for mixed trust, deny, or approval rules")
 	cmd.Flags().StringVar(&opts.Output, "output", "grant.yaml", "Output manifest path")
 	return cmd
 }
@@ -24,6 +24,8 @@ const (
 	GrantResource = "mcpaccessgrant"
 	// SessionResource is the kubectl resource name for MCPAgentSession.
 	SessionResource = "mcpagentsession"
+	// ApprovalResource is the kubectl resource name for MCPToolApproval.
+	ApprovalResource = "mcptoolapproval"
 )
 
 // AccessManager handles access grant and session operations.
@@ -333,9 +335,9 @@ func initToolRules(tools, explicitRules []string, trust string) ([]map[string]st
 			return nil, core.NewWithSentinel(nil, fmt.Sprintf("unsupported tool rule %q: tool name is required", rule))
 		}
 		switch decision {
-		case "allow", "deny":
+		case "allow", "deny", "requires_approval":
 		default:
-			return nil, core.NewWithSentinel(nil, fmt.Sprintf("unsupported tool rule %q: decision must be allow or deny", rule))
+			return nil, core.NewWithSentinel(nil, fmt.Sprintf("unsupported tool rule %q: decision must be allow, deny, or requires_approval", rule))
 		}
 		if _, ok := seen[name]; ok {
 			return nil, core.NewWithSentinel(nil, fmt.Sprintf("duplicate tool rule for %q", name))
@@ -456,6 +458,14 @@ func (m *AccessManager) getAccessPlatform(ctx context.Context, plat *platformapi
 		b, _ := json.MarshalIndent(session, "", "  ")
 		_, _ = os.Stdout.Write(append(b, '\n'))
 		return nil
+	case ApprovalResource:
+		approval, err := plat.GetApproval(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		b, _ := json.MarshalIndent(approval, "", "  ")
+		_, _ = os.Stdout.Write(append(b, '\n'))
+		return nil
 	default:
 		return core.NewWithSentinel(nil, fmt.Sprintf("unsupported access resource %q", resource))
 	}
@@ -614,3 +624,183 @@ func (m *AccessManager) ToggleAccessResource(resource, name, namespace string, v
 	}
 	return nil
 }
+
+// ListApprovals lists tool call approvals via the platform API unless
+// --use-kube is set. Direct Kubernetes mode ignores the phase filter and
+// prints kubectl's table, which includes the phase.
+func (m *AccessManager) ListApprovals(namespace, phase string, allNamespaces bool) error {
+	plat, kube, err := platformapi.ResolvePlatformOrKube(m.useKube)
+	if err != nil {
+		return err
+	}
+	if !kube {
+		approvals, err := plat.ListApprovals(context.Background(), m.accessListQueryNamespace(namespace, allNamespaces), phase)
+		if err != nil {
+			return core.WrapWithSentinelAndContext(nil, err, fmt.Sprintf("list approvals: %v", err), map[string]any{"component": "access"})
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "NAME\tNAMESPACE\tSERVER\tTOOL\tHUMAN\tAGENT\tPHASE\tEXPIRES")
+		for _, a := range approvals {
+			expires := ""
+			if a.ExpiresAt != nil {
+				expires = a.ExpiresAt.UTC().Format(time.RFC3339)
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.Name, a.Namespace, a.ServerRef.Name, a.Tool, a.Subject.HumanID, a.Subject.AgentID, a.Phase, expires)
+		}
+		_ = tw.Flush()
+		return nil
+	}
+
+	args := []string{"get", ApprovalResource}
+	switch {
+	case namespace != "":
+		args = append(args, "-n", namespace)
+	case allNamespaces:
+		args = append(args, "-A")
+	default:
+		args = append(args, "-n", m.accessListQueryNamespace(namespace, allNamespaces))
+	}
+	if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+		return core.WrapWithSentinelAndContext(nil, err, kubeerr.DirectModeFailureMessage("failed to list approvals", err.Error()), map[string]any{
+			"namespace": namespace,
+			"component": "access",
+		})
+	}
+	return nil
+}
+
+// DecideApproval approves or denies a pending tool call approval. The
+// platform API records the caller as the approver; direct Kubernetes mode
+// records the username the cluster authenticates the caller as, which the
+// admission webhook requires.
+func (m *AccessManager) DecideApproval(name, namespace string, approve bool, comment string) error {
+	name, namespace, err := validateAccessResourceInput(name, namespace)
+	if err != nil {
+		return err
+	}
+	verb := "denied"
+	decision := "denied"
+	if approve {
+		verb = "approved"
+		decision = "approved"
+	}
+
+	plat, kube, err := platformapi.ResolvePlatformOrKube(m.useKube)
+	if err != nil {
+		return err
+	}
+	if !kube {
+		approval, err := plat.DecideApproval(context.Background(), namespace, name, approve, comment)
+		if err != nil {
+			return core.WrapWithSentinelAndContext(nil, err, fmt.Sprintf("decide approval %q: %v", name, err), map[string]any{
+				"name":      name,
+				"namespace": namespace,
+				"component": "access",
+			})
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "approval %q %s: tool %s on %s\n", name, verb, approval.Tool, approval.ServerRef.Name)
+		return nil
+	}
+
+	approver, err := m.kubeUsername()
+	if err != nil {
+		return err
+	}
+	current, err := m.getApprovalKube(name, namespace)
+	if err != nil {
+		return err
+	}
+	if !current.pending(time.Now()) {
+		return core.NewWithSentinel(nil, fmt.Sprintf("approval %q is no longer pending", name))
+	}
+	if requester := strings.TrimSpace(current.Spec.Subject.HumanID); requester != "" && requester == approver {
+		return core.NewWithSentinel(nil, "approvers cannot decide their own tool calls")
+	}
+	// Pinning the resourceVersion that was checked keeps a concurrent
+	// decision from being overwritten.
+	data, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"resourceVersion": current.Metadata.ResourceVersion},
+		"spec": map[string]any{
+			"decision":  decision,
+			"decidedBy": approver,
+			"decidedAt": time.Now().UTC().Format(time.RFC3339),
+			"comment":   comment,
+		},
+	})
+	if err != nil {
+		return core.WrapWithSentinelAndContext(nil, err, fmt.Sprintf("failed to marshal approval patch for %q: %v", name, err), map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"component": "access",
+		})
+	}
+	args := []string{"patch", ApprovalResource, name, "-n", namespace, "--type", "merge", "--patch", string(data)}
+	if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+		return core.WrapWithSentinelAndContext(nil, err, kubeerr.DirectModeFailureMessage(fmt.Sprintf("failed to decide approval %q in namespace %q", name, namespace), err.Error()), map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"component": "access",
+		})
+	}
+	return nil
+}
+
+// kubeUsername returns the username the API server authenticates the current
+// kubeconfig context as.
+func (m *AccessManager) kubeUsername() (string, error) {
+	out, err := m.kubectl.Output([]string{"auth", "whoami", "-o", "jsonpath={.status.userInfo.username}"})
+	if err != nil {
+		return "", core.WrapWithSentinelAndContext(nil, err, kubeerr.DirectModeFailureMessage("failed to read your Kubernetes username", err.Error()), map[string]any{
+			"component": "access",
+		})
+	}
+	username := strings.TrimSpace(string(out))
+	if username == "" {
+		return "", core.NewWithSentinel(nil, "kubectl auth whoami reported no username")
+	}
+	return username, nil
+}
+
+// kubeApproval is the part of an MCPToolApproval DecideApproval checks in
+// direct Kubernetes mode.
+type kubeApproval struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Spec struct {
+		Subject struct {
+			HumanID string `json:"humanID"`
+		} `json:"subject"`
+		Decision  string     `json:"decision"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+	} `json:"spec"`
+	Status struct {
+		ConsumedAt *time.Time `json:"consumedAt"`
+	} `json:"status"`
+}
+
+// pending reports whether the approval can still be decided.
+func (a kubeApproval) pending(now time.Time) bool {
+	return a.Spec.Decision == "" && a.Status.ConsumedAt == nil &&
+		(a.Spec.ExpiresAt == nil || now.Before(*a.Spec.ExpiresAt))
+}
+
+func (m *AccessManager) getApprovalKube(name, namespace string) (kubeApproval, error) {
+	var approval kubeApproval
+	out, err := m.kubectl.Output([]string{"get", ApprovalResource, name, "-n", namespace, "-o", "json"})
+	if err != nil {
+		return approval, core.WrapWithSentinelAndContext(nil, err, kubeerr.DirectModeFailureMessage(fmt.Sprintf("failed to get approval %q in namespace %q", name, namespace), err.Error()), map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"component": "access",
+		})
+	}
+	if err := json.Unmarshal(out, &approval); err != nil {
+		return approval, core.WrapWithSentinelAndContext(nil, err, fmt.Sprintf("failed to parse approval %q: %v", name, err), map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"component": "access",
+		})
+	}
+	return approval, nil
+}
@@ -45,7 +45,7 @@ func TestInitGrantManifest(t *testing.T) {
 		Trust:       "medium",
 		SideEffects: []string{"read", "write", "read"},
 		Tools:       []string{"list_invoices", "refund_invoice", "list_invoices"},
-		ToolRules:   []string{"delete_invoice:deny:high"},
+		ToolRules:   []string{"delete_invoice:deny:high", "close_account:requires_approval:high"},
 		Output:      output,
 	})
 	if err != nil {
@@ -70,6 +70,7 @@ func TestInitGrantManifest(t *testing.T) {
 		"name: refund_invoice",
 		"name: delete_invoice",
 		"decision: deny",
+		"decision: requires_approval",
 		"requiredTrust: high",
 	} {
 		if !strings.Contains(text, want) {
@@ -113,7 +114,7 @@ func TestInitGrantManifestRejectsInvalidToolRule(t *testing.T) {
 		ToolRules:   []string{"refund_invoice:audit:high"},
 		Output:      filepath.Join(t.TempDir(), "grant.yaml"),
 	})
-	if err == nil || !strings.Contains(err.Error(), "allow or deny") {
+	if err == nil || !strings.Contains(err.Error(), "allow, deny, or requires_approval") {
 		t.Fatalf("error = %v, want decision validation", err)
 	}
 }
@@ -432,6 +433,54 @@ func TestAccessManager_ToggleAccessResource(t *testing.T) {
 	}
 }
 
+func TestAccessManager_DecideApproval(t *testing.T) {
+	approval := `{"metadata":{"resourceVersion":"42"},"spec":{"subject":{"humanID":"alice@example.com"},"expiresAt":"2999-01-01T00:00:00Z"}}`
+	username := "alice@example.com"
+	mock := &core.MockExecutor{}
+	mock.CommandFunc = func(spec core.ExecSpec) *core.MockCommand {
+		if len(spec.Args) > 1 && spec.Args[0] == "auth" && spec.Args[1] == "whoami" {
+			return &core.MockCommand{Args: spec.Args, OutputData: []byte(username)}
+		}
+		return &core.MockCommand{Args: spec.Args, OutputData: mock.DefaultOutput}
+	}
+	mock.DefaultOutput = []byte(approval)
+	mgr := newKubeTestAccessManager(core.NewTestKubectlClient(mock))
+
+	if err := mgr.DecideApproval("payments-abc12", "team-a", true, "ok"); err == nil || !strings.Contains(err.Error(), "own tool calls") {
+		t.Fatalf("error = %v, want the requester rejected as approver", err)
+	}
+	username = ""
+	if err := mgr.DecideApproval("payments-abc12", "team-a", true, "ok"); err == nil || !strings.Contains(err.Error(), "no username") {
+		t.Fatalf("error = %v, want an unknown Kubernetes username rejected", err)
+	}
+	username = "bob@example.com\n"
+	if err := mgr.DecideApproval("payments-abc12", "team-a", false, "too large"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cmd := mock.LastCommand()
+	for _, want := range []string{"patch", ApprovalResource, "payments-abc12", "-n", "team-a", "--type", "merge"} {
+		if !contains(cmd.Args, want) {
+			t.Fatalf("expected %q in args, got %v", want, cmd.Args)
+		}
+	}
+	patch := cmd.Args[len(cmd.Args)-1]
+	for _, want := range []string{`"resourceVersion":"42"`, `"decision":"denied"`, `"decidedBy":"bob@example.com"`, `"comment":"too large"`, `"decidedAt":`} {
+		if !strings.Contains(patch, want) {
+			t.Fatalf("patch %s missing %s", patch, want)
+		}
+	}
+
+	mock.DefaultOutput = []byte(`{"metadata":{"resourceVersion":"43"},"spec":{"decision":"approved","subject":{"humanID":"alice@example.com"}}}`)
+	before := len(mock.Commands)
+	if err := mgr.DecideApproval("payments-abc12", "team-a", true, ""); err == nil || !strings.Contains(err.Error(), "no longer pending") {
+		t.Fatalf("error = %v, want a decided approval rejected", err)
+	}
+	if len(mock.Commands) != before+2 {
+		t.Fatalf("commands = %v, want only the username and the read of a closed approval", mock.Commands[before:])
+	}
+}
+
 func captureStdout(t *testing.T, fn func() error) string {
 	t.Helper()
 	orig := os.Stdout
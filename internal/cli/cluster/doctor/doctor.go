@@ -245,6 +245,7 @@ func doctorCheckSpecs(kubectl core.KubectlRunner, distro Distribution) []doctorC
 		{Name: "traefik web entrypoint", Detail: "checking the Traefik Service ports for the web entrypoint", Run: func() DoctorCheck { return checkTraefikWebEntrypoint(kubectl, distro) }},
 		{Name: "traefik service exposure", Detail: "checking LoadBalancer or NodePort exposure for the web entrypoint", Run: func() DoctorCheck { return checkTraefikServiceExposure(kubectl, distro) }},
 		{Name: "ingress LoadBalancer status", Detail: "checking host-based MCP Runtime ingresses for published LoadBalancer status", Run: func() DoctorCheck { return checkIngressLoadBalancerStatus(kubectl) }},
+		{Name: "gateway API routes", Detail: "checking operator-managed HTTPRoutes are accepted by their parent Gateway with resolved backends", Run: func() DoctorCheck { return checkGatewayAPIRoutes(kubectl) }},
 		{Name: "platform API live inventory ingress", Detail: "checking team namespace NetworkPolicies allow platform API probes to MCPServer Services", Run: func() DoctorCheck { return checkPlatformAPILiveInventoryNetworkPolicy(kubectl) }},
 		{Name: "mcp-servers DNS/network", Detail: "launching a temporary curl pod in mcp-servers to reach the registry service", Run: func() DoctorCheck { return checkMCPServersDNSAndNetwork(kubectl) }},
 		{
@@ -1861,6 +1861,78 @@ func TestCheckIngressLoadBalancerStatus(t *testing.T) {
 	})
 }
 
+func TestCheckGatewayAPIRoutes(t *testing.T) {
+	newMock := func(modes string, crdErr error, routes string, gatewayErr error) *core.MockExecutor {
+		return &core.MockExecutor{
+			CommandFunc: func(spec core.ExecSpec) *core.MockCommand {
+				switch {
+				case contains(spec.Args, "mcpservers"):
+					return &core.MockCommand{OutputData: []byte(modes)}
+				case contains(spec.Args, "crd"):
+					return &core.MockCommand{OutputData: []byte(doctorHTTPRouteCRD), OutputErr: crdErr}
+				case contains(spec.Args, doctorHTTPRouteCRD):
+					return &core.MockCommand{OutputData: []byte(routes)}
+				case contains(spec.Args, "gateways.gateway.networking.k8s.io"):
+					return &core.MockCommand{OutputData: []byte("public"), OutputErr: gatewayErr}
+				default:
+					return &core.MockCommand{}
+				}
+			},
+		}
+	}
+	route := func(status string) string {
+		return `{"items": [{
+			"metadata": {"namespace": "mcp-servers", "name": "search", "generation": 2},
+			"spec": {"parentRefs": [{"name": "public", "namespace": "gateway-system"}]},
+			"status": {"parents": [` + status + `]}
+		}]}`
+	}
+	accepted := `{"parentRef": {"name": "public", "namespace": "gateway-system"}, "conditions": [
+		{"type": "Accepted", "status": "True", "observedGeneration": 2},
+		{"type": "ResolvedRefs", "status": "%s", "reason": "BackendNotFound", "message": "service missing", "observedGeneration": 2}
+	]}`
+
+	t.Run("passes when Gateway API is unused", func(t *testing.T) {
+		check := checkGatewayAPIRoutes(core.NewTestKubectlClient(newMock("mcp-servers/search|\n", errors.New("not found"), "", nil)))
+		if !check.OK {
+			t.Fatalf("expected OK, got detail=%q", check.Detail)
+		}
+	})
+
+	t.Run("fails when a server selects gateway-api without the CRDs", func(t *testing.T) {
+		check := checkGatewayAPIRoutes(core.NewTestKubectlClient(newMock("mcp-servers/search|gateway-api\n", errors.New("not found"), "", nil)))
+		if check.OK || !strings.Contains(check.Detail, "mcp-servers/search") {
+			t.Fatalf("expected missing CRD failure naming the server, got OK=%v detail=%q", check.OK, check.Detail)
+		}
+	})
+
+	t.Run("passes when routes are accepted and resolved", func(t *testing.T) {
+		check := checkGatewayAPIRoutes(core.NewTestKubectlClient(newMock("", nil, route(fmt.Sprintf(accepted, "True")), nil)))
+		if !check.OK {
+			t.Fatalf("expected OK, got detail=%q", check.Detail)
+		}
+	})
+
+	t.Run("fails with the controller reason for unresolved backends", func(t *testing.T) {
+		check := checkGatewayAPIRoutes(core.NewTestKubectlClient(newMock("", nil, route(fmt.Sprintf(accepted, "False")), nil)))
+		if check.OK {
+			t.Fatal("expected unresolved backend to fail")
+		}
+		for _, want := range []string{"mcp-servers/search", "ResolvedRefs=False", "BackendNotFound"} {
+			if !strings.Contains(check.Detail, want) {
+				t.Fatalf("detail should contain %q, got %q", want, check.Detail)
+			}
+		}
+	})
+
+	t.Run("fails when the parent Gateway is missing", func(t *testing.T) {
+		check := checkGatewayAPIRoutes(core.NewTestKubectlClient(newMock("", nil, route(""), errors.New("not found"))))
+		if check.OK || !strings.Contains(check.Detail, "parent Gateway gateway-system/public not found") {
+			t.Fatalf("expected missing Gateway failure, got OK=%v detail=%q", check.OK, check.Detail)
+		}
+	})
+}
+
 func TestCheckPlatformAPILiveInventoryNetworkPolicy(t *testing.T) {
 	t.Run("fails when team policy blocks platform API ingress", func(t *testing.T) {
 		mock := &core.MockExecutor{
@@ -0,0 +1,167 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mcp-runtime/internal/cli/core"
+)
+
+const (
+	doctorHTTPRouteCRD        = "httproutes.gateway.networking.k8s.io"
+	doctorRoutingModeGateway  = "gateway-api"
+	doctorGatewayAPIRemedy    = "check the parent Gateway exists, its listeners allow routes from the server namespace (allowedRoutes), and its Gateway controller is running; `kubectl describe httproute -n <namespace> <name>` shows the controller's reason"
+	doctorGatewayAPICRDRemedy = "install the Gateway API standard CRDs and a Gateway controller, or set spec.routing.mode to ingress"
+)
+
+type doctorHTTPRoute struct {
+	Metadata struct {
+		Namespace  string `json:"namespace"`
+		Name       string `json:"name"`
+		Generation int64  `json:"generation"`
+	} `json:"metadata"`
+	Spec struct {
+		ParentRefs []doctorRouteParentRef `json:"parentRefs"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef  doctorRouteParentRef `json:"parentRef"`
+			Conditions []struct {
+				Type               string `json:"type"`
+				Status             string `json:"status"`
+				Reason             string `json:"reason"`
+				Message            string `json:"message"`
+				ObservedGeneration int64  `json:"observedGeneration"`
+			} `json:"conditions"`
+		} `json:"parents"`
+	} `json:"status"`
+}
+
+type doctorRouteParentRef struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName"`
+}
+
+// checkGatewayAPIRoutes diagnoses servers routed through Gateway API: the
+// HTTPRoute CRD must exist when a server selects gateway-api mode, and every
+// operator-managed HTTPRoute must be Accepted with ResolvedRefs by its parent.
+func checkGatewayAPIRoutes(kubectl core.KubectlRunner) DoctorCheck {
+	const name = "gateway API routes"
+
+	modes, err := readKubectlOutput(kubectl, []string{"get", "mcpservers", "-A", "-o", `jsonpath={range .items[*]}{.metadata.namespace}{"/"}{.metadata.name}{"|"}{.spec.routing.mode}{"\n"}{end}`})
+	if err != nil {
+		return DoctorCheck{Name: name, OK: false, Detail: fmt.Sprintf("failed listing MCPServers: %v", err), Remedy: "check MCPServer CRD availability and RBAC for listing MCPServers across namespaces"}
+	}
+	gatewayServers := serversInGatewayAPIMode(modes)
+
+	if _, err := readKubectlOutput(kubectl, []string{"get", "crd", doctorHTTPRouteCRD, "-o", "jsonpath={.metadata.name}"}); err != nil {
+		if len(gatewayServers) == 0 {
+			return DoctorCheck{Name: name, OK: true, Detail: "Gateway API CRDs not installed; no MCPServer selects gateway-api routing"}
+		}
+		return DoctorCheck{
+			Name:   name,
+			OK:     false,
+			Detail: fmt.Sprintf("CRD %s not found but %d MCPServer(s) select gateway-api routing: %s", doctorHTTPRouteCRD, len(gatewayServers), strings.Join(gatewayServers, ", ")),
+			Remedy: doctorGatewayAPICRDRemedy,
+		}
+	}
+
+	out, err := readKubectlOutput(kubectl, []string{"get", doctorHTTPRouteCRD, "-A", "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "json"})
+	if err != nil {
+		return DoctorCheck{Name: name, OK: false, Detail: fmt.Sprintf("failed listing HTTPRoutes: %v", err), Remedy: "check RBAC for listing HTTPRoutes across namespaces"}
+	}
+	var list struct {
+		Items []doctorHTTPRoute `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		return DoctorCheck{Name: name, OK: false, Detail: fmt.Sprintf("failed parsing HTTPRoutes: %v", err), Remedy: "rerun cluster doctor"}
+	}
+	if len(list.Items) == 0 {
+		if len(gatewayServers) > 0 {
+			return DoctorCheck{
+				Name:   name,
+				OK:     false,
+				Detail: fmt.Sprintf("%d MCPServer(s) select gateway-api routing but no operator-managed HTTPRoute exists: %s", len(gatewayServers), strings.Join(gatewayServers, ", ")),
+				Remedy: "check the MCPServer status and operator logs; gateway-api routing needs spec.routing.parentRef or MCP_GATEWAY_API_PARENT_NAME on the operator",
+			}
+		}
+		return DoctorCheck{Name: name, OK: true, Detail: "no operator-managed HTTPRoutes found"}
+	}
+
+	var problems []string
+	for _, route := range list.Items {
+		problems = append(problems, httpRouteProblems(kubectl, route)...)
+	}
+	if len(problems) > 0 {
+		return DoctorCheck{
+			Name:   name,
+			OK:     false,
+			Detail: strings.Join(problems, "; "),
+			Remedy: doctorGatewayAPIRemedy,
+		}
+	}
+	return DoctorCheck{Name: name, OK: true, Detail: fmt.Sprintf("%d HTTPRoute(s) accepted with resolved backends", len(list.Items))}
+}
+
+func serversInGatewayAPIMode(out string) []string {
+	var servers []string
+	for _, line := range filterNonEmptyLines(out) {
+		server, mode, _ := strings.Cut(line, "|")
+		if strings.TrimSpace(mode) == doctorRoutingModeGateway {
+			servers = append(servers, strings.TrimSpace(server))
+		}
+	}
+	return servers
+}
+
+// httpRouteProblems describes each parent of the route that has not accepted
+// it or could not resolve its backend.
+func httpRouteProblems(kubectl core.KubectlRunner, route doctorHTTPRoute) []string {
+	routeLabel := route.Metadata.Namespace + "/" + route.Metadata.Name
+	var problems []string
+	for _, parent := range route.Spec.ParentRefs {
+		if parent.Namespace == "" {
+			parent.Namespace = route.Metadata.Namespace
+		}
+		parentLabel := parent.Namespace + "/" + parent.Name
+		found := false
+		for _, parentStatus := range route.Status.Parents {
+			ref := parentStatus.ParentRef
+			if ref.Namespace == "" {
+				ref.Namespace = route.Metadata.Namespace
+			}
+			if ref != parent {
+				continue
+			}
+			found = true
+			for _, conditionType := range []string{"Accepted", "ResolvedRefs"} {
+				status := "Unknown"
+				reason := "no condition reported"
+				for _, condition := range parentStatus.Conditions {
+					if condition.Type != conditionType {
+						continue
+					}
+					status = condition.Status
+					reason = strings.TrimSpace(condition.Reason + ": " + condition.Message)
+					if condition.ObservedGeneration < route.Metadata.Generation {
+						status = "Stale"
+					}
+				}
+				if status != "True" {
+					problems = append(problems, fmt.Sprintf("%s parent %s %s=%s (%s)", routeLabel, parentLabel, conditionType, status, reason))
+				}
+			}
+		}
+		if found {
+			continue
+		}
+		if _, err := readKubectlOutput(kubectl, []string{"get", "gateways.gateway.networking.k8s.io", parent.Name, "-n", parent.Namespace, "-o", "jsonpath={.metadata.name}"}); err != nil {
+			problems = append(problems, fmt.Sprintf("%s parent Gateway %s not found", routeLabel, parentLabel))
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s has no status from parent %s; its Gateway controller has not processed the route", routeLabel, parentLabel))
+	}
+	return problems
+}
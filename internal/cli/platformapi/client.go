@@ -122,6 +122,14 @@ type sessionGetResponse struct {
 	Session sentinelaccess.SessionSummary `json:"session"`
 }
 
+type approvalsListResponse struct {
+	Approvals []sentinelaccess.ApprovalSummary `json:"approvals"`
+}
+
+type approvalGetResponse struct {
+	Approval sentinelaccess.ApprovalSummary `json:"approval"`
+}
+
 type grantAPIBody struct {
 	Name               string                          `json:"name"`
 	Namespace          string                          `json:"namespace"`
@@ -132,6 +140,9 @@ type grantAPIBody struct {
 	PolicyVersion      string                          `json:"policyVersion,omitempty"`
 	Disabled           *bool                           `json:"disabled,omitempty"`
 	ToolRules          []sentinelaccess.ToolRule       `json:"toolRules"`
+	PromptRules        []sentinelaccess.PromptRule     `json:"promptRules,omitempty"`
+	ResourceRules      []sentinelaccess.ResourceRule   `json:"resourceRules,omitempty"`
+	RateLimits         []sentinelaccess.RateLimit      `json:"rateLimits,omitempty"`
 }
 
 type sessionAPIBody struct {
@@ -448,6 +459,87 @@ func (c *PlatformClient) PatchSession(ctx context.Context, namespace, name strin
 	return nil
 }
 
+// ListApprovals returns tool call approvals on servers the caller administers,
+// optionally only those in phase.
+func (c *PlatformClient) ListApprovals(ctx context.Context, namespace, phase string) ([]sentinelaccess.ApprovalSummary, error) {
+	v := url.Values{}
+	if strings.TrimSpace(namespace) != "" {
+		v.Set("namespace", namespace)
+	}
+	if strings.TrimSpace(phase) != "" {
+		v.Set("phase", phase)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/runtime/approvals", v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, httpAPIError(resp.StatusCode, b)
+	}
+	var out approvalsListResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out.Approvals, nil
+}
+
+// GetApproval returns one tool call approval.
+func (c *PlatformClient) GetApproval(ctx context.Context, namespace, name string) (sentinelaccess.ApprovalSummary, error) {
+	p := fmt.Sprintf("/runtime/approvals/%s/%s", url.PathEscape(namespace), url.PathEscape(name))
+	resp, err := c.do(ctx, http.MethodGet, p, "", nil)
+	if err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	defer resp.Body.Close()
+	b, err := readBody(resp.Body)
+	if err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return sentinelaccess.ApprovalSummary{}, httpAPIError(resp.StatusCode, b)
+	}
+	var out approvalGetResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	return out.Approval, nil
+}
+
+// DecideApproval approves or denies a pending tool call approval.
+func (c *PlatformClient) DecideApproval(ctx context.Context, namespace, name string, approve bool, comment string) (sentinelaccess.ApprovalSummary, error) {
+	action := "deny"
+	if approve {
+		action = "approve"
+	}
+	js, err := json.Marshal(map[string]any{"comment": comment})
+	if err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	p := fmt.Sprintf("/runtime/approvals/%s/%s/%s", url.PathEscape(strings.TrimSpace(namespace)), url.PathEscape(strings.TrimSpace(name)), action)
+	resp, err := c.do(ctx, http.MethodPost, p, "", bytes.NewReader(js))
+	if err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	defer resp.Body.Close()
+	b, err := readBody(resp.Body)
+	if err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return sentinelaccess.ApprovalSummary{}, httpAPIError(resp.StatusCode, b)
+	}
+	var out approvalGetResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return sentinelaccess.ApprovalSummary{}, err
+	}
+	return out.Approval, nil
+}
+
 func (c *PlatformClient) ApplyAccessFromYAMLFile(ctx context.Context, path string) error {
 	b, err := readFileAtPath(path)
 	if err != nil {
@@ -516,10 +608,46 @@ func grantFromV1(g *mcpv1alpha1.MCPAccessGrant) grantAPIBody {
 	}
 	rules := make([]sentinelaccess.ToolRule, 0, len(g.Spec.ToolRules))
 	for _, tr := range g.Spec.ToolRules {
-		rules = append(rules, sentinelaccess.ToolRule{
+		rule := sentinelaccess.ToolRule{
 			Name:          tr.Name,
 			Decision:      sentinelaccess.PolicyDecision(tr.Decision),
 			RequiredTrust: sentinelaccess.TrustLevel(tr.RequiredTrust),
+		}
+		for _, constraint := range tr.ArgumentConstraints {
+			rule.ArgumentConstraints = append(rule.ArgumentConstraints, sentinelaccess.ArgumentConstraint{
+				Argument: constraint.Argument,
+				OneOf:    constraint.OneOf,
+				Pattern:  constraint.Pattern,
+				Minimum:  constraint.Minimum,
+				Maximum:  constraint.Maximum,
+				Optional: constraint.Optional,
+			})
+		}
+		rules = append(rules, rule)
+	}
+	var promptRules []sentinelaccess.PromptRule
+	for _, pr := range g.Spec.PromptRules {
+		promptRules = append(promptRules, sentinelaccess.PromptRule{
+			Name:          pr.Name,
+			Decision:      sentinelaccess.PolicyDecision(pr.Decision),
+			RequiredTrust: sentinelaccess.TrustLevel(pr.RequiredTrust),
+		})
+	}
+	var resourceRules []sentinelaccess.ResourceRule
+	for _, rr := range g.Spec.ResourceRules {
+		resourceRules = append(resourceRules, sentinelaccess.ResourceRule{
+			URI:           rr.URI,
+			Decision:      sentinelaccess.PolicyDecision(rr.Decision),
+			RequiredTrust: sentinelaccess.TrustLevel(rr.RequiredTrust),
+		})
+	}
+	var rateLimits []sentinelaccess.RateLimit
+	for _, limit := range g.Spec.RateLimits {
+		rateLimits = append(rateLimits, sentinelaccess.RateLimit{
+			Tool:           limit.Tool,
+			SideEffect:     sentinelaccess.ToolSideEffect(limit.SideEffect),
+			CallsPerMinute: limit.CallsPerMinute,
+			CallsPerDay:    limit.CallsPerDay,
 		})
 	}
 	dis := g.Spec.Disabled
@@ -533,6 +661,9 @@ func grantFromV1(g *mcpv1alpha1.MCPAccessGrant) grantAPIBody {
 		PolicyVersion:      g.Spec.PolicyVersion,
 		Disabled:           &dis,
 		ToolRules:          rules,
+		PromptRules:        promptRules,
+		ResourceRules:      resourceRules,
+		RateLimits:         rateLimits,
 	}
 }
 
@@ -579,19 +710,24 @@ func httpAPIError(status int, body []byte) error {
 
 // ServerListItem is one row from the platform API runtime servers list.
 type ServerListItem struct {
-	Name        string            `json:"name"`
-	Namespace   string            `json:"namespace"`
-	TeamID      string            `json:"team_id,omitempty"`
-	Image       string            `json:"image,omitempty"`
-	ImageTag    string            `json:"imageTag,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Ready       string            `json:"ready"`
-	Status      string            `json:"status"`
-	Labels      map[string]string `json:"labels"`
-	Age         string            `json:"age"`
-	Endpoint    string            `json:"endpoint,omitempty"`
-	Tools       []ToolConfig      `json:"tools,omitempty"`
-	AccessJSON  map[string]any    `json:"access_json,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	TeamID    string `json:"team_id,omitempty"`
+	Image     string `json:"image,omitempty"`
+	ImageTag  string `json:"imageTag,omitempty"`
+	// ImageDigest is the digest a pinned server runs.
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImageVerification explains a failed digest resolution or signature
+	// check for the server's current spec.
+	ImageVerification string            `json:"imageVerification,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Ready             string            `json:"ready"`
+	Status            string            `json:"status"`
+	Labels            map[string]string `json:"labels"`
+	Age               string            `json:"age"`
+	Endpoint          string            `json:"endpoint,omitempty"`
+	Tools             []ToolConfig      `json:"tools,omitempty"`
+	AccessJSON        map[string]any    `json:"access_json,omitempty"`
 }
 
 type serverListResponse struct {
@@ -728,6 +728,14 @@ func waitForDeployedServer(ctx context.Context, plat *platformapi.PlatformClient
 				continue
 			}
 			last = &servers[i]
+			if verification := strings.TrimSpace(servers[i].ImageVerification); verification != "" {
+				// The operator will not roll out an image that fails
+				// verification, so waiting for readiness cannot succeed.
+				return platformapi.ServerListItem{}, core.NewWithSentinel(
+					nil,
+					fmt.Sprintf("server %s image verification failed: %s", name, verification),
+				)
+			}
 			if strings.EqualFold(strings.TrimSpace(servers[i].Ready), "true") || strings.EqualFold(strings.TrimSpace(servers[i].Status), "ready") {
 				if err := validateDeployedServerImage(servers[i], expectedImage, expectedTag); err != nil {
 					return platformapi.ServerListItem{}, err
@@ -977,6 +985,32 @@ func mergeDeployMetadata(spec *mcpv1alpha1.MCPServerSpec, src *metadata.ServerMe
 	if len(spec.SecretEnvVars) == 0 {
 		spec.SecretEnvVars = convertDeploySecretEnvVars(src.SecretEnvVars)
 	}
+	if len(spec.Volumes) == 0 && len(spec.VolumeMounts) == 0 {
+		spec.Volumes = metadata.ConvertVolumes(src.Volumes)
+		spec.VolumeMounts = metadata.ConvertVolumeMounts(src.VolumeMounts)
+	}
+	if spec.Transport == "" && spec.Stdio == nil {
+		spec.Transport = mcpv1alpha1.ServerTransport(src.Transport)
+		spec.Stdio = metadata.ConvertStdio(src.Stdio)
+	}
+	if len(spec.InitContainers) == 0 {
+		spec.InitContainers = metadata.ConvertContainers(src.InitContainers)
+	}
+	if len(spec.ExtraContainers) == 0 {
+		spec.ExtraContainers = metadata.ConvertContainers(src.ExtraContainers)
+	}
+	if spec.Scheduling == nil {
+		spec.Scheduling = metadata.ConvertScheduling(src.Scheduling)
+	}
+	if spec.Network == nil {
+		spec.Network = metadata.ConvertNetwork(src.Network)
+	}
+	if spec.DisruptionBudget == nil && src.DisruptionBudget != nil {
+		spec.DisruptionBudget = &mcpv1alpha1.DisruptionBudgetConfig{
+			Enabled:        src.DisruptionBudget.Enabled,
+			MaxUnavailable: src.DisruptionBudget.MaxUnavailable,
+		}
+	}
 	if src.Auth != nil {
 		spec.Auth = &mcpv1alpha1.AuthConfig{
 			Mode:            mcpv1alpha1.AuthMode(src.Auth.Mode),
@@ -1051,9 +1085,11 @@ func convertDeployInventory(items []metadata.InventoryItem) []mcpv1alpha1.Invent
 	out := make([]mcpv1alpha1.InventoryItem, 0, len(items))
 	for _, item := range items {
 		out = append(out, mcpv1alpha1.InventoryItem{
-			Name:        item.Name,
-			Description: item.Description,
-			Labels:      copyStringMap(item.Labels),
+			Name:          item.Name,
+			Description:   item.Description,
+			Labels:        copyStringMap(item.Labels),
+			URI:           item.URI,
+			RequiredTrust: mcpv1alpha1.TrustLevel(item.RequiredTrust),
 		})
 	}
 	return out
@@ -1258,6 +1258,46 @@ func TestDeployServerFailsWhenServerDoesNotBecomeReady(t *testing.T) {
 	}
 }
 
+func TestDeployServerReportsImageVerificationFailure(t *testing.T) {
+	t.Setenv("MCP_RUNTIME_CONFIG_DIR", t.TempDir())
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/runtime/teams/core":
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"team":{"slug":"core","name":"Core","namespace":"mcp-team-core"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/runtime/servers":
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"server":{"name":"data-utility","namespace":"mcp-team-core","ready":"0/0","status":"Pending","age":"0s"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/runtime/servers":
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"servers":[{"name":"data-utility","namespace":"mcp-team-core","ready":"0/0","status":"Error","age":"1s","imageVerification":"registry/data-utility:latest is not signed"}]}`))
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer api.Close()
+
+	t.Setenv(authfile.EnvAPIToken, "token-1")
+	t.Setenv(authfile.EnvAPIURL, api.URL)
+	origCfg := core.DefaultCLIConfig
+	origPoll := serverDeployPollInterval
+	core.DefaultCLIConfig = &core.CLIConfig{DeploymentTimeout: time.Minute}
+	serverDeployPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() {
+		core.DefaultCLIConfig = origCfg
+		serverDeployPollInterval = origPoll
+	})
+
+	mock := &core.MockExecutor{}
+	kubectl := core.NewTestKubectlClient(mock)
+	mgr := NewServerManager(kubectl, zap.NewNop())
+
+	err := mgr.DeployServer("data-utility", "", "core", "tenant", "data-utility", "latest", 1, 8088, 80, "", ".mcp", false)
+	if err == nil || !strings.Contains(err.Error(), "image verification failed: registry/data-utility:latest is not signed") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestDeployImageRefsEquivalentAcceptsScopedDisplayRefs(t *testing.T) {
 	t.Parallel()
 
@@ -1353,3 +1393,108 @@ func TestSelectDeployMetadataNameMatching(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeDeployMetadataVolumes(t *testing.T) {
+	src := &metadata.ServerMetadata{
+		Name:  "search",
+		Image: "registry.example.com/acme/search",
+		Volumes: []metadata.Volume{
+			{Name: "index", PersistentVolumeClaim: &metadata.PersistentVolumeClaimVolumeSource{Template: &metadata.PersistentVolumeClaimTemplate{Size: "10Gi"}}},
+		},
+		VolumeMounts: []metadata.VolumeMount{{Name: "index", MountPath: "/var/lib/search"}},
+	}
+
+	spec := &mcpv1alpha1.MCPServerSpec{}
+	mergeDeployMetadata(spec, src)
+	if len(spec.Volumes) != 1 || spec.Volumes[0].PersistentVolumeClaim.Template.Size != "10Gi" {
+		t.Fatalf("volumes = %#v, want the metadata volume", spec.Volumes)
+	}
+	if len(spec.VolumeMounts) != 1 || spec.VolumeMounts[0].MountPath != "/var/lib/search" {
+		t.Fatalf("volumeMounts = %#v, want the metadata mount", spec.VolumeMounts)
+	}
+
+	spec = &mcpv1alpha1.MCPServerSpec{
+		Volumes:      []mcpv1alpha1.Volume{{Name: "scratch", EmptyDir: &mcpv1alpha1.EmptyDirVolumeSource{}}},
+		VolumeMounts: []mcpv1alpha1.VolumeMount{{Name: "scratch", MountPath: "/tmp"}},
+	}
+	mergeDeployMetadata(spec, src)
+	if len(spec.Volumes) != 1 || spec.Volumes[0].Name != "scratch" || spec.VolumeMounts[0].MountPath != "/tmp" {
+		t.Fatalf("spec volumes were replaced: %#v %#v", spec.Volumes, spec.VolumeMounts)
+	}
+}
+
+func TestMergeDeployMetadataContainers(t *testing.T) {
+	src := &metadata.ServerMetadata{
+		Name:           "payments",
+		Image:          "registry.example.com/acme/payments",
+		InitContainers: []metadata.Container{{Name: "fetch-secrets", Image: "registry.example.com/acme/vault-agent", Args: []string{"-once"}}},
+		ExtraContainers: []metadata.Container{{
+			Name:  "cloud-sql-proxy",
+			Image: "gcr.io/cloud-sql-connectors/cloud-sql-proxy:2",
+			Ports: []metadata.ContainerPort{{Name: "sql", ContainerPort: 5432}},
+		}},
+	}
+
+	spec := &mcpv1alpha1.MCPServerSpec{}
+	mergeDeployMetadata(spec, src)
+	if len(spec.InitContainers) != 1 || spec.InitContainers[0].Name != "fetch-secrets" || spec.InitContainers[0].Args[0] != "-once" {
+		t.Fatalf("initContainers = %#v, want the metadata init container", spec.InitContainers)
+	}
+	if len(spec.ExtraContainers) != 1 || spec.ExtraContainers[0].Ports[0].ContainerPort != 5432 || spec.ExtraContainers[0].Ports[0].Expose {
+		t.Fatalf("extraContainers = %#v, want the unexposed proxy", spec.ExtraContainers)
+	}
+
+	spec = &mcpv1alpha1.MCPServerSpec{ExtraContainers: []mcpv1alpha1.Container{{Name: "log-shipper", Image: "fluent/fluent-bit"}}}
+	mergeDeployMetadata(spec, src)
+	if len(spec.ExtraContainers) != 1 || spec.ExtraContainers[0].Name != "log-shipper" {
+		t.Fatalf("spec extraContainers were replaced: %#v", spec.ExtraContainers)
+	}
+}
+
+func TestMergeDeployMetadataStdioTransport(t *testing.T) {
+	src := &metadata.ServerMetadata{
+		Name:      "filesystem",
+		Image:     "node:22-alpine",
+		Transport: "stdio",
+		Stdio:     &metadata.StdioConfig{Command: []string{"npx", "-y", "@modelcontextprotocol/server-filesystem"}, Args: []string{"/data"}, SessionMode: "shared"},
+	}
+
+	spec := &mcpv1alpha1.MCPServerSpec{}
+	mergeDeployMetadata(spec, src)
+	if spec.Transport != mcpv1alpha1.ServerTransportStdio || spec.Stdio == nil || spec.Stdio.Command[0] != "npx" || spec.Stdio.Args[0] != "/data" || spec.Stdio.SessionMode != mcpv1alpha1.StdioSessionModeShared {
+		t.Fatalf("transport = %q, stdio = %#v, want the metadata stdio command", spec.Transport, spec.Stdio)
+	}
+
+	spec = &mcpv1alpha1.MCPServerSpec{Transport: mcpv1alpha1.ServerTransportHTTP}
+	mergeDeployMetadata(spec, src)
+	if spec.Transport != mcpv1alpha1.ServerTransportHTTP || spec.Stdio != nil {
+		t.Fatalf("spec transport was replaced: %q, %#v", spec.Transport, spec.Stdio)
+	}
+}
+
+func TestMergeDeployMetadataScheduling(t *testing.T) {
+	src := &metadata.ServerMetadata{
+		Name:  "payments",
+		Image: "registry.example.com/acme/payments",
+		Scheduling: &metadata.SchedulingConfig{
+			NodeSelector: map[string]string{"pool": "mcp"},
+			Tolerations:  []metadata.Toleration{{Key: "dedicated", Operator: "Exists"}},
+		},
+		DisruptionBudget: &metadata.DisruptionBudgetConfig{Enabled: true},
+	}
+
+	spec := &mcpv1alpha1.MCPServerSpec{}
+	mergeDeployMetadata(spec, src)
+	if spec.Scheduling == nil || spec.Scheduling.NodeSelector["pool"] != "mcp" || len(spec.Scheduling.Tolerations) != 1 {
+		t.Fatalf("scheduling = %#v, want the metadata settings", spec.Scheduling)
+	}
+	if spec.DisruptionBudget == nil || !spec.DisruptionBudget.Enabled {
+		t.Fatalf("disruptionBudget = %#v, want enabled", spec.DisruptionBudget)
+	}
+
+	spec = &mcpv1alpha1.MCPServerSpec{Scheduling: &mcpv1alpha1.SchedulingConfig{PriorityClassName: "batch"}}
+	mergeDeployMetadata(spec, src)
+	if spec.Scheduling.PriorityClassName != "batch" || spec.Scheduling.NodeSelector != nil {
+		t.Fatalf("spec scheduling was replaced: %#v", spec.Scheduling)
+	}
+}
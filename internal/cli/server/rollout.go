@@ -0,0 +1,186 @@
+package server
+
+// rollout.go implements `server rollout status|promote|abort` — a view of the
+// operator's canary analysis and a way to override it. Promote and abort set
+// the mcpruntime.org/rollout-action annotation; the operator applies the action
+// on its next reconcile and removes the annotation.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/internal/cli/core"
+	"mcp-runtime/internal/cli/kubeerr"
+)
+
+func newRolloutCmd(mgr *ServerManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Inspect and steer canary rollouts (requires --use-kube)",
+	}
+
+	var statusNamespace string
+	statusCmd := &cobra.Command{
+		Use:   "status [name]",
+		Short: "Show the canary analysis state for a server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.RolloutStatus(args[0], statusNamespace)
+		},
+	}
+	statusCmd.Flags().StringVar(&statusNamespace, "namespace", core.NamespaceMCPServers, "Namespace")
+
+	var promoteNamespace string
+	promoteCmd := &cobra.Command{
+		Use:   "promote [name]",
+		Short: "Promote the canary image to stable without waiting for analysis",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.RolloutAction(args[0], promoteNamespace, mcpv1alpha1.RolloutActionPromote)
+		},
+	}
+	promoteCmd.Flags().StringVar(&promoteNamespace, "namespace", core.NamespaceMCPServers, "Namespace")
+
+	var abortNamespace string
+	abortCmd := &cobra.Command{
+		Use:   "abort [name]",
+		Short: "Stop the canary analysis and serve the stable image on both tracks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.RolloutAction(args[0], abortNamespace, mcpv1alpha1.RolloutActionAbort)
+		},
+	}
+	abortCmd.Flags().StringVar(&abortNamespace, "namespace", core.NamespaceMCPServers, "Namespace")
+
+	cmd.AddCommand(statusCmd, promoteCmd, abortCmd)
+	return cmd
+}
+
+// RolloutStatus prints status.rollout for a server.
+func (m *ServerManager) RolloutStatus(name, namespace string) error {
+	if err := m.requireKubectlForMutation(); err != nil {
+		return err
+	}
+	name, namespace, err := validateServerInput(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	cmd, err := m.kubectl.CommandArgs([]string{"get", "mcpserver", name, "-n", namespace, "-o", "json"})
+	if err != nil {
+		return err
+	}
+	output, execErr := cmd.CombinedOutput()
+	if execErr != nil {
+		detail := kubeerr.CommandDetail(string(output), execErr)
+		wrappedErr := core.WrapWithSentinelAndContext(
+			core.ErrGetMCPServerFailed,
+			execErr,
+			kubeerr.DirectModeFailureMessage(fmt.Sprintf("failed to get server %q in namespace %q", name, namespace), detail),
+			map[string]any{"server": name, "namespace": namespace, "component": "server"},
+		)
+		core.Error("Failed to get rollout status")
+		core.LogStructuredError(m.logger, wrappedErr, "Failed to get rollout status")
+		return wrappedErr
+	}
+
+	var server mcpv1alpha1.MCPServer
+	if err := json.Unmarshal(output, &server); err != nil {
+		wrappedErr := core.WrapWithSentinelAndContext(
+			core.ErrGetMCPServerFailed,
+			err,
+			fmt.Sprintf("failed to parse server %q: %v", name, err),
+			map[string]any{"server": name, "namespace": namespace, "component": "server"},
+		)
+		core.Error("Failed to get rollout status")
+		core.LogStructuredError(m.logger, wrappedErr, "Failed to get rollout status")
+		return wrappedErr
+	}
+	printRolloutStatus(os.Stdout, &server)
+	return nil
+}
+
+func printRolloutStatus(w io.Writer, server *mcpv1alpha1.MCPServer) {
+	rollout := server.Status.Rollout
+	if rollout == nil {
+		if server.Spec.Rollout == nil || server.Spec.Rollout.Analysis == nil {
+			_, _ = fmt.Fprintf(w, "Server %s has no canary analysis configured (spec.rollout.analysis).\n", server.Name)
+		} else {
+			_, _ = fmt.Fprintf(w, "Server %s has not reported rollout status yet.\n", server.Name)
+		}
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Phase:\t%s\n", rollout.Phase)
+	_, _ = fmt.Fprintf(tw, "Stable image:\t%s\n", valueOrDash(rollout.StableImage))
+	_, _ = fmt.Fprintf(tw, "Canary image:\t%s\n", valueOrDash(rollout.CanaryImage))
+	if rollout.Phase == mcpv1alpha1.RolloutPhaseProgressing && server.Spec.Rollout != nil && server.Spec.Rollout.Analysis != nil {
+		_, _ = fmt.Fprintf(tw, "Step:\t%d/%d\n", rollout.Step+1, len(server.Spec.Rollout.Analysis.Steps))
+		if rollout.StepStartedAt != nil {
+			_, _ = fmt.Fprintf(tw, "Step started:\t%s\n", rollout.StepStartedAt.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+	}
+	if last := rollout.LastAnalysis; last != nil {
+		result := "failed"
+		if last.Passed {
+			result = "passed"
+		}
+		_, _ = fmt.Fprintf(tw, "Last analysis:\tstep %d %s (error rate %s, p95 %s, denial delta %s)\n",
+			last.Step+1, result, valueOrDash(last.ErrorRate), valueOrDash(last.P95Latency), valueOrDash(last.DenialRateDelta))
+	}
+	if rollout.Message != "" {
+		_, _ = fmt.Fprintf(tw, "Message:\t%s\n", rollout.Message)
+	}
+	if action := server.Annotations[mcpv1alpha1.RolloutActionAnnotation]; action != "" {
+		_, _ = fmt.Fprintf(tw, "Pending action:\t%s\n", action)
+	}
+	_ = tw.Flush()
+}
+
+func valueOrDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
+
+// RolloutAction asks the operator to promote or abort a server's canary.
+func (m *ServerManager) RolloutAction(name, namespace, action string) error {
+	if err := m.requireKubectlForMutation(); err != nil {
+		return err
+	}
+	name, namespace, err := validateServerInput(name, namespace)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case mcpv1alpha1.RolloutActionPromote, mcpv1alpha1.RolloutActionAbort:
+	default:
+		return core.NewWithSentinel(nil, fmt.Sprintf("unsupported rollout action %q (use promote|abort)", action))
+	}
+
+	args := []string{"annotate", "mcpserver", name, "-n", namespace, mcpv1alpha1.RolloutActionAnnotation + "=" + action, "--overwrite"}
+	// #nosec G204 -- name/namespace validated via validateServerInput; action is a fixed value.
+	if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+		wrappedErr := core.WrapWithSentinelAndContext(
+			nil,
+			err,
+			kubeerr.DirectModeFailureMessage(fmt.Sprintf("failed to request rollout %s for server %q in namespace %q", action, name, namespace), err.Error()),
+			map[string]any{"server": name, "namespace": namespace, "action": action, "component": "server"},
+		)
+		core.Error("Failed to request rollout action")
+		core.LogStructuredError(m.logger, wrappedErr, "Failed to request rollout action")
+		return wrappedErr
+	}
+	core.Info(fmt.Sprintf("Requested rollout %s for %s; the operator applies it on its next reconcile. Check with `mcp-runtime server rollout status %s`.", action, name, name))
+	return nil
+}
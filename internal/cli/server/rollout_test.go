@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/internal/cli/core"
+)
+
+func TestServerManager_RolloutActionAnnotatesServer(t *testing.T) {
+	mock := &core.MockExecutor{}
+	mgr := newKubeTestServerManager(core.NewTestKubectlClient(mock))
+
+	if err := mgr.RolloutAction("payments", "team-a", mcpv1alpha1.RolloutActionPromote); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmd := mock.LastCommand()
+	for _, want := range []string{"annotate", "mcpserver", "payments", "-n", "team-a", "mcpruntime.org/rollout-action=promote", "--overwrite"} {
+		if !contains(cmd.Args, want) {
+			t.Fatalf("expected %q in args, got %v", want, cmd.Args)
+		}
+	}
+
+	if err := mgr.RolloutAction("payments", "team-a", "resume"); err == nil {
+		t.Fatal("expected an error for an unsupported action")
+	}
+}
+
+func TestServerManager_RolloutCommandsRequireUseKube(t *testing.T) {
+	mgr := NewServerManager(core.NewTestKubectlClient(&core.MockExecutor{}), nil)
+	if err := mgr.RolloutAction("payments", "team-a", mcpv1alpha1.RolloutActionAbort); err == nil || !strings.Contains(err.Error(), "--use-kube") {
+		t.Fatalf("RolloutAction() error = %v, want --use-kube guidance", err)
+	}
+	if err := mgr.RolloutStatus("payments", "team-a"); err == nil || !strings.Contains(err.Error(), "--use-kube") {
+		t.Fatalf("RolloutStatus() error = %v, want --use-kube guidance", err)
+	}
+}
+
+func TestServerManager_RolloutStatusReadsServer(t *testing.T) {
+	mock := &core.MockExecutor{
+		DefaultOutput: []byte(`{"metadata":{"name":"payments"},"status":{"rollout":{"phase":"Stable","stableImage":"example.com/payments:v1"}}}`),
+	}
+	mgr := newKubeTestServerManager(core.NewTestKubectlClient(mock))
+
+	if err := mgr.RolloutStatus("payments", "team-a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmd := mock.LastCommand()
+	for _, want := range []string{"get", "mcpserver", "payments", "-n", "team-a", "json"} {
+		if !contains(cmd.Args, want) {
+			t.Fatalf("expected %q in args, got %v", want, cmd.Args)
+		}
+	}
+}
+
+func TestPrintRolloutStatus(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "payments",
+			Annotations: map[string]string{mcpv1alpha1.RolloutActionAnnotation: "abort"},
+		},
+		Spec: mcpv1alpha1.MCPServerSpec{Rollout: &mcpv1alpha1.RolloutConfig{
+			Strategy: mcpv1alpha1.RolloutStrategyCanary,
+			Analysis: &mcpv1alpha1.CanaryAnalysis{Steps: []mcpv1alpha1.CanaryAnalysisStep{{Duration: "5m"}, {Duration: "10m"}}},
+		}},
+		Status: mcpv1alpha1.MCPServerStatus{Rollout: &mcpv1alpha1.RolloutStatus{
+			Phase:         mcpv1alpha1.RolloutPhaseProgressing,
+			StableImage:   "example.com/payments:v1",
+			CanaryImage:   "example.com/payments:v2",
+			Step:          1,
+			StepStartedAt: &metav1.Time{Time: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)},
+			LastAnalysis:  &mcpv1alpha1.CanaryAnalysisResult{Step: 0, Passed: true, ErrorRate: "0.0100"},
+		}},
+	}
+
+	var out bytes.Buffer
+	printRolloutStatus(&out, server)
+	for _, want := range []string{
+		"Progressing",
+		"example.com/payments:v2",
+		"2/2",
+		"2026-01-01T12:00:00Z",
+		"step 1 passed (error rate 0.0100, p95 -, denial delta -)",
+		"Pending action:  abort",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printRolloutStatus(&out, &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "plain"}})
+	if !strings.Contains(out.String(), "no canary analysis configured") {
+		t.Fatalf("output = %q", out.String())
+	}
+}
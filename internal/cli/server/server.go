@@ -301,6 +301,7 @@ For pushing images, use 'registry push'.`,
 	inspectCmd := &cobra.Command{
 		Use:   "inspect [name]",
 		Short: "Show the rendered gateway policy document for a server",
+		Long:  "Show the rendered gateway policy document for a server, including the egress rules declared under spec.network.egress.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return mgr.InspectServerPolicy(args[0], policyNamespace)
@@ -315,7 +316,7 @@ For pushing images, use 'registry push'.`,
 	}
 	buildCmd.AddCommand(newBuildImageCmd(mgr.Logger()))
 
-	cmd.AddCommand(initCmd, listCmd, getCmd, createCmd, applyCmd, deployCmd, generateCmd, exportCmd, patchCmd, deleteCmd, logsCmd, statusCmd, connectCmd, policyCmd, buildCmd, newValidateCmd())
+	cmd.AddCommand(initCmd, listCmd, getCmd, createCmd, applyCmd, deployCmd, generateCmd, exportCmd, patchCmd, deleteCmd, logsCmd, statusCmd, connectCmd, policyCmd, newRolloutCmd(mgr), buildCmd, newValidateCmd())
 	return cmd
 }
 
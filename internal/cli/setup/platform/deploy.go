@@ -544,7 +544,9 @@ func renderOperatorManagerManifest(operatorImage, gatewayProxyImage string, oper
 	if existingEnvValue != nil {
 		existingGatewayOTLPEndpoint = existingEnvValue(gatewayOTELExporterOTLPEndpointEnv)
 	}
-	if envVars := operatorEnvOverrides(gatewayProxyImage, existingGatewayOTLPEndpoint); len(envVars) > 0 {
+	// The stdio bridge binary ships in the operator image.
+	envVars := append(operatorEnvOverrides(gatewayProxyImage, existingGatewayOTLPEndpoint), operatorEnvVar{Name: stdioBridgeImageEnv, Value: operatorImage})
+	if len(envVars) > 0 {
 		envMap := make(map[string]string, len(envVars))
 		for _, ev := range envVars {
 			envMap[ev.Name] = ev.Value
@@ -690,7 +692,9 @@ func deployOperatorManifestsWithKubectl(kubectl core.KubectlRunner, logger *zap.
 
 	// Inject environment variables if provided.
 	existingGatewayOTLPEndpoint := existingOperatorEnvValue(kubectl, gatewayOTELExporterOTLPEndpointEnv)
-	if envVars := operatorEnvOverrides(gatewayProxyImage, existingGatewayOTLPEndpoint); len(envVars) > 0 {
+	// The stdio bridge binary ships in the operator image.
+	envVars := append(operatorEnvOverrides(gatewayProxyImage, existingGatewayOTLPEndpoint), operatorEnvVar{Name: stdioBridgeImageEnv, Value: operatorImage})
+	if len(envVars) > 0 {
 		envMap := make(map[string]string, len(envVars))
 		for _, ev := range envVars {
 			envMap[ev.Name] = ev.Value
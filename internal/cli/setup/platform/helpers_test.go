@@ -2948,6 +2948,9 @@ func TestDeployOperatorManifestsWithKubectl(t *testing.T) {
 	if !strings.Contains(managerManifest, "name: MCP_GATEWAY_PROXY_IMAGE") || !strings.Contains(managerManifest, "value: "+gatewayProxyImage) {
 		t.Fatalf("expected manager manifest to include gateway proxy image env, got:\n%s", managerManifest)
 	}
+	if !strings.Contains(managerManifest, "name: "+stdioBridgeImageEnv) || !strings.Contains(managerManifest, "value: "+operatorImage) {
+		t.Fatalf("expected manager manifest to point the stdio bridge at the operator image, got:\n%s", managerManifest)
+	}
 	if !strings.Contains(managerManifest, "name: MCP_SENTINEL_INGEST_URL") || !strings.Contains(managerManifest, "value: "+defaultAnalyticsIngestURL) {
 		t.Fatalf("expected manager manifest to include analytics ingest env, got:\n%s", managerManifest)
 	}
@@ -30,6 +30,8 @@ const defaultAnalyticsIngestURL = "http://mcp-sentinel-ingest.mcp-sentinel.svc.c
 
 const gatewayOTELExporterOTLPEndpointEnv = "MCP_GATEWAY_OTEL_EXPORTER_OTLP_ENDPOINT"
 
+const stdioBridgeImageEnv = "MCP_STDIO_BRIDGE_IMAGE"
+
 const defaultGatewayOTELExporterOTLPEndpoint = "http://otel-collector.mcp-sentinel.svc.cluster.local:4318"
 
 const gatewayProxyDockerfilePath = "services/mcp-gateway/Dockerfile"
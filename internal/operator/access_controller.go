@@ -0,0 +1,447 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+	"mcp-runtime/pkg/policy"
+)
+
+// Phases reported on MCPAccessGrant and MCPAgentSession status.
+const (
+	AccessPhasePending  = "Pending"
+	AccessPhaseActive   = "Active"
+	AccessPhaseDisabled = "Disabled"
+	AccessPhaseRevoked  = "Revoked"
+	AccessPhaseExpired  = "Expired"
+)
+
+// MCPAccessGrantReconciler reports on MCPAccessGrant status whether the grant
+// reached the referenced server's gateway policy. It never changes the policy
+// itself; MCPServerReconciler renders grants into the policy ConfigMap.
+type MCPAccessGrantReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits an Event when the grant's phase changes. Nil disables
+	// events.
+	Recorder events.EventRecorder
+
+	// SharedRateLimits reports that gateways share rate limit counters
+	// through the operator, so limits hold across gateway replicas. Without
+	// it, grants with rate limits on servers that run several gateway
+	// replicas get the RateLimitsPerReplica condition.
+	SharedRateLimits bool
+}
+
+// MCPAgentSessionReconciler reports on MCPAgentSession status whether the
+// session reached the referenced server's gateway policy and whether it is
+// revoked or expired.
+type MCPAgentSessionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits an Event when the session's phase changes or an expired
+	// session is deleted. Nil disables events.
+	Recorder events.EventRecorder
+
+	// ExpiredSessionRetention, when positive, deletes sessions that have been
+	// expired for longer than this duration. Zero keeps expired sessions.
+	ExpiredSessionRetention time.Duration
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+}
+
+// accessPolicyObservation is what a grant or session controller learned about
+// the referenced server and its rendered gateway policy.
+type accessPolicyObservation struct {
+	serverFound    bool
+	serverReason   string
+	serverMessage  string
+	policyRendered bool
+	policyReason   string
+	policyMessage  string
+	revision       string
+	// gatewayReplicas is the most gateway pods the server runs at once.
+	gatewayReplicas int32
+}
+
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpaccessgrants/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpagentsessions,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpagentsessions/status,verbs=get;update;patch
+
+// Reconcile updates the status of one MCPAccessGrant.
+func (r *MCPAccessGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	grant := &mcpv1alpha1.MCPAccessGrant{}
+	if err := r.Get(ctx, req.NamespacedName, grant); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	observed, err := observeAccessPolicy(ctx, r.Client, grant.Namespace, grant.Spec.ServerRef, "grant", func(doc *policy.Document) bool {
+		for _, rendered := range doc.Grants {
+			if rendered.Name == grant.Name && string(rendered.Namespace) == grant.Namespace {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := mcpv1alpha1.MCPAccessGrantStatus{Conditions: append([]metav1.Condition(nil), grant.Status.Conditions...)}
+	observed.setConditions(&status.Conditions, grant.Generation)
+	status.PolicyRevision = observed.revision
+	status.ObservedGeneration = grant.Generation
+	rateLimitsPerReplica := setRateLimitsPerReplica(&status.Conditions, grant, observed, r.SharedRateLimits)
+	switch {
+	case grant.Spec.Disabled:
+		status.Phase = AccessPhaseDisabled
+		status.Message = "grant is disabled"
+	case observed.serverFound && observed.policyRendered:
+		status.Phase = AccessPhaseActive
+		status.Message = observed.policyMessage
+	default:
+		status.Phase = AccessPhasePending
+		status.Message = observed.pendingMessage()
+	}
+
+	if equality.Semantic.DeepEqual(grant.Status, status) {
+		return ctrl.Result{}, nil
+	}
+	previousPhase := grant.Status.Phase
+	wasPerReplica := meta.IsStatusConditionTrue(grant.Status.Conditions, string(operatorutil.RateLimitsPerReplica))
+	grant.Status = status
+	if err := r.Status().Update(ctx, grant); err != nil {
+		return ctrl.Result{}, ignoreStatusConflict(ctx, err)
+	}
+	recordAccessPhaseChange(r.Recorder, grant, previousPhase, status.Phase, status.Message)
+	if rateLimitsPerReplica != "" && !wasPerReplica {
+		recordEvent(r.Recorder, grant, corev1.EventTypeWarning, EventReasonRateLimitsPerReplica, "UpdateStatus", "%s", rateLimitsPerReplica)
+	}
+	return ctrl.Result{}, nil
+}
+
+// setRateLimitsPerReplica reports whether the grant's rate limits are split
+// across gateway replicas. Without shared counters each replica keeps its
+// own, so with n replicas a subject can make up to n times the configured
+// calls. It returns the condition message when they are.
+func setRateLimitsPerReplica(conditions *[]metav1.Condition, grant *mcpv1alpha1.MCPAccessGrant, observed accessPolicyObservation, shared bool) string {
+	if len(grant.Spec.RateLimits) == 0 || !observed.policyRendered {
+		meta.RemoveStatusCondition(conditions, string(operatorutil.RateLimitsPerReplica))
+		return ""
+	}
+	if shared {
+		operatorutil.SetCondition(conditions, operatorutil.RateLimitsPerReplica, false, "SharedCounters",
+			"rate limits are counted by the operator for all of the server's gateway replicas", grant.Generation)
+		return ""
+	}
+	if observed.gatewayReplicas <= 1 {
+		operatorutil.SetCondition(conditions, operatorutil.RateLimitsPerReplica, false, "SingleReplica",
+			"rate limits are counted by the server's only gateway replica", grant.Generation)
+		return ""
+	}
+	message := fmt.Sprintf("the server can run %d gateway replicas, which count rate limits on their own, so a subject can make up to %d times the configured calls",
+		observed.gatewayReplicas, observed.gatewayReplicas)
+	operatorutil.SetCondition(conditions, operatorutil.RateLimitsPerReplica, true, "MultipleReplicas", message, grant.Generation)
+	return message
+}
+
+// maxGatewayReplicas is the most pods running the server's gateway at once
+// outside of rolling updates: spec.replicas, which includes canary replicas,
+// or the autoscaler's maximum when that is higher.
+func maxGatewayReplicas(server *mcpv1alpha1.MCPServer) int32 {
+	replicas := int32(1)
+	if server.Spec.Replicas != nil {
+		replicas = *server.Spec.Replicas
+	}
+	if server.Spec.Autoscaling != nil && server.Spec.Autoscaling.MaxReplicas > replicas {
+		replicas = server.Spec.Autoscaling.MaxReplicas
+	}
+	return replicas
+}
+
+// Reconcile updates the status of one MCPAgentSession, requeues it for its
+// expiry, and deletes it once it has been expired longer than the retention.
+func (r *MCPAgentSessionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	session := &mcpv1alpha1.MCPAgentSession{}
+	if err := r.Get(ctx, req.NamespacedName, session); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	expired := session.Spec.ExpiresAt != nil && !now.Before(session.Spec.ExpiresAt.Time)
+	if expired && r.ExpiredSessionRetention > 0 {
+		if expiredFor := now.Sub(session.Spec.ExpiresAt.Time); expiredFor >= r.ExpiredSessionRetention {
+			log.FromContext(ctx).Info("Deleting expired MCPAgentSession", "name", session.Name, "namespace", session.Namespace, "expiredFor", expiredFor.String())
+			if err := r.Delete(ctx, session); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+			recordEvent(r.Recorder, session, corev1.EventTypeNormal, EventReasonExpiredSessionDeleted, "Delete",
+				"Deleted session expired for %s", expiredFor.Round(time.Second))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	observed, err := observeAccessPolicy(ctx, r.Client, session.Namespace, session.Spec.ServerRef, "session", func(doc *policy.Document) bool {
+		for _, rendered := range doc.Sessions {
+			if string(rendered.Name) == session.Name && string(rendered.Namespace) == session.Namespace {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := mcpv1alpha1.MCPAgentSessionStatus{Conditions: append([]metav1.Condition(nil), session.Status.Conditions...)}
+	observed.setConditions(&status.Conditions, session.Generation)
+	status.PolicyRevision = observed.revision
+	status.ObservedGeneration = session.Generation
+	// Gateways record activity through the activation server.
+	status.LastActiveAt = session.Status.LastActiveAt
+	revokedMessage := "session is not revoked"
+	if session.Spec.Revoked {
+		revokedMessage = "session is revoked"
+	}
+	operatorutil.SetCondition(&status.Conditions, operatorutil.Revoked, session.Spec.Revoked, conditionReason(session.Spec.Revoked, "Revoked", "NotRevoked"), revokedMessage, session.Generation)
+	expiredMessage := "session has no expiry"
+	if session.Spec.ExpiresAt != nil {
+		expiredMessage = "session expires at " + session.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+		if expired {
+			expiredMessage = "session expired at " + session.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+	}
+	operatorutil.SetCondition(&status.Conditions, operatorutil.Expired, expired, conditionReason(expired, "Expired", "NotExpired"), expiredMessage, session.Generation)
+	switch {
+	case session.Spec.Revoked:
+		status.Phase = AccessPhaseRevoked
+		status.Message = revokedMessage
+	case expired:
+		status.Phase = AccessPhaseExpired
+		status.Message = expiredMessage
+	case observed.serverFound && observed.policyRendered:
+		status.Phase = AccessPhaseActive
+		status.Message = observed.policyMessage
+	default:
+		status.Phase = AccessPhasePending
+		status.Message = observed.pendingMessage()
+	}
+
+	var result ctrl.Result
+	switch {
+	case session.Spec.ExpiresAt != nil && !expired:
+		result.RequeueAfter = session.Spec.ExpiresAt.Sub(now)
+	case expired && r.ExpiredSessionRetention > 0:
+		result.RequeueAfter = session.Spec.ExpiresAt.Add(r.ExpiredSessionRetention).Sub(now)
+	}
+
+	if equality.Semantic.DeepEqual(session.Status, status) {
+		return result, nil
+	}
+	previousPhase := session.Status.Phase
+	session.Status = status
+	if err := r.Status().Update(ctx, session); err != nil {
+		return result, ignoreStatusConflict(ctx, err)
+	}
+	recordAccessPhaseChange(r.Recorder, session, previousPhase, status.Phase, status.Message)
+	return result, nil
+}
+
+// recordAccessPhaseChange emits an Event for a grant or session whose phase
+// changed. Falling back to Pending after the first observation means the
+// object dropped out of the gateway policy, so it is a Warning.
+func recordAccessPhaseChange(recorder events.EventRecorder, obj runtime.Object, previous, phase, message string) {
+	if previous == phase {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == AccessPhasePending && previous != "" {
+		eventType = corev1.EventTypeWarning
+	}
+	if previous == "" {
+		recordEvent(recorder, obj, eventType, EventReasonAccessPhaseChanged, "UpdateStatus", "Phase is %s: %s", phase, message)
+		return
+	}
+	recordEvent(recorder, obj, eventType, EventReasonAccessPhaseChanged, "UpdateStatus", "Phase changed from %s to %s: %s", previous, phase, message)
+}
+
+// observeAccessPolicy looks up the server a grant or session references and
+// whether its rendered gateway policy includes the object, as decided by
+// included.
+func observeAccessPolicy(ctx context.Context, c client.Client, namespace string, ref mcpv1alpha1.ServerReference, kind string, included func(*policy.Document) bool) (accessPolicyObservation, error) {
+	serverKey := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if serverKey.Namespace == "" {
+		serverKey.Namespace = namespace
+	}
+	server := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, serverKey, server); err != nil {
+		if !errors.IsNotFound(err) {
+			return accessPolicyObservation{}, err
+		}
+		message := fmt.Sprintf("MCPServer %s not found", serverKey)
+		return accessPolicyObservation{
+			serverReason:  "ServerNotFound",
+			serverMessage: message,
+			policyReason:  "ServerNotFound",
+			policyMessage: message,
+		}, nil
+	}
+
+	observed := accessPolicyObservation{
+		serverFound:   true,
+		serverReason:  "ServerFound",
+		serverMessage: fmt.Sprintf("MCPServer %s found", serverKey),
+	}
+	if !gatewayEnabled(server) {
+		observed.policyReason = "GatewayDisabled"
+		observed.policyMessage = fmt.Sprintf("MCPServer %s has no gateway, so no policy is rendered", serverKey)
+		return observed, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: gatewayPolicyConfigMapName(server.Name), Namespace: server.Namespace}, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return accessPolicyObservation{}, err
+		}
+		observed.policyReason = "PolicyPending"
+		observed.policyMessage = fmt.Sprintf("gateway policy for MCPServer %s has not been rendered yet", serverKey)
+		return observed, nil
+	}
+	var doc policy.Document
+	if err := json.Unmarshal([]byte(configMap.Data[gatewayPolicyFileName]), &doc); err != nil {
+		observed.policyReason = "PolicyInvalid"
+		observed.policyMessage = fmt.Sprintf("gateway policy for MCPServer %s cannot be read: %v", serverKey, err)
+		return observed, nil
+	}
+	observed.revision = doc.Revision
+	observed.gatewayReplicas = maxGatewayReplicas(server)
+	if !included(&doc) {
+		observed.policyReason = "NotInPolicy"
+		observed.policyMessage = fmt.Sprintf("gateway policy revision %s does not include this %s yet", doc.Revision, kind)
+		return observed, nil
+	}
+	observed.policyRendered = true
+	observed.policyReason = "Rendered"
+	observed.policyMessage = fmt.Sprintf("included in gateway policy revision %s", doc.Revision)
+	return observed, nil
+}
+
+func (o accessPolicyObservation) setConditions(conditions *[]metav1.Condition, generation int64) {
+	operatorutil.SetCondition(conditions, operatorutil.ServerFound, o.serverFound, o.serverReason, o.serverMessage, generation)
+	operatorutil.SetCondition(conditions, operatorutil.PolicyRendered, o.policyRendered, o.policyReason, o.policyMessage, generation)
+}
+
+func (o accessPolicyObservation) pendingMessage() string {
+	if !o.serverFound {
+		return o.serverMessage
+	}
+	return o.policyMessage
+}
+
+func conditionReason(value bool, whenTrue, whenFalse string) string {
+	if value {
+		return whenTrue
+	}
+	return whenFalse
+}
+
+// ignoreStatusConflict drops status update conflicts: the newer object
+// triggers another reconcile that recomputes the status.
+func ignoreStatusConflict(ctx context.Context, err error) error {
+	if errors.IsConflict(err) {
+		log.FromContext(ctx).V(1).Info("Status update conflict, will retry on next reconcile")
+		return nil
+	}
+	return client.IgnoreNotFound(err)
+}
+
+func (r *MCPAccessGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcpaccessgrant-status").
+		For(&mcpv1alpha1.MCPAccessGrant{}).
+		Watches(&mcpv1alpha1.MCPServer{}, handler.EnqueueRequestsFromMapFunc(accessRequestsForServer(r.Client, newGrantList))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(accessRequestsForPolicyConfigMap(r.Client, newGrantList))).
+		Complete(r)
+}
+
+func (r *MCPAgentSessionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcpagentsession-status").
+		For(&mcpv1alpha1.MCPAgentSession{}).
+		Watches(&mcpv1alpha1.MCPServer{}, handler.EnqueueRequestsFromMapFunc(accessRequestsForServer(r.Client, newSessionList))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(accessRequestsForPolicyConfigMap(r.Client, newSessionList))).
+		Complete(r)
+}
+
+func newGrantList() client.ObjectList   { return &mcpv1alpha1.MCPAccessGrantList{} }
+func newSessionList() client.ObjectList { return &mcpv1alpha1.MCPAgentSessionList{} }
+
+// accessRequestsForServer maps an MCPServer event to the grants or sessions
+// (selected by newList) that reference it.
+func accessRequestsForServer(c client.Client, newList func() client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []ctrl.Request {
+		server, ok := obj.(*mcpv1alpha1.MCPServer)
+		if !ok {
+			return nil
+		}
+		return accessRequestsReferencing(ctx, c, newList(), server)
+	}
+}
+
+// accessRequestsForPolicyConfigMap maps a change to a server's gateway policy
+// ConfigMap to the grants or sessions that reference the owning server.
+func accessRequestsForPolicyConfigMap(c client.Client, newList func() client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []ctrl.Request {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.Kind != "MCPServer" || obj.GetName() != gatewayPolicyConfigMapName(owner.Name) {
+			return nil
+		}
+		server := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: owner.Name, Namespace: obj.GetNamespace()}}
+		return accessRequestsReferencing(ctx, c, newList(), server)
+	}
+}
+
+func accessRequestsReferencing(ctx context.Context, c client.Client, list client.ObjectList, server *mcpv1alpha1.MCPServer) []ctrl.Request {
+	if err := c.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list access objects for MCPServer", "name", server.Name, "namespace", server.Namespace)
+		return nil
+	}
+	var requests []ctrl.Request
+	switch items := list.(type) {
+	case *mcpv1alpha1.MCPAccessGrantList:
+		for _, grant := range items.Items {
+			if serverReferenceMatches(grant.Namespace, grant.Spec.ServerRef, server) {
+				requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: grant.Name, Namespace: grant.Namespace}})
+			}
+		}
+	case *mcpv1alpha1.MCPAgentSessionList:
+		for _, session := range items.Items {
+			if serverReferenceMatches(session.Namespace, session.Spec.ServerRef, server) {
+				requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: session.Name, Namespace: session.Namespace}})
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,323 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+func newAccessStatusScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+func gatewayServerForStatus() *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Gateway: &mcpv1alpha1.GatewayConfig{Enabled: true},
+		},
+	}
+}
+
+// renderPolicyConfigMap renders the server's policy from the objects in c and
+// stores it the way MCPServerReconciler does.
+func renderPolicyConfigMap(t *testing.T, c client.Client, scheme *runtime.Scheme, server *mcpv1alpha1.MCPServer) string {
+	t.Helper()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	if err := r.reconcilePolicyConfigMap(context.Background(), server); err != nil {
+		t.Fatalf("reconcilePolicyConfigMap() error = %v", err)
+	}
+	doc, err := r.renderGatewayPolicy(context.Background(), server)
+	if err != nil {
+		t.Fatalf("renderGatewayPolicy() error = %v", err)
+	}
+	return doc.Revision
+}
+
+func TestMCPAccessGrantReconcilerReportsPolicyRendered(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := gatewayServerForStatus()
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "servers", Generation: 2},
+		Spec: mcpv1alpha1.MCPAccessGrantSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			Subject:   mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(server, grant).
+		WithStatusSubresource(&mcpv1alpha1.MCPAccessGrant{}).
+		Build()
+	r := &MCPAccessGrantReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ops", Namespace: "servers"}}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &mcpv1alpha1.MCPAccessGrant{}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	if got.Status.Phase != AccessPhasePending {
+		t.Fatalf("phase before render = %q, want Pending", got.Status.Phase)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, string(operatorutil.PolicyRendered)); cond == nil || cond.Reason != "PolicyPending" {
+		t.Fatalf("PolicyRendered = %#v, want PolicyPending", cond)
+	}
+
+	revision := renderPolicyConfigMap(t, c, scheme, server)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	if got.Status.Phase != AccessPhaseActive || got.Status.PolicyRevision != revision {
+		t.Fatalf("status = %#v, want Active at revision %s", got.Status, revision)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(operatorutil.ServerFound)) ||
+		!meta.IsStatusConditionTrue(got.Status.Conditions, string(operatorutil.PolicyRendered)) {
+		t.Fatalf("conditions = %#v, want ServerFound and PolicyRendered true", got.Status.Conditions)
+	}
+	if got.Status.ObservedGeneration != 2 {
+		t.Fatalf("observedGeneration = %d, want 2", got.Status.ObservedGeneration)
+	}
+}
+
+func TestMCPAccessGrantReconcilerReportsMissingServer(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "servers"},
+		Spec:       mcpv1alpha1.MCPAccessGrantSpec{ServerRef: mcpv1alpha1.ServerReference{Name: "missing"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(grant).
+		WithStatusSubresource(&mcpv1alpha1.MCPAccessGrant{}).
+		Build()
+	r := &MCPAccessGrantReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ops", Namespace: "servers"}}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &mcpv1alpha1.MCPAccessGrant{}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(operatorutil.ServerFound))
+	if got.Status.Phase != AccessPhasePending || cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "ServerNotFound" {
+		t.Fatalf("status = %#v, want Pending with ServerFound false", got.Status)
+	}
+}
+
+func TestMCPAccessGrantReconcilerFlagsRateLimitsPerReplica(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := gatewayServerForStatus()
+	replicas := int32(3)
+	server.Spec.Replicas = &replicas
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPAccessGrantSpec{
+			ServerRef:  mcpv1alpha1.ServerReference{Name: "payments"},
+			Subject:    mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+			RateLimits: []mcpv1alpha1.RateLimit{{CallsPerMinute: 10}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(server, grant).
+		WithStatusSubresource(&mcpv1alpha1.MCPAccessGrant{}).
+		Build()
+	renderPolicyConfigMap(t, c, scheme, server)
+	recorder := events.NewFakeRecorder(10)
+	r := &MCPAccessGrantReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ops", Namespace: "servers"}}
+	reconcile := func() *metav1.Condition {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		got := &mcpv1alpha1.MCPAccessGrant{}
+		_ = c.Get(context.Background(), req.NamespacedName, got)
+		return meta.FindStatusCondition(got.Status.Conditions, string(operatorutil.RateLimitsPerReplica))
+	}
+
+	if cond := reconcile(); cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "3 gateway replicas") {
+		t.Fatalf("RateLimitsPerReplica = %#v, want true for three replicas", cond)
+	}
+	warnings := 0
+	for _, event := range drainEvents(recorder) {
+		if strings.HasPrefix(event, "Warning RateLimitsPerReplica ") {
+			warnings++
+		}
+	}
+	if warnings != 1 {
+		t.Fatalf("RateLimitsPerReplica warnings = %d, want 1", warnings)
+	}
+
+	server.Spec.Replicas = nil
+	server.Spec.Autoscaling = &mcpv1alpha1.AutoscalingConfig{MaxReplicas: 5}
+	if err := c.Update(context.Background(), server); err != nil {
+		t.Fatal(err)
+	}
+	if cond := reconcile(); cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "5 gateway replicas") {
+		t.Fatalf("RateLimitsPerReplica = %#v, want true up to the autoscaler maximum", cond)
+	}
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Fatalf("events = %q, want no repeated warning", got)
+	}
+
+	server.Spec.Autoscaling = nil
+	if err := c.Update(context.Background(), server); err != nil {
+		t.Fatal(err)
+	}
+	if cond := reconcile(); cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "SingleReplica" {
+		t.Fatalf("RateLimitsPerReplica = %#v, want false for one replica", cond)
+	}
+
+	server.Spec.Replicas = &replicas
+	if err := c.Update(context.Background(), server); err != nil {
+		t.Fatal(err)
+	}
+	r.SharedRateLimits = true
+	if cond := reconcile(); cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "SharedCounters" {
+		t.Fatalf("RateLimitsPerReplica = %#v, want false with shared counters", cond)
+	}
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Fatalf("events = %q, want no warning with shared counters", got)
+	}
+}
+
+func TestMCPAgentSessionReconcilerReportsRevokedAndExpired(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := gatewayServerForStatus()
+	now := time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)
+	expiresAt := metav1.NewTime(now.Add(time.Hour))
+	session := &mcpv1alpha1.MCPAgentSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sess-1", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPAgentSessionSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			Subject:   mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+			ExpiresAt: &expiresAt,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(server, session).
+		WithStatusSubresource(&mcpv1alpha1.MCPAgentSession{}).
+		Build()
+	renderPolicyConfigMap(t, c, scheme, server)
+	r := &MCPAgentSessionReconciler{Client: c, Scheme: scheme, now: func() time.Time { return now }}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sess-1", Namespace: "servers"}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Fatalf("RequeueAfter = %v, want requeue at expiry", result.RequeueAfter)
+	}
+	got := &mcpv1alpha1.MCPAgentSession{}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	if got.Status.Phase != AccessPhaseActive || meta.IsStatusConditionTrue(got.Status.Conditions, string(operatorutil.Expired)) {
+		t.Fatalf("status = %#v, want Active and not expired", got.Status)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	if got.Status.Phase != AccessPhaseExpired || !meta.IsStatusConditionTrue(got.Status.Conditions, string(operatorutil.Expired)) {
+		t.Fatalf("status = %#v, want Expired", got.Status)
+	}
+
+	got.Spec.Revoked = true
+	if err := c.Update(context.Background(), got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	_ = c.Get(context.Background(), req.NamespacedName, got)
+	if got.Status.Phase != AccessPhaseRevoked || !meta.IsStatusConditionTrue(got.Status.Conditions, string(operatorutil.Revoked)) {
+		t.Fatalf("status = %#v, want Revoked", got.Status)
+	}
+}
+
+func TestMCPAgentSessionReconcilerDeletesSessionsPastRetention(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	now := time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)
+	expiresAt := metav1.NewTime(now.Add(-2 * time.Hour))
+	session := &mcpv1alpha1.MCPAgentSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sess-1", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPAgentSessionSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			ExpiresAt: &expiresAt,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(session).
+		WithStatusSubresource(&mcpv1alpha1.MCPAgentSession{}).
+		Build()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sess-1", Namespace: "servers"}}
+
+	r := &MCPAgentSessionReconciler{Client: c, Scheme: scheme, ExpiredSessionRetention: 3 * time.Hour, now: func() time.Time { return now }}
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Fatalf("RequeueAfter = %v, want requeue when the retention ends", result.RequeueAfter)
+	}
+
+	r.ExpiredSessionRetention = time.Hour
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, &mcpv1alpha1.MCPAgentSession{}); !errors.IsNotFound(err) {
+		t.Fatalf("Get() error = %v, want session deleted", err)
+	}
+}
+
+func TestAccessRequestsForPolicyConfigMap(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	grants := []client.Object{
+		&mcpv1alpha1.MCPAccessGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "match", Namespace: "servers"},
+			Spec:       mcpv1alpha1.MCPAccessGrantSpec{ServerRef: mcpv1alpha1.ServerReference{Name: "payments"}},
+		},
+		&mcpv1alpha1.MCPAccessGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "servers"},
+			Spec:       mcpv1alpha1.MCPAccessGrantSpec{ServerRef: mcpv1alpha1.ServerReference{Name: "billing"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(grants...).Build()
+	isController := true
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      gatewayPolicyConfigMapName("payments"),
+		Namespace: "servers",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: mcpv1alpha1.GroupVersion.String(), Kind: "MCPServer", Name: "payments", Controller: &isController,
+		}},
+	}}
+
+	requests := accessRequestsForPolicyConfigMap(c, newGrantList)(context.Background(), configMap)
+	if len(requests) != 1 || requests[0].Name != "match" {
+		t.Fatalf("requests = %#v, want only the grant referencing payments", requests)
+	}
+	configMap.Name = "unrelated"
+	if requests := accessRequestsForPolicyConfigMap(c, newGrantList)(context.Background(), configMap); len(requests) != 0 {
+		t.Fatalf("requests for unrelated ConfigMap = %#v, want none", requests)
+	}
+}
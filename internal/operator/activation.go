@@ -0,0 +1,195 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// ActivationPath is the path activators POST scale-up requests to.
+const ActivationPath = "/activate"
+
+// activationDebounce is how recent an activation request may be before a new
+// one is not written again. Activators re-send while they wait.
+const activationDebounce = 5 * time.Second
+
+// activationRequest is the body activators send.
+type activationRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ActivationServer accepts scale-up requests from scale-to-zero activators
+// and records them in ActivationRequestedAnnotation, which the MCPServer
+// reconciler acts on. Only the activator pods of a server can wake it. It also
+// serves ApprovalPath, where gateways request and poll tool call approvals,
+// SessionActivityPath, where they share agent session activity, and
+// RateLimitPath, where they share rate limit counters. It runs on every
+// replica, not only the leader, so any replica behind the activation Service
+// can take the request; rate limit requests are forwarded to the leader.
+type ActivationServer struct {
+	Client client.Client
+	// APIReader reads the pods of callers; nil means Client.
+	APIReader client.Reader
+	// Addr is the listen address, such as :8082.
+	Addr string
+	// Identity is the username the operator authenticates to the API server
+	// as. The approval handler creates MCPToolApprovals naming it and only
+	// honors approvals that do.
+	Identity string
+	// Elected is closed once this replica is the leader; the manager's
+	// Elected channel. Nil, or an empty LeaderLease, means this replica
+	// keeps the rate limit counters itself.
+	Elected <-chan struct{}
+	// LeaderLease is the leader election lease, whose holder keeps the rate
+	// limit counters.
+	LeaderLease types.NamespacedName
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+	// auth identifies callers; nil means a callerAuthenticator over Client
+	// and APIReader.
+	auth *callerAuthenticator
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *ActivationServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *ActivationServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(ActivationPath, s)
+	mux.Handle(ApprovalPath, &approvalHandler{client: s.Client, auth: s.authenticator(), identity: s.Identity, now: s.now})
+	mux.Handle(SessionActivityPath, &sessionActivityHandler{client: s.Client, auth: s.authenticator(), now: s.now})
+	rateLimits := &rateLimitHandler{auth: s.authenticator(), now: s.now}
+	if s.Elected != nil && s.LeaderLease.Name != "" {
+		_, port, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return fmt.Errorf("parse activation address: %w", err)
+		}
+		reader := s.APIReader
+		if reader == nil {
+			reader = s.Client
+		}
+		rateLimits.leader = &leaderForwarder{
+			reader:  reader,
+			lease:   s.LeaderLease,
+			elected: s.Elected,
+			port:    port,
+			client:  &http.Client{Timeout: 5 * time.Second},
+		}
+	}
+	mux.Handle(RateLimitPath, rateLimits)
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+	errs := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+		close(errs)
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *ActivationServer) authenticator() *callerAuthenticator {
+	if s.auth == nil {
+		s.auth = &callerAuthenticator{client: s.Client, reader: s.APIReader}
+	}
+	return s.auth
+}
+
+// ServeHTTP records an activation request for a scale-to-zero MCPServer.
+func (s *ActivationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pod, err := s.authenticator().callerPod(r)
+	if err != nil {
+		if !errors.Is(err, errCallerUnauthenticated) {
+			log.FromContext(r.Context()).Error(err, "Failed to authenticate activation caller")
+		}
+		writeCallerError(w, err)
+		return
+	}
+	var req activationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, "invalid activation request", http.StatusBadRequest)
+		return
+	}
+	req.Namespace = strings.TrimSpace(req.Namespace)
+	req.Name = strings.TrimSpace(req.Name)
+	if len(validation.IsDNS1123Label(req.Namespace)) > 0 || len(validation.IsDNS1123Subdomain(req.Name)) > 0 {
+		http.Error(w, "namespace and name must be valid Kubernetes names", http.StatusBadRequest)
+		return
+	}
+	// Each server's activator may only wake that server.
+	if !callerIsComponent(pod, LabelComponentActivator, req.Namespace, activatorName(req.Name)) {
+		writeCallerError(w, errCallerForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	mcpServer := &mcpv1alpha1.MCPServer{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, mcpServer); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			http.Error(w, "server not found", http.StatusNotFound)
+			return
+		}
+		log.FromContext(ctx).Error(err, "Failed to read MCPServer for activation", "namespace", req.Namespace, "name", req.Name)
+		http.Error(w, "failed to read server", http.StatusInternalServerError)
+		return
+	}
+	if !scaleToZeroEnabled(mcpServer) {
+		http.Error(w, "server does not scale to zero", http.StatusConflict)
+		return
+	}
+
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	if last, ok := activationRequestedAt(mcpServer); ok && now.Sub(last) < activationDebounce {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	patch := client.MergeFrom(mcpServer.DeepCopy())
+	if mcpServer.Annotations == nil {
+		mcpServer.Annotations = map[string]string{}
+	}
+	mcpServer.Annotations[mcpv1alpha1.ActivationRequestedAnnotation] = now.UTC().Format(time.RFC3339Nano)
+	if err := s.Client.Patch(ctx, mcpServer, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record activation request", "namespace", req.Namespace, "name", req.Name)
+		http.Error(w, "failed to record activation", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,87 @@
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	gatewayAnalyticsSpoolVolumeName = mcpv1alpha1.GatewayAnalyticsSpoolVolumeName
+	gatewayAnalyticsSpoolMountDir   = mcpv1alpha1.GatewayMountRoot + "/analytics-spool"
+	defaultAnalyticsSpoolMaxSize    = "256Mi"
+	// analyticsSpoolUsablePercent is the share of spool.maxSize the gateway
+	// fills with events, leaving room for the cursor and lock files so an
+	// emptyDir never exceeds its size limit and gets the pod evicted.
+	analyticsSpoolUsablePercent = 90
+)
+
+// analyticsSpool returns the server's spool settings when the gateway emits
+// analytics, nil otherwise.
+func (r *MCPServerReconciler) analyticsSpool(mcpServer *mcpv1alpha1.MCPServer) *mcpv1alpha1.AnalyticsSpoolConfig {
+	if !gatewayEnabled(mcpServer) || !r.analyticsEnabled(mcpServer) {
+		return nil
+	}
+	return mcpServer.Spec.Analytics.Spool
+}
+
+func analyticsSpoolMaxSize(spool *mcpv1alpha1.AnalyticsSpoolConfig) (resource.Quantity, error) {
+	maxSize := strings.TrimSpace(spool.MaxSize)
+	if maxSize == "" {
+		maxSize = defaultAnalyticsSpoolMaxSize
+	}
+	quantity, err := resource.ParseQuantity(maxSize)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("invalid analytics spool maxSize %q: %w", spool.MaxSize, err)
+	}
+	return quantity, nil
+}
+
+// applyAnalyticsSpool mounts the spool volume into the gateway container and
+// points the gateway at it.
+func applyAnalyticsSpool(container *corev1.Container, spool *mcpv1alpha1.AnalyticsSpoolConfig) error {
+	maxSize, err := analyticsSpoolMaxSize(spool)
+	if err != nil {
+		return err
+	}
+	fsync := spool.Fsync
+	if fsync == "" {
+		fsync = mcpv1alpha1.SpoolFsyncInterval
+	}
+	volumeName := strings.TrimSpace(spool.Volume)
+	if volumeName == "" {
+		volumeName = gatewayAnalyticsSpoolVolumeName
+	}
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "ANALYTICS_SPOOL_DIR", Value: gatewayAnalyticsSpoolMountDir},
+		corev1.EnvVar{Name: "ANALYTICS_SPOOL_MAX_BYTES", Value: strconv.FormatInt(maxSize.Value()*analyticsSpoolUsablePercent/100, 10)},
+		corev1.EnvVar{Name: "ANALYTICS_SPOOL_FSYNC", Value: string(fsync)},
+	)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: gatewayAnalyticsSpoolMountDir,
+	})
+	return nil
+}
+
+// analyticsSpoolVolume returns the emptyDir the operator adds for a spool
+// without a spec volume, sized to spool.maxSize; nil when the spool uses a
+// spec volume.
+func analyticsSpoolVolume(spool *mcpv1alpha1.AnalyticsSpoolConfig) (*corev1.Volume, error) {
+	if strings.TrimSpace(spool.Volume) != "" {
+		return nil, nil
+	}
+	maxSize, err := analyticsSpoolMaxSize(spool)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Volume{
+		Name:         gatewayAnalyticsSpoolVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &maxSize}},
+	}, nil
+}
@@ -0,0 +1,94 @@
+package operator
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func spoolServer(spool *mcpv1alpha1.AnalyticsSpoolConfig) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:     "example.com/payments:v1",
+			Port:      8088,
+			Gateway:   &mcpv1alpha1.GatewayConfig{Enabled: true, Image: "example.com/gateway:v1", Port: 8091},
+			Analytics: &mcpv1alpha1.AnalyticsConfig{IngestURL: "http://mcp-sentinel-ingest.mcp-sentinel.svc.cluster.local:8081/events", Spool: spool},
+		},
+	}
+}
+
+func envValue(env []corev1.EnvVar, name string) string {
+	for _, v := range env {
+		if v.Name == name {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+func TestBuildDeploymentContainersMountsAnalyticsSpool(t *testing.T) {
+	r := &MCPServerReconciler{}
+
+	server := spoolServer(&mcpv1alpha1.AnalyticsSpoolConfig{MaxSize: "100Mi"})
+	containers, volumes, err := r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	gateway := containers[len(containers)-1]
+	if got := envValue(gateway.Env, "ANALYTICS_SPOOL_DIR"); got != gatewayAnalyticsSpoolMountDir {
+		t.Fatalf("ANALYTICS_SPOOL_DIR = %q, want %q", got, gatewayAnalyticsSpoolMountDir)
+	}
+	if got := envValue(gateway.Env, "ANALYTICS_SPOOL_MAX_BYTES"); got != "94371840" {
+		t.Fatalf("ANALYTICS_SPOOL_MAX_BYTES = %q, want 90%% of 100Mi", got)
+	}
+	if got := envValue(gateway.Env, "ANALYTICS_SPOOL_FSYNC"); got != "interval" {
+		t.Fatalf("ANALYTICS_SPOOL_FSYNC = %q, want the interval default", got)
+	}
+	if !slices.ContainsFunc(gateway.VolumeMounts, func(m corev1.VolumeMount) bool {
+		return m.Name == gatewayAnalyticsSpoolVolumeName && m.MountPath == gatewayAnalyticsSpoolMountDir && !m.ReadOnly
+	}) {
+		t.Fatalf("gateway mounts = %#v, want the writable spool volume", gateway.VolumeMounts)
+	}
+	spoolVolume := volumes[slices.IndexFunc(volumes, func(v corev1.Volume) bool { return v.Name == gatewayAnalyticsSpoolVolumeName })]
+	if spoolVolume.EmptyDir == nil || spoolVolume.EmptyDir.SizeLimit == nil || spoolVolume.EmptyDir.SizeLimit.String() != "100Mi" {
+		t.Fatalf("spool volume = %#v, want an emptyDir limited to maxSize", spoolVolume)
+	}
+
+	server = spoolServer(&mcpv1alpha1.AnalyticsSpoolConfig{Volume: "audit-spool", Fsync: mcpv1alpha1.SpoolFsyncAlways})
+	server.Spec.Volumes = []mcpv1alpha1.Volume{{
+		Name:                  "audit-spool",
+		PersistentVolumeClaim: &mcpv1alpha1.PersistentVolumeClaimVolumeSource{ClaimName: "payments-audit"},
+	}}
+	containers, volumes, err = r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	gateway = containers[len(containers)-1]
+	if !slices.ContainsFunc(gateway.VolumeMounts, func(m corev1.VolumeMount) bool {
+		return m.Name == "audit-spool" && m.MountPath == gatewayAnalyticsSpoolMountDir
+	}) {
+		t.Fatalf("gateway mounts = %#v, want the claim volume", gateway.VolumeMounts)
+	}
+	if got := envValue(gateway.Env, "ANALYTICS_SPOOL_FSYNC"); got != "always" {
+		t.Fatalf("ANALYTICS_SPOOL_FSYNC = %q, want always", got)
+	}
+	if slices.ContainsFunc(volumes, func(v corev1.Volume) bool { return v.Name == gatewayAnalyticsSpoolVolumeName }) {
+		t.Fatalf("volumes = %#v, want no operator emptyDir when the spool uses a spec volume", volumes)
+	}
+
+	server = spoolServer(&mcpv1alpha1.AnalyticsSpoolConfig{})
+	server.Spec.Analytics.Disabled = true
+	containers, volumes, err = r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	if envValue(containers[len(containers)-1].Env, "ANALYTICS_SPOOL_DIR") != "" ||
+		slices.ContainsFunc(volumes, func(v corev1.Volume) bool { return v.Name == gatewayAnalyticsSpoolVolumeName }) {
+		t.Fatal("spool configured for a server with analytics disabled")
+	}
+}
@@ -0,0 +1,374 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// ApprovalPath is the path gateways POST held tool calls to. It is served
+// next to ActivationPath.
+const ApprovalPath = "/approvals"
+
+// defaultApprovalTimeout is how long an approval stays pending when the
+// server does not set spec.approval.timeout.
+const defaultApprovalTimeout = 15 * time.Minute
+
+// maxApprovalArguments caps the arguments JSON stored on an approval for
+// display. The digest still covers the full arguments.
+const maxApprovalArguments = 4096
+
+// Statuses returned to gateways in approvalResponse.
+const (
+	approvalStatusPending  = "pending"
+	approvalStatusApproved = "approved"
+	approvalStatusDenied   = "denied"
+	approvalStatusExpired  = "expired"
+)
+
+// approvalRequest is the body gateways send for a held tool call. The same
+// request both asks for and polls an approval: it resolves to the pending
+// approval of the same call, or creates one.
+type approvalRequest struct {
+	Namespace             string `json:"namespace"`
+	Server                string `json:"server"`
+	HumanID               string `json:"human_id,omitempty"`
+	AgentID               string `json:"agent_id,omitempty"`
+	TeamID                string `json:"team_id,omitempty"`
+	SessionID             string `json:"session_id,omitempty"`
+	Tool                  string `json:"tool"`
+	Arguments             string `json:"arguments,omitempty"`
+	ArgumentsDigest       string `json:"arguments_digest"`
+	RiskLevel             string `json:"risk_level,omitempty"`
+	MatchedGrant          string `json:"matched_grant,omitempty"`
+	MatchedGrantNamespace string `json:"matched_grant_namespace,omitempty"`
+}
+
+// approvalResponse tells the gateway what to do with the held call.
+type approvalResponse struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	DecidedBy string `json:"decided_by,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// approvalHandler records held tool calls as MCPToolApprovals and reports
+// their decisions. A decided approval is consumed by the first request that
+// sees the decision, so an approval releases one call and a denied call can
+// be asked for again. Only the gateway pods of the requested server may
+// call it; see callerAuthenticator.
+type approvalHandler struct {
+	client client.Client
+	auth   *callerAuthenticator
+	// identity is the operator's username. Approvals are created naming it
+	// in ApprovalRequestedByAnnotation, which the admission webhook only
+	// admits from the operator, and approvals that name anyone else are
+	// ignored, so nobody can pre-approve a call by creating its approval.
+	identity string
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+}
+
+// ServeHTTP resolves a held tool call to its MCPToolApproval.
+func (h *approvalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pod, err := h.auth.callerPod(r)
+	if err != nil {
+		if !errors.Is(err, errCallerUnauthenticated) {
+			log.FromContext(r.Context()).Error(err, "Failed to authenticate approval caller")
+		}
+		writeCallerError(w, err)
+		return
+	}
+	var req approvalRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 2*maxApprovalArguments)).Decode(&req); err != nil {
+		http.Error(w, "invalid approval request", http.StatusBadRequest)
+		return
+	}
+	req.Namespace = strings.TrimSpace(req.Namespace)
+	req.Server = strings.TrimSpace(req.Server)
+	if len(validation.IsDNS1123Label(req.Namespace)) > 0 || len(validation.IsDNS1123Subdomain(req.Server)) > 0 {
+		http.Error(w, "namespace and server must be valid Kubernetes names", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Tool) == "" || strings.TrimSpace(req.ArgumentsDigest) == "" {
+		http.Error(w, "tool and arguments_digest are required", http.StatusBadRequest)
+		return
+	}
+	// A gateway only holds calls to its own server, so the caller's pod
+	// decides which namespace and server the approval can be for.
+	if !callerIsComponent(pod, LabelComponentGateway, req.Namespace, req.Server) {
+		writeCallerError(w, errCallerForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	if h.identity == "" {
+		log.FromContext(ctx).Error(errors.New("operator identity is not configured"), "Cannot resolve tool call approvals")
+		http.Error(w, "approvals are not available", http.StatusServiceUnavailable)
+		return
+	}
+	mcpServer := &mcpv1alpha1.MCPServer{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: req.Server, Namespace: req.Namespace}, mcpServer); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			http.Error(w, "server not found", http.StatusNotFound)
+			return
+		}
+		log.FromContext(ctx).Error(err, "Failed to read MCPServer for approval", "namespace", req.Namespace, "name", req.Server)
+		http.Error(w, "failed to read server", http.StatusInternalServerError)
+		return
+	}
+
+	var response approvalResponse
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		response, err = h.resolve(ctx, mcpServer, req)
+		return err
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to resolve tool call approval", "namespace", req.Namespace, "server", req.Server, "tool", req.Tool)
+		http.Error(w, "failed to resolve approval", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// resolve returns the oldest unconsumed approval of the call, consuming it
+// when decided, or creates a pending one. Only approvals the operator created
+// for exactly this call count; the key label alone can be copied.
+func (h *approvalHandler) resolve(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, req approvalRequest) (approvalResponse, error) {
+	now := h.currentTime()
+	key := approvalKey(req)
+	list := &mcpv1alpha1.MCPToolApprovalList{}
+	if err := h.client.List(ctx, list, client.InNamespace(req.Namespace), client.MatchingLabels{mcpv1alpha1.ApprovalKeyLabel: key}); err != nil {
+		return approvalResponse{}, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].CreationTimestamp.Before(&list.Items[j].CreationTimestamp)
+	})
+	for i := range list.Items {
+		approval := &list.Items[i]
+		if approval.Status.ConsumedAt != nil || !h.requestedCall(approval, mcpServer, req) {
+			continue
+		}
+		phase, message := approvalPhase(approval, now)
+		response := approvalResponseFor(approval, phase)
+		if phase == mcpv1alpha1.ApprovalPhasePending {
+			return response, nil
+		}
+		approval.Status.Phase = phase
+		approval.Status.Message = message
+		approval.Status.ConsumedAt = &metav1.Time{Time: now}
+		approval.Status.ObservedGeneration = approval.Generation
+		if err := h.client.Status().Update(ctx, approval); err != nil {
+			return approvalResponse{}, err
+		}
+		return response, nil
+	}
+
+	arguments := req.Arguments
+	if len(arguments) > maxApprovalArguments {
+		arguments = arguments[:maxApprovalArguments]
+	}
+	matchedGrant := req.MatchedGrant
+	if matchedGrant != "" && req.MatchedGrantNamespace != "" {
+		matchedGrant = req.MatchedGrantNamespace + "/" + matchedGrant
+	}
+	approval := &mcpv1alpha1.MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: approvalNamePrefix(mcpServer.Name),
+			Namespace:    req.Namespace,
+			Labels: map[string]string{
+				mcpv1alpha1.ApprovalKeyLabel:    key,
+				mcpv1alpha1.ApprovalServerLabel: mcpServer.Name,
+			},
+			Annotations: map[string]string{mcpv1alpha1.ApprovalRequestedByAnnotation: h.identity},
+		},
+		Spec: mcpv1alpha1.MCPToolApprovalSpec{
+			ServerRef:       mcpv1alpha1.ServerReference{Name: mcpServer.Name, Namespace: mcpServer.Namespace},
+			Subject:         mcpv1alpha1.SubjectRef{HumanID: req.HumanID, AgentID: req.AgentID, TeamID: req.TeamID},
+			SessionID:       req.SessionID,
+			Tool:            req.Tool,
+			Arguments:       arguments,
+			ArgumentsDigest: req.ArgumentsDigest,
+			RiskLevel:       mcpv1alpha1.ToolRiskLevel(req.RiskLevel),
+			MatchedGrant:    matchedGrant,
+			ExpiresAt:       &metav1.Time{Time: now.Add(approvalTimeout(mcpServer)).Truncate(time.Second)},
+		},
+	}
+	if err := h.client.Create(ctx, approval); err != nil {
+		return approvalResponse{}, err
+	}
+	log.FromContext(ctx).Info("Tool call held for approval", "namespace", approval.Namespace, "approval", approval.Name, "tool", req.Tool)
+	return approvalResponseFor(approval, mcpv1alpha1.ApprovalPhasePending), nil
+}
+
+// requestedCall reports whether approval was created by the operator for the
+// call in req.
+func (h *approvalHandler) requestedCall(approval *mcpv1alpha1.MCPToolApproval, mcpServer *mcpv1alpha1.MCPServer, req approvalRequest) bool {
+	spec := approval.Spec
+	return approval.Annotations[mcpv1alpha1.ApprovalRequestedByAnnotation] == h.identity &&
+		spec.ServerRef.Name == mcpServer.Name && spec.ServerRef.Namespace == mcpServer.Namespace &&
+		spec.Subject == (mcpv1alpha1.SubjectRef{HumanID: req.HumanID, AgentID: req.AgentID, TeamID: req.TeamID}) &&
+		spec.SessionID == req.SessionID && spec.Tool == req.Tool && spec.ArgumentsDigest == req.ArgumentsDigest
+}
+
+func (h *approvalHandler) currentTime() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+func approvalResponseFor(approval *mcpv1alpha1.MCPToolApproval, phase string) approvalResponse {
+	response := approvalResponse{
+		ID:        approval.Name,
+		Namespace: approval.Namespace,
+		DecidedBy: approval.Spec.DecidedBy,
+		Comment:   approval.Spec.Comment,
+	}
+	if approval.Spec.ExpiresAt != nil {
+		response.ExpiresAt = approval.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	switch phase {
+	case mcpv1alpha1.ApprovalPhaseApproved:
+		response.Status = approvalStatusApproved
+	case mcpv1alpha1.ApprovalPhaseDenied:
+		response.Status = approvalStatusDenied
+	case mcpv1alpha1.ApprovalPhaseExpired:
+		response.Status = approvalStatusExpired
+	default:
+		response.Status = approvalStatusPending
+	}
+	return response
+}
+
+// approvalPhase derives an approval's phase from its decision and expiry. A
+// decision made after the approval expired does not count.
+func approvalPhase(approval *mcpv1alpha1.MCPToolApproval, now time.Time) (string, string) {
+	expiresAt := approval.Spec.ExpiresAt
+	decidedInTime := approval.Spec.DecidedAt == nil || expiresAt == nil || !approval.Spec.DecidedAt.After(expiresAt.Time)
+	switch {
+	case approval.Spec.Decision == mcpv1alpha1.ApprovalDecisionApproved && decidedInTime:
+		return mcpv1alpha1.ApprovalPhaseApproved, "approved by " + approval.Spec.DecidedBy
+	case approval.Spec.Decision == mcpv1alpha1.ApprovalDecisionDenied && decidedInTime:
+		return mcpv1alpha1.ApprovalPhaseDenied, "denied by " + approval.Spec.DecidedBy
+	case expiresAt != nil && !now.Before(expiresAt.Time):
+		return mcpv1alpha1.ApprovalPhaseExpired, "expired at " + expiresAt.UTC().Format(time.RFC3339) + " without a decision"
+	}
+	if expiresAt == nil {
+		return mcpv1alpha1.ApprovalPhasePending, "waiting for a decision"
+	}
+	return mcpv1alpha1.ApprovalPhasePending, "waiting for a decision until " + expiresAt.UTC().Format(time.RFC3339)
+}
+
+// approvalKey identifies a held call: the same subject calling the same tool
+// with the same arguments in the same session.
+func approvalKey(req approvalRequest) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		req.Namespace, req.Server, req.HumanID, req.AgentID, req.TeamID, req.SessionID, req.Tool, req.ArgumentsDigest,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])[:40]
+}
+
+func approvalNamePrefix(serverName string) string {
+	// Leave room for the generated suffix within the 63 characters of a label.
+	if len(serverName) > 50 {
+		serverName = strings.TrimRight(serverName[:50], "-.")
+	}
+	return serverName + "-"
+}
+
+func approvalTimeout(mcpServer *mcpv1alpha1.MCPServer) time.Duration {
+	if mcpServer.Spec.Approval == nil {
+		return defaultApprovalTimeout
+	}
+	timeout, err := time.ParseDuration(strings.TrimSpace(mcpServer.Spec.Approval.Timeout))
+	if err != nil || timeout <= 0 {
+		return defaultApprovalTimeout
+	}
+	return timeout
+}
+
+// MCPToolApprovalReconciler reports an MCPToolApproval's phase on its status
+// and requeues pending approvals for their expiry, so an undecided approval
+// turns Expired without a gateway asking about it.
+type MCPToolApprovalReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits an Event when the approval's phase changes, naming the
+	// approver. Nil disables events.
+	Recorder events.EventRecorder
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+}
+
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcptoolapprovals,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcptoolapprovals/status,verbs=get;update;patch
+
+// Reconcile updates the status of one MCPToolApproval.
+func (r *MCPToolApprovalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	approval := &mcpv1alpha1.MCPToolApproval{}
+	if err := r.Get(ctx, req.NamespacedName, approval); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	status := approval.Status
+	status.Phase, status.Message = approvalPhase(approval, now)
+	status.ObservedGeneration = approval.Generation
+
+	var result ctrl.Result
+	if status.Phase == mcpv1alpha1.ApprovalPhasePending && approval.Spec.ExpiresAt != nil {
+		result.RequeueAfter = approval.Spec.ExpiresAt.Sub(now)
+	}
+	if equality.Semantic.DeepEqual(approval.Status, status) {
+		return result, nil
+	}
+	previousPhase := approval.Status.Phase
+	approval.Status = status
+	if err := r.Status().Update(ctx, approval); err != nil {
+		return result, ignoreStatusConflict(ctx, err)
+	}
+	recordAccessPhaseChange(r.Recorder, approval, previousPhase, status.Phase, approval.Spec.Tool+" "+status.Message)
+	return result, nil
+}
+
+func (r *MCPToolApprovalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcptoolapproval-status").
+		For(&mcpv1alpha1.MCPToolApproval{}).
+		Complete(r)
+}
@@ -0,0 +1,177 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// testOperatorIdentity is the username approval handlers under test
+// authenticate as.
+const testOperatorIdentity = "system:serviceaccount:mcp-runtime:mcp-runtime-operator-controller-manager"
+
+func TestApprovalHandlerHoldsDecidesAndConsumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Approval: &mcpv1alpha1.ApprovalConfig{Timeout: "10m"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, callerTestPod("payments-gw", "servers", "payments", LabelComponentGateway),
+		callerTestPod("missing-gw", "servers", "missing", LabelComponentGateway)).
+		WithStatusSubresource(&mcpv1alpha1.MCPToolApproval{}).Build()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := &approvalHandler{client: c, auth: callerTestAuthenticator(c), identity: testOperatorIdentity, now: func() time.Time { return now }}
+
+	postAs := func(caller, body string) (int, approvalResponse) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, ApprovalPath, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer servers/"+caller)
+		h.ServeHTTP(rec, req)
+		var response approvalResponse
+		_ = json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec.Code, response
+	}
+	post := func(body string) (int, approvalResponse) { return postAs("payments-gw", body) }
+	call := `{"namespace":"servers","server":"payments","human_id":"alice","tool":"refund","arguments":"{\"amount\":10}","arguments_digest":"sha256:abc","risk_level":"high"}`
+
+	if code, _ := post(`{"namespace":"servers","server":"payments","tool":"refund"}`); code != http.StatusBadRequest {
+		t.Fatalf("request without digest = %d, want 400", code)
+	}
+	if code, _ := postAs("missing-gw", `{"namespace":"servers","server":"missing","tool":"refund","arguments_digest":"sha256:abc"}`); code != http.StatusNotFound {
+		t.Fatalf("request for a missing server = %d, want 404", code)
+	}
+
+	// Approvals someone else created, or created for another call under the
+	// same key, never release the call.
+	forged := func(name, requestedBy, humanID string) *mcpv1alpha1.MCPToolApproval {
+		return &mcpv1alpha1.MCPToolApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "servers",
+				CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)},
+				Labels:            map[string]string{mcpv1alpha1.ApprovalKeyLabel: approvalKey(approvalRequest{Namespace: "servers", Server: "payments", HumanID: "alice", Tool: "refund", ArgumentsDigest: "sha256:abc"})},
+				Annotations:       map[string]string{mcpv1alpha1.ApprovalRequestedByAnnotation: requestedBy},
+			},
+			Spec: mcpv1alpha1.MCPToolApprovalSpec{
+				ServerRef:       mcpv1alpha1.ServerReference{Name: "payments", Namespace: "servers"},
+				Subject:         mcpv1alpha1.SubjectRef{HumanID: humanID},
+				Tool:            "refund",
+				ArgumentsDigest: "sha256:abc",
+				Decision:        mcpv1alpha1.ApprovalDecisionApproved,
+				DecidedBy:       "mallory",
+			},
+		}
+	}
+	for _, approval := range []*mcpv1alpha1.MCPToolApproval{forged("preapproved", "mallory", "alice"), forged("mismatched", testOperatorIdentity, "bob")} {
+		if err := c.Create(context.Background(), approval); err != nil {
+			t.Fatalf("create %s: %v", approval.Name, err)
+		}
+	}
+
+	code, first := post(call)
+	if code != http.StatusOK || first.Status != approvalStatusPending || first.ID == "" || first.ExpiresAt != "2026-01-01T12:10:00Z" || first.ID == "preapproved" || first.ID == "mismatched" {
+		t.Fatalf("first request = %d %+v, want a pending approval expiring in 10m", code, first)
+	}
+	if _, again := post(call); again.ID != first.ID || again.Status != approvalStatusPending {
+		t.Fatalf("repeated request = %+v, want the same pending approval %s", again, first.ID)
+	}
+	if _, other := post(strings.Replace(call, "sha256:abc", "sha256:def", 1)); other.ID == first.ID {
+		t.Fatal("a call with other arguments matched the same approval")
+	}
+
+	key := types.NamespacedName{Name: first.ID, Namespace: "servers"}
+	approval := &mcpv1alpha1.MCPToolApproval{}
+	if err := c.Get(context.Background(), key, approval); err != nil {
+		t.Fatalf("get approval: %v", err)
+	}
+	if approval.Spec.Tool != "refund" || approval.Spec.Subject.HumanID != "alice" || approval.Labels[mcpv1alpha1.ApprovalServerLabel] != "payments" ||
+		approval.Annotations[mcpv1alpha1.ApprovalRequestedByAnnotation] != testOperatorIdentity {
+		t.Fatalf("approval = %+v", approval)
+	}
+	approval.Spec.Decision = mcpv1alpha1.ApprovalDecisionApproved
+	approval.Spec.DecidedBy = "bob"
+	approval.Spec.DecidedAt = &metav1.Time{Time: now}
+	if err := c.Update(context.Background(), approval); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+
+	if _, released := post(call); released.ID != first.ID || released.Status != approvalStatusApproved || released.DecidedBy != "bob" {
+		t.Fatalf("request after approval = %+v, want approved by bob", released)
+	}
+	if _, next := post(call); next.ID == first.ID || next.Status != approvalStatusPending {
+		t.Fatalf("request after the approval was consumed = %+v, want a new pending approval", next)
+	}
+	if err := c.Get(context.Background(), key, approval); err != nil {
+		t.Fatalf("get approval: %v", err)
+	}
+	if approval.Status.ConsumedAt == nil || approval.Status.Phase != mcpv1alpha1.ApprovalPhaseApproved {
+		t.Fatalf("approval status = %+v, want consumed and Approved", approval.Status)
+	}
+}
+
+func TestMCPToolApprovalReconcilerExpires(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	approval := &mcpv1alpha1.MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-x1", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPToolApprovalSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			Tool:      "refund",
+			ExpiresAt: &metav1.Time{Time: now.Add(time.Minute)},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(approval).
+		WithStatusSubresource(&mcpv1alpha1.MCPToolApproval{}).Build()
+	r := &MCPToolApprovalReconciler{Client: c, Scheme: scheme, now: func() time.Time { return now }}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(approval)}
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Fatalf("RequeueAfter = %s, want the time until expiry", result.RequeueAfter)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, approval); err != nil {
+		t.Fatalf("get approval: %v", err)
+	}
+	if approval.Status.Phase != mcpv1alpha1.ApprovalPhasePending {
+		t.Fatalf("phase = %q, want Pending", approval.Status.Phase)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, approval); err != nil {
+		t.Fatalf("get approval: %v", err)
+	}
+	if approval.Status.Phase != mcpv1alpha1.ApprovalPhaseExpired {
+		t.Fatalf("phase = %q, want Expired", approval.Status.Phase)
+	}
+
+	// A decision made after the approval expired does not release the call.
+	approval.Spec.Decision = mcpv1alpha1.ApprovalDecisionApproved
+	approval.Spec.DecidedBy = "bob"
+	approval.Spec.DecidedAt = &metav1.Time{Time: now}
+	if phase, _ := approvalPhase(approval, now); phase != mcpv1alpha1.ApprovalPhaseExpired {
+		t.Fatalf("late approval phase = %q, want Expired", phase)
+	}
+}
@@ -0,0 +1,469 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/kubeworkload"
+)
+
+const (
+	defaultScaleToZeroIdleAfter = 15 * time.Minute
+	defaultColdStartTimeout     = 60 * time.Second
+	// scaleToZeroCheckInterval is how often an active scale-to-zero server is
+	// checked for idleness once its idle period has elapsed.
+	scaleToZeroCheckInterval = time.Minute
+
+	// Custom metrics the HPA reads for gateway targets. Serving them requires
+	// a custom metrics adapter (for example prometheus-adapter) exposing the
+	// gateway series per pod.
+	inFlightRequestsMetric  = "mcp_gateway_inflight_requests"
+	requestsPerSecondMetric = "mcp_gateway_requests_per_second"
+)
+
+// autoscalingEnabled reports whether an HPA owns the stable replica count.
+func autoscalingEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer != nil && mcpServer.Spec.Autoscaling != nil
+}
+
+// scaleToZeroEnabled reports whether the server may be scaled to zero. The
+// activator and idle detection both sit on the gateway.
+func scaleToZeroEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return autoscalingEnabled(mcpServer) &&
+		mcpServer.Spec.Autoscaling.ScaleToZero != nil &&
+		gatewayEnabled(mcpServer)
+}
+
+func scaledToZero(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return scaleToZeroEnabled(mcpServer) &&
+		mcpServer.Status.Autoscaling != nil &&
+		mcpServer.Status.Autoscaling.ScaledToZero
+}
+
+func autoscalingMinReplicas(mcpServer *mcpv1alpha1.MCPServer) int32 {
+	if minReplicas := mcpServer.Spec.Autoscaling.MinReplicas; minReplicas != nil && *minReplicas > 0 {
+		return *minReplicas
+	}
+	return 1
+}
+
+// stableDeploymentReplicas returns the stable Deployment's replica count. With
+// autoscaling the HPA owns the count, so the current value is kept; zero is
+// only kept while the server is scaled to zero.
+func stableDeploymentReplicas(mcpServer *mcpv1alpha1.MCPServer, current *int32) int32 {
+	if !autoscalingEnabled(mcpServer) {
+		return desiredStableReplicas(mcpServer)
+	}
+	if scaledToZero(mcpServer) {
+		return 0
+	}
+	if current != nil && *current > 0 {
+		return *current
+	}
+	return autoscalingMinReplicas(mcpServer)
+}
+
+func scaleToZeroDuration(value string, fallback time.Duration) time.Duration {
+	if duration, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && duration > 0 {
+		return duration
+	}
+	return fallback
+}
+
+func (r *MCPServerReconciler) reconcileHorizontalPodAutoscaler(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mcpServer.Name,
+			Namespace: mcpServer.Namespace,
+		},
+	}
+	if !autoscalingEnabled(mcpServer) {
+		return r.deleteOwned(ctx, mcpServer, hpa)
+	}
+
+	metrics, err := buildAutoscalingMetrics(mcpServer.Spec.Autoscaling)
+	if err != nil {
+		return err
+	}
+	minReplicas := autoscalingMinReplicas(mcpServer)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, hpa, func() error {
+		hpa.Labels = map[string]string{
+			LabelApp:       mcpServer.Name,
+			LabelManagedBy: LabelManagedByValue,
+		}
+		hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       mcpServer.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: mcpServer.Spec.Autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		}
+		return ctrl.SetControllerReference(mcpServer, hpa, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("HorizontalPodAutoscaler reconciled", "operation", op, "name", hpa.Name)
+	}
+	return nil
+}
+
+func buildAutoscalingMetrics(autoscaling *mcpv1alpha1.AutoscalingConfig) ([]autoscalingv2.MetricSpec, error) {
+	var metrics []autoscalingv2.MetricSpec
+	for _, target := range []struct {
+		name        corev1.ResourceName
+		utilization *int32
+	}{
+		{corev1.ResourceCPU, autoscaling.TargetCPUUtilization},
+		{corev1.ResourceMemory, autoscaling.TargetMemoryUtilization},
+	} {
+		if target.utilization == nil {
+			continue
+		}
+		utilization := *target.utilization
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		})
+	}
+	for _, target := range []struct {
+		metric string
+		value  string
+	}{
+		{inFlightRequestsMetric, autoscaling.TargetInFlightRequests},
+		{requestsPerSecondMetric, autoscaling.TargetRequestsPerSecond},
+	} {
+		value := strings.TrimSpace(target.value)
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s target %q: %w", target.metric, target.value, err)
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: target.metric},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &quantity,
+				},
+			},
+		})
+	}
+	return metrics, nil
+}
+
+// deleteOwned deletes obj (identified by name and namespace) if it exists and
+// is controlled by mcpServer.
+func (r *MCPServerReconciler) deleteOwned(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, mcpServer) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+func activatorName(serverName string) string {
+	return serverName + "-activator"
+}
+
+func workloadServiceName(serverName string) string {
+	return serverName + "-workload"
+}
+
+func activatorLabels(serverName string) map[string]string {
+	return map[string]string{
+		LabelApp:       activatorName(serverName),
+		LabelManagedBy: LabelManagedByValue,
+	}
+}
+
+// serviceSelector returns the selector of the server's Service. A
+// scale-to-zero server without ready replicas is served by its activator,
+// which holds requests until the Deployment is back.
+func (r *MCPServerReconciler) serviceSelector(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (map[string]string, error) {
+	workload := map[string]string{
+		LabelApp:       mcpServer.Name,
+		LabelManagedBy: LabelManagedByValue,
+	}
+	if !scaleToZeroEnabled(mcpServer) || strings.TrimSpace(r.ActivationURL) == "" {
+		return workload, nil
+	}
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, deployment)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && deployment.Status.ReadyReplicas > 0 {
+		return workload, nil
+	}
+	return activatorLabels(mcpServer.Name), nil
+}
+
+// reconcileActivator keeps the activator Deployment and the workload Service
+// it forwards to while scale to zero is enabled, and removes them otherwise.
+func (r *MCPServerReconciler) reconcileActivator(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: activatorName(mcpServer.Name), Namespace: mcpServer.Namespace}}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: workloadServiceName(mcpServer.Name), Namespace: mcpServer.Namespace}}
+	if !scaleToZeroEnabled(mcpServer) || strings.TrimSpace(r.ActivationURL) == "" {
+		if err := r.deleteOwned(ctx, mcpServer, deployment); err != nil {
+			return err
+		}
+		return r.deleteOwned(ctx, mcpServer, service)
+	}
+
+	image, err := r.resolveGatewayImage(mcpServer)
+	if err != nil {
+		return err
+	}
+	gatewayPort := mcpServer.Spec.Gateway.Port
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = map[string]string{
+			LabelApp:       mcpServer.Name,
+			LabelManagedBy: LabelManagedByValue,
+		}
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.Selector = map[string]string{
+			LabelApp:       mcpServer.Name,
+			LabelManagedBy: LabelManagedByValue,
+		}
+		service.Spec.Ports = []corev1.ServicePort{{
+			Name:       "http",
+			Port:       mcpServer.Spec.ServicePort,
+			TargetPort: intstr.FromInt32(gatewayPort),
+			Protocol:   corev1.ProtocolTCP,
+		}}
+		return ctrl.SetControllerReference(mcpServer, service, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	coldStart := scaleToZeroDuration(mcpServer.Spec.Autoscaling.ScaleToZero.ColdStartTimeout, defaultColdStartTimeout)
+	upstream := fmt.Sprintf("http://%s.%s.svc:%d", workloadServiceName(mcpServer.Name), mcpServer.Namespace, mcpServer.Spec.ServicePort)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		replicas := int32(1)
+		labels := activatorLabels(mcpServer.Name)
+		deployment.Labels = labels
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		// The component label lets activators through the operator's
+		// NetworkPolicy. It stays off the selector, which is immutable.
+		templateLabels := activatorLabels(mcpServer.Name)
+		templateLabels[LabelComponent] = LabelComponentActivator
+		deployment.Spec.Template.ObjectMeta.Labels = templateLabels
+		deployment.Spec.Template.Spec = corev1.PodSpec{
+			ImagePullSecrets: r.buildImagePullSecrets(mcpServer),
+			Containers: []corev1.Container{{
+				Name:            "activator",
+				Image:           image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "MCP_GATEWAY_MODE", Value: "activator"},
+					{Name: "PORT", Value: strconv.Itoa(int(gatewayPort))},
+					{Name: "METRICS_PORT", Value: strconv.Itoa(DefaultGatewayMetricsPort)},
+					{Name: "UPSTREAM_URL", Value: upstream},
+					{Name: "ACTIVATION_URL", Value: strings.TrimSpace(r.ActivationURL)},
+					{Name: "COLD_START_TIMEOUT", Value: coldStart.String()},
+					{Name: "MCP_SERVER_NAME", Value: mcpServer.Name},
+					{Name: "MCP_SERVER_NAMESPACE", Value: mcpServer.Namespace},
+					{Name: "OPERATOR_TOKEN_FILE", Value: operatorTokenMountDir + "/" + operatorTokenPath},
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      gatewayOperatorTokenVolumeName,
+					MountPath: operatorTokenMountDir,
+					ReadOnly:  true,
+				}},
+				Ports: []corev1.ContainerPort{
+					{Name: "gateway", ContainerPort: gatewayPort, Protocol: corev1.ProtocolTCP},
+					{Name: "metrics", ContainerPort: DefaultGatewayMetricsPort, Protocol: corev1.ProtocolTCP},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("10m"),
+						corev1.ResourceMemory: resource.MustParse("32Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("200m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+				SecurityContext: kubeworkload.RestrictedReadOnlyContainerSecurityContext(),
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(DefaultGatewayMetricsPort)},
+					},
+					PeriodSeconds: 10,
+				},
+			}},
+			Volumes: []corev1.Volume{operatorTokenVolume(gatewayOperatorTokenVolumeName)},
+		}
+		kubeworkload.ApplyRestrictedPodDefaults(&deployment.Spec.Template.Spec)
+		return ctrl.SetControllerReference(mcpServer, deployment, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("Activator deployment reconciled", "operation", op, "name", deployment.Name)
+	}
+	return nil
+}
+
+// activationRequestedAt parses ActivationRequestedAnnotation.
+func activationRequestedAt(mcpServer *mcpv1alpha1.MCPServer) (time.Time, bool) {
+	value := strings.TrimSpace(mcpServer.Annotations[mcpv1alpha1.ActivationRequestedAnnotation])
+	if value == "" {
+		return time.Time{}, false
+	}
+	requested, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return requested, true
+}
+
+// idleRequestsQuery counts the requests a server's gateways handled over the
+// idle window. A gateway has no request series until it serves a request, so
+// the count falls back to zero while Prometheus scrapes the policy reload
+// series every gateway records at startup; with neither there is no sample.
+func idleRequestsQuery(namespace, server string, window time.Duration) string {
+	selector := fmt.Sprintf(`{namespace=%q,server=%q}`, namespace, server)
+	return fmt.Sprintf(`sum(increase(mcp_gateway_requests_total%s[%ds])) or 0 * sum(mcp_gateway_policy_reloads_total%s)`,
+		selector, int64(window.Round(time.Second)/time.Second), selector)
+}
+
+// reconcileScaleToZero decides whether the stable Deployment is held at zero
+// replicas and records the decision in status.autoscaling before the
+// Deployment is rendered. A server is scaled to zero once its gateways saw no
+// requests for idleAfter, and back up when an activator requests activation
+// after that. It returns when the server should next be checked.
+func (r *MCPServerReconciler) reconcileScaleToZero(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (time.Duration, error) {
+	if !scaleToZeroEnabled(mcpServer) {
+		if mcpServer.Status.Autoscaling == nil {
+			return 0, nil
+		}
+		mcpServer.Status.Autoscaling = nil
+		return 0, r.writeAutoscalingStatus(ctx, mcpServer, "", "")
+	}
+
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	status := &mcpv1alpha1.AutoscalingStatus{}
+	if mcpServer.Status.Autoscaling != nil {
+		status = mcpServer.Status.Autoscaling.DeepCopy()
+	}
+	requested, hasRequest := activationRequestedAt(mcpServer)
+
+	if status.ScaledToZero {
+		if !hasRequest || (status.LastScaledToZeroTime != nil && !requested.After(status.LastScaledToZeroTime.Time)) {
+			return 0, nil
+		}
+		status.ScaledToZero = false
+		status.LastActivationTime = &metav1.Time{Time: now}
+		mcpServer.Status.Autoscaling = status
+		return 0, r.writeAutoscalingStatus(ctx, mcpServer, EventReasonScaledFromZero,
+			fmt.Sprintf("Scaling Deployment %s up from zero for a request held by the activator", mcpServer.Name))
+	}
+
+	// The idle clock starts at the latest of creation, the last activation,
+	// and the last activation request.
+	activeSince := mcpServer.CreationTimestamp.Time
+	if status.LastActivationTime != nil && status.LastActivationTime.After(activeSince) {
+		activeSince = status.LastActivationTime.Time
+	}
+	if hasRequest && requested.After(activeSince) {
+		activeSince = requested
+	}
+	idleAfter := scaleToZeroDuration(mcpServer.Spec.Autoscaling.ScaleToZero.IdleAfter, defaultScaleToZeroIdleAfter)
+	if wait := idleAfter - now.Sub(activeSince); wait > 0 {
+		return r.storeAutoscalingStatus(ctx, mcpServer, status, wait)
+	}
+	if r.Metrics == nil || strings.TrimSpace(r.ActivationURL) == "" {
+		// Without request metrics the server cannot be shown idle, and without
+		// an activation endpoint it could not be woken again.
+		return r.storeAutoscalingStatus(ctx, mcpServer, status, 0)
+	}
+
+	requests, ok, err := r.Metrics.Query(ctx, idleRequestsQuery(mcpServer.Namespace, mcpServer.Name, idleAfter))
+	if err != nil {
+		log.FromContext(ctx).Info("Scale-to-zero idle query failed", "mcpServer", mcpServer.Name, "error", err.Error())
+		return r.storeAutoscalingStatus(ctx, mcpServer, status, scaleToZeroCheckInterval)
+	}
+	// Without a sample Prometheus is not scraping the gateways, so the
+	// server may be busy; it stays up until the next check.
+	if !ok {
+		log.FromContext(ctx).Info("Scale-to-zero idle query returned no sample", "mcpServer", mcpServer.Name)
+		return r.storeAutoscalingStatus(ctx, mcpServer, status, scaleToZeroCheckInterval)
+	}
+	if requests > 0 {
+		return r.storeAutoscalingStatus(ctx, mcpServer, status, scaleToZeroCheckInterval)
+	}
+	status.ScaledToZero = true
+	status.LastScaledToZeroTime = &metav1.Time{Time: now}
+	mcpServer.Status.Autoscaling = status
+	return 0, r.writeAutoscalingStatus(ctx, mcpServer, EventReasonScaledToZero,
+		fmt.Sprintf("No requests for %s; scaled Deployment %s to zero", idleAfter, mcpServer.Name))
+}
+
+// storeAutoscalingStatus records status when it is new, such as on the first
+// reconcile after scale to zero is enabled, and passes requeue through.
+func (r *MCPServerReconciler) storeAutoscalingStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, status *mcpv1alpha1.AutoscalingStatus, requeue time.Duration) (time.Duration, error) {
+	if equality.Semantic.DeepEqual(mcpServer.Status.Autoscaling, status) {
+		return requeue, nil
+	}
+	mcpServer.Status.Autoscaling = status
+	return requeue, r.writeAutoscalingStatus(ctx, mcpServer, "", "")
+}
+
+// writeAutoscalingStatus stores mcpServer.Status.Autoscaling on the latest
+// object and records an event when reason is set.
+func (r *MCPServerReconciler) writeAutoscalingStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, reason, note string) error {
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !equality.Semantic.DeepEqual(latest.Status.Autoscaling, mcpServer.Status.Autoscaling) {
+		latest.Status.Autoscaling = mcpServer.Status.Autoscaling.DeepCopy()
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+	}
+	if reason != "" {
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, reason, "ScaleToZero", "%s", note)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package operator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newAutoscalingScheme() *runtime.Scheme {
+	scheme := newCanaryScheme()
+	_ = autoscalingv2.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
+	return scheme
+}
+
+func scaleToZeroServer(created time.Time) *mcpv1alpha1.MCPServer {
+	maxReplicas := int32(4)
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers", CreationTimestamp: metav1.Time{Time: created}},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:       "example.com/payments",
+			ServicePort: 80,
+			Gateway:     &mcpv1alpha1.GatewayConfig{Enabled: true, Image: "example.com/gateway:v1", Port: 8091},
+			Autoscaling: &mcpv1alpha1.AutoscalingConfig{
+				MaxReplicas:            maxReplicas,
+				TargetInFlightRequests: "10",
+				ScaleToZero:            &mcpv1alpha1.ScaleToZeroConfig{IdleAfter: "10m"},
+			},
+		},
+	}
+}
+
+func TestReconcileHorizontalPodAutoscaler(t *testing.T) {
+	minReplicas, cpu := int32(2), int32(70)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Autoscaling: &mcpv1alpha1.AutoscalingConfig{
+				MinReplicas:            &minReplicas,
+				MaxReplicas:            6,
+				TargetCPUUtilization:   &cpu,
+				TargetInFlightRequests: "20",
+			},
+		},
+	}
+	scheme := newAutoscalingScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if err := r.reconcileHorizontalPodAutoscaler(ctx, server); err != nil {
+		t.Fatalf("reconcile HPA: %v", err)
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "payments", Namespace: "servers"}, hpa); err != nil {
+		t.Fatalf("get HPA: %v", err)
+	}
+	if hpa.Spec.ScaleTargetRef.Kind != "Deployment" || hpa.Spec.ScaleTargetRef.Name != "payments" {
+		t.Fatalf("scale target = %#v", hpa.Spec.ScaleTargetRef)
+	}
+	if *hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 6 || len(hpa.Spec.Metrics) != 2 {
+		t.Fatalf("HPA spec = %#v", hpa.Spec)
+	}
+	if cpuMetric := hpa.Spec.Metrics[0].Resource; cpuMetric == nil || *cpuMetric.Target.AverageUtilization != 70 {
+		t.Fatalf("cpu metric = %#v", hpa.Spec.Metrics[0])
+	}
+	if pods := hpa.Spec.Metrics[1].Pods; pods == nil || pods.Metric.Name != inFlightRequestsMetric || pods.Target.AverageValue.String() != "20" {
+		t.Fatalf("in-flight metric = %#v", hpa.Spec.Metrics[1])
+	}
+
+	server.Spec.Autoscaling = nil
+	if err := r.reconcileHorizontalPodAutoscaler(ctx, server); err != nil {
+		t.Fatalf("remove HPA: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "payments", Namespace: "servers"}, hpa); !errors.IsNotFound(err) {
+		t.Fatalf("expected the HPA to be deleted, got %v", err)
+	}
+}
+
+func TestStableDeploymentReplicas(t *testing.T) {
+	current := int32(5)
+	replicas := int32(3)
+	fixed := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Replicas: &replicas}}
+	if got := stableDeploymentReplicas(fixed, &current); got != 3 {
+		t.Fatalf("fixed replicas = %d, want spec.replicas", got)
+	}
+
+	server := scaleToZeroServer(time.Now())
+	if got := stableDeploymentReplicas(server, &current); got != 5 {
+		t.Fatalf("autoscaled replicas = %d, want the HPA's current count", got)
+	}
+	if got := stableDeploymentReplicas(server, nil); got != 1 {
+		t.Fatalf("new autoscaled replicas = %d, want minReplicas", got)
+	}
+	server.Status.Autoscaling = &mcpv1alpha1.AutoscalingStatus{ScaledToZero: true}
+	if got := stableDeploymentReplicas(server, &current); got != 0 {
+		t.Fatalf("scaled-to-zero replicas = %d, want 0", got)
+	}
+}
+
+func TestScaleToZeroIdleAndActivation(t *testing.T) {
+	created := time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)
+	metrics := staticCanaryMetrics{"mcp_gateway_requests_total": 4}
+	r, recorder, now := newCanaryTestReconciler(newAutoscalingScheme(), metrics, scaleToZeroServer(created))
+	r.ActivationURL = "http://activation.example/activate"
+	ctx := context.Background()
+	get := func() *mcpv1alpha1.MCPServer {
+		t.Helper()
+		server := &mcpv1alpha1.MCPServer{}
+		if err := r.Get(ctx, types.NamespacedName{Name: "payments", Namespace: "servers"}, server); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		return server
+	}
+
+	requeue, err := r.reconcileScaleToZero(ctx, get())
+	if err != nil {
+		t.Fatalf("busy server: %v", err)
+	}
+	if requeue != scaleToZeroCheckInterval || scaledToZero(get()) {
+		t.Fatalf("requeue = %s, scaled = %v; want a busy server kept up", requeue, scaledToZero(get()))
+	}
+
+	// No sample means the gateways are not scraped, not that they are idle.
+	delete(metrics, "mcp_gateway_requests_total")
+	requeue, err = r.reconcileScaleToZero(ctx, get())
+	if err != nil {
+		t.Fatalf("unscraped server: %v", err)
+	}
+	if requeue != scaleToZeroCheckInterval || scaledToZero(get()) {
+		t.Fatalf("requeue = %s, scaled = %v; want a server without samples kept up", requeue, scaledToZero(get()))
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Fatalf("events = %v, want none", events)
+	}
+
+	metrics["mcp_gateway_requests_total"] = 0
+	if _, err := r.reconcileScaleToZero(ctx, get()); err != nil {
+		t.Fatalf("idle server: %v", err)
+	}
+	server := get()
+	if !scaledToZero(server) || server.Status.Autoscaling.LastScaledToZeroTime == nil {
+		t.Fatalf("status.autoscaling = %#v, want scaled to zero", server.Status.Autoscaling)
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.Contains(events[0], EventReasonScaledToZero) {
+		t.Fatalf("events = %v, want ScaledToZero", events)
+	}
+	selector, err := r.serviceSelector(ctx, server)
+	if err != nil || selector[LabelApp] != "payments-activator" {
+		t.Fatalf("service selector = %v, %v; want the activator", selector, err)
+	}
+
+	// An activation request from before the scale-down does not wake it.
+	server.Annotations = map[string]string{mcpv1alpha1.ActivationRequestedAnnotation: now.Add(-time.Minute).Format(time.RFC3339Nano)}
+	if _, err := r.reconcileScaleToZero(ctx, server); err != nil || !scaledToZero(get()) {
+		t.Fatalf("stale activation woke the server (err %v)", err)
+	}
+
+	*now = now.Add(time.Hour)
+	server = get()
+	server.Annotations = map[string]string{mcpv1alpha1.ActivationRequestedAnnotation: now.Format(time.RFC3339Nano)}
+	if err := r.Update(ctx, server); err != nil {
+		t.Fatalf("annotate MCPServer: %v", err)
+	}
+	requeue, err = r.reconcileScaleToZero(ctx, get())
+	if err != nil {
+		t.Fatalf("activate server: %v", err)
+	}
+	server = get()
+	if scaledToZero(server) || server.Status.Autoscaling.LastActivationTime == nil {
+		t.Fatalf("status.autoscaling = %#v, want activated", server.Status.Autoscaling)
+	}
+	if requeue != 0 {
+		t.Fatalf("requeue = %s, want an immediate reconcile", requeue)
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.Contains(events[0], EventReasonScaledFromZero) {
+		t.Fatalf("events = %v, want ScaledFromZero", events)
+	}
+
+	// The idle clock restarts at the activation.
+	requeue, err = r.reconcileScaleToZero(ctx, server)
+	if err != nil || requeue != 10*time.Minute {
+		t.Fatalf("requeue = %s, %v; want the full idle period", requeue, err)
+	}
+}
+
+func TestServiceSelectorUsesWorkloadWhenReady(t *testing.T) {
+	ready := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	r, _, _ := newCanaryTestReconciler(newAutoscalingScheme(), nil, ready)
+	r.ActivationURL = "http://activation.example/activate"
+
+	selector, err := r.serviceSelector(context.Background(), scaleToZeroServer(time.Now()))
+	if err != nil || selector[LabelApp] != "payments" {
+		t.Fatalf("service selector = %v, %v; want the workload", selector, err)
+	}
+}
+
+func TestActivationServerRecordsRequest(t *testing.T) {
+	plain := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "servers"}}
+	scheme := newAutoscalingScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		scaleToZeroServer(time.Now()),
+		plain,
+		callerTestPod("payments-activator-0", "servers", "payments-activator", LabelComponentActivator),
+		callerTestPod("plain-activator-0", "servers", "plain-activator", LabelComponentActivator),
+		callerTestPod("missing-activator-0", "servers", "missing-activator", LabelComponentActivator),
+		callerTestPod("payments-gw", "servers", "payments", LabelComponentGateway),
+		callerTestPod("payments-activator-0", "other", "payments-activator", LabelComponentActivator),
+	).Build()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := &ActivationServer{Client: c, now: func() time.Time { return now }, auth: callerTestAuthenticator(c)}
+
+	post := func(method, caller, body string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, ActivationPath, strings.NewReader(body))
+		if caller != "" {
+			req.Header.Set("Authorization", "Bearer "+caller)
+		}
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	for _, tc := range []struct {
+		method, caller, body string
+		want                 int
+	}{
+		{http.MethodGet, "", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "", `{"namespace":"servers","name":"payments"}`, http.StatusUnauthorized},
+		{http.MethodPost, "servers/gone", `{"namespace":"servers","name":"payments"}`, http.StatusUnauthorized},
+		{http.MethodPost, "servers/payments-activator-0", "{", http.StatusBadRequest},
+		{http.MethodPost, "servers/payments-activator-0", `{"namespace":"servers","name":"Bad_Name"}`, http.StatusBadRequest},
+		{http.MethodPost, "servers/missing-activator-0", `{"namespace":"servers","name":"missing"}`, http.StatusNotFound},
+		{http.MethodPost, "servers/plain-activator-0", `{"namespace":"servers","name":"plain"}`, http.StatusConflict},
+		{http.MethodPost, "servers/plain-activator-0", `{"namespace":"servers","name":"payments"}`, http.StatusForbidden},
+		{http.MethodPost, "servers/payments-gw", `{"namespace":"servers","name":"payments"}`, http.StatusForbidden},
+		{http.MethodPost, "other/payments-activator-0", `{"namespace":"servers","name":"payments"}`, http.StatusForbidden},
+		{http.MethodPost, "servers/payments-activator-0", `{"namespace":"servers","name":"payments"}`, http.StatusAccepted},
+	} {
+		if got := post(tc.method, tc.caller, tc.body); got != tc.want {
+			t.Fatalf("%s as %q %s = %d, want %d", tc.method, tc.caller, tc.body, got, tc.want)
+		}
+	}
+
+	server := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "payments", Namespace: "servers"}, server); err != nil {
+		t.Fatalf("get MCPServer: %v", err)
+	}
+	if requested, ok := activationRequestedAt(server); !ok || !requested.Equal(now) {
+		t.Fatalf("activation annotation = %q, want %s", server.Annotations[mcpv1alpha1.ActivationRequestedAnnotation], now)
+	}
+}
+
+func TestReconcileActivatorAuthenticatesToOperator(t *testing.T) {
+	server := scaleToZeroServer(time.Now())
+	r, _, _ := newCanaryTestReconciler(newAutoscalingScheme(), nil, server)
+	r.ActivationURL = "http://activation.example/activate"
+	if err := r.reconcileActivator(context.Background(), server); err != nil {
+		t.Fatalf("reconcileActivator() error = %v", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "payments-activator", Namespace: "servers"}, deployment); err != nil {
+		t.Fatalf("get activator: %v", err)
+	}
+	if deployment.Spec.Template.Labels[LabelComponent] != LabelComponentActivator {
+		t.Fatalf("template labels = %v, want the activator component", deployment.Spec.Template.Labels)
+	}
+	if _, ok := deployment.Spec.Selector.MatchLabels[LabelComponent]; ok {
+		t.Fatalf("selector = %v, want the component label kept off the immutable selector", deployment.Spec.Selector.MatchLabels)
+	}
+	pod := deployment.Spec.Template.Spec
+	if len(pod.Volumes) != 1 || pod.Volumes[0].Projected == nil || pod.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience != OperatorTokenAudience {
+		t.Fatalf("volumes = %#v, want a token projected for the operator audience", pod.Volumes)
+	}
+	if got := envValue(pod.Containers[0].Env, "OPERATOR_TOKEN_FILE"); got != operatorTokenMountDir+"/token" {
+		t.Fatalf("OPERATOR_TOKEN_FILE = %q", got)
+	}
+}
+
+func TestIdleRequestsQueryFallsBackToZeroForScrapedGateways(t *testing.T) {
+	got := idleRequestsQuery("servers", "payments", 15*time.Minute)
+	want := `sum(increase(mcp_gateway_requests_total{namespace="servers",server="payments"}[900s])) or 0 * sum(mcp_gateway_policy_reloads_total{namespace="servers",server="payments"})`
+	if got != want {
+		t.Fatalf("idleRequestsQuery() = %s\nwant %s", got, want)
+	}
+}
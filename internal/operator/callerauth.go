@@ -0,0 +1,169 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// OperatorTokenAudience is the audience of the projected ServiceAccount
+// tokens gateways and activators present to the activation server. A token
+// minted for the API server or any other audience is rejected.
+const OperatorTokenAudience = "mcp-runtime-operator"
+
+// Extra keys the API server sets on a TokenReview of a pod-bound token.
+const (
+	podNameExtraKey = "authentication.kubernetes.io/pod-name"
+	podUIDExtraKey  = "authentication.kubernetes.io/pod-uid"
+)
+
+const (
+	gatewayOperatorTokenVolumeName = mcpv1alpha1.GatewayOperatorTokenVolumeName
+	operatorTokenMountDir          = mcpv1alpha1.GatewayMountRoot + "/operator-token"
+	operatorTokenPath              = "token"
+	// operatorTokenExpirationSeconds is how long a projected token is valid.
+	// The kubelet rotates it well before then and callers re-read the file
+	// for every request.
+	operatorTokenExpirationSeconds = 3600
+)
+
+var (
+	errCallerUnauthenticated = errors.New("caller is not authenticated")
+	errCallerForbidden       = errors.New("caller may not act for this server")
+)
+
+// callerAuthenticator identifies the pod behind a request to the activation
+// server from its bearer token, a projected ServiceAccount token bound to
+// the pod and OperatorTokenAudience.
+type callerAuthenticator struct {
+	// client creates TokenReviews.
+	client client.Client
+	// reader reads caller pods; nil means client. The manager passes its
+	// uncached API reader so the operator does not watch every pod.
+	reader client.Reader
+
+	// reviewToken reviews a token; nil creates a TokenReview.
+	reviewToken func(ctx context.Context, token string) (authenticationv1.TokenReviewStatus, error)
+}
+
+// callerPod returns the running pod the request's token was issued to.
+func (a *callerAuthenticator) callerPod(r *http.Request) (*corev1.Pod, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return nil, errCallerUnauthenticated
+	}
+	ctx := r.Context()
+	status, err := a.review(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("review caller token: %w", err)
+	}
+	if !status.Authenticated || !slices.Contains(status.Audiences, OperatorTokenAudience) {
+		return nil, errCallerUnauthenticated
+	}
+	parts := strings.Split(status.User.Username, ":")
+	if len(parts) != 4 || parts[0] != "system" || parts[1] != "serviceaccount" {
+		return nil, errCallerUnauthenticated
+	}
+	namespace, serviceAccount := parts[2], parts[3]
+	podName, podUID := extraValue(status.User.Extra, podNameExtraKey), extraValue(status.User.Extra, podUIDExtraKey)
+	if podName == "" || podUID == "" {
+		// Only pod-bound tokens name the pod they were issued to.
+		return nil, errCallerUnauthenticated
+	}
+
+	reader := a.reader
+	if reader == nil {
+		reader = a.client
+	}
+	pod := &corev1.Pod{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: podName, Namespace: namespace}, pod); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, errCallerUnauthenticated
+		}
+		return nil, fmt.Errorf("read caller pod: %w", err)
+	}
+	if string(pod.UID) != podUID || pod.Spec.ServiceAccountName != serviceAccount || pod.DeletionTimestamp != nil {
+		return nil, errCallerUnauthenticated
+	}
+	return pod, nil
+}
+
+func (a *callerAuthenticator) review(ctx context.Context, token string) (authenticationv1.TokenReviewStatus, error) {
+	if a.reviewToken != nil {
+		return a.reviewToken(ctx, token)
+	}
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{OperatorTokenAudience},
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return authenticationv1.TokenReviewStatus{}, err
+	}
+	return review.Status, nil
+}
+
+func extraValue(extra map[string]authenticationv1.ExtraValue, key string) string {
+	if values := extra[key]; len(values) == 1 {
+		return values[0]
+	}
+	return ""
+}
+
+// callerIsComponent reports whether pod runs component for the MCPServer
+// namespace/server. The operator sets both labels on the pod templates it
+// builds; pods in other namespaces never match.
+func callerIsComponent(pod *corev1.Pod, component, namespace, app string) bool {
+	return pod.Namespace == namespace &&
+		pod.Labels[LabelComponent] == component &&
+		pod.Labels[LabelApp] == app
+}
+
+// writeCallerError answers a request whose caller could not be identified
+// or is not allowed to act for the requested server.
+func writeCallerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errCallerUnauthenticated):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mcp-runtime-operator"`)
+		http.Error(w, "caller is not authenticated", http.StatusUnauthorized)
+	case errors.Is(err, errCallerForbidden):
+		http.Error(w, "caller may not act for this server", http.StatusForbidden)
+	default:
+		http.Error(w, "failed to authenticate caller", http.StatusInternalServerError)
+	}
+}
+
+// operatorTokenVolume projects a ServiceAccount token for
+// OperatorTokenAudience, bound to the pod that mounts it.
+func operatorTokenVolume(name string) corev1.Volume {
+	expiration := int64(operatorTokenExpirationSeconds)
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          OperatorTokenAudience,
+						ExpirationSeconds: &expiration,
+						Path:              operatorTokenPath,
+					},
+				}},
+			},
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get
@@ -0,0 +1,464 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/controlplane"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+// canaryMetricsRetry is how long analysis waits after a failed metrics query
+// or an inconclusive step.
+const canaryMetricsRetry = time.Minute
+
+// canaryInconclusiveFactor bounds how long a step waits for samples: a step
+// whose canary track still has none once this many step durations passed
+// since it started is rolled back rather than promoted without evidence.
+const canaryInconclusiveFactor = 2
+
+// canaryAnalysisEnabled reports whether the canary is driven by automated
+// analysis rather than simply mirroring the stable image.
+func canaryAnalysisEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return canaryEnabled(mcpServer) &&
+		mcpServer.Spec.Rollout.Analysis != nil &&
+		len(mcpServer.Spec.Rollout.Analysis.Steps) > 0
+}
+
+// stableTrackImage returns the image for the stable Deployment. Under canary
+// analysis the stable track keeps the last promoted image; before the first
+// analysis it keeps whatever it already runs so enabling analysis together
+// with an image change does not skip the canary.
+func (r *MCPServerReconciler) stableTrackImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, desired string) (string, error) {
+	if !canaryAnalysisEnabled(mcpServer) {
+		return desired, nil
+	}
+	if rollout := mcpServer.Status.Rollout; rollout != nil && rollout.StableImage != "" {
+		return rollout.StableImage, nil
+	}
+	return r.currentStableImage(ctx, mcpServer, desired)
+}
+
+// canaryTrackImage returns the image for the canary Deployment: the desired
+// image, unless that image was rolled back or aborted, in which case the
+// canary falls back to the stable image until spec.image changes again.
+func canaryTrackImage(mcpServer *mcpv1alpha1.MCPServer, desired string) string {
+	if !canaryAnalysisEnabled(mcpServer) {
+		return desired
+	}
+	rollout := mcpServer.Status.Rollout
+	if rollout == nil || rollout.StableImage == "" || rollout.CanaryImage != desired {
+		return desired
+	}
+	if rollout.Phase == mcpv1alpha1.RolloutPhaseRolledBack || rollout.Phase == mcpv1alpha1.RolloutPhaseAborted {
+		return rollout.StableImage
+	}
+	return desired
+}
+
+// currentStableImage returns the image the stable Deployment runs, or
+// fallback when there is none. When the server's image must be pinned, the
+// running image goes through the same digest and signature checks as
+// spec.image, since it may predate them; an image that fails them is not kept
+// and the stable track moves to fallback, which already passed.
+func (r *MCPServerReconciler) currentStableImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, fallback string) (string, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return fallback, nil
+		}
+		return "", err
+	}
+	image := deploymentContainerImage(deployment, mcpServer.Name)
+	if image == "" || image == fallback {
+		return fallback, nil
+	}
+	if !imagePinned(mcpServer) {
+		return image, nil
+	}
+	digest, reason, err := r.verifyImage(ctx, mcpServer, image)
+	if err != nil {
+		if reason == "" {
+			return "", err
+		}
+		log.FromContext(ctx).Info("Running image failed verification; the stable track runs the desired image", "image", image, "reason", reason, "error", err.Error())
+		return fallback, nil
+	}
+	return pinnedImage(image, digest), nil
+}
+
+func deploymentContainerImage(deployment *appsv1.Deployment, container string) string {
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Name == container {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+// canaryServing reports whether the canary Deployment has fully rolled out
+// image and all its replicas are ready.
+func (r *MCPServerReconciler) canaryServing(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, image string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: canaryDeploymentName(mcpServer.Name), Namespace: mcpServer.Namespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	desired := controlplane.DeploymentDesiredReplicas(*deployment, 0)
+	return deploymentContainerImage(deployment, mcpServer.Name) == image &&
+		deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == desired &&
+		deployment.Status.ReadyReplicas == desired &&
+		desired > 0, nil
+}
+
+// canaryEvent is an Event to record once the rollout status is persisted.
+type canaryEvent struct {
+	eventType, reason, note string
+}
+
+// reconcileCanaryAnalysis advances automated canary analysis and records it
+// in status.rollout. It returns how long to wait before the next check, or
+// zero when nothing is pending.
+func (r *MCPServerReconciler) reconcileCanaryAnalysis(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (time.Duration, error) {
+	if !canaryAnalysisEnabled(mcpServer) {
+		if mcpServer.Status.Rollout == nil {
+			return 0, nil
+		}
+		return 0, r.writeRolloutStatus(ctx, mcpServer, nil, false, nil)
+	}
+
+	desired, err := r.resolveImage(ctx, mcpServer)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+
+	rollout := &mcpv1alpha1.RolloutStatus{}
+	if mcpServer.Status.Rollout != nil {
+		rollout = mcpServer.Status.Rollout.DeepCopy()
+	}
+	if rollout.StableImage == "" {
+		stable, err := r.currentStableImage(ctx, mcpServer, desired)
+		if err != nil {
+			return 0, err
+		}
+		rollout.StableImage = stable
+		rollout.Phase = mcpv1alpha1.RolloutPhaseStable
+		rollout.Message = "stable image " + stable
+	}
+
+	var events []canaryEvent
+	action := strings.TrimSpace(mcpServer.Annotations[mcpv1alpha1.RolloutActionAnnotation])
+	if action != "" {
+		events = append(events, applyRolloutAction(rollout, action, now))
+	}
+
+	var requeue time.Duration
+	switch {
+	case desired == rollout.StableImage:
+		if rollout.Phase == mcpv1alpha1.RolloutPhaseProgressing {
+			rollout.Phase = mcpv1alpha1.RolloutPhaseStable
+			rollout.Message = "spec image matches the stable image; canary analysis stopped"
+			rollout.StepStartedAt = nil
+		}
+	case desired != rollout.CanaryImage:
+		rollout.Phase = mcpv1alpha1.RolloutPhaseProgressing
+		rollout.CanaryImage = desired
+		rollout.Step = 0
+		rollout.StepStartedAt = nil
+		rollout.LastAnalysis = nil
+		rollout.Message = "waiting for the canary to run " + desired
+		events = append(events, canaryEvent{corev1.EventTypeNormal, EventReasonCanaryAnalysisStarted,
+			fmt.Sprintf("Started canary analysis of %s against stable %s", desired, rollout.StableImage)})
+	case rollout.Phase == mcpv1alpha1.RolloutPhaseProgressing:
+		requeue, err = r.analyseCanaryStep(ctx, mcpServer, rollout, now, &events)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.writeRolloutStatus(ctx, mcpServer, rollout, action != "", events); err != nil {
+		return 0, err
+	}
+	return requeue, nil
+}
+
+// applyRolloutAction performs a promote or abort requested through
+// RolloutActionAnnotation.
+func applyRolloutAction(rollout *mcpv1alpha1.RolloutStatus, action string, now time.Time) canaryEvent {
+	pending := rollout.CanaryImage != "" && rollout.CanaryImage != rollout.StableImage
+	switch action {
+	case mcpv1alpha1.RolloutActionPromote:
+		if pending && rollout.Phase != mcpv1alpha1.RolloutPhaseStable && rollout.Phase != mcpv1alpha1.RolloutPhasePromoted {
+			promoteCanary(rollout, now, "promoted manually")
+			return canaryEvent{corev1.EventTypeNormal, EventReasonCanaryPromoted, "Canary " + rollout.StableImage + " promoted manually"}
+		}
+	case mcpv1alpha1.RolloutActionAbort:
+		if rollout.Phase == mcpv1alpha1.RolloutPhaseProgressing {
+			rollout.Phase = mcpv1alpha1.RolloutPhaseAborted
+			rollout.StepStartedAt = nil
+			rollout.Message = "aborted manually; the canary runs the stable image until spec.image changes"
+			return canaryEvent{corev1.EventTypeNormal, EventReasonCanaryAborted, "Canary " + rollout.CanaryImage + " aborted manually"}
+		}
+	default:
+		return canaryEvent{corev1.EventTypeWarning, EventReasonRolloutActionIgnored,
+			fmt.Sprintf("Unknown rollout action %q; use %s or %s", action, mcpv1alpha1.RolloutActionPromote, mcpv1alpha1.RolloutActionAbort)}
+	}
+	return canaryEvent{corev1.EventTypeWarning, EventReasonRolloutActionIgnored,
+		fmt.Sprintf("Rollout action %s ignored in phase %s", action, rollout.Phase)}
+}
+
+func promoteCanary(rollout *mcpv1alpha1.RolloutStatus, now time.Time, reason string) {
+	rollout.Phase = mcpv1alpha1.RolloutPhasePromoted
+	rollout.StableImage = rollout.CanaryImage
+	rollout.StepStartedAt = nil
+	rollout.Message = fmt.Sprintf("%s %s at %s", rollout.CanaryImage, reason, now.UTC().Format(time.RFC3339))
+}
+
+// analyseCanaryStep waits for the current step's duration with the canary
+// serving, then compares the tracks and advances, promotes, or rolls back.
+func (r *MCPServerReconciler) analyseCanaryStep(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, rollout *mcpv1alpha1.RolloutStatus, now time.Time, events *[]canaryEvent) (time.Duration, error) {
+	steps := mcpServer.Spec.Rollout.Analysis.Steps
+	if int(rollout.Step) >= len(steps) {
+		rollout.Step = int32(len(steps) - 1)
+	}
+	serving, err := r.canaryServing(ctx, mcpServer, rollout.CanaryImage)
+	if err != nil {
+		return 0, err
+	}
+	if !serving {
+		// The step clock only runs while the canary serves the image under
+		// analysis; the MCPServer requeue picks analysis back up.
+		rollout.StepStartedAt = nil
+		rollout.Message = "waiting for the canary to run " + rollout.CanaryImage
+		return 0, nil
+	}
+	if rollout.StepStartedAt == nil {
+		started := metav1.NewTime(now)
+		rollout.StepStartedAt = &started
+	}
+
+	step := steps[rollout.Step]
+	duration, _ := time.ParseDuration(strings.TrimSpace(step.Duration))
+	if elapsed := now.Sub(rollout.StepStartedAt.Time); elapsed < duration {
+		rollout.Message = fmt.Sprintf("step %d/%d: observing %s for %s", rollout.Step+1, len(steps), rollout.CanaryImage, duration)
+		return duration - elapsed, nil
+	}
+
+	result, failures, missing, err := r.evaluateCanaryStep(ctx, mcpServer, step, duration)
+	if err != nil {
+		log.FromContext(ctx).Info("Canary analysis query failed", "mcpServer", mcpServer.Name, "error", err.Error())
+		rollout.Message = fmt.Sprintf("step %d/%d: metrics unavailable, retrying: %v", rollout.Step+1, len(steps), err)
+		return canaryMetricsRetry, nil
+	}
+	result.Step = rollout.Step
+	result.Time = metav1.NewTime(now)
+	rollout.LastAnalysis = result
+
+	if len(failures) == 0 && len(missing) > 0 {
+		// No samples means no traffic reached the canary, not that it is
+		// healthy: keep the step open and roll back at its deadline.
+		deadline := rollout.StepStartedAt.Add(canaryInconclusiveFactor * duration)
+		if now.Before(deadline) {
+			rollout.Message = fmt.Sprintf("step %d/%d: inconclusive, no canary samples for %s; rolling back at %s unless traffic arrives",
+				rollout.Step+1, len(steps), strings.Join(missing, ", "), deadline.UTC().Format(time.RFC3339))
+			return min(canaryMetricsRetry, deadline.Sub(now)), nil
+		}
+		failures = append(failures, "no canary samples for "+strings.Join(missing, ", ")+" by the step deadline")
+	}
+
+	if len(failures) > 0 {
+		rollout.Phase = mcpv1alpha1.RolloutPhaseRolledBack
+		rollout.StepStartedAt = nil
+		rollout.Message = fmt.Sprintf("step %d/%d failed: %s", rollout.Step+1, len(steps), strings.Join(failures, "; "))
+		*events = append(*events, canaryEvent{corev1.EventTypeWarning, EventReasonCanaryRolledBack,
+			fmt.Sprintf("Canary %s rolled back: %s", rollout.CanaryImage, rollout.Message)})
+		return 0, nil
+	}
+
+	rollout.Step++
+	if int(rollout.Step) == len(steps) {
+		promoteCanary(rollout, now, fmt.Sprintf("passed %d analysis steps and was promoted", len(steps)))
+		*events = append(*events, canaryEvent{corev1.EventTypeNormal, EventReasonCanaryPromoted,
+			"Canary " + rollout.StableImage + " passed analysis and was promoted"})
+		return 0, nil
+	}
+	started := metav1.NewTime(now)
+	rollout.StepStartedAt = &started
+	next, _ := time.ParseDuration(strings.TrimSpace(steps[rollout.Step].Duration))
+	rollout.Message = fmt.Sprintf("step %d/%d: observing %s for %s", rollout.Step+1, len(steps), rollout.CanaryImage, next)
+	return next, nil
+}
+
+// evaluateCanaryStep queries the metrics a step has thresholds for and
+// returns the observed values, the thresholds the canary exceeded, and the
+// thresholds it could not judge because the canary track had no samples (no
+// traffic). A step with missing samples has not passed.
+func (r *MCPServerReconciler) evaluateCanaryStep(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, step mcpv1alpha1.CanaryAnalysisStep, window time.Duration) (*mcpv1alpha1.CanaryAnalysisResult, []string, []string, error) {
+	result := &mcpv1alpha1.CanaryAnalysisResult{Passed: true}
+	maxErrorRate := strings.TrimSpace(step.MaxErrorRate)
+	maxLatency := strings.TrimSpace(step.MaxP95Latency)
+	maxDenialDelta := strings.TrimSpace(step.MaxDenialRateDelta)
+	if maxErrorRate == "" && maxLatency == "" && maxDenialDelta == "" {
+		return result, nil, nil, nil
+	}
+	if r.Metrics == nil {
+		return nil, nil, nil, fmt.Errorf("no Prometheus configured for canary analysis (set MCP_PROMETHEUS_URL on the operator)")
+	}
+
+	queries := buildCanaryTrackQueries(mcpServer.Namespace, mcpServer.Name, window)
+	var failures, missing []string
+	if maxErrorRate != "" {
+		limit, _ := strconv.ParseFloat(maxErrorRate, 64)
+		value, ok, err := r.Metrics.Query(ctx, queries.errorRate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if ok {
+			result.ErrorRate = formatCanaryValue(value)
+			if value > limit {
+				failures = append(failures, fmt.Sprintf("error rate %s exceeds %s", result.ErrorRate, maxErrorRate))
+			}
+		} else {
+			missing = append(missing, "error rate")
+		}
+	}
+	if maxLatency != "" {
+		limit, _ := time.ParseDuration(maxLatency)
+		value, ok, err := r.Metrics.Query(ctx, queries.p95Latency)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if ok {
+			latency := time.Duration(value * float64(time.Second))
+			result.P95Latency = latency.Round(time.Millisecond).String()
+			if latency > limit {
+				failures = append(failures, fmt.Sprintf("p95 latency %s exceeds %s", result.P95Latency, maxLatency))
+			}
+		} else {
+			missing = append(missing, "p95 latency")
+		}
+	}
+	if maxDenialDelta != "" {
+		limit, _ := strconv.ParseFloat(maxDenialDelta, 64)
+		canary, canaryOK, err := r.Metrics.Query(ctx, queries.canaryDenialRate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		stable, stableOK, err := r.Metrics.Query(ctx, queries.stableDenialRate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if canaryOK {
+			if !stableOK {
+				stable = 0
+			}
+			delta := canary - stable
+			result.DenialRateDelta = formatCanaryValue(delta)
+			if delta > limit {
+				failures = append(failures, fmt.Sprintf("denial rate delta %s exceeds %s", result.DenialRateDelta, maxDenialDelta))
+			}
+		} else {
+			missing = append(missing, "denial rate")
+		}
+	}
+	result.Passed = len(failures) == 0 && len(missing) == 0
+	return result, failures, missing, nil
+}
+
+func formatCanaryValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
+
+// writeRolloutStatus stores rollout (nil clears it) with its CanaryAnalysis
+// condition, clears a handled rollout action, and then records events.
+func (r *MCPServerReconciler) writeRolloutStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, rollout *mcpv1alpha1.RolloutStatus, clearAction bool, events []canaryEvent) error {
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	status := latest.Status.DeepCopy()
+	status.Rollout = rollout
+	if rollout == nil {
+		meta.RemoveStatusCondition(&status.Conditions, string(operatorutil.CanaryAnalysis))
+	} else {
+		succeeded := rollout.Phase == mcpv1alpha1.RolloutPhaseStable || rollout.Phase == mcpv1alpha1.RolloutPhasePromoted
+		operatorutil.SetCondition(&status.Conditions, operatorutil.CanaryAnalysis, succeeded, string(rollout.Phase), rollout.Message, latest.Generation)
+	}
+	if !equality.Semantic.DeepEqual(&latest.Status, status) {
+		latest.Status = *status
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+	}
+
+	if clearAction {
+		if _, ok := latest.Annotations[mcpv1alpha1.RolloutActionAnnotation]; ok {
+			patch := client.MergeFrom(latest.DeepCopy())
+			delete(latest.Annotations, mcpv1alpha1.RolloutActionAnnotation)
+			if err := r.Patch(ctx, latest, patch); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, event := range events {
+		recordEvent(r.Recorder, mcpServer, event.eventType, event.reason, "CanaryAnalysis", "%s", event.note)
+	}
+	return nil
+}
+
+// canaryTrackQueries are the PromQL queries one analysis step runs, built from
+// the gateway request metrics labeled with rollout_track. Numerators fall back
+// to zero so a track without errors or denials yields 0 rather than no sample;
+// a track without traffic still yields no sample.
+type canaryTrackQueries struct {
+	errorRate        string
+	p95Latency       string
+	canaryDenialRate string
+	stableDenialRate string
+}
+
+func buildCanaryTrackQueries(namespace, server string, window time.Duration) canaryTrackQueries {
+	selector := func(track string, extra string) string {
+		labels := fmt.Sprintf(`namespace=%q,server=%q,rollout_track=%q`, namespace, server, track)
+		if extra != "" {
+			labels += "," + extra
+		}
+		return "{" + labels + "}"
+	}
+	rangeSelector := fmt.Sprintf("[%ds]", int64(window.Round(time.Second)/time.Second))
+	denialRate := func(track string) string {
+		return fmt.Sprintf("(sum(rate(mcp_gateway_policy_decisions_total%s%s)) or vector(0)) / sum(rate(mcp_gateway_policy_decisions_total%s%s))",
+			selector(track, `decision="deny"`), rangeSelector, selector(track, ""), rangeSelector)
+	}
+	return canaryTrackQueries{
+		errorRate: fmt.Sprintf("(sum(rate(mcp_gateway_requests_total%s%s)) or vector(0)) / sum(rate(mcp_gateway_requests_total%s%s))",
+			selector("canary", `status=~"5.."`), rangeSelector, selector("canary", ""), rangeSelector),
+		p95Latency: fmt.Sprintf("histogram_quantile(0.95, sum by (le) (rate(mcp_gateway_request_duration_seconds_bucket%s%s)))",
+			selector("canary", ""), rangeSelector),
+		canaryDenialRate: denialRate("canary"),
+		stableDenialRate: denialRate("stable"),
+	}
+}
@@ -0,0 +1,276 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+// staticCanaryMetrics answers every query whose text contains a key with
+// that key's value.
+type staticCanaryMetrics map[string]float64
+
+func (m staticCanaryMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	for fragment, value := range m {
+		if strings.Contains(query, fragment) {
+			return value, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func canaryAnalysisServer(step mcpv1alpha1.CanaryAnalysisStep) *mcpv1alpha1.MCPServer {
+	replicas, canaryReplicas := int32(3), int32(1)
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:    "example.com/payments",
+			ImageTag: "v2",
+			Replicas: &replicas,
+			Rollout: &mcpv1alpha1.RolloutConfig{
+				Strategy:       mcpv1alpha1.RolloutStrategyCanary,
+				CanaryReplicas: &canaryReplicas,
+				Analysis:       &mcpv1alpha1.CanaryAnalysis{Steps: []mcpv1alpha1.CanaryAnalysisStep{step}},
+			},
+		},
+		Status: mcpv1alpha1.MCPServerStatus{
+			Rollout: &mcpv1alpha1.RolloutStatus{Phase: mcpv1alpha1.RolloutPhaseStable, StableImage: "example.com/payments:v1"},
+		},
+	}
+}
+
+func servingCanaryDeployment(image string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-canary", Namespace: "servers"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "payments", Image: image}},
+			}},
+		},
+		Status: appsv1.DeploymentStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+}
+
+func newCanaryTestReconciler(scheme *runtime.Scheme, metrics MetricsSource, objects ...client.Object) (*MCPServerReconciler, *events.FakeRecorder, *time.Time) {
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := &MCPServerReconciler{Client: c, Scheme: scheme, Recorder: recorder, Metrics: metrics}
+	r.now = func() time.Time { return now }
+	return r, recorder, &now
+}
+
+func newCanaryScheme() *runtime.Scheme {
+	scheme := newAccessStatusScheme()
+	_ = appsv1.AddToScheme(scheme)
+	return scheme
+}
+
+func getRollout(t *testing.T, r *MCPServerReconciler) *mcpv1alpha1.MCPServer {
+	t.Helper()
+	got := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "payments", Namespace: "servers"}, got); err != nil {
+		t.Fatalf("get MCPServer: %v", err)
+	}
+	if got.Status.Rollout == nil {
+		t.Fatal("status.rollout is nil")
+	}
+	return got
+}
+
+func TestCanaryAnalysisPromotesAfterPassingSteps(t *testing.T) {
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "5m", MaxErrorRate: "0.05", MaxP95Latency: "500ms"})
+	metrics := staticCanaryMetrics{"mcp_gateway_requests_total": 0.01, "histogram_quantile": 0.2}
+	r, recorder, now := newCanaryTestReconciler(newCanaryScheme(), metrics, server, servingCanaryDeployment("example.com/payments:v2"))
+	ctx := context.Background()
+
+	if got := canaryTrackImage(server, "example.com/payments:v2"); got != "example.com/payments:v2" {
+		t.Fatalf("canary image = %q, want the desired image", got)
+	}
+	if got, err := r.stableTrackImage(ctx, server, "example.com/payments:v2"); err != nil || got != "example.com/payments:v1" {
+		t.Fatalf("stable image = %q, %v; want the promoted v1", got, err)
+	}
+
+	if _, err := r.reconcileCanaryAnalysis(ctx, server); err != nil {
+		t.Fatalf("start analysis: %v", err)
+	}
+	server = getRollout(t, r)
+	if server.Status.Rollout.Phase != mcpv1alpha1.RolloutPhaseProgressing || server.Status.Rollout.CanaryImage != "example.com/payments:v2" {
+		t.Fatalf("rollout = %#v, want Progressing for v2", server.Status.Rollout)
+	}
+
+	requeue, err := r.reconcileCanaryAnalysis(ctx, server)
+	if err != nil {
+		t.Fatalf("observe step: %v", err)
+	}
+	if requeue != 5*time.Minute {
+		t.Fatalf("requeue = %s, want the step duration", requeue)
+	}
+
+	*now = now.Add(5 * time.Minute)
+	server = getRollout(t, r)
+	if _, err := r.reconcileCanaryAnalysis(ctx, server); err != nil {
+		t.Fatalf("evaluate step: %v", err)
+	}
+	server = getRollout(t, r)
+	rollout := server.Status.Rollout
+	if rollout.Phase != mcpv1alpha1.RolloutPhasePromoted || rollout.StableImage != "example.com/payments:v2" {
+		t.Fatalf("rollout = %#v, want v2 promoted", rollout)
+	}
+	if rollout.LastAnalysis == nil || !rollout.LastAnalysis.Passed || rollout.LastAnalysis.ErrorRate != "0.0100" || rollout.LastAnalysis.P95Latency != "200ms" {
+		t.Fatalf("last analysis = %#v", rollout.LastAnalysis)
+	}
+	cond := meta.FindStatusCondition(server.Status.Conditions, string(operatorutil.CanaryAnalysis))
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != string(mcpv1alpha1.RolloutPhasePromoted) {
+		t.Fatalf("CanaryAnalysis condition = %#v, want Promoted", cond)
+	}
+	got := drainEvents(recorder)
+	if len(got) != 2 || !strings.HasPrefix(got[0], "Normal CanaryAnalysisStarted") || !strings.HasPrefix(got[1], "Normal CanaryPromoted") {
+		t.Fatalf("events = %q, want analysis started then promoted", got)
+	}
+}
+
+func TestCanaryAnalysisRollsBackOnFailedThreshold(t *testing.T) {
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "1m", MaxDenialRateDelta: "0.05"})
+	server.Status.Rollout = &mcpv1alpha1.RolloutStatus{
+		Phase:         mcpv1alpha1.RolloutPhaseProgressing,
+		StableImage:   "example.com/payments:v1",
+		CanaryImage:   "example.com/payments:v2",
+		StepStartedAt: &metav1.Time{Time: time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)},
+	}
+	metrics := staticCanaryMetrics{`rollout_track="canary"`: 0.30, `rollout_track="stable"`: 0.10}
+	r, recorder, _ := newCanaryTestReconciler(newCanaryScheme(), metrics, server, servingCanaryDeployment("example.com/payments:v2"))
+
+	if _, err := r.reconcileCanaryAnalysis(context.Background(), server); err != nil {
+		t.Fatalf("reconcileCanaryAnalysis() error = %v", err)
+	}
+	server = getRollout(t, r)
+	rollout := server.Status.Rollout
+	if rollout.Phase != mcpv1alpha1.RolloutPhaseRolledBack || rollout.StableImage != "example.com/payments:v1" {
+		t.Fatalf("rollout = %#v, want RolledBack keeping v1", rollout)
+	}
+	if !strings.Contains(rollout.Message, "denial rate delta 0.2000 exceeds 0.05") {
+		t.Fatalf("message = %q", rollout.Message)
+	}
+	if got := canaryTrackImage(server, "example.com/payments:v2"); got != "example.com/payments:v1" {
+		t.Fatalf("canary image after rollback = %q, want the stable image", got)
+	}
+	cond := meta.FindStatusCondition(server.Status.Conditions, string(operatorutil.CanaryAnalysis))
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != string(mcpv1alpha1.RolloutPhaseRolledBack) {
+		t.Fatalf("CanaryAnalysis condition = %#v, want RolledBack", cond)
+	}
+	if got := drainEvents(recorder); len(got) != 1 || !strings.HasPrefix(got[0], "Warning CanaryRolledBack") {
+		t.Fatalf("events = %q, want a rollback warning", got)
+	}
+}
+
+func TestCanaryAnalysisWithoutSamplesRollsBackAtDeadline(t *testing.T) {
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "5m", MaxErrorRate: "0.05"})
+	started := time.Date(2026, 1, 1, 11, 55, 0, 0, time.UTC)
+	server.Status.Rollout = &mcpv1alpha1.RolloutStatus{
+		Phase:         mcpv1alpha1.RolloutPhaseProgressing,
+		StableImage:   "example.com/payments:v1",
+		CanaryImage:   "example.com/payments:v2",
+		StepStartedAt: &metav1.Time{Time: started},
+	}
+	r, recorder, now := newCanaryTestReconciler(newCanaryScheme(), staticCanaryMetrics{}, server, servingCanaryDeployment("example.com/payments:v2"))
+	ctx := context.Background()
+
+	requeue, err := r.reconcileCanaryAnalysis(ctx, server)
+	if err != nil {
+		t.Fatalf("reconcileCanaryAnalysis() error = %v", err)
+	}
+	rollout := getRollout(t, r).Status.Rollout
+	if rollout.Phase != mcpv1alpha1.RolloutPhaseProgressing || !strings.Contains(rollout.Message, "inconclusive") || requeue != canaryMetricsRetry {
+		t.Fatalf("rollout = %#v (requeue %s), want the step held open as inconclusive", rollout, requeue)
+	}
+	if rollout.LastAnalysis == nil || rollout.LastAnalysis.Passed {
+		t.Fatalf("last analysis = %#v, want a step that has not passed", rollout.LastAnalysis)
+	}
+
+	*now = started.Add(canaryInconclusiveFactor * 5 * time.Minute)
+	if _, err := r.reconcileCanaryAnalysis(ctx, getRollout(t, r)); err != nil {
+		t.Fatalf("reconcileCanaryAnalysis() at deadline error = %v", err)
+	}
+	rollout = getRollout(t, r).Status.Rollout
+	if rollout.Phase != mcpv1alpha1.RolloutPhaseRolledBack || rollout.StableImage != "example.com/payments:v1" || !strings.Contains(rollout.Message, "no canary samples for error rate") {
+		t.Fatalf("rollout = %#v, want RolledBack for missing samples", rollout)
+	}
+	if got := drainEvents(recorder); len(got) != 1 || !strings.HasPrefix(got[0], "Warning CanaryRolledBack") {
+		t.Fatalf("events = %q, want a rollback warning", got)
+	}
+}
+
+func TestCanaryAnalysisWaitsForServingCanary(t *testing.T) {
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "1m"})
+	server.Status.Rollout = &mcpv1alpha1.RolloutStatus{
+		Phase:       mcpv1alpha1.RolloutPhaseProgressing,
+		StableImage: "example.com/payments:v1",
+		CanaryImage: "example.com/payments:v2",
+	}
+	r, _, _ := newCanaryTestReconciler(newCanaryScheme(), nil, server, servingCanaryDeployment("example.com/payments:v1"))
+
+	requeue, err := r.reconcileCanaryAnalysis(context.Background(), server)
+	if err != nil {
+		t.Fatalf("reconcileCanaryAnalysis() error = %v", err)
+	}
+	rollout := getRollout(t, r).Status.Rollout
+	if requeue != 0 || rollout.StepStartedAt != nil || !strings.HasPrefix(rollout.Message, "waiting for the canary") {
+		t.Fatalf("rollout = %#v (requeue %s), want waiting for the canary image", rollout, requeue)
+	}
+}
+
+func TestCanaryRolloutActionAbortClearsAnnotation(t *testing.T) {
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "1m"})
+	server.Annotations = map[string]string{mcpv1alpha1.RolloutActionAnnotation: mcpv1alpha1.RolloutActionAbort}
+	server.Status.Rollout = &mcpv1alpha1.RolloutStatus{
+		Phase:       mcpv1alpha1.RolloutPhaseProgressing,
+		StableImage: "example.com/payments:v1",
+		CanaryImage: "example.com/payments:v2",
+	}
+	r, recorder, _ := newCanaryTestReconciler(newCanaryScheme(), nil, server)
+
+	if _, err := r.reconcileCanaryAnalysis(context.Background(), server); err != nil {
+		t.Fatalf("reconcileCanaryAnalysis() error = %v", err)
+	}
+	got := getRollout(t, r)
+	if got.Status.Rollout.Phase != mcpv1alpha1.RolloutPhaseAborted {
+		t.Fatalf("phase = %q, want Aborted", got.Status.Rollout.Phase)
+	}
+	if _, ok := got.Annotations[mcpv1alpha1.RolloutActionAnnotation]; ok {
+		t.Fatalf("annotations = %v, want the rollout action removed", got.Annotations)
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.HasPrefix(events[0], "Normal CanaryAborted") {
+		t.Fatalf("events = %q, want CanaryAborted", events)
+	}
+}
+
+func TestBuildCanaryTrackQueries(t *testing.T) {
+	queries := buildCanaryTrackQueries("servers", "payments", 5*time.Minute)
+	want := `(sum(rate(mcp_gateway_requests_total{namespace="servers",server="payments",rollout_track="canary",status=~"5.."}[300s])) or vector(0)) / sum(rate(mcp_gateway_requests_total{namespace="servers",server="payments",rollout_track="canary"}[300s]))`
+	if queries.errorRate != want {
+		t.Fatalf("errorRate query = %s\nwant %s", queries.errorRate, want)
+	}
+	if !strings.Contains(queries.stableDenialRate, `rollout_track="stable",decision="deny"`) {
+		t.Fatalf("stable denial query = %s", queries.stableDenialRate)
+	}
+}
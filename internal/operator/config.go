@@ -4,6 +4,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
 )
 
 // OperatorConfig holds configuration for the operator loaded from environment variables.
@@ -23,6 +25,19 @@ type OperatorConfig struct {
 	// IngressReadinessMode controls how ingress readiness is evaluated.
 	IngressReadinessMode string
 
+	// RoutingMode is the default routing mode: "ingress" or "gateway-api".
+	RoutingMode string
+
+	// GatewayAPIParent is the default Gateway HTTPRoutes attach to in
+	// gateway-api mode. Nil when MCP_GATEWAY_API_PARENT_NAME is unset.
+	GatewayAPIParent *mcpv1alpha1.GatewayParentRef
+
+	// GatewayAPIProxyNamespace is where the parent Gateway's data plane runs.
+	GatewayAPIProxyNamespace string
+
+	// GatewayAPIProxyServiceAccount is the data plane's service account.
+	GatewayAPIProxyServiceAccount string
+
 	// ProvisionedRegistryURL is the URL of the provisioned registry.
 	ProvisionedRegistryURL string
 
@@ -48,6 +63,9 @@ type OperatorConfig struct {
 	// GatewayProxyImage is the default image used for the optional MCP gateway sidecar.
 	GatewayProxyImage string
 
+	// StdioBridgeImage is the image that provides the stdio bridge binary.
+	StdioBridgeImage string
+
 	// GatewayOTLPEndpoint is the OTLP/HTTP endpoint injected into MCP gateway sidecars.
 	GatewayOTLPEndpoint string
 
@@ -61,12 +79,17 @@ type OperatorConfig struct {
 // LoadOperatorConfig loads operator configuration from environment variables.
 func LoadOperatorConfig() *OperatorConfig {
 	ingressReadinessMode, _ := NormalizeIngressReadinessMode(os.Getenv("MCP_INGRESS_READINESS_MODE"))
+	routingMode, _ := NormalizeRoutingMode(os.Getenv("MCP_ROUTING_MODE"))
 	cfg := &OperatorConfig{
 		DefaultIngressHost:            getEnvCompat("MCP_DEFAULT_INGRESS_HOST", "DEFAULT_INGRESS_HOST"),
 		DefaultIngressClass:           getEnvOrDefault("DEFAULT_INGRESS_CLASS", DefaultIngressClass),
 		DefaultIngressEntryPoints:     strings.TrimSpace(os.Getenv("MCP_DEFAULT_INGRESS_ENTRYPOINTS")),
 		DefaultIngressTLS:             getEnvBool("MCP_DEFAULT_INGRESS_TLS"),
 		IngressReadinessMode:          ingressReadinessMode,
+		RoutingMode:                   routingMode,
+		GatewayAPIParent:              GatewayAPIParentFromEnv(os.Getenv),
+		GatewayAPIProxyNamespace:      strings.TrimSpace(os.Getenv("MCP_GATEWAY_API_PROXY_NAMESPACE")),
+		GatewayAPIProxyServiceAccount: strings.TrimSpace(os.Getenv("MCP_GATEWAY_API_PROXY_SERVICE_ACCOUNT")),
 		ProvisionedRegistryURL:        os.Getenv("PROVISIONED_REGISTRY_URL"),
 		ProvisionedRegistryUsername:   os.Getenv("PROVISIONED_REGISTRY_USERNAME"),
 		ProvisionedRegistryPassword:   os.Getenv("PROVISIONED_REGISTRY_PASSWORD"),
@@ -75,6 +98,7 @@ func LoadOperatorConfig() *OperatorConfig {
 		RegistryPullHost:              getEnvOrDefault("MCP_REGISTRY_PULL_HOST", getEnvOrDefault("MCP_REGISTRY_ENDPOINT", "registry.registry.svc.cluster.local:5000")),
 		RequeueDelaySeconds:           getEnvIntOrDefault("REQUEUE_DELAY_SECONDS", RequeueDelayNotReady),
 		GatewayProxyImage:             os.Getenv("MCP_GATEWAY_PROXY_IMAGE"),
+		StdioBridgeImage:              os.Getenv("MCP_STDIO_BRIDGE_IMAGE"),
 		GatewayOTLPEndpoint:           os.Getenv("MCP_GATEWAY_OTEL_EXPORTER_OTLP_ENDPOINT"),
 		AnalyticsIngestURL:            getEnvCompat("MCP_SENTINEL_INGEST_URL", "MCP_ANALYTICS_INGEST_URL"),
 		ClusterName:                   getEnvOrDefault("MCP_CLUSTER_NAME", "local"),
@@ -143,5 +167,33 @@ func NormalizeIngressReadinessMode(value string) (string, bool) {
 	}
 }
 
+// NormalizeRoutingMode returns a supported routing mode.
+// Empty or invalid values fall back to ingress mode.
+func NormalizeRoutingMode(value string) (string, bool) {
+	switch mode := mcpv1alpha1.RoutingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "", mcpv1alpha1.RoutingModeIngress:
+		return string(mcpv1alpha1.RoutingModeIngress), true
+	case mcpv1alpha1.RoutingModeGatewayAPI:
+		return string(mode), true
+	default:
+		return string(mcpv1alpha1.RoutingModeIngress), false
+	}
+}
+
+// GatewayAPIParentFromEnv returns the default parent Gateway from
+// MCP_GATEWAY_API_PARENT_NAME, _NAMESPACE, and _SECTION, or nil when no name
+// is configured.
+func GatewayAPIParentFromEnv(getenv func(string) string) *mcpv1alpha1.GatewayParentRef {
+	name := strings.TrimSpace(getenv("MCP_GATEWAY_API_PARENT_NAME"))
+	if name == "" {
+		return nil
+	}
+	return &mcpv1alpha1.GatewayParentRef{
+		Name:        name,
+		Namespace:   strings.TrimSpace(getenv("MCP_GATEWAY_API_PARENT_NAMESPACE")),
+		SectionName: strings.TrimSpace(getenv("MCP_GATEWAY_API_PARENT_SECTION")),
+	}
+}
+
 // DefaultOperatorConfig is the default configuration loaded at startup.
 var DefaultOperatorConfig = LoadOperatorConfig()
@@ -191,6 +191,10 @@ func TestLoadOperatorConfig(t *testing.T) {
 	t.Setenv("MCP_DEFAULT_INGRESS_ENTRYPOINTS", "websecure")
 	t.Setenv("MCP_DEFAULT_INGRESS_TLS", "true")
 	t.Setenv("MCP_INGRESS_READINESS_MODE", "permissive")
+	t.Setenv("MCP_ROUTING_MODE", "gateway-api")
+	t.Setenv("MCP_GATEWAY_API_PARENT_NAME", "public")
+	t.Setenv("MCP_GATEWAY_API_PARENT_NAMESPACE", "gateway-system")
+	t.Setenv("MCP_GATEWAY_API_PROXY_SERVICE_ACCOUNT", "envoy")
 	t.Setenv("PROVISIONED_REGISTRY_URL", "registry.example.com:5000")
 	t.Setenv("MCP_REGISTRY_ENDPOINT", "10.43.39.164:5000")
 	t.Setenv("MCP_REGISTRY_INGRESS_HOST", "registry.mcpruntime.org")
@@ -218,6 +222,15 @@ func TestLoadOperatorConfig(t *testing.T) {
 	if cfg.IngressReadinessMode != IngressReadinessModePermissive {
 		t.Fatalf("expected ingress readiness mode override, got %q", cfg.IngressReadinessMode)
 	}
+	if cfg.RoutingMode != "gateway-api" {
+		t.Fatalf("expected routing mode override, got %q", cfg.RoutingMode)
+	}
+	if cfg.GatewayAPIParent == nil || cfg.GatewayAPIParent.Name != "public" || cfg.GatewayAPIParent.Namespace != "gateway-system" {
+		t.Fatalf("expected gateway api parent override, got %#v", cfg.GatewayAPIParent)
+	}
+	if cfg.GatewayAPIProxyServiceAccount != "envoy" {
+		t.Fatalf("expected gateway api proxy service account override, got %q", cfg.GatewayAPIProxyServiceAccount)
+	}
 	if cfg.ProvisionedRegistryURL != "registry.example.com:5000" {
 		t.Fatalf("expected registry url override, got %q", cfg.ProvisionedRegistryURL)
 	}
@@ -291,3 +304,20 @@ func TestNormalizeIngressReadinessMode(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeRoutingMode(t *testing.T) {
+	for value, want := range map[string]struct {
+		mode  string
+		valid bool
+	}{
+		"":              {"ingress", true},
+		"ingress":       {"ingress", true},
+		" Gateway-API ": {"gateway-api", true},
+		"istio":         {"ingress", false},
+	} {
+		mode, valid := NormalizeRoutingMode(value)
+		if mode != want.mode || valid != want.valid {
+			t.Fatalf("NormalizeRoutingMode(%q) = %q, %v; want %q, %v", value, mode, valid, want.mode, want.valid)
+		}
+	}
+}
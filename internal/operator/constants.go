@@ -1,6 +1,8 @@
 // Package operator provides the Kubernetes operator for MCPServer resources.
 package operator
 
+import mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+
 // Resource defaults for MCPServer deployments.
 const (
 	// DefaultRequestCPU is the default CPU request for containers.
@@ -22,7 +24,7 @@ const (
 	// DefaultGatewayPort is the default container port for the MCP proxy sidecar.
 	DefaultGatewayPort = 8091
 	// DefaultGatewayMetricsPort is the default Prometheus scrape port for the MCP gateway sidecar.
-	DefaultGatewayMetricsPort = 9103
+	DefaultGatewayMetricsPort = mcpv1alpha1.GatewayMetricsPort
 	// DefaultServicePort is the default service port.
 	DefaultServicePort = 80
 )
@@ -35,6 +37,15 @@ const (
 	LabelManagedBy = "app.kubernetes.io/managed-by"
 	// LabelManagedByValue is the value for the managed-by label.
 	LabelManagedByValue = "mcp-runtime"
+	// LabelComponent marks the pods that call the operator's activation
+	// server, so its NetworkPolicy and caller checks can tell them apart.
+	LabelComponent = "mcpruntime.org/component"
+	// LabelComponentGateway is the LabelComponent value of MCPServer pods
+	// that run the gateway sidecar.
+	LabelComponentGateway = "gateway"
+	// LabelComponentActivator is the LabelComponent value of scale-to-zero
+	// activator pods.
+	LabelComponentActivator = "activator"
 )
 
 // Secret names.
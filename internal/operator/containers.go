@@ -0,0 +1,92 @@
+package operator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/kubeworkload"
+)
+
+// buildInitContainers returns the pod's init containers: the stdio bridge
+// installer for transport stdio, then spec.initContainers.
+func (r *MCPServerReconciler) buildInitContainers(mcpServer *mcpv1alpha1.MCPServer) ([]corev1.Container, error) {
+	var containers []corev1.Container
+	if stdioTransport(mcpServer) {
+		bridge, err := r.buildStdioBridgeInitContainer(mcpServer)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, bridge)
+	}
+	initContainers, err := r.buildAdditionalContainers(mcpServer.Spec.InitContainers)
+	if err != nil {
+		return nil, err
+	}
+	return append(containers, initContainers...), nil
+}
+
+// buildAdditionalContainers converts spec.initContainers and
+// spec.extraContainers into pod containers with the server container's
+// security context and resource defaults.
+func (r *MCPServerReconciler) buildAdditionalContainers(specs []mcpv1alpha1.Container) ([]corev1.Container, error) {
+	var containers []corev1.Container
+	for _, spec := range specs {
+		container := corev1.Container{
+			Name:            spec.Name,
+			Image:           spec.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         append([]string(nil), spec.Command...),
+			Args:            append([]string(nil), spec.Args...),
+			Env:             r.buildEnvVars(spec.EnvVars, spec.SecretEnvVars),
+			SecurityContext: kubeworkload.RestrictedContainerSecurityContext(),
+		}
+		for _, port := range spec.Ports {
+			container.Ports = append(container.Ports, corev1.ContainerPort{
+				Name:          port.Name,
+				ContainerPort: port.ContainerPort,
+				Protocol:      containerPortProtocol(port.Protocol),
+			})
+		}
+		for _, mount := range spec.VolumeMounts {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      mount.Name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+		if err := applyContainerResources(&container, spec.Resources); err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// exposedContainerPorts returns Service ports for the extra container ports
+// that opt in with expose.
+func exposedContainerPorts(mcpServer *mcpv1alpha1.MCPServer) []corev1.ServicePort {
+	var ports []corev1.ServicePort
+	for _, container := range mcpServer.Spec.ExtraContainers {
+		for _, port := range container.Ports {
+			if !port.Expose {
+				continue
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:       port.Name,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromString(port.Name),
+				Protocol:   containerPortProtocol(port.Protocol),
+			})
+		}
+	}
+	return ports
+}
+
+func containerPortProtocol(protocol string) corev1.Protocol {
+	if protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return corev1.Protocol(protocol)
+}
@@ -0,0 +1,58 @@
+package operator
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestBuildDeploymentContainersAddsExtraContainers(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:   "example.com/search",
+			Port:    8088,
+			Gateway: &mcpv1alpha1.GatewayConfig{Enabled: true, Image: "example.com/gateway:v1", Port: 8091},
+			ExtraContainers: []mcpv1alpha1.Container{
+				{
+					Name:    "cloud-sql-proxy",
+					Image:   "example.com/cloud-sql-proxy",
+					Args:    []string{"--port=5432"},
+					EnvVars: []mcpv1alpha1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+					Ports: []mcpv1alpha1.ContainerPort{
+						{Name: "sql", ContainerPort: 5432},
+						{Name: "proxy-metrics", ContainerPort: 9801, Expose: true},
+					},
+				},
+			},
+		},
+	}
+	r := &MCPServerReconciler{}
+
+	containers, _, err := r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	if len(containers) != 3 || containers[0].Name != "search" || containers[1].Name != "cloud-sql-proxy" || containers[2].Name != mcpv1alpha1.GatewayContainerName {
+		t.Fatalf("containers = %#v, want server, extra container, gateway", containers)
+	}
+	extra := containers[1]
+	if extra.SecurityContext == nil || extra.SecurityContext.RunAsNonRoot == nil || !*extra.SecurityContext.RunAsNonRoot {
+		t.Fatalf("extra container security context = %#v, want restricted", extra.SecurityContext)
+	}
+	if len(extra.Env) != 1 || extra.Env[0].Name != "LOG_LEVEL" || len(extra.Args) != 1 {
+		t.Fatalf("extra container env/args = %#v / %#v", extra.Env, extra.Args)
+	}
+	if len(extra.Ports) != 2 || extra.Ports[0].Protocol != corev1.ProtocolTCP {
+		t.Fatalf("extra container ports = %#v", extra.Ports)
+	}
+
+	ports := exposedContainerPorts(server)
+	if len(ports) != 1 || ports[0].Name != "proxy-metrics" || ports[0].Port != 9801 || ports[0].TargetPort != intstr.FromString("proxy-metrics") {
+		t.Fatalf("exposed ports = %#v, want only the opted-in port", ports)
+	}
+}
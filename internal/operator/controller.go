@@ -8,11 +8,14 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -60,6 +63,23 @@ type MCPServerReconciler struct {
 	// IngressReadinessMode controls how ingress readiness is evaluated.
 	IngressReadinessMode string
 
+	// RoutingMode is the default routing mode for servers without
+	// spec.routing.mode: "ingress" (the default) or "gateway-api".
+	RoutingMode string
+
+	// GatewayAPIParent is the default Gateway that HTTPRoutes attach to in
+	// gateway-api mode.
+	GatewayAPIParent *mcpv1alpha1.GatewayParentRef
+
+	// GatewayAPIProxyNamespace is where the parent Gateway's data plane runs.
+	// The mtls NetworkPolicy admits the gateway port from it. Empty means the
+	// Gateway's namespace.
+	GatewayAPIProxyNamespace string
+
+	// GatewayAPIProxyServiceAccount is the data plane's service account. When
+	// set, mtls gateways pin its SPIFFE identity as the trusted proxy.
+	GatewayAPIProxyServiceAccount string
+
 	// ProvisionedRegistry holds the provisioned registry configuration.
 	// If nil or URL is empty, provisioned registry features are disabled.
 	ProvisionedRegistry *RegistryConfig
@@ -67,6 +87,10 @@ type MCPServerReconciler struct {
 	// GatewayProxyImage is the default image used for the optional MCP gateway sidecar.
 	GatewayProxyImage string
 
+	// StdioBridgeImage is the image that provides the stdio bridge binary for
+	// servers with transport stdio.
+	StdioBridgeImage string
+
 	// GatewayOTLPEndpoint is the OTLP/HTTP endpoint injected into MCP gateway sidecars.
 	GatewayOTLPEndpoint string
 
@@ -79,6 +103,50 @@ type MCPServerReconciler struct {
 	// MTLSClusterIssuer is the pre-existing cert-manager ClusterIssuer used for
 	// gateway and adapter workload certificates.
 	MTLSClusterIssuer string
+
+	// Recorder emits Kubernetes Events for notable transitions such as policy
+	// revision changes, canary rollout, and certificate issuance. Nil disables
+	// events.
+	Recorder events.EventRecorder
+
+	// Metrics answers the PromQL queries of automated canary analysis and
+	// scale-to-zero idle detection. Nil means analysis steps with metric
+	// thresholds wait for a manual promote or abort, and servers are never
+	// scaled to zero.
+	Metrics MetricsSource
+
+	// ActivationURL is the operator endpoint scale-to-zero activators call to
+	// request a scale-up. Empty disables scale to zero.
+	ActivationURL string
+
+	// ApprovalURL is the operator endpoint gateways call to request and poll
+	// tool call approvals. Empty makes held calls fail with
+	// approval_unavailable.
+	ApprovalURL string
+
+	// SessionActivityURL is the operator endpoint gateways share agent
+	// session activity through, so session.idleTimeout holds across gateway
+	// replicas. Empty leaves each gateway replica tracking activity on its
+	// own.
+	SessionActivityURL string
+
+	// RateLimitURL is the operator endpoint gateways share MCPAccessGrant
+	// rate limit counters through, so the limits hold across gateway
+	// replicas. Empty leaves each gateway replica counting on its own.
+	RateLimitURL string
+
+	// Registry resolves image digests and fetches image signatures for
+	// spec.imageVerification. Nil means the OCI distribution API over HTTPS.
+	Registry ImageRegistry
+
+	// ImageVerificationKeys is the Secret whose values are the PEM public
+	// keys trusted for spec.imageVerification.requireSignature. It lives
+	// with the operator rather than in server namespaces, which the teams
+	// the requirement constrains can write. Empty rejects every signature.
+	ImageVerificationKeys types.NamespacedName
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
 }
 
 // Use constants from constants.go
@@ -91,8 +159,8 @@ const (
 )
 
 const (
-	gatewayPolicyVolumeName       = "gateway-policy"
-	gatewayPolicyMountDir         = "/var/run/mcp-runtime/policy"
+	gatewayPolicyVolumeName       = mcpv1alpha1.GatewayPolicyVolumeName
+	gatewayPolicyMountDir         = mcpv1alpha1.GatewayMountRoot + "/policy"
 	gatewayPolicyFileName         = "policy.json"
 	gatewayPolicyFilePath         = gatewayPolicyMountDir + "/" + gatewayPolicyFileName
 	restrictedRunAsUser           = kubeworkload.RestrictedRunAsUser
@@ -107,21 +175,42 @@ type resourceReadiness = operatorutil.ResourceReadiness
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch;update
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpaccessgrants,verbs=get;list;watch
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpagentsessions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpserverclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcppolicybaselines,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;backendtlspolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=traefik.io,resources=ingressroutetcps;ingressroutes;middlewares;tlsoptions;tlsstores;serverstransports,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	result, err := r.reconcile(ctx, req)
+	outcome := "success"
+	switch {
+	case err != nil:
+		outcome = "error"
+	case result.RequeueAfter > 0:
+		outcome = "requeue"
+	}
+	reconcileDurationSeconds.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+func (r *MCPServerReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	mcpServer, found, err := r.fetchMCPServer(ctx, req)
@@ -129,15 +218,23 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 	if !found {
+		forgetServerMetrics(req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Reconciling MCPServer", "name", mcpServer.Name, "namespace", mcpServer.Namespace)
 
-	mcpServer = r.defaultedMCPServerForReconcile(mcpServer)
+	class, err := r.resolveServerClass(ctx, mcpServer, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	mcpServer = r.defaultedMCPServerForReconcile(mcpServer, class)
 	if err := r.validateMCPServerSpec(ctx, mcpServer, logger); err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.validateServerClass(ctx, mcpServer, class, logger); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	if err := r.validateIngressConfig(ctx, mcpServer, logger); err != nil {
 		return ctrl.Result{}, err
@@ -145,6 +242,16 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if err := r.validateGatewayConfig(ctx, mcpServer, logger); err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.validateStdioBridgeConfig(ctx, mcpServer, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	scaleRequeue, err := r.reconcileScaleToZero(ctx, mcpServer)
+	if err != nil {
+		reconcileErrorsTotal.WithLabelValues("scale-to-zero").Inc()
+		logOperatorError(logger, err, "Failed to reconcile scale to zero")
+		return ctrl.Result{}, err
+	}
 
 	if err := r.reconcileResources(ctx, mcpServer, logger); err != nil {
 		return ctrl.Result{}, err
@@ -152,6 +259,14 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	readiness, err := r.checkResourceReadiness(ctx, mcpServer)
 	if err != nil {
+		reconcileErrorsTotal.WithLabelValues("readiness").Inc()
+		return ctrl.Result{}, err
+	}
+
+	analysisRequeue, err := r.reconcileCanaryAnalysis(ctx, mcpServer)
+	if err != nil {
+		reconcileErrorsTotal.WithLabelValues("canary-analysis").Inc()
+		logOperatorError(logger, err, "Failed to reconcile canary analysis")
 		return ctrl.Result{}, err
 	}
 
@@ -164,7 +279,18 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if !allReady {
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: soonestRequeue(analysisRequeue, scaleRequeue)}, nil
+}
+
+// soonestRequeue returns the shortest positive delay, or zero if none is set.
+func soonestRequeue(delays ...time.Duration) time.Duration {
+	var soonest time.Duration
+	for _, delay := range delays {
+		if delay > 0 && (soonest == 0 || delay < soonest) {
+			soonest = delay
+		}
+	}
+	return soonest
 }
 
 func (r *MCPServerReconciler) fetchMCPServer(ctx context.Context, req ctrl.Request) (*mcpv1alpha1.MCPServer, bool, error) {
@@ -180,7 +306,7 @@ func (r *MCPServerReconciler) fetchMCPServer(ctx context.Context, req ctrl.Reque
 
 func (r *MCPServerReconciler) validateMCPServerSpec(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
 	if _, err := mcpServer.ValidateCreate(); err != nil {
-		r.updateStatus(ctx, mcpServer, "Error", err.Error(), resourceReadiness{})
+		r.rejectSpec(ctx, mcpServer, err)
 		logOperatorError(logger, err, "Invalid MCPServer specification")
 		return err
 	}
@@ -204,7 +330,7 @@ func (r *MCPServerReconciler) validateIngressConfig(ctx context.Context, mcpServ
 func (r *MCPServerReconciler) validateGatewayConfig(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
 	if gatewayEnabled(mcpServer) {
 		if _, err := r.resolveGatewayImage(mcpServer); err != nil {
-			r.updateStatus(ctx, mcpServer, "Error", err.Error(), resourceReadiness{})
+			r.rejectSpec(ctx, mcpServer, err)
 			logOperatorError(logger, err, "Missing gateway image")
 			return err
 		}
@@ -239,78 +365,61 @@ func (r *MCPServerReconciler) requireSpecField(ctx context.Context, mcpServer *m
 		"field":     field,
 	}
 	err := newOperatorError(message, contextMap)
-	r.updateStatus(ctx, mcpServer, "Error", err.Error(), resourceReadiness{})
+	r.rejectSpec(ctx, mcpServer, err)
 	logOperatorError(logger, err, "Missing "+field)
 	return err
 }
 
-func (r *MCPServerReconciler) reconcileResources(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
-	contextMap := map[string]any{
-		"mcpServer": mcpServer.Name,
-		"namespace": mcpServer.Namespace,
-	}
+// rejectSpec records a spec the operator cannot act on: the Error status, a
+// Warning event, and a validation failure in the reconcile error metric.
+func (r *MCPServerReconciler) rejectSpec(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, err error) {
+	reconcileErrorsTotal.WithLabelValues("validation").Inc()
+	recordEvent(r.Recorder, mcpServer, corev1.EventTypeWarning, EventReasonInvalidSpec, "Validate", "%v", err)
+	r.updateStatus(ctx, mcpServer, "Error", err.Error(), resourceReadiness{})
+}
 
-	if err := r.reconcilePolicyConfigMap(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "configmap"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile policy ConfigMap", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile policy ConfigMap")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile policy ConfigMap: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileGatewayCertificate(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "certificate"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile gateway Certificate", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile gateway Certificate")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile gateway Certificate: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileTraefikClientCertificate(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "traefik-client-certificate"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile Traefik client Certificate", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile Traefik client Certificate")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Traefik client Certificate: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileDeployment(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "deployment"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile Deployment", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile Deployment")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Deployment: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileCanaryDeployment(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "canary-deployment"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile canary Deployment", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile canary Deployment")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile canary Deployment: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileService(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "service"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile Service", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile Service")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Service: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileIngress(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "ingress"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile Ingress", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile Ingress")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Ingress: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileMTLSNetworkPolicy(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "networkpolicy"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile mTLS NetworkPolicy", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile mTLS NetworkPolicy")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile mTLS NetworkPolicy: %v", err), resourceReadiness{})
-		return wrappedErr
-	}
-	if err := r.reconcileMTLSTrustBundle(ctx, mcpServer); err != nil {
-		contextMap["resource"] = "trust-bundle"
-		wrappedErr := wrapOperatorError(err, "Failed to reconcile mTLS trust bundle", contextMap)
-		logOperatorError(logger, wrappedErr, "Failed to reconcile mTLS trust bundle")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile mTLS trust bundle: %v", err), resourceReadiness{})
+func (r *MCPServerReconciler) reconcileResources(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	steps := []struct {
+		resource    string
+		description string
+		reconcile   func(context.Context, *mcpv1alpha1.MCPServer) error
+	}{
+		{"configmap", "policy ConfigMap", r.reconcilePolicyConfigMap},
+		{"certificate", "gateway Certificate", r.reconcileGatewayCertificate},
+		{"traefik-client-certificate", "Traefik client Certificate", r.reconcileTraefikClientCertificate},
+		{"persistentvolumeclaim", "PersistentVolumeClaims", r.reconcilePersistentVolumeClaims},
+		// The image gate sits right before the workloads it protects, so a
+		// registry outage or an unsigned image holds back rollouts without
+		// holding back policy updates such as grant and session revocations.
+		{"image", "image digest and signature", r.reconcileImageVerification},
+		{"deployment", "Deployment", r.reconcileDeployment},
+		{"canary-deployment", "canary Deployment", r.reconcileCanaryDeployment},
+		{"service", "Service", r.reconcileService},
+		{"hpa", "HorizontalPodAutoscaler", r.reconcileHorizontalPodAutoscaler},
+		{"pdb", "PodDisruptionBudget", r.reconcilePodDisruptionBudget},
+		{"activator", "scale-to-zero activator", r.reconcileActivator},
+		{"ingress", "Ingress", r.reconcileIngress},
+		{"networkpolicy", "mTLS NetworkPolicy", r.reconcileMTLSNetworkPolicy},
+		{"egress-networkpolicy", "egress NetworkPolicy", r.reconcileEgressNetworkPolicy},
+		{"trust-bundle", "mTLS trust bundle", r.reconcileMTLSTrustBundle},
+	}
+
+	for _, step := range steps {
+		err := step.reconcile(ctx, mcpServer)
+		if err == nil {
+			continue
+		}
+		contextMap := map[string]any{
+			"mcpServer": mcpServer.Name,
+			"namespace": mcpServer.Namespace,
+			"resource":  step.resource,
+		}
+		message := "Failed to reconcile " + step.description
+		wrappedErr := wrapOperatorError(err, message, contextMap)
+		logOperatorError(logger, wrappedErr, message)
+		reconcileErrorsTotal.WithLabelValues(step.resource).Inc()
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeWarning, EventReasonReconcileFailed, "Reconcile", "%s: %v", message, err)
+		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("%s: %v", message, err), resourceReadiness{})
 		return wrappedErr
 	}
 	return nil
@@ -357,6 +466,9 @@ func (r *MCPServerReconciler) checkResourceReadiness(ctx context.Context, mcpSer
 }
 
 func determinePhase(readiness resourceReadiness, mcpServer *mcpv1alpha1.MCPServer) (string, bool) {
+	if scaledToZero(mcpServer) && readiness.Service && readiness.Ingress {
+		return "ScaledToZero", true
+	}
 	allReady := readiness.Deployment && readiness.Service && readiness.Ingress
 	if gatewayEnabled(mcpServer) {
 		allReady = allReady && readiness.Gateway && readiness.Policy
@@ -373,8 +485,11 @@ func determinePhase(readiness resourceReadiness, mcpServer *mcpv1alpha1.MCPServe
 	return "Pending", false
 }
 
-func (r *MCPServerReconciler) defaultedMCPServerForReconcile(mcpServer *mcpv1alpha1.MCPServer) *mcpv1alpha1.MCPServer {
+// defaultedMCPServerForReconcile returns a copy of the server with the class
+// defaults (class may be nil) and then the built-in defaults applied.
+func (r *MCPServerReconciler) defaultedMCPServerForReconcile(mcpServer *mcpv1alpha1.MCPServer, class *mcpv1alpha1.MCPServerClass) *mcpv1alpha1.MCPServer {
 	defaulted := mcpServer.DeepCopy()
+	class.ApplyDefaults(defaulted)
 	defaulted.DefaultWithOptions(mcpv1alpha1.MCPServerDefaultOptions{
 		DefaultIngressHost:        r.DefaultIngressHost,
 		DefaultAnalyticsIngestURL: r.DefaultAnalyticsIngestURL,
@@ -388,9 +503,15 @@ func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(&mcpv1alpha1.MCPAccessGrant{}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedServer)).
 		Watches(&mcpv1alpha1.MCPAgentSession{}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedServer)).
+		Watches(&mcpv1alpha1.MCPServerClass{}, handler.EnqueueRequestsFromMapFunc(r.requestsForServerClass)).
+		Watches(&mcpv1alpha1.MCPPolicyBaseline{}, handler.EnqueueRequestsFromMapFunc(r.requestsForPolicyBaseline)).
 		Complete(r)
 }
 
@@ -2,13 +2,17 @@ package operator
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -206,7 +210,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			},
 		}
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertReplicas(t, mcpServer.Spec.Replicas, 1)
 		assertEqual(t, "port", mcpServer.Spec.Port, int32(8088))
@@ -225,7 +229,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			Scheme:             runtime.NewScheme(),
 			DefaultIngressHost: "example.com",
 		}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 		assertEqual(t, "publicPathPrefix", mcpServer.Spec.PublicPathPrefix, "test-server")
 		assertEqual(t, "ingressHost", mcpServer.Spec.IngressHost, "example.com")
 	})
@@ -241,7 +245,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			Scheme:             runtime.NewScheme(),
 			DefaultIngressHost: "example.com",
 		}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 		assertEqual(t, "publicPathPrefix", mcpServer.Spec.PublicPathPrefix, "custom-prefix")
 		assertEqual(t, "ingressHost", mcpServer.Spec.IngressHost, "")
 	})
@@ -259,7 +263,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			},
 		}
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertReplicas(t, mcpServer.Spec.Replicas, 5)
 		assertEqual(t, "port", mcpServer.Spec.Port, int32(9000))
@@ -277,7 +281,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			},
 		}
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertEqual(t, "imageTag", mcpServer.Spec.ImageTag, "")
 	})
@@ -290,7 +294,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			},
 		}
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertEqual(t, "imageTag", mcpServer.Spec.ImageTag, "latest")
 	})
@@ -303,7 +307,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			},
 		}
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertEqual(t, "imageTag", mcpServer.Spec.ImageTag, "")
 	})
@@ -311,7 +315,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 	t.Run("skips ingressPath if name is empty", func(t *testing.T) {
 		mcpServer := mcpv1alpha1.MCPServer{} // No name set
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		assertEqual(t, "ingressPath", mcpServer.Spec.IngressPath, "")
 	})
@@ -331,7 +335,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 		}
 
 		r := MCPServerReconciler{Scheme: runtime.NewScheme()}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		if mcpServer.Spec.Gateway == nil {
 			t.Fatal("expected gateway defaults to be applied")
@@ -361,7 +365,7 @@ func TestDefaultedMCPServerForReconcile(t *testing.T) {
 			Scheme:                    runtime.NewScheme(),
 			DefaultAnalyticsIngestURL: "http://mcp-sentinel-ingest.mcp-sentinel.svc.cluster.local:8081/events",
 		}
-		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer)
+		mcpServer = *r.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 		if mcpServer.Spec.Analytics == nil {
 			t.Fatal("expected analytics defaults to be applied")
@@ -498,7 +502,7 @@ func TestReconcileDeploymentAddsGatewaySidecar(t *testing.T) {
 		Scheme:              scheme,
 		GatewayOTLPEndpoint: "http://otel-collector.mcp-sentinel.svc.cluster.local:4318",
 	}
-	mcpServer = *reconciler.defaultedMCPServerForReconcile(&mcpServer)
+	mcpServer = *reconciler.defaultedMCPServerForReconcile(&mcpServer, nil)
 
 	if err := reconciler.reconcileDeployment(context.Background(), &mcpServer); err != nil {
 		t.Fatalf("reconcileDeployment() error = %v", err)
@@ -838,6 +842,8 @@ func TestReconcileResources(t *testing.T) {
 	_ = appsv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
+	_ = autoscalingv2.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
 
 	t.Run("succeeds with valid resources", func(t *testing.T) {
 		mcpServer := &mcpv1alpha1.MCPServer{
@@ -855,6 +861,44 @@ func TestReconcileResources(t *testing.T) {
 			t.Fatalf("failed to reconcile resources: %v", err)
 		}
 	})
+
+	t.Run("keeps the policy current when image verification fails", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:       "registry.example.com/team/tools",
+				ImageTag:    "v1",
+				IngressHost: "example.com",
+				IngressPath: "/test",
+				Gateway:     &mcpv1alpha1.GatewayConfig{Enabled: true},
+				Tools:       []mcpv1alpha1.ToolConfig{{Name: "delete_issue", SideEffect: mcpv1alpha1.ToolSideEffectDestructive}},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, Registry: unreachableRegistry{}}
+		err := r.reconcileResources(context.Background(), mcpServer, logr.Discard())
+		if err == nil || !strings.Contains(err.Error(), "image digest and signature") {
+			t.Fatalf("reconcileResources() error = %v, want the image gate to fail", err)
+		}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: gatewayPolicyConfigMapName(mcpServer.Name), Namespace: "default"}, &corev1.ConfigMap{}); err != nil {
+			t.Fatalf("policy ConfigMap not reconciled before the image gate: %v", err)
+		}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: "test-server", Namespace: "default"}, &appsv1.Deployment{}); err == nil {
+			t.Fatal("Deployment rolled out although its image could not be pinned")
+		}
+	})
+}
+
+// unreachableRegistry fails every lookup, like a registry the operator cannot
+// reach.
+type unreachableRegistry struct{}
+
+func (unreachableRegistry) Digest(context.Context, string, RegistryAuth) (string, error) {
+	return "", errors.New("registry unreachable")
+}
+
+func (unreachableRegistry) Signatures(context.Context, string, string, RegistryAuth) ([]ImageSignature, error) {
+	return nil, errors.New("registry unreachable")
 }
 
 func TestCheckResourceReadiness(t *testing.T) {
@@ -863,6 +907,8 @@ func TestCheckResourceReadiness(t *testing.T) {
 	_ = appsv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
+	_ = autoscalingv2.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
 
 	t.Run("returns false when resources do not exist", func(t *testing.T) {
 		mcpServer := &mcpv1alpha1.MCPServer{
@@ -899,7 +945,7 @@ func TestDefaultedMCPServerForReconcileDoesNotPersistDefaults(t *testing.T) {
 		DefaultAnalyticsIngestURL: "http://mcp-sentinel-ingest.mcp-sentinel.svc.cluster.local:8081/events",
 	}
 
-	defaulted := r.defaultedMCPServerForReconcile(mcpServer)
+	defaulted := r.defaultedMCPServerForReconcile(mcpServer, nil)
 	assertEqual(t, "defaultedPort", defaulted.Spec.Port, int32(8088))
 	assertReplicas(t, defaulted.Spec.Replicas, 1)
 	assertEqual(t, "defaultedIngressHost", defaulted.Spec.IngressHost, "example.com")
@@ -935,7 +981,7 @@ func TestValidateMCPServerSpecRunsAfterDefaulting(t *testing.T) {
 		WithObjects(server.DeepCopy()).
 		Build()
 	reconciler := &MCPServerReconciler{Client: client, Scheme: scheme}
-	defaulted := reconciler.defaultedMCPServerForReconcile(server)
+	defaulted := reconciler.defaultedMCPServerForReconcile(server, nil)
 
 	err := reconciler.validateMCPServerSpec(context.Background(), defaulted, logr.Discard())
 	if err == nil {
@@ -1230,7 +1276,7 @@ func TestRenderGatewayPolicyIncludesCrossNamespaceReferences(t *testing.T) {
 		},
 	}
 	session := &mcpv1alpha1.MCPAgentSession{
-		ObjectMeta: metav1.ObjectMeta{Name: "session-a", Namespace: "team-b"},
+		ObjectMeta: metav1.ObjectMeta{Name: "session-a", Namespace: "team-b", CreationTimestamp: metav1.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)},
 		Spec: mcpv1alpha1.MCPAgentSessionSpec{
 			ServerRef:      mcpv1alpha1.ServerReference{Name: "payments", Namespace: "servers"},
 			Subject:        mcpv1alpha1.SubjectRef{AgentID: "agent-1", TeamID: "team-payments"},
@@ -1312,6 +1358,8 @@ func TestRenderGatewayPolicyIncludesCrossNamespaceReferences(t *testing.T) {
 	}
 	if renderedSession := sessionsByName["session-a"]; renderedSession.TeamID != "team-payments" {
 		t.Fatalf("expected session teamID to be rendered, got %+v", renderedSession)
+	} else if renderedSession.CreatedAt != "2026-03-26T12:00:00Z" {
+		t.Fatalf("expected session creation time to be rendered, got %q", renderedSession.CreatedAt)
 	}
 	if defaulted := sessionsByName["session-default"]; defaulted.TeamID != "team-payments" {
 		t.Fatalf("expected missing session teamID to default to server teamID, got %+v", defaulted)
@@ -1712,6 +1760,8 @@ func TestReconcile(t *testing.T) {
 	_ = appsv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
+	_ = autoscalingv2.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
 
 	t.Run("returns not found when MCPServer does not exist", func(t *testing.T) {
 		client := fake.NewClientBuilder().WithScheme(scheme).Build()
@@ -25,7 +25,11 @@ import (
 func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
 	logger := log.FromContext(ctx)
 
-	image, err := r.resolveImage(ctx, mcpServer)
+	desiredImage, err := r.resolveImage(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+	image, err := r.stableTrackImage(ctx, mcpServer, desiredImage)
 	if err != nil {
 		return err
 	}
@@ -55,7 +59,7 @@ func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, mcpServer
 			"app.kubernetes.io/managed-by": "mcp-runtime",
 			"mcpruntime.org/rollout-track": "stable",
 		}
-		replicas := desiredStableReplicas(mcpServer)
+		replicas := stableDeploymentReplicas(mcpServer, deployment.Spec.Replicas)
 
 		deployment.Labels = map[string]string{
 			"app":                          mcpServer.Name,
@@ -70,18 +74,26 @@ func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, mcpServer
 			},
 			Strategy: deploymentStrategy(mcpServer),
 		}
+		applyGatewayComponentLabel(templateLabels, mcpServer)
 		deployment.Spec.Template.ObjectMeta.Labels = templateLabels
 
 		containers, volumes, err := r.buildDeploymentContainers(mcpServer, image)
 		if err != nil {
 			return err
 		}
+		initContainers, err := r.buildInitContainers(mcpServer)
+		if err != nil {
+			return err
+		}
 		deployment.Spec.Template.Spec = corev1.PodSpec{
 			ImagePullSecrets: r.buildImagePullSecrets(mcpServer),
+			InitContainers:   initContainers,
 			Containers:       containers,
 			Volumes:          volumes,
 		}
 		kubeworkload.ApplyRestrictedPodDefaults(&deployment.Spec.Template.Spec)
+		applyServerVolumeSecurity(&deployment.Spec.Template.Spec, mcpServer)
+		applyServerScheduling(&deployment.Spec.Template.Spec, mcpServer)
 
 		if err := ctrl.SetControllerReference(mcpServer, deployment, r.Scheme); err != nil {
 			return err
@@ -111,14 +123,20 @@ func (r *MCPServerReconciler) reconcileCanaryDeployment(ctx context.Context, mcp
 		if err != nil {
 			return err
 		}
-		return r.Delete(ctx, existing)
+		if err := r.Delete(ctx, existing); err != nil {
+			return err
+		}
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonCanaryRemoved, "DeleteCanary",
+			"Removed canary Deployment %s", existing.Name)
+		return nil
 	}
 
 	logger := log.FromContext(ctx)
-	image, err := r.resolveImage(ctx, mcpServer)
+	desiredImage, err := r.resolveImage(ctx, mcpServer)
 	if err != nil {
 		return err
 	}
+	image := canaryTrackImage(mcpServer, desiredImage)
 	if err := r.ensureWorkloadServiceAccount(ctx, mcpServer.Namespace); err != nil {
 		return err
 	}
@@ -151,21 +169,30 @@ func (r *MCPServerReconciler) reconcileCanaryDeployment(ctx context.Context, mcp
 			},
 			Strategy: deploymentStrategy(mcpServer),
 		}
-		deployment.Spec.Template.ObjectMeta.Labels = map[string]string{
+		templateLabels := map[string]string{
 			"app":                          mcpServer.Name,
 			"app.kubernetes.io/managed-by": "mcp-runtime",
 			"mcpruntime.org/rollout-track": "canary",
 		}
+		applyGatewayComponentLabel(templateLabels, mcpServer)
+		deployment.Spec.Template.ObjectMeta.Labels = templateLabels
 		containers, volumes, err := r.buildDeploymentContainers(mcpServer, image)
 		if err != nil {
 			return err
 		}
+		initContainers, err := r.buildInitContainers(mcpServer)
+		if err != nil {
+			return err
+		}
 		deployment.Spec.Template.Spec = corev1.PodSpec{
 			ImagePullSecrets: r.buildImagePullSecrets(mcpServer),
+			InitContainers:   initContainers,
 			Containers:       containers,
 			Volumes:          volumes,
 		}
 		kubeworkload.ApplyRestrictedPodDefaults(&deployment.Spec.Template.Spec)
+		applyServerVolumeSecurity(&deployment.Spec.Template.Spec, mcpServer)
+		applyServerScheduling(&deployment.Spec.Template.Spec, mcpServer)
 		if err := ctrl.SetControllerReference(mcpServer, deployment, r.Scheme); err != nil {
 			return err
 		}
@@ -177,6 +204,10 @@ func (r *MCPServerReconciler) reconcileCanaryDeployment(ctx context.Context, mcp
 	if op != controllerutil.OperationResultNone {
 		logger.Info("Canary deployment reconciled", "operation", op, "name", deployment.Name)
 	}
+	if op == controllerutil.OperationResultCreated {
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonCanaryCreated, "CreateCanary",
+			"Created canary Deployment %s with image %s", deployment.Name, image)
+	}
 	return nil
 }
 
@@ -194,28 +225,28 @@ func (r *MCPServerReconciler) buildDeploymentContainers(mcpServer *mcpv1alpha1.M
 		},
 		Env:             r.buildEnvVars(mcpServer.Spec.EnvVars, mcpServer.Spec.SecretEnvVars),
 		SecurityContext: kubeworkload.RestrictedContainerSecurityContext(),
-		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(mcpServer.Spec.Port)},
-			},
-			InitialDelaySeconds: 5,
-			PeriodSeconds:       10,
-		},
-		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(mcpServer.Spec.Port)},
-			},
-			InitialDelaySeconds: 3,
-			PeriodSeconds:       5,
-		},
 	}
+	container.LivenessProbe, container.ReadinessProbe, container.StartupProbe = serverContainerProbes(mcpServer)
 
 	if err := applyContainerResources(&container, mcpServer.Spec.Resources); err != nil {
 		return nil, nil, err
 	}
 
-	containers := []corev1.Container{container}
-	var volumes []corev1.Volume
+	volumes, mounts, err := buildServerVolumes(mcpServer)
+	if err != nil {
+		return nil, nil, err
+	}
+	container.VolumeMounts = mounts
+	if stdioTransport(mcpServer) {
+		applyStdioBridge(&container, mcpServer)
+		volumes = append(volumes, stdioBridgeVolume())
+	}
+
+	extraContainers, err := r.buildAdditionalContainers(mcpServer.Spec.ExtraContainers)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers := append([]corev1.Container{container}, extraContainers...)
 	if gatewayEnabled(mcpServer) {
 		gatewayContainer, err := r.buildGatewayContainer(mcpServer)
 		if err != nil {
@@ -240,6 +271,18 @@ func (r *MCPServerReconciler) buildDeploymentContainers(mcpServer *mcpv1alpha1.M
 				},
 			})
 		}
+		if r.gatewayCallsOperator() {
+			volumes = append(volumes, operatorTokenVolume(gatewayOperatorTokenVolumeName))
+		}
+		if spool := r.analyticsSpool(mcpServer); spool != nil {
+			spoolVolume, err := analyticsSpoolVolume(spool)
+			if err != nil {
+				return nil, nil, err
+			}
+			if spoolVolume != nil {
+				volumes = append(volumes, *spoolVolume)
+			}
+		}
 	}
 
 	return containers, volumes, nil
@@ -376,7 +419,27 @@ func (r *MCPServerReconciler) ensureWorkloadServiceAccount(ctx context.Context,
 	return err
 }
 
+// resolveImage returns the image the server's containers run. Pinned servers
+// (see imagePinned) run the digest recorded by reconcileImageVerification.
 func (r *MCPServerReconciler) resolveImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (string, error) {
+	image := r.requestedImage(ctx, mcpServer)
+	if !imagePinned(mcpServer) {
+		return image, nil
+	}
+	resolved := mcpServer.Status.Image
+	if resolved == nil || resolved.Digest == "" || resolved.Reference != image {
+		return "", newOperatorError("image digest is not resolved", map[string]any{
+			"mcpServer": mcpServer.Name,
+			"namespace": mcpServer.Namespace,
+			"image":     image,
+		})
+	}
+	return pinnedImage(image, resolved.Digest), nil
+}
+
+// requestedImage applies spec.imageTag and the registry override to
+// spec.image.
+func (r *MCPServerReconciler) requestedImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) string {
 	logger := log.FromContext(ctx)
 
 	image := mcpServer.Spec.Image
@@ -399,7 +462,7 @@ func (r *MCPServerReconciler) resolveImage(ctx context.Context, mcpServer *mcpv1
 		image = rewriteRegistry(image, regOverride)
 	}
 
-	return image, nil
+	return image
 }
 
 func (r *MCPServerReconciler) resolveGatewayImage(mcpServer *mcpv1alpha1.MCPServer) (string, error) {
@@ -446,6 +509,11 @@ func (r *MCPServerReconciler) buildGatewayContainer(mcpServer *mcpv1alpha1.MCPSe
 		{Name: "MCP_SERVER_NAME", Value: mcpServer.Name},
 		{Name: "MCP_SERVER_NAMESPACE", Value: mcpServer.Namespace},
 		{Name: "MCP_CLUSTER_NAME", Value: strings.TrimSpace(r.ClusterName)},
+		// The stable and canary Deployments share this container; the pod's
+		// rollout-track label tells the gateway which track its metrics belong to.
+		{Name: "MCP_ROLLOUT_TRACK", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['mcpruntime.org/rollout-track']"},
+		}},
 	}
 	if externalBaseURL := gatewayExternalBaseURL(mcpServer); externalBaseURL != "" {
 		envVars = append(envVars, corev1.EnvVar{Name: "EXTERNAL_BASE_URL", Value: externalBaseURL})
@@ -478,15 +546,29 @@ func (r *MCPServerReconciler) buildGatewayContainer(mcpServer *mcpv1alpha1.MCPSe
 			corev1.EnvVar{Name: "TLS_KEY_FILE", Value: gatewayTLSMountDir + "/tls.key"},
 			corev1.EnvVar{Name: "TLS_CLIENT_CA_FILE", Value: gatewayTLSMountDir + "/ca.crt"},
 		)
-		// Pin the ingress identity so only the Traefik client certificate (not
+		// Pin the ingress identity so only the proxy's client certificate (not
 		// any other identity-CA-signed cert) is accepted over the re-encrypted hop.
-		if proxyID := traefikProxySPIFFEID(mcpServer); proxyID != "" {
+		if proxyID := r.trustedProxySPIFFEID(mcpServer); proxyID != "" {
 			envVars = append(envVars, corev1.EnvVar{Name: "TRUSTED_PROXY_SPIFFE_ID", Value: proxyID})
 		}
 	}
 	if mcpServer.Spec.Gateway.StripPrefix != "" {
 		envVars = append(envVars, corev1.EnvVar{Name: "STRIP_PREFIX", Value: mcpServer.Spec.Gateway.StripPrefix})
 	}
+	// Grants can require approval without a change to the server, so every
+	// gateway knows where to request it.
+	if approvalURL := strings.TrimSpace(r.ApprovalURL); approvalURL != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "APPROVAL_URL", Value: approvalURL})
+	}
+	if sessionActivityURL := strings.TrimSpace(r.SessionActivityURL); sessionActivityURL != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "SESSION_ACTIVITY_URL", Value: sessionActivityURL})
+	}
+	if rateLimitURL := strings.TrimSpace(r.RateLimitURL); rateLimitURL != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "RATE_LIMIT_URL", Value: rateLimitURL})
+	}
+	if r.gatewayCallsOperator() {
+		envVars = append(envVars, corev1.EnvVar{Name: "OPERATOR_TOKEN_FILE", Value: operatorTokenMountDir + "/" + operatorTokenPath})
+	}
 	if r.analyticsEnabled(mcpServer) {
 		analytics := mcpServer.Spec.Analytics
 		ingestURL := ""
@@ -527,7 +609,7 @@ func (r *MCPServerReconciler) buildGatewayContainer(mcpServer *mcpv1alpha1.MCPSe
 	}
 
 	container := corev1.Container{
-		Name:            "mcp-gateway",
+		Name:            mcpv1alpha1.GatewayContainerName,
 		Image:           image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Ports: []corev1.ContainerPort{
@@ -579,6 +661,18 @@ func (r *MCPServerReconciler) buildGatewayContainer(mcpServer *mcpv1alpha1.MCPSe
 			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port)},
 		}
 	}
+	if r.gatewayCallsOperator() {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      gatewayOperatorTokenVolumeName,
+			MountPath: operatorTokenMountDir,
+			ReadOnly:  true,
+		})
+	}
+	if spool := r.analyticsSpool(mcpServer); spool != nil {
+		if err := applyAnalyticsSpool(&container, spool); err != nil {
+			return corev1.Container{}, err
+		}
+	}
 	gatewayResources := mcpv1alpha1.ResourceRequirements{}
 	if mcpServer.Spec.Gateway != nil && mcpServer.Spec.Gateway.Resources != nil {
 		gatewayResources = *mcpServer.Spec.Gateway.Resources
@@ -588,6 +682,23 @@ func (r *MCPServerReconciler) buildGatewayContainer(mcpServer *mcpv1alpha1.MCPSe
 	}
 	return container, nil
 }
+
+// gatewayCallsOperator reports whether gateways call the operator's
+// activation server and so mount a token for it.
+func (r *MCPServerReconciler) gatewayCallsOperator() bool {
+	return strings.TrimSpace(r.ApprovalURL) != "" || strings.TrimSpace(r.SessionActivityURL) != "" ||
+		strings.TrimSpace(r.RateLimitURL) != ""
+}
+
+// applyGatewayComponentLabel marks pods that run the gateway sidecar, which
+// lets them reach the operator's approval endpoint. It only goes on pod
+// templates: Deployment selectors are immutable.
+func applyGatewayComponentLabel(labels map[string]string, mcpServer *mcpv1alpha1.MCPServer) {
+	if gatewayEnabled(mcpServer) {
+		labels[LabelComponent] = LabelComponentGateway
+	}
+}
+
 func desiredStableReplicas(mcpServer *mcpv1alpha1.MCPServer) int32 {
 	if mcpServer.Spec.Replicas == nil {
 		return 1
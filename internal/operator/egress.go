@@ -0,0 +1,250 @@
+package operator
+
+import (
+	"context"
+	"maps"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/policy"
+)
+
+func egressNetworkPolicyName(mcpServer *mcpv1alpha1.MCPServer) string {
+	return mcpServer.Name + "-egress"
+}
+
+// egressRestricted reports whether spec.network.egress turns the pods'
+// egress into an allow list.
+func egressRestricted(mcpServer *mcpv1alpha1.MCPServer) bool {
+	network := mcpServer.Spec.Network
+	if network == nil || network.Egress == nil {
+		return false
+	}
+	return network.Egress.DefaultDeny || len(network.Egress.Rules) > 0
+}
+
+// reconcileEgressNetworkPolicy materializes spec.network.egress as a
+// NetworkPolicy over the stable and canary pods, and deletes it when the
+// server declares no egress restriction. Cluster DNS and the gateway
+// sidecar's in-cluster endpoints are always allowed so that restricting the
+// server does not silently break name resolution, audit, or tracing.
+func (r *MCPServerReconciler) reconcileEgressNetworkPolicy(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      egressNetworkPolicyName(mcpServer),
+			Namespace: mcpServer.Namespace,
+		},
+	}
+	if !egressRestricted(mcpServer) {
+		return r.deleteOwned(ctx, mcpServer, networkPolicy)
+	}
+
+	rules := append(implicitEgressRules(), r.gatewayEgressRules(mcpServer)...)
+	for _, rule := range mcpServer.Spec.Network.Egress.Rules {
+		rules = append(rules, buildEgressRule(rule))
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, networkPolicy, func() error {
+		networkPolicy.Labels = map[string]string{
+			LabelApp:       mcpServer.Name,
+			LabelManagedBy: LabelManagedByValue,
+		}
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": mcpServer.Name},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		}
+		return ctrl.SetControllerReference(mcpServer, networkPolicy, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("Egress NetworkPolicy reconciled", "operation", op, "name", networkPolicy.Name)
+	}
+	return nil
+}
+
+// implicitEgressRules allows DNS lookups against the cluster DNS pods.
+func implicitEgressRules() []networkingv1.NetworkPolicyEgressRule {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dns := intstr.FromInt32(53)
+	return []networkingv1.NetworkPolicyEgressRule{{
+		To: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"},
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"k8s-app": "kube-dns"},
+			},
+		}},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dns},
+			{Protocol: &tcp, Port: &dns},
+		},
+	}}
+}
+
+// gatewayEgressRules allows the gateway sidecar to reach its upstream,
+// analytics ingest, OTLP, approval, session activity, and rate limit endpoints when they are cluster Services
+// (name, or name.namespace.svc[...]) or IP addresses. External host names
+// cannot be expressed in a NetworkPolicy and need an explicit CIDR rule.
+func (r *MCPServerReconciler) gatewayEgressRules(mcpServer *mcpv1alpha1.MCPServer) []networkingv1.NetworkPolicyEgressRule {
+	if mcpServer.Spec.Gateway == nil || !mcpServer.Spec.Gateway.Enabled {
+		return nil
+	}
+	endpoints := []string{mcpServer.Spec.Gateway.UpstreamURL, r.GatewayOTLPEndpoint, r.ApprovalURL, r.SessionActivityURL, r.RateLimitURL}
+	if r.analyticsEnabled(mcpServer) {
+		ingestURL := strings.TrimSpace(mcpServer.Spec.Analytics.IngestURL)
+		if ingestURL == "" {
+			ingestURL = r.DefaultAnalyticsIngestURL
+		}
+		endpoints = append(endpoints, ingestURL)
+	}
+	var rules []networkingv1.NetworkPolicyEgressRule
+	for _, endpoint := range endpoints {
+		if rule, ok := endpointEgressRule(endpoint, mcpServer.Namespace); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// endpointEgressRule allows TCP to the host and port of an endpoint URL.
+// Loopback and external host names yield no rule.
+func endpointEgressRule(endpoint, serverNamespace string) (networkingv1.NetworkPolicyEgressRule, bool) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Hostname() == "" {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+	host := parsed.Hostname()
+
+	var peer networkingv1.NetworkPolicyPeer
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() {
+			return networkingv1.NetworkPolicyEgressRule{}, false
+		}
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+		peer.IPBlock = &networkingv1.IPBlock{CIDR: ip.String() + "/" + strconv.Itoa(bits)}
+	} else {
+		labels := strings.Split(host, ".")
+		namespace := ""
+		switch {
+		case host == "localhost":
+		case len(labels) == 1:
+			namespace = serverNamespace
+		case len(labels) >= 3 && labels[2] == "svc":
+			namespace = labels[1]
+		}
+		if namespace == "" {
+			return networkingv1.NetworkPolicyEgressRule{}, false
+		}
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace},
+		}
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+	portNumber, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, false
+	}
+	tcp := corev1.ProtocolTCP
+	target := intstr.FromInt32(int32(portNumber))
+	return networkingv1.NetworkPolicyEgressRule{
+		To:    []networkingv1.NetworkPolicyPeer{peer},
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &target}},
+	}, true
+}
+
+func buildEgressRule(rule mcpv1alpha1.EgressRule) networkingv1.NetworkPolicyEgressRule {
+	var out networkingv1.NetworkPolicyEgressRule
+	for _, peer := range rule.To {
+		var converted networkingv1.NetworkPolicyPeer
+		if cidr := strings.TrimSpace(peer.CIDR); cidr != "" {
+			converted.IPBlock = &networkingv1.IPBlock{CIDR: cidr, Except: append([]string(nil), peer.Except...)}
+		}
+		if len(peer.NamespaceSelector) > 0 {
+			converted.NamespaceSelector = &metav1.LabelSelector{MatchLabels: maps.Clone(peer.NamespaceSelector)}
+		}
+		if len(peer.PodSelector) > 0 {
+			converted.PodSelector = &metav1.LabelSelector{MatchLabels: maps.Clone(peer.PodSelector)}
+		}
+		out.To = append(out.To, converted)
+	}
+	for _, port := range rule.Ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != "" {
+			protocol = corev1.Protocol(port.Protocol)
+		}
+		converted := networkingv1.NetworkPolicyPort{Protocol: &protocol}
+		if port.Port != 0 {
+			target := intstr.FromInt32(port.Port)
+			converted.Port = &target
+		}
+		if port.EndPort != 0 {
+			endPort := port.EndPort
+			converted.EndPort = &endPort
+		}
+		out.Ports = append(out.Ports, converted)
+	}
+	return out
+}
+
+// renderPolicyNetwork copies the egress rules into the rendered policy
+// document, where `server policy inspect` shows them.
+func renderPolicyNetwork(mcpServer *mcpv1alpha1.MCPServer) *policy.Network {
+	if !egressRestricted(mcpServer) {
+		return nil
+	}
+	egress := mcpServer.Spec.Network.Egress
+	rendered := &policy.Egress{DefaultDeny: egress.DefaultDeny}
+	for _, rule := range egress.Rules {
+		var renderedRule policy.EgressRule
+		for _, peer := range rule.To {
+			renderedRule.To = append(renderedRule.To, policy.EgressPeer{
+				CIDR:              strings.TrimSpace(peer.CIDR),
+				Except:            append([]string(nil), peer.Except...),
+				NamespaceSelector: copyLabels(peer.NamespaceSelector),
+				PodSelector:       copyLabels(peer.PodSelector),
+			})
+		}
+		for _, port := range rule.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = string(corev1.ProtocolTCP)
+			}
+			renderedRule.Ports = append(renderedRule.Ports, policy.NetworkPort{Protocol: protocol, Port: port.Port, EndPort: port.EndPort})
+		}
+		rendered.Rules = append(rendered.Rules, renderedRule)
+	}
+	return &policy.Network{Egress: rendered}
+}
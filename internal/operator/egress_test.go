@@ -0,0 +1,145 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func egressServer() *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "jira-tools", Namespace: "servers", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Gateway:   &mcpv1alpha1.GatewayConfig{Enabled: true, Port: 8091, UpstreamURL: "http://127.0.0.1:8088"},
+			Analytics: &mcpv1alpha1.AnalyticsConfig{IngestURL: "http://ingest.mcp-sentinel.svc.cluster.local:8081/events"},
+			Network: &mcpv1alpha1.NetworkConfig{Egress: &mcpv1alpha1.EgressConfig{
+				DefaultDeny: true,
+				Rules: []mcpv1alpha1.EgressRule{{
+					To:    []mcpv1alpha1.EgressPeer{{CIDR: "10.20.0.0/16", Except: []string{"10.20.9.0/24"}}},
+					Ports: []mcpv1alpha1.NetworkPort{{Port: 5432}, {Protocol: "UDP", Port: 9000, EndPort: 9100}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestReconcileEgressNetworkPolicy(t *testing.T) {
+	server := egressServer()
+	scheme := newAccessStatusScheme()
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme, GatewayOTLPEndpoint: "otel-collector.observability.svc:4317"}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "jira-tools-egress", Namespace: "servers"}
+
+	if err := r.reconcileEgressNetworkPolicy(ctx, server); err != nil {
+		t.Fatalf("reconcile egress NetworkPolicy: %v", err)
+	}
+	np := &networkingv1.NetworkPolicy{}
+	if err := c.Get(ctx, key, np); err != nil {
+		t.Fatalf("get NetworkPolicy: %v", err)
+	}
+	if len(np.Spec.PolicyTypes) != 1 || np.Spec.PolicyTypes[0] != networkingv1.PolicyTypeEgress || np.Spec.PodSelector.MatchLabels["app"] != "jira-tools" {
+		t.Fatalf("NetworkPolicy spec = %#v", np.Spec)
+	}
+	// DNS, OTLP, analytics ingest, then the declared rule; the loopback
+	// upstream needs no rule.
+	egress := np.Spec.Egress
+	if len(egress) != 4 {
+		t.Fatalf("egress rules = %#v, want 4", egress)
+	}
+	if egress[0].To[0].PodSelector.MatchLabels["k8s-app"] != "kube-dns" || len(egress[0].Ports) != 2 {
+		t.Fatalf("DNS rule = %#v", egress[0])
+	}
+	for i, namespace := range []string{"observability", "mcp-sentinel"} {
+		rule := egress[i+1]
+		if rule.To[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"] != namespace {
+			t.Fatalf("gateway rule %d = %#v, want namespace %s", i, rule, namespace)
+		}
+	}
+	if port := egress[2].Ports[0].Port.IntValue(); port != 8081 {
+		t.Fatalf("analytics ingest port = %d, want 8081", port)
+	}
+	declared := egress[3]
+	if declared.To[0].IPBlock.CIDR != "10.20.0.0/16" || declared.To[0].IPBlock.Except[0] != "10.20.9.0/24" {
+		t.Fatalf("declared peer = %#v", declared.To[0])
+	}
+	if *declared.Ports[0].Protocol != "TCP" || declared.Ports[0].Port.IntValue() != 5432 {
+		t.Fatalf("declared port = %#v, want TCP 5432", declared.Ports[0])
+	}
+	if *declared.Ports[1].Protocol != "UDP" || *declared.Ports[1].EndPort != 9100 {
+		t.Fatalf("declared range = %#v", declared.Ports[1])
+	}
+	if !metav1.IsControlledBy(np, server) {
+		t.Fatal("expected the NetworkPolicy to be owned by the server")
+	}
+
+	// Dropping the egress block removes the policy.
+	server.Spec.Network = nil
+	if err := r.reconcileEgressNetworkPolicy(ctx, server); err != nil {
+		t.Fatalf("remove egress NetworkPolicy: %v", err)
+	}
+	if err := c.Get(ctx, key, np); !errors.IsNotFound(err) {
+		t.Fatalf("expected the NetworkPolicy to be deleted, got %v", err)
+	}
+}
+
+func TestEndpointEgressRule(t *testing.T) {
+	for _, tt := range []struct {
+		endpoint  string
+		namespace string
+		cidr      string
+		port      int
+		ok        bool
+	}{
+		{endpoint: "http://ingest:8081", namespace: "servers", port: 8081, ok: true},
+		{endpoint: "https://ingest.sentinel.svc", namespace: "sentinel", port: 443, ok: true},
+		{endpoint: "10.0.0.7:4317", cidr: "10.0.0.7/32", port: 4317, ok: true},
+		{endpoint: "http://localhost:8088"},
+		{endpoint: "https://collector.example.com"},
+		{endpoint: ""},
+	} {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			rule, ok := endpointEgressRule(tt.endpoint, "servers")
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			peer := rule.To[0]
+			if tt.cidr != "" && (peer.IPBlock == nil || peer.IPBlock.CIDR != tt.cidr) {
+				t.Fatalf("peer = %#v, want CIDR %s", peer, tt.cidr)
+			}
+			if tt.namespace != "" && peer.NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"] != tt.namespace {
+				t.Fatalf("peer = %#v, want namespace %s", peer, tt.namespace)
+			}
+			if got := rule.Ports[0].Port.IntValue(); got != tt.port {
+				t.Fatalf("port = %d, want %d", got, tt.port)
+			}
+		})
+	}
+}
+
+func TestRenderPolicyNetwork(t *testing.T) {
+	if got := renderPolicyNetwork(&mcpv1alpha1.MCPServer{}); got != nil {
+		t.Fatalf("renderPolicyNetwork without egress = %#v, want nil", got)
+	}
+	network := renderPolicyNetwork(egressServer())
+	if network == nil || !network.Egress.DefaultDeny || len(network.Egress.Rules) != 1 {
+		t.Fatalf("network = %#v", network)
+	}
+	ports := network.Egress.Rules[0].Ports
+	if ports[0].Protocol != "TCP" || ports[0].Port != 5432 || ports[1].EndPort != 9100 {
+		t.Fatalf("ports = %#v", ports)
+	}
+}
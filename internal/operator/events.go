@@ -0,0 +1,41 @@
+package operator
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// Event reasons the operator records. They are part of the operator's
+// observable contract (kubectl describe, alerting on Warning events), so keep
+// them stable.
+const (
+	EventReasonInvalidSpec           = "InvalidSpec"
+	EventReasonReconcileFailed       = "ReconcileFailed"
+	EventReasonPolicyRevisionChanged = "PolicyRevisionChanged"
+	EventReasonCanaryCreated         = "CanaryCreated"
+	EventReasonCanaryRemoved         = "CanaryRemoved"
+	EventReasonCanaryAnalysisStarted = "CanaryAnalysisStarted"
+	EventReasonCanaryPromoted        = "CanaryPromoted"
+	EventReasonCanaryRolledBack      = "CanaryRolledBack"
+	EventReasonCanaryAborted         = "CanaryAborted"
+	EventReasonRolloutActionIgnored  = "RolloutActionIgnored"
+	EventReasonScaledToZero          = "ScaledToZero"
+	EventReasonScaledFromZero        = "ScaledFromZero"
+	EventReasonCertificateIssued     = "CertificateIssued"
+	EventReasonTrustBundleRotated    = "TrustBundleRotated"
+	EventReasonAccessPhaseChanged    = "PhaseChanged"
+	EventReasonExpiredSessionDeleted = "ExpiredSessionDeleted"
+	EventReasonToolInventoryDrift    = "ToolInventoryDrift"
+	EventReasonInventoryProbeFailed  = "InventoryProbeFailed"
+	EventReasonImagePinned           = "ImagePinned"
+	EventReasonRateLimitsPerReplica  = "RateLimitsPerReplica"
+)
+
+// recordEvent emits a Kubernetes Event about obj. A nil recorder (as in most
+// unit tests) records nothing.
+func recordEvent(recorder events.EventRecorder, obj runtime.Object, eventType, reason, action, note string, args ...any) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(obj, nil, eventType, reason, action, note, args...)
+}
@@ -0,0 +1,165 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+func TestReconcilePolicyConfigMapRecordsRevisionChanges(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := gatewayServerForStatus()
+	server.Name = "events-revision"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	recorder := events.NewFakeRecorder(10)
+	r := MCPServerReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	changes := policyRevisionChangesTotal.WithLabelValues(server.Namespace, server.Name)
+
+	if err := r.reconcilePolicyConfigMap(context.Background(), server); err != nil {
+		t.Fatalf("reconcilePolicyConfigMap() error = %v", err)
+	}
+	got := drainEvents(recorder)
+	if len(got) != 1 || !strings.HasPrefix(got[0], "Normal PolicyRevisionChanged Gateway policy rendered at revision ") {
+		t.Fatalf("events after first render = %q, want one PolicyRevisionChanged", got)
+	}
+	if value := testutil.ToFloat64(changes); value != 1 {
+		t.Fatalf("revision changes = %v, want 1", value)
+	}
+	if size := testutil.ToFloat64(policyRenderBytes.WithLabelValues(server.Namespace, server.Name)); size <= 0 {
+		t.Fatalf("policy render bytes = %v, want > 0", size)
+	}
+
+	if err := r.reconcilePolicyConfigMap(context.Background(), server); err != nil {
+		t.Fatalf("reconcilePolicyConfigMap() error = %v", err)
+	}
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Fatalf("events after unchanged render = %q, want none", got)
+	}
+
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: server.Namespace},
+		Spec: mcpv1alpha1.MCPAccessGrantSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: server.Name},
+			Subject:   mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+		},
+	}
+	if err := c.Create(context.Background(), grant); err != nil {
+		t.Fatalf("create grant: %v", err)
+	}
+	if err := r.reconcilePolicyConfigMap(context.Background(), server); err != nil {
+		t.Fatalf("reconcilePolicyConfigMap() error = %v", err)
+	}
+	got = drainEvents(recorder)
+	if len(got) != 1 || !strings.Contains(got[0], "Gateway policy revision changed from ") {
+		t.Fatalf("events after grant = %q, want a revision change", got)
+	}
+	if value := testutil.ToFloat64(changes); value != 2 {
+		t.Fatalf("revision changes = %v, want 2", value)
+	}
+}
+
+func TestReconcileRecordsInvalidSpec(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-image", Namespace: "servers"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Port: 8080},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(server).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &MCPServerReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	validationErrors := testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues("validation"))
+	failedReconciles := reconcileSampleCount(t, "error")
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name, Namespace: server.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err == nil {
+		t.Fatal("Reconcile() error = nil, want validation error")
+	}
+
+	got := drainEvents(recorder)
+	if len(got) != 1 || !strings.HasPrefix(got[0], "Warning InvalidSpec ") {
+		t.Fatalf("events = %q, want one InvalidSpec warning", got)
+	}
+	if value := testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues("validation")); value != validationErrors+1 {
+		t.Fatalf("validation errors = %v, want %v", value, validationErrors+1)
+	}
+	if count := reconcileSampleCount(t, "error"); count != failedReconciles+1 {
+		t.Fatalf("error reconciles observed = %d, want %d", count, failedReconciles+1)
+	}
+}
+
+func reconcileSampleCount(t *testing.T, result string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := reconcileDurationSeconds.WithLabelValues(result).(prometheus.Histogram).Write(&metric); err != nil {
+		t.Fatalf("read reconcile duration: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMCPAccessGrantReconcilerRecordsPhaseChanges(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := gatewayServerForStatus()
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPAccessGrantSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			Subject:   mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(server, grant).
+		WithStatusSubresource(&mcpv1alpha1.MCPAccessGrant{}).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &MCPAccessGrantReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ops", Namespace: "servers"}}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	renderPolicyConfigMap(t, c, scheme, server)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := drainEvents(recorder)
+	if len(got) != 2 {
+		t.Fatalf("events = %q, want two phase changes", got)
+	}
+	if !strings.HasPrefix(got[0], "Normal PhaseChanged Phase is Pending") {
+		t.Fatalf("first event = %q, want initial Pending", got[0])
+	}
+	if !strings.HasPrefix(got[1], "Normal PhaseChanged Phase changed from Pending to Active") {
+		t.Fatalf("second event = %q, want Pending to Active", got[1])
+	}
+}
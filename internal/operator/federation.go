@@ -0,0 +1,360 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/kubeworkload"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+const (
+	federationContainerName  = "federation"
+	federationPolicyVolume   = "federation-policies"
+	federationServicePort    = 80
+	federationMembersEnvName = "FEDERATION_MEMBERS"
+)
+
+// MCPFederationReconciler runs the federation gateway of each MCPFederation:
+// the gateway image in federation mode, serving the selected MCPServers
+// behind one MCP endpoint with each member's rendered policy mounted.
+type MCPFederationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// GatewayProxyImage is the gateway image the federation gateway runs.
+	GatewayProxyImage string
+
+	// DefaultIngressHost is the route host of federations without
+	// spec.ingressHost.
+	DefaultIngressHost string
+}
+
+// federationMember is one entry of FEDERATION_MEMBERS, read by the gateway
+// in federation mode.
+type federationMember struct {
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	URL        string `json:"url"`
+	PolicyFile string `json:"policyFile"`
+}
+
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpfederations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpfederations/status,verbs=get;update;patch
+
+// Reconcile keeps the federation gateway Deployment, Service, and Ingress in
+// line with the selected members and reports them on status.
+func (r *MCPFederationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	federation := &mcpv1alpha1.MCPFederation{}
+	if err := r.Get(ctx, req.NamespacedName, federation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	statusMembers, members, err := r.federationMembers(ctx, federation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status := mcpv1alpha1.MCPFederationStatus{
+		Members:            statusMembers,
+		URL:                r.federationURL(federation),
+		Conditions:         append([]metav1.Condition(nil), federation.Status.Conditions...),
+		ObservedGeneration: federation.Generation,
+	}
+
+	image := strings.TrimSpace(r.GatewayProxyImage)
+	if image == "" {
+		operatorutil.SetCondition(&status.Conditions, operatorutil.DeploymentReady, false, "MissingGatewayImage",
+			"federation requires the gateway image (set MCP_GATEWAY_PROXY_IMAGE on the operator)", federation.Generation)
+		return ctrl.Result{}, r.writeFederationStatus(ctx, federation, status)
+	}
+
+	deployment, err := r.reconcileFederationDeployment(ctx, federation, image, members)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileFederationService(ctx, federation); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileFederationIngress(ctx, federation); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ready := deployment.Status.ReadyReplicas > 0
+	message := fmt.Sprintf("%d/%d federation gateway replicas ready", deployment.Status.ReadyReplicas, federationReplicas(federation))
+	operatorutil.SetCondition(&status.Conditions, operatorutil.DeploymentReady, ready, conditionReason(ready, "Available", "Progressing"), message, federation.Generation)
+	return ctrl.Result{}, r.writeFederationStatus(ctx, federation, status)
+}
+
+func (r *MCPFederationReconciler) writeFederationStatus(ctx context.Context, federation *mcpv1alpha1.MCPFederation, status mcpv1alpha1.MCPFederationStatus) error {
+	if equality.Semantic.DeepEqual(federation.Status, status) {
+		return nil
+	}
+	federation.Status = status
+	if err := r.Status().Update(ctx, federation); err != nil {
+		return ignoreStatusConflict(ctx, err)
+	}
+	return nil
+}
+
+// federationMembers returns the selected servers for status, sorted by name,
+// and the federated ones for the gateway. A server is excluded when the
+// federation cannot apply its policy: without a gateway there is none, and in
+// auth mode mtls its gateway only accepts the ingress.
+func (r *MCPFederationReconciler) federationMembers(ctx context.Context, federation *mcpv1alpha1.MCPFederation) ([]mcpv1alpha1.MCPFederationMember, []federationMember, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&federation.Spec.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	servers := &mcpv1alpha1.MCPServerList{}
+	if err := r.List(ctx, servers, client.InNamespace(federation.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(servers.Items, func(i, j int) bool { return servers.Items[i].Name < servers.Items[j].Name })
+
+	var statusMembers []mcpv1alpha1.MCPFederationMember
+	var members []federationMember
+	for i := range servers.Items {
+		server := servers.Items[i].DeepCopy()
+		if !server.DeletionTimestamp.IsZero() {
+			continue
+		}
+		server.Default()
+		switch {
+		case !gatewayEnabled(server):
+			statusMembers = append(statusMembers, mcpv1alpha1.MCPFederationMember{Name: server.Name, Excluded: "gateway is disabled, so there is no policy to apply"})
+		case serverUsesMTLS(server):
+			statusMembers = append(statusMembers, mcpv1alpha1.MCPFederationMember{Name: server.Name, Excluded: "auth mode mtls only accepts requests from the ingress"})
+		default:
+			statusMembers = append(statusMembers, mcpv1alpha1.MCPFederationMember{Name: server.Name, Prefix: server.Name})
+			members = append(members, federationMember{
+				Name:       server.Name,
+				Prefix:     server.Name,
+				URL:        fmt.Sprintf("http://%s.%s.svc:%d%s", server.Name, server.Namespace, server.Spec.ServicePort, normalizeIngressPath(effectiveIngressPath(server))),
+				PolicyFile: federationPolicyFilePath(server.Name),
+			})
+		}
+	}
+	return statusMembers, members, nil
+}
+
+func (r *MCPFederationReconciler) reconcileFederationDeployment(ctx context.Context, federation *mcpv1alpha1.MCPFederation, image string, members []federationMember) (*appsv1.Deployment, error) {
+	membersJSON, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: federationResourceName(federation.Name), Namespace: federation.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		replicas := federationReplicas(federation)
+		labels := federationLabels(federation.Name)
+		deployment.Labels = labels
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		deployment.Spec.Template.ObjectMeta.Labels = labels
+		deployment.Spec.Template.Spec = corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            federationContainerName,
+				Image:           image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "MCP_GATEWAY_MODE", Value: "federation"},
+					{Name: "PORT", Value: strconv.Itoa(DefaultGatewayPort)},
+					{Name: "METRICS_PORT", Value: strconv.Itoa(DefaultGatewayMetricsPort)},
+					{Name: "MCP_FEDERATION_NAME", Value: federation.Name},
+					{Name: "MCP_FEDERATION_NAMESPACE", Value: federation.Namespace},
+					{Name: federationMembersEnvName, Value: string(membersJSON)},
+				},
+				Ports: []corev1.ContainerPort{
+					{Name: "gateway", ContainerPort: DefaultGatewayPort, Protocol: corev1.ProtocolTCP},
+					{Name: "metrics", ContainerPort: DefaultGatewayMetricsPort, Protocol: corev1.ProtocolTCP},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("50m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+				SecurityContext: kubeworkload.RestrictedReadOnlyContainerSecurityContext(),
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: federationPolicyVolume, MountPath: gatewayPolicyMountDir, ReadOnly: true},
+				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(DefaultGatewayMetricsPort)},
+					},
+					PeriodSeconds: 10,
+				},
+			}},
+			Volumes: []corev1.Volume{federationPolicyVolumeFor(members)},
+		}
+		kubeworkload.ApplyRestrictedPodDefaults(&deployment.Spec.Template.Spec)
+		return ctrl.SetControllerReference(federation, deployment, r.Scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("Federation deployment reconciled", "operation", op, "name", deployment.Name)
+	}
+	return deployment, nil
+}
+
+// federationPolicyVolumeFor projects each member's policy ConfigMap as
+// <member>.json. The ConfigMaps are optional so a member whose policy is not
+// rendered yet does not block the pod; the gateway hides it until it is.
+func federationPolicyVolumeFor(members []federationMember) corev1.Volume {
+	optional := true
+	sources := make([]corev1.VolumeProjection, 0, len(members))
+	for _, member := range members {
+		sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: gatewayPolicyConfigMapName(member.Name)},
+			Items:                []corev1.KeyToPath{{Key: gatewayPolicyFileName, Path: member.Name + ".json"}},
+			Optional:             &optional,
+		}})
+	}
+	return corev1.Volume{
+		Name:         federationPolicyVolume,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}},
+	}
+}
+
+func (r *MCPFederationReconciler) reconcileFederationService(ctx context.Context, federation *mcpv1alpha1.MCPFederation) error {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: federationResourceName(federation.Name), Namespace: federation.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = federationLabels(federation.Name)
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.Selector = federationLabels(federation.Name)
+		service.Spec.Ports = []corev1.ServicePort{{
+			Name:       "http",
+			Port:       federationServicePort,
+			TargetPort: intstr.FromInt32(DefaultGatewayPort),
+			Protocol:   corev1.ProtocolTCP,
+		}}
+		return ctrl.SetControllerReference(federation, service, r.Scheme)
+	})
+	return err
+}
+
+func (r *MCPFederationReconciler) reconcileFederationIngress(ctx context.Context, federation *mcpv1alpha1.MCPFederation) error {
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: federationResourceName(federation.Name), Namespace: federation.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		pathType := networkingv1.PathTypePrefix
+		ingressClassName := strings.TrimSpace(federation.Spec.IngressClass)
+		if ingressClassName == "" {
+			ingressClassName = DefaultIngressClass
+		}
+		ingress.Labels = federationLabels(federation.Name)
+		ingress.Spec = networkingv1.IngressSpec{
+			IngressClassName: &ingressClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: r.federationIngressHost(federation),
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     federationIngressPath(federation),
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+							Name: federationResourceName(federation.Name),
+							Port: networkingv1.ServiceBackendPort{Number: federationServicePort},
+						}},
+					}},
+				}},
+			}},
+		}
+		return ctrl.SetControllerReference(federation, ingress, r.Scheme)
+	})
+	return err
+}
+
+func (r *MCPFederationReconciler) federationIngressHost(federation *mcpv1alpha1.MCPFederation) string {
+	if host := strings.TrimSpace(federation.Spec.IngressHost); host != "" {
+		return host
+	}
+	return strings.TrimSpace(r.DefaultIngressHost)
+}
+
+// federationURL is the endpoint reported on status. Without a host it is the
+// path alone, served on any host of the ingress.
+func (r *MCPFederationReconciler) federationURL(federation *mcpv1alpha1.MCPFederation) string {
+	host := r.federationIngressHost(federation)
+	if host == "" {
+		return federationIngressPath(federation)
+	}
+	return "http://" + host + federationIngressPath(federation)
+}
+
+func federationIngressPath(federation *mcpv1alpha1.MCPFederation) string {
+	if path := strings.TrimSpace(federation.Spec.IngressPath); path != "" {
+		return normalizeIngressPath(path)
+	}
+	return "/federations/" + federation.Name + "/mcp"
+}
+
+func federationReplicas(federation *mcpv1alpha1.MCPFederation) int32 {
+	if federation.Spec.Replicas != nil {
+		return *federation.Spec.Replicas
+	}
+	return 1
+}
+
+func federationResourceName(name string) string {
+	return name + "-federation"
+}
+
+func federationPolicyFilePath(member string) string {
+	return gatewayPolicyMountDir + "/" + member + ".json"
+}
+
+func federationLabels(name string) map[string]string {
+	return map[string]string{
+		LabelApp:       federationResourceName(name),
+		LabelManagedBy: LabelManagedByValue,
+	}
+}
+
+// requestsForServer enqueues every federation in the server's namespace,
+// since a label change can add or remove it from any of them.
+func (r *MCPFederationReconciler) requestsForServer(ctx context.Context, obj client.Object) []ctrl.Request {
+	federations := &mcpv1alpha1.MCPFederationList{}
+	if err := r.List(ctx, federations, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	requests := make([]ctrl.Request, 0, len(federations.Items))
+	for _, federation := range federations.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: federation.Name, Namespace: federation.Namespace}})
+	}
+	return requests
+}
+
+func (r *MCPFederationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcpfederation").
+		For(&mcpv1alpha1.MCPFederation{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(&mcpv1alpha1.MCPServer{}, handler.EnqueueRequestsFromMapFunc(r.requestsForServer)).
+		Complete(r)
+}
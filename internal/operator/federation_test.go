@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestMCPFederationReconcilerRendersMembers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	platform := map[string]string{"team": "platform"}
+	federation := &mcpv1alpha1.MCPFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform-tools", Namespace: "servers", Generation: 1},
+		Spec:       mcpv1alpha1.MCPFederationSpec{Selector: metav1.LabelSelector{MatchLabels: platform}},
+	}
+	server := func(name string, labels map[string]string, gateway bool, auth mcpv1alpha1.AuthMode) *mcpv1alpha1.MCPServer {
+		s := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "servers", Labels: labels},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:   "example.com/" + name,
+				Gateway: &mcpv1alpha1.GatewayConfig{Enabled: gateway},
+			},
+		}
+		if auth != "" {
+			s.Spec.Auth = &mcpv1alpha1.AuthConfig{Mode: auth}
+		}
+		return s
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			federation,
+			server("search", platform, true, ""),
+			server("docs", platform, false, ""),
+			server("billing", platform, true, mcpv1alpha1.AuthModeMTLS),
+			server("payments", map[string]string{"team": "finance"}, true, ""),
+		).
+		WithStatusSubresource(&mcpv1alpha1.MCPFederation{}).
+		Build()
+	r := &MCPFederationReconciler{Client: c, Scheme: scheme, GatewayProxyImage: "example.com/gateway:v1", DefaultIngressHost: "mcp.example.com"}
+
+	key := types.NamespacedName{Name: "platform-tools", Namespace: "servers"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "platform-tools-federation", Namespace: "servers"}, deployment); err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	var members []federationMember
+	for _, env := range container.Env {
+		if env.Name == federationMembersEnvName {
+			if err := json.Unmarshal([]byte(env.Value), &members); err != nil {
+				t.Fatalf("decode %s: %v", federationMembersEnvName, err)
+			}
+		}
+	}
+	want := federationMember{Name: "search", Prefix: "search", URL: "http://search.servers.svc:80/search/mcp", PolicyFile: gatewayPolicyMountDir + "/search.json"}
+	if len(members) != 1 || members[0] != want {
+		t.Fatalf("members = %+v, want only %+v", members, want)
+	}
+	projected := deployment.Spec.Template.Spec.Volumes[0].Projected
+	if projected == nil || len(projected.Sources) != 1 || projected.Sources[0].ConfigMap.Name != gatewayPolicyConfigMapName("search") {
+		t.Fatalf("policy volume = %#v, want the search policy ConfigMap", deployment.Spec.Template.Spec.Volumes)
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "platform-tools-federation", Namespace: "servers"}, ingress); err != nil {
+		t.Fatalf("get ingress: %v", err)
+	}
+	if rule := ingress.Spec.Rules[0]; rule.Host != "mcp.example.com" || rule.HTTP.Paths[0].Path != "/federations/platform-tools/mcp" {
+		t.Fatalf("ingress rule = %#v", rule)
+	}
+
+	updated := &mcpv1alpha1.MCPFederation{}
+	if err := c.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("get federation: %v", err)
+	}
+	if updated.Status.URL != "http://mcp.example.com/federations/platform-tools/mcp" {
+		t.Fatalf("status.url = %q", updated.Status.URL)
+	}
+	if got := updated.Status.Members; len(got) != 3 || got[0].Name != "billing" || got[0].Excluded == "" || got[1].Name != "docs" || got[1].Excluded == "" || got[2].Prefix != "search" {
+		t.Fatalf("status.members = %+v, want billing and docs excluded and search federated", got)
+	}
+}
@@ -0,0 +1,253 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+var (
+	httpRouteGVK        = schema.GroupVersionKind{Group: gatewayAPIGroup, Version: "v1", Kind: "HTTPRoute"}
+	backendTLSPolicyGVK = schema.GroupVersionKind{Group: gatewayAPIGroup, Version: "v1", Kind: "BackendTLSPolicy"}
+)
+
+// routingMode returns the server's routing mode: spec.routing.mode when set,
+// otherwise the operator default.
+func (r *MCPServerReconciler) routingMode(mcpServer *mcpv1alpha1.MCPServer) mcpv1alpha1.RoutingMode {
+	if mcpServer.Spec.Routing != nil && mcpServer.Spec.Routing.Mode != "" {
+		return mcpServer.Spec.Routing.Mode
+	}
+	mode, _ := NormalizeRoutingMode(r.RoutingMode)
+	return mcpv1alpha1.RoutingMode(mode)
+}
+
+func (r *MCPServerReconciler) usesGatewayAPI(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return r.routingMode(mcpServer) == mcpv1alpha1.RoutingModeGatewayAPI
+}
+
+// gatewayAPIParent resolves the Gateway the server's HTTPRoute attaches to.
+func (r *MCPServerReconciler) gatewayAPIParent(mcpServer *mcpv1alpha1.MCPServer) (mcpv1alpha1.GatewayParentRef, error) {
+	if routing := mcpServer.Spec.Routing; routing != nil && routing.ParentRef != nil {
+		return *routing.ParentRef, nil
+	}
+	if r.GatewayAPIParent != nil && strings.TrimSpace(r.GatewayAPIParent.Name) != "" {
+		return *r.GatewayAPIParent, nil
+	}
+	return mcpv1alpha1.GatewayParentRef{}, fmt.Errorf("gateway-api routing requires spec.routing.parentRef or MCP_GATEWAY_API_PARENT_NAME on the operator")
+}
+
+// gatewayAPIProxyNamespace is where the parent Gateway's data plane runs: the
+// configured proxy namespace, else the Gateway's own namespace.
+func (r *MCPServerReconciler) gatewayAPIProxyNamespace(mcpServer *mcpv1alpha1.MCPServer) string {
+	if namespace := strings.TrimSpace(r.GatewayAPIProxyNamespace); namespace != "" {
+		return namespace
+	}
+	parent, err := r.gatewayAPIParent(mcpServer)
+	if err != nil || parent.Namespace == "" {
+		return mcpServer.Namespace
+	}
+	return parent.Namespace
+}
+
+// trustedProxySPIFFEID is the identity the gateway pins for the re-encrypted
+// mtls hop: the Traefik client certificate in ingress mode, or the parent
+// Gateway's backend client certificate in gateway-api mode. The latter is
+// only pinned when MCP_GATEWAY_API_PROXY_SERVICE_ACCOUNT is configured.
+func (r *MCPServerReconciler) trustedProxySPIFFEID(mcpServer *mcpv1alpha1.MCPServer) string {
+	if !r.usesGatewayAPI(mcpServer) {
+		return traefikProxySPIFFEID(mcpServer)
+	}
+	serviceAccount := strings.TrimSpace(r.GatewayAPIProxyServiceAccount)
+	if mcpServer.Spec.Auth == nil || serviceAccount == "" {
+		return ""
+	}
+	trustDomain := strings.TrimSpace(mcpServer.Spec.Auth.TrustDomain)
+	if trustDomain == "" {
+		return ""
+	}
+	return fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", trustDomain, r.gatewayAPIProxyNamespace(mcpServer), serviceAccount)
+}
+
+// reconcileHTTPRoute attaches the server to its parent Gateway. For mtls
+// servers a BackendTLSPolicy has the Gateway re-encrypt to the gateway sidecar
+// and verify it against the server's trust bundle; the parent Gateway itself
+// must verify caller certificates and inject the verified identity header.
+func (r *MCPServerReconciler) reconcileHTTPRoute(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	parent, err := r.gatewayAPIParent(mcpServer)
+	if err != nil {
+		return err
+	}
+	route := r.ownedUnstructured(mcpServer, httpRouteGVK, mcpServer.Name, buildHTTPRouteSpec(mcpServer, parent))
+	if err := r.applyUnstructured(ctx, route); err != nil {
+		return err
+	}
+	if !serverUsesMTLS(mcpServer) {
+		return r.deleteUnstructured(ctx, backendTLSPolicyGVK, mcpServer.Name, mcpServer.Namespace)
+	}
+	policy := r.ownedUnstructured(mcpServer, backendTLSPolicyGVK, mcpServer.Name, map[string]any{
+		"targetRefs": []any{map[string]any{
+			"group":       "",
+			"kind":        "Service",
+			"name":        mcpServer.Name,
+			"sectionName": "http",
+		}},
+		"validation": map[string]any{
+			"hostname": gatewayServerName(mcpServer),
+			"caCertificateRefs": []any{map[string]any{
+				"group": "",
+				"kind":  "ConfigMap",
+				"name":  mtlsTrustBundleSecretName(mcpServer),
+			}},
+		},
+	})
+	return r.applyUnstructured(ctx, policy)
+}
+
+// deleteHTTPRoute removes the Gateway API resources when the server routes
+// through an Ingress.
+func (r *MCPServerReconciler) deleteHTTPRoute(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, backendTLSPolicyGVK} {
+		if err := r.deleteUnstructured(ctx, gvk, mcpServer.Name, mcpServer.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildHTTPRouteSpec(mcpServer *mcpv1alpha1.MCPServer, parent mcpv1alpha1.GatewayParentRef) map[string]any {
+	parentRef := map[string]any{
+		"group": gatewayAPIGroup,
+		"kind":  "Gateway",
+		"name":  parent.Name,
+	}
+	if parent.Namespace != "" {
+		parentRef["namespace"] = parent.Namespace
+	}
+	if parent.SectionName != "" {
+		parentRef["sectionName"] = parent.SectionName
+	}
+
+	paths := []string{normalizeIngressPath(effectiveIngressPath(mcpServer))}
+	if serverUsesOAuth(mcpServer) {
+		paths = append(paths, oauthProtectedResourceIngressPath(effectiveIngressPath(mcpServer)))
+	}
+	matches := make([]any, 0, len(paths))
+	for _, path := range paths {
+		matches = append(matches, map[string]any{
+			"path": map[string]any{"type": "PathPrefix", "value": path},
+		})
+	}
+
+	spec := map[string]any{
+		"parentRefs": []any{parentRef},
+		"rules": []any{map[string]any{
+			"matches": matches,
+			"backendRefs": []any{map[string]any{
+				"name": mcpServer.Name,
+				"port": int64(mcpServer.Spec.ServicePort),
+			}},
+		}},
+	}
+	if host := effectiveIngressHost(mcpServer); host != "" {
+		spec["hostnames"] = []any{host}
+	}
+	return spec
+}
+
+// reconcileBackendTLSCABundle publishes the identity CA as a ConfigMap, the
+// only certificate reference kind BackendTLSPolicy requires implementations
+// to support. A nil ca deletes it.
+func (r *MCPServerReconciler) reconcileBackendTLSCABundle(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, ca []byte) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mtlsTrustBundleSecretName(mcpServer),
+			Namespace: mcpServer.Namespace,
+		},
+	}
+	if ca == nil || !r.usesGatewayAPI(mcpServer) {
+		return r.deleteOwned(ctx, mcpServer, configMap)
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{"ca.crt": string(ca)}
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		configMap.Labels["app.kubernetes.io/managed-by"] = "mcp-runtime"
+		configMap.Labels["mcpruntime.org/server"] = mcpServer.Name
+		return ctrl.SetControllerReference(mcpServer, configMap, r.Scheme)
+	})
+	return err
+}
+
+// checkHTTPRouteReady reports whether the parent Gateway has accepted the
+// route and resolved its backend, from the current generation's conditions.
+func (r *MCPServerReconciler) checkHTTPRouteReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
+	parent, err := r.gatewayAPIParent(mcpServer)
+	if err != nil {
+		return false, nil
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, route); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, item := range parents {
+		status, ok := item.(map[string]any)
+		if !ok || !routeParentMatches(status, parent, mcpServer.Namespace) {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+		if routeConditionTrue(conditions, "Accepted", route.GetGeneration()) &&
+			routeConditionTrue(conditions, "ResolvedRefs", route.GetGeneration()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func routeParentMatches(status map[string]any, parent mcpv1alpha1.GatewayParentRef, routeNamespace string) bool {
+	name, _, _ := unstructured.NestedString(status, "parentRef", "name")
+	namespace, _, _ := unstructured.NestedString(status, "parentRef", "namespace")
+	sectionName, _, _ := unstructured.NestedString(status, "parentRef", "sectionName")
+	if namespace == "" {
+		namespace = routeNamespace
+	}
+	wantNamespace := parent.Namespace
+	if wantNamespace == "" {
+		wantNamespace = routeNamespace
+	}
+	return name == parent.Name && namespace == wantNamespace && sectionName == parent.SectionName
+}
+
+// routeConditionTrue reports whether the named condition is True for the
+// route's current generation. Conditions written for an older generation are
+// stale and do not count.
+func routeConditionTrue(conditions []any, conditionType string, generation int64) bool {
+	for _, item := range conditions {
+		condition, ok := item.(map[string]any)
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		observed, _, _ := unstructured.NestedInt64(condition, "observedGeneration")
+		return condition["status"] == string(metav1.ConditionTrue) && observed >= generation
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func gatewayAPIScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := traefikScheme(t)
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, networkingv1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("scheme: %v", err)
+		}
+	}
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, backendTLSPolicyGVK, certificateGVK} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return scheme
+}
+
+func gatewayAPIServer() *mcpv1alpha1.MCPServer {
+	server := mtlsServer()
+	server.Spec.ServicePort = 80
+	server.Spec.IngressHost = "mcp.example.com"
+	server.Spec.IngressPath = "/secure/mcp"
+	server.Spec.Routing = &mcpv1alpha1.RoutingConfig{Mode: mcpv1alpha1.RoutingModeGatewayAPI}
+	return server
+}
+
+func TestReconcileIngressGatewayAPIMode(t *testing.T) {
+	scheme := gatewayAPIScheme(t)
+	server := gatewayAPIServer()
+	stale := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, stale).Build()
+	r := MCPServerReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		GatewayAPIParent: &mcpv1alpha1.GatewayParentRef{Name: "public", Namespace: "gateway-system"},
+	}
+	ctx := context.Background()
+
+	if err := r.reconcileIngress(ctx, server); err != nil {
+		t.Fatalf("reconcileIngress: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: server.Name, Namespace: server.Namespace}, &networkingv1.Ingress{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Ingress should be deleted in gateway-api mode, got %v", err)
+	}
+
+	route := getCR(t, c, httpRouteGVK, server.Name, server.Namespace)
+	parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	if len(parents) != 1 || parents[0].(map[string]any)["name"] != "public" || parents[0].(map[string]any)["namespace"] != "gateway-system" {
+		t.Fatalf("parentRefs = %v, want the operator default Gateway", parents)
+	}
+	if hosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); len(hosts) != 1 || hosts[0] != "mcp.example.com" {
+		t.Fatalf("hostnames = %v", hosts)
+	}
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule := rules[0].(map[string]any)
+	if path, _, _ := unstructured.NestedString(rule["matches"].([]any)[0].(map[string]any), "path", "value"); path != "/secure/mcp" {
+		t.Fatalf("match path = %q", path)
+	}
+	if port := rule["backendRefs"].([]any)[0].(map[string]any)["port"]; port != int64(80) {
+		t.Fatalf("backend port = %v, want the service port", port)
+	}
+
+	policy := getCR(t, c, backendTLSPolicyGVK, server.Name, server.Namespace)
+	if hostname, _, _ := unstructured.NestedString(policy.Object, "spec", "validation", "hostname"); hostname != gatewayServerName(server) {
+		t.Fatalf("validation hostname = %q", hostname)
+	}
+
+	// Switching back to ingress mode removes the Gateway API resources.
+	server.Spec.Routing = nil
+	server.Spec.Auth = nil
+	if err := r.reconcileIngress(ctx, server); err != nil {
+		t.Fatalf("reconcileIngress in ingress mode: %v", err)
+	}
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, backendTLSPolicyGVK} {
+		obj := crFixture(gvk, server.Name, server.Namespace)
+		if err := c.Get(ctx, types.NamespacedName{Name: server.Name, Namespace: server.Namespace}, obj); !apierrors.IsNotFound(err) {
+			t.Fatalf("%s should be deleted in ingress mode, got %v", gvk.Kind, err)
+		}
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: server.Name, Namespace: server.Namespace}, &networkingv1.Ingress{}); err != nil {
+		t.Fatalf("expected Ingress in ingress mode: %v", err)
+	}
+}
+
+func TestReconcileHTTPRouteRequiresParent(t *testing.T) {
+	r := MCPServerReconciler{}
+	if err := r.reconcileHTTPRoute(context.Background(), gatewayAPIServer()); err == nil {
+		t.Fatal("expected an error without a parent Gateway")
+	}
+}
+
+func TestCheckHTTPRouteReady(t *testing.T) {
+	scheme := gatewayAPIScheme(t)
+	server := gatewayAPIServer()
+	server.Spec.Routing.ParentRef = &mcpv1alpha1.GatewayParentRef{Name: "public", Namespace: "gateway-system"}
+	newRoute := func(parentName, resolvedRefs string, observedGeneration int64) *unstructured.Unstructured {
+		route := crFixture(httpRouteGVK, server.Name, server.Namespace)
+		route.SetGeneration(2)
+		route.Object["status"] = map[string]any{"parents": []any{map[string]any{
+			"parentRef": map[string]any{"name": parentName, "namespace": "gateway-system"},
+			"conditions": []any{
+				map[string]any{"type": "Accepted", "status": "True", "observedGeneration": observedGeneration},
+				map[string]any{"type": "ResolvedRefs", "status": resolvedRefs, "observedGeneration": observedGeneration},
+			},
+		}}}
+		return route
+	}
+
+	for _, tt := range []struct {
+		name  string
+		route *unstructured.Unstructured
+		want  bool
+	}{
+		{name: "accepted and resolved", route: newRoute("public", "True", 2), want: true},
+		{name: "unresolved backend", route: newRoute("public", "False", 2)},
+		{name: "stale conditions", route: newRoute("public", "True", 1)},
+		{name: "other parent", route: newRoute("internal", "True", 2)},
+		{name: "missing route"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server)
+			if tt.route != nil {
+				builder = builder.WithObjects(tt.route)
+			}
+			r := MCPServerReconciler{Client: builder.Build(), Scheme: scheme}
+			ready, err := r.checkIngressReady(context.Background(), server)
+			if err != nil || ready != tt.want {
+				t.Fatalf("checkIngressReady = %v, %v; want %v", ready, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatewayAPIProxyIdentity(t *testing.T) {
+	scheme := gatewayAPIScheme(t)
+	server := gatewayAPIServer()
+	server.Spec.Routing.ParentRef = &mcpv1alpha1.GatewayParentRef{Name: "public", Namespace: "gateway-system"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if got := r.trustedProxySPIFFEID(server); got != "" {
+		t.Fatalf("trustedProxySPIFFEID without a proxy service account = %q, want empty", got)
+	}
+	r.GatewayAPIProxyServiceAccount = "envoy"
+	if got := r.trustedProxySPIFFEID(server); got != "spiffe://example.org/ns/gateway-system/sa/envoy" {
+		t.Fatalf("trustedProxySPIFFEID = %q", got)
+	}
+
+	if err := r.reconcileMTLSNetworkPolicy(ctx, server); err != nil {
+		t.Fatalf("reconcile NetworkPolicy: %v", err)
+	}
+	var np networkingv1.NetworkPolicy
+	if err := c.Get(ctx, types.NamespacedName{Name: mtlsNetworkPolicyName(server), Namespace: server.Namespace}, &np); err != nil {
+		t.Fatalf("get NetworkPolicy: %v", err)
+	}
+	peer := np.Spec.Ingress[0].From[0]
+	if peer.PodSelector != nil || peer.NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"] != "gateway-system" {
+		t.Fatalf("gateway port peer = %#v, want the Gateway namespace", peer)
+	}
+
+	// The Gateway presents its own client certificate; no Traefik one is issued.
+	r.MTLSClusterIssuer = "identity-ca"
+	if err := r.reconcileTraefikClientCertificate(ctx, server); err != nil {
+		t.Fatalf("reconcileTraefikClientCertificate: %v", err)
+	}
+	cert := crFixture(certificateGVK, traefikClientCertSecretName(server), server.Namespace)
+	if err := c.Get(ctx, types.NamespacedName{Name: cert.GetName(), Namespace: server.Namespace}, cert); !apierrors.IsNotFound(err) {
+		t.Fatalf("Traefik client Certificate should not exist in gateway-api mode, got %v", err)
+	}
+}
+
+func TestReconcileBackendTLSCABundle(t *testing.T) {
+	scheme := gatewayAPIScheme(t)
+	server := gatewayAPIServer()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: mtlsTrustBundleSecretName(server), Namespace: server.Namespace}
+
+	if err := r.reconcileBackendTLSCABundle(ctx, server, []byte("CA")); err != nil {
+		t.Fatalf("publish CA bundle: %v", err)
+	}
+	var configMap corev1.ConfigMap
+	if err := c.Get(ctx, key, &configMap); err != nil || configMap.Data["ca.crt"] != "CA" {
+		t.Fatalf("CA ConfigMap = %#v, %v", configMap.Data, err)
+	}
+
+	server.Spec.Routing = nil
+	if err := r.reconcileBackendTLSCABundle(ctx, server, []byte("CA")); err != nil {
+		t.Fatalf("remove CA bundle: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("CA ConfigMap should be deleted in ingress mode, got %v", err)
+	}
+}
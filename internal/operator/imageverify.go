@@ -0,0 +1,648 @@
+package operator
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureType       = "cosign container image signature"
+	registryMaxBlobBytes      = 1 << 20
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ImageRegistry reads manifest digests and cosign signatures from an OCI
+// registry.
+type ImageRegistry interface {
+	// Digest resolves an image reference to its manifest digest.
+	Digest(ctx context.Context, image string, auth RegistryAuth) (string, error)
+	// Signatures returns the cosign signatures stored for digest in the
+	// image's repository. No signatures is not an error.
+	Signatures(ctx context.Context, image, digest string, auth RegistryAuth) ([]ImageSignature, error)
+}
+
+// RegistryAuth maps a registry host to the credentials for it.
+type RegistryAuth map[string]RegistryCredential
+
+// RegistryCredential is a username and password for a registry.
+type RegistryCredential struct {
+	Username string
+	Password string
+}
+
+// ImageSignature is one cosign signature: the simple-signing payload and the
+// raw signature over it.
+type ImageSignature struct {
+	Payload   []byte
+	Signature []byte
+}
+
+// OCIRegistry implements ImageRegistry over the OCI distribution API, with
+// the bearer token and basic auth flows registries use.
+type OCIRegistry struct {
+	// Client defaults to a client with a 30s timeout.
+	Client *http.Client
+	// InsecureHosts are registry hosts reached over plain HTTP, such as the
+	// in-cluster registry.
+	InsecureHosts []string
+	// Endpoints maps a registry host used in image references to the host
+	// the operator reaches it at, e.g. the node pull host of the in-cluster
+	// registry to its Service.
+	Endpoints map[string]string
+}
+
+var defaultRegistryClient = &http.Client{Timeout: 30 * time.Second}
+
+// imageReference is an image split into the parts the distribution API uses.
+type imageReference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+func parseImageReference(image string) (imageReference, error) {
+	image = strings.TrimSpace(image)
+	var ref imageReference
+	if name, digest, found := strings.Cut(image, "@"); found {
+		image, ref.digest = name, digest
+	}
+	if lastColon := strings.LastIndex(image, ":"); lastColon > strings.LastIndex(image, "/") {
+		image, ref.tag = image[:lastColon], image[lastColon+1:]
+	}
+	if image == "" {
+		return imageReference{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	ref.registry, ref.repository = "docker.io", image
+	if first, rest, found := strings.Cut(image, "/"); found && (strings.Contains(first, ".") || strings.Contains(first, ":") || first == "localhost") {
+		ref.registry, ref.repository = first, rest
+	}
+	if ref.registry == "docker.io" && !strings.Contains(ref.repository, "/") {
+		ref.repository = "library/" + ref.repository
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = "latest"
+	}
+	return ref, nil
+}
+
+// pinnedImage replaces any tag or digest of image with digest.
+func pinnedImage(image, digest string) string {
+	image, _, _ = strings.Cut(strings.TrimSpace(image), "@")
+	if lastColon := strings.LastIndex(image, ":"); lastColon > strings.LastIndex(image, "/") {
+		image = image[:lastColon]
+	}
+	return image + "@" + digest
+}
+
+// Digest implements ImageRegistry. A reference that already carries a digest
+// is returned as is.
+func (o *OCIRegistry) Digest(ctx context.Context, image string, auth RegistryAuth) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.digest != "" {
+		return ref.digest, nil
+	}
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		resp, err := o.get(ctx, ref, method, "/manifests/"+ref.tag, manifestMediaTypes, auth)
+		if err != nil {
+			return "", err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, registryMaxBlobBytes))
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("manifest %s:%s: HTTP %d", ref.repository, ref.tag, resp.StatusCode)
+		}
+		if digest := strings.TrimSpace(resp.Header.Get("Docker-Content-Digest")); digest != "" {
+			return digest, nil
+		}
+		if method == http.MethodGet {
+			sum := sha256.Sum256(body)
+			return "sha256:" + hex.EncodeToString(sum[:]), nil
+		}
+	}
+	return "", errors.New("registry returned no manifest digest")
+}
+
+// Signatures implements ImageRegistry. cosign stores the signatures of
+// sha256:<hex> as layers of the manifest tagged sha256-<hex>.sig.
+func (o *OCIRegistry) Signatures(ctx context.Context, image, digest string, auth RegistryAuth) ([]ImageSignature, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, hexDigest, found := strings.Cut(digest, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid digest %q", digest)
+	}
+	resp, err := o.get(ctx, ref, http.MethodGet, "/manifests/"+algorithm+"-"+hexDigest+".sig", manifestMediaTypes[1:2], auth)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signature manifest: HTTP %d", resp.StatusCode)
+	}
+	var manifest struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, registryMaxBlobBytes)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("decode signature manifest: %w", err)
+	}
+
+	var signatures []ImageSignature
+	for _, layer := range manifest.Layers {
+		encoded := layer.Annotations[cosignSignatureAnnotation]
+		if encoded == "" {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := o.blob(ctx, ref, layer.Digest, auth)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, ImageSignature{Payload: payload, Signature: signature})
+	}
+	return signatures, nil
+}
+
+// blob downloads a blob and checks it against its sha256 digest.
+func (o *OCIRegistry) blob(ctx context.Context, ref imageReference, digest string, auth RegistryAuth) ([]byte, error) {
+	resp, err := o.get(ctx, ref, http.MethodGet, "/blobs/"+digest, nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("blob %s: HTTP %d", digest, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, registryMaxBlobBytes))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	if "sha256:"+hex.EncodeToString(sum[:]) != digest {
+		return nil, fmt.Errorf("blob %s does not match its digest", digest)
+	}
+	return body, nil
+}
+
+// get sends a request for /v2/<repository><path>, answering a 401 challenge
+// once with basic auth or a bearer token obtained with the credentials.
+func (o *OCIRegistry) get(ctx context.Context, ref imageReference, method, path string, accept []string, auth RegistryAuth) (*http.Response, error) {
+	host := ref.registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	if endpoint := o.Endpoints[host]; endpoint != "" {
+		host = endpoint
+	}
+	scheme := "https"
+	if slices.Contains(o.InsecureHosts, ref.registry) || slices.Contains(o.InsecureHosts, host) {
+		scheme = "http"
+	}
+	endpoint := scheme + "://" + host + "/v2/" + ref.repository + path
+	credential, hasCredential := auth.lookup(ref.registry)
+
+	send := func(authorization string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return o.client().Do(req)
+	}
+	resp, err := send("")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	scheme, params := parseAuthChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if !hasCredential {
+			return nil, fmt.Errorf("registry %s requires credentials; add an image pull secret", ref.registry)
+		}
+		return send("Basic " + base64.StdEncoding.EncodeToString([]byte(credential.Username+":"+credential.Password)))
+	case "bearer":
+		token, err := o.token(ctx, params, ref, credential, hasCredential)
+		if err != nil {
+			return nil, err
+		}
+		return send("Bearer " + token)
+	}
+	return nil, fmt.Errorf("registry %s: unsupported auth challenge %q", ref.registry, challenge)
+}
+
+func (o *OCIRegistry) token(ctx context.Context, params map[string]string, ref imageReference, credential RegistryCredential, hasCredential bool) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry %s: bearer challenge without realm", ref.registry)
+	}
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasCredential {
+		req.SetBasicAuth(credential.Username, credential.Password)
+	}
+	resp, err := o.client().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s token: HTTP %d", ref.registry, resp.StatusCode)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, registryMaxBlobBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("registry %s token: %w", ref.registry, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+func (o *OCIRegistry) client() *http.Client {
+	if o.Client != nil {
+		return o.Client
+	}
+	return defaultRegistryClient
+}
+
+var authChallengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// parseAuthChallenge splits a WWW-Authenticate header into its lowercased
+// scheme and quoted parameters.
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for _, match := range authChallengeParam.FindAllStringSubmatch(rest, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	return strings.ToLower(scheme), params
+}
+
+// lookup finds the credential for a registry host.
+func (a RegistryAuth) lookup(registry string) (RegistryCredential, bool) {
+	credential, ok := a[registry]
+	return credential, ok
+}
+
+// addDockerConfig adds the credentials of a kubernetes.io/dockerconfigjson
+// or kubernetes.io/dockercfg Secret.
+func (a RegistryAuth) addDockerConfig(secret *corev1.Secret) {
+	var auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+		var config struct {
+			Auths json.RawMessage `json:"auths"`
+		}
+		if json.Unmarshal(data, &config) != nil || json.Unmarshal(config.Auths, &auths) != nil {
+			return
+		}
+	} else if json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths) != nil {
+		return
+	}
+	for host, entry := range auths {
+		credential := RegistryCredential{Username: entry.Username, Password: entry.Password}
+		if decoded, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil && credential.Username == "" {
+			credential.Username, credential.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		host, _, _ = strings.Cut(strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://"), "/")
+		if host == "index.docker.io" || host == "registry-1.docker.io" {
+			host = "docker.io"
+		}
+		a[host] = credential
+	}
+}
+
+// imagePinned reports whether the server image must run by digest: when
+// spec.imageVerification asks for it, or when the server declares tools with
+// write or destructive side effects, which may not run from a mutable tag.
+func imagePinned(mcpServer *mcpv1alpha1.MCPServer) bool {
+	if verification := mcpServer.Spec.ImageVerification; verification != nil && (verification.PinDigest || verification.RequireSignature) {
+		return true
+	}
+	for _, tool := range mcpServer.Spec.Tools {
+		if tool.SideEffect == mcpv1alpha1.ToolSideEffectWrite || tool.SideEffect == mcpv1alpha1.ToolSideEffectDestructive {
+			return true
+		}
+	}
+	return false
+}
+
+func signatureRequired(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.ImageVerification != nil && mcpServer.Spec.ImageVerification.RequireSignature
+}
+
+// reconcileImageVerification resolves the server image to a digest and, when
+// required, verifies its signature before any workload is rolled out. The
+// result is recorded in status.image, which resolveImage reads, and in the
+// ImageVerified condition. A failure stops the reconcile, so running pods keep
+// their previous digest. The tag is resolved again only when the image
+// reference or the spec generation changes.
+func (r *MCPServerReconciler) reconcileImageVerification(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	if !imagePinned(mcpServer) {
+		if mcpServer.Status.Image == nil && meta.FindStatusCondition(mcpServer.Status.Conditions, string(operatorutil.ImageVerified)) == nil {
+			return nil
+		}
+		mcpServer.Status.Image = nil
+		return r.writeImageStatus(ctx, mcpServer, nil, "", "")
+	}
+
+	reference := r.requestedImage(ctx, mcpServer)
+	if current := mcpServer.Status.Image; current != nil && current.Reference == reference && current.Digest != "" &&
+		current.ObservedGeneration == mcpServer.Generation && (current.Verified || !signatureRequired(mcpServer)) {
+		return nil
+	}
+
+	digest, reason, err := r.verifyImage(ctx, mcpServer, reference)
+	if err != nil {
+		if reason == "" {
+			return err
+		}
+		return r.failImageVerification(ctx, mcpServer, reason, err)
+	}
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	resolvedAt := metav1.NewTime(now)
+	image := &mcpv1alpha1.ImageStatus{
+		Reference:          reference,
+		Digest:             digest,
+		ResolvedAt:         &resolvedAt,
+		ObservedGeneration: mcpServer.Generation,
+	}
+	message := fmt.Sprintf("%s pinned to %s", reference, digest)
+	if reason == "SignatureVerified" {
+		image.Verified = true
+		message = fmt.Sprintf("%s pinned to %s and signed by a key in Secret %s", reference, digest, r.ImageVerificationKeys)
+	}
+
+	previous := mcpServer.Status.Image
+	mcpServer.Status.Image = image
+	if err := r.writeImageStatus(ctx, mcpServer, image, reason, message); err != nil {
+		return err
+	}
+	if previous == nil || previous.Digest != digest {
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonImagePinned, "ResolveImage", "%s", message)
+	}
+	return nil
+}
+
+// verifyImage resolves reference to a manifest digest and, when the server
+// requires a signature, verifies it. On success the reason is DigestPinned or
+// SignatureVerified; on failure it names the ImageVerified condition reason,
+// or is empty for errors that are retried without a status change.
+func (r *MCPServerReconciler) verifyImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, reference string) (string, string, error) {
+	if verification := mcpServer.Spec.ImageVerification; verification != nil && strings.TrimSpace(verification.PublicKeysSecret) != "" {
+		// The server's namespace is writable by the team the signature
+		// requirement constrains, so keys are only trusted from the operator.
+		return "", "PublicKeysSecretNotAllowed", fmt.Errorf("spec.imageVerification.publicKeysSecret is not supported; signatures are verified against the operator's key Secret %s", r.ImageVerificationKeys)
+	}
+	auth, err := r.registryAuth(ctx, mcpServer)
+	if err != nil {
+		return "", "", err
+	}
+	registry := r.Registry
+	if registry == nil {
+		registry = &OCIRegistry{}
+	}
+	digest, err := registry.Digest(ctx, reference, auth)
+	if err != nil {
+		return "", "DigestUnresolved", fmt.Errorf("resolve digest of %s: %w", reference, err)
+	}
+	if !signatureRequired(mcpServer) {
+		return digest, "DigestPinned", nil
+	}
+	if err := r.verifyImageSignature(ctx, registry, reference, digest, auth); err != nil {
+		return "", "SignatureInvalid", err
+	}
+	return digest, "SignatureVerified", nil
+}
+
+// verifyImageSignature requires a cosign signature over digest from one of
+// the public keys in the operator's ImageVerificationKeys Secret.
+func (r *MCPServerReconciler) verifyImageSignature(ctx context.Context, registry ImageRegistry, reference, digest string, auth RegistryAuth) error {
+	secretName := r.ImageVerificationKeys
+	if secretName.Name == "" {
+		return errors.New("the operator has no image verification key Secret configured")
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("public key Secret %s not found", secretName)
+		}
+		return err
+	}
+	keys, err := parsePublicKeys(secret.Data)
+	if err != nil {
+		return fmt.Errorf("public key Secret %s: %w", secretName, err)
+	}
+	signatures, err := registry.Signatures(ctx, reference, digest, auth)
+	if err != nil {
+		return fmt.Errorf("fetch signatures of %s: %w", reference, err)
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("%s@%s is not signed", reference, digest)
+	}
+	if !verifySignatures(signatures, digest, keys) {
+		return fmt.Errorf("%s@%s has no valid signature from a key in Secret %s", reference, digest, secretName)
+	}
+	return nil
+}
+
+func (r *MCPServerReconciler) failImageVerification(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, reason string, err error) error {
+	if writeErr := r.writeImageStatus(ctx, mcpServer, mcpServer.Status.Image, reason, err.Error()); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// writeImageStatus records status.image and the ImageVerified condition; an
+// empty reason removes the condition.
+func (r *MCPServerReconciler) writeImageStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, image *mcpv1alpha1.ImageStatus, reason, message string) error {
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mcpServer), latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	status := latest.Status.DeepCopy()
+	status.Image = image
+	if reason == "" {
+		meta.RemoveStatusCondition(&status.Conditions, string(operatorutil.ImageVerified))
+	} else {
+		verified := reason == "DigestPinned" || reason == "SignatureVerified"
+		operatorutil.SetCondition(&status.Conditions, operatorutil.ImageVerified, verified, reason, message, latest.Generation)
+	}
+	if equality.Semantic.DeepEqual(latest.Status, *status) {
+		return nil
+	}
+	latest.Status = *status
+	return r.Status().Update(ctx, latest)
+}
+
+// registryAuth collects the credentials of the server's image pull secrets
+// and of the provisioned registry.
+func (r *MCPServerReconciler) registryAuth(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (RegistryAuth, error) {
+	auth := RegistryAuth{}
+	if registry := r.ProvisionedRegistry; registry != nil && registry.URL != "" && registry.Username != "" {
+		host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry.URL, "https://"), "http://"), "/")
+		auth[host] = RegistryCredential{Username: registry.Username, Password: registry.Password}
+	}
+	for _, ref := range r.buildImagePullSecrets(mcpServer) {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: mcpServer.Namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		auth.addDockerConfig(secret)
+	}
+	return auth, nil
+}
+
+// parsePublicKeys reads every PEM public key in the Secret's values.
+func parsePublicKeys(data map[string][]byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, name := range sortedKeys(data) {
+		rest := data[name]
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "PUBLIC KEY" {
+				continue
+			}
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", name, err)
+			}
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM public keys")
+	}
+	return keys, nil
+}
+
+// verifySignatures reports whether one signature is a valid signature by one
+// of keys over a cosign payload for digest.
+func verifySignatures(signatures []ImageSignature, digest string, keys []crypto.PublicKey) bool {
+	for _, signature := range signatures {
+		var payload struct {
+			Critical struct {
+				Type  string `json:"type"`
+				Image struct {
+					DockerManifestDigest string `json:"docker-manifest-digest"`
+				} `json:"image"`
+			} `json:"critical"`
+		}
+		if err := json.Unmarshal(signature.Payload, &payload); err != nil {
+			continue
+		}
+		if payload.Critical.Type != cosignSignatureType || payload.Critical.Image.DockerManifestDigest != digest {
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, signature.Payload, signature.Signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	hashed := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, hashed[:], signature)
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature) == nil {
+			return true
+		}
+		return rsa.VerifyPSS(key, crypto.SHA256, hashed[:], signature, nil) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	}
+	return false
+}
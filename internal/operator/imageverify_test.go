@@ -0,0 +1,336 @@
+package operator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// testRegistry serves team/tools:v1 (signed with key) and team/tools:v2
+// (unsigned) behind the bearer token flow, accepting user:pass.
+func testRegistry(t *testing.T, key *ecdsa.PrivateKey) (*httptest.Server, map[string]string) {
+	t.Helper()
+	digests := map[string]string{}
+	manifests := map[string][]byte{}
+	blobs := map[string][]byte{}
+	for _, tag := range []string{"v1", "v2"} {
+		manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","annotations":{"tag":"` + tag + `"}}`)
+		digests[tag] = sha256Digest(manifest)
+		manifests[tag] = manifest
+	}
+
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"team/tools"},"image":{"docker-manifest-digest":"` + digests["v1"] + `"},"type":"cosign container image signature"},"optional":null}`)
+	hashed := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	blobs[sha256Digest(payload)] = payload
+	signatureManifest, _ := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      sha256Digest(payload),
+			"annotations": map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		}},
+	})
+	manifests["sha256-"+strings.TrimPrefix(digests["v1"], "sha256:")+".sig"] = signatureManifest
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" || req.URL.Query().Get("scope") != "repository:team/tools:pull" {
+				http.Error(w, "denied", http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"registry-token"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer registry-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test-registry",scope="repository:team/tools:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if reference, ok := strings.CutPrefix(req.URL.Path, "/v2/team/tools/manifests/"); ok {
+			manifest, found := manifests[reference]
+			if !found {
+				http.NotFound(w, req)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", sha256Digest(manifest))
+			_, _ = w.Write(manifest)
+			return
+		}
+		if digest, ok := strings.CutPrefix(req.URL.Path, "/v2/team/tools/blobs/"); ok && blobs[digest] != nil {
+			_, _ = w.Write(blobs[digest])
+			return
+		}
+		http.NotFound(w, req)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, digests
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestOCIRegistryDigestAndSignatures(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	srv, digests := testRegistry(t, key)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	registry := &OCIRegistry{InsecureHosts: []string{host}}
+	auth := RegistryAuth{host: {Username: "user", Password: "pass"}}
+	ctx := context.Background()
+	image := host + "/team/tools:v1"
+
+	digest, err := registry.Digest(ctx, image, auth)
+	if err != nil || digest != digests["v1"] {
+		t.Fatalf("Digest = %q, %v; want %s", digest, err, digests["v1"])
+	}
+	if _, err := registry.Digest(ctx, image, nil); err == nil {
+		t.Fatal("expected the token request without credentials to fail")
+	}
+	signatures, err := registry.Signatures(ctx, image, digest, auth)
+	if err != nil || len(signatures) != 1 {
+		t.Fatalf("Signatures = %d, %v", len(signatures), err)
+	}
+	keys, err := parsePublicKeys(map[string][]byte{"cosign.pub": publicKeyPEM(t, key)})
+	if err != nil {
+		t.Fatalf("parsePublicKeys: %v", err)
+	}
+	if !verifySignatures(signatures, digest, keys) {
+		t.Fatal("expected the signature to verify")
+	}
+	if verifySignatures(signatures, digests["v2"], keys) {
+		t.Fatal("a signature over another digest must not verify")
+	}
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKeys, _ := parsePublicKeys(map[string][]byte{"other.pub": publicKeyPEM(t, other)})
+	if verifySignatures(signatures, digest, otherKeys) {
+		t.Fatal("a signature by an untrusted key must not verify")
+	}
+	if unsigned, err := registry.Signatures(ctx, host+"/team/tools:v2", digests["v2"], auth); err != nil || len(unsigned) != 0 {
+		t.Fatalf("unsigned Signatures = %v, %v", unsigned, err)
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	for _, tt := range []struct {
+		image string
+		want  imageReference
+	}{
+		{"nginx", imageReference{registry: "docker.io", repository: "library/nginx", tag: "latest"}},
+		{"team/tools:v1", imageReference{registry: "docker.io", repository: "team/tools", tag: "v1"}},
+		{"localhost:5000/tools", imageReference{registry: "localhost:5000", repository: "tools", tag: "latest"}},
+		{"ghcr.io/org/tools:v1@sha256:abc", imageReference{registry: "ghcr.io", repository: "org/tools", tag: "v1", digest: "sha256:abc"}},
+	} {
+		got, err := parseImageReference(tt.image)
+		if err != nil || got != tt.want {
+			t.Fatalf("parseImageReference(%q) = %#v, %v; want %#v", tt.image, got, err, tt.want)
+		}
+	}
+	if got := pinnedImage("registry:5000/tools:v1@sha256:old", "sha256:new"); got != "registry:5000/tools@sha256:new" {
+		t.Fatalf("pinnedImage = %s", got)
+	}
+}
+
+func TestReconcileImageVerification(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	srv, digests := testRegistry(t, key)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "jira-tools", Namespace: "servers", Generation: 2},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:             host + "/team/tools",
+			ImageTag:          "v1",
+			ImagePullSecrets:  []string{"pull"},
+			ImageVerification: &mcpv1alpha1.ImageVerificationConfig{RequireSignature: true},
+		},
+	}
+	pull := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull", Namespace: "servers"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"auth":"` +
+			base64.StdEncoding.EncodeToString([]byte("user:pass")) + `"}}}`)},
+	}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-verification-keys", Namespace: "mcp-runtime"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM(t, key)},
+	}
+	scheme := newAccessStatusScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, pull, keys).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	recorder := events.NewFakeRecorder(10)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := &MCPServerReconciler{
+		Client: c, Scheme: scheme, Recorder: recorder,
+		Registry:              &OCIRegistry{InsecureHosts: []string{host}},
+		ImageVerificationKeys: client.ObjectKeyFromObject(keys),
+		now:                   func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	if err := r.reconcileImageVerification(ctx, server); err != nil {
+		t.Fatalf("reconcileImageVerification: %v", err)
+	}
+	image, err := r.resolveImage(ctx, server)
+	if err != nil || image != host+"/team/tools@"+digests["v1"] {
+		t.Fatalf("resolveImage = %q, %v", image, err)
+	}
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(server), latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	if status := latest.Status.Image; status == nil || status.Digest != digests["v1"] || !status.Verified || !status.ResolvedAt.Time.Equal(now) || status.ObservedGeneration != 2 {
+		t.Fatalf("image status = %#v", status)
+	}
+	condition := meta.FindStatusCondition(latest.Status.Conditions, string(operatorutil.ImageVerified))
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "SignatureVerified" {
+		t.Fatalf("condition = %#v", condition)
+	}
+	if recorded := drainEvents(recorder); len(recorded) != 1 || !strings.Contains(recorded[0], "Normal ImagePinned") {
+		t.Fatalf("events = %v", recorded)
+	}
+
+	// An unsigned tag fails the gate and leaves the verified digest in place.
+	server.Spec.ImageTag = "v2"
+	server.Generation = 3
+	err = r.reconcileImageVerification(ctx, server)
+	if err == nil || !strings.Contains(err.Error(), "is not signed") {
+		t.Fatalf("reconcileImageVerification unsigned = %v", err)
+	}
+	if _, err := r.resolveImage(ctx, server); err == nil {
+		t.Fatal("expected resolveImage to refuse the unverified tag")
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(server), latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	condition = meta.FindStatusCondition(latest.Status.Conditions, string(operatorutil.ImageVerified))
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "SignatureInvalid" || latest.Status.Image.Digest != digests["v1"] {
+		t.Fatalf("status after unsigned tag = %#v, image %#v", condition, latest.Status.Image)
+	}
+
+	// Keys the server's namespace names are never trusted, even when they
+	// would verify.
+	teamKeys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cosign-keys", Namespace: "servers"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM(t, key)},
+	}
+	if err := c.Create(ctx, teamKeys); err != nil {
+		t.Fatalf("create team keys: %v", err)
+	}
+	server.Spec.ImageTag = "v1"
+	server.Spec.ImageVerification.PublicKeysSecret = "cosign-keys"
+	server.Generation = 4
+	if err := r.reconcileImageVerification(ctx, server); err == nil || !strings.Contains(err.Error(), "publicKeysSecret is not supported") {
+		t.Fatalf("reconcileImageVerification with the server's own keys = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(server), latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	condition = meta.FindStatusCondition(latest.Status.Conditions, string(operatorutil.ImageVerified))
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "PublicKeysSecretNotAllowed" {
+		t.Fatalf("condition with the server's own keys = %#v", condition)
+	}
+
+	// Without an operator key Secret nothing verifies.
+	server.Spec.ImageVerification.PublicKeysSecret = ""
+	r.ImageVerificationKeys = types.NamespacedName{}
+	if err := r.reconcileImageVerification(ctx, server); err == nil || !strings.Contains(err.Error(), "no image verification key Secret") {
+		t.Fatalf("reconcileImageVerification without operator keys = %v", err)
+	}
+}
+
+func TestStableTrackVerifiesRunningImage(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	srv, digests := testRegistry(t, key)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	server := canaryAnalysisServer(mcpv1alpha1.CanaryAnalysisStep{Duration: "5m", MaxErrorRate: "0.05"})
+	server.Status.Rollout = nil
+	server.Spec.ImagePullSecrets = []string{"pull"}
+	server.Spec.ImageVerification = &mcpv1alpha1.ImageVerificationConfig{RequireSignature: true}
+	pull := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull", Namespace: "servers"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"auth":"` +
+			base64.StdEncoding.EncodeToString([]byte("user:pass")) + `"}}}`)},
+	}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-verification-keys", Namespace: "mcp-runtime"},
+		Data:       map[string][]byte{"cosign.pub": publicKeyPEM(t, key)},
+	}
+	desired := host + "/team/tools@sha256:desired"
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name    string
+		running string
+		want    string
+	}{
+		{"signed running image", host + "/team/tools:v1", host + "/team/tools@" + digests["v1"]},
+		{"unsigned running image", host + "/team/tools:v2", desired},
+		{"unsigned running digest", host + "/team/tools@" + digests["v2"], desired},
+	} {
+		stable := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "payments", Image: tt.running}},
+			}}},
+		}
+		r, _, _ := newCanaryTestReconciler(newCanaryScheme(), nil, server.DeepCopy(), pull, keys, stable)
+		r.Registry = &OCIRegistry{InsecureHosts: []string{host}}
+		r.ImageVerificationKeys = client.ObjectKeyFromObject(keys)
+		if got, err := r.stableTrackImage(ctx, server, desired); err != nil || got != tt.want {
+			t.Fatalf("%s: stable image = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestImagePinnedForWriteTools(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Image: "registry:5000/tools", ImageTag: "v1"}}
+	if imagePinned(server) {
+		t.Fatal("a read-only server without imageVerification should run its tag")
+	}
+	server.Spec.Tools = []mcpv1alpha1.ToolConfig{{Name: "delete_issue", SideEffect: mcpv1alpha1.ToolSideEffectDestructive}}
+	if !imagePinned(server) {
+		t.Fatal("a server with destructive tools must be pinned")
+	}
+	r := &MCPServerReconciler{}
+	if _, err := r.resolveImage(context.Background(), server); err == nil {
+		t.Fatal("expected resolveImage to require a resolved digest")
+	}
+}
@@ -23,6 +23,18 @@ func (r *MCPServerReconciler) reconcileIngress(ctx context.Context, mcpServer *m
 			Namespace: mcpServer.Namespace,
 		},
 	}
+	if r.usesGatewayAPI(mcpServer) {
+		if err := r.Delete(ctx, ingress); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := r.deleteMTLSIngress(ctx, mcpServer); err != nil {
+			return err
+		}
+		return r.reconcileHTTPRoute(ctx, mcpServer)
+	}
+	if err := r.deleteHTTPRoute(ctx, mcpServer); err != nil {
+		return err
+	}
 	if serverUsesMTLS(mcpServer) {
 		if err := r.Delete(ctx, ingress); err != nil && !apierrors.IsNotFound(err) {
 			return err
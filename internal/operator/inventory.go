@@ -0,0 +1,580 @@
+package operator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+const (
+	// DefaultInventoryProbeInterval is how often a Ready server is probed
+	// when MCP_INVENTORY_PROBE_INTERVAL is unset.
+	DefaultInventoryProbeInterval = 5 * time.Minute
+
+	inventoryProtocolVersion = "2025-06-18"
+	inventoryMaxBodyBytes    = 2 << 20
+
+	// inventoryAgentID is the agent identity the probe presents. The gateway
+	// does not audit requests from it.
+	inventoryAgentID = "mcp-runtime-live-inventory"
+	inventoryHumanID = "mcp-runtime-operator"
+)
+
+// Inventory is what a server advertised from tools/list, prompts/list, and
+// resources/list.
+type Inventory struct {
+	ProtocolVersion string
+	Tools           []InventoryTool
+	Prompts         []string
+	Resources       []string
+}
+
+// InventoryTool is one tool from tools/list.
+type InventoryTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+}
+
+// InventorySource lists the live inventory of a server.
+type InventorySource interface {
+	Probe(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*Inventory, error)
+}
+
+// MCPInventoryProber speaks MCP streamable HTTP to a server's Service, with
+// header identity so that requests pass a gateway in header or none auth mode.
+type MCPInventoryProber struct {
+	// Client defaults to a client with a 10s timeout.
+	Client *http.Client
+	// BaseURL overrides the http://<name>.<namespace>.svc:<port> Service URL;
+	// tests point it at an httptest server.
+	BaseURL func(mcpServer *mcpv1alpha1.MCPServer) string
+}
+
+var defaultInventoryClient = &http.Client{Timeout: 10 * time.Second}
+
+// MCPInventoryReconciler probes each Ready MCPServer periodically and records
+// its live inventory, and how its tools drift from spec.tools, in
+// status.inventory and the ToolInventoryDrift condition. It never changes the
+// spec; status.inventory.suggestedPatch is for a human to review and apply.
+type MCPInventoryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder emits an Event when drift appears or clears, or a probe fails.
+	// Nil disables events.
+	Recorder events.EventRecorder
+
+	// Source lists the server inventory; nil uses an MCPInventoryProber.
+	Source InventorySource
+
+	// Interval is how long to wait between probes of a Ready server.
+	Interval time.Duration
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+}
+
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers/status,verbs=get;update;patch
+
+// Reconcile probes a Ready server and writes its inventory to status. Servers
+// that are not Ready keep their last inventory until the next probe.
+func (r *MCPInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	mcpServer := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(ctx, req.NamespacedName, mcpServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mcpServer.DeletionTimestamp.IsZero() || mcpServer.Status.Phase != "Ready" {
+		return ctrl.Result{}, nil
+	}
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultInventoryProbeInterval
+	}
+
+	if reason := inventoryProbeUnsupported(mcpServer); reason != "" {
+		return ctrl.Result{}, r.writeInventoryStatus(ctx, mcpServer, nil, false, "ProbeUnsupported", reason)
+	}
+
+	source := r.Source
+	if source == nil {
+		source = &MCPInventoryProber{}
+	}
+	inventory, err := source.Probe(ctx, mcpServer)
+	if err != nil {
+		logger.Info("Inventory probe failed", "error", err.Error())
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeWarning, EventReasonInventoryProbeFailed, "ProbeInventory", "Listing the live inventory failed: %v", err)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	observed := buildInventoryStatus(mcpServer, inventory, metav1.NewTime(r.currentTime()))
+	drift := len(observed.UndeclaredTools) > 0 || len(observed.MissingTools) > 0
+	reason, message := "InventoryMatches", "the served tools match spec.tools"
+	if drift {
+		reason, message = "InventoryDrifted", inventoryDriftMessage(observed)
+	}
+	previous := meta.FindStatusCondition(mcpServer.Status.Conditions, string(operatorutil.ToolInventoryDrift))
+	if err := r.writeInventoryStatus(ctx, mcpServer, observed, drift, reason, message); err != nil {
+		return ctrl.Result{}, err
+	}
+	wasDrifted := previous != nil && previous.Status == metav1.ConditionTrue
+	switch {
+	case drift && (!wasDrifted || previous.Message != message):
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeWarning, EventReasonToolInventoryDrift, "ProbeInventory", "%s", message)
+	case !drift && wasDrifted:
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonToolInventoryDrift, "ProbeInventory", "%s", message)
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// writeInventoryStatus writes the inventory and the ToolInventoryDrift
+// condition onto the latest server, leaving the fields MCPServerReconciler
+// owns untouched.
+func (r *MCPInventoryReconciler) writeInventoryStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, inventory *mcpv1alpha1.InventoryStatus, drift bool, reason, message string) error {
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mcpServer), latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	status := latest.Status.DeepCopy()
+	status.Inventory = inventory
+	operatorutil.SetCondition(&status.Conditions, operatorutil.ToolInventoryDrift, drift, reason, message, latest.Generation)
+	if equality.Semantic.DeepEqual(latest.Status, *status) {
+		return nil
+	}
+	latest.Status = *status
+	if err := r.Status().Update(ctx, latest); err != nil {
+		return ignoreStatusConflict(ctx, err)
+	}
+	return nil
+}
+
+func (r *MCPInventoryReconciler) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// inventoryProbeUnsupported explains why the operator cannot list a server's
+// inventory through its Service, or returns "" when it can.
+func inventoryProbeUnsupported(mcpServer *mcpv1alpha1.MCPServer) string {
+	switch {
+	case serverUsesMTLS(mcpServer):
+		return "the gateway requires a client certificate the operator does not hold"
+	case serverUsesOAuth(mcpServer):
+		return "the gateway requires an OAuth token the operator does not hold"
+	case mcpServer.Spec.Policy != nil && mcpServer.Spec.Policy.FilterListResponses:
+		return "the gateway filters list responses by caller, so the operator sees no tools"
+	}
+	return ""
+}
+
+func inventoryDriftMessage(inventory *mcpv1alpha1.InventoryStatus) string {
+	var parts []string
+	if len(inventory.UndeclaredTools) > 0 {
+		parts = append(parts, "served but not declared (denied as tool_side_effect_unknown): "+strings.Join(inventory.UndeclaredTools, ", "))
+	}
+	if len(inventory.MissingTools) > 0 {
+		parts = append(parts, "declared but not served: "+strings.Join(inventory.MissingTools, ", "))
+	}
+	return "tools " + strings.Join(parts, "; tools ") + "; status.inventory.suggestedPatch reconciles spec.tools"
+}
+
+// buildInventoryStatus compares the live inventory with spec.tools.
+func buildInventoryStatus(mcpServer *mcpv1alpha1.MCPServer, inventory *Inventory, probedAt metav1.Time) *mcpv1alpha1.InventoryStatus {
+	status := &mcpv1alpha1.InventoryStatus{
+		ProbedAt:        &probedAt,
+		ProtocolVersion: inventory.ProtocolVersion,
+		Prompts:         sortedUnique(inventory.Prompts),
+		Resources:       sortedUnique(inventory.Resources),
+	}
+	served := map[string]InventoryTool{}
+	for _, tool := range inventory.Tools {
+		if tool.Name == "" {
+			continue
+		}
+		served[tool.Name] = tool
+	}
+	for _, name := range sortedKeys(served) {
+		status.Tools = append(status.Tools, mcpv1alpha1.DiscoveredTool{
+			Name:            name,
+			InputSchemaHash: inputSchemaHash(served[name].InputSchema),
+		})
+	}
+
+	declared := map[string]bool{}
+	var missing []int
+	for i, tool := range mcpServer.Spec.Tools {
+		declared[tool.Name] = true
+		if _, ok := served[tool.Name]; !ok {
+			status.MissingTools = append(status.MissingTools, tool.Name)
+			missing = append(missing, i)
+		}
+	}
+	var undeclared []InventoryTool
+	for _, tool := range status.Tools {
+		if !declared[tool.Name] {
+			status.UndeclaredTools = append(status.UndeclaredTools, tool.Name)
+			undeclared = append(undeclared, served[tool.Name])
+		}
+	}
+	slices.Sort(status.MissingTools)
+	status.SuggestedPatch = suggestedToolsPatch(len(mcpServer.Spec.Tools), missing, undeclared)
+	return status
+}
+
+// suggestedToolsPatch removes the missing tools (by index, last first) and
+// adds the undeclared ones as destructive, the side effect the gateway treats
+// most strictly, for a reviewer to downgrade.
+func suggestedToolsPatch(declared int, missing []int, undeclared []InventoryTool) string {
+	type operation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value,omitempty"`
+	}
+	var ops []operation
+	for i := len(missing) - 1; i >= 0; i-- {
+		ops = append(ops, operation{Op: "remove", Path: "/spec/tools/" + strconv.Itoa(missing[i])})
+	}
+	var added []mcpv1alpha1.ToolConfig
+	for _, tool := range undeclared {
+		added = append(added, mcpv1alpha1.ToolConfig{
+			Name:        tool.Name,
+			Description: strings.TrimSpace(tool.Description),
+			SideEffect:  mcpv1alpha1.ToolSideEffectDestructive,
+		})
+	}
+	if declared == 0 && len(added) > 0 {
+		ops = append(ops, operation{Op: "add", Path: "/spec/tools", Value: added})
+	} else {
+		for _, tool := range added {
+			ops = append(ops, operation{Op: "add", Path: "/spec/tools/-", Value: tool})
+		}
+	}
+	if len(ops) == 0 {
+		return ""
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return ""
+	}
+	return string(patch)
+}
+
+// inputSchemaHash digests the schema as canonical JSON (sorted keys, no
+// insignificant whitespace) so that formatting changes do not register.
+func inputSchemaHash(schema json.RawMessage) string {
+	if len(bytes.TrimSpace(schema)) == 0 {
+		return ""
+	}
+	var value any
+	if err := json.Unmarshal(schema, &value); err != nil {
+		return ""
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(canonical)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func sortedUnique(values []string) []string {
+	var out []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, value)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Probe implements InventorySource: initialize, then list the tools, prompts,
+// and resources the server declared capabilities for.
+func (p *MCPInventoryProber) Probe(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*Inventory, error) {
+	endpoint := p.endpoint(mcpServer)
+	session := &inventorySession{prober: p, endpoint: endpoint, protocol: inventoryProtocolVersion, identity: inventoryIdentityHeaders(mcpServer)}
+
+	initResult, err := session.call(ctx, 1, "initialize", map[string]any{
+		"protocolVersion": inventoryProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]string{"name": "mcp-runtime-operator", "version": "inventory"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	var initialized struct {
+		ProtocolVersion string                     `json:"protocolVersion"`
+		Capabilities    map[string]json.RawMessage `json:"capabilities"`
+	}
+	if err := json.Unmarshal(initResult, &initialized); err != nil {
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+	if version := strings.TrimSpace(initialized.ProtocolVersion); version != "" {
+		session.protocol = version
+	}
+	_, _ = session.rpc(ctx, nil, "notifications/initialized", map[string]any{})
+
+	inventory := &Inventory{ProtocolVersion: session.protocol}
+	if inventoryCapability(initialized.Capabilities, "tools") {
+		raw, err := session.call(ctx, 2, "tools/list", nil)
+		if err != nil {
+			return nil, fmt.Errorf("tools/list: %w", err)
+		}
+		var result struct {
+			Tools []InventoryTool `json:"tools"`
+		}
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, fmt.Errorf("tools/list: %w", err)
+		}
+		inventory.Tools = result.Tools
+	}
+	if inventoryCapability(initialized.Capabilities, "prompts") {
+		raw, err := session.call(ctx, 3, "prompts/list", nil)
+		if err != nil {
+			return nil, fmt.Errorf("prompts/list: %w", err)
+		}
+		var result struct {
+			Prompts []struct {
+				Name string `json:"name"`
+			} `json:"prompts"`
+		}
+		_ = json.Unmarshal(raw, &result)
+		for _, prompt := range result.Prompts {
+			inventory.Prompts = append(inventory.Prompts, prompt.Name)
+		}
+	}
+	if inventoryCapability(initialized.Capabilities, "resources") {
+		raw, err := session.call(ctx, 4, "resources/list", nil)
+		if err != nil {
+			return nil, fmt.Errorf("resources/list: %w", err)
+		}
+		var result struct {
+			Resources []struct {
+				URI string `json:"uri"`
+			} `json:"resources"`
+		}
+		_ = json.Unmarshal(raw, &result)
+		for _, resource := range result.Resources {
+			inventory.Resources = append(inventory.Resources, resource.URI)
+		}
+	}
+	return inventory, nil
+}
+
+func (p *MCPInventoryProber) endpoint(mcpServer *mcpv1alpha1.MCPServer) string {
+	base := ""
+	if p.BaseURL != nil {
+		base = strings.TrimRight(p.BaseURL(mcpServer), "/")
+	}
+	if base == "" {
+		port := mcpServer.Spec.ServicePort
+		if port == 0 {
+			port = 80
+		}
+		base = "http://" + mcpServer.Name + "." + mcpServer.Namespace + ".svc:" + strconv.Itoa(int(port))
+	}
+	return base + normalizeIngressPath(effectiveIngressPath(mcpServer))
+}
+
+// inventoryIdentityHeaders identifies the probe to a gateway in header mode,
+// using the server's configured header names.
+func inventoryIdentityHeaders(mcpServer *mcpv1alpha1.MCPServer) http.Header {
+	humanHeader, agentHeader := "X-MCP-Human-ID", "X-MCP-Agent-ID"
+	if auth := mcpServer.Spec.Auth; auth != nil {
+		if value := strings.TrimSpace(auth.HumanIDHeader); value != "" {
+			humanHeader = value
+		}
+		if value := strings.TrimSpace(auth.AgentIDHeader); value != "" {
+			agentHeader = value
+		}
+	}
+	headers := http.Header{}
+	headers.Set(humanHeader, inventoryHumanID)
+	headers.Set(agentHeader, inventoryAgentID)
+	return headers
+}
+
+func inventoryCapability(capabilities map[string]json.RawMessage, name string) bool {
+	raw := bytes.TrimSpace(capabilities[name])
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
+
+// inventorySession carries the negotiated protocol version and session ID
+// between the requests of one probe.
+type inventorySession struct {
+	prober    *MCPInventoryProber
+	endpoint  string
+	protocol  string
+	sessionID string
+	identity  http.Header
+}
+
+func (s *inventorySession) call(ctx context.Context, id int, method string, params any) (json.RawMessage, error) {
+	return s.rpc(ctx, json.RawMessage(strconv.Itoa(id)), method, params)
+}
+
+func (s *inventorySession) rpc(ctx context.Context, id json.RawMessage, method string, params any) (json.RawMessage, error) {
+	payload := map[string]any{"jsonrpc": "2.0", "method": method}
+	if id != nil {
+		payload["id"] = id
+	}
+	if params != nil {
+		payload["params"] = params
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range s.identity {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set("Mcp-Protocol-Version", s.protocol)
+	if s.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", s.sessionID)
+	}
+
+	httpClient := s.prober.Client
+	if httpClient == nil {
+		httpClient = defaultInventoryClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		s.sessionID = sessionID
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if id == nil {
+		return nil, nil
+	}
+	responseBody, err := readInventoryResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(responseBody, &envelope); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if envelope.Error != nil {
+		return nil, fmt.Errorf("JSON-RPC %d: %s", envelope.Error.Code, envelope.Error.Message)
+	}
+	return envelope.Result, nil
+}
+
+// readInventoryResponse returns the JSON body, or the first event's data
+// when the server answers with an event stream.
+func readInventoryResponse(resp *http.Response) ([]byte, error) {
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/event-stream") {
+		payload, err := io.ReadAll(io.LimitReader(resp.Body, inventoryMaxBodyBytes+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) > inventoryMaxBodyBytes {
+			return nil, errors.New("response too large")
+		}
+		return payload, nil
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), inventoryMaxBodyBytes)
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			if data.Len() > 0 {
+				break
+			}
+			continue
+		}
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.Write(bytes.TrimSpace(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// inventoryProbeTriggers starts a probe when a server is created, its spec
+// changes, or its phase changes (such as becoming Ready). The controller's
+// own status writes change neither, so they do not trigger another probe.
+func inventoryProbeTriggers() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldServer, ok := e.ObjectOld.(*mcpv1alpha1.MCPServer)
+			newServer, ok2 := e.ObjectNew.(*mcpv1alpha1.MCPServer)
+			if !ok || !ok2 {
+				return false
+			}
+			return oldServer.Generation != newServer.Generation || oldServer.Status.Phase != newServer.Status.Phase
+		},
+		DeleteFunc: func(event.DeleteEvent) bool { return false },
+	}
+}
+
+func (r *MCPInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcpserver-inventory").
+		For(&mcpv1alpha1.MCPServer{}, builder.WithPredicates(inventoryProbeTriggers())).
+		Complete(r)
+}
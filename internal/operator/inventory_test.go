@@ -0,0 +1,232 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
+)
+
+type fakeInventorySource struct {
+	inventory *Inventory
+	err       error
+	probes    int
+}
+
+func (f *fakeInventorySource) Probe(context.Context, *mcpv1alpha1.MCPServer) (*Inventory, error) {
+	f.probes++
+	return f.inventory, f.err
+}
+
+func inventoryServer() *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "jira-tools", Namespace: "servers", Generation: 3},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			ServicePort: 80,
+			IngressPath: "/jira/mcp",
+			Tools: []mcpv1alpha1.ToolConfig{
+				{Name: "search_issues", SideEffect: mcpv1alpha1.ToolSideEffectRead},
+				{Name: "delete_issue", SideEffect: mcpv1alpha1.ToolSideEffectDestructive},
+			},
+		},
+		Status: mcpv1alpha1.MCPServerStatus{Phase: "Ready"},
+	}
+}
+
+func TestMCPInventoryReconcilerRecordsDrift(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	server := inventoryServer()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	source := &fakeInventorySource{inventory: &Inventory{
+		ProtocolVersion: "2025-06-18",
+		Tools: []InventoryTool{
+			{Name: "search_issues", InputSchema: json.RawMessage(`{"type":"object"}`)},
+			{Name: "create_issue", Description: "Create an issue"},
+		},
+		Prompts:   []string{"triage"},
+		Resources: []string{"jira://projects"},
+	}}
+	recorder := events.NewFakeRecorder(10)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := &MCPInventoryReconciler{Client: c, Scheme: scheme, Recorder: recorder, Source: source, Interval: time.Minute, now: func() time.Time { return now }}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name, Namespace: server.Namespace}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil || result.RequeueAfter != time.Minute {
+		t.Fatalf("Reconcile = %v, %v; want a requeue after the interval", result, err)
+	}
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, req.NamespacedName, latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	inventory := latest.Status.Inventory
+	if inventory == nil || !inventory.ProbedAt.Time.Equal(now) || len(inventory.Tools) != 2 || inventory.Tools[0].Name != "create_issue" {
+		t.Fatalf("inventory = %#v", inventory)
+	}
+	if !strings.HasPrefix(inventory.Tools[1].InputSchemaHash, "sha256:") || inventory.Tools[0].InputSchemaHash != "" {
+		t.Fatalf("schema hashes = %#v", inventory.Tools)
+	}
+	if strings.Join(inventory.UndeclaredTools, ",") != "create_issue" || strings.Join(inventory.MissingTools, ",") != "delete_issue" {
+		t.Fatalf("undeclared = %v, missing = %v", inventory.UndeclaredTools, inventory.MissingTools)
+	}
+	var patch []map[string]any
+	if err := json.Unmarshal([]byte(inventory.SuggestedPatch), &patch); err != nil {
+		t.Fatalf("suggested patch %q: %v", inventory.SuggestedPatch, err)
+	}
+	if len(patch) != 2 || patch[0]["op"] != "remove" || patch[0]["path"] != "/spec/tools/1" || patch[1]["path"] != "/spec/tools/-" {
+		t.Fatalf("suggested patch = %v", patch)
+	}
+	if added := patch[1]["value"].(map[string]any); added["name"] != "create_issue" || added["sideEffect"] != "destructive" {
+		t.Fatalf("added tool = %v", added)
+	}
+	condition := meta.FindStatusCondition(latest.Status.Conditions, string(operatorutil.ToolInventoryDrift))
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "InventoryDrifted" || condition.ObservedGeneration != 3 {
+		t.Fatalf("condition = %#v", condition)
+	}
+	if recorded := drainEvents(recorder); len(recorded) != 1 || !strings.Contains(recorded[0], "Warning ToolInventoryDrift") {
+		t.Fatalf("events = %v", recorded)
+	}
+
+	// A second probe with the same drift records no new event; serving the
+	// declared tools clears the condition.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile again: %v", err)
+	}
+	if recorded := drainEvents(recorder); len(recorded) != 0 {
+		t.Fatalf("repeated drift events = %v", recorded)
+	}
+	source.inventory.Tools = []InventoryTool{{Name: "search_issues"}, {Name: "delete_issue"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after fix: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	if meta.IsStatusConditionTrue(latest.Status.Conditions, string(operatorutil.ToolInventoryDrift)) || latest.Status.Inventory.SuggestedPatch != "" {
+		t.Fatalf("status after fix = %#v", latest.Status)
+	}
+	if recorded := drainEvents(recorder); len(recorded) != 1 || !strings.Contains(recorded[0], "Normal ToolInventoryDrift") {
+		t.Fatalf("events after fix = %v", recorded)
+	}
+}
+
+func TestMCPInventoryReconcilerSkipsServers(t *testing.T) {
+	scheme := newAccessStatusScheme()
+	ctx := context.Background()
+
+	pending := inventoryServer()
+	pending.Status.Phase = "Pending"
+	mtls := inventoryServer()
+	mtls.Name = "secure"
+	mtls.Spec.Auth = &mcpv1alpha1.AuthConfig{Mode: mcpv1alpha1.AuthModeMTLS}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pending, mtls).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	source := &fakeInventorySource{err: errors.New("unexpected probe")}
+	r := &MCPInventoryReconciler{Client: c, Scheme: scheme, Source: source}
+
+	for _, server := range []*mcpv1alpha1.MCPServer{pending, mtls} {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name, Namespace: server.Namespace}}); err != nil {
+			t.Fatalf("Reconcile %s: %v", server.Name, err)
+		}
+	}
+	if source.probes != 0 {
+		t.Fatalf("probes = %d, want none for pending and mtls servers", source.probes)
+	}
+	latest := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "secure", Namespace: "servers"}, latest); err != nil {
+		t.Fatalf("get server: %v", err)
+	}
+	condition := meta.FindStatusCondition(latest.Status.Conditions, string(operatorutil.ToolInventoryDrift))
+	if condition == nil || condition.Reason != "ProbeUnsupported" || latest.Status.Inventory != nil {
+		t.Fatalf("mtls status = %#v", latest.Status)
+	}
+}
+
+func TestMCPInventoryProberProbe(t *testing.T) {
+	var methods []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/jira/mcp" || req.Header.Get("X-Agent") != inventoryAgentID || req.Header.Get("X-MCP-Human-ID") != inventoryHumanID {
+			http.Error(w, "unexpected request "+req.URL.Path, http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		var rpc struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		_ = json.Unmarshal(body, &rpc)
+		methods = append(methods, rpc.Method)
+		if rpc.Method != "initialize" && req.Header.Get("Mcp-Session-Id") != "session-1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Mcp-Session-Id", "session-1")
+		switch rpc.Method {
+		case "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+		case "initialize":
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-03-26","capabilities":{"tools":{},"resources":{}}}}`)
+		case "tools/list":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":2,\"result\":{\"tools\":[{\"name\":\"search_issues\",\"inputSchema\":{\"type\":\"object\"}}]}}\n\n")
+		case "resources/list":
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":4,"result":{"resources":[{"uri":"jira://projects","name":"projects"}]}}`)
+		default:
+			http.Error(w, "unexpected method", http.StatusBadRequest)
+		}
+	}))
+	defer upstream.Close()
+
+	server := inventoryServer()
+	server.Spec.Auth = &mcpv1alpha1.AuthConfig{Mode: mcpv1alpha1.AuthModeHeader, AgentIDHeader: "X-Agent"}
+	prober := &MCPInventoryProber{BaseURL: func(*mcpv1alpha1.MCPServer) string { return upstream.URL }}
+	inventory, err := prober.Probe(context.Background(), server)
+	if err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	if inventory.ProtocolVersion != "2025-03-26" || len(inventory.Tools) != 1 || inventory.Tools[0].Name != "search_issues" {
+		t.Fatalf("inventory = %#v", inventory)
+	}
+	if len(inventory.Prompts) != 0 || strings.Join(inventory.Resources, ",") != "jira://projects" {
+		t.Fatalf("prompts = %v, resources = %v", inventory.Prompts, inventory.Resources)
+	}
+	if got := strings.Join(methods, ","); got != "initialize,notifications/initialized,tools/list,resources/list" {
+		t.Fatalf("methods = %s", got)
+	}
+	if got := (&MCPInventoryProber{}).endpoint(server); got != "http://jira-tools.servers.svc:80/jira/mcp" {
+		t.Fatalf("default endpoint = %s", got)
+	}
+}
+
+func TestInputSchemaHashIgnoresFormatting(t *testing.T) {
+	compact := inputSchemaHash(json.RawMessage(`{"type":"object","properties":{"id":{"type":"string"}}}`))
+	spaced := inputSchemaHash(json.RawMessage("{ \"properties\": {\"id\": {\"type\": \"string\"}},\n \"type\": \"object\" }"))
+	if compact == "" || compact != spaced {
+		t.Fatalf("hashes differ: %q vs %q", compact, spaced)
+	}
+	if inputSchemaHash(json.RawMessage(`{"type":"string"}`)) == compact {
+		t.Fatal("different schemas should hash differently")
+	}
+}
+
+func TestSuggestedToolsPatchWithoutDeclaredTools(t *testing.T) {
+	patch := suggestedToolsPatch(0, nil, []InventoryTool{{Name: "search"}})
+	if patch != `[{"op":"add","path":"/spec/tools","value":[{"name":"search","sideEffect":"destructive"}]}]` {
+		t.Fatalf("patch = %s", patch)
+	}
+}
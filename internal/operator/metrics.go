@@ -0,0 +1,45 @@
+package operator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Operator metrics are registered with the controller-runtime registry, so
+// the manager's metrics endpoint serves them next to the built-in
+// controller_runtime_* series.
+var (
+	reconcileDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mcp_operator_reconcile_duration_seconds",
+		Help:    "Duration of MCPServer reconciles by result (success, requeue, or error).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"result"})
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_operator_reconcile_errors_total",
+		Help: "MCPServer reconcile failures by phase (validation, configmap, deployment, ingress, certificate, ...).",
+	}, []string{"phase"})
+	policyRenderBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcp_operator_policy_render_bytes",
+		Help: "Size of the gateway policy document last written for an MCPServer.",
+	}, []string{"namespace", "server"})
+	policyRevisionChangesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_operator_policy_revision_changes_total",
+		Help: "Gateway policy revision changes written for an MCPServer.",
+	}, []string{"namespace", "server"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileDurationSeconds,
+		reconcileErrorsTotal,
+		policyRenderBytes,
+		policyRevisionChangesTotal,
+	)
+}
+
+// forgetServerMetrics drops the per-server series of a deleted MCPServer or
+// one whose gateway was turned off.
+func forgetServerMetrics(namespace, name string) {
+	policyRenderBytes.DeleteLabelValues(namespace, name)
+	policyRevisionChangesTotal.DeleteLabelValues(namespace, name)
+}
@@ -31,8 +31,8 @@ const (
 )
 
 const (
-	gatewayTLSVolumeName = "gateway-mtls"
-	gatewayTLSMountDir   = "/var/run/mcp-runtime/tls"
+	gatewayTLSVolumeName = mcpv1alpha1.GatewayTLSVolumeName
+	gatewayTLSMountDir   = mcpv1alpha1.GatewayMountRoot + "/tls"
 )
 
 var certificateGVK = schema.GroupVersionKind{
@@ -168,13 +168,14 @@ func (r *MCPServerReconciler) deleteUnstructured(ctx context.Context, gvk schema
 // reconcileTraefikClientCertificate issues the client certificate the ingress
 // presents to the gateway over the re-encrypted hop. It is signed by the same
 // identity CA as user and gateway certificates and carries the pinned ingress
-// SPIFFE identity (see traefikProxySPIFFEID).
+// SPIFFE identity (see traefikProxySPIFFEID). In gateway-api mode the parent
+// Gateway presents its own backend client certificate instead.
 func (r *MCPServerReconciler) reconcileTraefikClientCertificate(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
 	cert := &unstructured.Unstructured{}
 	cert.SetGroupVersionKind(certificateGVK)
 	cert.SetName(traefikClientCertSecretName(mcpServer))
 	cert.SetNamespace(mcpServer.Namespace)
-	if !serverUsesMTLS(mcpServer) {
+	if !serverUsesMTLS(mcpServer) || r.usesGatewayAPI(mcpServer) {
 		if err := r.Delete(ctx, cert); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
 			return err
 		}
@@ -211,7 +212,8 @@ func (r *MCPServerReconciler) reconcileTraefikClientCertificate(ctx context.Cont
 // reconcileMTLSTrustBundle materializes the identity CA bundle as a Secret in
 // the server namespace, keyed for both Traefik (tls.ca) and cert-manager-style
 // (ca.crt) consumers, so the TLSOption (verify client certs) and the
-// ServersTransport (verify the gateway) can reference it.
+// ServersTransport (verify the gateway) can reference it. In gateway-api mode
+// the CA is also published as a ConfigMap for the BackendTLSPolicy.
 //
 // The CA is sourced from the gateway certificate's ca.crt, which is
 // issuer-agnostic: cert-manager populates ca.crt regardless of which
@@ -229,7 +231,7 @@ func (r *MCPServerReconciler) reconcileMTLSTrustBundle(ctx context.Context, mcpS
 		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
-		return nil
+		return r.reconcileBackendTLSCABundle(ctx, mcpServer, nil)
 	}
 
 	var gwSecret corev1.Secret
@@ -248,7 +250,7 @@ func (r *MCPServerReconciler) reconcileMTLSTrustBundle(ctx context.Context, mcpS
 		return fmt.Errorf("gateway secret %q has no ca.crt yet", gatewayTLSSecretName(mcpServer))
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
 		secret.Type = corev1.SecretTypeOpaque
 		secret.Data = map[string][]byte{"tls.ca": ca, "ca.crt": ca}
 		if secret.Labels == nil {
@@ -258,7 +260,23 @@ func (r *MCPServerReconciler) reconcileMTLSTrustBundle(ctx context.Context, mcpS
 		secret.Labels["mcpruntime.org/server"] = mcpServer.Name
 		return ctrl.SetControllerReference(mcpServer, secret, r.Scheme)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileBackendTLSCABundle(ctx, mcpServer, ca); err != nil {
+		return err
+	}
+	// The trust bundle is first written once cert-manager has issued the
+	// gateway certificate, and rewritten only when the CA changes.
+	switch op {
+	case controllerutil.OperationResultCreated:
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonCertificateIssued, "IssueCertificate",
+			"Gateway certificate %s issued; published trust bundle %s", gatewayTLSSecretName(mcpServer), secret.Name)
+	case controllerutil.OperationResultUpdated:
+		recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonTrustBundleRotated, "RotateTrustBundle",
+			"Gateway CA changed; updated trust bundle %s", secret.Name)
+	}
+	return nil
 }
 
 func mtlsNetworkPolicyName(mcpServer *mcpv1alpha1.MCPServer) string {
@@ -266,7 +284,9 @@ func mtlsNetworkPolicyName(mcpServer *mcpv1alpha1.MCPServer) string {
 }
 
 // reconcileMTLSNetworkPolicy restricts ingress to the gateway port so the
-// verified-identity header can only originate from the TLS-terminating ingress.
+// verified-identity header can only originate from the TLS-terminating ingress:
+// the Traefik pods, or the namespace of the parent Gateway's data plane in
+// gateway-api mode.
 // Without it, any pod could connect directly to the gateway and forge the
 // header. It is defense-in-depth on top of the gateway's own requirement that
 // the connection be a verified mTLS hop (see authenticateMTLS). The gateway
@@ -289,6 +309,22 @@ func (r *MCPServerReconciler) reconcileMTLSNetworkPolicy(ctx context.Context, mc
 	metricsPort := int32(DefaultGatewayMetricsPort)
 	tcp := corev1.ProtocolTCP
 
+	proxyPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": traefikNamespace},
+		},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "traefik"},
+		},
+	}
+	if r.usesGatewayAPI(mcpServer) {
+		proxyPeer = networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": r.gatewayAPIProxyNamespace(mcpServer)},
+			},
+		}
+	}
+
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
 		gatewayTarget := intstr.FromInt32(gatewayPort)
 		metricsTarget := intstr.FromInt32(metricsPort)
@@ -300,14 +336,7 @@ func (r *MCPServerReconciler) reconcileMTLSNetworkPolicy(ctx context.Context, mc
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{
 					// Only the ingress controller may reach the gateway port.
-					From: []networkingv1.NetworkPolicyPeer{{
-						NamespaceSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"kubernetes.io/metadata.name": traefikNamespace},
-						},
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{"app": "traefik"},
-						},
-					}},
+					From:  []networkingv1.NetworkPolicyPeer{proxyPeer},
 					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &gatewayTarget}},
 				},
 				{
@@ -316,6 +345,14 @@ func (r *MCPServerReconciler) reconcileMTLSNetworkPolicy(ctx context.Context, mc
 				},
 			},
 		}
+		// Extra container ports opted into the Service are reachable like
+		// metrics; they do not carry MCP traffic.
+		for _, port := range exposedContainerPorts(mcpServer) {
+			protocol, target := port.Protocol, intstr.FromInt32(port.Port)
+			policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &target}},
+			})
+		}
 		return ctrl.SetControllerReference(mcpServer, policy, r.Scheme)
 	})
 	return err
@@ -377,14 +414,14 @@ func (r *MCPServerReconciler) reconcileMTLSIngress(ctx context.Context, mcpServe
 		trustDomain = strings.TrimSpace(mcpServer.Spec.Auth.TrustDomain)
 	}
 
-	tlsOption := r.traefikResource(mcpServer, tlsOptionGVK, mtlsTLSOptionName(mcpServer), map[string]any{
+	tlsOption := r.ownedUnstructured(mcpServer, tlsOptionGVK, mtlsTLSOptionName(mcpServer), map[string]any{
 		"minVersion": "VersionTLS12",
 		"clientAuth": map[string]any{
 			"secretNames":    []any{mtlsTrustBundleSecretName(mcpServer)},
 			"clientAuthType": "RequireAndVerifyClientCert",
 		},
 	})
-	middleware := r.traefikResource(mcpServer, middlewareGVK, mtlsMiddlewareName(mcpServer), map[string]any{
+	middleware := r.ownedUnstructured(mcpServer, middlewareGVK, mtlsMiddlewareName(mcpServer), map[string]any{
 		"plugin": map[string]any{
 			spiffeIdentityPluginName: map[string]any{
 				"verifiedHeader": verifiedSPIFFEHeader,
@@ -392,7 +429,7 @@ func (r *MCPServerReconciler) reconcileMTLSIngress(ctx context.Context, mcpServe
 			},
 		},
 	})
-	serversTransport := r.traefikResource(mcpServer, serversTransportGVK, mtlsServersTransportName(mcpServer), map[string]any{
+	serversTransport := r.ownedUnstructured(mcpServer, serversTransportGVK, mtlsServersTransportName(mcpServer), map[string]any{
 		"serverName":          gatewayServerName(mcpServer),
 		"certificatesSecrets": []any{traefikClientCertSecretName(mcpServer)},
 		"rootCAsSecrets":      []any{mtlsTrustBundleSecretName(mcpServer)},
@@ -408,7 +445,7 @@ func (r *MCPServerReconciler) reconcileMTLSIngress(ctx context.Context, mcpServe
 	// per-IngressRoute secretName — Traefik resolves secretName only in the
 	// IngressRoute's own (tenant) namespace, where the shared platform host
 	// certificate does not exist.
-	ingressRoute := r.traefikResource(mcpServer, ingressRouteGVK, mcpServer.Name, map[string]any{
+	ingressRoute := r.ownedUnstructured(mcpServer, ingressRouteGVK, mcpServer.Name, map[string]any{
 		"entryPoints": []any{"websecure"},
 		"routes": []any{map[string]any{
 			"match":       match,
@@ -464,8 +501,9 @@ func (r *MCPServerReconciler) reconcileDefaultTLSStore(ctx context.Context) erro
 	return r.applyUnstructured(ctx, store)
 }
 
-// traefikResource builds an owned, labelled unstructured Traefik CR.
-func (r *MCPServerReconciler) traefikResource(mcpServer *mcpv1alpha1.MCPServer, gvk schema.GroupVersionKind, name string, spec map[string]any) *unstructured.Unstructured {
+// ownedUnstructured builds an owned, labelled unstructured Traefik or Gateway
+// API resource.
+func (r *MCPServerReconciler) ownedUnstructured(mcpServer *mcpv1alpha1.MCPServer, gvk schema.GroupVersionKind, name string, spec map[string]any) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(gvk)
 	obj.SetName(name)
@@ -30,6 +30,7 @@ func (r *MCPServerReconciler) reconcilePolicyConfigMap(ctx context.Context, mcpS
 			}
 			return err
 		}
+		forgetServerMetrics(mcpServer.Namespace, mcpServer.Name)
 		return r.Delete(ctx, existing)
 	}
 
@@ -49,11 +50,13 @@ func (r *MCPServerReconciler) reconcilePolicyConfigMap(ctx context.Context, mcpS
 			Namespace: mcpServer.Namespace,
 		},
 	}
+	previousRevision := ""
 	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
 		configMap.Labels = map[string]string{
 			"app":                          mcpServer.Name,
 			"app.kubernetes.io/managed-by": "mcp-runtime",
 		}
+		previousRevision = renderedPolicyRevision(configMap.Data[gatewayPolicyFileName])
 		rendered, err := renderPolicyConfigMapData(configMap.Data[gatewayPolicyFileName], doc)
 		if err != nil {
 			return err
@@ -63,7 +66,37 @@ func (r *MCPServerReconciler) reconcilePolicyConfigMap(ctx context.Context, mcpS
 		}
 		return ctrl.SetControllerReference(mcpServer, configMap, r.Scheme)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	policyRenderBytes.WithLabelValues(mcpServer.Namespace, mcpServer.Name).Set(float64(len(configMap.Data[gatewayPolicyFileName])))
+	if previousRevision != doc.Revision {
+		policyRevisionChangesTotal.WithLabelValues(mcpServer.Namespace, mcpServer.Name).Inc()
+		if previousRevision == "" {
+			recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonPolicyRevisionChanged, "RenderPolicy",
+				"Gateway policy rendered at revision %s", doc.Revision)
+		} else {
+			recordEvent(r.Recorder, mcpServer, corev1.EventTypeNormal, EventReasonPolicyRevisionChanged, "RenderPolicy",
+				"Gateway policy revision changed from %s to %s", previousRevision, doc.Revision)
+		}
+	}
+	return nil
+}
+
+// renderedPolicyRevision returns the revision recorded in a rendered policy
+// payload, or "" when there is none or it cannot be parsed.
+func renderedPolicyRevision(data string) string {
+	if data == "" {
+		return ""
+	}
+	var rendered struct {
+		Revision string `json:"revision"`
+	}
+	if err := json.Unmarshal([]byte(data), &rendered); err != nil {
+		return ""
+	}
+	return rendered.Revision
 }
 
 // renderPolicyConfigMapData serializes the policy document for the ConfigMap.
@@ -115,10 +148,11 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 	}
 	if mcpServer.Spec.Policy != nil {
 		doc.Policy = &policy.Config{
-			Mode:            string(mcpServer.Spec.Policy.Mode),
-			DefaultDecision: string(mcpServer.Spec.Policy.DefaultDecision),
-			EnforceOn:       mcpServer.Spec.Policy.EnforceOn,
-			PolicyVersion:   mcpServer.Spec.Policy.PolicyVersion,
+			Mode:                string(mcpServer.Spec.Policy.Mode),
+			DefaultDecision:     string(mcpServer.Spec.Policy.DefaultDecision),
+			EnforceOn:           mcpServer.Spec.Policy.EnforceOn,
+			PolicyVersion:       mcpServer.Spec.Policy.PolicyVersion,
+			FilterListResponses: mcpServer.Spec.Policy.FilterListResponses,
 		}
 	}
 	if mcpServer.Spec.Session != nil {
@@ -131,6 +165,13 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 			UpstreamTokenHeader: mcpServer.Spec.Session.UpstreamTokenHeader,
 		}
 	}
+	if mcpServer.Spec.Approval != nil {
+		doc.Approval = &policy.Approval{
+			RiskLevel: string(mcpServer.Spec.Approval.RiskLevel),
+			Timeout:   mcpServer.Spec.Approval.Timeout,
+			Wait:      mcpServer.Spec.Approval.Wait,
+		}
+	}
 	if len(mcpServer.Spec.Tools) > 0 {
 		doc.Tools = make([]policy.Tool, 0, len(mcpServer.Spec.Tools))
 		for _, tool := range mcpServer.Spec.Tools {
@@ -151,6 +192,28 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 		}
 	}
 
+	for _, prompt := range mcpServer.Spec.Prompts {
+		doc.Prompts = append(doc.Prompts, policy.Prompt{
+			Name:          policy.PromptName(prompt.Name),
+			Description:   prompt.Description,
+			RequiredTrust: string(prompt.RequiredTrust),
+			Labels:        copyLabels(prompt.Labels),
+		})
+	}
+	for _, resource := range mcpServer.Spec.MCPResources {
+		// Only resources with a URI pattern can be matched by the gateway.
+		if strings.TrimSpace(resource.URI) == "" {
+			continue
+		}
+		doc.Resources = append(doc.Resources, policy.Resource{
+			Name:          resource.Name,
+			URI:           resource.URI,
+			Description:   resource.Description,
+			RequiredTrust: string(resource.RequiredTrust),
+			Labels:        copyLabels(resource.Labels),
+		})
+	}
+
 	var grants mcpv1alpha1.MCPAccessGrantList
 	if err := r.List(ctx, &grants); err != nil {
 		return nil, err
@@ -174,10 +237,43 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 			rendered.AllowedSideEffects = append(rendered.AllowedSideEffects, string(sideEffect))
 		}
 		for _, rule := range grant.Spec.ToolRules {
-			rendered.ToolRules = append(rendered.ToolRules, policy.ToolAccess{
+			renderedRule := policy.ToolAccess{
 				Name:          policy.ToolName(rule.Name),
 				Decision:      string(defaultDecision(rule.Decision)),
 				RequiredTrust: string(defaultTrust(rule.RequiredTrust)),
+			}
+			for _, constraint := range rule.ArgumentConstraints {
+				renderedRule.ArgumentConstraints = append(renderedRule.ArgumentConstraints, policy.ArgumentConstraint{
+					Argument: constraint.Argument,
+					OneOf:    append([]string(nil), constraint.OneOf...),
+					Pattern:  constraint.Pattern,
+					Minimum:  constraint.Minimum,
+					Maximum:  constraint.Maximum,
+					Optional: constraint.Optional,
+				})
+			}
+			rendered.ToolRules = append(rendered.ToolRules, renderedRule)
+		}
+		for _, rule := range grant.Spec.PromptRules {
+			rendered.PromptRules = append(rendered.PromptRules, policy.PromptAccess{
+				Name:          policy.PromptName(rule.Name),
+				Decision:      string(defaultDecision(rule.Decision)),
+				RequiredTrust: string(defaultTrust(rule.RequiredTrust)),
+			})
+		}
+		for _, rule := range grant.Spec.ResourceRules {
+			rendered.ResourceRules = append(rendered.ResourceRules, policy.ResourceAccess{
+				URI:           rule.URI,
+				Decision:      string(defaultDecision(rule.Decision)),
+				RequiredTrust: string(defaultTrust(rule.RequiredTrust)),
+			})
+		}
+		for _, limit := range grant.Spec.RateLimits {
+			rendered.RateLimits = append(rendered.RateLimits, policy.RateLimit{
+				Tool:           policy.ToolName(limit.Tool),
+				SideEffect:     string(limit.SideEffect),
+				CallsPerMinute: int(limit.CallsPerMinute),
+				CallsPerDay:    int(limit.CallsPerDay),
 			})
 		}
 		doc.Grants = append(doc.Grants, rendered)
@@ -202,6 +298,9 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 			Revoked:        session.Spec.Revoked,
 			PolicyVersion:  session.Spec.PolicyVersion,
 		}
+		if !session.CreationTimestamp.IsZero() {
+			rendered.CreatedAt = session.CreationTimestamp.UTC().Format(time.RFC3339)
+		}
 		if session.Spec.ExpiresAt != nil {
 			rendered.ExpiresAt = session.Spec.ExpiresAt.UTC().Format(time.RFC3339)
 		}
@@ -210,6 +309,12 @@ func (r *MCPServerReconciler) renderGatewayPolicy(ctx context.Context, mcpServer
 		}
 		doc.Sessions = append(doc.Sessions, rendered)
 	}
+	doc.Network = renderPolicyNetwork(mcpServer)
+	baseline, err := r.renderPolicyBaseline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc.Baseline = baseline
 
 	// Stamp document-level metadata (schema version + deterministic revision).
 	// generated_at is left empty here and set at write time so it cannot affect
@@ -241,6 +346,17 @@ func gatewayPolicyConfigMapName(serverName string) string {
 	return serverName + "-gateway-policy"
 }
 
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+	return copied
+}
+
 func defaultTrust(trust mcpv1alpha1.TrustLevel) mcpv1alpha1.TrustLevel {
 	if trust == "" {
 		return mcpv1alpha1.TrustLevelLow
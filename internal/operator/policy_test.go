@@ -54,6 +54,57 @@ func TestRenderGatewayPolicyStampsAndValidates(t *testing.T) {
 	}
 }
 
+func TestRenderGatewayPolicyIncludesPromptAndResourceAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+
+	mcpServer := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Prompts: []mcpv1alpha1.InventoryItem{{Name: "refund-summary", RequiredTrust: mcpv1alpha1.TrustLevelMedium}},
+			MCPResources: []mcpv1alpha1.InventoryItem{
+				{Name: "ledger", URI: "ledger://*", RequiredTrust: mcpv1alpha1.TrustLevelHigh},
+				{Name: "unmatched"},
+			},
+		},
+	}
+	grant := &mcpv1alpha1.MCPAccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPAccessGrantSpec{
+			ServerRef:     mcpv1alpha1.ServerReference{Name: "payments"},
+			Subject:       mcpv1alpha1.SubjectRef{HumanID: "user-1"},
+			PromptRules:   []mcpv1alpha1.PromptRule{{Name: "refund-summary"}},
+			ResourceRules: []mcpv1alpha1.ResourceRule{{URI: "ledger://*", Decision: mcpv1alpha1.PolicyDecisionDeny}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer, grant).Build()
+	r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+	doc, err := r.renderGatewayPolicy(context.Background(), mcpServer)
+	if err != nil {
+		t.Fatalf("renderGatewayPolicy() error = %v", err)
+	}
+	if len(doc.Prompts) != 1 || doc.Prompts[0].RequiredTrust != "medium" {
+		t.Fatalf("Prompts = %#v, want refund-summary at medium trust", doc.Prompts)
+	}
+	if len(doc.Resources) != 1 || doc.Resources[0].URI != "ledger://*" {
+		t.Fatalf("Resources = %#v, want only the resource with a URI", doc.Resources)
+	}
+	if len(doc.Grants) != 1 {
+		t.Fatalf("Grants = %#v, want one grant", doc.Grants)
+	}
+	rendered := doc.Grants[0]
+	if len(rendered.PromptRules) != 1 || rendered.PromptRules[0].Decision != "allow" || rendered.PromptRules[0].RequiredTrust != "low" {
+		t.Fatalf("PromptRules = %#v, want defaulted allow at low trust", rendered.PromptRules)
+	}
+	if len(rendered.ResourceRules) != 1 || rendered.ResourceRules[0].Decision != "deny" {
+		t.Fatalf("ResourceRules = %#v, want deny rule", rendered.ResourceRules)
+	}
+	if err := policy.Validate(doc); err != nil {
+		t.Fatalf("rendered policy failed validation: %v", err)
+	}
+}
+
 func TestRenderPolicyConfigMapDataPreservesUnchangedRevision(t *testing.T) {
 	doc := &policy.Document{Server: policy.Server{Name: "demo"}}
 	if err := policy.Stamp(doc, ""); err != nil {
@@ -156,3 +207,69 @@ func TestRenderPolicyConfigMapDataRewritesWhenRevisionMetadataTampered(t *testin
 		t.Fatalf("written revision = %q, want %q", outDoc.Revision, unchanged.Revision)
 	}
 }
+
+func TestRenderGatewayPolicyMergesPolicyBaselines(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+
+	mcpServer := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Tools: []mcpv1alpha1.ToolConfig{
+				{Name: "refund_invoice", SideEffect: mcpv1alpha1.ToolSideEffectDestructive},
+			},
+		},
+	}
+	other := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "finance"}}
+	pii := &mcpv1alpha1.MCPPolicyBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pii"},
+		Spec: mcpv1alpha1.MCPPolicyBaselineSpec{Rules: []mcpv1alpha1.MCPPolicyBaselineRule{{
+			Name:          "high-trust",
+			ToolLabels:    map[string]string{"pii": "true"},
+			Effect:        mcpv1alpha1.BaselineEffectRequireTrust,
+			RequiredTrust: mcpv1alpha1.TrustLevelHigh,
+		}}},
+	}
+	agents := &mcpv1alpha1.MCPPolicyBaseline{
+		ObjectMeta: metav1.ObjectMeta{Name: "agents"},
+		Spec: mcpv1alpha1.MCPPolicyBaselineSpec{Rules: []mcpv1alpha1.MCPPolicyBaselineRule{{
+			Name:        "no-destructive",
+			Subject:     mcpv1alpha1.BaselineSubjectAgentOnly,
+			SideEffects: []mcpv1alpha1.ToolSideEffect{mcpv1alpha1.ToolSideEffectDestructive},
+			Effect:      mcpv1alpha1.BaselineEffectDeny,
+		}}},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer, other, pii, agents).Build()
+	r := MCPServerReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+
+	doc, err := r.renderGatewayPolicy(ctx, mcpServer)
+	if err != nil {
+		t.Fatalf("renderGatewayPolicy() error = %v", err)
+	}
+	if err := policy.Validate(doc); err != nil {
+		t.Fatalf("rendered policy failed validation: %v", err)
+	}
+	if doc.Baseline == nil || len(doc.Baseline.Rules) != 2 {
+		t.Fatalf("Baseline = %#v, want both baselines' rules", doc.Baseline)
+	}
+	if got := []string{doc.Baseline.Rules[0].Name, doc.Baseline.Rules[1].Name}; got[0] != "agents/no-destructive" || got[1] != "pii/high-trust" {
+		t.Fatalf("baseline rule names = %v, want ordered by baseline name", got)
+	}
+
+	pii.Spec.Rules[0].RequiredTrust = mcpv1alpha1.TrustLevelMedium
+	if err := client.Update(ctx, pii); err != nil {
+		t.Fatalf("update baseline: %v", err)
+	}
+	updated, err := r.renderGatewayPolicy(ctx, mcpServer)
+	if err != nil {
+		t.Fatalf("renderGatewayPolicy() error = %v", err)
+	}
+	if updated.Baseline.Revision == doc.Baseline.Revision || updated.Revision == doc.Revision {
+		t.Fatalf("baseline change kept revision %q", updated.Revision)
+	}
+
+	if requests := r.requestsForPolicyBaseline(ctx, pii); len(requests) != 2 {
+		t.Fatalf("requests for baseline = %v, want every server", requests)
+	}
+}
@@ -0,0 +1,68 @@
+package operator
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/policy"
+)
+
+// renderPolicyBaseline merges every MCPPolicyBaseline into one rule list,
+// ordered by baseline name and then rule order, or returns nil when there are
+// no rules. Rule names are qualified by their baseline so audit events point
+// at the object to edit.
+func (r *MCPServerReconciler) renderPolicyBaseline(ctx context.Context) (*policy.Baseline, error) {
+	var baselines mcpv1alpha1.MCPPolicyBaselineList
+	if err := r.List(ctx, &baselines); err != nil {
+		return nil, err
+	}
+	sort.Slice(baselines.Items, func(i, j int) bool {
+		return baselines.Items[i].Name < baselines.Items[j].Name
+	})
+	rendered := &policy.Baseline{}
+	for _, baseline := range baselines.Items {
+		for _, rule := range baseline.Spec.Rules {
+			renderedRule := policy.BaselineRule{
+				Name:          baseline.Name + "/" + rule.Name,
+				Subject:       string(rule.Subject),
+				ToolLabels:    copyLabels(rule.ToolLabels),
+				Effect:        string(rule.Effect),
+				RequiredTrust: string(rule.RequiredTrust),
+			}
+			for _, tool := range rule.Tools {
+				renderedRule.Tools = append(renderedRule.Tools, policy.ToolName(tool))
+			}
+			for _, sideEffect := range rule.SideEffects {
+				renderedRule.SideEffects = append(renderedRule.SideEffects, string(sideEffect))
+			}
+			rendered.Rules = append(rendered.Rules, renderedRule)
+		}
+	}
+	if len(rendered.Rules) == 0 {
+		return nil, nil
+	}
+	revision, err := policy.ComputeBaselineRevision(rendered)
+	if err != nil {
+		return nil, err
+	}
+	rendered.Revision = revision
+	return rendered, nil
+}
+
+// requestsForPolicyBaseline enqueues every server: baselines apply cluster-wide.
+func (r *MCPServerReconciler) requestsForPolicyBaseline(ctx context.Context, _ client.Object) []ctrl.Request {
+	servers := &mcpv1alpha1.MCPServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return nil
+	}
+	requests := make([]ctrl.Request, 0, len(servers.Items))
+	for _, server := range servers.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name, Namespace: server.Namespace}})
+	}
+	return requests
+}
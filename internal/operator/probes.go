@@ -0,0 +1,86 @@
+package operator
+
+import (
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	// gatewayMCPProbePath is the gateway sidecar endpoint, on its metrics
+	// port, that runs an initialize and ping handshake against the server
+	// container. The server's MCP path is passed as the path query parameter.
+	gatewayMCPProbePath   = "/probe/mcp"
+	defaultMCPProbeTarget = "/mcp"
+	// defaultMCPProbeTimeoutSeconds leaves room for the handshake's two
+	// round trips; the kubelet default of one second is too tight.
+	defaultMCPProbeTimeoutSeconds = 5
+)
+
+// serverContainerProbes returns the server container's liveness, readiness,
+// and startup probes. Without spec.probes they are TCP checks on spec.port and
+// there is no startup probe.
+func serverContainerProbes(mcpServer *mcpv1alpha1.MCPServer) (liveness, readiness, startup *corev1.Probe) {
+	var config mcpv1alpha1.ProbesConfig
+	if mcpServer.Spec.Probes != nil {
+		config = *mcpServer.Spec.Probes
+	}
+	liveness = buildServerProbe(mcpServer, config.Liveness, corev1.Probe{InitialDelaySeconds: 5, PeriodSeconds: 10})
+	readiness = buildServerProbe(mcpServer, config.Readiness, corev1.Probe{InitialDelaySeconds: 3, PeriodSeconds: 5})
+	if config.Startup != nil {
+		// Thirty ten-second periods give a server five minutes to start.
+		startup = buildServerProbe(mcpServer, config.Startup, corev1.Probe{PeriodSeconds: 10, FailureThreshold: 30})
+	}
+	return liveness, readiness, startup
+}
+
+func buildServerProbe(mcpServer *mcpv1alpha1.MCPServer, config *mcpv1alpha1.ProbeConfig, defaults corev1.Probe) *corev1.Probe {
+	probe := defaults
+	if config == nil {
+		config = &mcpv1alpha1.ProbeConfig{}
+	}
+	port := mcpServer.Spec.Port
+	if config.Port != nil {
+		port = *config.Port
+	}
+	path := strings.TrimSpace(config.Path)
+
+	switch config.Type {
+	case mcpv1alpha1.ProbeTypeHTTP:
+		if path == "" {
+			path = "/"
+		}
+		probe.HTTPGet = &corev1.HTTPGetAction{Path: path, Port: intstr.FromInt32(port)}
+	case mcpv1alpha1.ProbeTypeExec:
+		probe.Exec = &corev1.ExecAction{Command: append([]string(nil), config.Command...)}
+	case mcpv1alpha1.ProbeTypeMCP:
+		if path == "" {
+			path = defaultMCPProbeTarget
+		}
+		probe.HTTPGet = &corev1.HTTPGetAction{
+			Path: gatewayMCPProbePath + "?path=" + url.QueryEscape(path),
+			Port: intstr.FromInt32(DefaultGatewayMetricsPort),
+		}
+		probe.TimeoutSeconds = defaultMCPProbeTimeoutSeconds
+	default:
+		probe.TCPSocket = &corev1.TCPSocketAction{Port: intstr.FromInt32(port)}
+	}
+
+	if config.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *config.InitialDelaySeconds
+	}
+	if config.PeriodSeconds != nil {
+		probe.PeriodSeconds = *config.PeriodSeconds
+	}
+	if config.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *config.TimeoutSeconds
+	}
+	if config.FailureThreshold != nil {
+		probe.FailureThreshold = *config.FailureThreshold
+	}
+	return &probe
+}
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestServerContainerProbesDefaults(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Port: 8088},
+	}
+	liveness, readiness, startup := serverContainerProbes(server)
+	if liveness.TCPSocket == nil || liveness.TCPSocket.Port.IntVal != 8088 || liveness.PeriodSeconds != 10 {
+		t.Fatalf("liveness = %#v, want the TCP default", liveness)
+	}
+	if readiness.TCPSocket == nil || readiness.PeriodSeconds != 5 {
+		t.Fatalf("readiness = %#v, want the TCP default", readiness)
+	}
+	if startup != nil {
+		t.Fatalf("startup = %#v, want none by default", startup)
+	}
+}
+
+func TestServerContainerProbesFromSpec(t *testing.T) {
+	delay, threshold, port := int32(60), int32(60), int32(9000)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Port:    8088,
+			Gateway: &mcpv1alpha1.GatewayConfig{Enabled: true, Port: 8091},
+			Probes: &mcpv1alpha1.ProbesConfig{
+				Liveness:  &mcpv1alpha1.ProbeConfig{Type: mcpv1alpha1.ProbeTypeHTTP, Path: "/healthz", Port: &port, InitialDelaySeconds: &delay},
+				Readiness: &mcpv1alpha1.ProbeConfig{Type: mcpv1alpha1.ProbeTypeMCP, Path: "/rpc"},
+				Startup:   &mcpv1alpha1.ProbeConfig{Type: mcpv1alpha1.ProbeTypeExec, Command: []string{"cat", "/tmp/warm"}, FailureThreshold: &threshold},
+			},
+		},
+	}
+	liveness, readiness, startup := serverContainerProbes(server)
+
+	if liveness.HTTPGet == nil || liveness.HTTPGet.Path != "/healthz" || liveness.HTTPGet.Port.IntVal != 9000 {
+		t.Fatalf("liveness handler = %#v", liveness.ProbeHandler)
+	}
+	if liveness.InitialDelaySeconds != 60 || liveness.PeriodSeconds != 10 {
+		t.Fatalf("liveness timings = %d/%d, want the override and the default period", liveness.InitialDelaySeconds, liveness.PeriodSeconds)
+	}
+
+	if readiness.HTTPGet == nil || readiness.HTTPGet.Path != "/probe/mcp?path=%2Frpc" || readiness.HTTPGet.Port.IntVal != DefaultGatewayMetricsPort {
+		t.Fatalf("readiness handler = %#v, want the gateway MCP probe", readiness.ProbeHandler)
+	}
+	if readiness.TimeoutSeconds != defaultMCPProbeTimeoutSeconds {
+		t.Fatalf("readiness timeout = %d", readiness.TimeoutSeconds)
+	}
+
+	if startup == nil || startup.Exec == nil || len(startup.Exec.Command) != 2 {
+		t.Fatalf("startup = %#v, want the exec probe", startup)
+	}
+	if startup.FailureThreshold != 60 || startup.PeriodSeconds != 10 {
+		t.Fatalf("startup timings = %d/%d", startup.FailureThreshold, startup.PeriodSeconds)
+	}
+}
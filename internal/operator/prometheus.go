@@ -0,0 +1,93 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsSource evaluates instant PromQL queries for canary analysis and
+// idle detection.
+type MetricsSource interface {
+	// Query returns the value of a query that yields at most one sample. ok is
+	// false when the query returned no sample or NaN, such as a rate over a
+	// track that served no requests.
+	Query(ctx context.Context, query string) (value float64, ok bool, err error)
+}
+
+// PrometheusMetrics queries the Prometheus HTTP API.
+type PrometheusMetrics struct {
+	// URL is the Prometheus base URL, such as http://prometheus.monitoring:9090.
+	URL string
+	// Client defaults to a client with a 10s timeout.
+	Client *http.Client
+}
+
+var defaultPrometheusClient = &http.Client{Timeout: 10 * time.Second}
+
+// Query implements MetricsSource.
+func (p *PrometheusMetrics) Query(ctx context.Context, query string) (float64, bool, error) {
+	endpoint := strings.TrimRight(strings.TrimSpace(p.URL), "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	client := p.Client
+	if client == nil {
+		client = defaultPrometheusClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Value []any `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, false, fmt.Errorf("decode prometheus response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return 0, false, fmt.Errorf("prometheus query failed (HTTP %d): %s", resp.StatusCode, body.Error)
+	}
+	if body.Data.ResultType != "vector" {
+		return 0, false, fmt.Errorf("prometheus query returned %q, want vector", body.Data.ResultType)
+	}
+	switch len(body.Data.Result) {
+	case 0:
+		return 0, false, nil
+	case 1:
+	default:
+		return 0, false, fmt.Errorf("prometheus query returned %d samples, want at most 1", len(body.Data.Result))
+	}
+	sample := body.Data.Result[0].Value
+	if len(sample) != 2 {
+		return 0, false, fmt.Errorf("prometheus sample has %d fields, want 2", len(sample))
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("prometheus sample value is %T, want string", sample[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse prometheus sample %q: %w", raw, err)
+	}
+	if math.IsNaN(value) {
+		return 0, false, nil
+	}
+	return value, true, nil
+}
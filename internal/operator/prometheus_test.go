@@ -0,0 +1,48 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusMetricsQuery(t *testing.T) {
+	responses := map[string]string{
+		"one":   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.25"]}]}}`,
+		"none":  `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"nan":   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"NaN"]}]}}`,
+		"error": `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		body, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			http.Error(w, "unknown query", http.StatusBadRequest)
+			return
+		}
+		if strings.Contains(body, `"error"`) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+	source := &PrometheusMetrics{URL: srv.URL + "/"}
+
+	if value, ok, err := source.Query(context.Background(), "one"); err != nil || !ok || value != 0.25 {
+		t.Fatalf("Query(one) = %v, %v, %v; want 0.25", value, ok, err)
+	}
+	for _, query := range []string{"none", "nan"} {
+		if _, ok, err := source.Query(context.Background(), query); err != nil || ok {
+			t.Fatalf("Query(%s) ok = %v, err = %v; want no sample", query, ok, err)
+		}
+	}
+	if _, _, err := source.Query(context.Background(), "error"); err == nil || !strings.Contains(err.Error(), "parse error") {
+		t.Fatalf("Query(error) err = %v, want the prometheus error", err)
+	}
+}
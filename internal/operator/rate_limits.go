@@ -0,0 +1,354 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RateLimitPath is the path gateways POST grant rate limit counter updates
+// to. It is served next to ActivationPath.
+const RateLimitPath = "/rate-limits"
+
+const (
+	// maxRateLimitBody bounds a rate limit request.
+	maxRateLimitBody = 16 << 10
+	// maxRateLimitTTL is the longest a counter may live. Gateways keep
+	// hourly counters for a rolling day.
+	maxRateLimitTTL = 25 * time.Hour
+	// maxRateLimitSumKeys is the most counters one request may sum.
+	maxRateLimitSumKeys = 48
+	// rateLimitCallerTTL is how long an authenticated gateway is trusted
+	// before its token is reviewed again. Gateways count every limited tool
+	// call, so reviewing each one would double the API server load.
+	rateLimitCallerTTL = 30 * time.Second
+	// maxRateLimitCallers bounds the remembered callers.
+	maxRateLimitCallers = 4096
+	// rateLimitLeaderTTL is how long a replica that is not the leader keeps
+	// the leader's address before reading the lease again.
+	rateLimitLeaderTTL = 10 * time.Second
+	// rateLimitForwardedHeader marks a request forwarded to the leader, which
+	// must answer it rather than forward it again.
+	rateLimitForwardedHeader = "X-Mcp-Runtime-Forwarded"
+)
+
+// errNotLeader means the replica lost the leader lease and cannot count.
+var errNotLeader = errors.New("operator replica is not the leader")
+
+// rateLimitRequest is the body gateways send to add one to a counter or to
+// sum counters. Keys are scoped to the calling gateway's server.
+type rateLimitRequest struct {
+	Namespace string `json:"namespace"`
+	Server    string `json:"server"`
+	// Increment names the counter to add one to; empty sums Sum instead.
+	Increment  string   `json:"increment,omitempty"`
+	TTLSeconds int64    `json:"ttl_seconds,omitempty"`
+	Sum        []string `json:"sum,omitempty"`
+}
+
+// rateLimitResponse is the counter's new value or the sum.
+type rateLimitResponse struct {
+	Value int64 `json:"value"`
+}
+
+type rateLimitCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type rateLimitCaller struct {
+	pod       *corev1.Pod
+	expiresAt time.Time
+}
+
+// rateLimitHandler keeps the counters behind MCPAccessGrant rate limits so
+// that every gateway replica of a server counts against the same budgets.
+// Counters live in the memory of the leader; the other replicas forward to
+// it, and a new leader starts from empty counters. Only the gateway pods of a
+// server may count for it; see callerAuthenticator.
+type rateLimitHandler struct {
+	auth *callerAuthenticator
+	// leader forwards requests when this replica is not the leader; nil
+	// counts every request locally.
+	leader *leaderForwarder
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+
+	mu       sync.Mutex
+	counters map[string]rateLimitCounter
+	sweptAt  time.Time
+	callers  map[[sha256.Size]byte]rateLimitCaller
+}
+
+// ServeHTTP adds one to a counter or sums counters for the caller's server.
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRateLimitBody))
+	if err != nil {
+		http.Error(w, "invalid rate limit request", http.StatusBadRequest)
+		return
+	}
+	if h.leader != nil && !h.leader.isLeader() {
+		h.leader.forward(w, r, body)
+		return
+	}
+	pod, err := h.callerPod(r)
+	if err != nil {
+		if !errors.Is(err, errCallerUnauthenticated) {
+			log.FromContext(r.Context()).Error(err, "Failed to authenticate rate limit caller")
+		}
+		writeCallerError(w, err)
+		return
+	}
+	var req rateLimitRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid rate limit request", http.StatusBadRequest)
+		return
+	}
+	if len(validation.IsDNS1123Label(req.Namespace)) > 0 || len(validation.IsDNS1123Subdomain(req.Server)) > 0 {
+		http.Error(w, "namespace and server must be valid Kubernetes names", http.StatusBadRequest)
+		return
+	}
+	if !callerIsComponent(pod, LabelComponentGateway, req.Namespace, req.Server) {
+		writeCallerError(w, errCallerForbidden)
+		return
+	}
+
+	// The server's gateways share one key space that no other server's
+	// gateways can reach.
+	prefix := req.Namespace + "/" + req.Server + "\x00"
+	var response rateLimitResponse
+	switch ttl := time.Duration(req.TTLSeconds) * time.Second; {
+	case req.Increment != "":
+		if len(req.Sum) > 0 || ttl <= 0 || ttl > maxRateLimitTTL {
+			http.Error(w, "increment needs a ttl of at most 25 hours and no sum", http.StatusBadRequest)
+			return
+		}
+		response.Value = h.increment(prefix+req.Increment, ttl)
+	case len(req.Sum) > maxRateLimitSumKeys:
+		http.Error(w, fmt.Sprintf("at most %d counters can be summed", maxRateLimitSumKeys), http.StatusBadRequest)
+		return
+	default:
+		keys := make([]string, 0, len(req.Sum))
+		for _, key := range req.Sum {
+			keys = append(keys, prefix+key)
+		}
+		response.Value = h.sum(keys)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// callerPod authenticates the caller, reusing a review of the same token for
+// rateLimitCallerTTL.
+func (h *rateLimitHandler) callerPod(r *http.Request) (*corev1.Pod, error) {
+	key := sha256.Sum256([]byte(r.Header.Get("Authorization")))
+	now := h.currentTime()
+	h.mu.Lock()
+	cached, ok := h.callers[key]
+	h.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.pod, nil
+	}
+	pod, err := h.auth.callerPod(r)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.callers == nil || len(h.callers) >= maxRateLimitCallers {
+		h.callers = map[[sha256.Size]byte]rateLimitCaller{}
+	}
+	h.callers[key] = rateLimitCaller{pod: pod, expiresAt: now.Add(rateLimitCallerTTL)}
+	return pod, nil
+}
+
+// increment adds one to the counter for key, creating it to expire after
+// ttl, and returns the new count. Expired counters are swept at most once per
+// minute.
+func (h *rateLimitHandler) increment(key string, ttl time.Duration) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.currentTime()
+	if h.counters == nil {
+		h.counters = map[string]rateLimitCounter{}
+	}
+	if now.Sub(h.sweptAt) >= time.Minute {
+		for existing, counter := range h.counters {
+			if !now.Before(counter.expiresAt) {
+				delete(h.counters, existing)
+			}
+		}
+		h.sweptAt = now
+	}
+	counter, ok := h.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = rateLimitCounter{expiresAt: now.Add(ttl)}
+	}
+	counter.count++
+	h.counters[key] = counter
+	return counter.count
+}
+
+// sum returns the total of the given counters. Missing or expired counters
+// count as zero.
+func (h *rateLimitHandler) sum(keys []string) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.currentTime()
+	var total int64
+	for _, key := range keys {
+		if counter, ok := h.counters[key]; ok && now.Before(counter.expiresAt) {
+			total += counter.count
+		}
+	}
+	return total
+}
+
+func (h *rateLimitHandler) currentTime() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// leaderForwarder sends requests a replica that is not the leader receives
+// to the leader's pod, found through the leader election lease.
+type leaderForwarder struct {
+	// reader reads the lease and the leader's pod.
+	reader client.Reader
+	// lease is the leader election lease. Its holder identity starts with
+	// the leader's pod name.
+	lease types.NamespacedName
+	// elected is closed once this replica is the leader.
+	elected <-chan struct{}
+	// port is the port the leader serves RateLimitPath on.
+	port   string
+	client *http.Client
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+
+	mu        sync.Mutex
+	leaderURL string
+	readAt    time.Time
+}
+
+func (f *leaderForwarder) isLeader() bool {
+	select {
+	case <-f.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// forward relays the request to the leader and its answer to the caller. A
+// request that was already forwarded is not forwarded again.
+func (f *leaderForwarder) forward(w http.ResponseWriter, r *http.Request, body []byte) {
+	ctx := r.Context()
+	if r.Header.Get(rateLimitForwardedHeader) != "" {
+		http.Error(w, errNotLeader.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	target, err := f.url(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to find the operator leader for rate limits")
+		http.Error(w, "rate limit leader unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, "rate limit leader unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", r.Header.Get("Authorization"))
+	req.Header.Set(rateLimitForwardedHeader, "1")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		f.forget()
+		log.FromContext(ctx).Error(err, "Failed to forward rate limit request to the operator leader")
+		http.Error(w, "rate limit leader unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		// The leader moved; read the lease again next time.
+		f.forget()
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, io.LimitReader(resp.Body, maxRateLimitBody))
+}
+
+// url returns the leader's RateLimitPath URL.
+func (f *leaderForwarder) url(ctx context.Context) (string, error) {
+	now := time.Now()
+	if f.now != nil {
+		now = f.now()
+	}
+	f.mu.Lock()
+	if f.leaderURL != "" && now.Sub(f.readAt) < rateLimitLeaderTTL {
+		defer f.mu.Unlock()
+		return f.leaderURL, nil
+	}
+	f.mu.Unlock()
+
+	lease := &coordinationv1.Lease{}
+	if err := f.reader.Get(ctx, f.lease, lease); err != nil {
+		return "", fmt.Errorf("read leader lease: %w", err)
+	}
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	podName, _, _ := strings.Cut(holder, "_")
+	if podName == "" {
+		return "", errors.New("leader lease has no holder")
+	}
+	pod := &corev1.Pod{}
+	if err := f.reader.Get(ctx, types.NamespacedName{Name: podName, Namespace: f.lease.Namespace}, pod); err != nil {
+		return "", fmt.Errorf("read leader pod: %w", err)
+	}
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("leader pod %s has no IP", podName)
+	}
+	target := "http://" + net.JoinHostPort(pod.Status.PodIP, f.port) + RateLimitPath
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leaderURL, f.readAt = target, now
+	return target, nil
+}
+
+func (f *leaderForwarder) forget() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leaderURL = ""
+}
@@ -0,0 +1,145 @@
+package operator
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func postRateLimit(t *testing.T, h http.Handler, caller, body string) (int, int64) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, RateLimitPath, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer servers/"+caller)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var response rateLimitResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, response.Value
+}
+
+func TestRateLimitHandlerSharesCountersPerServer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			callerTestPod("payments-gw-1", "servers", "payments", LabelComponentGateway),
+			callerTestPod("payments-gw-2", "servers", "payments", LabelComponentGateway),
+			callerTestPod("billing-gw", "servers", "billing", LabelComponentGateway),
+			callerTestPod("payments-act", "servers", "payments", LabelComponentActivator),
+		).
+		Build()
+	now := time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)
+	h := &rateLimitHandler{auth: callerTestAuthenticator(c), now: func() time.Time { return now }}
+	increment := `{"namespace":"servers","server":"payments","increment":"k1","ttl_seconds":120}`
+
+	for i, caller := range []string{"payments-gw-1", "payments-gw-2", "payments-gw-1"} {
+		if code, value := postRateLimit(t, h, caller, increment); code != http.StatusOK || value != int64(i+1) {
+			t.Fatalf("increment %d from %s = %d %d, want 200 %d", i+1, caller, code, value, i+1)
+		}
+	}
+	if code, value := postRateLimit(t, h, "payments-gw-2", `{"namespace":"servers","server":"payments","sum":["k1","missing"]}`); code != http.StatusOK || value != 3 {
+		t.Fatalf("sum = %d %d, want 200 3", code, value)
+	}
+	// Other servers' gateways have their own key space.
+	if code, value := postRateLimit(t, h, "billing-gw", `{"namespace":"servers","server":"billing","sum":["k1"]}`); code != http.StatusOK || value != 0 {
+		t.Fatalf("other server sum = %d %d, want 200 0", code, value)
+	}
+	if code, _ := postRateLimit(t, h, "billing-gw", increment); code != http.StatusForbidden {
+		t.Fatalf("gateway of another server status = %d, want 403", code)
+	}
+	if code, _ := postRateLimit(t, h, "payments-act", increment); code != http.StatusForbidden {
+		t.Fatalf("activator status = %d, want 403", code)
+	}
+	if code, _ := postRateLimit(t, h, "payments-gw-1", `{"namespace":"servers","server":"payments","increment":"k1","ttl_seconds":172800}`); code != http.StatusBadRequest {
+		t.Fatalf("two-day ttl status = %d, want 400", code)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if code, value := postRateLimit(t, h, "payments-gw-1", increment); code != http.StatusOK || value != 1 {
+		t.Fatalf("increment after expiry = %d %d, want 200 1", code, value)
+	}
+}
+
+func TestRateLimitHandlerForwardsToLeader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = coordinationv1.AddToScheme(scheme)
+	gateway := callerTestPod("payments-gw", "servers", "payments", LabelComponentGateway)
+	gatewayClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gateway).Build()
+
+	elected := make(chan struct{})
+	close(elected)
+	leader := &rateLimitHandler{
+		auth:   callerTestAuthenticator(gatewayClient),
+		leader: &leaderForwarder{elected: elected},
+	}
+	leaderServer := httptest.NewServer(leader)
+	t.Cleanup(leaderServer.Close)
+	leaderURL, _ := url.Parse(leaderServer.URL)
+	host, port, _ := net.SplitHostPort(leaderURL.Host)
+
+	lease := types.NamespacedName{Name: "mcp-runtime-operator.mcpruntime.org", Namespace: "mcp-runtime"}
+	holder := "operator-0_4f3c"
+	operatorClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			&coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: lease.Name, Namespace: lease.Namespace},
+				Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "operator-0", Namespace: lease.Namespace},
+				Status:     corev1.PodStatus{PodIP: host},
+			},
+		).
+		Build()
+	follower := &rateLimitHandler{
+		auth: callerTestAuthenticator(gatewayClient),
+		leader: &leaderForwarder{
+			reader:  operatorClient,
+			lease:   lease,
+			elected: make(chan struct{}),
+			port:    port,
+			client:  leaderServer.Client(),
+		},
+	}
+
+	increment := `{"namespace":"servers","server":"payments","increment":"k1","ttl_seconds":120}`
+	for want, h := range []http.Handler{follower, leader, follower} {
+		if code, value := postRateLimit(t, h, "payments-gw", increment); code != http.StatusOK || value != int64(want+1) {
+			t.Fatalf("increment %d = %d %d, want 200 %d", want+1, code, value, want+1)
+		}
+	}
+	if len(follower.counters) != 0 {
+		t.Fatalf("follower counters = %v, want none kept outside the leader", follower.counters)
+	}
+	// The leader checks the gateway itself.
+	if code, _ := postRateLimit(t, follower, "payments-gw", `{"namespace":"servers","server":"billing","increment":"k1","ttl_seconds":120}`); code != http.StatusForbidden {
+		t.Fatalf("forwarded request for another server status = %d, want 403", code)
+	}
+
+	// A replica that lost the lease does not forward a forwarded request.
+	req := httptest.NewRequest(http.MethodPost, RateLimitPath, strings.NewReader(increment))
+	req.Header.Set("Authorization", "Bearer servers/payments-gw")
+	req.Header.Set(rateLimitForwardedHeader, "1")
+	rec := httptest.NewRecorder()
+	follower.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("forwarded request to a follower status = %d, want 503", rec.Code)
+	}
+}
@@ -0,0 +1,191 @@
+package operator
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// applyServerScheduling copies spec.scheduling onto a server pod template.
+// Pod affinity terms and spread constraints without labels select the
+// server's own pods across both rollout tracks.
+func applyServerScheduling(podSpec *corev1.PodSpec, mcpServer *mcpv1alpha1.MCPServer) {
+	scheduling := mcpServer.Spec.Scheduling
+	if scheduling == nil {
+		return
+	}
+	ownPods := map[string]string{LabelApp: mcpServer.Name}
+
+	podSpec.NodeSelector = maps.Clone(scheduling.NodeSelector)
+	podSpec.PriorityClassName = scheduling.PriorityClassName
+	for _, toleration := range scheduling.Tolerations {
+		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+			Key:               toleration.Key,
+			Operator:          corev1.TolerationOperator(toleration.Operator),
+			Value:             toleration.Value,
+			Effect:            corev1.TaintEffect(toleration.Effect),
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+	if scheduling.Affinity != nil {
+		podSpec.Affinity = buildAffinity(scheduling.Affinity, ownPods)
+	}
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		whenUnsatisfiable := corev1.DoNotSchedule
+		if constraint.WhenUnsatisfiable != "" {
+			whenUnsatisfiable = corev1.UnsatisfiableConstraintAction(constraint.WhenUnsatisfiable)
+		}
+		matchLabels := constraint.MatchLabels
+		if len(matchLabels) == 0 {
+			matchLabels = ownPods
+		}
+		podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           constraint.MaxSkew,
+			TopologyKey:       constraint.TopologyKey,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: maps.Clone(matchLabels)},
+		})
+	}
+}
+
+func buildAffinity(affinity *mcpv1alpha1.Affinity, ownPods map[string]string) *corev1.Affinity {
+	out := &corev1.Affinity{}
+	if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+		out.NodeAffinity = &corev1.NodeAffinity{}
+		if len(nodeAffinity.Required) > 0 {
+			required := &corev1.NodeSelector{}
+			for _, term := range nodeAffinity.Required {
+				required.NodeSelectorTerms = append(required.NodeSelectorTerms, buildNodeSelectorTerm(term))
+			}
+			out.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+		}
+		for _, term := range nodeAffinity.Preferred {
+			out.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(out.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+				Weight:     term.Weight,
+				Preference: buildNodeSelectorTerm(term.Preference),
+			})
+		}
+	}
+	if affinity.PodAffinity != nil {
+		required, preferred := buildPodAffinityTerms(affinity.PodAffinity, ownPods)
+		out.PodAffinity = &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	if affinity.PodAntiAffinity != nil {
+		required, preferred := buildPodAffinityTerms(affinity.PodAntiAffinity, ownPods)
+		out.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	return out
+}
+
+func buildNodeSelectorTerm(term mcpv1alpha1.NodeSelectorTerm) corev1.NodeSelectorTerm {
+	out := corev1.NodeSelectorTerm{}
+	for _, requirement := range term.MatchExpressions {
+		out.MatchExpressions = append(out.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: corev1.NodeSelectorOperator(requirement.Operator),
+			Values:   append([]string(nil), requirement.Values...),
+		})
+	}
+	return out
+}
+
+func buildPodAffinityTerms(affinity *mcpv1alpha1.PodAffinity, ownPods map[string]string) ([]corev1.PodAffinityTerm, []corev1.WeightedPodAffinityTerm) {
+	var required []corev1.PodAffinityTerm
+	for _, term := range affinity.Required {
+		required = append(required, buildPodAffinityTerm(term, ownPods))
+	}
+	var preferred []corev1.WeightedPodAffinityTerm
+	for _, term := range affinity.Preferred {
+		preferred = append(preferred, corev1.WeightedPodAffinityTerm{
+			Weight:          term.Weight,
+			PodAffinityTerm: buildPodAffinityTerm(term.PodAffinityTerm, ownPods),
+		})
+	}
+	return required, preferred
+}
+
+func buildPodAffinityTerm(term mcpv1alpha1.PodAffinityTerm, ownPods map[string]string) corev1.PodAffinityTerm {
+	selector := &metav1.LabelSelector{MatchLabels: maps.Clone(term.MatchLabels)}
+	for _, requirement := range term.MatchExpressions {
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: metav1.LabelSelectorOperator(requirement.Operator),
+			Values:   append([]string(nil), requirement.Values...),
+		})
+	}
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		selector.MatchLabels = maps.Clone(ownPods)
+	}
+	return corev1.PodAffinityTerm{
+		LabelSelector: selector,
+		TopologyKey:   term.TopologyKey,
+		Namespaces:    append([]string(nil), term.Namespaces...),
+	}
+}
+
+// disruptionBudgetMinReplicas is the fewest stable replicas the server runs
+// outside scale to zero: the autoscaler's lower bound, or spec.replicas.
+func disruptionBudgetMinReplicas(mcpServer *mcpv1alpha1.MCPServer) int32 {
+	if autoscalingEnabled(mcpServer) {
+		return autoscalingMinReplicas(mcpServer)
+	}
+	return desiredStableReplicas(mcpServer)
+}
+
+// reconcilePodDisruptionBudget keeps a PodDisruptionBudget over the stable
+// pods while spec.disruptionBudget is enabled and the server runs more than
+// one replica, and deletes it otherwise.
+func (r *MCPServerReconciler) reconcilePodDisruptionBudget(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mcpServer.Name,
+			Namespace: mcpServer.Namespace,
+		},
+	}
+	budget := mcpServer.Spec.DisruptionBudget
+	if budget == nil || !budget.Enabled || scaledToZero(mcpServer) || disruptionBudgetMinReplicas(mcpServer) < 2 {
+		return r.deleteOwned(ctx, mcpServer, pdb)
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	if value := strings.TrimSpace(budget.MaxUnavailable); value != "" {
+		maxUnavailable = intstr.Parse(value)
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+		pdb.Labels = map[string]string{
+			LabelApp:       mcpServer.Name,
+			LabelManagedBy: LabelManagedByValue,
+		}
+		pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				LabelApp:                       mcpServer.Name,
+				"mcpruntime.org/rollout-track": "stable",
+			}},
+		}
+		return ctrl.SetControllerReference(mcpServer, pdb, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("PodDisruptionBudget reconciled", "operation", op, "name", pdb.Name)
+	}
+	return nil
+}
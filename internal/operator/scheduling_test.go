@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestApplyServerScheduling(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Scheduling: &mcpv1alpha1.SchedulingConfig{
+				NodeSelector:      map[string]string{"pool": "mcp"},
+				Tolerations:       []mcpv1alpha1.Toleration{{Key: "dedicated", Value: "mcp", Effect: "NoSchedule"}},
+				PriorityClassName: "mcp-critical",
+				Affinity: &mcpv1alpha1.Affinity{
+					NodeAffinity: &mcpv1alpha1.NodeAffinity{Required: []mcpv1alpha1.NodeSelectorTerm{{
+						MatchExpressions: []mcpv1alpha1.LabelSelectorRequirement{{Key: "arch", Operator: "In", Values: []string{"amd64"}}},
+					}}},
+					PodAntiAffinity: &mcpv1alpha1.PodAffinity{Preferred: []mcpv1alpha1.WeightedPodAffinityTerm{
+						{Weight: 100, PodAffinityTerm: mcpv1alpha1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+					}},
+				},
+				TopologySpreadConstraints: []mcpv1alpha1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone"}},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{}
+	applyServerScheduling(podSpec, server)
+
+	if podSpec.NodeSelector["pool"] != "mcp" || podSpec.PriorityClassName != "mcp-critical" {
+		t.Fatalf("node selector / priority = %v / %q", podSpec.NodeSelector, podSpec.PriorityClassName)
+	}
+	if len(podSpec.Tolerations) != 1 || podSpec.Tolerations[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Fatalf("tolerations = %#v", podSpec.Tolerations)
+	}
+	required := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || required.NodeSelectorTerms[0].MatchExpressions[0].Operator != corev1.NodeSelectorOpIn {
+		t.Fatalf("node affinity = %#v", podSpec.Affinity.NodeAffinity)
+	}
+	anti := podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(anti) != 1 || anti[0].PodAffinityTerm.LabelSelector.MatchLabels[LabelApp] != "payments" {
+		t.Fatalf("pod anti-affinity = %#v, want the server's own pods selected", anti)
+	}
+	spread := podSpec.TopologySpreadConstraints
+	if len(spread) != 1 || spread[0].WhenUnsatisfiable != corev1.DoNotSchedule || spread[0].LabelSelector.MatchLabels[LabelApp] != "payments" {
+		t.Fatalf("topology spread = %#v", spread)
+	}
+}
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	replicas := int32(3)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Replicas:         &replicas,
+			DisruptionBudget: &mcpv1alpha1.DisruptionBudgetConfig{Enabled: true},
+		},
+	}
+	scheme := newAutoscalingScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "payments", Namespace: "servers"}
+
+	if err := r.reconcilePodDisruptionBudget(ctx, server); err != nil {
+		t.Fatalf("reconcile PDB: %v", err)
+	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	if err := c.Get(ctx, key, pdb); err != nil {
+		t.Fatalf("get PDB: %v", err)
+	}
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 1 {
+		t.Fatalf("maxUnavailable = %v, want the default of 1", pdb.Spec.MaxUnavailable)
+	}
+	if pdb.Spec.Selector.MatchLabels["mcpruntime.org/rollout-track"] != "stable" || !metav1.IsControlledBy(pdb, server) {
+		t.Fatalf("PDB = %#v, want an owned budget over the stable pods", pdb)
+	}
+
+	server.Spec.DisruptionBudget.MaxUnavailable = "50%"
+	if err := r.reconcilePodDisruptionBudget(ctx, server); err != nil {
+		t.Fatalf("update PDB: %v", err)
+	}
+	if err := c.Get(ctx, key, pdb); err != nil || pdb.Spec.MaxUnavailable.String() != "50%" {
+		t.Fatalf("maxUnavailable = %v, %v; want 50%%", pdb.Spec.MaxUnavailable, err)
+	}
+
+	// One replica leaves nothing for a budget to protect.
+	replicas = 1
+	if err := r.reconcilePodDisruptionBudget(ctx, server); err != nil {
+		t.Fatalf("single replica: %v", err)
+	}
+	if err := c.Get(ctx, key, pdb); !errors.IsNotFound(err) {
+		t.Fatalf("expected the PDB to be deleted for one replica, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// resolveServerClass returns the MCPServerClass that applies to the server,
+// or nil when none does. A named class that does not exist rejects the spec.
+func (r *MCPServerReconciler) resolveServerClass(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) (*mcpv1alpha1.MCPServerClass, error) {
+	class, err := mcpv1alpha1.ResolveMCPServerClass(ctx, r.Client, mcpServer)
+	if apierrors.IsNotFound(err) {
+		err = newOperatorError(fmt.Sprintf("MCPServerClass %s not found", mcpServer.Spec.ClassName), map[string]any{
+			"mcpServer": mcpServer.Name,
+			"namespace": mcpServer.Namespace,
+			"className": mcpServer.Spec.ClassName,
+		})
+		r.rejectSpec(ctx, mcpServer, err)
+		logOperatorError(logger, err, "Missing MCPServerClass")
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// validateServerClass enforces the class constraints on the defaulted spec.
+// The admission webhook already rejects violating writes; this catches
+// servers admitted before the class tightened, or without webhooks.
+func (r *MCPServerReconciler) validateServerClass(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, class *mcpv1alpha1.MCPServerClass, logger logr.Logger) error {
+	if err := class.ValidateServer(mcpServer); err != nil {
+		r.rejectSpec(ctx, mcpServer, err)
+		logOperatorError(logger, err, "MCPServer violates its MCPServerClass")
+		return err
+	}
+	return nil
+}
+
+// requestsForServerClass enqueues the servers that name the class, and those
+// without a class name, which may select it as the default.
+func (r *MCPServerReconciler) requestsForServerClass(ctx context.Context, obj client.Object) []ctrl.Request {
+	servers := &mcpv1alpha1.MCPServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, server := range servers.Items {
+		if className := strings.TrimSpace(server.Spec.ClassName); className == "" || className == obj.GetName() {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name, Namespace: server.Namespace}})
+		}
+	}
+	return requests
+}
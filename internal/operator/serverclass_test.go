@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestReconcileEnforcesServerClass(t *testing.T) {
+	maxReplicas := int32(2)
+	class := &mcpv1alpha1.MCPServerClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+		Spec: mcpv1alpha1.MCPServerClassSpec{
+			Constraints: &mcpv1alpha1.MCPServerClassConstraints{MaxReplicas: &maxReplicas},
+		},
+	}
+	replicas := int32(4)
+	violating := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "servers"},
+		Spec:       mcpv1alpha1.MCPServerSpec{ClassName: "restricted", Image: "registry.example.com/payments", Replicas: &replicas},
+	}
+	missing := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "servers"},
+		Spec:       mcpv1alpha1.MCPServerSpec{ClassName: "gold", Image: "registry.example.com/billing"},
+	}
+	scheme := newAccessStatusScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(class, violating, missing).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	for server, want := range map[*mcpv1alpha1.MCPServer]string{
+		violating: "MCPServerClass restricted allows at most 2 replicas",
+		missing:   "MCPServerClass gold not found",
+	} {
+		key := types.NamespacedName{Name: server.Name, Namespace: server.Namespace}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err == nil {
+			t.Fatalf("Reconcile %s: expected an error", server.Name)
+		}
+		latest := &mcpv1alpha1.MCPServer{}
+		if err := c.Get(ctx, key, latest); err != nil {
+			t.Fatalf("get %s: %v", server.Name, err)
+		}
+		if latest.Status.Phase != "Error" || !strings.Contains(latest.Status.Message, want) {
+			t.Fatalf("%s status = %q %q, want Error with %q", server.Name, latest.Status.Phase, latest.Status.Message, want)
+		}
+	}
+
+	requests := r.requestsForServerClass(ctx, class)
+	if len(requests) != 1 || requests[0].Name != "payments" {
+		t.Fatalf("requests for class = %v, want only payments", requests)
+	}
+}
@@ -21,6 +21,11 @@ func (r *MCPServerReconciler) reconcileService(ctx context.Context, mcpServer *m
 		targetPort = mcpServer.Spec.Gateway.Port
 	}
 
+	selector, err := r.serviceSelector(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      mcpServer.Name,
@@ -59,10 +64,11 @@ func (r *MCPServerReconciler) reconcileService(ctx context.Context, mcpServer *m
 				Protocol:   corev1.ProtocolTCP,
 			})
 		}
+		ports = append(ports, exposedContainerPorts(mcpServer)...)
 
 		service.Spec = corev1.ServiceSpec{
 			Type:     corev1.ServiceTypeClusterIP,
-			Selector: labels,
+			Selector: selector,
 			Ports:    ports,
 		}
 
@@ -0,0 +1,156 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// SessionActivityPath is the path gateways POST governed session requests
+// to. It is served next to ActivationPath.
+const SessionActivityPath = "/session-activity"
+
+// sessionActivityInterval is how stale a session's recorded activity may get
+// before a request records it again. Gateways report each active session at
+// most this often, so idle timeouts hold to within it.
+const sessionActivityInterval = time.Minute
+
+// errSessionNotBound means the session does not exist or belongs to another
+// server.
+var errSessionNotBound = errors.New("session is not bound to the server")
+
+// sessionActivityRequest is the body gateways send for a governed request
+// bound to an agent session.
+type sessionActivityRequest struct {
+	Namespace          string `json:"namespace"`
+	Server             string `json:"server"`
+	SessionNamespace   string `json:"session_namespace"`
+	Session            string `json:"session"`
+	IdleTimeoutSeconds int64  `json:"idle_timeout_seconds,omitempty"`
+}
+
+// sessionActivityResponse reports the activity recorded before the request.
+type sessionActivityResponse struct {
+	// LastActiveAt is RFC 3339 and empty when no activity was recorded.
+	LastActiveAt string `json:"last_active_at,omitempty"`
+}
+
+// sessionActivityHandler keeps the last activity of agent sessions in
+// MCPAgentSession status.lastActiveAt so that every gateway replica of a
+// server enforces the same idle timeout. A session idle longer than the
+// timeout is not marked active again, so it stays expired. Only the gateway
+// pods of the session's server may call it; see callerAuthenticator.
+type sessionActivityHandler struct {
+	client client.Client
+	auth   *callerAuthenticator
+
+	// now returns the current time; nil means time.Now.
+	now func() time.Time
+}
+
+// ServeHTTP records a governed request for a session and returns the
+// activity recorded before it.
+func (h *sessionActivityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pod, err := h.auth.callerPod(r)
+	if err != nil {
+		if !errors.Is(err, errCallerUnauthenticated) {
+			log.FromContext(r.Context()).Error(err, "Failed to authenticate session activity caller")
+		}
+		writeCallerError(w, err)
+		return
+	}
+	var req sessionActivityRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, "invalid session activity request", http.StatusBadRequest)
+		return
+	}
+	req.Namespace = strings.TrimSpace(req.Namespace)
+	req.Server = strings.TrimSpace(req.Server)
+	req.SessionNamespace = strings.TrimSpace(req.SessionNamespace)
+	req.Session = strings.TrimSpace(req.Session)
+	if len(validation.IsDNS1123Label(req.Namespace)) > 0 || len(validation.IsDNS1123Subdomain(req.Server)) > 0 ||
+		len(validation.IsDNS1123Label(req.SessionNamespace)) > 0 || len(validation.IsDNS1123Subdomain(req.Session)) > 0 {
+		http.Error(w, "namespaces, server, and session must be valid Kubernetes names", http.StatusBadRequest)
+		return
+	}
+	if !callerIsComponent(pod, LabelComponentGateway, req.Namespace, req.Server) {
+		writeCallerError(w, errCallerForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	var response sessionActivityResponse
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		response, err = h.touch(ctx, req)
+		return err
+	})
+	switch {
+	case errors.Is(err, errSessionNotBound):
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	case err != nil:
+		log.FromContext(ctx).Error(err, "Failed to record session activity", "namespace", req.SessionNamespace, "session", req.Session)
+		http.Error(w, "failed to record session activity", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// touch returns the session's recorded activity and records the request
+// unless the session is idle or was recorded less than
+// sessionActivityInterval ago.
+func (h *sessionActivityHandler) touch(ctx context.Context, req sessionActivityRequest) (sessionActivityResponse, error) {
+	session := &mcpv1alpha1.MCPAgentSession{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: req.Session, Namespace: req.SessionNamespace}, session); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return sessionActivityResponse{}, errSessionNotBound
+		}
+		return sessionActivityResponse{}, err
+	}
+	server := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: req.Server, Namespace: req.Namespace}}
+	if !serverReferenceMatches(session.Namespace, session.Spec.ServerRef, server) {
+		return sessionActivityResponse{}, errSessionNotBound
+	}
+
+	now := h.currentTime()
+	var response sessionActivityResponse
+	if previous := session.Status.LastActiveAt; previous != nil {
+		response.LastActiveAt = previous.UTC().Format(time.RFC3339)
+		idle := time.Duration(req.IdleTimeoutSeconds) * time.Second
+		if now.Sub(previous.Time) < sessionActivityInterval || (idle > 0 && now.Sub(previous.Time) > idle) {
+			return response, nil
+		}
+	}
+	patch := client.MergeFromWithOptions(session.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	session.Status.LastActiveAt = &metav1.Time{Time: now}
+	if err := h.client.Status().Patch(ctx, session, patch); err != nil {
+		return sessionActivityResponse{}, err
+	}
+	return response, nil
+}
+
+func (h *sessionActivityHandler) currentTime() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
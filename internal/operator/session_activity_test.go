@@ -0,0 +1,97 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestSessionActivityHandlerSharesLastActivity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	session := &mcpv1alpha1.MCPAgentSession{
+		ObjectMeta: metav1.ObjectMeta{Name: "sess-1", Namespace: "team-a"},
+		Spec:       mcpv1alpha1.MCPAgentSessionSpec{ServerRef: mcpv1alpha1.ServerReference{Name: "payments", Namespace: "servers"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			session,
+			callerTestPod("payments-gw", "servers", "payments", LabelComponentGateway),
+			callerTestPod("billing-gw", "servers", "billing", LabelComponentGateway),
+		).
+		WithStatusSubresource(&mcpv1alpha1.MCPAgentSession{}).
+		Build()
+	start := time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)
+	now := start
+	h := &sessionActivityHandler{client: c, auth: callerTestAuthenticator(c), now: func() time.Time { return now }}
+
+	touch := func(caller, server, session string) (int, string) {
+		t.Helper()
+		body := `{"namespace":"servers","server":"` + server + `","session_namespace":"team-a","session":"` + session + `","idle_timeout_seconds":3600}`
+		req := httptest.NewRequest(http.MethodPost, SessionActivityPath, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer servers/"+caller)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var response sessionActivityResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", rec.Body.String(), err)
+			}
+		}
+		return rec.Code, response.LastActiveAt
+	}
+	recorded := func() time.Time {
+		t.Helper()
+		got := &mcpv1alpha1.MCPAgentSession{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "sess-1", Namespace: "team-a"}, got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status.LastActiveAt == nil {
+			return time.Time{}
+		}
+		return got.Status.LastActiveAt.UTC()
+	}
+
+	for _, step := range []struct {
+		name     string
+		at       time.Duration
+		previous string
+		recorded time.Duration
+	}{
+		{name: "first request", at: 0, previous: "", recorded: 0},
+		{name: "within the interval", at: 30 * time.Second, previous: "2026-03-26T12:00:00Z", recorded: 0},
+		{name: "after the interval", at: 50 * time.Minute, previous: "2026-03-26T12:00:00Z", recorded: 50 * time.Minute},
+		{name: "after the idle timeout", at: 3 * time.Hour, previous: "2026-03-26T12:50:00Z", recorded: 50 * time.Minute},
+	} {
+		now = start.Add(step.at)
+		if code, previous := touch("payments-gw", "payments", "sess-1"); code != http.StatusOK || previous != step.previous {
+			t.Fatalf("%s: status = %d, last_active_at = %q; want 200 %q", step.name, code, previous, step.previous)
+		}
+		if got := recorded(); !got.Equal(start.Add(step.recorded)) {
+			t.Fatalf("%s: lastActiveAt = %v, want %v", step.name, got, start.Add(step.recorded))
+		}
+	}
+
+	if code, _ := touch("billing-gw", "payments", "sess-1"); code != http.StatusForbidden {
+		t.Fatalf("gateway of another server status = %d, want 403", code)
+	}
+	if code, _ := touch("billing-gw", "billing", "sess-1"); code != http.StatusNotFound {
+		t.Fatalf("session of another server status = %d, want 404", code)
+	}
+	if code, _ := touch("payments-gw", "payments", "missing"); code != http.StatusNotFound {
+		t.Fatalf("missing session status = %d, want 404", code)
+	}
+}
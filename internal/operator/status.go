@@ -69,6 +69,9 @@ func (r *MCPServerReconciler) checkServiceReady(ctx context.Context, mcpServer *
 }
 
 func (r *MCPServerReconciler) checkIngressReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
+	if r.usesGatewayAPI(mcpServer) {
+		return r.checkHTTPRouteReady(ctx, mcpServer)
+	}
 	if serverUsesMTLS(mcpServer) {
 		route := &unstructured.Unstructured{}
 		route.SetGroupVersionKind(ingressRouteTCPGVK)
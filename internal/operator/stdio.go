@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/kubeworkload"
+)
+
+const (
+	stdioBridgeVolumeName = mcpv1alpha1.StdioBridgeVolumeName
+	stdioBridgeMountDir   = mcpv1alpha1.GatewayMountRoot + "/stdio-bridge"
+	stdioBridgeBinaryPath = stdioBridgeMountDir + "/mcp-stdio-bridge"
+	// stdioBridgeImageBinary is where the bridge image keeps the binary.
+	stdioBridgeImageBinary = "/mcp-stdio-bridge"
+)
+
+func stdioTransport(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Transport == mcpv1alpha1.ServerTransportStdio
+}
+
+func (r *MCPServerReconciler) resolveStdioBridgeImage(mcpServer *mcpv1alpha1.MCPServer) (string, error) {
+	if image := strings.TrimSpace(r.StdioBridgeImage); image != "" {
+		return image, nil
+	}
+	contextMap := map[string]any{
+		"mcpServer": mcpServer.Name,
+		"namespace": mcpServer.Namespace,
+	}
+	return "", newOperatorError("transport stdio requires the stdio bridge image (set MCP_STDIO_BRIDGE_IMAGE on the operator)", contextMap)
+}
+
+func (r *MCPServerReconciler) validateStdioBridgeConfig(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	if !stdioTransport(mcpServer) {
+		return nil
+	}
+	if _, err := r.resolveStdioBridgeImage(mcpServer); err != nil {
+		r.rejectSpec(ctx, mcpServer, err)
+		logOperatorError(logger, err, "Missing stdio bridge image")
+		return err
+	}
+	return nil
+}
+
+// buildStdioBridgeInitContainer copies the bridge binary onto the shared
+// stdio-bridge volume, so it runs in the server image next to the command.
+func (r *MCPServerReconciler) buildStdioBridgeInitContainer(mcpServer *mcpv1alpha1.MCPServer) (corev1.Container, error) {
+	image, err := r.resolveStdioBridgeImage(mcpServer)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	container := corev1.Container{
+		Name:            mcpv1alpha1.StdioBridgeContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{stdioBridgeImageBinary, "install", stdioBridgeBinaryPath},
+		SecurityContext: kubeworkload.RestrictedReadOnlyContainerSecurityContext(),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: stdioBridgeVolumeName, MountPath: stdioBridgeMountDir},
+		},
+	}
+	if err := applyContainerResources(&container, mcpv1alpha1.ResourceRequirements{}); err != nil {
+		return corev1.Container{}, err
+	}
+	return container, nil
+}
+
+// applyStdioBridge runs spec.stdio's command behind the bridge, which serves
+// Streamable HTTP on spec.port. The gateway and probes are unchanged.
+func applyStdioBridge(container *corev1.Container, mcpServer *mcpv1alpha1.MCPServer) {
+	stdio := mcpServer.Spec.Stdio
+	args := []string{"--listen", ":" + strconv.Itoa(int(mcpServer.Spec.Port))}
+	if stdio.SessionMode != "" {
+		args = append(args, "--session-mode", string(stdio.SessionMode))
+	}
+	if stdio.MaxSessions > 0 {
+		args = append(args, "--max-sessions", strconv.Itoa(int(stdio.MaxSessions)))
+	}
+	args = append(args, "--")
+	args = append(args, stdio.Command...)
+	args = append(args, stdio.Args...)
+
+	container.Command = []string{stdioBridgeBinaryPath}
+	container.Args = args
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      stdioBridgeVolumeName,
+		MountPath: stdioBridgeMountDir,
+		ReadOnly:  true,
+	})
+}
+
+func stdioBridgeVolume() corev1.Volume {
+	return corev1.Volume{
+		Name:         stdioBridgeVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+}
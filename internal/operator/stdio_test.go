@@ -0,0 +1,73 @@
+package operator
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func stdioServer() *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "filesystem", Namespace: "servers"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:     "node:22-alpine",
+			Port:      8088,
+			Gateway:   &mcpv1alpha1.GatewayConfig{Enabled: true, Image: "example.com/gateway:v1", Port: 8091},
+			Transport: mcpv1alpha1.ServerTransportStdio,
+			Stdio: &mcpv1alpha1.StdioConfig{
+				Command:     []string{"npx", "-y", "@modelcontextprotocol/server-filesystem"},
+				Args:        []string{"/data"},
+				MaxSessions: 10,
+			},
+			InitContainers: []mcpv1alpha1.Container{{Name: "seed", Image: "example.com/seed"}},
+		},
+	}
+}
+
+func TestBuildDeploymentContainersRunsStdioServerBehindBridge(t *testing.T) {
+	server := stdioServer()
+	r := &MCPServerReconciler{StdioBridgeImage: "example.com/mcp-runtime-operator:v1"}
+
+	containers, volumes, err := r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	serverContainer := containers[0]
+	if !slices.Equal(serverContainer.Command, []string{stdioBridgeBinaryPath}) {
+		t.Fatalf("server command = %q, want the bridge", serverContainer.Command)
+	}
+	wantArgs := []string{"--listen", ":8088", "--max-sessions", "10", "--", "npx", "-y", "@modelcontextprotocol/server-filesystem", "/data"}
+	if !slices.Equal(serverContainer.Args, wantArgs) {
+		t.Fatalf("server args = %q, want %q", serverContainer.Args, wantArgs)
+	}
+	mounts := serverContainer.VolumeMounts
+	if len(mounts) != 1 || mounts[0].Name != stdioBridgeVolumeName || !mounts[0].ReadOnly {
+		t.Fatalf("server mounts = %#v, want the read-only bridge volume", mounts)
+	}
+	if containers[len(containers)-1].Name != mcpv1alpha1.GatewayContainerName {
+		t.Fatalf("containers = %#v, want the gateway unchanged", containers)
+	}
+	if !slices.ContainsFunc(volumes, func(v corev1.Volume) bool { return v.Name == stdioBridgeVolumeName && v.EmptyDir != nil }) {
+		t.Fatalf("volumes = %#v, want the bridge emptyDir", volumes)
+	}
+
+	initContainers, err := r.buildInitContainers(server)
+	if err != nil {
+		t.Fatalf("build init containers: %v", err)
+	}
+	if len(initContainers) != 2 || initContainers[0].Name != mcpv1alpha1.StdioBridgeContainerName || initContainers[1].Name != "seed" {
+		t.Fatalf("init containers = %#v, want the bridge installer first", initContainers)
+	}
+	installer := initContainers[0]
+	if installer.Image != r.StdioBridgeImage || !slices.Equal(installer.Command, []string{stdioBridgeImageBinary, "install", stdioBridgeBinaryPath}) {
+		t.Fatalf("installer = %#v", installer)
+	}
+
+	if _, err := (&MCPServerReconciler{}).buildInitContainers(server); err == nil {
+		t.Fatal("buildInitContainers() succeeded without a stdio bridge image")
+	}
+}
@@ -0,0 +1,213 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// LabelVolume names the spec volume an operator-created claim belongs to.
+const LabelVolume = "mcpruntime.org/volume"
+
+// persistentVolumeClaimName is the name of the claim the operator creates for
+// a volume with a claim template.
+func persistentVolumeClaimName(serverName, volumeName string) string {
+	return serverName + "-" + volumeName
+}
+
+// buildServerVolumes converts spec.volumes and spec.volumeMounts into pod
+// volumes and server container mounts.
+func buildServerVolumes(mcpServer *mcpv1alpha1.MCPServer) ([]corev1.Volume, []corev1.VolumeMount, error) {
+	var volumes []corev1.Volume
+	for _, volume := range mcpServer.Spec.Volumes {
+		podVolume := corev1.Volume{Name: volume.Name}
+		switch {
+		case volume.ConfigMap != nil:
+			podVolume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: volume.ConfigMap.Name},
+				Items:                buildKeyToPaths(volume.ConfigMap.Items),
+				Optional:             optionalBool(volume.ConfigMap.Optional),
+			}
+		case volume.Secret != nil:
+			podVolume.Secret = &corev1.SecretVolumeSource{
+				SecretName: volume.Secret.SecretName,
+				Items:      buildKeyToPaths(volume.Secret.Items),
+				Optional:   optionalBool(volume.Secret.Optional),
+			}
+		case volume.EmptyDir != nil:
+			podVolume.EmptyDir = &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMedium(volume.EmptyDir.Medium)}
+			if sizeLimit := strings.TrimSpace(volume.EmptyDir.SizeLimit); sizeLimit != "" {
+				quantity, err := resource.ParseQuantity(sizeLimit)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid sizeLimit for volume %q: %w", volume.Name, err)
+				}
+				podVolume.EmptyDir.SizeLimit = &quantity
+			}
+		case volume.PersistentVolumeClaim != nil:
+			claimName := volume.PersistentVolumeClaim.ClaimName
+			if volume.PersistentVolumeClaim.Template != nil {
+				claimName = persistentVolumeClaimName(mcpServer.Name, volume.Name)
+			}
+			podVolume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName}
+		default:
+			return nil, nil, fmt.Errorf("volume %q has no source", volume.Name)
+		}
+		volumes = append(volumes, podVolume)
+	}
+
+	var mounts []corev1.VolumeMount
+	for _, mount := range mcpServer.Spec.VolumeMounts {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      mount.Name,
+			MountPath: mount.MountPath,
+			SubPath:   mount.SubPath,
+			ReadOnly:  mount.ReadOnly,
+		})
+	}
+	return volumes, mounts, nil
+}
+
+func buildKeyToPaths(items []mcpv1alpha1.KeyToPath) []corev1.KeyToPath {
+	var converted []corev1.KeyToPath
+	for _, item := range items {
+		converted = append(converted, corev1.KeyToPath{Key: item.Key, Path: item.Path})
+	}
+	return converted
+}
+
+func optionalBool(optional bool) *bool {
+	if !optional {
+		return nil
+	}
+	return &optional
+}
+
+// usesPersistentVolumes reports whether any spec volume is a claim.
+func usesPersistentVolumes(mcpServer *mcpv1alpha1.MCPServer) bool {
+	for _, volume := range mcpServer.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// applyServerVolumeSecurity sets the pod fsGroup when the server mounts a
+// claim, so the non-root server user can write to the volume.
+func applyServerVolumeSecurity(podSpec *corev1.PodSpec, mcpServer *mcpv1alpha1.MCPServer) {
+	if !usesPersistentVolumes(mcpServer) || podSpec.SecurityContext == nil {
+		return
+	}
+	fsGroup := int64(restrictedRunAsUser)
+	podSpec.SecurityContext.FSGroup = &fsGroup
+}
+
+// reconcilePersistentVolumeClaims creates the claims for volumes with a claim
+// template and deletes owned claims whose volume was removed. Existing claims
+// only grow: access modes and storage class cannot change after creation.
+func (r *MCPServerReconciler) reconcilePersistentVolumeClaims(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	logger := log.FromContext(ctx)
+	desired := map[string]bool{}
+	for _, volume := range mcpServer.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.Template == nil {
+			continue
+		}
+		template := volume.PersistentVolumeClaim.Template
+		size, err := resource.ParseQuantity(strings.TrimSpace(template.Size))
+		if err != nil {
+			return fmt.Errorf("invalid size for volume %q: %w", volume.Name, err)
+		}
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      persistentVolumeClaimName(mcpServer.Name, volume.Name),
+				Namespace: mcpServer.Namespace,
+			},
+		}
+		desired[claim.Name] = true
+
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, claim, func() error {
+			if claim.Labels == nil {
+				claim.Labels = map[string]string{}
+			}
+			claim.Labels[LabelApp] = mcpServer.Name
+			claim.Labels[LabelManagedBy] = LabelManagedByValue
+			claim.Labels[LabelVolume] = volume.Name
+
+			if claim.CreationTimestamp.IsZero() {
+				claim.Spec.AccessModes = claimAccessModes(template.AccessModes)
+				claim.Spec.StorageClassName = template.StorageClassName
+			}
+			current, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+			if !ok || size.Cmp(current) > 0 {
+				claim.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: size}
+			} else if size.Cmp(current) < 0 {
+				logger.Info("Ignoring smaller size for existing PersistentVolumeClaim", "name", claim.Name, "current", current.String(), "requested", size.String())
+			}
+
+			if template.Retain {
+				removeOwnerReference(claim, mcpServer)
+				return nil
+			}
+			return ctrl.SetControllerReference(mcpServer, claim, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info("PersistentVolumeClaim reconciled", "operation", op, "name", claim.Name)
+		}
+	}
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, claims, client.InNamespace(mcpServer.Namespace), client.MatchingLabels{
+		LabelApp:       mcpServer.Name,
+		LabelManagedBy: LabelManagedByValue,
+	}); err != nil {
+		return err
+	}
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if desired[claim.Name] || !metav1.IsControlledBy(claim, mcpServer) {
+			continue
+		}
+		if err := r.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		logger.Info("PersistentVolumeClaim deleted", "name", claim.Name)
+	}
+	return nil
+}
+
+func claimAccessModes(modes []string) []corev1.PersistentVolumeAccessMode {
+	if len(modes) == 0 {
+		return []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	converted := make([]corev1.PersistentVolumeAccessMode, 0, len(modes))
+	for _, mode := range modes {
+		converted = append(converted, corev1.PersistentVolumeAccessMode(mode))
+	}
+	return converted
+}
+
+// removeOwnerReference drops mcpServer's owner reference so a retained claim
+// survives the MCPServer's deletion.
+func removeOwnerReference(obj metav1.Object, mcpServer *mcpv1alpha1.MCPServer) {
+	refs := obj.GetOwnerReferences()
+	kept := refs[:0]
+	for _, ref := range refs {
+		if ref.UID != mcpServer.UID {
+			kept = append(kept, ref)
+		}
+	}
+	obj.SetOwnerReferences(kept)
+}
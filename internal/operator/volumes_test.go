@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func volumeServer() *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "servers", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image: "example.com/search",
+			Port:  8088,
+			Volumes: []mcpv1alpha1.Volume{
+				{Name: "config", ConfigMap: &mcpv1alpha1.ConfigMapVolumeSource{Name: "search-config", Optional: true}},
+				{Name: "scratch", EmptyDir: &mcpv1alpha1.EmptyDirVolumeSource{Medium: "Memory", SizeLimit: "256Mi"}},
+				{Name: "index", PersistentVolumeClaim: &mcpv1alpha1.PersistentVolumeClaimVolumeSource{
+					Template: &mcpv1alpha1.PersistentVolumeClaimTemplate{Size: "10Gi"},
+				}},
+				{Name: "shared", PersistentVolumeClaim: &mcpv1alpha1.PersistentVolumeClaimVolumeSource{ClaimName: "team-data"}},
+			},
+			VolumeMounts: []mcpv1alpha1.VolumeMount{
+				{Name: "config", MountPath: "/etc/search", ReadOnly: true},
+				{Name: "index", MountPath: "/var/lib/search"},
+			},
+		},
+	}
+}
+
+func TestBuildDeploymentContainersAddsServerVolumes(t *testing.T) {
+	server := volumeServer()
+	server.Spec.Gateway = &mcpv1alpha1.GatewayConfig{Enabled: true, Image: "example.com/gateway:v1", Port: 8091}
+	r := &MCPServerReconciler{}
+
+	containers, volumes, err := r.buildDeploymentContainers(server, server.Spec.Image)
+	if err != nil {
+		t.Fatalf("build containers: %v", err)
+	}
+	if len(volumes) != 5 || volumes[4].Name != gatewayPolicyVolumeName {
+		t.Fatalf("volumes = %#v, want the spec volumes and then the gateway policy", volumes)
+	}
+	if cm := volumes[0].ConfigMap; cm == nil || cm.Name != "search-config" || cm.Optional == nil || !*cm.Optional {
+		t.Fatalf("configMap volume = %#v", volumes[0])
+	}
+	if dir := volumes[1].EmptyDir; dir == nil || dir.Medium != corev1.StorageMediumMemory || dir.SizeLimit.String() != "256Mi" {
+		t.Fatalf("emptyDir volume = %#v", volumes[1])
+	}
+	if claim := volumes[2].PersistentVolumeClaim; claim == nil || claim.ClaimName != "search-index" {
+		t.Fatalf("templated claim volume = %#v", volumes[2])
+	}
+	if claim := volumes[3].PersistentVolumeClaim; claim == nil || claim.ClaimName != "team-data" {
+		t.Fatalf("existing claim volume = %#v", volumes[3])
+	}
+	mounts := containers[0].VolumeMounts
+	if len(mounts) != 2 || mounts[0].MountPath != "/etc/search" || !mounts[0].ReadOnly || mounts[1].Name != "index" {
+		t.Fatalf("server mounts = %#v", mounts)
+	}
+	for _, mount := range containers[1].VolumeMounts {
+		if mount.Name == "index" || mount.Name == "config" {
+			t.Fatalf("gateway container mounts server volume %q", mount.Name)
+		}
+	}
+
+	podSpec := &corev1.PodSpec{SecurityContext: &corev1.PodSecurityContext{}}
+	applyServerVolumeSecurity(podSpec, server)
+	if podSpec.SecurityContext.FSGroup == nil || *podSpec.SecurityContext.FSGroup != restrictedRunAsUser {
+		t.Fatalf("fsGroup = %v, want the restricted user", podSpec.SecurityContext.FSGroup)
+	}
+}
+
+func TestReconcilePersistentVolumeClaims(t *testing.T) {
+	server := volumeServer()
+	scheme := newAccessStatusScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "search-index", Namespace: "servers"}
+	getClaim := func() *corev1.PersistentVolumeClaim {
+		t.Helper()
+		claim := &corev1.PersistentVolumeClaim{}
+		if err := c.Get(ctx, key, claim); err != nil {
+			t.Fatalf("get claim: %v", err)
+		}
+		return claim
+	}
+	storage := func(claim *corev1.PersistentVolumeClaim) string {
+		quantity := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		return quantity.String()
+	}
+
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil {
+		t.Fatalf("create claims: %v", err)
+	}
+	claim := getClaim()
+	if storage(claim) != "10Gi" || len(claim.Spec.AccessModes) != 1 || claim.Spec.AccessModes[0] != corev1.ReadWriteOnce {
+		t.Fatalf("claim spec = %#v", claim.Spec)
+	}
+	if !metav1.IsControlledBy(claim, server) || claim.Labels[LabelVolume] != "index" {
+		t.Fatalf("claim metadata = %#v, want owned and labelled", claim.ObjectMeta)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "search-shared", Namespace: "servers"}, &corev1.PersistentVolumeClaim{}); !errors.IsNotFound(err) {
+		t.Fatalf("existing claim reference created a claim: %v", err)
+	}
+
+	template := server.Spec.Volumes[2].PersistentVolumeClaim.Template
+	template.Size = "20Gi"
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil || storage(getClaim()) != "20Gi" {
+		t.Fatalf("grow claim: size %s, %v", storage(getClaim()), err)
+	}
+	template.Size = "5Gi"
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil || storage(getClaim()) != "20Gi" {
+		t.Fatalf("shrink claim: size %s, %v; want the larger size kept", storage(getClaim()), err)
+	}
+
+	template.Retain = true
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil {
+		t.Fatalf("retain claim: %v", err)
+	}
+	if claim := getClaim(); len(claim.OwnerReferences) != 0 {
+		t.Fatalf("owner references = %#v, want none for a retained claim", claim.OwnerReferences)
+	}
+	server.Spec.Volumes = server.Spec.Volumes[:2]
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil {
+		t.Fatalf("remove retained volume: %v", err)
+	}
+	getClaim()
+
+	server.Spec.Volumes = volumeServer().Spec.Volumes
+	server.Spec.Volumes[2].PersistentVolumeClaim.Template.Size = "20Gi"
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil {
+		t.Fatalf("re-own claim: %v", err)
+	}
+	server.Spec.Volumes = server.Spec.Volumes[:2]
+	if err := r.reconcilePersistentVolumeClaims(ctx, server); err != nil {
+		t.Fatalf("remove owned volume: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.PersistentVolumeClaim{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected the owned claim to be deleted, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
 )
 
 // convertToGrant converts an unstructured object to MCPAccessGrant.
@@ -99,6 +101,51 @@ func convertToSessionList(obj interface{}) (*MCPAgentSessionList, error) {
 	return list, nil
 }
 
+// convertToApproval converts an unstructured object to MCPToolApproval.
+func convertToApproval(obj interface{}) (*MCPToolApproval, error) {
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+
+	approval := &MCPToolApproval{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), approval); err != nil {
+		return nil, fmt.Errorf("failed to convert to MCPToolApproval: %w", err)
+	}
+	return approval, nil
+}
+
+// convertToApprovalList converts an unstructured list to MCPToolApprovalList.
+func convertToApprovalList(obj interface{}) (*MCPToolApprovalList, error) {
+	unstructuredList, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+
+	list := &MCPToolApprovalList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIGroup + "/" + APIVersion,
+			Kind:       "MCPToolApprovalList",
+		},
+		ListMeta: metav1.ListMeta{
+			ResourceVersion:    unstructuredList.GetResourceVersion(),
+			Continue:           unstructuredList.GetContinue(),
+			RemainingItemCount: unstructuredList.GetRemainingItemCount(),
+		},
+		Items: make([]MCPToolApproval, 0, len(unstructuredList.Items)),
+	}
+
+	for _, u := range unstructuredList.Items {
+		approval := &MCPToolApproval{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), approval); err != nil {
+			return nil, fmt.Errorf("failed to convert item to MCPToolApproval: %w", err)
+		}
+		list.Items = append(list.Items, *approval)
+	}
+
+	return list, nil
+}
+
 // GrantSummary provides a simplified view of a grant for UI display.
 type GrantSummary struct {
 	Name               string           `json:"name"`
@@ -152,6 +199,48 @@ func ToSessionSummary(session MCPAgentSession) SessionSummary {
 	return summary
 }
 
+// ApprovalSummary provides a simplified view of a tool call approval for UI
+// display.
+type ApprovalSummary struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	ServerRef ServerReference  `json:"serverRef"`
+	Subject   SubjectRef       `json:"subject"`
+	Tool      string           `json:"tool"`
+	Arguments string           `json:"arguments,omitempty"`
+	RiskLevel string           `json:"riskLevel,omitempty"`
+	Phase     string           `json:"phase"`
+	Decision  ApprovalDecision `json:"decision,omitempty"`
+	DecidedBy string           `json:"decidedBy,omitempty"`
+	Comment   string           `json:"comment,omitempty"`
+	ExpiresAt *metav1.Time     `json:"expiresAt,omitempty"`
+	Age       string           `json:"age"`
+}
+
+// ToApprovalSummary converts an MCPToolApproval to an ApprovalSummary. An
+// approval the operator has not reconciled yet is reported Pending.
+func ToApprovalSummary(approval MCPToolApproval) ApprovalSummary {
+	phase := approval.Status.Phase
+	if phase == "" {
+		phase = mcpv1alpha1.ApprovalPhasePending
+	}
+	return ApprovalSummary{
+		Name:      approval.Name,
+		Namespace: approval.Namespace,
+		ServerRef: approval.Spec.ServerRef,
+		Subject:   approval.Spec.Subject,
+		Tool:      approval.Spec.Tool,
+		Arguments: approval.Spec.Arguments,
+		RiskLevel: approval.Spec.RiskLevel,
+		Phase:     phase,
+		Decision:  approval.Spec.Decision,
+		DecidedBy: approval.Spec.DecidedBy,
+		Comment:   approval.Spec.Comment,
+		ExpiresAt: approval.Spec.ExpiresAt,
+		Age:       approval.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
 // ToJSON converts a grant or session to JSON bytes.
 func ToJSON(obj interface{}) ([]byte, error) {
 	return json.MarshalIndent(obj, "", "  ")
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,14 +26,16 @@ const (
 	AccessGrantResource   = mcpv1alpha1.MCPAccessGrantResource
 	AccessSessionResource = mcpv1alpha1.MCPAgentSessionResource
 	MCPServerResource     = mcpv1alpha1.MCPServerResource
+	ToolApprovalResource  = mcpv1alpha1.MCPToolApprovalResource
 	// DefaultMCPResourceNamespace is used when a ServerReference or access resource omits a namespace.
 	DefaultMCPResourceNamespace = "mcp-servers"
 )
 
 var (
-	grantGVR   = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: AccessGrantResource}
-	sessionGVR = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: AccessSessionResource}
-	serverGVR  = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: MCPServerResource}
+	grantGVR    = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: AccessGrantResource}
+	sessionGVR  = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: AccessSessionResource}
+	serverGVR   = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: MCPServerResource}
+	approvalGVR = schema.GroupVersionResource{Group: APIGroup, Version: APIVersion, Resource: ToolApprovalResource}
 )
 
 // Manager provides operations for MCPAccessGrant and MCPAgentSession resources.
@@ -305,6 +308,84 @@ func (m *Manager) patchSession(ctx context.Context, name, namespace string, patc
 	return nil
 }
 
+// ErrApprovalClosed is returned by DecideApproval when the approval was
+// already decided or has expired.
+var ErrApprovalClosed = errors.New("approval is no longer pending")
+
+// ListApprovals returns all MCPToolApproval resources, optionally filtered by namespace.
+func (m *Manager) ListApprovals(ctx context.Context, namespace string) (*MCPToolApprovalList, error) {
+	var obj *unstructured.UnstructuredList
+	var err error
+	if namespace != "" {
+		obj, err = m.dynamic.Resource(approvalGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	} else {
+		obj, err = m.dynamic.Resource(approvalGVR).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	return convertToApprovalList(obj)
+}
+
+// GetApproval returns a specific MCPToolApproval resource.
+func (m *Manager) GetApproval(ctx context.Context, name, namespace string) (*MCPToolApproval, error) {
+	obj, err := m.dynamic.Resource(approvalGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval %s/%s: %w", namespace, name, err)
+	}
+	return convertToApproval(obj)
+}
+
+// DecideApproval records an approver's decision on a pending MCPToolApproval.
+// It returns ErrApprovalClosed when the approval was already decided or has
+// expired. Each patch is pinned to the resourceVersion that was checked, so a
+// concurrent decision is never overwritten; on a conflict the approval is read
+// again and rechecked.
+func (m *Manager) DecideApproval(ctx context.Context, name, namespace string, decision ApprovalDecision, decidedBy, comment string) (*MCPToolApproval, error) {
+	if decision != ApprovalApproved && decision != ApprovalDenied {
+		return nil, fmt.Errorf("invalid approval decision %q", decision)
+	}
+	if strings.TrimSpace(decidedBy) == "" {
+		return nil, fmt.Errorf("approver identity is required")
+	}
+	var lastErr error
+	for attempt := 0; attempt <= maxApplyConflictRetries; attempt++ {
+		approval, err := m.GetApproval(ctx, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		now := metav1.Now()
+		if approval.Spec.Decision != "" || approval.Status.ConsumedAt != nil ||
+			(approval.Spec.ExpiresAt != nil && !now.Before(approval.Spec.ExpiresAt)) {
+			return nil, fmt.Errorf("approval %s/%s: %w", namespace, name, ErrApprovalClosed)
+		}
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"resourceVersion": approval.ResourceVersion,
+			},
+			"spec": map[string]interface{}{
+				"decision":  string(decision),
+				"decidedBy": decidedBy,
+				"decidedAt": now.UTC().Format(time.RFC3339),
+				"comment":   comment,
+			},
+		}
+		patchData, err := json.Marshal(patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal patch: %w", err)
+		}
+		obj, err := m.dynamic.Resource(approvalGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patchData, metav1.PatchOptions{})
+		if err == nil {
+			return convertToApproval(obj)
+		}
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("failed to patch approval %s/%s: %w", namespace, name, err)
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failed to patch approval %s/%s: %w", namespace, name, lastErr)
+}
+
 // maxApplyConflictRetries bounds the Get→Update retry loop in applyUnstructured.
 // Concurrent toggle endpoints (enable/disable, revoke/unrevoke) and the operator
 // status writer can bump the resourceVersion between our Get and Update; retrying
@@ -2,8 +2,10 @@ package access
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -152,6 +154,43 @@ func TestAssertMCPServerRef(t *testing.T) {
 	}
 }
 
+func TestDecideApprovalRecordsDecisionOnce(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := mcpv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+	pending := &mcpv1alpha1.MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-x1", Namespace: "mcp-servers"},
+		Spec: mcpv1alpha1.MCPToolApprovalSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "payments"},
+			Tool:      "refund",
+			ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)},
+		},
+	}
+	expired := pending.DeepCopy()
+	expired.Name = "payments-x2"
+	expired.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	manager := NewManager(dynamicfake.NewSimpleDynamicClient(scheme, pending, expired), nil)
+
+	if _, err := manager.DecideApproval(ctx, "payments-x1", "mcp-servers", ApprovalDecision("maybe"), "bob", ""); err == nil {
+		t.Fatal("DecideApproval accepted an invalid decision")
+	}
+	decided, err := manager.DecideApproval(ctx, "payments-x1", "mcp-servers", ApprovalApproved, "bob", "refund confirmed")
+	if err != nil {
+		t.Fatalf("DecideApproval: %v", err)
+	}
+	if decided.Spec.Decision != ApprovalApproved || decided.Spec.DecidedBy != "bob" || decided.Spec.DecidedAt == nil || decided.Spec.Comment != "refund confirmed" {
+		t.Fatalf("decided approval = %+v", decided.Spec)
+	}
+	if _, err := manager.DecideApproval(ctx, "payments-x1", "mcp-servers", ApprovalDenied, "carol", ""); !errors.Is(err, ErrApprovalClosed) {
+		t.Fatalf("second decision error = %v, want ErrApprovalClosed", err)
+	}
+	if _, err := manager.DecideApproval(ctx, "payments-x2", "mcp-servers", ApprovalApproved, "bob", ""); !errors.Is(err, ErrApprovalClosed) {
+		t.Fatalf("expired approval error = %v, want ErrApprovalClosed", err)
+	}
+}
+
 func TestResolveServerRefNamespace(t *testing.T) {
 	if got := ResolveServerRefNamespace(ServerReference{Namespace: "  \t"}); got != DefaultMCPResourceNamespace {
 		t.Fatalf("whitespace only namespace = %q, want default", got)
@@ -57,15 +57,42 @@ const (
 	DecisionAllow PolicyDecision = "allow"
 	DecisionDeny  PolicyDecision = "deny"
 	DecisionAudit PolicyDecision = "audit"
+	// DecisionRequiresApproval allows a tool call once a human approves it.
+	DecisionRequiresApproval PolicyDecision = "requires_approval"
 )
 
 // ToolRule controls access to an individual MCP tool.
 type ToolRule struct {
+	Name                string               `json:"name"`
+	Decision            PolicyDecision       `json:"decision"`
+	RequiredTrust       TrustLevel           `json:"requiredTrust,omitempty"`
+	ArgumentConstraints []ArgumentConstraint `json:"argumentConstraints,omitempty"`
+}
+
+// PromptRule controls access to an individual MCP prompt.
+type PromptRule struct {
 	Name          string         `json:"name"`
 	Decision      PolicyDecision `json:"decision"`
 	RequiredTrust TrustLevel     `json:"requiredTrust,omitempty"`
 }
 
+// ResourceRule controls access to MCP resources whose URI matches a glob.
+type ResourceRule struct {
+	URI           string         `json:"uri"`
+	Decision      PolicyDecision `json:"decision"`
+	RequiredTrust TrustLevel     `json:"requiredTrust,omitempty"`
+}
+
+// ArgumentConstraint restricts one top-level key of tools/call params.arguments.
+type ArgumentConstraint struct {
+	Argument string   `json:"argument"`
+	OneOf    []string `json:"oneOf,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Minimum  *int64   `json:"minimum,omitempty"`
+	Maximum  *int64   `json:"maximum,omitempty"`
+	Optional bool     `json:"optional,omitempty"`
+}
+
 // SecretKeyRef references a secret key.
 type SecretKeyRef struct {
 	Name string `json:"name"`
@@ -81,6 +108,17 @@ type MCPAccessGrantSpec struct {
 	PolicyVersion      string           `json:"policyVersion,omitempty"`
 	Disabled           bool             `json:"disabled,omitempty"`
 	ToolRules          []ToolRule       `json:"toolRules,omitempty"`
+	PromptRules        []PromptRule     `json:"promptRules,omitempty"`
+	ResourceRules      []ResourceRule   `json:"resourceRules,omitempty"`
+	RateLimits         []RateLimit      `json:"rateLimits,omitempty"`
+}
+
+// RateLimit caps tool calls per subject matched by a grant.
+type RateLimit struct {
+	Tool           string         `json:"tool,omitempty"`
+	SideEffect     ToolSideEffect `json:"sideEffect,omitempty"`
+	CallsPerMinute int32          `json:"callsPerMinute,omitempty"`
+	CallsPerDay    int32          `json:"callsPerDay,omitempty"`
 }
 
 // MCPAccessGrantStatus captures observed grant state.
@@ -139,3 +177,52 @@ type MCPAgentSessionList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []MCPAgentSession `json:"items"`
 }
+
+// ApprovalDecision is an approver's decision on a held tool call.
+type ApprovalDecision string
+
+const (
+	ApprovalApproved ApprovalDecision = "approved"
+	ApprovalDenied   ApprovalDecision = "denied"
+)
+
+// MCPToolApprovalSpec is a tool call the gateway held for approval and the
+// decision on it.
+type MCPToolApprovalSpec struct {
+	ServerRef       ServerReference  `json:"serverRef"`
+	Subject         SubjectRef       `json:"subject"`
+	SessionID       string           `json:"sessionID,omitempty"`
+	Tool            string           `json:"tool"`
+	Arguments       string           `json:"arguments,omitempty"`
+	ArgumentsDigest string           `json:"argumentsDigest,omitempty"`
+	RiskLevel       string           `json:"riskLevel,omitempty"`
+	MatchedGrant    string           `json:"matchedGrant,omitempty"`
+	ExpiresAt       *metav1.Time     `json:"expiresAt,omitempty"`
+	Decision        ApprovalDecision `json:"decision,omitempty"`
+	DecidedBy       string           `json:"decidedBy,omitempty"`
+	DecidedAt       *metav1.Time     `json:"decidedAt,omitempty"`
+	Comment         string           `json:"comment,omitempty"`
+}
+
+// MCPToolApprovalStatus captures observed approval state.
+type MCPToolApprovalStatus struct {
+	Phase      string       `json:"phase,omitempty"`
+	Message    string       `json:"message,omitempty"`
+	ConsumedAt *metav1.Time `json:"consumedAt,omitempty"`
+}
+
+// MCPToolApproval is a request for a human decision on a held tool call.
+type MCPToolApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPToolApprovalSpec   `json:"spec,omitempty"`
+	Status MCPToolApprovalStatus `json:"status,omitempty"`
+}
+
+// MCPToolApprovalList contains a list of MCPToolApproval.
+type MCPToolApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPToolApproval `json:"items"`
+}
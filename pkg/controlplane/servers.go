@@ -9,11 +9,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 
 	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/pkg/operatorutil"
 )
 
 const stableDeploymentSelector = "app.kubernetes.io/managed-by=mcp-runtime,mcpruntime.org/rollout-track=stable"
@@ -269,27 +271,37 @@ func ServerInfoFromMCPServer(mcpServer mcpv1alpha1.MCPServer, deploymentStatus S
 		}
 		trustDomain = strings.TrimSpace(mcpServer.Spec.Auth.TrustDomain)
 	}
+	imageDigest, imageVerification := "", ""
+	if mcpServer.Status.Image != nil {
+		imageDigest = mcpServer.Status.Image.Digest
+	}
+	if condition := meta.FindStatusCondition(mcpServer.Status.Conditions, string(operatorutil.ImageVerified)); condition != nil &&
+		condition.Status == metav1.ConditionFalse && condition.ObservedGeneration == mcpServer.Generation {
+		imageVerification = condition.Message
+	}
 	return ServerInfo{
-		Name:        mcpServer.Name,
-		Namespace:   mcpServer.Namespace,
-		UID:         string(mcpServer.UID),
-		TeamID:      strings.TrimSpace(mcpServer.Spec.TeamID),
-		Image:       strings.TrimSpace(mcpServer.Spec.Image),
-		ImageTag:    strings.TrimSpace(mcpServer.Spec.ImageTag),
-		Description: mcpServer.Spec.Description,
-		Ready:       deploymentStatus.Ready,
-		Status:      deploymentStatus.Status,
-		Labels:      mcpServer.Labels,
-		Age:         mcpServer.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
-		Endpoint:    PublicMCPEndpoint(mcpServer),
-		AuthMode:    authMode,
-		TrustDomain: trustDomain,
-		ServicePort: mcpServer.Spec.ServicePort,
-		Generation:  mcpServer.Generation,
-		Tools:       mcpServer.Spec.Tools,
-		Prompts:     inventoryItemsOrEmpty(mcpServer.Spec.Prompts),
-		Resources:   inventoryItemsOrEmpty(mcpServer.Spec.MCPResources),
-		Tasks:       inventoryItemsOrEmpty(mcpServer.Spec.Tasks),
+		Name:              mcpServer.Name,
+		Namespace:         mcpServer.Namespace,
+		UID:               string(mcpServer.UID),
+		TeamID:            strings.TrimSpace(mcpServer.Spec.TeamID),
+		Image:             strings.TrimSpace(mcpServer.Spec.Image),
+		ImageTag:          strings.TrimSpace(mcpServer.Spec.ImageTag),
+		ImageDigest:       imageDigest,
+		ImageVerification: imageVerification,
+		Description:       mcpServer.Spec.Description,
+		Ready:             deploymentStatus.Ready,
+		Status:            deploymentStatus.Status,
+		Labels:            mcpServer.Labels,
+		Age:               mcpServer.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
+		Endpoint:          PublicMCPEndpoint(mcpServer),
+		AuthMode:          authMode,
+		TrustDomain:       trustDomain,
+		ServicePort:       mcpServer.Spec.ServicePort,
+		Generation:        mcpServer.Generation,
+		Tools:             mcpServer.Spec.Tools,
+		Prompts:           inventoryItemsOrEmpty(mcpServer.Spec.Prompts),
+		Resources:         inventoryItemsOrEmpty(mcpServer.Spec.MCPResources),
+		Tasks:             inventoryItemsOrEmpty(mcpServer.Spec.Tasks),
 	}
 }
 
@@ -203,6 +203,33 @@ func TestApplyServerCreatesAndUpdatesMCPServer(t *testing.T) {
 	}
 }
 
+func TestServerInfoFromMCPServerReportsImageVerification(t *testing.T) {
+	server := mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "mcp-servers", Generation: 4},
+		Status: mcpv1alpha1.MCPServerStatus{
+			Phase: "Error",
+			Image: &mcpv1alpha1.ImageStatus{Digest: "sha256:abc"},
+			Conditions: []metav1.Condition{{
+				Type:               "ImageVerified",
+				Status:             metav1.ConditionFalse,
+				Reason:             "SignatureInvalid",
+				Message:            "registry/demo:v2 is not signed",
+				ObservedGeneration: 4,
+			}},
+		},
+	}
+	info := ServerInfoFromMCPServer(server, ServerDeploymentStatus{})
+	if info.ImageDigest != "sha256:abc" || info.ImageVerification != "registry/demo:v2 is not signed" {
+		t.Fatalf("image fields = %q, %q", info.ImageDigest, info.ImageVerification)
+	}
+
+	// A failure recorded for an older generation is stale.
+	server.Generation = 5
+	if info := ServerInfoFromMCPServer(server, ServerDeploymentStatus{}); info.ImageVerification != "" {
+		t.Fatalf("stale image verification = %q", info.ImageVerification)
+	}
+}
+
 func TestPublicMCPEndpointHonorsPlatformDomain(t *testing.T) {
 	t.Setenv("MCP_MCP_INGRESS_HOST", "")
 	t.Setenv("MCP_PLATFORM_DOMAIN", "example.com")
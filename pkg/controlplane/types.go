@@ -5,26 +5,31 @@ import mcpv1alpha1 "mcp-runtime/api/v1alpha1"
 // ServerInfo is the control-plane projection of an MCPServer and its backing
 // workload status.
 type ServerInfo struct {
-	Name        string                      `json:"name"`
-	Namespace   string                      `json:"namespace"`
-	UID         string                      `json:"uid,omitempty"`
-	TeamID      string                      `json:"team_id,omitempty"`
-	Image       string                      `json:"image,omitempty"`
-	ImageTag    string                      `json:"imageTag,omitempty"`
-	Description string                      `json:"description,omitempty"`
-	Ready       string                      `json:"ready"`
-	Status      string                      `json:"status"`
-	Labels      map[string]string           `json:"labels,omitempty"`
-	Age         string                      `json:"age"`
-	Endpoint    string                      `json:"endpoint,omitempty"`
-	AuthMode    mcpv1alpha1.AuthMode        `json:"authMode,omitempty"`
-	TrustDomain string                      `json:"trustDomain,omitempty"`
-	ServicePort int32                       `json:"servicePort,omitempty"`
-	Generation  int64                       `json:"generation,omitempty"`
-	Tools       []mcpv1alpha1.ToolConfig    `json:"tools,omitempty"`
-	Prompts     []mcpv1alpha1.InventoryItem `json:"prompts"`
-	Resources   []mcpv1alpha1.InventoryItem `json:"resources"`
-	Tasks       []mcpv1alpha1.InventoryItem `json:"tasks"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid,omitempty"`
+	TeamID    string `json:"team_id,omitempty"`
+	Image     string `json:"image,omitempty"`
+	ImageTag  string `json:"imageTag,omitempty"`
+	// ImageDigest is the digest a pinned server runs, from status.image.
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ImageVerification is why digest resolution or signature verification
+	// of the current generation failed; empty when it passed or is off.
+	ImageVerification string                      `json:"imageVerification,omitempty"`
+	Description       string                      `json:"description,omitempty"`
+	Ready             string                      `json:"ready"`
+	Status            string                      `json:"status"`
+	Labels            map[string]string           `json:"labels,omitempty"`
+	Age               string                      `json:"age"`
+	Endpoint          string                      `json:"endpoint,omitempty"`
+	AuthMode          mcpv1alpha1.AuthMode        `json:"authMode,omitempty"`
+	TrustDomain       string                      `json:"trustDomain,omitempty"`
+	ServicePort       int32                       `json:"servicePort,omitempty"`
+	Generation        int64                       `json:"generation,omitempty"`
+	Tools             []mcpv1alpha1.ToolConfig    `json:"tools,omitempty"`
+	Prompts           []mcpv1alpha1.InventoryItem `json:"prompts"`
+	Resources         []mcpv1alpha1.InventoryItem `json:"resources"`
+	Tasks             []mcpv1alpha1.InventoryItem `json:"tasks"`
 }
 
 // ServerDeploymentStatus summarizes readiness for the Deployment that backs an
@@ -2,6 +2,7 @@ package metadata
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 
@@ -27,6 +28,7 @@ func GenerateCRD(server *ServerMetadata, outputPath string) error {
 		Spec: mcpv1alpha1.MCPServerSpec{
 			Description: server.Description,
 			TeamID:      server.TeamID,
+			ClassName:   server.ClassName,
 			Image:       imageRefForClusterPull(server.Image),
 			ImageTag:    server.ImageTag,
 			Port:        server.Port,
@@ -113,10 +115,11 @@ func GenerateCRD(server *ServerMetadata, outputPath string) error {
 
 	if server.Policy != nil {
 		mcpServer.Spec.Policy = &mcpv1alpha1.PolicyConfig{
-			Mode:            mcpv1alpha1.PolicyMode(server.Policy.Mode),
-			DefaultDecision: mcpv1alpha1.PolicyDecision(server.Policy.DefaultDecision),
-			EnforceOn:       server.Policy.EnforceOn,
-			PolicyVersion:   server.Policy.PolicyVersion,
+			Mode:                mcpv1alpha1.PolicyMode(server.Policy.Mode),
+			DefaultDecision:     mcpv1alpha1.PolicyDecision(server.Policy.DefaultDecision),
+			EnforceOn:           server.Policy.EnforceOn,
+			PolicyVersion:       server.Policy.PolicyVersion,
+			FilterListResponses: server.Policy.FilterListResponses,
 		}
 	}
 
@@ -144,6 +147,21 @@ func GenerateCRD(server *ServerMetadata, outputPath string) error {
 		}
 	}
 
+	mcpServer.Spec.Volumes = ConvertVolumes(server.Volumes)
+	mcpServer.Spec.VolumeMounts = ConvertVolumeMounts(server.VolumeMounts)
+	mcpServer.Spec.Transport = mcpv1alpha1.ServerTransport(server.Transport)
+	mcpServer.Spec.Stdio = ConvertStdio(server.Stdio)
+	mcpServer.Spec.InitContainers = ConvertContainers(server.InitContainers)
+	mcpServer.Spec.ExtraContainers = ConvertContainers(server.ExtraContainers)
+	mcpServer.Spec.Scheduling = ConvertScheduling(server.Scheduling)
+	mcpServer.Spec.Network = ConvertNetwork(server.Network)
+	if server.DisruptionBudget != nil {
+		mcpServer.Spec.DisruptionBudget = &mcpv1alpha1.DisruptionBudgetConfig{
+			Enabled:        server.DisruptionBudget.Enabled,
+			MaxUnavailable: server.DisruptionBudget.MaxUnavailable,
+		}
+	}
+
 	if server.Rollout != nil {
 		mcpServer.Spec.Rollout = &mcpv1alpha1.RolloutConfig{
 			Strategy:       mcpv1alpha1.RolloutStrategy(server.Rollout.Strategy),
@@ -201,8 +219,10 @@ func convertInventoryItems(items []InventoryItem) []mcpv1alpha1.InventoryItem {
 	converted := make([]mcpv1alpha1.InventoryItem, 0, len(items))
 	for _, item := range items {
 		mcpItem := mcpv1alpha1.InventoryItem{
-			Name:        item.Name,
-			Description: item.Description,
+			Name:          item.Name,
+			Description:   item.Description,
+			URI:           item.URI,
+			RequiredTrust: mcpv1alpha1.TrustLevel(item.RequiredTrust),
 		}
 		if len(item.Labels) > 0 {
 			mcpItem.Labels = make(map[string]string, len(item.Labels))
@@ -215,6 +235,252 @@ func convertInventoryItems(items []InventoryItem) []mcpv1alpha1.InventoryItem {
 	return converted
 }
 
+// ConvertVolumes converts metadata volumes to MCPServer volumes.
+func ConvertVolumes(volumes []Volume) []mcpv1alpha1.Volume {
+	if len(volumes) == 0 {
+		return nil
+	}
+	converted := make([]mcpv1alpha1.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		out := mcpv1alpha1.Volume{Name: volume.Name}
+		if volume.ConfigMap != nil {
+			out.ConfigMap = &mcpv1alpha1.ConfigMapVolumeSource{
+				Name:     volume.ConfigMap.Name,
+				Items:    convertKeyToPaths(volume.ConfigMap.Items),
+				Optional: volume.ConfigMap.Optional,
+			}
+		}
+		if volume.Secret != nil {
+			out.Secret = &mcpv1alpha1.SecretVolumeSource{
+				SecretName: volume.Secret.SecretName,
+				Items:      convertKeyToPaths(volume.Secret.Items),
+				Optional:   volume.Secret.Optional,
+			}
+		}
+		if volume.EmptyDir != nil {
+			out.EmptyDir = &mcpv1alpha1.EmptyDirVolumeSource{
+				Medium:    volume.EmptyDir.Medium,
+				SizeLimit: volume.EmptyDir.SizeLimit,
+			}
+		}
+		if claim := volume.PersistentVolumeClaim; claim != nil {
+			out.PersistentVolumeClaim = &mcpv1alpha1.PersistentVolumeClaimVolumeSource{ClaimName: claim.ClaimName}
+			if claim.Template != nil {
+				out.PersistentVolumeClaim.Template = &mcpv1alpha1.PersistentVolumeClaimTemplate{
+					Size:             claim.Template.Size,
+					StorageClassName: claim.Template.StorageClassName,
+					AccessModes:      append([]string(nil), claim.Template.AccessModes...),
+					Retain:           claim.Template.Retain,
+				}
+			}
+		}
+		converted = append(converted, out)
+	}
+	return converted
+}
+
+// ConvertVolumeMounts converts metadata volume mounts to MCPServer mounts.
+func ConvertVolumeMounts(mounts []VolumeMount) []mcpv1alpha1.VolumeMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+	converted := make([]mcpv1alpha1.VolumeMount, 0, len(mounts))
+	for _, mount := range mounts {
+		converted = append(converted, mcpv1alpha1.VolumeMount{
+			Name:      mount.Name,
+			MountPath: mount.MountPath,
+			SubPath:   mount.SubPath,
+			ReadOnly:  mount.ReadOnly,
+		})
+	}
+	return converted
+}
+
+// ConvertContainers converts metadata init or extra containers to MCPServer
+// containers.
+func ConvertContainers(containers []Container) []mcpv1alpha1.Container {
+	if len(containers) == 0 {
+		return nil
+	}
+	converted := make([]mcpv1alpha1.Container, 0, len(containers))
+	for _, container := range containers {
+		out := mcpv1alpha1.Container{
+			Name:         container.Name,
+			Image:        container.Image,
+			Command:      append([]string(nil), container.Command...),
+			Args:         append([]string(nil), container.Args...),
+			VolumeMounts: ConvertVolumeMounts(container.VolumeMounts),
+		}
+		for _, env := range container.EnvVars {
+			out.EnvVars = append(out.EnvVars, mcpv1alpha1.EnvVar{Name: env.Name, Value: env.Value})
+		}
+		for _, env := range container.SecretEnvVars {
+			secretEnv := mcpv1alpha1.SecretEnvVar{Name: env.Name}
+			if env.SecretKeyRef != nil {
+				secretEnv.SecretKeyRef = &mcpv1alpha1.SecretKeyRef{Name: env.SecretKeyRef.Name, Key: env.SecretKeyRef.Key}
+			}
+			out.SecretEnvVars = append(out.SecretEnvVars, secretEnv)
+		}
+		if container.Resources != nil {
+			out.Resources = *convertResourceRequirements(container.Resources)
+		}
+		for _, port := range container.Ports {
+			out.Ports = append(out.Ports, mcpv1alpha1.ContainerPort{
+				Name:          port.Name,
+				ContainerPort: port.ContainerPort,
+				Protocol:      port.Protocol,
+				Expose:        port.Expose,
+			})
+		}
+		converted = append(converted, out)
+	}
+	return converted
+}
+
+// ConvertStdio converts a metadata stdio command to the MCPServer one.
+func ConvertStdio(stdio *StdioConfig) *mcpv1alpha1.StdioConfig {
+	if stdio == nil {
+		return nil
+	}
+	return &mcpv1alpha1.StdioConfig{
+		Command:     append([]string(nil), stdio.Command...),
+		Args:        append([]string(nil), stdio.Args...),
+		SessionMode: mcpv1alpha1.StdioSessionMode(stdio.SessionMode),
+		MaxSessions: stdio.MaxSessions,
+	}
+}
+
+// ConvertScheduling converts metadata scheduling settings to MCPServer ones.
+func ConvertScheduling(scheduling *SchedulingConfig) *mcpv1alpha1.SchedulingConfig {
+	if scheduling == nil {
+		return nil
+	}
+	out := &mcpv1alpha1.SchedulingConfig{
+		NodeSelector:      maps.Clone(scheduling.NodeSelector),
+		PriorityClassName: scheduling.PriorityClassName,
+	}
+	for _, toleration := range scheduling.Tolerations {
+		out.Tolerations = append(out.Tolerations, mcpv1alpha1.Toleration{
+			Key:               toleration.Key,
+			Operator:          toleration.Operator,
+			Value:             toleration.Value,
+			Effect:            toleration.Effect,
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+	if affinity := scheduling.Affinity; affinity != nil {
+		out.Affinity = &mcpv1alpha1.Affinity{
+			PodAffinity:     convertPodAffinity(affinity.PodAffinity),
+			PodAntiAffinity: convertPodAffinity(affinity.PodAntiAffinity),
+		}
+		if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+			out.Affinity.NodeAffinity = &mcpv1alpha1.NodeAffinity{}
+			for _, term := range nodeAffinity.Required {
+				out.Affinity.NodeAffinity.Required = append(out.Affinity.NodeAffinity.Required, convertNodeSelectorTerm(term))
+			}
+			for _, term := range nodeAffinity.Preferred {
+				out.Affinity.NodeAffinity.Preferred = append(out.Affinity.NodeAffinity.Preferred, mcpv1alpha1.PreferredSchedulingTerm{
+					Weight:     term.Weight,
+					Preference: convertNodeSelectorTerm(term.Preference),
+				})
+			}
+		}
+	}
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		out.TopologySpreadConstraints = append(out.TopologySpreadConstraints, mcpv1alpha1.TopologySpreadConstraint{
+			MaxSkew:           constraint.MaxSkew,
+			TopologyKey:       constraint.TopologyKey,
+			WhenUnsatisfiable: constraint.WhenUnsatisfiable,
+			MatchLabels:       maps.Clone(constraint.MatchLabels),
+		})
+	}
+	return out
+}
+
+// ConvertNetwork converts metadata network settings to MCPServer ones.
+func ConvertNetwork(network *NetworkConfig) *mcpv1alpha1.NetworkConfig {
+	if network == nil {
+		return nil
+	}
+	out := &mcpv1alpha1.NetworkConfig{}
+	if egress := network.Egress; egress != nil {
+		out.Egress = &mcpv1alpha1.EgressConfig{DefaultDeny: egress.DefaultDeny}
+		for _, rule := range egress.Rules {
+			converted := mcpv1alpha1.EgressRule{}
+			for _, peer := range rule.To {
+				converted.To = append(converted.To, mcpv1alpha1.EgressPeer{
+					CIDR:              peer.CIDR,
+					Except:            append([]string(nil), peer.Except...),
+					NamespaceSelector: maps.Clone(peer.NamespaceSelector),
+					PodSelector:       maps.Clone(peer.PodSelector),
+				})
+			}
+			for _, port := range rule.Ports {
+				converted.Ports = append(converted.Ports, mcpv1alpha1.NetworkPort{
+					Protocol: port.Protocol,
+					Port:     port.Port,
+					EndPort:  port.EndPort,
+				})
+			}
+			out.Egress.Rules = append(out.Egress.Rules, converted)
+		}
+	}
+	return out
+}
+
+func convertNodeSelectorTerm(term NodeSelectorTerm) mcpv1alpha1.NodeSelectorTerm {
+	return mcpv1alpha1.NodeSelectorTerm{MatchExpressions: convertLabelSelectorRequirements(term.MatchExpressions)}
+}
+
+func convertPodAffinity(affinity *PodAffinity) *mcpv1alpha1.PodAffinity {
+	if affinity == nil {
+		return nil
+	}
+	out := &mcpv1alpha1.PodAffinity{}
+	for _, term := range affinity.Required {
+		out.Required = append(out.Required, convertPodAffinityTerm(term))
+	}
+	for _, term := range affinity.Preferred {
+		out.Preferred = append(out.Preferred, mcpv1alpha1.WeightedPodAffinityTerm{
+			Weight:          term.Weight,
+			PodAffinityTerm: convertPodAffinityTerm(term.PodAffinityTerm),
+		})
+	}
+	return out
+}
+
+func convertPodAffinityTerm(term PodAffinityTerm) mcpv1alpha1.PodAffinityTerm {
+	return mcpv1alpha1.PodAffinityTerm{
+		MatchLabels:      maps.Clone(term.MatchLabels),
+		MatchExpressions: convertLabelSelectorRequirements(term.MatchExpressions),
+		TopologyKey:      term.TopologyKey,
+		Namespaces:       append([]string(nil), term.Namespaces...),
+	}
+}
+
+func convertLabelSelectorRequirements(requirements []LabelSelectorRequirement) []mcpv1alpha1.LabelSelectorRequirement {
+	var converted []mcpv1alpha1.LabelSelectorRequirement
+	for _, requirement := range requirements {
+		converted = append(converted, mcpv1alpha1.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: requirement.Operator,
+			Values:   append([]string(nil), requirement.Values...),
+		})
+	}
+	return converted
+}
+
+func convertKeyToPaths(items []KeyToPath) []mcpv1alpha1.KeyToPath {
+	if len(items) == 0 {
+		return nil
+	}
+	converted := make([]mcpv1alpha1.KeyToPath, 0, len(items))
+	for _, item := range items {
+		converted = append(converted, mcpv1alpha1.KeyToPath{Key: item.Key, Path: item.Path})
+	}
+	return converted
+}
+
 func convertResourceRequirements(resources *ResourceRequirements) *mcpv1alpha1.ResourceRequirements {
 	if resources == nil {
 		return nil
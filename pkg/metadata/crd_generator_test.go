@@ -31,6 +31,7 @@ func TestGenerateCRD(t *testing.T) {
 			Namespace:        "custom-ns",
 			Scope:            PublishScopeOrg,
 			TeamID:           "team-custom",
+			ClassName:        "restricted",
 		}
 
 		err := GenerateCRD(server, outputPath)
@@ -52,6 +53,7 @@ func TestGenerateCRD(t *testing.T) {
 		assertContains(t, content, "namespace: custom-ns")
 		assertContains(t, content, "mcpruntime.org/scope: org")
 		assertContains(t, content, "teamID: team-custom")
+		assertContains(t, content, "className: restricted")
 		assertContains(t, content, "description: Test server for CRD generation.")
 		assertContains(t, content, "image: my-image")
 		assertContains(t, content, "imageTag: v1.0.0")
@@ -130,6 +132,113 @@ func TestGenerateCRD(t *testing.T) {
 		assertContains(t, content, "value: debug")
 	})
 
+	t.Run("generates CRD with volumes", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		outputPath := filepath.Join(tmpDir, "volume-server.yaml")
+
+		server := &ServerMetadata{
+			Name:      "volume-server",
+			Image:     "my-image",
+			Namespace: "default",
+			Volumes: []Volume{
+				{Name: "config", ConfigMap: &ConfigMapVolumeSource{Name: "server-config", Items: []KeyToPath{{Key: "config.yaml", Path: "config.yaml"}}}},
+				{Name: "index", PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{Template: &PersistentVolumeClaimTemplate{Size: "10Gi", Retain: true}}},
+			},
+			VolumeMounts: []VolumeMount{
+				{Name: "config", MountPath: "/etc/server", ReadOnly: true},
+				{Name: "index", MountPath: "/var/lib/index"},
+			},
+		}
+
+		if err := GenerateCRD(server, outputPath); err != nil {
+			t.Fatalf("GenerateCRD failed: %v", err)
+		}
+
+		data, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatalf("failed to read output file: %v", err)
+		}
+
+		content := string(data)
+		assertContains(t, content, "volumes:")
+		assertContains(t, content, "name: server-config")
+		assertContains(t, content, "key: config.yaml")
+		assertContains(t, content, "size: 10Gi")
+		assertContains(t, content, "retain: true")
+		assertContains(t, content, "mountPath: /etc/server")
+		assertContains(t, content, "readOnly: true")
+	})
+
+	t.Run("generates CRD with scheduling and disruption budget", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		outputPath := filepath.Join(tmpDir, "scheduled-server.yaml")
+
+		server := &ServerMetadata{
+			Name:      "scheduled-server",
+			Image:     "my-image",
+			Namespace: "default",
+			Scheduling: &SchedulingConfig{
+				NodeSelector: map[string]string{"pool": "mcp"},
+				Tolerations:  []Toleration{{Key: "dedicated", Value: "mcp", Effect: "NoSchedule"}},
+				Affinity: &Affinity{PodAntiAffinity: &PodAffinity{Preferred: []WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+				}}},
+				TopologySpreadConstraints: []TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone"}},
+				PriorityClassName:         "mcp-critical",
+			},
+			DisruptionBudget: &DisruptionBudgetConfig{Enabled: true, MaxUnavailable: "25%"},
+		}
+
+		if err := GenerateCRD(server, outputPath); err != nil {
+			t.Fatalf("GenerateCRD failed: %v", err)
+		}
+
+		data, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatalf("failed to read output file: %v", err)
+		}
+
+		content := string(data)
+		assertContains(t, content, "pool: mcp")
+		assertContains(t, content, "effect: NoSchedule")
+		assertContains(t, content, "topologyKey: kubernetes.io/hostname")
+		assertContains(t, content, "topologyKey: topology.kubernetes.io/zone")
+		assertContains(t, content, "priorityClassName: mcp-critical")
+		assertContains(t, content, "maxUnavailable: 25%")
+	})
+
+	t.Run("generates CRD with egress rules", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		outputPath := filepath.Join(tmpDir, "egress-server.yaml")
+
+		server := &ServerMetadata{
+			Name:      "egress-server",
+			Image:     "my-image",
+			Namespace: "default",
+			Network: &NetworkConfig{Egress: &EgressConfig{
+				DefaultDeny: true,
+				Rules: []EgressRule{{
+					To:    []EgressPeer{{CIDR: "10.20.0.0/16"}},
+					Ports: []NetworkPort{{Port: 5432}},
+				}},
+			}},
+		}
+
+		if err := GenerateCRD(server, outputPath); err != nil {
+			t.Fatalf("GenerateCRD failed: %v", err)
+		}
+
+		data, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatalf("failed to read output file: %v", err)
+		}
+
+		content := string(data)
+		assertContains(t, content, "defaultDeny: true")
+		assertContains(t, content, "cidr: 10.20.0.0/16")
+		assertContains(t, content, "port: 5432")
+	})
+
 	t.Run("generates CRD with gateway and analytics", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		outputPath := filepath.Join(tmpDir, "gateway-server.yaml")
@@ -96,6 +96,12 @@ type ServerMetadata struct {
 	// Port is the port the container listens on (defaults to 8088).
 	Port int32 `yaml:"port,omitempty" json:"port,omitempty"`
 
+	// Transport is http (the default) or stdio.
+	Transport string `yaml:"transport,omitempty" json:"transport,omitempty"`
+
+	// Stdio is the server command for transport stdio.
+	Stdio *StdioConfig `yaml:"stdio,omitempty" json:"stdio,omitempty"`
+
 	// Replicas is the number of desired replicas (defaults to 1).
 	Replicas *int32 `yaml:"replicas,omitempty" json:"replicas,omitempty"`
 
@@ -108,6 +114,18 @@ type ServerMetadata struct {
 	// SecretEnvVars are secret-backed environment variables to pass to the container.
 	SecretEnvVars []SecretEnvVar `yaml:"secretEnvVars,omitempty" json:"secretEnvVars,omitempty"`
 
+	// Volumes are pod volumes the server container can mount.
+	Volumes []Volume `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+
+	// VolumeMounts mount Volumes into the server container.
+	VolumeMounts []VolumeMount `yaml:"volumeMounts,omitempty" json:"volumeMounts,omitempty"`
+
+	// InitContainers run to completion before the server container starts.
+	InitContainers []Container `yaml:"initContainers,omitempty" json:"initContainers,omitempty"`
+
+	// ExtraContainers run next to the server container.
+	ExtraContainers []Container `yaml:"extraContainers,omitempty" json:"extraContainers,omitempty"`
+
 	// Namespace is the Kubernetes namespace (defaults to "mcp-servers").
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
 
@@ -117,6 +135,10 @@ type ServerMetadata struct {
 	// TeamID is the stable platform team identifier that owns the server.
 	TeamID string `yaml:"teamID,omitempty" json:"teamID,omitempty"`
 
+	// ClassName selects the MCPServerClass whose defaults and constraints
+	// apply on the cluster.
+	ClassName string `yaml:"className,omitempty" json:"className,omitempty"`
+
 	// Tools describes the MCP tool inventory exposed by the server.
 	Tools []ToolConfig `yaml:"tools,omitempty" json:"tools,omitempty"`
 
@@ -146,6 +168,15 @@ type ServerMetadata struct {
 
 	// Rollout configures deployment rollout behavior.
 	Rollout *RolloutConfig `yaml:"rollout,omitempty" json:"rollout,omitempty"`
+
+	// Scheduling places the server's pods on nodes.
+	Scheduling *SchedulingConfig `yaml:"scheduling,omitempty" json:"scheduling,omitempty"`
+
+	// DisruptionBudget configures an operator-managed PodDisruptionBudget.
+	DisruptionBudget *DisruptionBudgetConfig `yaml:"disruptionBudget,omitempty" json:"disruptionBudget,omitempty"`
+
+	// Network restricts the server pods' egress.
+	Network *NetworkConfig `yaml:"network,omitempty" json:"network,omitempty"`
 }
 
 // ResourceRequirements defines resource limits and requests.
@@ -172,6 +203,95 @@ type SecretEnvVar struct {
 	SecretKeyRef *SecretKeyRef `yaml:"secretKeyRef,omitempty" json:"secretKeyRef,omitempty"`
 }
 
+// Volume is a pod volume for the server container. Exactly one source must be
+// set.
+type Volume struct {
+	Name                  string                             `yaml:"name" json:"name"`
+	ConfigMap             *ConfigMapVolumeSource             `yaml:"configMap,omitempty" json:"configMap,omitempty"`
+	Secret                *SecretVolumeSource                `yaml:"secret,omitempty" json:"secret,omitempty"`
+	EmptyDir              *EmptyDirVolumeSource              `yaml:"emptyDir,omitempty" json:"emptyDir,omitempty"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `yaml:"persistentVolumeClaim,omitempty" json:"persistentVolumeClaim,omitempty"`
+}
+
+// ConfigMapVolumeSource mounts the keys of a ConfigMap as files.
+type ConfigMapVolumeSource struct {
+	Name     string      `yaml:"name" json:"name"`
+	Items    []KeyToPath `yaml:"items,omitempty" json:"items,omitempty"`
+	Optional bool        `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// SecretVolumeSource mounts the keys of a Secret as files.
+type SecretVolumeSource struct {
+	SecretName string      `yaml:"secretName" json:"secretName"`
+	Items      []KeyToPath `yaml:"items,omitempty" json:"items,omitempty"`
+	Optional   bool        `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// KeyToPath maps a ConfigMap or Secret key to a file in the volume.
+type KeyToPath struct {
+	Key  string `yaml:"key" json:"key"`
+	Path string `yaml:"path" json:"path"`
+}
+
+// EmptyDirVolumeSource configures pod scratch space.
+type EmptyDirVolumeSource struct {
+	Medium    string `yaml:"medium,omitempty" json:"medium,omitempty"`
+	SizeLimit string `yaml:"sizeLimit,omitempty" json:"sizeLimit,omitempty"`
+}
+
+// PersistentVolumeClaimVolumeSource mounts an existing claim or one the
+// operator creates from Template.
+type PersistentVolumeClaimVolumeSource struct {
+	ClaimName string                         `yaml:"claimName,omitempty" json:"claimName,omitempty"`
+	Template  *PersistentVolumeClaimTemplate `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+// PersistentVolumeClaimTemplate describes an operator-managed claim.
+type PersistentVolumeClaimTemplate struct {
+	Size             string   `yaml:"size" json:"size"`
+	StorageClassName *string  `yaml:"storageClassName,omitempty" json:"storageClassName,omitempty"`
+	AccessModes      []string `yaml:"accessModes,omitempty" json:"accessModes,omitempty"`
+	Retain           bool     `yaml:"retain,omitempty" json:"retain,omitempty"`
+}
+
+// VolumeMount mounts a Volume into the server container.
+type VolumeMount struct {
+	Name      string `yaml:"name" json:"name"`
+	MountPath string `yaml:"mountPath" json:"mountPath"`
+	SubPath   string `yaml:"subPath,omitempty" json:"subPath,omitempty"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+}
+
+// StdioConfig is the command line of a stdio-only MCP server.
+type StdioConfig struct {
+	Command     []string `yaml:"command" json:"command"`
+	Args        []string `yaml:"args,omitempty" json:"args,omitempty"`
+	SessionMode string   `yaml:"sessionMode,omitempty" json:"sessionMode,omitempty"`
+	MaxSessions int32    `yaml:"maxSessions,omitempty" json:"maxSessions,omitempty"`
+}
+
+// Container is an init or extra container in the server pods.
+type Container struct {
+	Name          string                `yaml:"name" json:"name"`
+	Image         string                `yaml:"image" json:"image"`
+	Command       []string              `yaml:"command,omitempty" json:"command,omitempty"`
+	Args          []string              `yaml:"args,omitempty" json:"args,omitempty"`
+	EnvVars       []EnvVar              `yaml:"envVars,omitempty" json:"envVars,omitempty"`
+	SecretEnvVars []SecretEnvVar        `yaml:"secretEnvVars,omitempty" json:"secretEnvVars,omitempty"`
+	Resources     *ResourceRequirements `yaml:"resources,omitempty" json:"resources,omitempty"`
+	VolumeMounts  []VolumeMount         `yaml:"volumeMounts,omitempty" json:"volumeMounts,omitempty"`
+	Ports         []ContainerPort       `yaml:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// ContainerPort is a port an extra container listens on. Expose adds it to
+// the server's Service.
+type ContainerPort struct {
+	Name          string `yaml:"name" json:"name"`
+	ContainerPort int32  `yaml:"containerPort" json:"containerPort"`
+	Protocol      string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Expose        bool   `yaml:"expose,omitempty" json:"expose,omitempty"`
+}
+
 // ToolConfig describes one MCP tool exposed by a server.
 type ToolConfig struct {
 	Name          string            `yaml:"name" json:"name"`
@@ -187,6 +307,10 @@ type InventoryItem struct {
 	Name        string            `yaml:"name" json:"name"`
 	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
 	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// URI is a glob matched against resource URIs; it only applies to mcpResources.
+	URI string `yaml:"uri,omitempty" json:"uri,omitempty"`
+	// RequiredTrust is the minimum trust to fetch the prompt or read the resource.
+	RequiredTrust TrustLevel `yaml:"requiredTrust,omitempty" json:"requiredTrust,omitempty"`
 }
 
 // AuthConfig configures how identities are extracted at the gateway.
@@ -204,10 +328,11 @@ type AuthConfig struct {
 
 // PolicyConfig configures authorization behavior at the gateway.
 type PolicyConfig struct {
-	Mode            PolicyMode     `yaml:"mode,omitempty" json:"mode,omitempty"`
-	DefaultDecision PolicyDecision `yaml:"defaultDecision,omitempty" json:"defaultDecision,omitempty"`
-	EnforceOn       string         `yaml:"enforceOn,omitempty" json:"enforceOn,omitempty"`
-	PolicyVersion   string         `yaml:"policyVersion,omitempty" json:"policyVersion,omitempty"`
+	Mode                PolicyMode     `yaml:"mode,omitempty" json:"mode,omitempty"`
+	DefaultDecision     PolicyDecision `yaml:"defaultDecision,omitempty" json:"defaultDecision,omitempty"`
+	EnforceOn           string         `yaml:"enforceOn,omitempty" json:"enforceOn,omitempty"`
+	PolicyVersion       string         `yaml:"policyVersion,omitempty" json:"policyVersion,omitempty"`
+	FilterListResponses bool           `yaml:"filterListResponses,omitempty" json:"filterListResponses,omitempty"`
 }
 
 // SessionConfig configures server-side agent session behavior.
@@ -249,6 +374,124 @@ type RolloutConfig struct {
 	CanaryReplicas *int32          `yaml:"canaryReplicas,omitempty" json:"canaryReplicas,omitempty"`
 }
 
+// SchedulingConfig sets where the server's pods run.
+type SchedulingConfig struct {
+	NodeSelector              map[string]string          `yaml:"nodeSelector,omitempty" json:"nodeSelector,omitempty"`
+	Tolerations               []Toleration               `yaml:"tolerations,omitempty" json:"tolerations,omitempty"`
+	Affinity                  *Affinity                  `yaml:"affinity,omitempty" json:"affinity,omitempty"`
+	TopologySpreadConstraints []TopologySpreadConstraint `yaml:"topologySpreadConstraints,omitempty" json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                     `yaml:"priorityClassName,omitempty" json:"priorityClassName,omitempty"`
+}
+
+// Toleration tolerates a node taint.
+type Toleration struct {
+	Key               string `yaml:"key,omitempty" json:"key,omitempty"`
+	Operator          string `yaml:"operator,omitempty" json:"operator,omitempty"`
+	Value             string `yaml:"value,omitempty" json:"value,omitempty"`
+	Effect            string `yaml:"effect,omitempty" json:"effect,omitempty"`
+	TolerationSeconds *int64 `yaml:"tolerationSeconds,omitempty" json:"tolerationSeconds,omitempty"`
+}
+
+// Affinity groups node, pod, and pod anti-affinity rules.
+type Affinity struct {
+	NodeAffinity    *NodeAffinity `yaml:"nodeAffinity,omitempty" json:"nodeAffinity,omitempty"`
+	PodAffinity     *PodAffinity  `yaml:"podAffinity,omitempty" json:"podAffinity,omitempty"`
+	PodAntiAffinity *PodAffinity  `yaml:"podAntiAffinity,omitempty" json:"podAntiAffinity,omitempty"`
+}
+
+// NodeAffinity selects nodes by label.
+type NodeAffinity struct {
+	Required  []NodeSelectorTerm        `yaml:"required,omitempty" json:"required,omitempty"`
+	Preferred []PreferredSchedulingTerm `yaml:"preferred,omitempty" json:"preferred,omitempty"`
+}
+
+// NodeSelectorTerm matches nodes whose labels satisfy every expression.
+type NodeSelectorTerm struct {
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions" json:"matchExpressions"`
+}
+
+// PreferredSchedulingTerm weights a node selector term.
+type PreferredSchedulingTerm struct {
+	Weight     int32            `yaml:"weight" json:"weight"`
+	Preference NodeSelectorTerm `yaml:"preference" json:"preference"`
+}
+
+// PodAffinity co-locates pods with, or keeps them away from, matching pods.
+type PodAffinity struct {
+	Required  []PodAffinityTerm         `yaml:"required,omitempty" json:"required,omitempty"`
+	Preferred []WeightedPodAffinityTerm `yaml:"preferred,omitempty" json:"preferred,omitempty"`
+}
+
+// PodAffinityTerm matches pods in the same topology domain. Without labels it
+// selects the server's own pods.
+type PodAffinityTerm struct {
+	MatchLabels      map[string]string          `yaml:"matchLabels,omitempty" json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions,omitempty" json:"matchExpressions,omitempty"`
+	TopologyKey      string                     `yaml:"topologyKey" json:"topologyKey"`
+	Namespaces       []string                   `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+}
+
+// WeightedPodAffinityTerm weights a pod affinity term.
+type WeightedPodAffinityTerm struct {
+	Weight          int32           `yaml:"weight" json:"weight"`
+	PodAffinityTerm PodAffinityTerm `yaml:"podAffinityTerm" json:"podAffinityTerm"`
+}
+
+// LabelSelectorRequirement matches a label against values.
+type LabelSelectorRequirement struct {
+	Key      string   `yaml:"key" json:"key"`
+	Operator string   `yaml:"operator" json:"operator"`
+	Values   []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// TopologySpreadConstraint spreads pods across topology domains. Without
+// labels it counts the server's own pods.
+type TopologySpreadConstraint struct {
+	MaxSkew           int32             `yaml:"maxSkew" json:"maxSkew"`
+	TopologyKey       string            `yaml:"topologyKey" json:"topologyKey"`
+	WhenUnsatisfiable string            `yaml:"whenUnsatisfiable,omitempty" json:"whenUnsatisfiable,omitempty"`
+	MatchLabels       map[string]string `yaml:"matchLabels,omitempty" json:"matchLabels,omitempty"`
+}
+
+// DisruptionBudgetConfig configures the operator-managed PodDisruptionBudget.
+type DisruptionBudgetConfig struct {
+	Enabled        bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	MaxUnavailable string `yaml:"maxUnavailable,omitempty" json:"maxUnavailable,omitempty"`
+}
+
+// NetworkConfig configures the server pods' NetworkPolicy.
+type NetworkConfig struct {
+	Egress *EgressConfig `yaml:"egress,omitempty" json:"egress,omitempty"`
+}
+
+// EgressConfig allows egress only to its rules once it has a rule or
+// DefaultDeny is set.
+type EgressConfig struct {
+	DefaultDeny bool         `yaml:"defaultDeny,omitempty" json:"defaultDeny,omitempty"`
+	Rules       []EgressRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// EgressRule allows connections to any of To on any of Ports.
+type EgressRule struct {
+	To    []EgressPeer  `yaml:"to,omitempty" json:"to,omitempty"`
+	Ports []NetworkPort `yaml:"ports,omitempty" json:"ports,omitempty"`
+}
+
+// EgressPeer is an IP block or a label-selected set of pods.
+type EgressPeer struct {
+	CIDR              string            `yaml:"cidr,omitempty" json:"cidr,omitempty"`
+	Except            []string          `yaml:"except,omitempty" json:"except,omitempty"`
+	NamespaceSelector map[string]string `yaml:"namespaceSelector,omitempty" json:"namespaceSelector,omitempty"`
+	PodSelector       map[string]string `yaml:"podSelector,omitempty" json:"podSelector,omitempty"`
+}
+
+// NetworkPort is a port or an inclusive port range.
+type NetworkPort struct {
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+	Port     int32  `yaml:"port,omitempty" json:"port,omitempty"`
+	EndPort  int32  `yaml:"endPort,omitempty" json:"endPort,omitempty"`
+}
+
 // SecretKeyRef points to a single key in a Kubernetes Secret.
 type SecretKeyRef struct {
 	Name string `yaml:"name" json:"name"`
@@ -28,6 +28,29 @@ const (
 	PolicyReady ConditionType = "PolicyReady"
 	// CanaryReady indicates the canary deployment, when configured, is ready.
 	CanaryReady ConditionType = "CanaryReady"
+	// CanaryAnalysis indicates no canary is in flight or the last one was
+	// promoted; its reason is the rollout phase.
+	CanaryAnalysis ConditionType = "CanaryAnalysis"
+	// ImageVerified indicates the image is pinned to a digest and, when a
+	// signature is required, that a trusted key signed it.
+	ImageVerified ConditionType = "ImageVerified"
+	// ToolInventoryDrift indicates the tools the running server advertises
+	// differ from spec.tools.
+	ToolInventoryDrift ConditionType = "ToolInventoryDrift"
+
+	// ServerFound indicates the MCPServer referenced by a grant or session exists.
+	ServerFound ConditionType = "ServerFound"
+	// PolicyRendered indicates the referenced server's gateway policy includes
+	// the grant or session.
+	PolicyRendered ConditionType = "PolicyRendered"
+	// Expired indicates a session is past its expiresAt.
+	Expired ConditionType = "Expired"
+	// Revoked indicates a session has been revoked.
+	Revoked ConditionType = "Revoked"
+	// RateLimitsPerReplica indicates a grant's rate limits are counted by
+	// each of several gateway replicas on its own because the operator does
+	// not share rate limit counters.
+	RateLimitsPerReplica ConditionType = "RateLimitsPerReplica"
 )
 
 // ResourceReadiness tracks the readiness of different resource types.
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DecisionRequiresApproval is the tool rule decision that allows a call only
+// once a human approves it.
+const DecisionRequiresApproval = "requires_approval"
+
+// Approval configures human approval of tool calls.
+type Approval struct {
+	// RiskLevel requires approval for every tool call at or above this risk
+	// level. Empty leaves approval to requires_approval tool rules.
+	RiskLevel string `json:"risk_level,omitempty"`
+	// Timeout is how long an approval request stays pending before it is
+	// denied.
+	Timeout string `json:"timeout,omitempty"`
+	// Wait is how long the gateway holds a tools/call open for a decision
+	// before answering that the approval is still pending.
+	Wait string `json:"wait,omitempty"`
+}
+
+// ApprovalWait returns how long the gateway holds a call open for an approval
+// decision, zero when it answers pending right away.
+func ApprovalWait(policy *Document) time.Duration {
+	if policy == nil || policy.Approval == nil {
+		return 0
+	}
+	wait, err := time.ParseDuration(strings.TrimSpace(policy.Approval.Wait))
+	if err != nil || wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// applyApproval turns an allowed tool call into an approval_required decision
+// when the granting rule requires approval or the tool's risk level reaches
+// the document's approval threshold. Grants and baseline rules are checked
+// first, so a human is only asked about calls policy would otherwise allow.
+func applyApproval(policy *Document, request Request, decision Decision) Decision {
+	ruleRequires := decision.RequiresApproval
+	decision.RequiresApproval = false
+	if !decision.Allowed || !IsToolCallMethod(request.RPCMethod) || policyModeObserve(policy) {
+		return decision
+	}
+	if !ruleRequires && !riskRequiresApproval(policy, decision.RiskLevel) {
+		return decision
+	}
+	decision.Allowed = false
+	decision.Status = http.StatusForbidden
+	decision.Reason = "approval_required"
+	decision.RequiresApproval = true
+	return decision
+}
+
+func riskRequiresApproval(policy *Document, riskLevel string) bool {
+	if policy == nil || policy.Approval == nil || strings.TrimSpace(policy.Approval.RiskLevel) == "" {
+		return false
+	}
+	rank := riskRank(riskLevel)
+	return rank > 0 && rank >= riskRank(policy.Approval.RiskLevel)
+}
+
+func riskRank(riskLevel string) int {
+	switch strings.ToLower(strings.TrimSpace(riskLevel)) {
+	case "low":
+		return 1
+	case "medium":
+		return 2
+	case "high":
+		return 3
+	default:
+		return 0
+	}
+}
+
+func validateApproval(approval *Approval) error {
+	if approval == nil {
+		return nil
+	}
+	if strings.TrimSpace(approval.RiskLevel) != "" && riskRank(approval.RiskLevel) == 0 {
+		return fmt.Errorf("policy: approval has invalid risk_level %q", approval.RiskLevel)
+	}
+	for _, setting := range []struct{ name, value string }{
+		{"timeout", approval.Timeout},
+		{"wait", approval.Wait},
+	} {
+		if strings.TrimSpace(setting.value) == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(strings.TrimSpace(setting.value)); err != nil || duration < 0 {
+			return fmt.Errorf("policy: approval %s %q must be a non-negative duration", setting.name, setting.value)
+		}
+	}
+	return nil
+}
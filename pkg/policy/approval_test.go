@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func approvalDocument(t *testing.T, approval *Approval) *Document {
+	t.Helper()
+	doc := &Document{
+		Server: Server{Name: "payments", Namespace: "mcp-servers"},
+		Policy: &Config{Mode: "allow-list", DefaultDecision: "deny", PolicyVersion: "v1"},
+		Tools: []Tool{
+			{Name: "refund", RequiredTrust: "low", SideEffect: "write", RiskLevel: "medium"},
+			{Name: "drop_table", RequiredTrust: "low", SideEffect: "destructive"},
+			{Name: "lookup_customer", RequiredTrust: "low", SideEffect: "read"},
+		},
+		Grants: []Grant{
+			{
+				Name: "alice-ops", HumanID: "alice", MaxTrust: "high", AllowedSideEffects: []string{"read", "write", "destructive"},
+				ToolRules: []ToolAccess{
+					{Name: "refund", Decision: DecisionRequiresApproval},
+					{Name: "drop_table", Decision: "allow"},
+					{Name: "lookup_customer", Decision: "allow"},
+				},
+			},
+			{
+				Name: "bob-refunds", HumanID: "bob", MaxTrust: "high", AllowedSideEffects: []string{"write"},
+				ToolRules: []ToolAccess{{Name: "refund", Decision: DecisionRequiresApproval}},
+			},
+			{Name: "bob-finance", HumanID: "bob", MaxTrust: "high", AllowedSideEffects: []string{"read", "write"}},
+		},
+		Approval: approval,
+	}
+	if err := Stamp(doc, ""); err != nil {
+		t.Fatalf("Stamp() error = %v", err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return doc
+}
+
+func TestAuthorizeRequiresApprovalRule(t *testing.T) {
+	t.Parallel()
+
+	doc := approvalDocument(t, nil)
+	call := func(human HumanID, tool ToolName) Decision {
+		return Authorize(doc, Request{Identity: Identity{HumanID: human}, RPCMethod: "tools/call", ToolName: tool}, time.Time{})
+	}
+
+	pending := call("alice", "refund")
+	if pending.Allowed || pending.Status != http.StatusForbidden || pending.Reason != "approval_required" || !pending.RequiresApproval {
+		t.Fatalf("refund by alice = %#v, want approval_required", pending)
+	}
+	if pending.MatchedGrant != "alice-ops" || pending.RiskLevel != "medium" {
+		t.Fatalf("approval_required attribution = %q/%q, want alice-ops and medium risk", pending.MatchedGrant, pending.RiskLevel)
+	}
+	if decision := call("alice", "drop_table"); !decision.Allowed || decision.RequiresApproval {
+		t.Fatalf("drop_table by alice = %#v, want allowed without approval", decision)
+	}
+	if decision := call("bob", "refund"); !decision.Allowed || decision.RequiresApproval {
+		t.Fatalf("refund by bob = %#v, want the unrestricted grant to allow it without approval", decision)
+	}
+	if decision := call("carol", "refund"); decision.RequiresApproval || decision.Reason != "no_matching_grant" {
+		t.Fatalf("refund by carol = %#v, want no_matching_grant without approval", decision)
+	}
+}
+
+func TestAuthorizeRequiresApprovalByRiskLevel(t *testing.T) {
+	t.Parallel()
+
+	doc := approvalDocument(t, &Approval{RiskLevel: "high", Timeout: "15m"})
+	call := func(tool ToolName) Decision {
+		return Authorize(doc, Request{Identity: Identity{HumanID: "alice"}, RPCMethod: "tools/call", ToolName: tool}, time.Time{})
+	}
+
+	if decision := call("drop_table"); decision.Reason != "approval_required" || !decision.RequiresApproval || decision.RiskLevel != "high" {
+		t.Fatalf("drop_table = %#v, want approval_required for a high risk tool", decision)
+	}
+	if decision := call("lookup_customer"); !decision.Allowed {
+		t.Fatalf("lookup_customer = %#v, want allowed below the risk threshold", decision)
+	}
+
+	doc.Policy.Mode = "observe"
+	if decision := call("drop_table"); !decision.Allowed || decision.RequiresApproval {
+		t.Fatalf("observe mode = %#v, want allowed without approval", decision)
+	}
+}
+
+func TestValidateRejectsBadApproval(t *testing.T) {
+	t.Parallel()
+
+	for _, approval := range []*Approval{{RiskLevel: "severe"}, {Timeout: "soon"}, {Wait: "-1s"}} {
+		doc := &Document{SchemaVersion: SchemaVersion, Server: Server{Name: "payments"}, Approval: approval}
+		if err := Stamp(doc, ""); err != nil {
+			t.Fatalf("Stamp() error = %v", err)
+		}
+		if err := Validate(doc); err == nil {
+			t.Fatalf("Validate(%+v) error = nil, want invalid approval", approval)
+		}
+	}
+
+	doc := &Document{
+		SchemaVersion: SchemaVersion,
+		Server:        Server{Name: "payments"},
+		Grants:        []Grant{{Name: "g", HumanID: "alice", PromptRules: []PromptAccess{{Name: "p", Decision: DecisionRequiresApproval}}}},
+	}
+	if err := Stamp(doc, ""); err != nil {
+		t.Fatalf("Stamp() error = %v", err)
+	}
+	if err := Validate(doc); err == nil {
+		t.Fatal("Validate() error = nil, want requires_approval rejected on a prompt rule")
+	}
+}
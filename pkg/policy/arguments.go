@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// argumentsSatisfy reports whether arguments meet every constraint. It
+// returns the name of the first violated argument when they do not.
+func argumentsSatisfy(constraints []ArgumentConstraint, arguments map[string]any) (bool, string) {
+	for _, constraint := range constraints {
+		if !argumentSatisfies(constraint, arguments[constraint.Argument]) {
+			return false, constraint.Argument
+		}
+	}
+	return true, ""
+}
+
+func argumentSatisfies(constraint ArgumentConstraint, value any) bool {
+	if value == nil {
+		return constraint.Optional
+	}
+	if values, ok := value.([]any); ok {
+		for _, element := range values {
+			if element == nil || !scalarSatisfies(constraint, element) {
+				return false
+			}
+		}
+		return true
+	}
+	return scalarSatisfies(constraint, value)
+}
+
+func scalarSatisfies(constraint ArgumentConstraint, value any) bool {
+	if len(constraint.OneOf) > 0 {
+		text, ok := scalarString(value)
+		if !ok || !containsString(constraint.OneOf, text) {
+			return false
+		}
+	}
+	if constraint.Pattern != "" {
+		text, ok := value.(string)
+		if !ok || !MatchGlob(constraint.Pattern, text) {
+			return false
+		}
+	}
+	if constraint.Minimum != nil || constraint.Maximum != nil {
+		number, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		if constraint.Minimum != nil && number < float64(*constraint.Minimum) {
+			return false
+		}
+		if constraint.Maximum != nil && number > float64(*constraint.Maximum) {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarString renders a decoded JSON scalar the way it would be written in a
+// OneOf list. Objects and arrays have no string form.
+func scalarString(value any) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(typed), true
+	default:
+		return "", false
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGlob reports whether value matches pattern, where "*" matches any run
+// of characters (including none) and every other character matches itself.
+func MatchGlob(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+	return len(value) >= len(last) && strings.HasSuffix(value, last)
+}
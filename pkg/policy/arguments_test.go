@@ -0,0 +1,44 @@
+package policy
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"*@ourcorp.com", "alice@ourcorp.com", true},
+		{"*@ourcorp.com", "alice@ourcorp.com.evil.example", false},
+		{"*@ourcorp.com", "@ourcorp.com", true},
+		{"reports/*/daily", "reports/eu/daily", true},
+		{"reports/*/daily", "reports/eu/weekly", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"a*a", "a", false},
+		{"*", "", true},
+	}
+	for _, tc := range tests {
+		if got := MatchGlob(tc.pattern, tc.value); got != tc.want {
+			t.Fatalf("MatchGlob(%q, %q) = %v, want %v", tc.pattern, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestArgumentsSatisfyOneOfComparesScalars(t *testing.T) {
+	t.Parallel()
+
+	constraints := []ArgumentConstraint{{Argument: "region", OneOf: []string{"eu", "3", "true"}}}
+	for _, value := range []any{"eu", float64(3), true} {
+		if ok, _ := argumentsSatisfy(constraints, map[string]any{"region": value}); !ok {
+			t.Fatalf("argumentsSatisfy(%v) = false, want true", value)
+		}
+	}
+	for _, value := range []any{"us", map[string]any{"region": "eu"}, []any{"eu", nil}} {
+		if ok, argument := argumentsSatisfy(constraints, map[string]any{"region": value}); ok || argument != "region" {
+			t.Fatalf("argumentsSatisfy(%v) = %v, %q, want violation on region", value, ok, argument)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Baseline subjects and effects, matching the MCPPolicyBaseline API values.
+const (
+	BaselineSubjectAny         = "any"
+	BaselineSubjectAgentOnly   = "agent-only"
+	BaselineSubjectHuman       = "human"
+	BaselineEffectDeny         = "deny"
+	BaselineEffectRequireTrust = "require-trust"
+)
+
+// ComputeBaselineRevision returns a SHA-256 digest of the baseline rules. The
+// Revision field itself is excluded.
+func ComputeBaselineRevision(baseline *Baseline) (string, error) {
+	if baseline == nil {
+		return "", errors.New("policy: cannot compute revision of nil baseline")
+	}
+	data, err := json.Marshal(baseline.Rules)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// applyBaseline turns an allowed tool call into a baseline_denied decision
+// when a baseline rule matches it and the call does not satisfy the rule.
+// Baseline rules never allow anything a grant denied, and like grants they are
+// not enforced in observe mode.
+func applyBaseline(policy *Document, request Request, decision Decision) Decision {
+	if !decision.Allowed || policy == nil || policy.Baseline == nil || !IsToolCallMethod(request.RPCMethod) || policyModeObserve(policy) {
+		return decision
+	}
+	tool, _ := findTool(policy.Tools, request.ToolName)
+	for _, rule := range policy.Baseline.Rules {
+		if !baselineRuleMatches(rule, request, tool) {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(rule.Effect)) {
+		case BaselineEffectRequireTrust:
+			effectiveRank := 0
+			if decision.EffectiveTrust != "" {
+				effectiveRank = TrustRank(decision.EffectiveTrust)
+			}
+			if effectiveRank >= TrustRank(rule.RequiredTrust) {
+				continue
+			}
+			decision.RequiredTrust = NormalizeTrust(rule.RequiredTrust)
+		case BaselineEffectDeny:
+		default:
+			continue
+		}
+		decision.Allowed = false
+		decision.Status = http.StatusForbidden
+		decision.Reason = "baseline_denied"
+		decision.BaselineRule = rule.Name
+		return decision
+	}
+	return decision
+}
+
+func baselineRuleMatches(rule BaselineRule, request Request, tool Tool) bool {
+	identity := request.Identity
+	switch strings.ToLower(strings.TrimSpace(rule.Subject)) {
+	case BaselineSubjectAgentOnly:
+		if identity.AgentID == "" || identity.HumanID != "" {
+			return false
+		}
+	case BaselineSubjectHuman:
+		if identity.HumanID == "" {
+			return false
+		}
+	}
+	if len(rule.Tools) > 0 && !containsToolName(rule.Tools, request.ToolName) {
+		return false
+	}
+	if len(rule.SideEffects) > 0 && !sideEffectAllowed(rule.SideEffects, tool.SideEffect) {
+		return false
+	}
+	for key, value := range rule.ToolLabels {
+		if actual, ok := tool.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+func findTool(tools []Tool, name ToolName) (Tool, bool) {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+func containsToolName(names []ToolName, name ToolName) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateBaseline(baseline *Baseline) error {
+	if baseline == nil {
+		return nil
+	}
+	computed, err := ComputeBaselineRevision(baseline)
+	if err != nil {
+		return fmt.Errorf("policy: cannot compute baseline revision: %w", err)
+	}
+	if computed != baseline.Revision {
+		return fmt.Errorf("policy: baseline revision mismatch: document contains %q, computed %q", baseline.Revision, computed)
+	}
+	seen := make(map[string]struct{}, len(baseline.Rules))
+	for i, rule := range baseline.Rules {
+		if strings.TrimSpace(rule.Name) == "" {
+			return fmt.Errorf("policy: baseline rules[%d] name is required", i)
+		}
+		if _, dup := seen[rule.Name]; dup {
+			return fmt.Errorf("policy: duplicate baseline rule %q", rule.Name)
+		}
+		seen[rule.Name] = struct{}{}
+		switch strings.ToLower(strings.TrimSpace(rule.Subject)) {
+		case "", BaselineSubjectAny, BaselineSubjectAgentOnly, BaselineSubjectHuman:
+		default:
+			return fmt.Errorf("policy: baseline rule %q has invalid subject %q", rule.Name, rule.Subject)
+		}
+		for _, sideEffect := range rule.SideEffects {
+			if !validSideEffect(sideEffect, false) {
+				return fmt.Errorf("policy: baseline rule %q has invalid side effect %q", rule.Name, sideEffect)
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(rule.Effect)) {
+		case BaselineEffectDeny:
+		case BaselineEffectRequireTrust:
+			if strings.TrimSpace(rule.RequiredTrust) == "" || !validTrust(rule.RequiredTrust) {
+				return fmt.Errorf("policy: baseline rule %q has invalid required_trust %q", rule.Name, rule.RequiredTrust)
+			}
+		default:
+			return fmt.Errorf("policy: baseline rule %q has invalid effect %q", rule.Name, rule.Effect)
+		}
+	}
+	return nil
+}
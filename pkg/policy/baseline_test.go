@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func baselineDocument(t *testing.T, rules ...BaselineRule) *Document {
+	t.Helper()
+	doc := &Document{
+		Server: Server{Name: "payments", Namespace: "mcp-servers"},
+		Policy: &Config{Mode: "allow-list", DefaultDecision: "allow", PolicyVersion: "v1"},
+		Tools: []Tool{
+			{Name: "drop_table", RequiredTrust: "low", SideEffect: "destructive"},
+			{Name: "lookup_customer", RequiredTrust: "low", SideEffect: "read", Labels: map[string]string{"pii": "true"}},
+		},
+		Grants: []Grant{
+			{Name: "agent-ops", AgentID: "ops-bot", MaxTrust: "high", AllowedSideEffects: []string{"read", "write", "destructive"}},
+			{Name: "alice-ops", HumanID: "alice", AgentID: "ops-bot", MaxTrust: "high", AllowedSideEffects: []string{"read", "write", "destructive"}},
+			{Name: "support", HumanID: "bob", MaxTrust: "medium", AllowedSideEffects: []string{"read"}},
+		},
+		Baseline: &Baseline{Rules: rules},
+	}
+	revision, err := ComputeBaselineRevision(doc.Baseline)
+	if err != nil {
+		t.Fatalf("ComputeBaselineRevision() error = %v", err)
+	}
+	doc.Baseline.Revision = revision
+	if err := Stamp(doc, ""); err != nil {
+		t.Fatalf("Stamp() error = %v", err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return doc
+}
+
+func TestAuthorizeBaselineDenyBeatsGrant(t *testing.T) {
+	t.Parallel()
+
+	doc := baselineDocument(t, BaselineRule{
+		Name:        "guardrails/no-agent-destructive",
+		Subject:     BaselineSubjectAgentOnly,
+		SideEffects: []string{SideEffectDestructive},
+		Effect:      BaselineEffectDeny,
+	})
+	call := func(identity Identity, tool ToolName) Decision {
+		return Authorize(doc, Request{Identity: identity, RPCMethod: "tools/call", ToolName: tool}, time.Time{})
+	}
+
+	denied := call(Identity{AgentID: "ops-bot"}, "drop_table")
+	if denied.Allowed || denied.Status != http.StatusForbidden || denied.Reason != "baseline_denied" || denied.BaselineRule != "guardrails/no-agent-destructive" {
+		t.Fatalf("agent-only destructive call = %#v, want baseline_denied by guardrails/no-agent-destructive", denied)
+	}
+	if denied.MatchedGrant != "agent-ops" {
+		t.Fatalf("MatchedGrant = %q, want the grant that allowed the call", denied.MatchedGrant)
+	}
+	if decision := call(Identity{HumanID: "alice", AgentID: "ops-bot"}, "drop_table"); !decision.Allowed {
+		t.Fatalf("delegated destructive call = %#v, want allowed", decision)
+	}
+	if decision := call(Identity{AgentID: "ops-bot"}, "lookup_customer"); !decision.Allowed {
+		t.Fatalf("agent-only read call = %#v, want allowed", decision)
+	}
+}
+
+func TestAuthorizeBaselineRequiresTrust(t *testing.T) {
+	t.Parallel()
+
+	doc := baselineDocument(t, BaselineRule{
+		Name:          "guardrails/pii-high-trust",
+		ToolLabels:    map[string]string{"pii": "true"},
+		Effect:        BaselineEffectRequireTrust,
+		RequiredTrust: TrustLevelHigh,
+	})
+	call := func(identity Identity) Decision {
+		return Authorize(doc, Request{Identity: identity, RPCMethod: "tools/call", ToolName: "lookup_customer"}, time.Time{})
+	}
+
+	denied := call(Identity{HumanID: "bob"})
+	if denied.Allowed || denied.Reason != "baseline_denied" || denied.BaselineRule != "guardrails/pii-high-trust" || denied.RequiredTrust != TrustLevelHigh {
+		t.Fatalf("medium-trust pii call = %#v, want baseline_denied requiring high", denied)
+	}
+	// The default decision allows callers without a grant, but they have no
+	// trust to satisfy the baseline with.
+	if decision := call(Identity{HumanID: "mallory"}); decision.Allowed || decision.Reason != "baseline_denied" {
+		t.Fatalf("ungranted pii call = %#v, want baseline_denied", decision)
+	}
+	if decision := call(Identity{AgentID: "ops-bot"}); !decision.Allowed {
+		t.Fatalf("high-trust pii call = %#v, want allowed", decision)
+	}
+	if decision := Authorize(doc, Request{Identity: Identity{HumanID: "bob"}, RPCMethod: "tools/call", ToolName: "drop_table"}, time.Time{}); decision.Reason == "baseline_denied" {
+		t.Fatalf("unlabeled tool decision = %#v, want the baseline not to apply", decision)
+	}
+}
+
+func TestBaselineRevisionChangesDocumentRevision(t *testing.T) {
+	t.Parallel()
+
+	deny := baselineDocument(t, BaselineRule{Name: "guardrails/no-drop", Tools: []ToolName{"drop_table"}, Effect: BaselineEffectDeny})
+	trust := baselineDocument(t, BaselineRule{Name: "guardrails/no-drop", Tools: []ToolName{"drop_table"}, Effect: BaselineEffectRequireTrust, RequiredTrust: TrustLevelHigh})
+	if deny.Baseline.Revision == trust.Baseline.Revision || deny.Revision == trust.Revision {
+		t.Fatalf("baseline change kept revisions %q/%q", deny.Revision, deny.Baseline.Revision)
+	}
+
+	deny.Baseline.Rules[0].Effect = BaselineEffectRequireTrust
+	deny.Baseline.Rules[0].RequiredTrust = TrustLevelHigh
+	if err := Stamp(deny, ""); err != nil {
+		t.Fatalf("Stamp() error = %v", err)
+	}
+	if err := Validate(deny); err == nil {
+		t.Fatal("Validate() accepted a stale baseline revision")
+	}
+}
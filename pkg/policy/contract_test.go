@@ -4,6 +4,7 @@ package policy
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -64,6 +65,12 @@ func TestPolicyDocumentRoundTrip(t *testing.T) {
 				},
 			},
 		},
+		Prompts: []Prompt{
+			{Name: "incident-summary", Description: "Summarize an incident", RequiredTrust: "medium"},
+		},
+		Resources: []Resource{
+			{Name: "docs", URI: "file:///docs/*", RequiredTrust: "low", Labels: map[string]string{"category": "docs"}},
+		},
 		Grants: []Grant{
 			{
 				Name:          "developer-grant",
@@ -86,8 +93,13 @@ func TestPolicyDocumentRoundTrip(t *testing.T) {
 						Name:          "write-file",
 						Decision:      "allow",
 						RequiredTrust: "high",
+						ArgumentConstraints: []ArgumentConstraint{
+							{Argument: "path", Pattern: "/tmp/*"},
+						},
 					},
 				},
+				PromptRules:   []PromptAccess{{Name: "incident-summary", Decision: "allow", RequiredTrust: "high"}},
+				ResourceRules: []ResourceAccess{{URI: "file:///docs/private/*", Decision: "deny"}},
 			},
 			{
 				Name:          "agent-grant",
@@ -134,6 +146,12 @@ func TestPolicyDocumentRoundTrip(t *testing.T) {
 	verifyPolicy(t, original.Policy, decoded.Policy)
 	verifySession(t, original.Session, decoded.Session)
 	verifyTools(t, original.Tools, decoded.Tools)
+	if !reflect.DeepEqual(decoded.Prompts, original.Prompts) {
+		t.Errorf("Prompts mismatch: expected %#v, got %#v", original.Prompts, decoded.Prompts)
+	}
+	if !reflect.DeepEqual(decoded.Resources, original.Resources) {
+		t.Errorf("Resources mismatch: expected %#v, got %#v", original.Resources, decoded.Resources)
+	}
 	verifyGrants(t, original.Grants, decoded.Grants)
 	verifySessions(t, original.Sessions, decoded.Sessions)
 }
@@ -318,6 +336,15 @@ func verifyGrants(t *testing.T, expected, actual []Grant) {
 			if act.ToolRules[j].RequiredTrust != rule.RequiredTrust {
 				t.Errorf("Grant[%d].ToolRules[%d].RequiredTrust mismatch: expected %q, got %q", i, j, rule.RequiredTrust, act.ToolRules[j].RequiredTrust)
 			}
+			if !reflect.DeepEqual(act.ToolRules[j].ArgumentConstraints, rule.ArgumentConstraints) {
+				t.Errorf("Grant[%d].ToolRules[%d].ArgumentConstraints mismatch: expected %#v, got %#v", i, j, rule.ArgumentConstraints, act.ToolRules[j].ArgumentConstraints)
+			}
+		}
+		if !reflect.DeepEqual(act.PromptRules, exp.PromptRules) {
+			t.Errorf("Grant[%d].PromptRules mismatch: expected %#v, got %#v", i, exp.PromptRules, act.PromptRules)
+		}
+		if !reflect.DeepEqual(act.ResourceRules, exp.ResourceRules) {
+			t.Errorf("Grant[%d].ResourceRules mismatch: expected %#v, got %#v", i, exp.ResourceRules, act.ResourceRules)
 		}
 	}
 }
@@ -424,6 +451,68 @@ func TestPolicyHelperFunctions(t *testing.T) {
 		}
 	})
 
+	t.Run("IsGovernedMethod", func(t *testing.T) {
+		for _, method := range []string{"tools/call", "prompts/get", "resources/read", "resources/subscribe"} {
+			if !IsGovernedMethod(method) {
+				t.Errorf("IsGovernedMethod(%q) should be true", method)
+			}
+		}
+		for _, method := range []string{"tools/list", "resources/unsubscribe", "initialize"} {
+			if IsGovernedMethod(method) {
+				t.Errorf("IsGovernedMethod(%q) should be false", method)
+			}
+		}
+	})
+
+	t.Run("IsListMethod", func(t *testing.T) {
+		for _, method := range []string{"tools/list", "prompts/list", "resources/list"} {
+			if !IsListMethod(method) {
+				t.Errorf("IsListMethod(%q) should be true", method)
+			}
+		}
+		if IsListMethod("tools/call") {
+			t.Error("IsListMethod('tools/call') should be false")
+		}
+	})
+
+	t.Run("FilterListResponses", func(t *testing.T) {
+		if !FilterListResponses(&Document{Policy: &Config{FilterListResponses: true}}) {
+			t.Error("FilterListResponses with the setting enabled should be true")
+		}
+		if FilterListResponses(&Document{Policy: &Config{}}) {
+			t.Error("FilterListResponses without the setting should be false")
+		}
+		if FilterListResponses(nil) {
+			t.Error("FilterListResponses with nil document should be false")
+		}
+	})
+
+	t.Run("FindGrant", func(t *testing.T) {
+		doc := &Document{Grants: []Grant{{Name: "g", Namespace: "team-a"}, {Name: "g", Namespace: "team-b", MaxTrust: "high"}}}
+		grant, ok := FindGrant(doc, "g", "team-b")
+		if !ok || grant.MaxTrust != "high" {
+			t.Errorf("FindGrant('g', 'team-b') = %#v, %v, expected the team-b grant", grant, ok)
+		}
+		if _, ok := FindGrant(doc, "g", "team-c"); ok {
+			t.Error("FindGrant with an unknown namespace should not match")
+		}
+	})
+
+	t.Run("RateLimitsFor", func(t *testing.T) {
+		grant := Grant{RateLimits: []RateLimit{
+			{CallsPerDay: 1000},
+			{Tool: "delete_all", CallsPerMinute: 1},
+			{SideEffect: "destructive", CallsPerMinute: 5},
+			{SideEffect: "read", CallsPerMinute: 100},
+		}}
+		if got := RateLimitsFor(grant, "delete_all", "destructive"); len(got) != 3 {
+			t.Errorf("RateLimitsFor(delete_all) = %#v, expected grant-wide, tool, and side-effect limits", got)
+		}
+		if got := RateLimitsFor(grant, "echo", "read"); len(got) != 2 || got[1].CallsPerMinute != 100 {
+			t.Errorf("RateLimitsFor(echo) = %#v, expected grant-wide and read limits", got)
+		}
+	})
+
 	t.Run("PolicyUsesOAuth", func(t *testing.T) {
 		if !PolicyUsesOAuth(&Document{Auth: &Auth{Mode: "oauth"}}) {
 			t.Error("PolicyUsesOAuth with mode 'oauth' should be true")
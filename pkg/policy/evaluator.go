@@ -20,6 +20,14 @@ type Request struct {
 	Identity  Identity
 	RPCMethod string
 	ToolName  ToolName
+	// Arguments is the decoded tools/call params.arguments object, checked
+	// against the matched tool rule's argument constraints.
+	Arguments map[string]any
+	// PromptName is the prompt requested by prompts/get.
+	PromptName PromptName
+	// ResourceURI is the resource requested by resources/read or
+	// resources/subscribe.
+	ResourceURI string
 }
 
 // Decision is the result of evaluating a rendered policy document.
@@ -45,6 +53,23 @@ type Decision struct {
 	// MatchedSessionNamespace qualifies it for the same reason.
 	MatchedSession          string
 	MatchedSessionNamespace string
+	// ViolatedArgument names the argument that failed a tool rule constraint
+	// on an argument_constraint_violated denial.
+	ViolatedArgument string
+	// BaselineRule names the MCPPolicyBaseline rule, as <baseline>/<rule>,
+	// behind a baseline_denied decision.
+	BaselineRule string
+	// RetryAfter is set by the gateway on rate_limited denials to the time
+	// until the exhausted window frees up.
+	RetryAfter time.Duration
+	// RequiresApproval is set on approval_required decisions: the call is
+	// allowed once a human approves it.
+	RequiresApproval bool
+	// ApprovalID names the MCPToolApproval the gateway resolved an
+	// approval_required decision against, and ApprovalDecidedBy the approver
+	// who approved or denied it.
+	ApprovalID        string
+	ApprovalDecidedBy string
 }
 
 // Deny builds a denied authorization decision.
@@ -70,18 +95,28 @@ func Allow(reason, policyVersion string) Decision {
 	}
 }
 
-// Authorize evaluates a rendered gateway policy document for a single MCP RPC request.
+// Authorize evaluates a rendered gateway policy document for a single MCP RPC
+// request. Tool calls, prompt fetches, and resource reads are evaluated against
+// grants and sessions; every other method is allowed. Tool calls the grants
+// allow are then checked against the baseline rules, whose denials win, and
+// finally against the approval requirements.
 func Authorize(policy *Document, request Request, now time.Time) Decision {
+	decision := applyBaseline(policy, request, authorizeGrants(policy, request, now))
+	return applyApproval(policy, request, decision)
+}
+
+func authorizeGrants(policy *Document, request Request, now time.Time) Decision {
 	decision := Decision{
 		Allowed:       true,
 		Status:        http.StatusOK,
 		Reason:        "allowed",
 		PolicyVersion: policyVersionOrDefault(policy, ""),
 	}
-	if !IsToolCallMethod(request.RPCMethod) {
+	if !IsGovernedMethod(request.RPCMethod) {
 		return decision
 	}
-	_, _, decision.RiskLevel = resolveToolMetadata(policyTools(policy), request.ToolName)
+	target := resolveTarget(policy, request)
+	decision.RiskLevel = target.riskLevel
 	if policyModeObserve(policy) {
 		return decision
 	}
@@ -97,7 +132,7 @@ func Authorize(policy *Document, request Request, now time.Time) Decision {
 		now = time.Now()
 	}
 
-	sessions, tools, grants := policySlices(policy)
+	sessions, grants := policySlices(policy)
 	session, sessionFound := findSession(sessions, identity)
 	if sessionRequired(policy) {
 		if !sessionFound {
@@ -125,7 +160,7 @@ func Authorize(policy *Document, request Request, now time.Time) Decision {
 		matchedSessionNamespace = string(session.Namespace)
 	}
 
-	requiredTrust, requiredSideEffect, riskLevel := resolveToolMetadata(tools, request.ToolName)
+	requiredSideEffect, riskLevel := target.requiredSideEffect, target.riskLevel
 	matchingGrants := matchingGrants(grants, identity)
 	if len(matchingGrants) == 0 {
 		denied := decideByDefault(policy, "no_matching_grant")
@@ -134,15 +169,24 @@ func Authorize(policy *Document, request Request, now time.Time) Decision {
 		return denied
 	}
 
-	grant := bestGrantFor(matchingGrants, request.ToolName, requiredTrust, requiredSideEffect, policyVersionOrDefault(policy, ""))
+	grant := bestGrantFor(matchingGrants, target, request.Arguments, policyVersionOrDefault(policy, ""))
 	if grant.deny != nil {
 		denied := *grant.deny
 		denied.MatchedSession = matchedSession
 		denied.MatchedSessionNamespace = matchedSessionNamespace
 		return denied
 	}
-	if !grant.toolAllowed {
-		denied := decideByDefault(policy, "tool_not_granted")
+	if !grant.targetAllowed && grant.constraintDeny != nil {
+		// A rule named the tool but its argument constraints rejected this
+		// call. That is an explicit restriction, so the default decision does
+		// not apply.
+		denied := *grant.constraintDeny
+		denied.MatchedSession = matchedSession
+		denied.MatchedSessionNamespace = matchedSessionNamespace
+		return denied
+	}
+	if !grant.targetAllowed {
+		denied := decideByDefault(policy, target.kind+"_not_granted")
 		denied.MatchedSession = matchedSession
 		denied.MatchedSessionNamespace = matchedSessionNamespace
 		return denied
@@ -208,6 +252,8 @@ func Authorize(policy *Document, request Request, now time.Time) Decision {
 		}
 	}
 
+	// RequiresApproval only marks the allowed decision here; applyApproval
+	// turns it into approval_required once the baseline rules have passed.
 	return Decision{
 		Allowed:                 true,
 		Status:                  http.StatusOK,
@@ -223,18 +269,102 @@ func Authorize(policy *Document, request Request, now time.Time) Decision {
 		MatchedGrantNamespace:   grant.grantNamespace,
 		MatchedSession:          matchedSession,
 		MatchedSessionNamespace: matchedSessionNamespace,
+		RequiresApproval:        grant.approvalOnly(),
 	}
 }
 
-func policyTools(policy *Document) []Tool {
-	if policy == nil {
-		return nil
+// accessTarget is the tool, prompt, or resource a governed request acts on.
+type accessTarget struct {
+	// kind prefixes the target-specific denial reasons: tool_denied,
+	// prompt_not_granted, resource_denied, and so on.
+	kind               string
+	requiredTrust      string
+	requiredSideEffect string
+	riskLevel          string
+	// rules returns the grant's rules that name the target, and whether the
+	// grant restricts this kind of target at all.
+	rules func(Grant) (matched []accessRule, restricted bool)
+}
+
+// accessRule is the part of a tool, prompt, or resource rule the grant
+// selection needs.
+type accessRule struct {
+	decision      string
+	requiredTrust string
+	constraints   []ArgumentConstraint
+}
+
+// resolveTarget resolves the inventory metadata and grant rules for the
+// request's method. Prompts and resources carry no side-effect metadata: they
+// are always reads.
+func resolveTarget(policy *Document, request Request) accessTarget {
+	var tools []Tool
+	var prompts []Prompt
+	var resources []Resource
+	if policy != nil {
+		tools, prompts, resources = policy.Tools, policy.Prompts, policy.Resources
+	}
+	switch {
+	case IsPromptMethod(request.RPCMethod):
+		requiredTrust := resolvePromptTrust(prompts, request.PromptName)
+		return accessTarget{
+			kind:               "prompt",
+			requiredTrust:      requiredTrust,
+			requiredSideEffect: SideEffectRead,
+			riskLevel:          NormalizeRiskLevel("", requiredTrust, SideEffectRead),
+			rules: func(grant Grant) ([]accessRule, bool) {
+				var matched []accessRule
+				for _, rule := range grant.PromptRules {
+					if rule.Name == request.PromptName {
+						matched = append(matched, accessRule{decision: rule.Decision, requiredTrust: rule.RequiredTrust})
+					}
+				}
+				return matched, len(grant.PromptRules) > 0
+			},
+		}
+	case IsResourceMethod(request.RPCMethod):
+		requiredTrust := resolveResourceTrust(resources, request.ResourceURI)
+		return accessTarget{
+			kind:               "resource",
+			requiredTrust:      requiredTrust,
+			requiredSideEffect: SideEffectRead,
+			riskLevel:          NormalizeRiskLevel("", requiredTrust, SideEffectRead),
+			rules: func(grant Grant) ([]accessRule, bool) {
+				var matched []accessRule
+				for _, rule := range grant.ResourceRules {
+					if MatchGlob(rule.URI, request.ResourceURI) {
+						matched = append(matched, accessRule{decision: rule.Decision, requiredTrust: rule.RequiredTrust})
+					}
+				}
+				return matched, len(grant.ResourceRules) > 0
+			},
+		}
+	default:
+		requiredTrust, requiredSideEffect, riskLevel := resolveToolMetadata(tools, request.ToolName)
+		return accessTarget{
+			kind:               "tool",
+			requiredTrust:      requiredTrust,
+			requiredSideEffect: requiredSideEffect,
+			riskLevel:          riskLevel,
+			rules: func(grant Grant) ([]accessRule, bool) {
+				var matched []accessRule
+				for _, rule := range grant.ToolRules {
+					if rule.Name == request.ToolName {
+						matched = append(matched, accessRule{
+							decision:      rule.Decision,
+							requiredTrust: rule.RequiredTrust,
+							constraints:   rule.ArgumentConstraints,
+						})
+					}
+				}
+				return matched, len(grant.ToolRules) > 0
+			},
+		}
 	}
-	return policy.Tools
 }
 
 type grantSelection struct {
-	toolAllowed       bool
+	targetAllowed     bool
 	sideEffectAllowed bool
 	adminTrustRank    int
 	requiredTrustRank int
@@ -243,12 +373,27 @@ type grantSelection struct {
 	grantName         string
 	grantNamespace    string
 	deny              *Decision
+	// constraintDeny records the first rule that named the tool but whose
+	// argument constraints failed. It only decides the outcome when no other
+	// grant allows the tool.
+	constraintDeny *Decision
+	// approvalAllowed and plainAllowed record whether a requires_approval
+	// rule or an unconditional allow let the call through. Any unconditional
+	// allow wins, so approval is only needed when every allowing rule asks
+	// for it.
+	approvalAllowed bool
+	plainAllowed    bool
 }
 
-func bestGrantFor(grants []Grant, toolName ToolName, requiredTrust, requiredSideEffect, policyVersion string) grantSelection {
+func (s grantSelection) approvalOnly() bool {
+	return s.approvalAllowed && !s.plainAllowed
+}
+
+func bestGrantFor(grants []Grant, target accessTarget, arguments map[string]any, policyVersion string) grantSelection {
+	requiredSideEffect := target.requiredSideEffect
 	selection := grantSelection{
-		requiredTrustRank: TrustRank(requiredTrust),
-		requiredTrust:     requiredTrust,
+		requiredTrustRank: TrustRank(target.requiredTrust),
+		requiredTrust:     target.requiredTrust,
 		policyVersion:     policyVersion,
 	}
 	// attribute records the grant a decision is reported against. The policy
@@ -269,8 +414,9 @@ func bestGrantFor(grants []Grant, toolName ToolName, requiredTrust, requiredSide
 			continue
 		}
 		adminRank := TrustRank(grant.MaxTrust)
-		if len(grant.ToolRules) == 0 {
-			selection.toolAllowed = true
+		rules, restricted := target.rules(grant)
+		if !restricted {
+			selection.targetAllowed = true
 			if selection.grantName == "" {
 				attribute(grant)
 			}
@@ -279,22 +425,30 @@ func bestGrantFor(grants []Grant, toolName ToolName, requiredTrust, requiredSide
 					attribute(grant)
 				}
 				selection.sideEffectAllowed = true
+				selection.plainAllowed = true
 				selection.adminTrustRank = maxInt(selection.adminTrustRank, adminRank)
 			}
 			continue
 		}
-		for _, rule := range grant.ToolRules {
-			if rule.Name != toolName {
-				continue
-			}
-			if strings.EqualFold(rule.Decision, "deny") {
-				deny := Deny(http.StatusForbidden, "tool_denied", ChoosePolicyVersion(grant.PolicyVersion, policyVersion))
+		for _, rule := range rules {
+			if strings.EqualFold(rule.decision, "deny") {
+				deny := Deny(http.StatusForbidden, target.kind+"_denied", ChoosePolicyVersion(grant.PolicyVersion, policyVersion))
 				deny.MatchedGrant = grant.Name
 				deny.MatchedGrantNamespace = string(grant.Namespace)
 				selection.deny = &deny
 				return selection
 			}
-			selection.toolAllowed = true
+			if ok, argument := argumentsSatisfy(rule.constraints, arguments); !ok {
+				if selection.constraintDeny == nil {
+					deny := Deny(http.StatusForbidden, "argument_constraint_violated", ChoosePolicyVersion(grant.PolicyVersion, policyVersion))
+					deny.MatchedGrant = grant.Name
+					deny.MatchedGrantNamespace = string(grant.Namespace)
+					deny.ViolatedArgument = argument
+					selection.constraintDeny = &deny
+				}
+				continue
+			}
+			selection.targetAllowed = true
 			if selection.grantName == "" {
 				attribute(grant)
 			}
@@ -303,10 +457,15 @@ func bestGrantFor(grants []Grant, toolName ToolName, requiredTrust, requiredSide
 					attribute(grant)
 				}
 				selection.sideEffectAllowed = true
-				ruleRank := TrustRank(rule.RequiredTrust)
+				if strings.EqualFold(rule.decision, DecisionRequiresApproval) {
+					selection.approvalAllowed = true
+				} else {
+					selection.plainAllowed = true
+				}
+				ruleRank := TrustRank(rule.requiredTrust)
 				if ruleRank > selection.requiredTrustRank {
 					selection.requiredTrustRank = ruleRank
-					selection.requiredTrust = NormalizeTrust(rule.RequiredTrust)
+					selection.requiredTrust = NormalizeTrust(rule.requiredTrust)
 					attribute(grant)
 				}
 				selection.adminTrustRank = maxInt(selection.adminTrustRank, adminRank)
@@ -316,11 +475,11 @@ func bestGrantFor(grants []Grant, toolName ToolName, requiredTrust, requiredSide
 	return selection
 }
 
-func policySlices(policy *Document) ([]Binding, []Tool, []Grant) {
+func policySlices(policy *Document) ([]Binding, []Grant) {
 	if policy == nil {
-		return nil, nil, nil
+		return nil, nil
 	}
-	return policy.Sessions, policy.Tools, policy.Grants
+	return policy.Sessions, policy.Grants
 }
 
 func decideByDefault(policy *Document, reason string) Decision {
@@ -389,6 +548,32 @@ func resolveToolMetadata(tools []Tool, toolName ToolName) (string, string, strin
 	return requiredTrust, "", ""
 }
 
+// resolvePromptTrust returns the required trust of the named prompt, low when
+// the inventory does not list it.
+func resolvePromptTrust(prompts []Prompt, name PromptName) string {
+	for _, prompt := range prompts {
+		if prompt.Name == name && prompt.RequiredTrust != "" {
+			return NormalizeTrust(prompt.RequiredTrust)
+		}
+	}
+	return TrustLevelLow
+}
+
+// resolveResourceTrust returns the highest required trust among inventory
+// entries whose URI pattern matches uri, low when none match.
+func resolveResourceTrust(resources []Resource, uri string) string {
+	requiredTrust := TrustLevelLow
+	for _, resource := range resources {
+		if resource.RequiredTrust == "" || !MatchGlob(resource.URI, uri) {
+			continue
+		}
+		if TrustRank(resource.RequiredTrust) > TrustRank(requiredTrust) {
+			requiredTrust = NormalizeTrust(resource.RequiredTrust)
+		}
+	}
+	return requiredTrust
+}
+
 func NormalizeRiskLevel(risk, trust, sideEffect string) string {
 	switch strings.ToLower(strings.TrimSpace(risk)) {
 	case "low", "medium", "high":
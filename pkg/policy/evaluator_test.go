@@ -676,6 +676,175 @@ func TestAuthorizeReportsMatchedNamespaces(t *testing.T) {
 	}
 }
 
+func TestAuthorizeArgumentConstraints(t *testing.T) {
+	t.Parallel()
+
+	maxLimit := int64(100)
+	policy := testPolicyWithGrant()
+	policy.Grants[0].ToolRules = []ToolAccess{{
+		Name:     "upper",
+		Decision: "allow",
+		ArgumentConstraints: []ArgumentConstraint{
+			{Argument: "database", OneOf: []string{"analytics", "reporting"}},
+			{Argument: "to", Pattern: "*@ourcorp.com", Optional: true},
+			{Argument: "limit", Maximum: &maxLimit, Optional: true},
+		},
+	}}
+
+	tests := []struct {
+		name         string
+		arguments    map[string]any
+		wantAllow    bool
+		wantArgument string
+	}{
+		{name: "allowed", arguments: map[string]any{"database": "analytics", "to": []any{"a@ourcorp.com", "b@ourcorp.com"}, "limit": float64(100)}, wantAllow: true},
+		{name: "database not listed", arguments: map[string]any{"database": "billing"}, wantArgument: "database"},
+		{name: "required argument missing", arguments: map[string]any{}, wantArgument: "database"},
+		{name: "recipient outside domain", arguments: map[string]any{"database": "analytics", "to": []any{"a@ourcorp.com", "x@evil.example"}}, wantArgument: "to"},
+		{name: "limit over cap", arguments: map[string]any{"database": "analytics", "limit": float64(101)}, wantArgument: "limit"},
+		{name: "limit not numeric", arguments: map[string]any{"database": "analytics", "limit": "5"}, wantArgument: "limit"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := Authorize(policy, Request{
+				Identity:  Identity{HumanID: "human-1", AgentID: "agent-1"},
+				RPCMethod: "tools/call",
+				ToolName:  "upper",
+				Arguments: tc.arguments,
+			}, time.Time{})
+			if decision.Allowed != tc.wantAllow {
+				t.Fatalf("decision = %#v, want allowed=%v", decision, tc.wantAllow)
+			}
+			if tc.wantAllow {
+				return
+			}
+			if decision.Reason != "argument_constraint_violated" || decision.ViolatedArgument != tc.wantArgument {
+				t.Fatalf("decision = %#v, want argument_constraint_violated on %q", decision, tc.wantArgument)
+			}
+			if decision.MatchedGrant != "grant-1" {
+				t.Fatalf("decision = %#v, want constraining grant attributed", decision)
+			}
+		})
+	}
+}
+
+func TestAuthorizeArgumentConstraintIgnoresDefaultAllow(t *testing.T) {
+	t.Parallel()
+
+	policy := testPolicyWithGrant()
+	policy.Policy.DefaultDecision = "allow"
+	policy.Grants[0].ToolRules = []ToolAccess{{
+		Name:                "upper",
+		Decision:            "allow",
+		ArgumentConstraints: []ArgumentConstraint{{Argument: "database", OneOf: []string{"analytics"}}},
+	}}
+
+	decision := Authorize(policy, Request{
+		Identity:  Identity{HumanID: "human-1", AgentID: "agent-1"},
+		RPCMethod: "tools/call",
+		ToolName:  "upper",
+		Arguments: map[string]any{"database": "billing"},
+	}, time.Time{})
+
+	if decision.Allowed || decision.Reason != "argument_constraint_violated" {
+		t.Fatalf("decision = %#v, want argument_constraint_violated despite default allow", decision)
+	}
+}
+
+func TestAuthorizeUnconstrainedGrantOverridesConstraintViolation(t *testing.T) {
+	t.Parallel()
+
+	policy := testPolicyWithGrant()
+	policy.Grants[0].ToolRules = []ToolAccess{{
+		Name:                "upper",
+		Decision:            "allow",
+		ArgumentConstraints: []ArgumentConstraint{{Argument: "database", OneOf: []string{"analytics"}}},
+	}}
+	policy.Grants = append(policy.Grants, Grant{
+		Name:               "grant-2",
+		HumanID:            "human-1",
+		AgentID:            "agent-1",
+		MaxTrust:           "high",
+		AllowedSideEffects: []string{"read"},
+		ToolRules:          []ToolAccess{{Name: "upper", Decision: "allow"}},
+	})
+
+	decision := Authorize(policy, Request{
+		Identity:  Identity{HumanID: "human-1", AgentID: "agent-1"},
+		RPCMethod: "tools/call",
+		ToolName:  "upper",
+		Arguments: map[string]any{"database": "billing"},
+	}, time.Time{})
+
+	if !decision.Allowed || decision.MatchedGrant != "grant-2" {
+		t.Fatalf("decision = %#v, want allowed by unconstrained grant-2", decision)
+	}
+}
+
+func TestAuthorizePromptsAndResources(t *testing.T) {
+	t.Parallel()
+
+	policy := testPolicyWithGrant()
+	policy.Prompts = []Prompt{{Name: "incident-summary", RequiredTrust: "high"}}
+	policy.Resources = []Resource{{Name: "secrets", URI: "vault://*", RequiredTrust: "high"}}
+	policy.Grants[0].MaxTrust = "medium"
+	policy.Grants[0].PromptRules = []PromptAccess{
+		{Name: "greeting", Decision: "allow"},
+		{Name: "incident-summary", Decision: "allow"},
+	}
+	policy.Grants[0].ResourceRules = []ResourceAccess{
+		{URI: "file:///docs/*", Decision: "allow"},
+		{URI: "file:///docs/private/*", Decision: "deny"},
+		{URI: "vault://*", Decision: "allow"},
+	}
+
+	tests := []struct {
+		name       string
+		request    Request
+		wantAllow  bool
+		wantReason string
+	}{
+		{name: "granted prompt", request: Request{RPCMethod: "prompts/get", PromptName: "greeting"}, wantAllow: true, wantReason: "allowed"},
+		{name: "prompt not in rules", request: Request{RPCMethod: "prompts/get", PromptName: "other"}, wantReason: "prompt_not_granted"},
+		{name: "prompt above trust", request: Request{RPCMethod: "prompts/get", PromptName: "incident-summary"}, wantReason: "trust_too_low"},
+		{name: "granted resource", request: Request{RPCMethod: "resources/read", ResourceURI: "file:///docs/readme.md"}, wantAllow: true, wantReason: "allowed"},
+		{name: "denied resource", request: Request{RPCMethod: "resources/subscribe", ResourceURI: "file:///docs/private/keys"}, wantReason: "resource_denied"},
+		{name: "resource not in rules", request: Request{RPCMethod: "resources/read", ResourceURI: "file:///etc/passwd"}, wantReason: "resource_not_granted"},
+		{name: "resource above trust", request: Request{RPCMethod: "resources/read", ResourceURI: "vault://db"}, wantReason: "trust_too_low"},
+		{name: "unsubscribe passes through", request: Request{RPCMethod: "resources/unsubscribe", ResourceURI: "file:///etc/passwd"}, wantAllow: true, wantReason: "allowed"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.request.Identity = Identity{HumanID: "human-1", AgentID: "agent-1"}
+			decision := Authorize(policy, tc.request, time.Time{})
+			if decision.Allowed != tc.wantAllow || decision.Reason != tc.wantReason {
+				t.Fatalf("decision = %#v, want allowed=%v reason %q", decision, tc.wantAllow, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestAuthorizePromptsWithoutRulesFollowGrantSideEffects(t *testing.T) {
+	t.Parallel()
+
+	policy := testPolicyWithGrant()
+	request := Request{Identity: Identity{HumanID: "human-1", AgentID: "agent-1"}, RPCMethod: "prompts/get", PromptName: "greeting"}
+
+	if decision := Authorize(policy, request, time.Time{}); !decision.Allowed || decision.RequiredSideEffect != SideEffectRead {
+		t.Fatalf("decision = %#v, want read allowed by grant without prompt rules", decision)
+	}
+
+	policy.Grants[0].AllowedSideEffects = []string{"write"}
+	if decision := Authorize(policy, request, time.Time{}); decision.Allowed || decision.Reason != "side_effect_not_allowed" {
+		t.Fatalf("decision = %#v, want side_effect_not_allowed without read", decision)
+	}
+
+	request.Identity = Identity{HumanID: "stranger"}
+	if decision := Authorize(policy, request, time.Time{}); decision.Allowed || decision.Reason != "no_matching_grant" {
+		t.Fatalf("decision = %#v, want no_matching_grant", decision)
+	}
+}
+
 func testPolicyWithGrant() *Document {
 	return &Document{
 		Policy: &Config{
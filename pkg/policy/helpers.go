@@ -3,6 +3,7 @@ package policy
 
 import (
 	"strings"
+	"time"
 )
 
 // IsToolCallMethod returns true if the method is a tool invocation method.
@@ -15,6 +16,71 @@ func IsToolCallMethod(method string) bool {
 	}
 }
 
+// IsPromptMethod returns true if the method fetches a prompt.
+func IsPromptMethod(method string) bool {
+	return method == "prompts/get"
+}
+
+// IsResourceMethod returns true if the method reads or subscribes to a resource.
+func IsResourceMethod(method string) bool {
+	switch method {
+	case "resources/read", "resources/subscribe":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsGovernedMethod returns true if the method is authorized against grants and
+// sessions: tool calls, prompt fetches, and resource reads.
+func IsGovernedMethod(method string) bool {
+	return IsToolCallMethod(method) || IsPromptMethod(method) || IsResourceMethod(method)
+}
+
+// IsListMethod returns true if the method enumerates tools, prompts, or resources.
+func IsListMethod(method string) bool {
+	switch method {
+	case "tools/list", "prompts/list", "resources/list":
+		return true
+	default:
+		return false
+	}
+}
+
+// FilterListResponses returns true if list responses should be filtered per caller.
+func FilterListResponses(policy *Document) bool {
+	return policy != nil && policy.Policy != nil && policy.Policy.FilterListResponses
+}
+
+// FindGrant returns the grant with the given name and namespace.
+func FindGrant(policy *Document, name, namespace string) (Grant, bool) {
+	if policy == nil || name == "" {
+		return Grant{}, false
+	}
+	for _, grant := range policy.Grants {
+		if grant.Name == name && string(grant.Namespace) == namespace {
+			return grant, true
+		}
+	}
+	return Grant{}, false
+}
+
+// RateLimitsFor returns the grant's rate limits that apply to a call of
+// toolName with the given side effect.
+func RateLimitsFor(grant Grant, toolName ToolName, sideEffect string) []RateLimit {
+	var limits []RateLimit
+	for _, limit := range grant.RateLimits {
+		if limit.Tool != "" && limit.Tool != toolName {
+			continue
+		}
+		if limit.SideEffect != "" && NormalizeSideEffect(limit.SideEffect) != NormalizeSideEffect(sideEffect) {
+			continue
+		}
+		limits = append(limits, limit)
+	}
+	return limits
+}
+
 // FirstNonEmpty returns the first non-empty string from the provided values.
 func FirstNonEmpty(values ...string) string {
 	for _, value := range values {
@@ -57,6 +123,18 @@ func PolicyServerCluster(policy *Document) string {
 	return policy.Server.Cluster
 }
 
+// SessionLimits returns the session max lifetime and idle timeout of a policy
+// document. Unset or unparsable values are zero, meaning no limit; Validate
+// rejects unparsable values before a document is activated.
+func SessionLimits(policy *Document) (maxLifetime, idleTimeout time.Duration) {
+	if policy == nil || policy.Session == nil {
+		return 0, 0
+	}
+	maxLifetime, _ = time.ParseDuration(strings.TrimSpace(policy.Session.MaxLifetime))
+	idleTimeout, _ = time.ParseDuration(strings.TrimSpace(policy.Session.IdleTimeout))
+	return max(maxLifetime, 0), max(idleTimeout, 0)
+}
+
 // PolicyVersion returns the policy version from a policy document.
 func PolicyVersion(policy *Document) string {
 	if policy == nil || policy.Policy == nil {
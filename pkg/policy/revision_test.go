@@ -59,6 +59,29 @@ func TestComputeRevisionChangesWithContent(t *testing.T) {
 	}
 }
 
+func TestComputeRevisionChangesWithArgumentConstraints(t *testing.T) {
+	base := validStampedDocument()
+	base.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "echo", Decision: "allow"}}}}
+	baseRev, _ := ComputeRevision(base)
+
+	constrained := validStampedDocument()
+	constrained.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{
+		Name:                "echo",
+		Decision:            "allow",
+		ArgumentConstraints: []ArgumentConstraint{{Argument: "text", Pattern: "hello*"}},
+	}}}}
+	constrainedRev, _ := ComputeRevision(constrained)
+	if baseRev == constrainedRev {
+		t.Fatal("adding an argument constraint did not change revision")
+	}
+
+	constrained.Grants[0].ToolRules[0].ArgumentConstraints[0].Pattern = "bye*"
+	changedRev, _ := ComputeRevision(constrained)
+	if changedRev == constrainedRev {
+		t.Fatal("changing an argument constraint did not change revision")
+	}
+}
+
 func TestStampSetsMetadata(t *testing.T) {
 	doc := validStampedDocument()
 	if err := Stamp(doc, "2026-06-10T12:00:00Z"); err != nil {
@@ -38,6 +38,9 @@ type SessionID string
 // ToolName identifies an MCP tool in a rendered gateway policy.
 type ToolName string
 
+// PromptName identifies an MCP prompt in a rendered gateway policy.
+type PromptName string
+
 // Document is the root gateway policy document that contains all policy configuration.
 type Document struct {
 	// SchemaVersion identifies the compatibility of the rendered JSON contract.
@@ -48,14 +51,22 @@ type Document struct {
 	// produces the same revision regardless of when it was generated.
 	Revision string `json:"revision"`
 	// GeneratedAt is informational only and must not affect Revision.
-	GeneratedAt string    `json:"generated_at,omitempty"`
-	Server      Server    `json:"server"`
-	Auth        *Auth     `json:"auth,omitempty"`
-	Policy      *Config   `json:"policy,omitempty"`
-	Session     *Session  `json:"session,omitempty"`
-	Tools       []Tool    `json:"tools,omitempty"`
-	Grants      []Grant   `json:"grants,omitempty"`
-	Sessions    []Binding `json:"sessions,omitempty"`
+	GeneratedAt string     `json:"generated_at,omitempty"`
+	Server      Server     `json:"server"`
+	Auth        *Auth      `json:"auth,omitempty"`
+	Policy      *Config    `json:"policy,omitempty"`
+	Session     *Session   `json:"session,omitempty"`
+	Tools       []Tool     `json:"tools,omitempty"`
+	Prompts     []Prompt   `json:"prompts,omitempty"`
+	Resources   []Resource `json:"resources,omitempty"`
+	Grants      []Grant    `json:"grants,omitempty"`
+	Sessions    []Binding  `json:"sessions,omitempty"`
+	Network     *Network   `json:"network,omitempty"`
+	// Baseline carries the cluster-wide MCPPolicyBaseline rules. Being part of
+	// the document, its revision is covered by Revision.
+	Baseline *Baseline `json:"baseline,omitempty"`
+	// Approval configures which allowed tool calls wait for a human decision.
+	Approval *Approval `json:"approval,omitempty"`
 }
 
 // Server identifies the MCP server this policy applies to.
@@ -85,6 +96,10 @@ type Config struct {
 	DefaultDecision string `json:"default_decision,omitempty"`
 	EnforceOn       string `json:"enforce_on,omitempty"`
 	PolicyVersion   string `json:"policy_version,omitempty"`
+	// FilterListResponses makes the gateway drop entries from tools/list,
+	// prompts/list, and resources/list responses that the caller could not
+	// invoke under this document.
+	FilterListResponses bool `json:"filter_list_responses,omitempty"`
 }
 
 // Session configures session management settings.
@@ -107,6 +122,62 @@ type Tool struct {
 	Labels        map[string]string `json:"labels,omitempty"`
 }
 
+// Prompt describes an MCP prompt and its trust requirement. Fetching a prompt
+// is always a read.
+type Prompt struct {
+	Name          PromptName        `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	RequiredTrust string            `json:"required_trust,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+// Resource describes MCP resources whose URI matches a glob pattern and their
+// trust requirement. Reading or subscribing to a resource is always a read.
+type Resource struct {
+	Name string `json:"name"`
+	// URI is a glob matched against resource URIs; "*" matches any run of
+	// characters.
+	URI           string            `json:"uri"`
+	Description   string            `json:"description,omitempty"`
+	RequiredTrust string            `json:"required_trust,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+// Network records the server's declared network restrictions so that policy
+// inspection shows them next to the tools they box in. The operator enforces
+// them as a NetworkPolicy; the gateway does not read them.
+type Network struct {
+	Egress *Egress `json:"egress,omitempty"`
+}
+
+// Egress is the server's egress allow list. Cluster DNS and the gateway's own
+// in-cluster endpoints are allowed in addition to Rules.
+type Egress struct {
+	DefaultDeny bool         `json:"default_deny,omitempty"`
+	Rules       []EgressRule `json:"rules,omitempty"`
+}
+
+// EgressRule allows connections to any of To on any of Ports.
+type EgressRule struct {
+	To    []EgressPeer  `json:"to,omitempty"`
+	Ports []NetworkPort `json:"ports,omitempty"`
+}
+
+// EgressPeer is an IP block or a label-selected set of pods.
+type EgressPeer struct {
+	CIDR              string            `json:"cidr,omitempty"`
+	Except            []string          `json:"except,omitempty"`
+	NamespaceSelector map[string]string `json:"namespace_selector,omitempty"`
+	PodSelector       map[string]string `json:"pod_selector,omitempty"`
+}
+
+// NetworkPort is a port or an inclusive port range.
+type NetworkPort struct {
+	Protocol string `json:"protocol,omitempty"`
+	Port     int32  `json:"port,omitempty"`
+	EndPort  int32  `json:"end_port,omitempty"`
+}
+
 // Grant defines access grants for subjects (humans/agents).
 type Grant struct {
 	Name               string       `json:"name"`
@@ -119,20 +190,64 @@ type Grant struct {
 	PolicyVersion      string       `json:"policy_version,omitempty"`
 	Disabled           bool         `json:"disabled,omitempty"`
 	ToolRules          []ToolAccess `json:"tool_rules,omitempty"`
+	// PromptRules and ResourceRules restrict prompts/get and
+	// resources/read|subscribe the way ToolRules restrict tools/call. Empty
+	// means the grant does not restrict prompts or resources by name or URI.
+	PromptRules   []PromptAccess   `json:"prompt_rules,omitempty"`
+	ResourceRules []ResourceAccess `json:"resource_rules,omitempty"`
+	RateLimits    []RateLimit      `json:"rate_limits,omitempty"`
+}
+
+// RateLimit caps how many tool calls each subject matched by a grant may make.
+// Tool and SideEffect narrow which calls count toward the limit; with both
+// empty every tool call allowed by the grant counts.
+type RateLimit struct {
+	Tool       ToolName `json:"tool,omitempty"`
+	SideEffect string   `json:"side_effect,omitempty"`
+	// CallsPerMinute is enforced over fixed one-minute windows.
+	CallsPerMinute int `json:"calls_per_minute,omitempty"`
+	// CallsPerDay is a rolling 24-hour quota.
+	CallsPerDay int `json:"calls_per_day,omitempty"`
 }
 
 // Binding represents an agent session binding.
 type Binding struct {
-	Name             SessionID `json:"name"`
-	Namespace        Namespace `json:"namespace,omitempty"`
-	HumanID          HumanID   `json:"human_id,omitempty"`
-	AgentID          AgentID   `json:"agent_id,omitempty"`
-	TeamID           TeamID    `json:"team_id,omitempty"`
-	ConsentedTrust   string    `json:"consented_trust,omitempty"`
-	Revoked          bool      `json:"revoked,omitempty"`
-	ExpiresAt        string    `json:"expires_at,omitempty"`
-	PolicyVersion    string    `json:"policy_version,omitempty"`
-	UpstreamTokenRef string    `json:"upstream_token_ref,omitempty"`
+	Name           SessionID `json:"name"`
+	Namespace      Namespace `json:"namespace,omitempty"`
+	HumanID        HumanID   `json:"human_id,omitempty"`
+	AgentID        AgentID   `json:"agent_id,omitempty"`
+	TeamID         TeamID    `json:"team_id,omitempty"`
+	ConsentedTrust string    `json:"consented_trust,omitempty"`
+	Revoked        bool      `json:"revoked,omitempty"`
+	ExpiresAt      string    `json:"expires_at,omitempty"`
+	// CreatedAt is when the MCPAgentSession was created. The session max
+	// lifetime is measured from it so every gateway replica agrees on it.
+	CreatedAt        string `json:"created_at,omitempty"`
+	PolicyVersion    string `json:"policy_version,omitempty"`
+	UpstreamTokenRef string `json:"upstream_token_ref,omitempty"`
+}
+
+// Baseline is the merged set of cluster-wide baseline rules. Rules are checked
+// in order against tool calls the grants allowed; the first one that matches
+// and is not satisfied denies the call.
+type Baseline struct {
+	// Revision is a digest of Rules, identical on every server rendered from
+	// the same baselines. See ComputeBaselineRevision.
+	Revision string         `json:"revision"`
+	Rules    []BaselineRule `json:"rules,omitempty"`
+}
+
+// BaselineRule matches tool calls by caller and tool metadata. Every selector
+// that is set must match.
+type BaselineRule struct {
+	// Name is <baseline>/<rule> and is reported on baseline_denied decisions.
+	Name          string            `json:"name"`
+	Subject       string            `json:"subject,omitempty"`
+	Tools         []ToolName        `json:"tools,omitempty"`
+	SideEffects   []string          `json:"side_effects,omitempty"`
+	ToolLabels    map[string]string `json:"tool_labels,omitempty"`
+	Effect        string            `json:"effect"`
+	RequiredTrust string            `json:"required_trust,omitempty"`
 }
 
 // ToolAccess defines access rules for a specific tool.
@@ -140,6 +255,40 @@ type ToolAccess struct {
 	Name          ToolName `json:"name"`
 	Decision      string   `json:"decision,omitempty"`
 	RequiredTrust string   `json:"required_trust,omitempty"`
+	// ArgumentConstraints must all hold for a tools/call allowed by this rule.
+	ArgumentConstraints []ArgumentConstraint `json:"argument_constraints,omitempty"`
+}
+
+// PromptAccess defines access rules for a specific prompt.
+type PromptAccess struct {
+	Name          PromptName `json:"name"`
+	Decision      string     `json:"decision,omitempty"`
+	RequiredTrust string     `json:"required_trust,omitempty"`
+}
+
+// ResourceAccess defines access rules for resources whose URI matches a glob.
+type ResourceAccess struct {
+	URI           string `json:"uri"`
+	Decision      string `json:"decision,omitempty"`
+	RequiredTrust string `json:"required_trust,omitempty"`
+}
+
+// ArgumentConstraint restricts one top-level key of tools/call
+// params.arguments. When the argument is an array, every element must satisfy
+// the constraint.
+type ArgumentConstraint struct {
+	Argument string `json:"argument"`
+	// OneOf lists the allowed values, compared as strings.
+	OneOf []string `json:"one_of,omitempty"`
+	// Pattern is a glob string values must match; "*" matches any run of
+	// characters.
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum and Maximum bound numeric values inclusively.
+	Minimum *int64 `json:"minimum,omitempty"`
+	Maximum *int64 `json:"maximum,omitempty"`
+	// Optional allows calls that omit the argument. A missing argument
+	// otherwise violates the constraint.
+	Optional bool `json:"optional,omitempty"`
 }
 
 func ToolRiskLevel(policy *Document, toolName string) string {
@@ -3,6 +3,7 @@ package policy
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Validate checks that a rendered gateway policy document is structurally sound
@@ -41,13 +42,28 @@ func Validate(doc *Document) error {
 	if err := validateConfig(doc.Policy); err != nil {
 		return err
 	}
+	if err := validateSession(doc.Session); err != nil {
+		return err
+	}
 	if err := validateTools(doc.Tools); err != nil {
 		return err
 	}
+	if err := validatePrompts(doc.Prompts); err != nil {
+		return err
+	}
+	if err := validateResources(doc.Resources); err != nil {
+		return err
+	}
 	if err := validateGrants(doc.Grants); err != nil {
 		return err
 	}
-	return validateBindings(doc.Sessions)
+	if err := validateBindings(doc.Sessions); err != nil {
+		return err
+	}
+	if err := validateApproval(doc.Approval); err != nil {
+		return err
+	}
+	return validateBaseline(doc.Baseline)
 }
 
 func validateAuth(auth *Auth) error {
@@ -86,6 +102,27 @@ func validateConfig(cfg *Config) error {
 	return nil
 }
 
+func validateSession(session *Session) error {
+	if session == nil {
+		return nil
+	}
+	if err := validateSessionDuration("max_lifetime", session.MaxLifetime); err != nil {
+		return err
+	}
+	return validateSessionDuration("idle_timeout", session.IdleTimeout)
+}
+
+func validateSessionDuration(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || duration < 0 {
+		return fmt.Errorf("policy: session %s %q must be a non-negative duration", field, value)
+	}
+	return nil
+}
+
 func validateTools(tools []Tool) error {
 	seen := make(map[ToolName]struct{}, len(tools))
 	for i, tool := range tools {
@@ -106,6 +143,43 @@ func validateTools(tools []Tool) error {
 	return nil
 }
 
+func validatePrompts(prompts []Prompt) error {
+	seen := make(map[PromptName]struct{}, len(prompts))
+	for i, prompt := range prompts {
+		if strings.TrimSpace(string(prompt.Name)) == "" {
+			return fmt.Errorf("policy: prompts[%d] name is required", i)
+		}
+		if _, dup := seen[prompt.Name]; dup {
+			return fmt.Errorf("policy: duplicate prompt name %q", prompt.Name)
+		}
+		seen[prompt.Name] = struct{}{}
+		if !validTrust(prompt.RequiredTrust) {
+			return fmt.Errorf("policy: prompt %q has invalid required_trust %q", prompt.Name, prompt.RequiredTrust)
+		}
+	}
+	return nil
+}
+
+func validateResources(resources []Resource) error {
+	seen := make(map[string]struct{}, len(resources))
+	for i, resource := range resources {
+		if strings.TrimSpace(resource.Name) == "" {
+			return fmt.Errorf("policy: resources[%d] name is required", i)
+		}
+		if _, dup := seen[resource.Name]; dup {
+			return fmt.Errorf("policy: duplicate resource name %q", resource.Name)
+		}
+		seen[resource.Name] = struct{}{}
+		if strings.TrimSpace(resource.URI) == "" {
+			return fmt.Errorf("policy: resource %q uri is required", resource.Name)
+		}
+		if !validTrust(resource.RequiredTrust) {
+			return fmt.Errorf("policy: resource %q has invalid required_trust %q", resource.Name, resource.RequiredTrust)
+		}
+	}
+	return nil
+}
+
 func validateGrants(grants []Grant) error {
 	seen := make(map[string]struct{}, len(grants))
 	for i, grant := range grants {
@@ -133,13 +207,95 @@ func validateGrants(grants []Grant) error {
 				return fmt.Errorf("policy: grant %q has duplicate tool rule %q", grant.Name, rule.Name)
 			}
 			seenRules[rule.Name] = struct{}{}
-			if !validDecision(rule.Decision) {
+			if !validDecision(rule.Decision) && !strings.EqualFold(strings.TrimSpace(rule.Decision), DecisionRequiresApproval) {
 				return fmt.Errorf("policy: grant %q tool rule %q has invalid decision %q", grant.Name, rule.Name, rule.Decision)
 			}
 			if !validTrust(rule.RequiredTrust) {
 				return fmt.Errorf("policy: grant %q tool rule %q has invalid required_trust %q", grant.Name, rule.Name, rule.RequiredTrust)
 			}
+			if err := validateArgumentConstraints(rule); err != nil {
+				return fmt.Errorf("policy: grant %q %w", grant.Name, err)
+			}
+		}
+		seenPrompts := make(map[PromptName]struct{}, len(grant.PromptRules))
+		for j, rule := range grant.PromptRules {
+			if strings.TrimSpace(string(rule.Name)) == "" {
+				return fmt.Errorf("policy: grant %q prompt_rules[%d] name is required", grant.Name, j)
+			}
+			if _, dup := seenPrompts[rule.Name]; dup {
+				return fmt.Errorf("policy: grant %q has duplicate prompt rule %q", grant.Name, rule.Name)
+			}
+			seenPrompts[rule.Name] = struct{}{}
+			if !validDecision(rule.Decision) {
+				return fmt.Errorf("policy: grant %q prompt rule %q has invalid decision %q", grant.Name, rule.Name, rule.Decision)
+			}
+			if !validTrust(rule.RequiredTrust) {
+				return fmt.Errorf("policy: grant %q prompt rule %q has invalid required_trust %q", grant.Name, rule.Name, rule.RequiredTrust)
+			}
+		}
+		seenResources := make(map[string]struct{}, len(grant.ResourceRules))
+		for j, rule := range grant.ResourceRules {
+			if strings.TrimSpace(rule.URI) == "" {
+				return fmt.Errorf("policy: grant %q resource_rules[%d] uri is required", grant.Name, j)
+			}
+			if _, dup := seenResources[rule.URI]; dup {
+				return fmt.Errorf("policy: grant %q has duplicate resource rule %q", grant.Name, rule.URI)
+			}
+			seenResources[rule.URI] = struct{}{}
+			if !validDecision(rule.Decision) {
+				return fmt.Errorf("policy: grant %q resource rule %q has invalid decision %q", grant.Name, rule.URI, rule.Decision)
+			}
+			if !validTrust(rule.RequiredTrust) {
+				return fmt.Errorf("policy: grant %q resource rule %q has invalid required_trust %q", grant.Name, rule.URI, rule.RequiredTrust)
+			}
+		}
+		if err := validateRateLimits(grant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateArgumentConstraints(rule ToolAccess) error {
+	if len(rule.ArgumentConstraints) > 0 && strings.EqualFold(strings.TrimSpace(rule.Decision), "deny") {
+		return fmt.Errorf("tool rule %q has argument constraints on a deny decision", rule.Name)
+	}
+	seen := make(map[string]struct{}, len(rule.ArgumentConstraints))
+	for i, constraint := range rule.ArgumentConstraints {
+		if strings.TrimSpace(constraint.Argument) == "" {
+			return fmt.Errorf("tool rule %q argument_constraints[%d] argument is required", rule.Name, i)
+		}
+		if _, dup := seen[constraint.Argument]; dup {
+			return fmt.Errorf("tool rule %q has duplicate argument constraint %q", rule.Name, constraint.Argument)
+		}
+		seen[constraint.Argument] = struct{}{}
+		if len(constraint.OneOf) == 0 && constraint.Pattern == "" && constraint.Minimum == nil && constraint.Maximum == nil {
+			return fmt.Errorf("tool rule %q argument constraint %q must set one_of, pattern, minimum, or maximum", rule.Name, constraint.Argument)
+		}
+		if constraint.Minimum != nil && constraint.Maximum != nil && *constraint.Minimum > *constraint.Maximum {
+			return fmt.Errorf("tool rule %q argument constraint %q has minimum greater than maximum", rule.Name, constraint.Argument)
+		}
+	}
+	return nil
+}
+
+func validateRateLimits(grant Grant) error {
+	seen := make(map[string]struct{}, len(grant.RateLimits))
+	for i, limit := range grant.RateLimits {
+		if limit.SideEffect != "" && !validSideEffect(limit.SideEffect, false) {
+			return fmt.Errorf("policy: grant %q rate_limits[%d] has invalid side_effect %q", grant.Name, i, limit.SideEffect)
+		}
+		if limit.CallsPerMinute < 0 || limit.CallsPerDay < 0 {
+			return fmt.Errorf("policy: grant %q rate_limits[%d] must not be negative", grant.Name, i)
+		}
+		if limit.CallsPerMinute == 0 && limit.CallsPerDay == 0 {
+			return fmt.Errorf("policy: grant %q rate_limits[%d] must set calls_per_minute or calls_per_day", grant.Name, i)
+		}
+		scope := string(limit.Tool) + "\x00" + NormalizeSideEffect(limit.SideEffect)
+		if _, dup := seen[scope]; dup {
+			return fmt.Errorf("policy: grant %q has duplicate rate limit for tool %q side_effect %q", grant.Name, limit.Tool, limit.SideEffect)
 		}
+		seen[scope] = struct{}{}
 	}
 	return nil
 }
@@ -187,8 +343,9 @@ func validSideEffect(value string, allowEmpty bool) bool {
 	}
 }
 
-// validDecision reports whether a tool-rule decision is empty (treated as allow
-// downstream) or one of the recognized decisions.
+// validDecision reports whether a rule decision is empty (treated as allow
+// downstream) or one of the recognized decisions. Tool rules additionally
+// accept requires_approval.
 func validDecision(value string) bool {
 	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "", "allow", "deny":
@@ -55,6 +55,8 @@ func TestValidateRejects(t *testing.T) {
 		{"oauth without issuer", func(d *Document) { d.Auth = &Auth{Mode: "oauth"} }, true, "issuer_url"},
 		{"invalid policy mode", func(d *Document) { d.Policy = &Config{Mode: "deny-everything"} }, true, "policy mode"},
 		{"invalid default decision", func(d *Document) { d.Policy = &Config{DefaultDecision: "maybe"} }, true, "default decision"},
+		{"invalid session max lifetime", func(d *Document) { d.Session = &Session{MaxLifetime: "a day"} }, true, "max_lifetime"},
+		{"negative session idle timeout", func(d *Document) { d.Session = &Session{IdleTimeout: "-1h"} }, true, "idle_timeout"},
 		{"invalid tool trust", func(d *Document) { d.Tools = []Tool{{Name: "t", RequiredTrust: "ultra"}} }, true, "required_trust"},
 		{"invalid tool side effect", func(d *Document) { d.Tools = []Tool{{Name: "t", SideEffect: "explode"}} }, true, "side_effect"},
 		{"duplicate tool", func(d *Document) { d.Tools = []Tool{{Name: "t"}, {Name: "t"}} }, true, "duplicate tool"},
@@ -70,6 +72,46 @@ func TestValidateRejects(t *testing.T) {
 		{"duplicate tool rule", func(d *Document) {
 			d.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "t"}, {Name: "t"}}}}
 		}, true, "duplicate tool rule"},
+		{"argument constraint without argument", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "t", ArgumentConstraints: []ArgumentConstraint{{OneOf: []string{"a"}}}}}}}
+		}, true, "argument is required"},
+		{"argument constraint without predicate", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "t", ArgumentConstraints: []ArgumentConstraint{{Argument: "a"}}}}}}
+		}, true, "must set one_of"},
+		{"argument constraint inverted bounds", func(d *Document) {
+			low, high := int64(10), int64(1)
+			d.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "t", ArgumentConstraints: []ArgumentConstraint{{Argument: "a", Minimum: &low, Maximum: &high}}}}}}
+		}, true, "minimum greater than maximum"},
+		{"argument constraint on deny rule", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", ToolRules: []ToolAccess{{Name: "t", Decision: "deny", ArgumentConstraints: []ArgumentConstraint{{Argument: "a", Pattern: "*"}}}}}}
+		}, true, "deny decision"},
+		{"rate limit without budget", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", RateLimits: []RateLimit{{Tool: "t"}}}}
+		}, true, "calls_per_minute or calls_per_day"},
+		{"negative rate limit", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", RateLimits: []RateLimit{{CallsPerMinute: -1}}}}
+		}, true, "must not be negative"},
+		{"invalid rate limit side effect", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", RateLimits: []RateLimit{{SideEffect: "boom", CallsPerDay: 1}}}}
+		}, true, "side_effect"},
+		{"duplicate rate limit scope", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", RateLimits: []RateLimit{{Tool: "t", CallsPerDay: 1}, {Tool: "t", CallsPerMinute: 1}}}}
+		}, true, "duplicate rate limit"},
+		{"prompt without name", func(d *Document) { d.Prompts = []Prompt{{RequiredTrust: "low"}} }, true, "prompts[0] name"},
+		{"invalid prompt trust", func(d *Document) { d.Prompts = []Prompt{{Name: "p", RequiredTrust: "ultra"}} }, true, "required_trust"},
+		{"resource without uri", func(d *Document) { d.Resources = []Resource{{Name: "r"}} }, true, "uri is required"},
+		{"duplicate resource", func(d *Document) {
+			d.Resources = []Resource{{Name: "r", URI: "file:///a"}, {Name: "r", URI: "file:///b"}}
+		}, true, "duplicate resource"},
+		{"invalid prompt rule decision", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", PromptRules: []PromptAccess{{Name: "p", Decision: "perhaps"}}}}
+		}, true, "decision"},
+		{"duplicate resource rule", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", ResourceRules: []ResourceAccess{{URI: "file:///*"}, {URI: "file:///*"}}}}
+		}, true, "duplicate resource rule"},
+		{"resource rule without uri", func(d *Document) {
+			d.Grants = []Grant{{Name: "g", ResourceRules: []ResourceAccess{{Decision: "allow"}}}}
+		}, true, "resource_rules[0] uri"},
 		{"duplicate session", func(d *Document) { d.Sessions = []Binding{{Name: "s"}, {Name: "s"}} }, true, "duplicate session"},
 		{"invalid consented trust", func(d *Document) {
 			d.Sessions = []Binding{{Name: "s", ConsentedTrust: "godmode"}}
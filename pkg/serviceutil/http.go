@@ -31,9 +31,16 @@ func WriteJSON(w http.ResponseWriter, status int, payload any) {
 
 // StartMetricsServer starts a Prometheus metrics server with /metrics and /health.
 func StartMetricsServer(port string) (func(context.Context) error, <-chan error) {
+	return StartMetricsServerWithRoutes(port, nil)
+}
+
+// StartMetricsServerWithRoutes starts a metrics server like StartMetricsServer
+// that also serves routes, such as probe endpoints that must stay reachable
+// when the main listener requires client certificates.
+func StartMetricsServerWithRoutes(port string, routes map[string]http.Handler) (func(context.Context) error, <-chan error) {
 	metricsServer := &http.Server{
 		Addr:              listenAddr(port),
-		Handler:           metricsHandler(),
+		Handler:           metricsHandler(routes),
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
 		WriteTimeout:      15 * time.Second,
@@ -49,13 +56,16 @@ func StartMetricsServer(port string) (func(context.Context) error, <-chan error)
 	return metricsServer.Shutdown, errs
 }
 
-func metricsHandler() http.Handler {
+func metricsHandler(routes map[string]http.Handler) http.Handler {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
 	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
+	for pattern, handler := range routes {
+		mux.Handle(pattern, handler)
+	}
 	return mux
 }
 
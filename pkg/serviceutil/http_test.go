@@ -36,7 +36,7 @@ func TestLogRequestsPreservesFlusher(t *testing.T) {
 
 func TestMetricsHandlerServesHealth(t *testing.T) {
 	recorder := httptest.NewRecorder()
-	metricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	metricsHandler(nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
 
 	if recorder.Code != http.StatusOK {
 		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
@@ -46,6 +46,19 @@ func TestMetricsHandlerServesHealth(t *testing.T) {
 	}
 }
 
+func TestMetricsHandlerServesExtraRoutes(t *testing.T) {
+	handler := metricsHandler(map[string]http.Handler{
+		"/probe": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/probe", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want the route's response", recorder.Code)
+	}
+}
+
 func TestStartMetricsServerReportsListenError(t *testing.T) {
 	_, errs := StartMetricsServer("127.0.0.1:-1")
 
@@ -0,0 +1,294 @@
+package main
+
+// activator.go implements the gateway's activator mode
+// (MCP_GATEWAY_MODE=activator). The operator runs one activator per
+// scale-to-zero MCPServer and points the server's Service at it while the
+// workload has no ready replicas. The activator holds each request, asks the
+// operator to scale the workload up, and forwards the request once the
+// workload Service accepts connections.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"mcp-runtime/pkg/serviceutil"
+)
+
+const (
+	defaultColdStartTimeout = 60 * time.Second
+	// activatorPollInterval is how often the workload Service is dialed while
+	// a request waits.
+	activatorPollInterval = 250 * time.Millisecond
+	// activationResendInterval bounds how often the operator is asked to
+	// scale up while requests wait.
+	activationResendInterval = 5 * time.Second
+)
+
+type activatorMetrics struct {
+	requestsTotal *prometheus.CounterVec
+	waitSeconds   *prometheus.HistogramVec
+}
+
+func newActivatorMetrics(registerer prometheus.Registerer) *activatorMetrics {
+	m := &activatorMetrics{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_activator_requests_total",
+			Help: "Total requests held by scale-to-zero activators, by result.",
+		}, []string{"namespace", "server", "result"}),
+		waitSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_gateway_activator_wait_seconds",
+			Help:    "Time requests waited in a scale-to-zero activator for the workload to become reachable.",
+			Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+		}, []string{"namespace", "server"}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(m.requestsTotal, m.waitSeconds)
+	}
+	return m
+}
+
+// activator holds requests for a scaled-to-zero server until its workload is
+// reachable.
+type activator struct {
+	upstream      *url.URL
+	proxy         *httputil.ReverseProxy
+	activationURL string
+	// operatorTokenFile authenticates activation requests; see
+	// OPERATOR_TOKEN_FILE.
+	operatorTokenFile string
+	serverName        string
+	serverNamespace   string
+	coldStartTimeout  time.Duration
+	pollInterval      time.Duration
+	httpClient        *http.Client
+	metrics           *activatorMetrics
+	// dial reports whether the workload accepts connections.
+	dial func(ctx context.Context) error
+
+	mu             sync.Mutex
+	lastActivation time.Time
+}
+
+func newActivator(upstream *url.URL, activationURL, serverName, serverNamespace string, coldStartTimeout time.Duration, metrics *activatorMetrics) *activator {
+	a := &activator{
+		upstream:         upstream,
+		proxy:            newUpstreamReverseProxy(upstream),
+		activationURL:    activationURL,
+		serverName:       serverName,
+		serverNamespace:  serverNamespace,
+		coldStartTimeout: coldStartTimeout,
+		pollInterval:     activatorPollInterval,
+		httpClient:       &http.Client{Timeout: 3 * time.Second},
+		metrics:          metrics,
+	}
+	a.proxy.ErrorHandler = func(w http.ResponseWriter, _ *http.Request, err error) {
+		log.Printf("activator upstream error: %v", err)
+		http.Error(w, "upstream error", http.StatusBadGateway)
+	}
+	a.dial = a.dialUpstream
+	return a
+}
+
+func (a *activator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The request may be replayed to the workload after a long wait, so the
+	// body is read up front instead of streamed.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRPCBodyBytes))
+	if err != nil {
+		a.metrics.requestsTotal.WithLabelValues(a.serverNamespace, a.serverName, "rejected").Inc()
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	ready := a.waitForUpstream(r.Context())
+	a.metrics.waitSeconds.WithLabelValues(a.serverNamespace, a.serverName).Observe(time.Since(start).Seconds())
+	if !ready {
+		a.metrics.requestsTotal.WithLabelValues(a.serverNamespace, a.serverName, "timeout").Inc()
+		w.Header().Set("Retry-After", strconv.Itoa(int(activationResendInterval.Seconds())))
+		http.Error(w, "server is starting; retry shortly", http.StatusServiceUnavailable)
+		return
+	}
+	a.metrics.requestsTotal.WithLabelValues(a.serverNamespace, a.serverName, "proxied").Inc()
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	a.proxy.ServeHTTP(w, r)
+}
+
+// waitForUpstream requests activation and polls the workload until it
+// accepts connections, the cold start timeout passes, or ctx is done.
+func (a *activator) waitForUpstream(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, a.coldStartTimeout)
+	defer cancel()
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.dial(ctx); err == nil {
+			return true
+		}
+		a.requestActivation(ctx)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *activator) dialUpstream(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", upstreamHostPort(a.upstream))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func upstreamHostPort(upstream *url.URL) string {
+	if upstream.Port() != "" {
+		return upstream.Host
+	}
+	if upstream.Scheme == "https" {
+		return net.JoinHostPort(upstream.Hostname(), "443")
+	}
+	return net.JoinHostPort(upstream.Hostname(), "80")
+}
+
+// requestActivation asks the operator to scale the workload up, at most once
+// per activationResendInterval across all waiting requests.
+func (a *activator) requestActivation(ctx context.Context) {
+	a.mu.Lock()
+	if !a.lastActivation.IsZero() && time.Since(a.lastActivation) < activationResendInterval {
+		a.mu.Unlock()
+		return
+	}
+	a.lastActivation = time.Now()
+	a.mu.Unlock()
+
+	payload, err := json.Marshal(map[string]string{"namespace": a.serverNamespace, "name": a.serverName})
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.activationURL, bytes.NewReader(payload))
+	if err != nil {
+		log.Printf("activation request failed: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setOperatorToken(req, a.operatorTokenFile); err != nil {
+		log.Printf("activation request failed: %v", err)
+		return
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		log.Printf("activation request failed: %v", err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		log.Printf("activation request for %s/%s returned %s", a.serverNamespace, a.serverName, resp.Status)
+	}
+}
+
+// activatorConfigFromEnv reads the activator settings the operator sets on
+// the activator Deployment.
+func activatorConfigFromEnv(getenv func(string) string) (*url.URL, string, time.Duration, error) {
+	upstream, err := url.Parse(strings.TrimSpace(getenv("UPSTREAM_URL")))
+	if err != nil || upstream.Host == "" {
+		return nil, "", 0, fmt.Errorf("invalid UPSTREAM_URL %q", getenv("UPSTREAM_URL"))
+	}
+	activationURL := strings.TrimSpace(getenv("ACTIVATION_URL"))
+	if activationURL == "" {
+		return nil, "", 0, errors.New("ACTIVATION_URL is required")
+	}
+	coldStart := defaultColdStartTimeout
+	if value := strings.TrimSpace(getenv("COLD_START_TIMEOUT")); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, "", 0, fmt.Errorf("invalid COLD_START_TIMEOUT %q", value)
+		}
+		coldStart = parsed
+	}
+	return upstream, activationURL, coldStart, nil
+}
+
+func runActivator() {
+	port := serviceutil.EnvOr("PORT", "8091")
+	metricsPort := serviceutil.EnvOr("METRICS_PORT", "9103")
+	upstream, activationURL, coldStart, err := activatorConfigFromEnv(os.Getenv)
+	if err != nil {
+		log.Fatalf("invalid activator configuration: %v", err)
+	}
+	a := newActivator(
+		upstream,
+		activationURL,
+		strings.TrimSpace(os.Getenv("MCP_SERVER_NAME")),
+		strings.TrimSpace(os.Getenv("MCP_SERVER_NAMESPACE")),
+		coldStart,
+		newActivatorMetrics(prometheus.DefaultRegisterer),
+	)
+	a.operatorTokenFile = strings.TrimSpace(os.Getenv("OPERATOR_TOKEN_FILE"))
+
+	metricsShutdown, metricsErrs := serviceutil.StartMetricsServer(metricsPort)
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           a,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      coldStart + 5*time.Minute,
+		IdleTimeout:       60 * time.Second,
+	}
+	log.Printf("mcp-gateway activator listening on :%s -> %s (metrics on :%s)", port, upstream, metricsPort)
+
+	serverErrs := make(chan error, 2)
+	go func() {
+		if err, ok := <-metricsErrs; ok {
+			serverErrs <- err
+		}
+	}()
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrs <- err
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-serverErrs:
+		log.Fatalf("activator failed: %v", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), coldStart+5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("activator shutdown failed: %v", err)
+		}
+		if err := metricsShutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics server shutdown failed: %v", err)
+		}
+	}
+}
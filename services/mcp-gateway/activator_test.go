@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestActivatorWaitsForWorkloadAndForwards(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("upstream:" + string(body)))
+	}))
+	defer upstream.Close()
+
+	var activations atomic.Int32
+	operator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["namespace"] != "team-a" || req["name"] != "payments" {
+			t.Errorf("unexpected activation request %v (%v)", req, err)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer activator-token" {
+			t.Errorf("Authorization = %q, want the projected operator token", got)
+		}
+		activations.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer operator.Close()
+
+	target, _ := url.Parse(upstream.URL)
+	metrics := newActivatorMetrics(prometheus.NewRegistry())
+	a := newActivator(target, operator.URL, "payments", "team-a", 5*time.Second, metrics)
+	a.pollInterval = 10 * time.Millisecond
+	a.operatorTokenFile = filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(a.operatorTokenFile, []byte("activator-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var dials atomic.Int32
+	a.dial = func(context.Context) error {
+		if dials.Add(1) < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0"}`)))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != `upstream:{"jsonrpc":"2.0"}` {
+		t.Fatalf("response = %d %q", rec.Code, rec.Body.String())
+	}
+	if got := activations.Load(); got != 1 {
+		t.Fatalf("activation requests = %d, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.requestsTotal.WithLabelValues("team-a", "payments", "proxied")); got != 1 {
+		t.Fatalf("proxied requests = %v, want 1", got)
+	}
+}
+
+func TestActivatorTimesOutWithRetryAfter(t *testing.T) {
+	operator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer operator.Close()
+
+	target, _ := url.Parse("http://payments-workload.team-a.svc:8088")
+	metrics := newActivatorMetrics(prometheus.NewRegistry())
+	a := newActivator(target, operator.URL, "payments", "team-a", 50*time.Millisecond, metrics)
+	a.pollInterval = 10 * time.Millisecond
+	a.dial = func(context.Context) error { return errors.New("connection refused") }
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{}`)))
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("response = %d (Retry-After %q), want 503 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if got := testutil.ToFloat64(metrics.requestsTotal.WithLabelValues("team-a", "payments", "timeout")); got != 1 {
+		t.Fatalf("timed out requests = %v, want 1", got)
+	}
+}
+
+func TestActivatorRejectsOversizedBody(t *testing.T) {
+	target, _ := url.Parse("http://payments-workload.team-a.svc:8088")
+	a := newActivator(target, "http://operator.invalid/activate", "payments", "team-a", time.Second, newActivatorMetrics(prometheus.NewRegistry()))
+	a.dial = func(context.Context) error {
+		t.Fatal("oversized requests must not wait for the workload")
+		return nil
+	}
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(strings.Repeat("x", maxRPCBodyBytes+1))))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestActivatorConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"UPSTREAM_URL":       "http://payments-workload.team-a.svc:8088",
+		"ACTIVATION_URL":     "http://mcp-runtime-operator-activation.mcp-runtime.svc:8082/activate",
+		"COLD_START_TIMEOUT": "90s",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	upstream, activationURL, coldStart, err := activatorConfigFromEnv(getenv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upstreamHostPort(upstream) != "payments-workload.team-a.svc:8088" || activationURL != env["ACTIVATION_URL"] || coldStart != 90*time.Second {
+		t.Fatalf("unexpected config: %v %q %v", upstream, activationURL, coldStart)
+	}
+
+	env["COLD_START_TIMEOUT"] = "soon"
+	if _, _, _, err := activatorConfigFromEnv(getenv); err == nil {
+		t.Fatal("expected an error for an invalid cold start timeout")
+	}
+	delete(env, "COLD_START_TIMEOUT")
+	delete(env, "ACTIVATION_URL")
+	if _, _, _, err := activatorConfigFromEnv(getenv); err == nil {
+		t.Fatal("expected an error without ACTIVATION_URL")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -16,6 +18,20 @@ import (
 	"mcp-runtime/pkg/serviceutil"
 )
 
+// errAnalyticsRejected marks an event the ingest service refused as invalid.
+// Sending it again cannot succeed, so the spool drops it instead of retrying.
+var errAnalyticsRejected = errors.New("analytics event rejected")
+
+const (
+	// spoolRetryMin and spoolRetryMax bound the backoff between attempts to
+	// deliver a spooled event while the ingest service is unavailable.
+	spoolRetryMin = 500 * time.Millisecond
+	spoolRetryMax = 30 * time.Second
+	// spoolDrainTimeout is how long shutdown waits for the spool sender to
+	// deliver the backlog; what is left is replayed after the restart.
+	spoolDrainTimeout = 10 * time.Second
+)
+
 func (s *gatewayServer) startAnalyticsDispatcher() {
 	if s.analyticsURL == "" {
 		return
@@ -26,6 +42,23 @@ func (s *gatewayServer) startAnalyticsDispatcher() {
 			s.analyticsMu.Unlock()
 			return
 		}
+		if s.spool != nil {
+			ctx, cancel := context.WithCancel(context.Background())
+			stop, done := make(chan struct{}), make(chan struct{})
+			s.spoolCancel, s.spoolStop, s.spoolDone = cancel, stop, done
+			s.analyticsWG.Add(2)
+			s.analyticsMu.Unlock()
+			go func() {
+				defer s.analyticsWG.Done()
+				defer close(done)
+				s.runSpoolSender(ctx, stop)
+			}()
+			go func() {
+				defer s.analyticsWG.Done()
+				s.runSpoolMaintenance(ctx)
+			}()
+			return
+		}
 		if s.analyticsQueue == nil {
 			s.analyticsQueue = make(chan analyticsEvent, analyticsQueueSize)
 		}
@@ -38,7 +71,7 @@ func (s *gatewayServer) startAnalyticsDispatcher() {
 				for event := range queue {
 					parentCtx := serviceutil.ContextWithTraceContext(context.Background(), event.TraceContext)
 					ctx, cancel := context.WithTimeout(parentCtx, time.Duration(analyticsEmitTimeout)*time.Second)
-					s.emit(ctx, event.Envelope)
+					_ = s.emit(ctx, event.Envelope)
 					cancel()
 				}
 			}()
@@ -59,8 +92,25 @@ func (s *gatewayServer) stopAnalyticsDispatcher() {
 	if queue != nil {
 		close(queue)
 	}
+	stop, done, cancel := s.spoolStop, s.spoolDone, s.spoolCancel
 	s.analyticsMu.Unlock()
+	if stop != nil {
+		// Give the sender a bounded window to drain the spool, then stop it.
+		close(stop)
+		timer := time.NewTimer(spoolDrainTimeout)
+		select {
+		case <-done:
+		case <-timer.C:
+		}
+		timer.Stop()
+		cancel()
+	}
 	s.analyticsWG.Wait()
+	if s.spool != nil {
+		if err := s.spool.close(false); err != nil {
+			log.Printf("close gateway analytics spool: %v", err)
+		}
+	}
 }
 
 func (s *gatewayServer) emitIfEnabled(ctx context.Context, event events.Envelope) {
@@ -70,14 +120,29 @@ func (s *gatewayServer) emitIfEnabled(ctx context.Context, event events.Envelope
 	s.analyticsMu.Lock()
 	if s.analyticsClosed {
 		s.analyticsMu.Unlock()
-		dropped := s.analyticsDropped.Add(1)
-		if shouldLogAnalyticsDrop(dropped) {
-			log.Printf("gateway analytics dispatcher closed; dropped event total=%d source=%q event_type=%q", dropped, event.Source, event.EventType)
-		}
+		s.recordAnalyticsDrop("closed", event)
 		return
 	}
 	s.analyticsMu.Unlock()
 
+	if s.spool != nil {
+		err := s.spool.append(analyticsEvent{
+			Envelope:     event,
+			TraceContext: serviceutil.CaptureTraceContext(ctx),
+		})
+		switch {
+		case err == nil:
+		case errors.Is(err, errSpoolFull):
+			s.recordAnalyticsDrop("spool_full", event)
+		case errors.Is(err, errSpoolClosed):
+			s.recordAnalyticsDrop("closed", event)
+		default:
+			log.Printf("gateway analytics spool write failed: %v", err)
+			s.recordAnalyticsDrop("spool_error", event)
+		}
+		return
+	}
+
 	queue := s.analyticsEventQueue()
 	if queue == nil {
 		return
@@ -92,10 +157,17 @@ func (s *gatewayServer) emitIfEnabled(ctx context.Context, event events.Envelope
 		s.analyticsMu.Unlock()
 	default:
 		s.analyticsMu.Unlock()
-		dropped := s.analyticsDropped.Add(1)
-		if shouldLogAnalyticsDrop(dropped) {
-			log.Printf("gateway analytics queue full; dropped event total=%d source=%q event_type=%q", dropped, event.Source, event.EventType)
-		}
+		s.recordAnalyticsDrop("queue_full", event)
+	}
+}
+
+// recordAnalyticsDrop counts an event the gateway gave up on and logs a
+// sample of drops.
+func (s *gatewayServer) recordAnalyticsDrop(reason string, event events.Envelope) {
+	analyticsEventsDroppedTotal.WithLabelValues(reason).Inc()
+	dropped := s.analyticsDropped.Add(1)
+	if shouldLogAnalyticsDrop(dropped) {
+		log.Printf("gateway analytics event dropped (%s); dropped event total=%d source=%q event_type=%q", reason, dropped, event.Source, event.EventType)
 	}
 }
 
@@ -125,16 +197,17 @@ func shouldLogAnalyticsDrop(dropped uint64) bool {
 	return dropped != 0 && dropped&(dropped-1) == 0
 }
 
-// emit sends analytics events to the ingest service.
-func (s *gatewayServer) emit(ctx context.Context, event events.Envelope) {
+// emit sends analytics events to the ingest service. An event the service
+// refused as invalid returns an error wrapping errAnalyticsRejected.
+func (s *gatewayServer) emit(ctx context.Context, event events.Envelope) error {
 	data, err := json.Marshal(event)
 	if err != nil {
-		return
+		return fmt.Errorf("%w: %v", errAnalyticsRejected, err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.analyticsURL, bytes.NewReader(data))
 	if err != nil {
-		return
+		return err
 	}
 	req.Header.Set("content-type", "application/json")
 	if s.apiKey != "" {
@@ -145,13 +218,153 @@ func (s *gatewayServer) emit(ctx context.Context, event events.Envelope) {
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		log.Printf("failed to emit gateway analytics event to %s: %v", s.analyticsURL, err)
-		return
+		return err
 	}
 	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		log.Printf("gateway analytics emission failed with status %d to %s", resp.StatusCode, s.analyticsURL)
-		_, _ = io.Copy(io.Discard, resp.Body)
-		return
+		switch resp.StatusCode {
+		case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+			return fmt.Errorf("%w: status %d", errAnalyticsRejected, resp.StatusCode)
+		}
+		return fmt.Errorf("ingest returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// runSpoolSender delivers spooled events in order until stop is closed and
+// the spool is drained, or ctx ends. A failed delivery is retried with
+// backoff and holds back the events behind it, so an ingest outage only
+// grows the backlog. When its own spool is empty the sender also drains
+// slots other gateways on the volume left behind.
+func (s *gatewayServer) runSpoolSender(ctx context.Context, stop <-chan struct{}) {
+	var adopted *eventSpool
+	defer func() {
+		if adopted != nil {
+			s.spoolAdopted.Store(nil)
+			_ = adopted.close(false)
+		}
+	}()
+	var lastAdopt time.Time
+	for {
+		source := s.spool
+		if adopted != nil {
+			source = adopted
+		}
+		entries, err := source.read(spoolReadBatch)
+		if err != nil {
+			log.Printf("gateway analytics spool read failed: %v", err)
+			if !waitSpoolRetry(ctx, spoolRetryMax) {
+				return
+			}
+			continue
+		}
+		if len(entries) == 0 {
+			if adopted != nil {
+				s.spoolAdopted.Store(nil)
+				if err := adopted.close(true); err != nil {
+					log.Printf("release adopted gateway analytics spool %s: %v", adopted.dir, err)
+				}
+				adopted = nil
+				continue
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if time.Since(lastAdopt) >= spoolLeaseTTL {
+				lastAdopt = time.Now()
+				if adopted = s.spool.adopt(); adopted != nil {
+					log.Printf("draining gateway analytics spool %s left by another gateway", adopted.dir)
+					s.spoolAdopted.Store(adopted)
+					continue
+				}
+			}
+			timer := time.NewTimer(spoolLeaseTTL)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-stop:
+			case <-s.spool.wake:
+			case <-timer.C:
+			}
+			timer.Stop()
+			continue
+		}
+		delivered := s.deliverSpooled(ctx, source, entries)
+		if err := source.commit(); err != nil {
+			log.Printf("gateway analytics spool cursor write failed: %v", err)
+		}
+		if !delivered {
+			return
+		}
+	}
+}
+
+// deliverSpooled sends entries in order, acknowledging each once the ingest
+// service accepted or rejected it. It returns false when ctx ended first.
+func (s *gatewayServer) deliverSpooled(ctx context.Context, spool *eventSpool, entries []spoolEntry) bool {
+	for _, entry := range entries {
+		if entry.corrupt {
+			s.recordAnalyticsDrop("corrupt", events.Envelope{})
+			spool.ack(entry)
+			continue
+		}
+		for backoff := spoolRetryMin; ; backoff = min(backoff*2, spoolRetryMax) {
+			emitCtx, cancel := context.WithTimeout(serviceutil.ContextWithTraceContext(ctx, entry.event.TraceContext), time.Duration(analyticsEmitTimeout)*time.Second)
+			err := s.emit(emitCtx, entry.event.Envelope)
+			cancel()
+			if err == nil {
+				break
+			}
+			if errors.Is(err, errAnalyticsRejected) {
+				s.recordAnalyticsDrop("rejected", entry.event.Envelope)
+				break
+			}
+			analyticsDeliveryRetriesTotal.Inc()
+			if !waitSpoolRetry(ctx, backoff) {
+				return false
+			}
+		}
+		spool.ack(entry)
+	}
+	return true
+}
+
+// runSpoolMaintenance flushes the spool under the interval fsync policy and
+// keeps the slot leases of the spool and any adopted slot fresh.
+func (s *gatewayServer) runSpoolMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(spoolSyncInterval)
+	defer ticker.Stop()
+	lastLease := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			refresh := now.Sub(lastLease) >= spoolLeaseTTL/3
+			if refresh {
+				lastLease = now
+			}
+			s.spool.maintain(refresh)
+			if adopted := s.spoolAdopted.Load(); adopted != nil {
+				adopted.maintain(refresh)
+			}
+		}
+	}
+}
+
+// waitSpoolRetry waits d, reporting false when ctx ends first.
+func waitSpoolRetry(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
-	_, _ = io.Copy(io.Discard, resp.Body)
 }
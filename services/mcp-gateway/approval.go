@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+const (
+	// approvalPollInterval is how often a held tools/call asks the operator
+	// whether its approval was decided.
+	approvalPollInterval = time.Second
+	// maxApprovalWait caps how long a tools/call is held open for a decision,
+	// below the gateway's HTTP write timeout.
+	maxApprovalWait = 4 * time.Minute
+	// maxApprovalArguments caps the arguments JSON sent for display on the
+	// MCPToolApproval; the digest always covers the full arguments as sent.
+	maxApprovalArguments = 4096
+	// rpcApprovalPendingCode is the JSON-RPC error code of a tools/call still
+	// waiting for approval. Retrying the same call picks up the decision.
+	rpcApprovalPendingCode = -32001
+)
+
+// Approval statuses returned by the operator approval endpoint.
+const (
+	approvalStatusPending  = "pending"
+	approvalStatusApproved = "approved"
+	approvalStatusDenied   = "denied"
+	approvalStatusExpired  = "expired"
+)
+
+// approvalRequest is the body POSTed to APPROVAL_URL for a tools/call that
+// requires approval. The operator returns the call's oldest unconsumed
+// MCPToolApproval, creating a pending one when there is none.
+type approvalRequest struct {
+	Namespace             string `json:"namespace"`
+	Server                string `json:"server"`
+	HumanID               string `json:"human_id,omitempty"`
+	AgentID               string `json:"agent_id,omitempty"`
+	TeamID                string `json:"team_id,omitempty"`
+	SessionID             string `json:"session_id,omitempty"`
+	Tool                  string `json:"tool"`
+	Arguments             string `json:"arguments,omitempty"`
+	ArgumentsDigest       string `json:"arguments_digest"`
+	RiskLevel             string `json:"risk_level,omitempty"`
+	MatchedGrant          string `json:"matched_grant,omitempty"`
+	MatchedGrantNamespace string `json:"matched_grant_namespace,omitempty"`
+}
+
+type approvalResponse struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	DecidedBy string `json:"decided_by,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// resolveApproval settles an approval_required tools/call. It records the
+// call with the operator and holds it for up to the policy's approval wait,
+// polling for a decision. An approved call is allowed with reason
+// approval_granted; a denied or expired one is denied. A call still pending
+// when the wait ends is denied with approval_pending, and the client retries
+// it once the approval is decided. Each decision releases a single call.
+func (s *gatewayServer) resolveApproval(ex *Exchange, decision policypkg.Decision) policypkg.Decision {
+	if !decision.RequiresApproval {
+		return decision
+	}
+	if s.approvalURL == "" {
+		return attributedDenial(decision, http.StatusServiceUnavailable, "approval_unavailable", 0)
+	}
+	request, err := s.newApprovalRequest(ex, decision)
+	if err != nil {
+		return attributedDenial(decision, http.StatusServiceUnavailable, "approval_unavailable", 0)
+	}
+
+	wait := policypkg.ApprovalWait(ex.Policy)
+	if wait > maxApprovalWait {
+		wait = maxApprovalWait
+	}
+	ctx, cancel := context.WithTimeout(ex.R.Context(), wait)
+	defer cancel()
+	approval, err := s.requestApproval(ex.R.Context(), request)
+	for err == nil && approval.Status == approvalStatusPending && pollApproval(ctx) {
+		approval, err = s.requestApproval(ex.R.Context(), request)
+	}
+	if err != nil {
+		log.Printf("approval request for %s failed: %v", request.Tool, err)
+		return attributedDenial(decision, http.StatusServiceUnavailable, "approval_unavailable", 0)
+	}
+
+	ex.Approval = &approval
+	decision.ApprovalID = approval.ID
+	decision.ApprovalDecidedBy = approval.DecidedBy
+	switch approval.Status {
+	case approvalStatusApproved:
+		decision.Allowed = true
+		decision.Status = http.StatusOK
+		decision.Reason = "approval_granted"
+		decision.RequiresApproval = false
+		return decision
+	case approvalStatusDenied:
+		return attributedDenial(decision, http.StatusForbidden, "approval_denied", 0)
+	case approvalStatusExpired:
+		return attributedDenial(decision, http.StatusForbidden, "approval_expired", 0)
+	default:
+		return attributedDenial(decision, http.StatusForbidden, "approval_pending", 0)
+	}
+}
+
+// pollApproval waits one poll interval, reporting false when ctx ends first.
+func pollApproval(ctx context.Context) bool {
+	timer := time.NewTimer(approvalPollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (s *gatewayServer) newApprovalRequest(ex *Exchange, decision policypkg.Decision) (approvalRequest, error) {
+	// The arguments are recorded as the caller sent them: the upstream
+	// receives these bytes, while the decoded arguments read numbers as
+	// float64 and would make distinct large integers look the same.
+	arguments := []byte(ex.Inspection.RawArguments)
+	if len(arguments) == 0 {
+		arguments = []byte("null")
+	}
+	digest := sha256.Sum256(arguments)
+	display := string(arguments)
+	if len(display) > maxApprovalArguments {
+		display = display[:maxApprovalArguments]
+	}
+	return approvalRequest{
+		Namespace:             policypkg.FirstNonEmpty(policypkg.PolicyServerNamespace(ex.Policy), s.serverNamespace),
+		Server:                policypkg.FirstNonEmpty(policypkg.PolicyServerName(ex.Policy), s.serverName),
+		HumanID:               ex.Identity.HumanID,
+		AgentID:               ex.Identity.AgentID,
+		TeamID:                ex.Identity.TeamID,
+		SessionID:             ex.Identity.SessionID,
+		Tool:                  ex.Inspection.ToolName,
+		Arguments:             display,
+		ArgumentsDigest:       "sha256:" + hex.EncodeToString(digest[:]),
+		RiskLevel:             decision.RiskLevel,
+		MatchedGrant:          decision.MatchedGrant,
+		MatchedGrantNamespace: decision.MatchedGrantNamespace,
+	}, nil
+}
+
+func (s *gatewayServer) requestApproval(ctx context.Context, request approvalRequest) (approvalResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return approvalResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.approvalURL, bytes.NewReader(payload))
+	if err != nil {
+		return approvalResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setOperatorToken(req, s.operatorTokenFile); err != nil {
+		return approvalResponse{}, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return approvalResponse{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return approvalResponse{}, fmt.Errorf("approval endpoint returned %s", resp.Status)
+	}
+	var approval approvalResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&approval); err != nil {
+		return approvalResponse{}, err
+	}
+	if approval.ID == "" {
+		return approvalResponse{}, fmt.Errorf("approval endpoint returned no approval id")
+	}
+	return approval, nil
+}
+
+// isApprovalReason reports whether a denial came from the approval workflow.
+// Those denials are answered as JSON-RPC errors that name the approval.
+func isApprovalReason(reason string) bool {
+	switch reason {
+	case "approval_required", "approval_pending", "approval_denied", "approval_expired":
+		return true
+	}
+	return false
+}
+
+// approvalRPCError builds the JSON-RPC error for an approval denial. A
+// pending call gets rpcApprovalPendingCode so clients can tell it apart
+// from a final denial and retry it.
+func approvalRPCError(policy *policypkg.Document, decision policypkg.Decision, approval *approvalResponse, id json.RawMessage) rpcErrorResponse {
+	response := deniedRPCError(policy, decision, id)
+	if approval == nil {
+		return response
+	}
+	data := response.Error.Data
+	data["approval_id"] = approval.ID
+	data["approval_namespace"] = approval.Namespace
+	data["approval_status"] = approval.Status
+	if approval.ExpiresAt != "" {
+		data["expires_at"] = approval.ExpiresAt
+	}
+	if approval.DecidedBy != "" {
+		data["decided_by"] = approval.DecidedBy
+	}
+	if approval.Comment != "" {
+		data["comment"] = approval.Comment
+	}
+	if approval.Status == approvalStatusPending {
+		response.Error.Code = rpcApprovalPendingCode
+		response.Error.Message = "tool call is waiting for approval"
+	}
+	return response
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+func approvalPolicy() *policypkg.Document {
+	policy := headerPolicy()
+	policy.Grants[0].ToolRules = []policypkg.ToolAccess{{Name: "echo", Decision: policypkg.DecisionRequiresApproval}}
+	return policy
+}
+
+// fakeApprovalEndpoint answers approval requests with the queued statuses in
+// order, repeating the last one, and records the requests it received.
+type fakeApprovalEndpoint struct {
+	mu       sync.Mutex
+	statuses []string
+	requests []approvalRequest
+	// authorization is the Authorization header of the last request.
+	authorization string
+}
+
+func (f *fakeApprovalEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request approvalRequest
+	_ = json.NewDecoder(r.Body).Decode(&request)
+	f.mu.Lock()
+	f.requests = append(f.requests, request)
+	f.authorization = r.Header.Get("Authorization")
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	f.mu.Unlock()
+	response := approvalResponse{ID: "payments-abc12", Namespace: "mcp-servers", Status: status, ExpiresAt: "2026-01-01T12:15:00Z"}
+	if status != approvalStatusPending {
+		response.DecidedBy = "bob"
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func TestHandleGatewayHoldsToolCallForApproval(t *testing.T) {
+	t.Parallel()
+
+	endpoint := &fakeApprovalEndpoint{statuses: []string{approvalStatusPending}}
+	approvals := httptest.NewServer(endpoint)
+	t.Cleanup(approvals.Close)
+	proxy := newTestGatewayServer(t, approvalPolicy(), okUpstream)
+	proxy.approvalURL = approvals.URL
+	proxy.operatorTokenFile = filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(proxy.operatorTokenFile, []byte("projected-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`))
+	var response batchResponseEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", recorder.Body.String(), err)
+	}
+	if response.Error == nil || response.Error.Code != rpcApprovalPendingCode {
+		t.Fatalf("response = %s, want a pending approval error", recorder.Body.String())
+	}
+	if response.Error.Data["approval_id"] != "payments-abc12" || response.Error.Data["error"] != "approval_pending" {
+		t.Fatalf("error data = %#v, want the pending approval id", response.Error.Data)
+	}
+	request := endpoint.requests[0]
+	if request.Tool != "echo" || request.HumanID != "human-1" || request.Arguments != `{"text":"hi"}` || request.ArgumentsDigest == "" || request.MatchedGrant != "grant-1" {
+		t.Fatalf("approval request = %+v", request)
+	}
+	if endpoint.authorization != "Bearer projected-token" {
+		t.Fatalf("Authorization = %q, want the projected operator token", endpoint.authorization)
+	}
+
+	endpoint.statuses = []string{approvalStatusApproved}
+	recorder = httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != `{"jsonrpc":"2.0","id":7,"result":{}}` {
+		t.Fatalf("approved call = %d %s, want the upstream result", recorder.Code, recorder.Body.String())
+	}
+	if endpoint.requests[1].ArgumentsDigest != request.ArgumentsDigest {
+		t.Fatal("the retried call was sent with a different arguments digest")
+	}
+
+	endpoint.statuses = []string{approvalStatusDenied}
+	recorder = httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	response = batchResponseEntry{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", recorder.Body.String(), err)
+	}
+	if recorder.Code != http.StatusForbidden || response.Error == nil || response.Error.Code != rpcDeniedErrorCode || response.Error.Data["decided_by"] != "bob" {
+		t.Fatalf("denied call = %d %s, want a denial naming the approver", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestApprovalRequestDigestsArgumentsAsSent(t *testing.T) {
+	t.Parallel()
+
+	endpoint := &fakeApprovalEndpoint{statuses: []string{approvalStatusPending}}
+	approvals := httptest.NewServer(endpoint)
+	t.Cleanup(approvals.Close)
+	proxy := newTestGatewayServer(t, approvalPolicy(), okUpstream)
+	proxy.approvalURL = approvals.URL
+
+	// Both ids decode to the same float64.
+	for _, id := range []string{"9007199254740993", "9007199254740992"} {
+		recorder := httptest.NewRecorder()
+		proxy.handleGateway(recorder, newBatchRequest(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo","arguments":{"account":`+id+`}}}`))
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("held call = %d %s, want pending", recorder.Code, recorder.Body.String())
+		}
+	}
+	first, second := endpoint.requests[0], endpoint.requests[1]
+	if first.Arguments != `{"account":9007199254740993}` {
+		t.Fatalf("arguments = %s, want the integer as sent", first.Arguments)
+	}
+	if first.ArgumentsDigest == second.ArgumentsDigest {
+		t.Fatal("calls with different large integer arguments share an arguments digest")
+	}
+}
+
+func TestResolveApprovalWaitsForDecision(t *testing.T) {
+	t.Parallel()
+
+	endpoint := &fakeApprovalEndpoint{statuses: []string{approvalStatusPending, approvalStatusApproved}}
+	approvals := httptest.NewServer(endpoint)
+	t.Cleanup(approvals.Close)
+	policy := approvalPolicy()
+	policy.Approval = &policypkg.Approval{Wait: "5s"}
+	proxy := newTestGatewayServer(t, policy, okUpstream)
+	proxy.approvalURL = approvals.URL
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("held call = %d %s, want it released once approved", recorder.Code, recorder.Body.String())
+	}
+	if len(endpoint.requests) != 2 {
+		t.Fatalf("approval requests = %d, want a poll after the pending answer", len(endpoint.requests))
+	}
+}
+
+func TestResolveApprovalFailsClosedWithoutEndpoint(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, approvalPolicy(), okUpstream)
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503 without an approval endpoint", recorder.Code)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// rpcDeniedErrorCode is the JSON-RPC error code returned for batch entries the
+// gateway denies. It sits in the implementation-defined server error range.
+const rpcDeniedErrorCode = -32000
+
+type batchMergerContextKey struct{}
+
+type rpcErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   rpcError        `json:"error"`
+}
+
+type rpcError struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// authorizeBatch is the authzFilter path for JSON-RPC batches. Every entry
+// gets its own decision in Exchange.BatchDecisions: governed methods go through
+// policy.Authorize exactly as a single request would, and other methods are
+// allowed like their unbatched passthrough. The exchange decision is allowed
+// when at least one entry is forwarded; when every entry is denied the
+// per-entry errors are written here and the pipeline stops.
+func (s *gatewayServer) authorizeBatch(ex *Exchange) Result {
+	version := policypkg.ChoosePolicyVersion(policypkg.PolicyVersion(ex.Policy), s.defaultPolicyVersion)
+	identity := policyIdentity(ex.Identity)
+	filterLists := policypkg.FilterListResponses(ex.Policy)
+	now := time.Now()
+
+	ex.BatchDecisions = make([]policypkg.Decision, len(ex.Inspection.Batch))
+	var firstDenied *policypkg.Decision
+	allowed := 0
+	for i, entry := range ex.Inspection.Batch {
+		var decision policypkg.Decision
+		switch {
+		case entry.Governed && ex.PolicyErr != nil:
+			decision = policypkg.Deny(http.StatusServiceUnavailable, "policy_unavailable", version)
+		case entry.Governed:
+			// A batched tool call that requires approval stays denied with
+			// approval_required: the call is only held when sent on its own.
+			decision = policypkg.Authorize(ex.Policy, policyRequest(identity, entry.Method, entry.ToolName, entry.ResourceURI, entry.Arguments), now)
+			decision = s.applySessionLimits(ex.R.Context(), ex.Policy, decision, now)
+			if entry.ToolCall {
+				decision = s.applyRateLimits(ex.R.Context(), ex.Policy, ex.Identity, entry.ToolName, decision)
+			}
+		case filterLists && policypkg.IsListMethod(entry.Method):
+			// List filtering rewrites a single list response. A batched list
+			// cannot be filtered, so it fails closed instead of leaking entries.
+			decision = policypkg.Deny(http.StatusForbidden, "list_filter_batch_unsupported", version)
+		default:
+			decision = policypkg.Allow("allowed", version)
+		}
+		ex.BatchDecisions[i] = decision
+		if decision.Allowed {
+			allowed++
+		} else if firstDenied == nil {
+			firstDenied = &ex.BatchDecisions[i]
+		}
+	}
+
+	switch {
+	case firstDenied == nil:
+		ex.Decision = policypkg.Allow("allowed", version)
+	case allowed > 0:
+		ex.Decision = policypkg.Allow("batch_partially_denied", version)
+	default:
+		ex.Decision = *firstDenied
+		s.writeBatchDeniedResponse(ex)
+		return Reject
+	}
+	return Continue
+}
+
+// batchDeniedResponses returns the JSON-RPC error responses for the denied
+// entries of the batch. Denied notifications get no response, as JSON-RPC
+// requires.
+func (s *gatewayServer) batchDeniedResponses(ex *Exchange) []rpcErrorResponse {
+	var responses []rpcErrorResponse
+	for i, entry := range ex.Inspection.Batch {
+		decision := ex.BatchDecisions[i]
+		if decision.Allowed || len(entry.ID) == 0 {
+			continue
+		}
+		responses = append(responses, deniedRPCError(ex.Policy, decision, entry.ID))
+	}
+	return responses
+}
+
+// deniedRPCError builds the JSON-RPC error response for a denied request. The
+// data carries the same fields as the plain JSON denial body plus the HTTP
+// status the denial would have had.
+func deniedRPCError(policy *policypkg.Document, decision policypkg.Decision, id json.RawMessage) rpcErrorResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	data := gatewayDeniedPayload(policy, decision)
+	data["status"] = gatewayDeniedStatus(policy, decision)
+	return rpcErrorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: rpcError{
+			Code:    rpcDeniedErrorCode,
+			Message: "request denied by gateway policy",
+			Data:    data,
+		},
+	}
+}
+
+// invalidParamsRPCError is the JSON-RPC error for a request rejected with
+// rpcInvalidParamsReason.
+func invalidParamsRPCError(id json.RawMessage) rpcErrorResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return rpcErrorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: rpcError{
+			Code:    rpcInvalidParamsCode,
+			Message: "params repeat a key or use a case variant of one the gateway authorizes on",
+		},
+	}
+}
+
+// invalidRequestRPCError is the JSON-RPC error for a request rejected with
+// rpcInvalidRequestReason. Its id is null because the request's own id cannot
+// be read unambiguously.
+func invalidRequestRPCError() rpcErrorResponse {
+	return rpcErrorResponse{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage("null"),
+		Error: rpcError{
+			Code:    rpcInvalidRequestCode,
+			Message: "request repeats a JSON-RPC member or uses a case variant of one",
+		},
+	}
+}
+
+// writeBatchDeniedResponse answers a batch in which every entry was denied.
+// The HTTP status is 200 because the denials are carried per entry; a batch
+// of only notifications is acknowledged with 202 and no body.
+func (s *gatewayServer) writeBatchDeniedResponse(ex *Exchange) {
+	responses := s.batchDeniedResponses(ex)
+	if len(responses) == 0 {
+		ex.W.WriteHeader(http.StatusAccepted)
+		return
+	}
+	ex.W.Header().Set("content-type", "application/json")
+	ex.W.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(ex.W).Encode(responses)
+}
+
+// batchUpstreamRequest returns the request to forward for a partially denied
+// batch: the body carries only the allowed entries, and a batchMerger on the
+// context adds the denied entries' errors to the upstream response. A batch
+// with nothing denied is forwarded unchanged.
+func (s *gatewayServer) batchUpstreamRequest(ex *Exchange) (*http.Request, error) {
+	forwarded := make([]json.RawMessage, 0, len(ex.Inspection.Batch))
+	for i, entry := range ex.Inspection.Batch {
+		if ex.BatchDecisions[i].Allowed {
+			forwarded = append(forwarded, entry.Raw)
+		}
+	}
+	if len(forwarded) == len(ex.Inspection.Batch) {
+		return ex.R, nil
+	}
+	body, err := json.Marshal(forwarded)
+	if err != nil {
+		return nil, err
+	}
+	merger := &batchMerger{responses: s.batchDeniedResponses(ex)}
+	out := ex.R.WithContext(context.WithValue(ex.R.Context(), batchMergerContextKey{}, merger))
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.Header.Del("Content-Length")
+	// The merger has to read JSON responses, so ask upstream for an
+	// uncompressed body.
+	out.Header.Del("Accept-Encoding")
+	return out, nil
+}
+
+// batchMerger adds the gateway's error responses for denied batch entries to
+// the upstream response for the forwarded entries.
+type batchMerger struct {
+	responses []rpcErrorResponse
+}
+
+func batchMergerFromContext(ctx context.Context) *batchMerger {
+	merger, _ := ctx.Value(batchMergerContextKey{}).(*batchMerger)
+	return merger
+}
+
+// apply rewrites resp in place. When upstream only acknowledged notifications
+// the errors become the whole response. JSON bodies are merged into a single
+// batch array; SSE streams get the errors as a leading message event.
+func (m *batchMerger) apply(resp *http.Response) error {
+	if len(m.responses) == 0 {
+		return nil
+	}
+	errorsJSON, err := json.Marshal(m.responses)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusNoContent {
+		_ = resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = http.StatusText(http.StatusOK)
+		resp.Header.Set("Content-Type", "application/json")
+		setResponseBody(resp, errorsJSON)
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	if encoding := strings.TrimSpace(resp.Header.Get("Content-Encoding")); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return errResponseEncoding
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		prefix := []byte("event: message\ndata: " + string(errorsJSON) + "\n\n")
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		return nil
+	case "application/json":
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRewrittenResponseBytes+1))
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if len(body) > maxRewrittenResponseBytes {
+			return errResponseTooLarge
+		}
+		merged, err := mergeBatchResponse(body, m.responses)
+		if err != nil {
+			return err
+		}
+		setResponseBody(resp, merged)
+		return nil
+	default:
+		return nil
+	}
+}
+
+// mergeBatchResponse appends the denial errors to an upstream batch response.
+// A single response object is accepted too and becomes the first element.
+func mergeBatchResponse(body []byte, denied []rpcErrorResponse) ([]byte, error) {
+	var entries []json.RawMessage
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, err
+		}
+	} else if len(trimmed) > 0 {
+		var single json.RawMessage
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return nil, err
+		}
+		entries = append(entries, single)
+	}
+	for _, response := range denied {
+		raw, err := json.Marshal(response)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, raw)
+	}
+	return json.Marshal(entries)
+}
+
+func setResponseBody(resp *http.Response, body []byte) {
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+}
+
+// emitBatchAudit emits one audit event per batch entry with that entry's
+// method, tool, and decision. Entries that never reached authorization (for
+// example when authentication failed) carry the exchange decision.
+func (s *gatewayServer) emitBatchAudit(ex *Exchange) {
+	latencyMs := time.Since(ex.StartTime).Milliseconds()
+	for i, entry := range ex.Inspection.Batch {
+		decision := ex.Decision
+		if i < len(ex.BatchDecisions) {
+			decision = ex.BatchDecisions[i]
+		}
+		extra := withResourceURI(map[string]any{"batch_index": i, "batch_size": len(ex.Inspection.Batch)}, entry.ResourceURI)
+		s.emitAuditEvent(
+			ex.R, ex.OriginalPath, entry.Method, entry.ToolName,
+			ex.Identity, ex.Policy, decision,
+			ex.W.status, latencyMs, ex.W.bytes,
+			ex.withResponseOutcome(extra, entry.ID, entry.Method, decision),
+		)
+	}
+}
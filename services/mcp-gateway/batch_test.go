@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type batchResponseEntry struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int            `json:"code"`
+		Data map[string]any `json:"data"`
+	} `json:"error"`
+}
+
+func newBatchRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "http://gateway.example.local/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(defaultHumanHeader, "human-1")
+	req.Header.Set(defaultAgentHeader, "client-1")
+	req.Header.Set(defaultTeamHeader, "team-acme")
+	req.Header.Set(defaultSessionHeader, "session-1")
+	req.ContentLength = int64(len(body))
+	return req
+}
+
+// echoBatchUpstream answers every request entry of a batch with a result that
+// carries the entry's id, and records the batch it received.
+func echoBatchUpstream(received *atomic.Value) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received.Store(string(body))
+		var entries []struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.Unmarshal(body, &entries)
+		responses := make([]map[string]any, 0, len(entries))
+		for _, entry := range entries {
+			if len(entry.ID) == 0 {
+				continue
+			}
+			responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": entry.ID, "result": map[string]any{}})
+		}
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(responses)
+	}
+}
+
+func decodeBatchResponse(t *testing.T, body []byte) map[string]batchResponseEntry {
+	t.Helper()
+
+	var entries []batchResponseEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", body, err)
+	}
+	byID := make(map[string]batchResponseEntry, len(entries))
+	for _, entry := range entries {
+		byID[string(entry.ID)] = entry
+	}
+	return byID
+}
+
+func TestInspectRPCRequestParsesBatch(t *testing.T) {
+	t.Parallel()
+
+	payload := `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}},{"jsonrpc":"2.0","method":"notifications/initialized"}]`
+	inspection := inspectRPCRequest(newBatchRequest(payload))
+
+	if inspection.Indeterminate || inspection.Method != rpcBatchMethod || !inspection.ToolCall {
+		t.Fatalf("inspection = %#v, want determinate batch with a tool call", inspection)
+	}
+	if len(inspection.Batch) != 2 {
+		t.Fatalf("len(Batch) = %d, want 2", len(inspection.Batch))
+	}
+	if entry := inspection.Batch[0]; entry.Method != "tools/call" || entry.ToolName != "echo" || string(entry.ID) != "1" {
+		t.Fatalf("Batch[0] = %#v, want tools/call echo with id 1", entry)
+	}
+	if entry := inspection.Batch[1]; entry.ToolCall || len(entry.ID) != 0 {
+		t.Fatalf("Batch[1] = %#v, want notification", entry)
+	}
+}
+
+func TestInspectRPCRequestRejectsMalformedBatch(t *testing.T) {
+	t.Parallel()
+
+	for name, payload := range map[string]string{
+		"empty":          `[]`,
+		"missing method": `[{"jsonrpc":"2.0","id":1,"method":"tools/call"},{"jsonrpc":"2.0","id":2,"result":{}}]`,
+		"not objects":    `[1,2]`,
+	} {
+		inspection := inspectRPCRequest(newBatchRequest(payload))
+		if !inspection.Indeterminate || inspection.FailureReason != "rpc_inspection_failed" {
+			t.Fatalf("%s: inspection = %#v, want rpc_inspection_failed", name, inspection)
+		}
+	}
+}
+
+func TestHandleGatewayBatchForwardsAllowedEntriesAndAnswersDenied(t *testing.T) {
+	t.Parallel()
+
+	var received atomic.Value
+	proxy := newTestGatewayServer(t, headerPolicy(), echoBatchUpstream(&received))
+
+	payload := `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}},` +
+		`{"jsonrpc":"2.0","id":"two","method":"tools/call","params":{"name":"delete_all"}},` +
+		`{"jsonrpc":"2.0","id":3,"method":"tools/list"}]`
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(payload))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	forwarded, _ := received.Load().(string)
+	if strings.Contains(forwarded, "delete_all") || !strings.Contains(forwarded, `"id":1`) || !strings.Contains(forwarded, `"id":3`) {
+		t.Fatalf("upstream received %s, want only the allowed entries", forwarded)
+	}
+
+	responses := decodeBatchResponse(t, recorder.Body.Bytes())
+	if len(responses) != 3 {
+		t.Fatalf("responses = %s, want 3 entries", recorder.Body.String())
+	}
+	if responses["1"].Error != nil || responses["3"].Error != nil {
+		t.Fatalf("responses = %s, want results for ids 1 and 3", recorder.Body.String())
+	}
+	denied := responses[`"two"`]
+	if denied.Error == nil || denied.Error.Code != rpcDeniedErrorCode {
+		t.Fatalf("response for id two = %#v, want denial error", denied)
+	}
+	if reason, _ := denied.Error.Data["error"].(string); reason == "" {
+		t.Fatalf("denial data = %#v, want reason", denied.Error.Data)
+	}
+}
+
+func TestHandleGatewayBatchAllDeniedSkipsUpstream(t *testing.T) {
+	t.Parallel()
+
+	var upstreamCalled atomic.Bool
+	proxy := newTestGatewayServer(t, headerPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		upstreamCalled.Store(true)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	payload := `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"delete_all"}},` +
+		`{"jsonrpc":"2.0","method":"tools/call","params":{"name":"delete_all"}}]`
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(payload))
+
+	if upstreamCalled.Load() {
+		t.Fatal("upstream called for a fully denied batch")
+	}
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	responses := decodeBatchResponse(t, recorder.Body.Bytes())
+	if len(responses) != 1 || responses["1"].Error == nil {
+		t.Fatalf("responses = %s, want a single error for id 1 and none for the notification", recorder.Body.String())
+	}
+}
+
+func TestHandleGatewayBatchMergesErrorsIntoNotificationAck(t *testing.T) {
+	t.Parallel()
+
+	var received atomic.Value
+	proxy := newTestGatewayServer(t, headerPolicy(), echoBatchUpstream(&received))
+
+	payload := `[{"jsonrpc":"2.0","method":"notifications/initialized"},` +
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"delete_all"}}]`
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(payload))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	responses := decodeBatchResponse(t, recorder.Body.Bytes())
+	if len(responses) != 1 || responses["2"].Error == nil {
+		t.Fatalf("responses = %s, want the error for id 2", recorder.Body.String())
+	}
+}
+
+func TestHandleGatewayBatchAuditsEachEntry(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var payloads []map[string]any
+	ingest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			Payload map[string]any `json:"payload"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		payloads = append(payloads, event.Payload)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ingest.Close)
+
+	var received atomic.Value
+	proxy := newTestGatewayServer(t, headerPolicy(), echoBatchUpstream(&received))
+	proxy.httpClient = ingest.Client()
+	proxy.analyticsURL = ingest.URL
+	proxy.startAnalyticsDispatcher()
+
+	payload := `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}},` +
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"delete_all"}}]`
+	proxy.handleGateway(httptest.NewRecorder(), newBatchRequest(payload))
+	proxy.stopAnalyticsDispatcher()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(payloads) != 2 {
+		t.Fatalf("audit events = %d, want one per batch entry", len(payloads))
+	}
+	decisions := map[string]string{}
+	for _, payload := range payloads {
+		tool, _ := payload["tool_name"].(string)
+		decision, _ := payload["decision"].(string)
+		decisions[tool] = decision
+		if payload["batch_size"] != float64(2) {
+			t.Fatalf("batch_size = %v, want 2", payload["batch_size"])
+		}
+	}
+	if decisions["echo"] != "allow" || decisions["delete_all"] != "deny" {
+		t.Fatalf("per-entry decisions = %v, want echo allowed and delete_all denied", decisions)
+	}
+}
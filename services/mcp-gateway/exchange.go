@@ -10,7 +10,7 @@
 //	Stage 2 – PolicyFilter:    atomic policy snapshot acquisition; OAuth metadata early-exit
 //	Stage 3 – AuthFilter:      authentication and identity extraction (header or OAuth JWT)
 //	Stage 4 – AuthzFilter:     authorization and session/grant evaluation
-//	Stage 5 – UpstreamFilter:  identity header rewrite; path rewrite; upstream proxy
+//	Stage 5 – UpstreamFilter:  identity header rewrite; path rewrite; upstream proxy; list filtering
 //	Stage 6 – (orchestrator):  audit/analytics finalization
 //
 // Ordering guarantees:
@@ -84,6 +84,15 @@ type Exchange struct {
 
 	// Set by stage 4 (AuthzFilter). Policy and Identity must not change after this.
 	Decision policypkg.Decision
+	// BatchDecisions holds one decision per Inspection.Batch entry, in order.
+	// It is only set for JSON-RPC batches; Decision then summarizes the batch.
+	BatchDecisions []policypkg.Decision
+	// ListFilter is set by AuthzFilter for list methods when the policy opts in
+	// to per-caller list filtering; UpstreamFilter applies it to the response.
+	ListFilter *listFilter
+	// Approval is the MCPToolApproval AuthzFilter resolved an approval_required
+	// tool call against; writeDeniedResponse reports it to the caller.
+	Approval *approvalResponse
 
 	// SkipAudit is set by a filter that writes a terminal response that bypasses
 	// authentication and authorization entirely (e.g. OAuth metadata early-exit in
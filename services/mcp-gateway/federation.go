@@ -0,0 +1,852 @@
+package main
+
+// federation.go implements the gateway's federation mode
+// (MCP_GATEWAY_MODE=federation). The operator runs one federation gateway per
+// MCPFederation and mounts the rendered policy of each member server. The
+// federation serves the members behind one MCP endpoint: initialize opens a
+// session with every member, list results are merged with each entry's name
+// prefixed by its member, and calls are routed to the member the prefix
+// names. Entries and calls are authorized with the member's policy before
+// they are listed or forwarded; the member's own gateway still enforces its
+// policy, session and rate limits, and audits every forwarded call.
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	policypkg "mcp-runtime/pkg/policy"
+	"mcp-runtime/pkg/serviceutil"
+)
+
+const (
+	// federationNameSeparator joins a member prefix and an entry name. The
+	// operator uses server names as prefixes, which cannot contain it.
+	federationNameSeparator        = "__"
+	federationPolicyReloadInterval = 5 * time.Second
+	// federationMemberTimeout bounds the initialize and list requests the
+	// federation fans out to members. Routed calls use the caller's context.
+	federationMemberTimeout = 30 * time.Second
+	// maxFederationListPages bounds how many pages of one member's list are
+	// followed through nextCursor.
+	maxFederationListPages = 20
+	// federationResourceRouteTTL is how long a listed resource stays routable
+	// before the session's resources are listed again.
+	federationResourceRouteTTL = 10 * time.Minute
+	// maxFederationResourceRoutes bounds the remembered resource routes; once
+	// full, arbitrary routes are dropped and listed again on their next use.
+	maxFederationResourceRoutes = 10000
+	mcpSessionHeader            = "Mcp-Session-Id"
+
+	rpcParseErrorCode     = -32700
+	rpcInvalidRequestCode = -32600
+	rpcMethodNotFoundCode = -32601
+	rpcInvalidParamsCode  = -32602
+	rpcInternalErrorCode  = -32603
+)
+
+// federationMember is one entry of FEDERATION_MEMBERS.
+type federationMember struct {
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	URL        string `json:"url"`
+	PolicyFile string `json:"policyFile"`
+
+	// policy is the last valid policy loaded from PolicyFile; nil until one
+	// loads, which hides the member and denies its calls.
+	policy atomic.Pointer[policypkg.Document]
+	// policyErr is the last load error, logged when it changes. Only the
+	// reload loop touches it.
+	policyErr string
+}
+
+type federationMetrics struct {
+	requestsTotal *prometheus.CounterVec
+}
+
+func newFederationMetrics(registerer prometheus.Registerer) *federationMetrics {
+	m := &federationMetrics{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_federation_member_requests_total",
+			Help: "Total requests federation gateways handled for a member, by RPC method and result.",
+		}, []string{"namespace", "federation", "member", "rpc_method", "result"}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(m.requestsTotal)
+	}
+	return m
+}
+
+// federation serves the federated MCP endpoint.
+type federation struct {
+	name      string
+	namespace string
+	members   []*federationMember
+	byPrefix  map[string]*federationMember
+	// auth authenticates callers with a member's policy exactly as the
+	// member's gateway does. Only its identity and OAuth helpers are used.
+	auth    *gatewayServer
+	client  *http.Client
+	metrics *federationMetrics
+
+	mu sync.Mutex
+	// resourceRoutes remembers, until the time it maps to, that a member
+	// listed a resource URI in one of its upstream sessions, so resources/read
+	// can be routed. Routes are per member session: callers and sessions
+	// never see each other's listings.
+	resourceRoutes map[resourceRoute]time.Time
+	// routesSweptAt is when expired routes were last dropped.
+	routesSweptAt time.Time
+}
+
+// resourceRoute is a resource URI listed by a member in one upstream session.
+type resourceRoute struct {
+	member    string
+	sessionID string
+	uri       string
+}
+
+// federationSession holds each member's upstream session ID, keyed by member
+// name; members that did not initialize are absent. It travels as the
+// federation's own Mcp-Session-Id so every replica can serve the session.
+// Tampering with it only reaches member sessions the caller could address
+// directly, and every request is still authenticated per member.
+type federationSession map[string]string
+
+func (s federationSession) encode() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeFederationSession(value string) (federationSession, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, false
+	}
+	session := federationSession{}
+	if err := json.Unmarshal(data, &session); err != nil || len(session) == 0 {
+		return nil, false
+	}
+	return session, true
+}
+
+func newFederation(name, namespace string, members []*federationMember, auth *gatewayServer, metrics *federationMetrics) (*federation, error) {
+	f := &federation{
+		name:           name,
+		namespace:      namespace,
+		members:        members,
+		byPrefix:       make(map[string]*federationMember, len(members)),
+		auth:           auth,
+		client:         &http.Client{},
+		metrics:        metrics,
+		resourceRoutes: map[resourceRoute]time.Time{},
+	}
+	for _, member := range members {
+		if member.Name == "" || member.Prefix == "" || strings.Contains(member.Prefix, federationNameSeparator) {
+			return nil, fmt.Errorf("member %q needs a name and a prefix without %q", member.Name, federationNameSeparator)
+		}
+		if target, err := url.Parse(member.URL); err != nil || target.Host == "" {
+			return nil, fmt.Errorf("member %s has invalid url %q", member.Name, member.URL)
+		}
+		if _, dup := f.byPrefix[member.Prefix]; dup {
+			return nil, fmt.Errorf("prefix %q is used by more than one member", member.Prefix)
+		}
+		f.byPrefix[member.Prefix] = member
+	}
+	return f, nil
+}
+
+// reloadPolicies loads every member's policy file. As in the gateway's policy
+// cache, a failed load keeps the member's last valid policy.
+func (f *federation) reloadPolicies() {
+	for _, member := range f.members {
+		doc, err := f.loadMemberPolicy(member)
+		if err != nil {
+			if err.Error() != member.policyErr {
+				log.Printf("federation policy for member %s not loaded: %v", member.Name, err)
+				member.policyErr = err.Error()
+			}
+			continue
+		}
+		member.policyErr = ""
+		member.policy.Store(doc)
+	}
+}
+
+func (f *federation) loadMemberPolicy(member *federationMember) (*policypkg.Document, error) {
+	data, err := os.ReadFile(member.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("policy is not rendered yet")
+	}
+	doc := &policypkg.Document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if err := policypkg.Validate(doc); err != nil {
+		return nil, err
+	}
+	f.auth.applyPolicyDefaults(doc)
+	return doc, nil
+}
+
+// authenticate returns the member's policy and the caller's identity under
+// it. The decision is a denial when the member's gateway would reject the
+// caller before authorization.
+func (f *federation) authenticate(r *http.Request, member *federationMember) (*policypkg.Document, policypkg.Identity, policypkg.Decision) {
+	policy := member.policy.Load()
+	if policy == nil {
+		return nil, policypkg.Identity{}, policypkg.Deny(http.StatusServiceUnavailable, "policy_unavailable", f.auth.defaultPolicyVersion)
+	}
+	version := policypkg.ChoosePolicyVersion(policypkg.PolicyVersion(policy), f.auth.defaultPolicyVersion)
+	if policy.Auth != nil && strings.EqualFold(policy.Auth.Mode, "mtls") {
+		// The member's gateway only accepts the ingress in mtls mode.
+		return policy, policypkg.Identity{}, policypkg.Deny(http.StatusForbidden, "mtls_not_federated", version)
+	}
+	result := f.auth.authenticateOAuth(r, policy)
+	if !result.Allowed {
+		return policy, policypkg.Identity{}, policypkg.Deny(result.Status, result.Reason, version)
+	}
+	return policy, policyIdentity(result.Identity), policypkg.Allow("allowed", version)
+}
+
+func (f *federation) record(member *federationMember, method, result string) {
+	f.metrics.requestsTotal.WithLabelValues(f.namespace, f.name, member.Name, metricRPCMethod(method), result).Inc()
+}
+
+func (f *federation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		f.endSession(w, r)
+		return
+	default:
+		// Members' server-initiated streams are not federated.
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRPCBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		writeRPCError(w, nil, rpcInvalidRequestCode, "the federation endpoint does not accept JSON-RPC batches")
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeRPCError(w, nil, rpcParseErrorCode, "parse error")
+		return
+	}
+	if len(req.ID) == 0 || req.Method == "" {
+		// Notifications and client responses are acknowledged without
+		// forwarding; members got notifications/initialized at initialize.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if req.Method == "initialize" {
+		f.initialize(w, r, req)
+		return
+	}
+
+	rawSession := r.Header.Get(mcpSessionHeader)
+	session, ok := decodeFederationSession(rawSession)
+	if !ok {
+		if strings.TrimSpace(rawSession) == "" {
+			http.Error(w, "missing "+mcpSessionHeader, http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	switch req.Method {
+	case "ping":
+		writeRPCResult(w, req.ID, map[string]any{})
+	case "tools/list":
+		f.list(w, r, req, session, "tools")
+	case "prompts/list":
+		f.list(w, r, req, session, "prompts")
+	case "resources/list":
+		f.list(w, r, req, session, "resources")
+	case "tools/call", "prompts/get":
+		f.callByName(w, r, req, session)
+	case "resources/read", "resources/subscribe", "resources/unsubscribe":
+		f.callByURI(w, r, req, session)
+	default:
+		writeRPCError(w, req.ID, rpcMethodNotFoundCode, fmt.Sprintf("method %s is not supported by the federation endpoint", req.Method))
+	}
+}
+
+// initialize opens a session with every member and answers with the union of
+// their capabilities. Members that fail are left out of the session.
+func (f *federation) initialize(w http.ResponseWriter, r *http.Request, req rpcRequest) {
+	type memberInit struct {
+		sessionID       string
+		protocolVersion string
+		capabilities    map[string]json.RawMessage
+	}
+	inits := make([]*memberInit, len(f.members))
+	var wg sync.WaitGroup
+	for i, member := range f.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), federationMemberTimeout)
+			defer cancel()
+			result, header, err := f.call(ctx, r, member, "", "initialize", req.Params)
+			if err != nil {
+				log.Printf("federation member %s initialize failed: %v", member.Name, err)
+				f.record(member, "initialize", "error")
+				return
+			}
+			var decoded struct {
+				ProtocolVersion string                     `json:"protocolVersion"`
+				Capabilities    map[string]json.RawMessage `json:"capabilities"`
+			}
+			_ = json.Unmarshal(result, &decoded)
+			sessionID := header.Get(mcpSessionHeader)
+			if resp, err := f.send(ctx, r, http.MethodPost, member, sessionID, []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)); err == nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+			f.record(member, "initialize", "ok")
+			inits[i] = &memberInit{sessionID: sessionID, protocolVersion: decoded.ProtocolVersion, capabilities: decoded.Capabilities}
+		}()
+	}
+	wg.Wait()
+
+	session := federationSession{}
+	protocolVersion := ""
+	capabilities := map[string]any{}
+	for i, init := range inits {
+		if init == nil {
+			continue
+		}
+		session[f.members[i].Name] = init.sessionID
+		if protocolVersion == "" {
+			protocolVersion = init.protocolVersion
+		}
+		for _, capability := range []string{"tools", "prompts", "resources"} {
+			if _, ok := init.capabilities[capability]; ok {
+				capabilities[capability] = map[string]any{}
+			}
+		}
+	}
+	if len(session) == 0 {
+		writeRPCError(w, req.ID, rpcInternalErrorCode, "no federation member completed initialize")
+		return
+	}
+	sessionID, err := session.encode()
+	if err != nil {
+		writeRPCError(w, req.ID, rpcInternalErrorCode, "failed to open session")
+		return
+	}
+	w.Header().Set(mcpSessionHeader, sessionID)
+	writeRPCResult(w, req.ID, map[string]any{
+		"protocolVersion": protocolVersion,
+		"capabilities":    capabilities,
+		"serverInfo":      map[string]any{"name": f.namespace + "/" + f.name, "version": "federation"},
+	})
+}
+
+// list merges one list method over the session's members in member order.
+// An entry is kept only when the member's policy would allow using it,
+// whatever the member's list filtering setting, and its name is prefixed
+// with the member's prefix. Members that fail are left out.
+func (f *federation) list(w http.ResponseWriter, r *http.Request, req rpcRequest, session federationSession, resultKey string) {
+	lists := make([][]json.RawMessage, len(f.members))
+	var wg sync.WaitGroup
+	for i, member := range f.members {
+		sessionID, joined := session[member.Name]
+		if !joined {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entries, err := f.listMember(r, member, sessionID, req.Method, resultKey)
+			if err != nil {
+				log.Printf("federation member %s %s failed: %v", member.Name, req.Method, err)
+				f.record(member, req.Method, "error")
+				return
+			}
+			f.record(member, req.Method, "ok")
+			lists[i] = entries
+		}()
+	}
+	wg.Wait()
+
+	merged := []json.RawMessage{}
+	for _, entries := range lists {
+		merged = append(merged, entries...)
+	}
+	writeRPCResult(w, req.ID, map[string]any{resultKey: merged})
+}
+
+// listMember fetches every page of one member's list and returns the entries
+// the caller may use, renamed with the member's prefix.
+func (f *federation) listMember(r *http.Request, member *federationMember, sessionID, method, resultKey string) ([]json.RawMessage, error) {
+	policy, identity, decision := f.authenticate(r, member)
+	if !decision.Allowed {
+		// A caller the member would reject sees none of its entries.
+		return nil, nil
+	}
+	now := time.Now()
+	visible := func(entry listEntry) bool {
+		var request policypkg.Request
+		switch method {
+		case "tools/list":
+			request = policyRequest(identity, "tools/call", entry.Name, "", nil)
+		case "prompts/list":
+			request = policyRequest(identity, "prompts/get", entry.Name, "", nil)
+		default:
+			request = policyRequest(identity, "resources/read", "", entry.URI, nil)
+		}
+		decision := policypkg.Authorize(policy, request, now)
+		// As in list filtering, a tool whose rule only constrains arguments
+		// or requires approval stays listed.
+		return decision.Allowed || decision.RequiresApproval || decision.Reason == "argument_constraint_violated"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), federationMemberTimeout)
+	defer cancel()
+	var kept []json.RawMessage
+	cursor := ""
+	for page := 0; page < maxFederationListPages; page++ {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		rawParams, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		result, _, err := f.call(ctx, r, member, sessionID, method, rawParams)
+		if err != nil {
+			return nil, err
+		}
+		var decoded map[string]json.RawMessage
+		if err := json.Unmarshal(result, &decoded); err != nil {
+			return nil, err
+		}
+		var entries []map[string]json.RawMessage
+		if raw, ok := decoded[resultKey]; ok {
+			if err := json.Unmarshal(raw, &entries); err != nil {
+				return nil, err
+			}
+		}
+		for _, entry := range entries {
+			var name, uri string
+			_ = json.Unmarshal(entry["name"], &name)
+			_ = json.Unmarshal(entry["uri"], &uri)
+			if !visible(listEntry{Name: name, URI: uri}) {
+				continue
+			}
+			prefixed, err := json.Marshal(member.Prefix + federationNameSeparator + name)
+			if err != nil {
+				return nil, err
+			}
+			entry["name"] = prefixed
+			raw, err := json.Marshal(entry)
+			if err != nil {
+				return nil, err
+			}
+			kept = append(kept, raw)
+			if uri != "" && resultKey == "resources" {
+				f.rememberResource(resourceRoute{member: member.Name, sessionID: sessionID, uri: uri}, now)
+			}
+		}
+		cursor = ""
+		if raw, ok := decoded["nextCursor"]; ok {
+			_ = json.Unmarshal(raw, &cursor)
+		}
+		if cursor == "" {
+			break
+		}
+	}
+	return kept, nil
+}
+
+// callByName routes tools/call and prompts/get by the prefix of params.name.
+func (f *federation) callByName(w http.ResponseWriter, r *http.Request, req rpcRequest, session federationSession) {
+	params := map[string]json.RawMessage{}
+	if len(req.Params) > 0 && json.Unmarshal(req.Params, &params) != nil {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, "params must be an object")
+		return
+	}
+	parsed, err := parseRPCParams(req.Params)
+	if err != nil {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, err.Error())
+		return
+	}
+	prefix, name, found := strings.Cut(parsed.Name, federationNameSeparator)
+	member := f.byPrefix[prefix]
+	if !found || member == nil || name == "" {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, fmt.Sprintf("unknown federated name %q", parsed.Name))
+		return
+	}
+	sessionID, joined := session[member.Name]
+	if !joined {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, fmt.Sprintf("member %s is not part of this session; initialize again", member.Name))
+		return
+	}
+	if !f.authorize(w, r, req, member, name, "", parsed.Arguments) {
+		return
+	}
+	rawName, err := json.Marshal(name)
+	if err != nil {
+		writeRPCError(w, req.ID, rpcInternalErrorCode, "failed to rewrite params")
+		return
+	}
+	params["name"] = rawName
+	f.forward(w, r, req, member, sessionID, params)
+}
+
+// callByURI routes resources/read, subscribe, and unsubscribe to the first
+// member, in member order, that listed the URI in this session, listing the
+// session's resources again when no route is known.
+func (f *federation) callByURI(w http.ResponseWriter, r *http.Request, req rpcRequest, session federationSession) {
+	params := map[string]json.RawMessage{}
+	if len(req.Params) > 0 && json.Unmarshal(req.Params, &params) != nil {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, "params must be an object")
+		return
+	}
+	parsed, err := parseRPCParams(req.Params)
+	if err != nil {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, err.Error())
+		return
+	}
+	uri := parsed.URI
+	member := f.resourceOwner(session, uri, time.Now())
+	if member == nil {
+		for _, candidate := range f.members {
+			if sessionID, joined := session[candidate.Name]; joined {
+				_, _ = f.listMember(r, candidate, sessionID, "resources/list", "resources")
+			}
+		}
+		member = f.resourceOwner(session, uri, time.Now())
+	}
+	if member == nil {
+		writeRPCError(w, req.ID, rpcInvalidParamsCode, fmt.Sprintf("unknown federated resource %q", uri))
+		return
+	}
+	sessionID := session[member.Name]
+	if !f.authorize(w, r, req, member, "", uri, nil) {
+		return
+	}
+	f.forward(w, r, req, member, sessionID, params)
+}
+
+// resourceOwner returns the first member of the session, in member order,
+// with an unexpired route for uri.
+func (f *federation) resourceOwner(session federationSession, uri string, now time.Time) *federationMember {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, member := range f.members {
+		sessionID, joined := session[member.Name]
+		if !joined {
+			continue
+		}
+		if expiresAt, ok := f.resourceRoutes[resourceRoute{member: member.Name, sessionID: sessionID, uri: uri}]; ok && now.Before(expiresAt) {
+			return member
+		}
+	}
+	return nil
+}
+
+// rememberResource records a listed resource for federationResourceRouteTTL.
+// Expired routes are dropped at most once a minute, or whenever the routes
+// are full.
+func (f *federation) rememberResource(route resourceRoute, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, known := f.resourceRoutes[route]
+	if (!known && len(f.resourceRoutes) >= maxFederationResourceRoutes) || now.Sub(f.routesSweptAt) >= time.Minute {
+		for existing, expiresAt := range f.resourceRoutes {
+			if !now.Before(expiresAt) {
+				delete(f.resourceRoutes, existing)
+			}
+		}
+		f.routesSweptAt = now
+		for existing := range f.resourceRoutes {
+			if len(f.resourceRoutes) < maxFederationResourceRoutes {
+				break
+			}
+			delete(f.resourceRoutes, existing)
+		}
+	}
+	f.resourceRoutes[route] = now.Add(federationResourceRouteTTL)
+}
+
+// authorize decides a routed request with the member's policy and answers a
+// denial with a JSON-RPC error naming the member.
+func (f *federation) authorize(w http.ResponseWriter, r *http.Request, req rpcRequest, member *federationMember, name, uri string, arguments map[string]any) bool {
+	policy, identity, decision := f.authenticate(r, member)
+	if decision.Allowed {
+		decision = policypkg.Authorize(policy, policyRequest(identity, req.Method, name, uri, arguments), time.Now())
+	}
+	// A call that requires approval is forwarded: the member's gateway holds
+	// it and resolves the approval.
+	if decision.Allowed || decision.RequiresApproval {
+		return true
+	}
+	f.record(member, req.Method, "denied")
+	denied := deniedRPCError(policy, decision, req.ID)
+	denied.Error.Data["member"] = member.Name
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(denied)
+	return false
+}
+
+// forward sends the request with params to the member and streams the
+// member's response back unchanged; the caller's request ID is kept, so
+// responses need no rewriting.
+func (f *federation) forward(w http.ResponseWriter, r *http.Request, req rpcRequest, member *federationMember, sessionID string, params map[string]json.RawMessage) {
+	payload, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": req.ID, "method": req.Method, "params": params})
+	if err != nil {
+		writeRPCError(w, req.ID, rpcInternalErrorCode, "failed to encode request")
+		return
+	}
+	resp, err := f.send(r.Context(), r, http.MethodPost, member, sessionID, payload)
+	if err != nil {
+		log.Printf("federation member %s %s failed: %v", member.Name, req.Method, err)
+		f.record(member, req.Method, "error")
+		writeRPCError(w, req.ID, rpcInternalErrorCode, fmt.Sprintf("member %s is unreachable", member.Name))
+		return
+	}
+	defer resp.Body.Close()
+	f.record(member, req.Method, "forwarded")
+	for _, header := range []string{"Content-Type", "Retry-After", "WWW-Authenticate"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// endSession deletes the member sessions of a federated session.
+func (f *federation) endSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := decodeFederationSession(r.Header.Get(mcpSessionHeader))
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	for _, member := range f.members {
+		if sessionID := session[member.Name]; sessionID != "" {
+			if resp, err := f.send(ctx, r, http.MethodDelete, member, sessionID, nil); err == nil {
+				_ = resp.Body.Close()
+			}
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// call posts one JSON-RPC request to a member and returns its result.
+func (f *federation) call(ctx context.Context, r *http.Request, member *federationMember, sessionID, method string, params json.RawMessage) (json.RawMessage, http.Header, error) {
+	message := map[string]any{"jsonrpc": "2.0", "id": 1, "method": method}
+	if len(params) > 0 {
+		message["params"] = params
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := f.send(ctx, r, http.MethodPost, member, sessionID, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("member returned %s", resp.Status)
+	}
+	decoded, err := decodeMCPProbeResponse(resp, maxRewrittenResponseBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if decoded.Error != nil {
+		return nil, nil, fmt.Errorf("JSON-RPC error %d: %s", decoded.Error.Code, decoded.Error.Message)
+	}
+	return decoded.Result, resp.Header, nil
+}
+
+// send makes a request to a member with the caller's headers, so the member's
+// gateway authenticates the same identity or token.
+func (f *federation) send(ctx context.Context, r *http.Request, method string, member *federationMember, sessionID string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, member.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = r.Header.Clone()
+	for _, header := range []string{"Content-Length", "Accept-Encoding", "Connection", "Keep-Alive", "Proxy-Connection", "Te", "Trailer", "Transfer-Encoding", "Upgrade", mcpSessionHeader} {
+		req.Header.Del(header)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set(mcpSessionHeader, sessionID)
+	}
+	return f.client.Do(req)
+}
+
+func writeRPCResult(w http.ResponseWriter, id json.RawMessage, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+}
+
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rpcErrorResponse{JSONRPC: "2.0", ID: id, Error: rpcError{Code: code, Message: message}})
+}
+
+// federationMembersFromEnv reads FEDERATION_MEMBERS, which the operator sets
+// on the federation Deployment.
+func federationMembersFromEnv(getenv func(string) string) ([]*federationMember, error) {
+	var members []*federationMember
+	raw := strings.TrimSpace(getenv("FEDERATION_MEMBERS"))
+	if raw == "" {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &members); err != nil {
+		return nil, fmt.Errorf("invalid FEDERATION_MEMBERS: %w", err)
+	}
+	return members, nil
+}
+
+func runFederation() {
+	port := serviceutil.EnvOr("PORT", "8091")
+	metricsPort := serviceutil.EnvOr("METRICS_PORT", "9103")
+	members, err := federationMembersFromEnv(os.Getenv)
+	if err != nil {
+		log.Fatalf("invalid federation configuration: %v", err)
+	}
+	auth := &gatewayServer{
+		httpClient:            &http.Client{Timeout: 3 * time.Second},
+		defaultHumanHeader:    serviceutil.EnvOr("HUMAN_ID_HEADER", defaultHumanHeader),
+		defaultAgentHeader:    serviceutil.EnvOr("AGENT_ID_HEADER", defaultAgentHeader),
+		defaultTeamHeader:     serviceutil.EnvOr("TEAM_ID_HEADER", defaultTeamHeader),
+		defaultSessionHeader:  serviceutil.EnvOr("SESSION_ID_HEADER", defaultSessionHeader),
+		defaultPolicyMode:     serviceutil.EnvOr("POLICY_MODE", defaultPolicyMode),
+		defaultPolicyDecision: serviceutil.EnvOr("POLICY_DEFAULT_DECISION", defaultPolicyDecision),
+		defaultPolicyVersion:  serviceutil.EnvOr("POLICY_VERSION", defaultPolicyVersion),
+		oauthProviders:        map[string]*oauthProvider{},
+	}
+	f, err := newFederation(
+		strings.TrimSpace(os.Getenv("MCP_FEDERATION_NAME")),
+		strings.TrimSpace(os.Getenv("MCP_FEDERATION_NAMESPACE")),
+		members,
+		auth,
+		newFederationMetrics(prometheus.DefaultRegisterer),
+	)
+	if err != nil {
+		log.Fatalf("invalid federation configuration: %v", err)
+	}
+	f.reloadPolicies()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		ticker := time.NewTicker(federationPolicyReloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.reloadPolicies()
+			}
+		}
+	}()
+
+	metricsShutdown, metricsErrs := serviceutil.StartMetricsServer(metricsPort)
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           f,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      5 * time.Minute,
+		IdleTimeout:       60 * time.Second,
+	}
+	log.Printf("mcp-gateway federation listening on :%s with %d members (metrics on :%s)", port, len(members), metricsPort)
+
+	serverErrs := make(chan error, 2)
+	go func() {
+		if err, ok := <-metricsErrs; ok {
+			serverErrs <- err
+		}
+	}()
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrs <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErrs:
+		log.Fatalf("federation failed: %v", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("federation shutdown failed: %v", err)
+		}
+		if err := metricsShutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics server shutdown failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// fakeFederationMember serves initialize, tools/list with echo and secret,
+// and tools/call, and records the last routed call.
+type fakeFederationMember struct {
+	session  string
+	lastCall map[string]any
+	lastSeen string
+}
+
+func (m *fakeFederationMember) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params map[string]any  `json:"params"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if len(req.ID) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	var result any
+	switch req.Method {
+	case "initialize":
+		w.Header().Set(mcpSessionHeader, m.session)
+		result = map[string]any{"protocolVersion": "2025-06-18", "capabilities": map[string]any{"tools": map[string]any{}}}
+	case "tools/list":
+		result = map[string]any{"tools": []map[string]any{{"name": "echo"}, {"name": "secret"}}}
+	case "tools/call":
+		m.lastCall = req.Params
+		m.lastSeen = r.Header.Get(mcpSessionHeader)
+		result = map[string]any{"content": []map[string]any{{"type": "text", "text": "ok"}}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func TestFederationMergesAndRoutesWithMemberPolicy(t *testing.T) {
+	dir := t.TempDir()
+	var members []*federationMember
+	fakes := map[string]*fakeFederationMember{}
+	for _, name := range []string{"alpha", "beta"} {
+		fake := &fakeFederationMember{session: name + "-session"}
+		fakes[name] = fake
+		upstream := httptest.NewServer(fake)
+		t.Cleanup(upstream.Close)
+		policyFile := filepath.Join(dir, name+".json")
+		writeStampedPolicy(t, policyFile, func(doc *policypkg.Document) {
+			header := headerPolicy()
+			doc.Server.Name = policypkg.ServerName(name)
+			doc.Auth, doc.Session, doc.Tools, doc.Grants, doc.Sessions = header.Auth, header.Session, header.Tools, header.Grants, header.Sessions
+		})
+		members = append(members, &federationMember{Name: name, Prefix: name, URL: upstream.URL, PolicyFile: policyFile})
+	}
+	f, err := newFederation("platform", "mcp-servers", members, minimalServer(), newFederationMetrics(nil))
+	if err != nil {
+		t.Fatalf("newFederation() error = %v", err)
+	}
+	f.reloadPolicies()
+
+	post := func(session, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewBufferString(body))
+		req.Header.Set(defaultHumanHeader, "human-1")
+		req.Header.Set(defaultAgentHeader, "client-1")
+		req.Header.Set(defaultTeamHeader, "team-acme")
+		req.Header.Set(defaultSessionHeader, "session-1")
+		if session != "" {
+			req.Header.Set(mcpSessionHeader, session)
+		}
+		rec := httptest.NewRecorder()
+		f.ServeHTTP(rec, req)
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) map[string]any {
+		t.Helper()
+		var decoded map[string]any
+		data, _ := io.ReadAll(rec.Body)
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("decode response %q: %v", data, err)
+		}
+		return decoded
+	}
+
+	rec := post("", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	session := rec.Header().Get(mcpSessionHeader)
+	if rec.Code != http.StatusOK || session == "" {
+		t.Fatalf("initialize status = %d, session = %q", rec.Code, session)
+	}
+	decodedSession, ok := decodeFederationSession(session)
+	if !ok || decodedSession["alpha"] != "alpha-session" || decodedSession["beta"] != "beta-session" {
+		t.Fatalf("federated session = %v", decodedSession)
+	}
+
+	tools := decode(post(session, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))["result"].(map[string]any)["tools"].([]any)
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	if fmt.Sprint(names) != "[alpha__echo beta__echo]" {
+		t.Fatalf("merged tools = %v, want the granted echo of each member", names)
+	}
+
+	rec = post(session, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"beta__echo","arguments":{"text":"hi"}}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("tools/call status = %d", rec.Code)
+	}
+	if beta := fakes["beta"]; beta.lastCall["name"] != "echo" || beta.lastSeen != "beta-session" {
+		t.Fatalf("beta got call %v with session %q, want echo on beta-session", beta.lastCall, beta.lastSeen)
+	}
+	if fakes["alpha"].lastCall != nil {
+		t.Fatalf("alpha got call %v, want none", fakes["alpha"].lastCall)
+	}
+
+	denied := decode(post(session, `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"alpha__secret"}}`))
+	rpcErr, _ := denied["error"].(map[string]any)
+	if rpcErr == nil || rpcErr["code"] != float64(rpcDeniedErrorCode) || rpcErr["data"].(map[string]any)["member"] != "alpha" {
+		t.Fatalf("ungranted call response = %v, want a denial naming alpha", denied)
+	}
+	if fakes["alpha"].lastCall != nil {
+		t.Fatal("denied call reached alpha")
+	}
+
+	unknown := decode(post(session, `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"gamma__echo"}}`))
+	if rpcErr, _ := unknown["error"].(map[string]any); rpcErr == nil || rpcErr["code"] != float64(rpcInvalidParamsCode) {
+		t.Fatalf("unknown prefix response = %v, want invalid params", unknown)
+	}
+}
+
+func TestNewFederationRejectsDuplicatePrefixes(t *testing.T) {
+	members := []*federationMember{
+		{Name: "a", Prefix: "shared", URL: "http://a.ns.svc/mcp"},
+		{Name: "b", Prefix: "shared", URL: "http://b.ns.svc/mcp"},
+	}
+	if _, err := newFederation("f", "ns", members, minimalServer(), newFederationMetrics(nil)); err == nil {
+		t.Fatal("newFederation() error = nil, want duplicate prefix error")
+	}
+}
+
+func TestFederationResourceRoutesArePerSessionAndExpire(t *testing.T) {
+	members := []*federationMember{
+		{Name: "alpha", Prefix: "alpha", URL: "http://alpha.ns.svc/mcp"},
+		{Name: "beta", Prefix: "beta", URL: "http://beta.ns.svc/mcp"},
+	}
+	f, err := newFederation("f", "ns", members, minimalServer(), newFederationMetrics(nil))
+	if err != nil {
+		t.Fatalf("newFederation() error = %v", err)
+	}
+	now := time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC)
+	first := federationSession{"alpha": "alpha-1", "beta": "beta-1"}
+	second := federationSession{"alpha": "alpha-2", "beta": "beta-2"}
+
+	f.rememberResource(resourceRoute{member: "beta", sessionID: "beta-1", uri: "file:///a"}, now)
+	f.rememberResource(resourceRoute{member: "alpha", sessionID: "alpha-1", uri: "file:///a"}, now)
+	f.rememberResource(resourceRoute{member: "beta", sessionID: "beta-2", uri: "file:///a"}, now)
+	if owner := f.resourceOwner(first, "file:///a", now); owner == nil || owner.Name != "alpha" {
+		t.Fatalf("first session owner = %v, want alpha, the first member that listed the URI", owner)
+	}
+	if owner := f.resourceOwner(second, "file:///a", now); owner == nil || owner.Name != "beta" {
+		t.Fatalf("second session owner = %v, want beta, the only member that listed it in that session", owner)
+	}
+	if owner := f.resourceOwner(federationSession{"alpha": "alpha-3"}, "file:///a", now); owner != nil {
+		t.Fatalf("unlisted session owner = %v, want none", owner)
+	}
+
+	later := now.Add(federationResourceRouteTTL)
+	if owner := f.resourceOwner(first, "file:///a", later); owner != nil {
+		t.Fatalf("expired route owner = %v, want none", owner)
+	}
+	f.rememberResource(resourceRoute{member: "alpha", sessionID: "alpha-3", uri: "file:///b"}, later)
+	if len(f.resourceRoutes) != 1 {
+		t.Fatalf("routes = %v, want the expired routes dropped", f.resourceRoutes)
+	}
+
+	for i := 0; i < maxFederationResourceRoutes+10; i++ {
+		f.rememberResource(resourceRoute{member: "alpha", sessionID: "alpha-3", uri: fmt.Sprintf("file:///%d", i)}, later)
+	}
+	if len(f.resourceRoutes) > maxFederationResourceRoutes {
+		t.Fatalf("routes = %d, want at most %d", len(f.resourceRoutes), maxFederationResourceRoutes)
+	}
+}
@@ -8,19 +8,34 @@ import (
 )
 
 // authzFilter is stage 4 of the gateway pipeline. It evaluates the policy
-// decision for tool calls and indeterminate RPC attempts.
+// decision for governed requests (tool calls, prompts/get, resources/read, and
+// resources/subscribe) and indeterminate RPC attempts, then applies the
+// session max lifetime and idle timeout. Rate limits apply to tool calls only.
 //
-// Non-tool-call requests (e.g. tools/list, resources/list, ping, GET passthrough)
-// are not subject to grant/session policy evaluation and always Continue.
+// Other requests (e.g. tools/list, resources/list, ping, GET passthrough) are
+// not subject to grant/session policy evaluation and always Continue. When
+// the policy enables list filtering, list requests additionally get a
+// listFilter so the upstream response only shows entries the caller could use.
 //
-// For tool calls, the authorization inputs are Exchange.Policy and
+// For governed requests, the authorization inputs are Exchange.Policy and
 // Exchange.Identity. Both were set by earlier stages and must not be mutated
 // after this filter sets Exchange.Decision.
 //
+// JSON-RPC batches are authorized entry by entry (see authorizeBatch); denied
+// entries are answered with per-id JSON-RPC errors while allowed entries are
+// forwarded.
+//
+// Tool calls that require approval are resolved against an MCPToolApproval
+// (see resolveApproval) before rate limits apply, so only released calls count
+// toward them.
+//
 // On any denial, authzFilter writes the denial response and returns Reject.
 func (s *gatewayServer) authzFilter(ex *Exchange) Result {
-	if !ex.Inspection.ToolCall && !ex.Inspection.Indeterminate {
-		// Non-tool requests (tools/list, resources/*, ping, GET passthrough) are
+	if len(ex.Inspection.Batch) > 0 {
+		return s.authorizeBatch(ex)
+	}
+	if !ex.Inspection.Governed && !ex.Inspection.Indeterminate {
+		// Ungoverned requests (tools/list, resources/list, ping, GET passthrough) are
 		// intentionally not subject to grant/session policy. Record an explicit
 		// allow so the deny default set in newExchange unambiguously means
 		// "never evaluated"; without this the passthrough would rely on the
@@ -30,6 +45,9 @@ func (s *gatewayServer) authzFilter(ex *Exchange) Result {
 			"allowed",
 			policypkg.ChoosePolicyVersion(policypkg.PolicyVersion(ex.Policy), s.defaultPolicyVersion),
 		)
+		if policypkg.IsListMethod(ex.Inspection.Method) && policypkg.FilterListResponses(ex.Policy) {
+			ex.ListFilter = s.newListFilter(ex)
+		}
 		return Continue
 	}
 
@@ -41,17 +59,25 @@ func (s *gatewayServer) authzFilter(ex *Exchange) Result {
 			policypkg.ChoosePolicyVersion(policypkg.PolicyVersion(ex.Policy), s.defaultPolicyVersion),
 		)
 	case ex.Inspection.Indeterminate:
+		status := http.StatusForbidden
+		if ex.Inspection.FailureReason == rpcInvalidParamsReason || ex.Inspection.FailureReason == rpcInvalidRequestReason {
+			status = http.StatusBadRequest
+		}
 		ex.Decision = policypkg.Deny(
-			http.StatusForbidden,
+			status,
 			policypkg.FirstNonEmpty(ex.Inspection.FailureReason, "rpc_inspection_failed"),
 			policypkg.ChoosePolicyVersion(policypkg.PolicyVersion(ex.Policy), s.defaultPolicyVersion),
 		)
 	default:
-		ex.Decision = policypkg.Authorize(ex.Policy, policypkg.Request{
-			Identity:  policyIdentity(ex.Identity),
-			RPCMethod: ex.Inspection.Method,
-			ToolName:  policypkg.ToolName(ex.Inspection.ToolName),
-		}, time.Now())
+		now := time.Now()
+		ex.Decision = policypkg.Authorize(ex.Policy, policyRequest(
+			policyIdentity(ex.Identity), ex.Inspection.Method, ex.Inspection.ToolName, ex.Inspection.ResourceURI, ex.Inspection.Arguments,
+		), now)
+		ex.Decision = s.applySessionLimits(ex.R.Context(), ex.Policy, ex.Decision, now)
+		if ex.Inspection.ToolCall {
+			ex.Decision = s.resolveApproval(ex, ex.Decision)
+			ex.Decision = s.applyRateLimits(ex.R.Context(), ex.Policy, ex.Identity, ex.Inspection.ToolName, ex.Decision)
+		}
 	}
 
 	if !ex.Decision.Allowed {
@@ -60,3 +86,16 @@ func (s *gatewayServer) authzFilter(ex *Exchange) Result {
 	}
 	return Continue
 }
+
+// policyRequest builds the evaluator request for one inspected RPC. params.name
+// names the tool for tools/call and the prompt for prompts/get.
+func policyRequest(identity policypkg.Identity, method, name, uri string, arguments map[string]any) policypkg.Request {
+	request := policypkg.Request{Identity: identity, RPCMethod: method, ResourceURI: uri}
+	if policypkg.IsPromptMethod(method) {
+		request.PromptName = policypkg.PromptName(name)
+		return request
+	}
+	request.ToolName = policypkg.ToolName(name)
+	request.Arguments = arguments
+	return request
+}
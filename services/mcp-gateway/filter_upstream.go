@@ -1,9 +1,16 @@
 package main
 
+import "net/http"
+
 // upstreamFilter is stage 5 of the gateway pipeline. It rewrites identity
 // headers and the upstream token on the outbound request, strips any configured
 // path prefix, and forwards the request to the upstream MCP server via the
-// reverse proxy.
+// reverse proxy. When authzFilter attached a ListFilter, the upstream list
+// response is rewritten before it reaches the caller; for a partially denied
+// JSON-RPC batch only the allowed entries are forwarded and the denied entries'
+// errors are merged into the upstream response. For RPC requests the response
+// is captured, within bounds, so the audit stage can record each call's
+// JSON-RPC outcome.
 //
 // upstreamFilter reads Exchange.Policy, Exchange.Identity, and Exchange.OAuthToken
 // (all set by earlier stages) and must not mutate them. It always returns Respond
@@ -31,6 +38,30 @@ func (s *gatewayServer) upstreamFilter(ex *Exchange) Result {
 		}
 	}
 
+	if ex.Inspection.Method != "" {
+		// The outcome has to be read from the body, so ask upstream for an
+		// uncompressed one.
+		ex.R.Header.Del("Accept-Encoding")
+		ex.W.capture = &responseCapture{}
+		defer ex.W.capture.finish()
+	}
+
+	if len(ex.BatchDecisions) > 0 {
+		out, err := s.batchUpstreamRequest(ex)
+		if err != nil {
+			http.Error(ex.W, "failed to prepare upstream batch", http.StatusInternalServerError)
+			return Respond
+		}
+		s.proxy.ServeHTTP(ex.W, out)
+		return Respond
+	}
+	if ex.ListFilter != nil {
+		// The filter has to read the list, so ask upstream for an uncompressed
+		// body and hand the filter to the proxy's ModifyResponse hook.
+		ex.R.Header.Del("Accept-Encoding")
+		s.proxy.ServeHTTP(ex.W, ex.R.WithContext(withListFilter(ex.R.Context(), ex.ListFilter)))
+		return Respond
+	}
 	s.proxy.ServeHTTP(ex.W, ex.R)
 	return Respond
 }
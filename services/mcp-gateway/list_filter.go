@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// maxRewrittenResponseBytes bounds how much of a list or batch response (or a
+// single SSE event) the gateway buffers while rewriting it. Larger responses
+// fail closed rather than leaking unfiltered entries.
+const maxRewrittenResponseBytes = 8 << 20
+
+var (
+	errResponseTooLarge = errors.New("upstream response exceeds rewrite buffer")
+	errResponseEncoding = errors.New("upstream response has unsupported content encoding")
+)
+
+type listFilterContextKey struct{}
+
+// listEntry is the subset of a tools/prompts/resources list entry the gateway
+// needs to authorize it.
+type listEntry struct {
+	Name string `json:"name"`
+	URI  string `json:"uri"`
+}
+
+// listFilter rewrites a single list response so only entries the caller could
+// use remain. It is created by authzFilter and applied to the upstream
+// response by the reverse proxy's ModifyResponse hook.
+type listFilter struct {
+	// resultKey is the array inside result that holds the entries
+	// (tools, prompts, or resources).
+	resultKey string
+	allowed   func(listEntry) bool
+	// hidden counts entries removed so far. It is only written while the proxy
+	// copies the response, which completes before the audit stage reads it.
+	hidden int
+}
+
+// newListFilter returns the per-caller filter for a list request, or nil when
+// the method is not a list method. Each entry is checked with the same
+// evaluator that would authorize invoking it, so the filtered list never
+// shows something the caller would be denied.
+func (s *gatewayServer) newListFilter(ex *Exchange) *listFilter {
+	identity := policyIdentity(ex.Identity)
+	now := time.Now()
+	authorize := func(method, name, uri string) bool {
+		decision := policypkg.Authorize(ex.Policy, policyRequest(identity, method, name, uri, nil), now)
+		// A list carries no arguments, so a tool whose rule only constrains
+		// arguments is still usable and stays listed, as is a tool callable
+		// once approved.
+		return decision.Allowed || decision.RequiresApproval || decision.Reason == "argument_constraint_violated"
+	}
+	switch ex.Inspection.Method {
+	case "tools/list":
+		return &listFilter{resultKey: "tools", allowed: func(entry listEntry) bool {
+			return authorize("tools/call", entry.Name, "")
+		}}
+	case "prompts/list":
+		return &listFilter{resultKey: "prompts", allowed: func(entry listEntry) bool {
+			return authorize("prompts/get", entry.Name, "")
+		}}
+	case "resources/list":
+		return &listFilter{resultKey: "resources", allowed: func(entry listEntry) bool {
+			return authorize("resources/read", "", entry.URI)
+		}}
+	default:
+		return nil
+	}
+}
+
+func withListFilter(ctx context.Context, filter *listFilter) context.Context {
+	return context.WithValue(ctx, listFilterContextKey{}, filter)
+}
+
+func listFilterFromContext(ctx context.Context) *listFilter {
+	filter, _ := ctx.Value(listFilterContextKey{}).(*listFilter)
+	return filter
+}
+
+// modifyUpstreamResponse is the reverse proxy ModifyResponse hook. It is a
+// no-op unless upstreamFilter attached a listFilter or batchMerger to the
+// outbound request.
+func modifyUpstreamResponse(resp *http.Response) error {
+	if resp == nil || resp.Request == nil {
+		return nil
+	}
+	if filter := listFilterFromContext(resp.Request.Context()); filter != nil {
+		return filter.apply(resp)
+	}
+	if merger := batchMergerFromContext(resp.Request.Context()); merger != nil {
+		return merger.apply(resp)
+	}
+	return nil
+}
+
+// apply rewrites resp in place. Non-200 responses are passed through because
+// they carry no list. JSON bodies are buffered and rewritten; SSE bodies are
+// rewritten event by event so streaming is preserved.
+func (f *listFilter) apply(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	if encoding := strings.TrimSpace(resp.Header.Get("Content-Encoding")); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return errResponseEncoding
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		resp.Body = &sseListFilterReader{src: bufio.NewReader(resp.Body), body: resp.Body, filter: f}
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		return nil
+	case "application/json":
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRewrittenResponseBytes+1))
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if len(body) > maxRewrittenResponseBytes {
+			return errResponseTooLarge
+		}
+		rewritten, err := f.rewriteMessage(body)
+		if err != nil {
+			return err
+		}
+		setResponseBody(resp, rewritten)
+		return nil
+	default:
+		return nil
+	}
+}
+
+// rewriteMessage filters result.<resultKey> of one JSON-RPC response. Messages
+// without that array (errors, notifications) are returned unchanged, as are
+// responses where nothing was hidden so the upstream bytes are preserved.
+func (f *listFilter) rewriteMessage(payload []byte) ([]byte, error) {
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, err
+	}
+	rawResult, ok := message["result"]
+	if !ok {
+		return payload, nil
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(rawResult, &result); err != nil {
+		return nil, err
+	}
+	rawEntries, ok := result[f.resultKey]
+	if !ok {
+		return payload, nil
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal(rawEntries, &entries); err != nil {
+		return nil, err
+	}
+	kept := make([]json.RawMessage, 0, len(entries))
+	for _, raw := range entries {
+		var entry listEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, err
+		}
+		if f.allowed(entry) {
+			kept = append(kept, raw)
+		}
+	}
+	hidden := len(entries) - len(kept)
+	if hidden == 0 {
+		return payload, nil
+	}
+	f.hidden += hidden
+
+	var err error
+	if result[f.resultKey], err = json.Marshal(kept); err != nil {
+		return nil, err
+	}
+	if message["result"], err = json.Marshal(result); err != nil {
+		return nil, err
+	}
+	return json.Marshal(message)
+}
+
+// sseListFilterReader rewrites the data of each server-sent event as it is
+// read. Non-data lines (event, id, retry, comments) are forwarded as-is; the
+// data lines of an event are replaced by a single rewritten data line only
+// when entries were hidden.
+type sseListFilterReader struct {
+	src    *bufio.Reader
+	body   io.Closer
+	filter *listFilter
+	event  [][]byte
+	size   int
+	out    bytes.Buffer
+	err    error
+}
+
+func (r *sseListFilterReader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 && r.err == nil {
+		line, err := r.src.ReadBytes('\n')
+		if len(line) > 0 {
+			r.event = append(r.event, line)
+			r.size += len(line)
+			if r.size > maxRewrittenResponseBytes {
+				r.err = errResponseTooLarge
+				break
+			}
+			if len(bytes.TrimRight(line, "\r\n")) == 0 {
+				if flushErr := r.flushEvent(); flushErr != nil {
+					r.err = flushErr
+					break
+				}
+			}
+		}
+		if err != nil {
+			if flushErr := r.flushEvent(); flushErr != nil {
+				err = flushErr
+			}
+			r.err = err
+		}
+	}
+	if r.out.Len() > 0 {
+		return r.out.Read(p)
+	}
+	return 0, r.err
+}
+
+func (r *sseListFilterReader) Close() error {
+	return r.body.Close()
+}
+
+func (r *sseListFilterReader) flushEvent() error {
+	lines := r.event
+	r.event = nil
+	r.size = 0
+	if len(lines) == 0 {
+		return nil
+	}
+
+	var data [][]byte
+	for _, line := range lines {
+		if value, ok := sseDataValue(line); ok {
+			data = append(data, value)
+		}
+	}
+	if len(data) == 0 {
+		for _, line := range lines {
+			r.out.Write(line)
+		}
+		return nil
+	}
+	payload := bytes.Join(data, []byte("\n"))
+	rewritten, err := r.filter.rewriteMessage(payload)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(rewritten, payload) {
+		for _, line := range lines {
+			r.out.Write(line)
+		}
+		return nil
+	}
+	wroteData := false
+	for _, line := range lines {
+		if _, ok := sseDataValue(line); !ok {
+			r.out.Write(line)
+			continue
+		}
+		if !wroteData {
+			r.out.WriteString("data: ")
+			r.out.Write(rewritten)
+			r.out.WriteString("\n")
+			wroteData = true
+		}
+	}
+	return nil
+}
+
+// sseDataValue returns the value of an SSE "data:" line with the single
+// optional leading space and the line terminator removed.
+func sseDataValue(line []byte) ([]byte, bool) {
+	line = bytes.TrimRight(line, "\r\n")
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return nil, false
+	}
+	value := bytes.TrimPrefix(line, []byte("data:"))
+	return bytes.TrimPrefix(value, []byte(" ")), true
+}
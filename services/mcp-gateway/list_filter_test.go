@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+const toolsListResponse = `{"jsonrpc":"2.0","id":1,"result":{"tools":[{"name":"echo","description":"Echo input."},{"name":"delete_all","description":"Delete everything."}]}}`
+
+func listFilteringPolicy() *policypkg.Document {
+	policy := headerPolicy()
+	policy.Policy.FilterListResponses = true
+	policy.Tools = append(policy.Tools, policypkg.Tool{Name: "delete_all", RequiredTrust: "high", SideEffect: "destructive"})
+	return policy
+}
+
+func newToolsListRequest() *http.Request {
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+	req := httptest.NewRequest(http.MethodPost, "http://gateway.example.local/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set(defaultHumanHeader, "human-1")
+	req.Header.Set(defaultAgentHeader, "client-1")
+	req.Header.Set(defaultTeamHeader, "team-acme")
+	req.Header.Set(defaultSessionHeader, "session-1")
+	req.ContentLength = int64(len(body))
+	return req
+}
+
+func listedToolNames(t *testing.T, payload []byte) []string {
+	t.Helper()
+
+	var message struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(payload, &message); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", payload, err)
+	}
+	names := make([]string, 0, len(message.Result.Tools))
+	for _, tool := range message.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestHandleGatewayFiltersJSONToolsList(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, listFilteringPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, toolsListResponse)
+	})
+	registry := prometheus.NewRegistry()
+	proxy.metrics = newGatewayMetrics(registry)
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newToolsListRequest())
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	if got := listedToolNames(t, recorder.Body.Bytes()); len(got) != 1 || got[0] != "echo" {
+		t.Fatalf("listed tools = %v, want [echo]", got)
+	}
+	if got := recorder.Header().Get("Content-Length"); got != "" && got != strconv.Itoa(recorder.Body.Len()) {
+		t.Fatalf("Content-Length = %q, want %d", got, recorder.Body.Len())
+	}
+	if got := testutil.ToFloat64(proxy.metrics.listEntriesHiddenTotal.WithLabelValues("", "", "", "", "tools/list")); got != 1 {
+		t.Fatalf("hidden entries metric = %v, want 1", got)
+	}
+}
+
+func TestHandleGatewayFiltersSSEToolsList(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, listFilteringPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, ": keep-alive\n\nevent: message\nid: 7\ndata: "+toolsListResponse+"\n\n")
+	})
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newToolsListRequest())
+
+	body := recorder.Body.String()
+	if !strings.HasPrefix(body, ": keep-alive\n\nevent: message\nid: 7\ndata: ") {
+		t.Fatalf("SSE framing not preserved: %q", body)
+	}
+	data := strings.TrimSuffix(strings.SplitN(body, "data: ", 2)[1], "\n\n")
+	if got := listedToolNames(t, []byte(data)); len(got) != 1 || got[0] != "echo" {
+		t.Fatalf("listed tools = %v, want [echo]", got)
+	}
+}
+
+func TestHandleGatewayLeavesToolsListUnfilteredWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, headerPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, toolsListResponse)
+	})
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newToolsListRequest())
+
+	if recorder.Body.String() != toolsListResponse {
+		t.Fatalf("body = %s, want upstream response unchanged", recorder.Body.String())
+	}
+}
+
+func TestHandleGatewayFilteredToolsListHidesEverythingWithoutGrant(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, listFilteringPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, toolsListResponse)
+	})
+
+	req := newToolsListRequest()
+	req.Header.Set(defaultHumanHeader, "stranger")
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, req)
+
+	if got := listedToolNames(t, recorder.Body.Bytes()); len(got) != 0 {
+		t.Fatalf("listed tools = %v, want none for a caller without a grant", got)
+	}
+}
+
+func TestHandleGatewayFiltersResourcesListByURI(t *testing.T) {
+	t.Parallel()
+
+	policy := listFilteringPolicy()
+	policy.Grants[0].ResourceRules = []policypkg.ResourceAccess{{URI: "file:///docs/*", Decision: "allow"}}
+	proxy := newTestGatewayServer(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":{"resources":[{"uri":"file:///docs/a.md","name":"a"},{"uri":"file:///etc/passwd","name":"passwd"}]}}`)
+	})
+
+	req := newBatchRequest(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, req)
+
+	body := recorder.Body.String()
+	if !strings.Contains(body, "file:///docs/a.md") || strings.Contains(body, "passwd") {
+		t.Fatalf("body = %s, want only the granted resource", body)
+	}
+}
+
+func TestListFilterPassesThroughErrorsAndCountsHidden(t *testing.T) {
+	t.Parallel()
+
+	filter := &listFilter{resultKey: "tools", allowed: func(entry listEntry) bool { return entry.Name == "echo" }}
+
+	errorResponse := []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"nope"}}`)
+	got, err := filter.rewriteMessage(errorResponse)
+	if err != nil {
+		t.Fatalf("rewriteMessage(error) error = %v", err)
+	}
+	if string(got) != string(errorResponse) {
+		t.Fatalf("rewriteMessage(error) = %s, want unchanged", got)
+	}
+
+	if _, err := filter.rewriteMessage([]byte(toolsListResponse)); err != nil {
+		t.Fatalf("rewriteMessage(list) error = %v", err)
+	}
+	if filter.hidden != 1 {
+		t.Fatalf("hidden = %d, want 1", filter.hidden)
+	}
+
+	if _, err := filter.rewriteMessage([]byte(`not json`)); err == nil {
+		t.Fatal("rewriteMessage(invalid) error = nil, want failure so the response fails closed")
+	}
+}
+
+func TestAuditRecordsHiddenListEntries(t *testing.T) {
+	t.Parallel()
+
+	var captured atomic.Value
+	ingest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			Payload map[string]any `json:"payload"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		captured.Store(event.Payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ingest.Close)
+
+	proxy := newTestGatewayServer(t, listFilteringPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, toolsListResponse)
+	})
+	proxy.httpClient = ingest.Client()
+	proxy.analyticsURL = ingest.URL
+	proxy.startAnalyticsDispatcher()
+
+	proxy.handleGateway(httptest.NewRecorder(), newToolsListRequest())
+	proxy.stopAnalyticsDispatcher()
+
+	payload, _ := captured.Load().(map[string]any)
+	if payload == nil {
+		t.Fatal("no audit event emitted for tools/list")
+	}
+	if got := payload["list_entries_hidden"]; got != float64(1) {
+		t.Fatalf("list_entries_hidden = %v, want 1", got)
+	}
+}
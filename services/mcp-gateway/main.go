@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -24,6 +25,15 @@ import (
 )
 
 func main() {
+	switch strings.TrimSpace(os.Getenv("MCP_GATEWAY_MODE")) {
+	case "activator":
+		runActivator()
+		return
+	case "federation":
+		runFederation()
+		return
+	}
+
 	port := serviceutil.EnvOr("PORT", "8091")
 	metricsPort := serviceutil.EnvOr("METRICS_PORT", "9103")
 	upstream := serviceutil.EnvOr("UPSTREAM_URL", "http://127.0.0.1:8090")
@@ -62,7 +72,7 @@ func main() {
 
 	srv := &gatewayServer{
 		proxy:                 proxy,
-		metrics:               newGatewayMetrics(prometheus.DefaultRegisterer),
+		metrics:               newGatewayMetrics(trackedRegisterer(prometheus.DefaultRegisterer, os.Getenv("MCP_ROLLOUT_TRACK"))),
 		analyticsURL:          analyticsURL,
 		apiKey:                apiKey,
 		source:                source,
@@ -84,6 +94,36 @@ func main() {
 		defaultPolicyDecision: serviceutil.EnvOr("POLICY_DEFAULT_DECISION", defaultPolicyDecision),
 		defaultPolicyVersion:  serviceutil.EnvOr("POLICY_VERSION", defaultPolicyVersion),
 		oauthProviders:        map[string]*oauthProvider{},
+		approvalURL:           strings.TrimSpace(os.Getenv("APPROVAL_URL")),
+		sessionActivityURL:    strings.TrimSpace(os.Getenv("SESSION_ACTIVITY_URL")),
+		rateLimitURL:          strings.TrimSpace(os.Getenv("RATE_LIMIT_URL")),
+		operatorTokenFile:     strings.TrimSpace(os.Getenv("OPERATOR_TOKEN_FILE")),
+	}
+	srv.rateLimits, err = newRateLimitStore(os.Getenv("RATE_LIMIT_STORE"), srv.rateLimitURL, srv.countRateLimit)
+	if err != nil {
+		log.Fatalf("invalid RATE_LIMIT_STORE: %v", err)
+	}
+	srv.sessionActivity, err = newSessionActivityStore(os.Getenv("SESSION_ACTIVITY_STORE"), srv.sessionActivityURL, srv.reportSessionActivity)
+	if err != nil {
+		log.Fatalf("invalid SESSION_ACTIVITY_STORE: %v", err)
+	}
+	if spoolDir := strings.TrimSpace(os.Getenv("ANALYTICS_SPOOL_DIR")); spoolDir != "" && analyticsURL != "" {
+		maxBytes := int64(defaultSpoolMaxBytes)
+		if raw := strings.TrimSpace(os.Getenv("ANALYTICS_SPOOL_MAX_BYTES")); raw != "" {
+			maxBytes, err = strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				log.Fatalf("invalid ANALYTICS_SPOOL_MAX_BYTES: %v", err)
+			}
+		}
+		srv.spool, err = openEventSpool(spoolDir, maxBytes, serviceutil.EnvOr("ANALYTICS_SPOOL_FSYNC", spoolFsyncInterval))
+		if err != nil {
+			log.Fatalf("open analytics spool: %v", err)
+		}
+		backlogBytes, backlogEvents := srv.spool.backlog()
+		log.Printf("analytics spool %s: replaying %d events (%d bytes)", srv.spool.dir, backlogEvents, backlogBytes)
+		// The spool sender runs from startup so a backlog from before the
+		// restart is replayed without waiting for new traffic.
+		srv.startAnalyticsDispatcher()
 	}
 	if err := srv.startPolicyCache(); err != nil {
 		log.Fatalf("initial policy load failed: %v", err)
@@ -103,7 +143,11 @@ func main() {
 	mux.Handle("/metrics", promhttp.Handler())
 	mux.HandleFunc("/", srv.handleGateway)
 
-	metricsShutdown, metricsErrs := serviceutil.StartMetricsServer(metricsPort)
+	// The MCP probe lives on the metrics port so kubelet probes reach it even
+	// when the gateway port requires client certificates.
+	metricsShutdown, metricsErrs := serviceutil.StartMetricsServerWithRoutes(metricsPort, map[string]http.Handler{
+		mcpProbeRoute: newMCPProbe(target),
+	})
 
 	shutdown, err := initTracer("mcp-gateway")
 	if err != nil {
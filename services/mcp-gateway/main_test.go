@@ -789,6 +789,37 @@ func TestAuditPayloadIncludesToolRiskLevel(t *testing.T) {
 	}
 }
 
+func TestAuditPayloadIncludesBaselineRule(t *testing.T) {
+	t.Parallel()
+
+	proxy := &gatewayServer{
+		serverName:           "example-server",
+		serverNamespace:      "mcp-servers",
+		defaultPolicyVersion: "test-policy",
+	}
+	req := httptest.NewRequest(http.MethodPost, "http://proxy.example.com/mcp", strings.NewReader(`{"jsonrpc":"2.0"}`))
+
+	payload := proxy.auditPayload(
+		req,
+		"/mcp",
+		"tools/call",
+		"drop_table",
+		identityContext{AgentID: "ops-bot"},
+		nil,
+		policypkg.Decision{Status: http.StatusForbidden, Reason: "baseline_denied", BaselineRule: "guardrails/no-agent-destructive"},
+		http.StatusForbidden,
+		1,
+		2,
+	)
+
+	if got := payload["reason"]; got != "baseline_denied" {
+		t.Fatalf("reason = %#v, want baseline_denied", got)
+	}
+	if got := payload["baseline_rule"]; got != "guardrails/no-agent-destructive" {
+		t.Fatalf("baseline_rule = %#v, want guardrails/no-agent-destructive", got)
+	}
+}
+
 func TestStartPolicyCacheRequiresConfiguredPolicyFile(t *testing.T) {
 	t.Parallel()
 
@@ -1265,6 +1296,8 @@ func newTestGatewayServer(t *testing.T, policy *policypkg.Document, upstream htt
 		defaultPolicyDecision: defaultPolicyDecision,
 		defaultPolicyVersion:  "test-policy",
 		oauthProviders:        map[string]*oauthProvider{},
+		rateLimits:            newMemoryRateLimitStore(),
+		sessionActivity:       newMemorySessionActivityStore(),
 	}
 	server.snapshotPolicy(policySnapshot{Policy: policy, Revision: policy.Revision, LoadedAt: time.Now(), Ready: true})
 	return server
@@ -31,10 +31,39 @@ var (
 		Name: "mcp_gateway_policy_last_success_timestamp_seconds",
 		Help: "Unix timestamp (seconds) of the last successful gateway policy load.",
 	})
+
+	// analyticsEventsDroppedTotal counts analytics and audit events the
+	// gateway gave up on, grouped by reason: queue_full and closed for the
+	// in-memory queue, spool_full and spool_error when the spool could not
+	// take the event, rejected when ingest refused it, corrupt for an
+	// unreadable spool record.
+	analyticsEventsDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_gateway_analytics_events_dropped_total",
+		Help: "Total analytics events the gateway dropped without delivering them, grouped by reason.",
+	}, []string{"reason"})
+
+	// analyticsDeliveryRetriesTotal counts failed deliveries of spooled
+	// events that will be retried.
+	analyticsDeliveryRetriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "mcp_gateway_analytics_delivery_retries_total",
+		Help: "Total failed deliveries of spooled analytics events that were retried.",
+	})
+
+	// analyticsSpoolBacklogBytes and analyticsSpoolBacklogEvents report what
+	// the spool holds that the ingest service has not accepted yet.
+	analyticsSpoolBacklogBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mcp_gateway_analytics_spool_backlog_bytes",
+		Help: "Bytes of spooled analytics events not yet delivered.",
+	})
+	analyticsSpoolBacklogEvents = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mcp_gateway_analytics_spool_backlog_events",
+		Help: "Number of spooled analytics events not yet delivered.",
+	})
 )
 
 func init() {
 	prometheus.MustRegister(policyReloadTotal, policyActiveRevisionInfo, policyLastSuccessTimestamp)
+	prometheus.MustRegister(analyticsEventsDroppedTotal, analyticsDeliveryRetriesTotal, analyticsSpoolBacklogBytes, analyticsSpoolBacklogEvents)
 }
 
 // recordPolicyReloadSuccess records a successful reload and republishes the
@@ -53,6 +82,20 @@ func recordPolicyReloadFailure() {
 	policyReloadTotal.WithLabelValues("failure").Inc()
 }
 
+// rolloutTrackLabel labels request metrics with the rollout track of the pod
+// (stable or canary) so canary analysis can compare the two.
+const rolloutTrackLabel = "rollout_track"
+
+// trackedRegisterer adds the rollout track as a constant label to every metric
+// registered through it. An empty track leaves metrics unlabeled.
+func trackedRegisterer(registerer prometheus.Registerer, track string) prometheus.Registerer {
+	track = strings.TrimSpace(track)
+	if registerer == nil || track == "" {
+		return registerer
+	}
+	return prometheus.WrapRegistererWith(prometheus.Labels{rolloutTrackLabel: track}, registerer)
+}
+
 type gatewayMetrics struct {
 	requestsTotal          *prometheus.CounterVec
 	policyDecisionsTotal   *prometheus.CounterVec
@@ -62,6 +105,10 @@ type gatewayMetrics struct {
 	responseBytesTotal     *prometheus.CounterVec
 	policyReloadsTotal     *prometheus.CounterVec
 	policyLastReload       *prometheus.GaugeVec
+	listEntriesHiddenTotal *prometheus.CounterVec
+	rpcErrorsTotal         *prometheus.CounterVec
+	toolCallResultsTotal   *prometheus.CounterVec
+	toolResultBytesTotal   *prometheus.CounterVec
 }
 
 type gatewayMetricScope struct {
@@ -106,6 +153,22 @@ func newGatewayMetrics(registerer prometheus.Registerer) *gatewayMetrics {
 			Name: "mcp_gateway_policy_last_reload_success_timestamp_seconds",
 			Help: "Unix timestamp of the last successful gateway policy reload.",
 		}, []string{"namespace", "server", "cluster", "team_id"}),
+		listEntriesHiddenTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_list_entries_hidden_total",
+			Help: "Total list response entries hidden from callers by per-caller list filtering.",
+		}, []string{"namespace", "server", "cluster", "team_id", "rpc_method"}),
+		rpcErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_rpc_errors_total",
+			Help: "Total JSON-RPC error responses upstream MCP servers returned to allowed requests.",
+		}, []string{"namespace", "server", "cluster", "team_id", "rpc_method", "code"}),
+		toolCallResultsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_tool_call_results_total",
+			Help: "Total allowed tools/call responses grouped by tool and outcome (success, tool_error, or rpc_error).",
+		}, []string{"namespace", "server", "cluster", "team_id", "tool", "outcome"}),
+		toolResultBytesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_gateway_tool_result_bytes_total",
+			Help: "Total size of tools/call result JSON returned by upstream MCP servers.",
+		}, []string{"namespace", "server", "cluster", "team_id", "tool"}),
 	}
 	if registerer != nil {
 		registerer.MustRegister(
@@ -117,6 +180,10 @@ func newGatewayMetrics(registerer prometheus.Registerer) *gatewayMetrics {
 			m.responseBytesTotal,
 			m.policyReloadsTotal,
 			m.policyLastReload,
+			m.listEntriesHiddenTotal,
+			m.rpcErrorsTotal,
+			m.toolCallResultsTotal,
+			m.toolResultBytesTotal,
 		)
 	}
 	return m
@@ -186,6 +253,48 @@ func (m *gatewayMetrics) recordPolicyDecision(scope gatewayMetricScope, rpcMetho
 	).Inc()
 }
 
+func (m *gatewayMetrics) recordListEntriesHidden(scope gatewayMetricScope, rpcMethod string, hidden int) {
+	if m == nil || hidden <= 0 {
+		return
+	}
+	m.listEntriesHiddenTotal.WithLabelValues(
+		scope.Namespace,
+		scope.Server,
+		scope.Cluster,
+		scope.TeamID,
+		metricRPCMethod(rpcMethod),
+	).Add(float64(hidden))
+}
+
+// recordRPCOutcome counts the upstream outcome of one allowed JSON-RPC
+// request. Tool labels are limited to tools the policy declares.
+func (m *gatewayMetrics) recordRPCOutcome(scope gatewayMetricScope, policy *policypkg.Document, rpcMethod, toolName string, outcome rpcOutcome) {
+	if m == nil {
+		return
+	}
+	if outcome.HasError {
+		m.rpcErrorsTotal.WithLabelValues(
+			scope.Namespace, scope.Server, scope.Cluster, scope.TeamID,
+			metricRPCMethod(rpcMethod), strconv.Itoa(outcome.ErrorCode),
+		).Inc()
+	}
+	if rpcMethod != "tools/call" {
+		return
+	}
+	tool := metricToolName(policy, toolName)
+	result := "success"
+	switch {
+	case outcome.HasError:
+		result = "rpc_error"
+	case outcome.ToolIsError:
+		result = "tool_error"
+	}
+	m.toolCallResultsTotal.WithLabelValues(scope.Namespace, scope.Server, scope.Cluster, scope.TeamID, tool, result).Inc()
+	if !outcome.HasError {
+		m.toolResultBytesTotal.WithLabelValues(scope.Namespace, scope.Server, scope.Cluster, scope.TeamID, tool).Add(float64(outcome.ResultBytes))
+	}
+}
+
 func (m *gatewayMetrics) recordPolicyReload(scope gatewayMetricScope, err error) {
 	if m == nil {
 		return
@@ -243,9 +352,23 @@ func metricRPCMethod(method string) string {
 		"prompts/list",
 		"prompts/get",
 		"completion/complete",
-		"logging/setLevel":
+		"logging/setLevel",
+		rpcBatchMethod:
 		return strings.TrimSpace(method)
 	default:
 		return "other"
 	}
 }
+
+// metricToolName keeps tool label cardinality bounded by the policy's tool
+// inventory; undeclared names are grouped as other.
+func metricToolName(policy *policypkg.Document, toolName string) string {
+	if policy != nil {
+		for _, tool := range policy.Tools {
+			if string(tool.Name) == toolName && toolName != "" {
+				return toolName
+			}
+		}
+	}
+	return "other"
+}
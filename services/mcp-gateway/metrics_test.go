@@ -1,10 +1,15 @@
 package main
 
 import (
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	policypkg "mcp-runtime/pkg/policy"
 )
 
 func TestPolicyReloadMetrics(t *testing.T) {
@@ -33,3 +38,23 @@ func TestPolicyReloadMetrics(t *testing.T) {
 		t.Fatalf("stale revision info = %v, want 0 after new revision activated", got)
 	}
 }
+
+func TestTrackedRegistererLabelsRequestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := newGatewayMetrics(trackedRegisterer(registry, "canary"))
+	scope := gatewayMetricScope{Namespace: "servers", Server: "payments"}
+	metrics.recordRequest(scope, httptest.NewRequest("POST", "/mcp", nil), "tools/call", policypkg.Decision{Allowed: true}, 200, time.Millisecond, 10, 20)
+
+	expected := `
+# HELP mcp_gateway_requests_total Total HTTP requests handled by MCP gateway sidecars.
+# TYPE mcp_gateway_requests_total counter
+mcp_gateway_requests_total{cluster="",decision="allow",method="POST",namespace="servers",rollout_track="canary",rpc_method="tools/call",server="payments",status="200",team_id=""} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "mcp_gateway_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := trackedRegisterer(registry, " "); got != prometheus.Registerer(registry) {
+		t.Fatalf("trackedRegisterer() with empty track = %T, want the registry unchanged", got)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// setOperatorToken authenticates a request to the operator with the
+// projected ServiceAccount token at tokenFile (OPERATOR_TOKEN_FILE). The file
+// is read for every request because the kubelet rotates it in place. An
+// empty tokenFile sends the request unauthenticated, which the operator
+// rejects.
+func setOperatorToken(req *http.Request, tokenFile string) error {
+	if tokenFile == "" {
+		return nil
+	}
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("read operator token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return nil
+}
@@ -88,6 +88,22 @@ func TestInspectFilterSetsInspection(t *testing.T) {
 	}
 }
 
+func TestInspectFilterCapturesToolArguments(t *testing.T) {
+	t.Parallel()
+	s := minimalServer()
+	body := `{"method":"tools/call","params":{"name":"query_db","arguments":{"database":"analytics","limit":25}}}`
+	ex := newTestExchange(http.MethodPost, "/mcp", body, map[string]string{"Content-Type": "application/json"})
+
+	s.inspectFilter(ex)
+
+	if got := ex.Inspection.Arguments["database"]; got != "analytics" {
+		t.Fatalf("Arguments[database] = %v, want analytics", got)
+	}
+	if got := ex.Inspection.Arguments["limit"]; got != float64(25) {
+		t.Fatalf("Arguments[limit] = %v, want 25", got)
+	}
+}
+
 // ---- stage 2: policyFilter --------------------------------------------------
 
 func TestPolicyFilterContinuesForNonOAuthPath(t *testing.T) {
@@ -528,6 +544,155 @@ func TestAuthzFilterRejectsDeniedToolCall(t *testing.T) {
 	}
 }
 
+func TestAuthzFilterEnforcesArgumentConstraints(t *testing.T) {
+	t.Parallel()
+
+	policy := headerPolicy()
+	policy.Session = nil
+	policy.Grants[0].ToolRules = []policypkg.ToolAccess{{
+		Name:                "echo",
+		Decision:            "allow",
+		ArgumentConstraints: []policypkg.ArgumentConstraint{{Argument: "database", OneOf: []string{"analytics"}}},
+	}}
+
+	for _, tc := range []struct {
+		database string
+		want     Result
+	}{
+		{database: "analytics", want: Continue},
+		{database: "billing", want: Reject},
+	} {
+		s := minimalServer()
+		body := `{"method":"tools/call","params":{"name":"echo","arguments":{"database":"` + tc.database + `"}}}`
+		ex := newTestExchange(http.MethodPost, "/mcp", body, map[string]string{"Content-Type": "application/json"})
+		s.inspectFilter(ex)
+		ex.Policy = policy
+		ex.Identity = identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+		if got := s.authzFilter(ex); got != tc.want {
+			t.Fatalf("authzFilter(database=%s) = %v, want %v (decision %#v)", tc.database, got, tc.want, ex.Decision)
+		}
+		if tc.want == Reject && (ex.Decision.Reason != "argument_constraint_violated" || ex.Decision.ViolatedArgument != "database") {
+			t.Fatalf("Decision = %#v, want argument_constraint_violated on database", ex.Decision)
+		}
+	}
+}
+
+func TestAuthzFilterRejectsAmbiguousToolParams(t *testing.T) {
+	t.Parallel()
+
+	policy := headerPolicy()
+	policy.Session = nil
+	policy.Grants[0].ToolRules = []policypkg.ToolAccess{{
+		Name:                "echo",
+		Decision:            "allow",
+		ArgumentConstraints: []policypkg.ArgumentConstraint{{Argument: "database", OneOf: []string{"analytics"}}},
+	}}
+
+	for _, params := range []string{
+		`{"name":"echo","arguments":{"database":"prod"},"Arguments":{"database":"analytics"}}`,
+		`{"name":"echo","arguments":{"database":"prod"},"arguments":{"database":"analytics"}}`,
+		`{"name":"echo","NAME":"echo","arguments":{"database":"analytics"}}`,
+		`{"name":"echo","arguments":{"database":"prod","database":"analytics"}}`,
+		`{"name":"echo","arguments":["analytics"]}`,
+	} {
+		s := minimalServer()
+		body := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":` + params + `}`
+		ex := newTestExchange(http.MethodPost, "/mcp", body, map[string]string{"Content-Type": "application/json"})
+		s.inspectFilter(ex)
+		ex.Policy = policy
+		ex.Identity = identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+		if got := s.authzFilter(ex); got != Reject || ex.Decision.Reason != rpcInvalidParamsReason {
+			t.Fatalf("authzFilter(%s) = %v %q, want Reject %s", params, got, ex.Decision.Reason, rpcInvalidParamsReason)
+		}
+		recorder := ex.W.ResponseWriter.(*httptest.ResponseRecorder)
+		var response rpcErrorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode response %q: %v", recorder.Body.String(), err)
+		}
+		if recorder.Code != http.StatusBadRequest || response.Error.Code != rpcInvalidParamsCode || string(response.ID) != "7" {
+			t.Fatalf("response = %d %+v, want 400 with a JSON-RPC invalid params error for id 7", recorder.Code, response)
+		}
+	}
+
+	batch := inspectRPCRequest(newBatchRequest(`[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"database":"prod"},"ARGUMENTS":{"database":"analytics"}}}]`))
+	if !batch.Indeterminate || batch.FailureReason != rpcInvalidParamsReason {
+		t.Fatalf("batch inspection = %+v, want indeterminate with %s", batch, rpcInvalidParamsReason)
+	}
+}
+
+func TestAuthzFilterRejectsAmbiguousResourceURI(t *testing.T) {
+	t.Parallel()
+
+	policy := headerPolicy()
+	policy.Session = nil
+	policy.Grants[0].ResourceRules = []policypkg.ResourceAccess{{URI: "docs://*", Decision: "allow"}}
+
+	for _, params := range []string{
+		`{"uri":"file:///etc/shadow","URI":"docs://ok"}`,
+		`{"URI":"docs://ok","uri":"file:///etc/shadow"}`,
+		`{"uri":"file:///etc/shadow","uri":"docs://ok"}`,
+	} {
+		s := minimalServer()
+		body := `{"jsonrpc":"2.0","id":3,"method":"resources/read","params":` + params + `}`
+		ex := newTestExchange(http.MethodPost, "/mcp", body, map[string]string{"Content-Type": "application/json"})
+		s.inspectFilter(ex)
+		ex.Policy = policy
+		ex.Identity = identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+		if got := s.authzFilter(ex); got != Reject || ex.Decision.Reason != rpcInvalidParamsReason {
+			t.Fatalf("authzFilter(%s) = %v %q, want Reject %s", params, got, ex.Decision.Reason, rpcInvalidParamsReason)
+		}
+	}
+
+	s := minimalServer()
+	ex := newTestExchange(http.MethodPost, "/mcp", `{"method":"resources/read","params":{"uri":"docs://ok"}}`, map[string]string{"Content-Type": "application/json"})
+	s.inspectFilter(ex)
+	if ex.Inspection.ResourceURI != "docs://ok" || ex.Inspection.Indeterminate {
+		t.Fatalf("inspection = %+v, want uri docs://ok", ex.Inspection)
+	}
+}
+
+func TestAuthzFilterAuthorizesPromptsAndResources(t *testing.T) {
+	t.Parallel()
+
+	policy := headerPolicy()
+	policy.Session = nil
+	policy.Grants[0].PromptRules = []policypkg.PromptAccess{{Name: "greeting", Decision: "allow"}}
+	policy.Grants[0].ResourceRules = []policypkg.ResourceAccess{{URI: "file:///docs/*", Decision: "allow"}}
+
+	for _, tc := range []struct {
+		body       string
+		want       Result
+		wantReason string
+	}{
+		{body: `{"method":"prompts/get","params":{"name":"greeting"}}`, want: Continue, wantReason: "allowed"},
+		{body: `{"method":"prompts/get","params":{"name":"admin"}}`, want: Reject, wantReason: "prompt_not_granted"},
+		{body: `{"method":"resources/read","params":{"uri":"file:///docs/a.md"}}`, want: Continue, wantReason: "allowed"},
+		{body: `{"method":"resources/subscribe","params":{"uri":"file:///etc/passwd"}}`, want: Reject, wantReason: "resource_not_granted"},
+	} {
+		s := minimalServer()
+		ex := newTestExchange(http.MethodPost, "/mcp", tc.body, map[string]string{"Content-Type": "application/json"})
+		s.inspectFilter(ex)
+		ex.Policy = policy
+		ex.Identity = identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+		if got := s.authzFilter(ex); got != tc.want || ex.Decision.Reason != tc.wantReason {
+			t.Fatalf("authzFilter(%s) = %v %q, want %v %q", tc.body, got, ex.Decision.Reason, tc.want, tc.wantReason)
+		}
+	}
+
+	s := minimalServer()
+	ex := newTestExchange(http.MethodPost, "/mcp", `{"method":"resources/read","params":{"uri":"file:///docs/a.md"}}`, map[string]string{"Content-Type": "application/json"})
+	s.inspectFilter(ex)
+	ex.Policy = policy
+	ex.Identity = identityContext{HumanID: "stranger"}
+	if got := s.authzFilter(ex); got != Reject || ex.Decision.Reason != "no_matching_grant" {
+		t.Fatalf("authzFilter(stranger) = %v %q, want Reject no_matching_grant", got, ex.Decision.Reason)
+	}
+}
+
 func TestAuthzFilterAuthorizationInputsUnchangedAfterDecision(t *testing.T) {
 	t.Parallel()
 	// Prove that upstreamFilter (stage 5) receives the same Policy and Identity
@@ -632,3 +797,47 @@ func TestErrPolicyUnavailableIsSentinel(t *testing.T) {
 		t.Fatal("errPolicyUnavailable.Error() is empty")
 	}
 }
+
+func TestAuthzFilterRejectsAmbiguousRequestMembers(t *testing.T) {
+	t.Parallel()
+
+	policy := headerPolicy()
+	policy.Session = nil
+
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"delete_db"},"Method":"ping"}`,
+		`{"jsonrpc":"2.0","id":7,"method":"ping","method":"tools/call","params":{"name":"delete_db"}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo"},"PARAMS":{"name":"delete_db"}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo"},"params":{"name":"delete_db"}}`,
+		`{"jsonrpc":"2.0","id":7,"ID":8,"method":"tools/call","params":{"name":"echo"}}`,
+		`{"jsonrpc":"2.0","JSONRPC":"1.0","id":7,"method":"tools/call","params":{"name":"echo"}}`,
+	} {
+		s := minimalServer()
+		ex := newTestExchange(http.MethodPost, "/mcp", body, map[string]string{"Content-Type": "application/json"})
+		s.inspectFilter(ex)
+		ex.Policy = policy
+		ex.Identity = identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+		if got := s.authzFilter(ex); got != Reject || ex.Decision.Reason != rpcInvalidRequestReason {
+			t.Fatalf("authzFilter(%s) = %v %q, want Reject %s", body, got, ex.Decision.Reason, rpcInvalidRequestReason)
+		}
+		recorder := ex.W.ResponseWriter.(*httptest.ResponseRecorder)
+		var response rpcErrorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode response %q: %v", recorder.Body.String(), err)
+		}
+		if recorder.Code != http.StatusBadRequest || response.Error.Code != rpcInvalidRequestCode || string(response.ID) != "null" {
+			t.Fatalf("response = %d %+v, want 400 with a JSON-RPC invalid request error", recorder.Code, response)
+		}
+	}
+
+	for _, body := range []string{
+		`[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"delete_db"},"Method":"ping"}]`,
+		`[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo"},"PARAMS":{"name":"delete_db"}}]`,
+	} {
+		batch := inspectRPCRequest(newBatchRequest(body))
+		if !batch.Indeterminate || batch.FailureReason != rpcInvalidRequestReason {
+			t.Fatalf("batch inspection of %s = %+v, want indeterminate with %s", body, batch, rpcInvalidRequestReason)
+		}
+	}
+}
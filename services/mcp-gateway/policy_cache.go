@@ -71,6 +71,9 @@ func (s *gatewayServer) reloadPolicy() error {
 	})
 	recordPolicyReloadSuccess(doc.Revision, doc.SchemaVersion, loadedAt)
 	s.metrics.recordPolicyReload(s.metricScope(doc), nil)
+	if s.sessionActivity != nil {
+		s.sessionActivity.Retain(doc.Sessions)
+	}
 	return nil
 }
 
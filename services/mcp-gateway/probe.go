@@ -0,0 +1,220 @@
+package main
+
+// probe.go serves /probe/mcp on the metrics port. The operator points the
+// server container's "mcp" probes at it: a TCP connect only shows the port is
+// open, while this runs the initialize → notifications/initialized → ping
+// handshake a client would and fails when any step does.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	mcpProbeRoute       = "/probe/mcp"
+	defaultMCPProbePath = "/mcp"
+	// mcpProbeTimeout caps one handshake; the kubelet usually gives up first.
+	mcpProbeTimeout       = 10 * time.Second
+	mcpProbeMaxBodyBytes  = 1 << 20
+	mcpProbeClientName    = "mcp-runtime-probe"
+	mcpProbeClientVersion = "1.0.0"
+	// mcpProbeProtocolVersion is offered in initialize; the server may answer
+	// with another version, which is then used for the rest of the handshake.
+	mcpProbeProtocolVersion = "2025-06-18"
+)
+
+type mcpProbe struct {
+	upstream *url.URL
+	client   *http.Client
+}
+
+func newMCPProbe(upstream *url.URL) *mcpProbe {
+	return &mcpProbe{upstream: upstream, client: &http.Client{Timeout: mcpProbeTimeout}}
+}
+
+// ServeHTTP returns 200 when the upstream completes the handshake at the path
+// given by the path query parameter, and 503 with the failing step otherwise.
+func (p *mcpProbe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	target, err := p.target(r.URL.Query().Get("path"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), mcpProbeTimeout)
+	defer cancel()
+	if err := p.check(ctx, target); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// target resolves the MCP endpoint path against the upstream. Only a path is
+// accepted, so the probe cannot be pointed at another host.
+func (p *mcpProbe) target(rawPath string) (string, error) {
+	rawPath = strings.TrimSpace(rawPath)
+	if rawPath == "" {
+		rawPath = defaultMCPProbePath
+	}
+	if !strings.HasPrefix(rawPath, "/") || strings.ContainsAny(rawPath, "?#") {
+		return "", fmt.Errorf("invalid probe path %q", rawPath)
+	}
+	target := *p.upstream
+	target.Path = path.Clean(rawPath)
+	target.RawPath = ""
+	target.RawQuery = ""
+	target.Fragment = ""
+	return target.String(), nil
+}
+
+type mcpProbeResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *mcpProbe) check(ctx context.Context, target string) error {
+	initialize, header, err := p.call(ctx, target, "", "", map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": mcpProbeProtocolVersion,
+			"capabilities":    map[string]any{},
+			"clientInfo":      map[string]string{"name": mcpProbeClientName, "version": mcpProbeClientVersion},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("initialize: %w", err)
+	}
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := json.Unmarshal(initialize.Result, &result); err != nil || result.ProtocolVersion == "" {
+		return errors.New("initialize: response has no protocolVersion")
+	}
+	sessionID := header.Get("Mcp-Session-Id")
+	if sessionID != "" {
+		defer p.endSession(target, sessionID, result.ProtocolVersion)
+	}
+
+	if _, _, err := p.call(ctx, target, sessionID, result.ProtocolVersion, map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "notifications/initialized",
+	}); err != nil {
+		return fmt.Errorf("notifications/initialized: %w", err)
+	}
+	if _, _, err := p.call(ctx, target, sessionID, result.ProtocolVersion, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "ping",
+	}); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	return nil
+}
+
+// call posts one JSON-RPC message. Notifications return a nil response.
+func (p *mcpProbe) call(ctx context.Context, target, sessionID, protocolVersion string, message map[string]any) (*mcpProbeResponse, http.Header, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+	if protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", protocolVersion)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("upstream returned %s", resp.Status)
+	}
+	if _, isRequest := message["id"]; !isRequest {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, mcpProbeMaxBodyBytes))
+		return nil, resp.Header, nil
+	}
+	decoded, err := decodeMCPProbeResponse(resp, mcpProbeMaxBodyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if decoded.Error != nil {
+		return nil, nil, fmt.Errorf("JSON-RPC error %d: %s", decoded.Error.Code, decoded.Error.Message)
+	}
+	if len(decoded.Result) == 0 {
+		return nil, nil, errors.New("response has no result")
+	}
+	return decoded, resp.Header, nil
+}
+
+// endSession deletes the probe's session so probes do not pile up sessions
+// on the server. Servers that do not support DELETE answer 405, which is fine.
+func (p *mcpProbe) endSession(target, sessionID, protocolVersion string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, target, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+	req.Header.Set("MCP-Protocol-Version", protocolVersion)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// decodeMCPProbeResponse reads up to limit bytes of a JSON or SSE response
+// and returns the first JSON-RPC response in it.
+func decodeMCPProbeResponse(resp *http.Response, limit int64) (*mcpProbeResponse, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/event-stream") {
+		for _, line := range strings.Split(string(body), "\n") {
+			line = strings.TrimRight(line, "\r")
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			var decoded mcpProbeResponse
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &decoded); err == nil && (decoded.Result != nil || decoded.Error != nil) {
+				return &decoded, nil
+			}
+		}
+		return nil, errors.New("no JSON-RPC response in event stream")
+	}
+	var decoded mcpProbeResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &decoded, nil
+}
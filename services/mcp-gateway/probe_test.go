@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// fakeMCPUpstream answers the probe handshake; ping fails when pingError is
+// set, and responses use SSE when sse is set.
+func fakeMCPUpstream(t *testing.T, sse bool, pingError bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rpc" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			calls = append(calls, "DELETE "+r.Header.Get("Mcp-Session-Id"))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var msg struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("decode probe message: %v", err)
+		}
+		calls = append(calls, msg.Method)
+		if msg.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		var payload string
+		switch msg.Method {
+		case "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			payload = `{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-03-26","capabilities":{}}}`
+		case "ping":
+			if r.Header.Get("Mcp-Session-Id") != "session-1" || r.Header.Get("MCP-Protocol-Version") != "2025-03-26" {
+				t.Errorf("ping headers = %v", r.Header)
+			}
+			payload = `{"jsonrpc":"2.0","id":2,"result":{}}`
+			if pingError {
+				payload = `{"jsonrpc":"2.0","id":2,"error":{"code":-32603,"message":"model not loaded"}}`
+			}
+		}
+		if sse {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("event: message\ndata: " + payload + "\n\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	return server, &calls
+}
+
+func probeRequest(t *testing.T, upstream string, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	target, err := url.Parse(upstream)
+	if err != nil {
+		t.Fatalf("parse upstream: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	newMCPProbe(target).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mcpProbeRoute+"?path="+url.QueryEscape(path), nil))
+	return rec
+}
+
+func TestMCPProbeHandshake(t *testing.T) {
+	for _, sse := range []bool{false, true} {
+		upstream, calls := fakeMCPUpstream(t, sse, false)
+		rec := probeRequest(t, upstream.URL, "/rpc")
+		upstream.Close()
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("sse=%v: status = %d (%s), want 200", sse, rec.Code, rec.Body.String())
+		}
+		if got := strings.Join(*calls, ","); got != "initialize,notifications/initialized,ping,DELETE session-1" {
+			t.Fatalf("sse=%v: calls = %s", sse, got)
+		}
+	}
+}
+
+func TestMCPProbeFailsOnPingError(t *testing.T) {
+	upstream, _ := fakeMCPUpstream(t, false, true)
+	defer upstream.Close()
+
+	rec := probeRequest(t, upstream.URL, "/rpc")
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "ping: JSON-RPC error -32603: model not loaded") {
+		t.Fatalf("response = %d %q, want 503 naming the ping failure", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMCPProbeFailsOnWrongPathOrDeadUpstream(t *testing.T) {
+	upstream, _ := fakeMCPUpstream(t, false, false)
+	rec := probeRequest(t, upstream.URL, "/mcp")
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "initialize: upstream returned 404") {
+		t.Fatalf("response = %d %q, want 503 from initialize", rec.Code, rec.Body.String())
+	}
+	upstream.Close()
+
+	rec = probeRequest(t, upstream.URL, "/rpc")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503 for a closed upstream", rec.Code)
+	}
+}
+
+func TestMCPProbeTargetRejectsNonPaths(t *testing.T) {
+	upstream, _ := url.Parse("http://127.0.0.1:8088")
+	probe := newMCPProbe(upstream)
+	if got, err := probe.target(""); err != nil || got != "http://127.0.0.1:8088/mcp" {
+		t.Fatalf("default target = %q, %v", got, err)
+	}
+	for _, path := range []string{"http://evil.example/mcp", "mcp", "/mcp?x=1"} {
+		if _, err := probe.target(path); err == nil {
+			t.Fatalf("target(%q) succeeded, want an error", path)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ func newUpstreamReverseProxy(target *url.URL) *httputil.ReverseProxy {
 			req.Out.Host = target.Host
 			req.SetXForwarded()
 		},
+		ModifyResponse: modifyUpstreamResponse,
 	}
 	return proxy
 }
@@ -45,7 +47,27 @@ func (s *gatewayServer) handleGateway(w http.ResponseWriter, r *http.Request) {
 			ex.W.status, time.Since(ex.StartTime), ex.R.ContentLength, ex.W.bytes,
 		)
 		if policyDecisionObserved {
-			s.metrics.recordPolicyDecision(metricScope, ex.Inspection.Method, ex.Decision)
+			if len(ex.BatchDecisions) > 0 {
+				for i, entry := range ex.Inspection.Batch {
+					if decision := ex.BatchDecisions[i]; entry.Governed || !decision.Allowed {
+						s.metrics.recordPolicyDecision(metricScope, entry.Method, decision)
+					}
+				}
+			} else {
+				s.metrics.recordPolicyDecision(metricScope, ex.Inspection.Method, ex.Decision)
+			}
+		}
+		if ex.ListFilter != nil {
+			s.metrics.recordListEntriesHidden(metricScope, ex.Inspection.Method, ex.ListFilter.hidden)
+		}
+		if len(ex.BatchDecisions) > 0 {
+			for i, entry := range ex.Inspection.Batch {
+				if outcome, ok := ex.responseOutcome(entry.ID, ex.BatchDecisions[i]); ok {
+					s.metrics.recordRPCOutcome(metricScope, ex.Policy, entry.Method, entry.ToolName, outcome)
+				}
+			}
+		} else if outcome, ok := ex.responseOutcome(ex.Inspection.ID, ex.Decision); ok {
+			s.metrics.recordRPCOutcome(metricScope, ex.Policy, ex.Inspection.Method, ex.Inspection.ToolName, outcome)
 		}
 	}()
 
@@ -57,7 +79,7 @@ func (s *gatewayServer) handleGateway(w http.ResponseWriter, r *http.Request) {
 	if ex.Decision.PolicyVersion == "" {
 		ex.Decision.PolicyVersion = s.defaultPolicyVersion
 	}
-	policyDecisionObserved = ex.Inspection.ToolCall || ex.Inspection.Indeterminate
+	policyDecisionObserved = ex.Inspection.Governed || ex.Inspection.Indeterminate
 	if !policyDecisionObserved && !ex.Decision.Allowed && !ex.SkipAudit {
 		policyDecisionObserved = true
 	}
@@ -77,13 +99,14 @@ func (s *gatewayServer) buildPipeline() []Filter {
 	}
 }
 
-// emitAuditFromExchange is stage 6 of the pipeline. It emits a single audit
-// event after the pipeline completes, preserving the following semantics from
+// emitAuditFromExchange is stage 6 of the pipeline. It emits the audit
+// event(s) after the pipeline completes, preserving the following semantics from
 // the previous monolithic handleGateway:
 //
 //   - Allowed requests: audit when rpcMethod is non-empty (actual RPC traffic).
 //   - Denied requests: audit when rpcMethod is non-empty OR the request was a
 //     genuine MCP client attempt (application/json body that failed parsing).
+//   - JSON-RPC batches: one event per entry, carrying that entry's decision.
 //   - Internal platform service probes (mcp-runtime-live-inventory) are never audited.
 func (s *gatewayServer) emitAuditFromExchange(ex *Exchange) {
 	if ex.SkipAudit {
@@ -92,6 +115,10 @@ func (s *gatewayServer) emitAuditFromExchange(ex *Exchange) {
 	if ex.Identity.AgentID == "mcp-runtime-live-inventory" {
 		return
 	}
+	if len(ex.Inspection.Batch) > 0 {
+		s.emitBatchAudit(ex)
+		return
+	}
 	rpcMethod := ex.Inspection.Method
 	if ex.Decision.Allowed {
 		if rpcMethod == "" {
@@ -100,10 +127,17 @@ func (s *gatewayServer) emitAuditFromExchange(ex *Exchange) {
 	} else if rpcMethod == "" && !ex.Inspection.IsRPCAttempt {
 		return
 	}
+	var extra map[string]any
+	if ex.ListFilter != nil {
+		extra = map[string]any{"list_entries_hidden": ex.ListFilter.hidden}
+	}
+	extra = withResourceURI(extra, ex.Inspection.ResourceURI)
+	extra = ex.withResponseOutcome(extra, ex.Inspection.ID, rpcMethod, ex.Decision)
 	s.emitAuditEvent(
 		ex.R, ex.OriginalPath, rpcMethod, ex.Inspection.ToolName,
 		ex.Identity, ex.Policy, ex.Decision,
 		ex.W.status, time.Since(ex.StartTime).Milliseconds(), ex.W.bytes,
+		extra,
 	)
 }
 
@@ -117,6 +151,34 @@ func (s *gatewayServer) writeDeniedResponse(ex *Exchange) {
 	}
 	status := gatewayDeniedStatus(ex.Policy, ex.Decision)
 	ex.Decision.Status = status
+	if isApprovalReason(ex.Decision.Reason) {
+		// Approval outcomes are answered as JSON-RPC errors that name the
+		// MCPToolApproval, so the caller can follow it and retry.
+		ex.W.WriteHeader(status)
+		_ = json.NewEncoder(ex.W).Encode(approvalRPCError(ex.Policy, ex.Decision, ex.Approval, ex.Inspection.ID))
+		return
+	}
+	if ex.Decision.Reason == rpcInvalidParamsReason {
+		// Params the gateway cannot read unambiguously are answered as a
+		// JSON-RPC error against the call rather than a policy denial.
+		ex.W.WriteHeader(status)
+		_ = json.NewEncoder(ex.W).Encode(invalidParamsRPCError(ex.Inspection.ID))
+		return
+	}
+	if ex.Decision.Reason == rpcInvalidRequestReason {
+		// The id of a request whose members are ambiguous is not trusted.
+		ex.W.WriteHeader(status)
+		_ = json.NewEncoder(ex.W).Encode(invalidRequestRPCError())
+		return
+	}
+	if ex.Decision.Reason == "rate_limited" {
+		// Rate limiting is answered as a JSON-RPC error so MCP clients can
+		// surface it against the call and back off.
+		ex.W.Header().Set("retry-after", strconv.Itoa(retryAfterSeconds(ex.Decision.RetryAfter)))
+		ex.W.WriteHeader(status)
+		_ = json.NewEncoder(ex.W).Encode(deniedRPCError(ex.Policy, ex.Decision, ex.Inspection.ID))
+		return
+	}
 	ex.W.WriteHeader(status)
 	_ = json.NewEncoder(ex.W).Encode(gatewayDeniedPayload(ex.Policy, ex.Decision))
 }
@@ -130,6 +192,9 @@ func gatewayDeniedStatus(policy *policypkg.Document, decision policypkg.Decision
 
 func gatewayDeniedPayload(policy *policypkg.Document, decision policypkg.Decision) map[string]any {
 	payload := map[string]any{"error": decision.Reason}
+	if decision.RetryAfter > 0 {
+		payload["retry_after_seconds"] = retryAfterSeconds(decision.RetryAfter)
+	}
 	if policypkg.PolicyUsesOAuth(policy) {
 		return payload
 	}
@@ -138,7 +203,7 @@ func gatewayDeniedPayload(policy *policypkg.Document, decision policypkg.Decisio
 		payload["message"] = "This MCP server uses MCP Runtime header/session governance. Direct clients must connect through the mcp-runtime adapter proxy or stdio adapter, or send an adapter-issued identity/session."
 		payload["adapter_required"] = true
 		payload["required_headers"] = governanceRequiredHeaders(policy)
-	case "missing_client_certificate", "invalid_spiffe_identity", "ambiguous_spiffe_identity", "untrusted_proxy", "missing_verified_identity", "session_not_found", "session_revoked", "session_expired":
+	case "missing_client_certificate", "invalid_spiffe_identity", "ambiguous_spiffe_identity", "untrusted_proxy", "missing_verified_identity", "session_not_found", "session_revoked", "session_expired", "session_max_lifetime", "session_idle_timeout":
 		if policy != nil && policy.Auth != nil && strings.EqualFold(policy.Auth.Mode, "mtls") {
 			payload["message"] = "This MCP server requires a platform-issued client certificate with a valid SPIFFE session identity."
 			payload["client_certificate_required"] = true
@@ -169,6 +234,19 @@ func governanceRequiredHeaders(policy *policypkg.Document) []string {
 	return []string{humanHeader, agentHeader, teamHeader, sessionHeader}
 }
 
+// withResourceURI adds the requested resource URI to an audit event's extra
+// fields. tool_name already carries the tool or prompt name.
+func withResourceURI(extra map[string]any, uri string) map[string]any {
+	if uri == "" {
+		return extra
+	}
+	if extra == nil {
+		extra = map[string]any{}
+	}
+	extra["resource_uri"] = uri
+	return extra
+}
+
 func (s *gatewayServer) emitAuditEvent(
 	r *http.Request,
 	path, rpcMethod, toolName string,
@@ -178,11 +256,16 @@ func (s *gatewayServer) emitAuditEvent(
 	status int,
 	latencyMs int64,
 	bytesOut int,
+	extra map[string]any,
 ) {
+	payload := s.auditPayload(r, path, rpcMethod, toolName, authCtx, policy, decision, status, latencyMs, bytesOut)
+	for key, value := range extra {
+		payload[key] = value
+	}
 	envelope, err := events.NewEnvelope(
 		s.source,
 		s.eventType,
-		s.auditPayload(r, path, rpcMethod, toolName, authCtx, policy, decision, status, latencyMs, bytesOut),
+		payload,
 		time.Now().UTC(),
 	)
 	if err != nil {
@@ -256,6 +339,18 @@ func (s *gatewayServer) auditPayload(
 	if decision.EffectiveTrust != "" {
 		payload["effective_trust"] = decision.EffectiveTrust
 	}
+	if decision.ViolatedArgument != "" {
+		payload["violated_argument"] = decision.ViolatedArgument
+	}
+	if decision.BaselineRule != "" {
+		payload["baseline_rule"] = decision.BaselineRule
+	}
+	if decision.ApprovalID != "" {
+		payload["approval_id"] = decision.ApprovalID
+		if decision.ApprovalDecidedBy != "" {
+			payload["approval_decided_by"] = decision.ApprovalDecidedBy
+		}
+	}
 	return payload
 }
 func absoluteRequestURL(r *http.Request, requestPath string) string {
@@ -362,13 +457,22 @@ func trimRequestPathPrefix(value, prefix string) (string, bool) {
 }
 func (r *statusRecorder) WriteHeader(status int) {
 	r.status = status
+	if r.capture != nil {
+		r.capture.start(r.Header())
+	}
 	r.ResponseWriter.WriteHeader(status)
 }
 
 // Write records response data and updates byte count.
 func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.capture != nil {
+		r.capture.start(r.Header())
+	}
 	n, err := r.ResponseWriter.Write(data)
 	r.bytes += n
+	if r.capture != nil {
+		r.capture.write(data[:n])
+	}
 	return n, err
 }
 
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// rateLimitStore holds the call counters behind grant rate limits and quotas.
+// The operator store shares the counters between gateway replicas; the
+// in-memory store counts what each replica sees on its own.
+type rateLimitStore interface {
+	// Increment adds one to the counter for key, creating it to expire after
+	// ttl, and returns the new count.
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Sum returns the total of the given counters. Missing or expired keys
+	// count as zero.
+	Sum(ctx context.Context, keys []string) (int64, error)
+}
+
+// newRateLimitStore returns the store selected by RATE_LIMIT_STORE. The
+// default is the operator store when the operator set RATE_LIMIT_URL, and the
+// in-memory store otherwise.
+func newRateLimitStore(kind, url string, count rateLimitCounter) (rateLimitStore, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = "memory"
+		if url != "" {
+			kind = "operator"
+		}
+	}
+	switch kind {
+	case "memory":
+		return newMemoryRateLimitStore(), nil
+	case "operator":
+		if url == "" {
+			return nil, fmt.Errorf("rate limit store %q requires RATE_LIMIT_URL", kind)
+		}
+		return &operatorRateLimitStore{count: count}, nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store %q", kind)
+	}
+}
+
+type memoryCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// memoryRateLimitStore is a process-local rateLimitStore. Expired counters are
+// swept at most once per minute while incrementing.
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	counters  map[string]memoryCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{counters: map[string]memoryCounter{}, now: time.Now}
+}
+
+func (m *memoryRateLimitStore) Increment(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= time.Minute {
+		for existing, counter := range m.counters {
+			if !now.Before(counter.expiresAt) {
+				delete(m.counters, existing)
+			}
+		}
+		m.lastSweep = now
+	}
+	counter, ok := m.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = memoryCounter{expiresAt: now.Add(ttl)}
+	}
+	counter.count++
+	m.counters[key] = counter
+	return counter.count, nil
+}
+
+func (m *memoryRateLimitStore) Sum(_ context.Context, keys []string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var total int64
+	for _, key := range keys {
+		if counter, ok := m.counters[key]; ok && now.Before(counter.expiresAt) {
+			total += counter.count
+		}
+	}
+	return total, nil
+}
+
+// rateLimitCounter sends one request to the operator's rate limit API and
+// returns the value it answers.
+type rateLimitCounter func(ctx context.Context, req rateLimitRequest) (int64, error)
+
+// operatorRateLimitStore keeps the counters in the operator, which scopes
+// them to this server, so every gateway replica of the server counts against
+// the same budgets.
+type operatorRateLimitStore struct {
+	count rateLimitCounter
+}
+
+func (o *operatorRateLimitStore) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return o.count(ctx, rateLimitRequest{Increment: key, TTLSeconds: int64(ttl / time.Second)})
+}
+
+func (o *operatorRateLimitStore) Sum(ctx context.Context, keys []string) (int64, error) {
+	return o.count(ctx, rateLimitRequest{Sum: keys})
+}
+
+// rateLimitRequest and rateLimitResponse are the operator's rate limit API.
+type rateLimitRequest struct {
+	Namespace  string   `json:"namespace"`
+	Server     string   `json:"server"`
+	Increment  string   `json:"increment,omitempty"`
+	TTLSeconds int64    `json:"ttl_seconds,omitempty"`
+	Sum        []string `json:"sum,omitempty"`
+}
+
+type rateLimitResponse struct {
+	Value int64 `json:"value"`
+}
+
+// countRateLimit is the operator store's rateLimitCounter. It POSTs to
+// RATE_LIMIT_URL as this server's gateway.
+func (s *gatewayServer) countRateLimit(ctx context.Context, request rateLimitRequest) (int64, error) {
+	request.Namespace = s.serverNamespace
+	request.Server = s.serverName
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.rateLimitURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setOperatorToken(req, s.operatorTokenFile); err != nil {
+		return 0, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, fmt.Errorf("rate limit endpoint returned %s", resp.Status)
+	}
+	var response rateLimitResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<12)).Decode(&response); err != nil {
+		return 0, err
+	}
+	return response.Value, nil
+}
+
+// applyRateLimits checks an allowed tool-call decision against the matched
+// grant's rate limits and returns a rate_limited denial when a budget is
+// exhausted. Every checked call counts, including calls that end up limited,
+// so a looping agent stays limited until it backs off. Per-minute limits use
+// fixed windows; daily quotas sum 24 hourly counters for a rolling day.
+func (s *gatewayServer) applyRateLimits(ctx context.Context, policy *policypkg.Document, identity identityContext, toolName string, decision policypkg.Decision) policypkg.Decision {
+	if !decision.Allowed || decision.MatchedGrant == "" {
+		return decision
+	}
+	grant, ok := policypkg.FindGrant(policy, decision.MatchedGrant, decision.MatchedGrantNamespace)
+	if !ok {
+		return decision
+	}
+	limits := policypkg.RateLimitsFor(grant, policypkg.ToolName(toolName), decision.RequiredSideEffect)
+	if len(limits) == 0 {
+		return decision
+	}
+	if s.rateLimits == nil {
+		return attributedDenial(decision, http.StatusServiceUnavailable, "rate_limit_unavailable", 0)
+	}
+
+	now := time.Now().UTC()
+	subject := strings.Join([]string{
+		string(grant.Namespace), grant.Name, identity.HumanID, identity.AgentID, identity.TeamID,
+	}, "\x00")
+	for _, limit := range limits {
+		scope := subject + "\x00" + string(limit.Tool) + "\x00" + policypkg.NormalizeSideEffect(limit.SideEffect)
+		if limit.CallsPerMinute > 0 {
+			window := now.Truncate(time.Minute)
+			count, err := s.rateLimits.Increment(ctx, scope+"\x00m"+strconv.FormatInt(window.Unix(), 10), 2*time.Minute)
+			if err != nil {
+				return attributedDenial(decision, http.StatusServiceUnavailable, "rate_limit_unavailable", 0)
+			}
+			if count > int64(limit.CallsPerMinute) {
+				return attributedDenial(decision, http.StatusTooManyRequests, "rate_limited", window.Add(time.Minute).Sub(now))
+			}
+		}
+		if limit.CallsPerDay > 0 {
+			hour := now.Truncate(time.Hour)
+			count, err := s.rateLimits.Increment(ctx, scope+"\x00h"+strconv.FormatInt(hour.Unix(), 10), 25*time.Hour)
+			if err != nil {
+				return attributedDenial(decision, http.StatusServiceUnavailable, "rate_limit_unavailable", 0)
+			}
+			previous := make([]string, 0, 23)
+			for i := 1; i < 24; i++ {
+				previous = append(previous, scope+"\x00h"+strconv.FormatInt(hour.Add(-time.Duration(i)*time.Hour).Unix(), 10))
+			}
+			earlier, err := s.rateLimits.Sum(ctx, previous)
+			if err != nil {
+				return attributedDenial(decision, http.StatusServiceUnavailable, "rate_limit_unavailable", 0)
+			}
+			if count+earlier > int64(limit.CallsPerDay) {
+				// The oldest hourly counter rolls out of the day at the next
+				// hour boundary, which is the earliest a call can fit again.
+				return attributedDenial(decision, http.StatusTooManyRequests, "rate_limited", hour.Add(time.Hour).Sub(now))
+			}
+		}
+	}
+	return decision
+}
+
+// attributedDenial turns a decision into a denial while keeping its
+// grant, session, and trust attribution for the audit trail.
+func attributedDenial(decision policypkg.Decision, status int, reason string, retryAfter time.Duration) policypkg.Decision {
+	decision.Allowed = false
+	decision.Status = status
+	decision.Reason = reason
+	decision.RetryAfter = retryAfter
+	return decision
+}
+
+// retryAfterSeconds rounds a Retry-After duration up to whole seconds.
+func retryAfterSeconds(retryAfter time.Duration) int {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+const echoToolCall = `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"echo"}}`
+
+func rateLimitedPolicy(limit policypkg.RateLimit) *policypkg.Document {
+	policy := headerPolicy()
+	policy.Grants[0].RateLimits = []policypkg.RateLimit{limit}
+	return policy
+}
+
+func okUpstream(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":7,"result":{}}`))
+}
+
+func TestMemoryRateLimitStoreExpiresCounters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	store := newMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for want := int64(1); want <= 2; want++ {
+		got, err := store.Increment(ctx, "a", time.Minute)
+		if err != nil || got != want {
+			t.Fatalf("Increment() = %d, %v; want %d", got, err, want)
+		}
+	}
+	if total, _ := store.Sum(ctx, []string{"a", "missing"}); total != 2 {
+		t.Fatalf("Sum() = %d, want 2", total)
+	}
+
+	now = now.Add(time.Minute)
+	if total, _ := store.Sum(ctx, []string{"a"}); total != 0 {
+		t.Fatalf("Sum() after expiry = %d, want 0", total)
+	}
+	if got, _ := store.Increment(ctx, "a", time.Minute); got != 1 {
+		t.Fatalf("Increment() after expiry = %d, want 1", got)
+	}
+}
+
+func TestNewRateLimitStoreRejectsUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	if store, err := newRateLimitStore("", "", nil); err != nil {
+		t.Fatalf("newRateLimitStore() error = %v", err)
+	} else if _, ok := store.(*memoryRateLimitStore); !ok {
+		t.Fatalf("newRateLimitStore() = %T, want the memory store without an operator URL", store)
+	}
+	if store, err := newRateLimitStore("", "http://operator/rate-limits", nil); err != nil {
+		t.Fatalf("newRateLimitStore() error = %v", err)
+	} else if _, ok := store.(*operatorRateLimitStore); !ok {
+		t.Fatalf("newRateLimitStore() = %T, want the operator store with an operator URL", store)
+	}
+	if _, err := newRateLimitStore("memory", "http://operator/rate-limits", nil); err != nil {
+		t.Fatalf("newRateLimitStore(memory) error = %v", err)
+	}
+	if _, err := newRateLimitStore("operator", "", nil); err == nil {
+		t.Fatal("newRateLimitStore(operator) error = nil, want the missing URL reported")
+	}
+	if _, err := newRateLimitStore("etcd", "", nil); err == nil {
+		t.Fatal("newRateLimitStore(etcd) error = nil, want unsupported store")
+	}
+}
+
+// fakeRateLimitOperator counts the way the operator's rate limit endpoint
+// does, scoping keys to the calling server.
+func fakeRateLimitOperator(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	counters := map[string]int64{}
+	operator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rateLimitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Server == "" {
+			http.Error(w, "invalid rate limit request", http.StatusBadRequest)
+			return
+		}
+		prefix := req.Namespace + "/" + req.Server + "\x00"
+		mu.Lock()
+		var response rateLimitResponse
+		if req.Increment != "" {
+			counters[prefix+req.Increment]++
+			response.Value = counters[prefix+req.Increment]
+		}
+		for _, key := range req.Sum {
+			response.Value += counters[prefix+key]
+		}
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(operator.Close)
+	return operator
+}
+
+func TestOperatorRateLimitStoreSharesBudgetsAcrossReplicas(t *testing.T) {
+	t.Parallel()
+
+	operator := fakeRateLimitOperator(t)
+	policy := rateLimitedPolicy(policypkg.RateLimit{Tool: "echo", CallsPerMinute: 3, CallsPerDay: 5})
+	replicas := make([]*gatewayServer, 2)
+	for i := range replicas {
+		replica := newTestGatewayServer(t, policy, okUpstream)
+		replica.serverNamespace, replica.serverName = "servers", "payments"
+		replica.rateLimitURL = operator.URL + "/rate-limits"
+		store, err := newRateLimitStore("", replica.rateLimitURL, replica.countRateLimit)
+		if err != nil {
+			t.Fatalf("newRateLimitStore() error = %v", err)
+		}
+		replica.rateLimits = store
+		replicas[i] = replica
+	}
+
+	call := func(replica int) int {
+		recorder := httptest.NewRecorder()
+		replicas[replica].handleGateway(recorder, newBatchRequest(echoToolCall))
+		return recorder.Code
+	}
+	for i, replica := range []int{0, 1, 0} {
+		if code := call(replica); code != http.StatusOK {
+			t.Fatalf("call %d on replica %d status = %d, want 200", i+1, replica, code)
+		}
+	}
+	// Each replica has seen at most two calls, but together they used the
+	// per-minute budget.
+	if code := call(1); code != http.StatusTooManyRequests {
+		t.Fatalf("fourth call status = %d, want 429 across replicas", code)
+	}
+
+	// Another server's gateways count on their own.
+	other := newTestGatewayServer(t, policy, okUpstream)
+	other.serverNamespace, other.serverName = "servers", "billing"
+	other.rateLimitURL = operator.URL + "/rate-limits"
+	other.rateLimits = &operatorRateLimitStore{count: other.countRateLimit}
+	recorder := httptest.NewRecorder()
+	other.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("other server status = %d, want 200", recorder.Code)
+	}
+}
+
+func TestOperatorRateLimitStoreFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	operator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limit leader unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(operator.Close)
+	proxy := newTestGatewayServer(t, rateLimitedPolicy(policypkg.RateLimit{Tool: "echo", CallsPerMinute: 3}), okUpstream)
+	proxy.serverNamespace, proxy.serverName = "servers", "payments"
+	proxy.rateLimitURL = operator.URL
+	proxy.rateLimits = &operatorRateLimitStore{count: proxy.countRateLimit}
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusServiceUnavailable || !strings.Contains(recorder.Body.String(), "rate_limit_unavailable") {
+		t.Fatalf("status = %d, body = %s; want rate_limit_unavailable 503", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleGatewayRateLimitsToolCallsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, rateLimitedPolicy(policypkg.RateLimit{Tool: "echo", CallsPerMinute: 2}), okUpstream)
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("call %d status = %d, want 200", i+1, recorder.Code)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Fatal("Retry-After header missing")
+	}
+	var response batchResponseEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", recorder.Body.String(), err)
+	}
+	if string(response.ID) != "7" || response.Error == nil || response.Error.Code != rpcDeniedErrorCode {
+		t.Fatalf("response = %s, want JSON-RPC error for id 7", recorder.Body.String())
+	}
+	if response.Error.Data["error"] != "rate_limited" || response.Error.Data["retry_after_seconds"] == nil {
+		t.Fatalf("error data = %#v, want rate_limited with retry_after_seconds", response.Error.Data)
+	}
+}
+
+func TestHandleGatewayRateLimitsAreScopedPerSubject(t *testing.T) {
+	t.Parallel()
+
+	policy := rateLimitedPolicy(policypkg.RateLimit{CallsPerMinute: 1})
+	policy.Grants[0].HumanID = ""
+	policy.Sessions[0].HumanID = ""
+	proxy := newTestGatewayServer(t, policy, okUpstream)
+
+	for _, human := range []string{"human-1", "human-2"} {
+		req := newBatchRequest(echoToolCall)
+		req.Header.Set(defaultHumanHeader, human)
+		recorder := httptest.NewRecorder()
+		proxy.handleGateway(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s status = %d, want 200 from its own budget", human, recorder.Code)
+		}
+	}
+}
+
+func TestApplyRateLimitsEnforcesDailyQuota(t *testing.T) {
+	t.Parallel()
+
+	policy := rateLimitedPolicy(policypkg.RateLimit{SideEffect: "read", CallsPerDay: 3})
+	server := &gatewayServer{rateLimits: newMemoryRateLimitStore()}
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedGrant = "grant-1"
+	allowed.RequiredSideEffect = "read"
+	identity := identityContext{HumanID: "human-1", AgentID: "client-1", TeamID: "team-acme"}
+
+	for i := 0; i < 3; i++ {
+		if decision := server.applyRateLimits(context.Background(), policy, identity, "echo", allowed); !decision.Allowed {
+			t.Fatalf("call %d decision = %#v, want allowed within quota", i+1, decision)
+		}
+	}
+	decision := server.applyRateLimits(context.Background(), policy, identity, "echo", allowed)
+	if decision.Allowed || decision.Reason != "rate_limited" || decision.Status != http.StatusTooManyRequests {
+		t.Fatalf("decision = %#v, want rate_limited 429", decision)
+	}
+	if decision.RetryAfter <= 0 || decision.RetryAfter > time.Hour {
+		t.Fatalf("RetryAfter = %v, want until the next hour boundary", decision.RetryAfter)
+	}
+	if decision.MatchedGrant != "grant-1" {
+		t.Fatalf("MatchedGrant = %q, want attribution kept", decision.MatchedGrant)
+	}
+}
+
+func TestApplyRateLimitsFailsClosedWithoutStore(t *testing.T) {
+	t.Parallel()
+
+	policy := rateLimitedPolicy(policypkg.RateLimit{CallsPerMinute: 10})
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedGrant = "grant-1"
+
+	decision := (&gatewayServer{}).applyRateLimits(context.Background(), policy, identityContext{}, "echo", allowed)
+	if decision.Allowed || decision.Reason != "rate_limit_unavailable" || decision.Status != http.StatusServiceUnavailable {
+		t.Fatalf("decision = %#v, want rate_limit_unavailable 503", decision)
+	}
+}
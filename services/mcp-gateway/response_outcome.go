@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// maxCapturedResponseBytes bounds how much of an upstream JSON response, or of
+// one SSE event, the gateway keeps to read the JSON-RPC outcome. Larger
+// responses are still streamed to the caller in full; their outcome is just
+// not recorded.
+const maxCapturedResponseBytes = 256 << 10
+
+// rpcOutcome is what the upstream answered to one JSON-RPC request.
+type rpcOutcome struct {
+	// ErrorCode is the JSON-RPC error code when HasError is set.
+	ErrorCode int
+	HasError  bool
+	// ToolIsError is result.isError of a tools/call result: the tool ran
+	// and reported a failure.
+	ToolIsError bool
+	// ResultBytes is the size of the result JSON, zero for an error.
+	ResultBytes int
+}
+
+// responseCapture keeps a bounded copy of a proxied JSON-RPC response and
+// extracts the outcome of each response message it carries. JSON bodies
+// (single responses and batches) are parsed once the proxy finishes; SSE
+// streams are parsed event by event as they are written, so a long stream
+// holds at most one event. Other content types and encoded bodies are not
+// captured.
+type responseCapture struct {
+	started  bool
+	disabled bool
+	sse      bool
+	body     []byte
+	// truncated is set when a JSON body or an SSE event outgrew the buffer
+	// and its outcome was dropped.
+	truncated bool
+	line      []byte
+	lineLong  bool
+	event     []byte
+	eventLong bool
+	outcomes  map[string]rpcOutcome
+}
+
+// start decides from the response headers whether the body can be read.
+func (c *responseCapture) start(header http.Header) {
+	if c.started {
+		return
+	}
+	c.started = true
+	if encoding := strings.TrimSpace(header.Get("Content-Encoding")); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		c.disabled = true
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+	case "text/event-stream":
+		c.sse = true
+	default:
+		c.disabled = true
+	}
+}
+
+func (c *responseCapture) write(data []byte) {
+	if c.disabled {
+		return
+	}
+	if !c.sse {
+		if len(c.body)+len(data) > maxCapturedResponseBytes {
+			c.truncated = true
+			c.disabled = true
+			c.body = nil
+			return
+		}
+		c.body = append(c.body, data...)
+		return
+	}
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n')
+		chunk := data
+		if end >= 0 {
+			chunk = data[:end+1]
+		}
+		data = data[len(chunk):]
+		if len(c.line)+len(chunk) > maxCapturedResponseBytes {
+			c.lineLong = true
+		} else if !c.lineLong {
+			c.line = append(c.line, chunk...)
+		}
+		if end >= 0 {
+			c.endLine()
+		}
+	}
+}
+
+func (c *responseCapture) endLine() {
+	line, long := c.line, c.lineLong
+	c.line, c.lineLong = c.line[:0], false
+	if !long && len(bytes.TrimRight(line, "\r\n")) == 0 {
+		c.endEvent()
+		return
+	}
+	if long {
+		// Only data lines can be this long in practice; drop the event.
+		c.eventLong = true
+		return
+	}
+	value, ok := sseDataValue(line)
+	if !ok {
+		return
+	}
+	if len(c.event)+len(value)+1 > maxCapturedResponseBytes {
+		c.eventLong = true
+		return
+	}
+	if len(c.event) > 0 {
+		c.event = append(c.event, '\n')
+	}
+	c.event = append(c.event, value...)
+}
+
+func (c *responseCapture) endEvent() {
+	if c.eventLong {
+		c.truncated = true
+	} else if len(c.event) > 0 {
+		c.observe(c.event)
+	}
+	c.event, c.eventLong = c.event[:0], false
+}
+
+// finish parses whatever the proxy left buffered: the JSON body, or the last
+// SSE event of a stream that ended without a blank line.
+func (c *responseCapture) finish() {
+	if c.disabled {
+		return
+	}
+	if c.sse {
+		if len(c.line) > 0 || c.lineLong {
+			c.endLine()
+		}
+		c.endEvent()
+		return
+	}
+	c.observe(c.body)
+	c.body = nil
+}
+
+// observe records the outcome of every response message in payload, a single
+// JSON-RPC message or a batch. Requests and notifications the server sends on
+// the stream are skipped.
+func (c *responseCapture) observe(payload []byte) {
+	payload = bytes.TrimSpace(payload)
+	var messages []json.RawMessage
+	if bytes.HasPrefix(payload, []byte("[")) {
+		if err := json.Unmarshal(payload, &messages); err != nil {
+			return
+		}
+	} else {
+		messages = []json.RawMessage{payload}
+	}
+	for _, raw := range messages {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Result json.RawMessage `json:"result"`
+			Error  *struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &message); err != nil || message.Method != "" {
+			continue
+		}
+		var outcome rpcOutcome
+		switch {
+		case message.Error != nil:
+			outcome.HasError = true
+			outcome.ErrorCode = message.Error.Code
+		case message.Result != nil:
+			outcome.ResultBytes = len(message.Result)
+			var result struct {
+				IsError bool `json:"isError"`
+			}
+			if json.Unmarshal(message.Result, &result) == nil {
+				outcome.ToolIsError = result.IsError
+			}
+		default:
+			continue
+		}
+		if c.outcomes == nil {
+			c.outcomes = map[string]rpcOutcome{}
+		}
+		c.outcomes[rpcIDKey(message.ID)] = outcome
+	}
+}
+
+// outcome returns the recorded outcome of the request with id.
+func (c *responseCapture) outcome(id json.RawMessage) (rpcOutcome, bool) {
+	if c == nil || len(id) == 0 {
+		return rpcOutcome{}, false
+	}
+	outcome, ok := c.outcomes[rpcIDKey(id)]
+	return outcome, ok
+}
+
+// rpcIDKey normalizes a raw JSON-RPC id so the request and response spellings
+// of the same id compare equal.
+func rpcIDKey(id json.RawMessage) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, id); err != nil {
+		return string(bytes.TrimSpace(id))
+	}
+	return compacted.String()
+}
+
+// responseOutcome returns the upstream outcome of the request with id, only
+// for calls the gateway allowed through.
+func (ex *Exchange) responseOutcome(id json.RawMessage, decision policypkg.Decision) (rpcOutcome, bool) {
+	if !decision.Allowed {
+		return rpcOutcome{}, false
+	}
+	return ex.W.capture.outcome(id)
+}
+
+// withResponseOutcome adds the upstream outcome of the request with id to an
+// audit event's extra fields: rpc_error_code for a JSON-RPC error, otherwise
+// result_bytes and, for tools/call, tool_is_error. A response too large to
+// read is marked response_truncated.
+func (ex *Exchange) withResponseOutcome(extra map[string]any, id json.RawMessage, rpcMethod string, decision policypkg.Decision) map[string]any {
+	outcome, ok := ex.responseOutcome(id, decision)
+	truncated := !ok && decision.Allowed && ex.W.capture != nil && ex.W.capture.truncated
+	if !ok && !truncated {
+		return extra
+	}
+	if extra == nil {
+		extra = map[string]any{}
+	}
+	switch {
+	case truncated:
+		extra["response_truncated"] = true
+	case outcome.HasError:
+		extra["rpc_error_code"] = outcome.ErrorCode
+	default:
+		extra["result_bytes"] = outcome.ResultBytes
+		if rpcMethod == "tools/call" {
+			extra["tool_is_error"] = outcome.ToolIsError
+		}
+	}
+	return extra
+}
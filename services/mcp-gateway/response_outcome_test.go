@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestResponseCaptureReadsOutcomes(t *testing.T) {
+	t.Parallel()
+
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+	capture := &responseCapture{}
+	capture.start(jsonHeader)
+	capture.write([]byte(`[{"jsonrpc":"2.0","id":1,"result":{"content":[]}},`))
+	capture.write([]byte(`{"jsonrpc":"2.0","id":"b","error":{"code":-32602,"message":"bad params"}}]`))
+	capture.finish()
+	if got, ok := capture.outcome(json.RawMessage(`1`)); !ok || got.HasError || got.ResultBytes != len(`{"content":[]}`) {
+		t.Fatalf("outcome(1) = %+v, %v, want a result", got, ok)
+	}
+	if got, ok := capture.outcome(json.RawMessage(` "b" `)); !ok || !got.HasError || got.ErrorCode != -32602 {
+		t.Fatalf(`outcome("b") = %+v, %v, want error -32602`, got, ok)
+	}
+
+	sse := &responseCapture{}
+	sse.start(http.Header{"Content-Type": []string{"text/event-stream"}})
+	stream := "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{}}\n\n" +
+		"data: {\"jsonrpc\":\"2.0\",\"id\":7,\n" +
+		"data: \"result\":{\"isError\":true}}\n"
+	for _, chunk := range []string{stream[:20], stream[20:90], stream[90:]} {
+		sse.write([]byte(chunk))
+	}
+	sse.finish()
+	if got, ok := sse.outcome(json.RawMessage(`7`)); !ok || !got.ToolIsError {
+		t.Fatalf("SSE outcome = %+v, %v, want a tool error from the multi-line event", got, ok)
+	}
+
+	large := &responseCapture{}
+	large.start(jsonHeader)
+	large.write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("x", maxCapturedResponseBytes) + `"}`))
+	large.finish()
+	if _, ok := large.outcome(json.RawMessage(`1`)); ok || !large.truncated {
+		t.Fatal("an oversized body was parsed, want it marked truncated")
+	}
+
+	encoded := &responseCapture{}
+	encoded.start(http.Header{"Content-Type": []string{"application/json"}, "Content-Encoding": []string{"gzip"}})
+	encoded.write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	encoded.finish()
+	if _, ok := encoded.outcome(json.RawMessage(`1`)); ok {
+		t.Fatal("an encoded body was parsed")
+	}
+}
+
+func TestAuditRecordsToolCallOutcome(t *testing.T) {
+	t.Parallel()
+
+	var captured atomic.Value
+	ingest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			Payload map[string]any `json:"payload"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		captured.Store(event.Payload)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(ingest.Close)
+
+	proxy := newTestGatewayServer(t, headerPolicy(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"jsonrpc\":\"2.0\",\"id\":7,\"result\":{\"content\":[],\"isError\":true}}\n\n")
+	})
+	registry := prometheus.NewRegistry()
+	proxy.metrics = newGatewayMetrics(registry)
+	proxy.httpClient = ingest.Client()
+	proxy.analyticsURL = ingest.URL
+	proxy.startAnalyticsDispatcher()
+
+	proxy.handleGateway(httptest.NewRecorder(), newBatchRequest(echoToolCall))
+	proxy.stopAnalyticsDispatcher()
+
+	payload, _ := captured.Load().(map[string]any)
+	if payload == nil {
+		t.Fatal("no audit event emitted for tools/call")
+	}
+	if payload["tool_is_error"] != true || payload["result_bytes"] != float64(len(`{"content":[],"isError":true}`)) {
+		t.Fatalf("payload = %#v, want tool_is_error and result_bytes", payload)
+	}
+	if _, ok := payload["rpc_error_code"]; ok {
+		t.Fatalf("rpc_error_code = %v, want it absent for a result", payload["rpc_error_code"])
+	}
+
+	if got := testutil.ToFloat64(proxy.metrics.toolCallResultsTotal.WithLabelValues("", "", "", "", "echo", "tool_error")); got != 1 {
+		t.Fatalf("tool_error results = %v, want 1", got)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -10,13 +12,56 @@ import (
 	policypkg "mcp-runtime/pkg/policy"
 )
 
+// rpcBatchMethod is the Inspection.Method recorded for a JSON-RPC batch. The
+// entries themselves are inspected individually into Inspection.Batch.
+const rpcBatchMethod = "batch"
+
+// maxRPCBatchEntries bounds how many entries a single batch may carry. Larger
+// batches are treated as indeterminate rather than authorized entry by entry.
+const maxRPCBatchEntries = 100
+
+// rpcInvalidParamsReason is the failure reason of a request whose params the
+// gateway cannot read unambiguously. It is answered as a JSON-RPC invalid
+// params error.
+const rpcInvalidParamsReason = "invalid_params"
+
+// rpcInvalidRequestReason is the failure reason of a request whose JSON-RPC
+// members the gateway cannot read unambiguously. It is answered as a JSON-RPC
+// invalid request error.
+const rpcInvalidRequestReason = "invalid_request"
+
+// rpcEnvelopeMembers are the JSON-RPC request members. A request may carry
+// each at most once and only in this exact case.
+var rpcEnvelopeMembers = []string{"jsonrpc", "id", "method", "params"}
+
+var (
+	// errRPCParamsNotObject is returned by decodeRPCObject for JSON that is
+	// not an object.
+	errRPCParamsNotObject = errors.New("not a JSON object")
+	// errRPCDuplicateKey is returned by decodeRPCObject for an object that
+	// repeats a key.
+	errRPCDuplicateKey = errors.New("duplicate key")
+	// errRPCAmbiguousRequest is returned by parseRPCRequest for a request
+	// that repeats a JSON-RPC member or carries a case variant of one.
+	errRPCAmbiguousRequest = errors.New("ambiguous JSON-RPC request")
+)
+
 type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
 	Method string          `json:"method"`
 	Params json.RawMessage `json:"params"`
 }
 
-type toolParams struct {
-	Name string `json:"name"`
+// rpcParams holds the params fields the gateway authorizes on: name and
+// arguments of tools/call and prompts/get, and uri of resources/read and
+// resources/subscribe.
+type rpcParams struct {
+	Name      string
+	Arguments map[string]any
+	// RawArguments is params.arguments exactly as sent, or nil when it is
+	// absent or null.
+	RawArguments json.RawMessage
+	URI          string
 }
 
 func inspectRPCRequest(r *http.Request) rpcInspection {
@@ -44,27 +89,193 @@ func inspectRPCRequest(r *http.Request) rpcInspection {
 	}
 	r.Body = io.NopCloser(bytes.NewReader(body))
 
-	var req rpcRequest
-	if err := json.Unmarshal(body, &req); err != nil {
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		return inspectRPCBatch(body)
+	}
+
+	req, err := parseRPCRequest(body)
+	if errors.Is(err, errRPCAmbiguousRequest) {
+		return rpcInspection{Indeterminate: true, FailureReason: rpcInvalidRequestReason, IsRPCAttempt: true}
+	}
+	if err != nil || strings.TrimSpace(req.Method) == "" {
 		return rpcInspection{Indeterminate: true, FailureReason: "rpc_inspection_failed", IsRPCAttempt: true}
 	}
-	if strings.TrimSpace(req.Method) == "" {
+
+	params, err := parseRPCParams(req.Params)
+	if err != nil {
+		return rpcInspection{ID: req.ID, Method: req.Method, Indeterminate: true, FailureReason: rpcInvalidParamsReason, IsRPCAttempt: true}
+	}
+	return rpcInspection{
+		ID:           req.ID,
+		Method:       req.Method,
+		ToolName:     params.Name,
+		Arguments:    params.Arguments,
+		RawArguments: params.RawArguments,
+		ResourceURI:  params.URI,
+		ToolCall:     policypkg.IsToolCallMethod(req.Method),
+		Governed:     policypkg.IsGovernedMethod(req.Method),
+	}
+}
+
+// inspectRPCBatch inspects every entry of a JSON-RPC batch. A batch that is
+// empty, too large, or contains any entry without a method (including client
+// responses) is indeterminate as a whole, matching single-request handling.
+func inspectRPCBatch(body []byte) rpcInspection {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil || len(raws) == 0 || len(raws) > maxRPCBatchEntries {
 		return rpcInspection{Indeterminate: true, FailureReason: "rpc_inspection_failed", IsRPCAttempt: true}
 	}
 
-	var toolName string
-	if len(req.Params) > 0 {
-		var params toolParams
-		if err := json.Unmarshal(req.Params, &params); err == nil {
-			toolName = params.Name
+	inspection := rpcInspection{Method: rpcBatchMethod, Batch: make([]rpcBatchEntry, 0, len(raws))}
+	for _, raw := range raws {
+		req, err := parseRPCRequest(raw)
+		if errors.Is(err, errRPCAmbiguousRequest) {
+			return rpcInspection{Indeterminate: true, FailureReason: rpcInvalidRequestReason, IsRPCAttempt: true}
+		}
+		if err != nil || strings.TrimSpace(req.Method) == "" {
+			return rpcInspection{Indeterminate: true, FailureReason: "rpc_inspection_failed", IsRPCAttempt: true}
 		}
+		params, err := parseRPCParams(req.Params)
+		if err != nil {
+			return rpcInspection{Indeterminate: true, FailureReason: rpcInvalidParamsReason, IsRPCAttempt: true}
+		}
+		entry := rpcBatchEntry{
+			ID:          req.ID,
+			Method:      req.Method,
+			ToolName:    params.Name,
+			Arguments:   params.Arguments,
+			ResourceURI: params.URI,
+			ToolCall:    policypkg.IsToolCallMethod(req.Method),
+			Governed:    policypkg.IsGovernedMethod(req.Method),
+			Raw:         raw,
+		}
+		inspection.ToolCall = inspection.ToolCall || entry.ToolCall
+		inspection.Governed = inspection.Governed || entry.Governed
+		inspection.Batch = append(inspection.Batch, entry)
 	}
+	return inspection
+}
 
-	return rpcInspection{
-		Method:   req.Method,
-		ToolName: toolName,
-		ToolCall: policypkg.IsToolCallMethod(req.Method),
+// parseRPCRequest decodes a JSON-RPC request object with exact keys, for the
+// same reason as parseRPCParams: encoding/json would read
+// {"method":"tools/call","Method":"ping"} as ping while a case-sensitive
+// upstream runs tools/call. A request that repeats a member, or carries a
+// case variant of one, fails with errRPCAmbiguousRequest.
+func parseRPCRequest(raw json.RawMessage) (rpcRequest, error) {
+	var req rpcRequest
+	members, err := decodeRPCObject(raw)
+	if errors.Is(err, errRPCDuplicateKey) {
+		return req, fmt.Errorf("%w: %v", errRPCAmbiguousRequest, err)
+	}
+	if err != nil {
+		return req, err
 	}
+	for key := range members {
+		for _, member := range rpcEnvelopeMembers {
+			if key != member && strings.EqualFold(key, member) {
+				return req, fmt.Errorf("%w: key %q is ambiguous with %q", errRPCAmbiguousRequest, key, member)
+			}
+		}
+	}
+	if raw, ok := members["method"]; ok {
+		if err := json.Unmarshal(raw, &req.Method); err != nil {
+			return rpcRequest{}, fmt.Errorf("method: %w", err)
+		}
+	}
+	req.ID = members["id"]
+	req.Params = members["params"]
+	return req, nil
+}
+
+// parseRPCParams extracts the fields of rpcParams. Keys match exactly, the
+// way a case-sensitive upstream reads them: encoding/json folds case and lets
+// a later duplicate win, so {"arguments":...,"Arguments":...} or
+// {"uri":...,"URI":...} would be authorized on one value and executed with
+// the other. Params that repeat a key, or carry a case variant of a key the
+// gateway authorizes on, are rejected, as are arguments that repeat a key.
+// Params that are not an object yield the zero value.
+func parseRPCParams(params json.RawMessage) (rpcParams, error) {
+	var parsed rpcParams
+	if len(params) == 0 {
+		return parsed, nil
+	}
+	members, err := decodeRPCObject(params)
+	if errors.Is(err, errRPCParamsNotObject) {
+		return parsed, nil
+	}
+	if err != nil {
+		return parsed, err
+	}
+	for key := range members {
+		for _, field := range []string{"name", "arguments", "uri"} {
+			if key != field && strings.EqualFold(key, field) {
+				return parsed, fmt.Errorf("params key %q is ambiguous with %q", key, field)
+			}
+		}
+	}
+	if raw, ok := members["name"]; ok {
+		if err := json.Unmarshal(raw, &parsed.Name); err != nil {
+			return rpcParams{}, fmt.Errorf("params name: %w", err)
+		}
+	}
+	if raw, ok := members["arguments"]; ok && !isJSONNull(raw) {
+		arguments, err := decodeRPCObject(raw)
+		if err != nil {
+			return rpcParams{}, fmt.Errorf("params arguments: %w", err)
+		}
+		parsed.RawArguments = raw
+		parsed.Arguments = make(map[string]any, len(arguments))
+		for key, value := range arguments {
+			var decoded any
+			if err := json.Unmarshal(value, &decoded); err != nil {
+				return rpcParams{}, fmt.Errorf("params arguments %q: %w", key, err)
+			}
+			parsed.Arguments[key] = decoded
+		}
+	}
+	if raw, ok := members["uri"]; ok {
+		if err := json.Unmarshal(raw, &parsed.URI); err != nil {
+			return rpcParams{}, fmt.Errorf("params uri: %w", err)
+		}
+	}
+	return parsed, nil
+}
+
+// decodeRPCObject decodes a JSON object into its members keyed by their exact
+// (unescaped) names. A key that appears twice is an error.
+func decodeRPCObject(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, errRPCParamsNotObject
+	}
+	members := map[string]json.RawMessage{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if _, dup := members[key]; dup {
+			return nil, fmt.Errorf("%w %q", errRPCDuplicateKey, key)
+		}
+		members[key] = value
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
 }
 
 // maxInt64 returns the maximum of two int64 values.
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+// sessionActivitySyncInterval is how often the operator store reports an
+// active session to the operator. It matches the operator's own interval for
+// recording activity, so idle timeouts hold across replicas to within it.
+const sessionActivitySyncInterval = time.Minute
+
+// sessionActivity is what the gateway records about one agent session.
+type sessionActivity struct {
+	CreatedAt    time.Time
+	LastActiveAt time.Time
+}
+
+// sessionActivityStore tracks when each agent session was first and last used
+// for the session idle timeout, and for the max lifetime of bindings rendered
+// without a creation time. The operator store shares activity between gateway
+// replicas; the in-memory store tracks what each replica sees on its own.
+type sessionActivityStore interface {
+	// Touch returns the activity recorded for binding before this call and
+	// whether a record existed. A new session is recorded as created and
+	// active at now. An existing session is marked active at now unless
+	// idleTimeout is positive and it has been idle longer than that, so an
+	// idle session stays expired instead of being revived by a retry. The
+	// record may be dropped after until; zero keeps it while the binding is
+	// in the policy.
+	Touch(ctx context.Context, binding policypkg.Binding, now time.Time, idleTimeout time.Duration, until time.Time) (sessionActivity, bool, error)
+	// Retain drops the records of sessions that are not among bindings, once
+	// a policy without them is loaded.
+	Retain(bindings []policypkg.Binding)
+}
+
+// newSessionActivityStore returns the store selected by SESSION_ACTIVITY_STORE.
+// The default is the operator store when the operator set
+// SESSION_ACTIVITY_URL, and the in-memory store otherwise.
+func newSessionActivityStore(kind, url string, report sessionActivityReporter) (sessionActivityStore, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = "memory"
+		if url != "" {
+			kind = "operator"
+		}
+	}
+	switch kind {
+	case "memory":
+		return newMemorySessionActivityStore(), nil
+	case "operator":
+		if url == "" {
+			return nil, fmt.Errorf("session activity store %q requires SESSION_ACTIVITY_URL", kind)
+		}
+		return &operatorSessionActivityStore{local: newMemorySessionActivityStore(), report: report}, nil
+	default:
+		return nil, fmt.Errorf("unsupported session activity store %q", kind)
+	}
+}
+
+// sessionActivityKey identifies a session by its binding, including its
+// creation and expiry, so a recreated or reissued binding starts a new
+// session.
+func sessionActivityKey(binding policypkg.Binding) string {
+	return strings.Join([]string{
+		string(binding.Namespace), string(binding.Name), string(binding.HumanID),
+		string(binding.AgentID), string(binding.TeamID), binding.CreatedAt, binding.ExpiresAt,
+	}, "\x00")
+}
+
+type memorySessionRecord struct {
+	sessionActivity
+	// until is when the record may be dropped; zero keeps it while bound.
+	until time.Time
+	// syncedAt is when the operator store last reported the session.
+	syncedAt time.Time
+}
+
+// memorySessionActivityStore is a process-local sessionActivityStore. Records
+// past their until time are swept at most once per minute while touching, and
+// records of bindings that left the policy are dropped by Retain.
+type memorySessionActivityStore struct {
+	mu        sync.Mutex
+	sessions  map[string]memorySessionRecord
+	lastSweep time.Time
+}
+
+func newMemorySessionActivityStore() *memorySessionActivityStore {
+	return &memorySessionActivityStore{sessions: map[string]memorySessionRecord{}}
+}
+
+func (m *memorySessionActivityStore) Touch(_ context.Context, binding policypkg.Binding, now time.Time, idleTimeout time.Duration, until time.Time) (sessionActivity, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, ok := m.touchLocked(sessionActivityKey(binding), now, idleTimeout, until, time.Time{})
+	return previous, ok, nil
+}
+
+// touchLocked implements Touch for key. lastSeen is activity recorded
+// elsewhere; when it is later than the local record it counts as the
+// session's previous activity.
+func (m *memorySessionActivityStore) touchLocked(key string, now time.Time, idleTimeout time.Duration, until, lastSeen time.Time) (sessionActivity, bool) {
+	if now.Sub(m.lastSweep) >= time.Minute {
+		for existing, record := range m.sessions {
+			if !record.until.IsZero() && now.After(record.until) {
+				delete(m.sessions, existing)
+			}
+		}
+		m.lastSweep = now
+	}
+	record, ok := m.sessions[key]
+	if lastSeen.After(record.LastActiveAt) {
+		if !ok {
+			record.CreatedAt = lastSeen
+		}
+		record.LastActiveAt = lastSeen
+		ok = true
+	}
+	previous := record.sessionActivity
+	switch {
+	case !ok:
+		record.sessionActivity = sessionActivity{CreatedAt: now, LastActiveAt: now}
+	case idleTimeout <= 0 || now.Sub(previous.LastActiveAt) <= idleTimeout:
+		if now.After(record.LastActiveAt) {
+			record.LastActiveAt = now
+		}
+	}
+	record.until = until
+	m.sessions[key] = record
+	return previous, ok
+}
+
+func (m *memorySessionActivityStore) Retain(bindings []policypkg.Binding) {
+	bound := make(map[string]struct{}, len(bindings))
+	for _, binding := range bindings {
+		bound[sessionActivityKey(binding)] = struct{}{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.sessions {
+		if _, ok := bound[key]; !ok {
+			delete(m.sessions, key)
+		}
+	}
+}
+
+// sessionActivityReporter reports a governed request for binding to the
+// operator and returns the session's last activity recorded there before it,
+// zero when none was.
+type sessionActivityReporter func(ctx context.Context, binding policypkg.Binding, idleTimeout time.Duration) (time.Time, error)
+
+// operatorSessionActivityStore shares session activity between gateway
+// replicas through the operator, which keeps it in MCPAgentSession
+// status.lastActiveAt. Each replica also records the activity it sees and
+// asks the operator only about sessions it has not seen, sessions that look
+// idle from here, and sessions it has not reported for
+// sessionActivitySyncInterval.
+type operatorSessionActivityStore struct {
+	local  *memorySessionActivityStore
+	report sessionActivityReporter
+}
+
+func (o *operatorSessionActivityStore) Touch(ctx context.Context, binding policypkg.Binding, now time.Time, idleTimeout time.Duration, until time.Time) (sessionActivity, bool, error) {
+	key := sessionActivityKey(binding)
+	o.local.mu.Lock()
+	record, ok := o.local.sessions[key]
+	if ok && now.Sub(record.syncedAt) < sessionActivitySyncInterval && (idleTimeout <= 0 || now.Sub(record.LastActiveAt) <= idleTimeout) {
+		// Activity this replica saw is recent enough on its own, and it
+		// was reported to the operator less than an interval ago.
+		previous, existed := o.local.touchLocked(key, now, idleTimeout, until, time.Time{})
+		o.local.mu.Unlock()
+		return previous, existed, nil
+	}
+	o.local.mu.Unlock()
+
+	lastSeen, err := o.report(ctx, binding, idleTimeout)
+	if err != nil {
+		return sessionActivity{}, false, err
+	}
+	o.local.mu.Lock()
+	defer o.local.mu.Unlock()
+	previous, existed := o.local.touchLocked(key, now, idleTimeout, until, lastSeen)
+	record = o.local.sessions[key]
+	record.syncedAt = now
+	o.local.sessions[key] = record
+	return previous, existed, nil
+}
+
+func (o *operatorSessionActivityStore) Retain(bindings []policypkg.Binding) {
+	o.local.Retain(bindings)
+}
+
+// sessionActivityRequest and sessionActivityResponse are the operator's
+// session activity API.
+type sessionActivityRequest struct {
+	Namespace          string `json:"namespace"`
+	Server             string `json:"server"`
+	SessionNamespace   string `json:"session_namespace"`
+	Session            string `json:"session"`
+	IdleTimeoutSeconds int64  `json:"idle_timeout_seconds,omitempty"`
+}
+
+type sessionActivityResponse struct {
+	LastActiveAt string `json:"last_active_at,omitempty"`
+}
+
+// reportSessionActivity is the operator store's sessionActivityReporter. It
+// POSTs to SESSION_ACTIVITY_URL as this server's gateway.
+func (s *gatewayServer) reportSessionActivity(ctx context.Context, binding policypkg.Binding, idleTimeout time.Duration) (time.Time, error) {
+	payload, err := json.Marshal(sessionActivityRequest{
+		Namespace:          s.serverNamespace,
+		Server:             s.serverName,
+		SessionNamespace:   string(binding.Namespace),
+		Session:            string(binding.Name),
+		IdleTimeoutSeconds: int64(idleTimeout / time.Second),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.sessionActivityURL, bytes.NewReader(payload))
+	if err != nil {
+		return time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setOperatorToken(req, s.operatorTokenFile); err != nil {
+		return time.Time{}, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return time.Time{}, fmt.Errorf("session activity endpoint returned %s", resp.Status)
+	}
+	var activity sessionActivityResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<12)).Decode(&activity); err != nil {
+		return time.Time{}, err
+	}
+	if activity.LastActiveAt == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, activity.LastActiveAt)
+}
+
+// applySessionLimits enforces the policy's session max lifetime and idle
+// timeout on a decision that resolved to a session binding. Every governed
+// request counts as activity, including denied ones, and the session limits
+// take precedence over the grant decision the same way an expired binding
+// does. The max lifetime runs from the binding's created_at, so every replica
+// agrees on it; bindings rendered without one fall back to when the store
+// first saw the session.
+func (s *gatewayServer) applySessionLimits(ctx context.Context, policy *policypkg.Document, decision policypkg.Decision, now time.Time) policypkg.Decision {
+	if decision.MatchedSession == "" {
+		return decision
+	}
+	maxLifetime, idleTimeout := policypkg.SessionLimits(policy)
+	if maxLifetime <= 0 && idleTimeout <= 0 {
+		return decision
+	}
+	binding, ok := findBinding(policy, decision.MatchedSession, decision.MatchedSessionNamespace)
+	if !ok {
+		return decision
+	}
+	createdAt, err := time.Parse(time.RFC3339, binding.CreatedAt)
+	hasCreatedAt := err == nil
+	if maxLifetime > 0 && hasCreatedAt && now.Sub(createdAt) > maxLifetime {
+		s.sessionMaxLifetimeDenials.Add(1)
+		return attributedDenial(decision, http.StatusUnauthorized, "session_max_lifetime", 0)
+	}
+	if idleTimeout <= 0 && hasCreatedAt {
+		return decision
+	}
+	if s.sessionActivity == nil {
+		return attributedDenial(decision, http.StatusServiceUnavailable, "session_store_unavailable", 0)
+	}
+
+	// The record is useless once the session can no longer be used.
+	var until time.Time
+	if expiresAt, err := time.Parse(time.RFC3339, binding.ExpiresAt); err == nil {
+		until = expiresAt
+	}
+	if maxLifetime > 0 && hasCreatedAt && (until.IsZero() || createdAt.Add(maxLifetime).Before(until)) {
+		until = createdAt.Add(maxLifetime)
+	}
+	activity, existed, err := s.sessionActivity.Touch(ctx, binding, now, idleTimeout, until)
+	if err != nil {
+		return attributedDenial(decision, http.StatusServiceUnavailable, "session_store_unavailable", 0)
+	}
+	if !existed {
+		return decision
+	}
+	if maxLifetime > 0 && !hasCreatedAt && now.Sub(activity.CreatedAt) > maxLifetime {
+		s.sessionMaxLifetimeDenials.Add(1)
+		return attributedDenial(decision, http.StatusUnauthorized, "session_max_lifetime", 0)
+	}
+	if idleTimeout > 0 && now.Sub(activity.LastActiveAt) > idleTimeout {
+		s.sessionIdleTimeoutDenials.Add(1)
+		return attributedDenial(decision, http.StatusUnauthorized, "session_idle_timeout", 0)
+	}
+	return decision
+}
+
+// findBinding returns the session binding with the given name and namespace.
+func findBinding(policy *policypkg.Document, name, namespace string) (policypkg.Binding, bool) {
+	if policy == nil {
+		return policypkg.Binding{}, false
+	}
+	for _, binding := range policy.Sessions {
+		if string(binding.Name) == name && string(binding.Namespace) == namespace {
+			return binding, true
+		}
+	}
+	return policypkg.Binding{}, false
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	policypkg "mcp-runtime/pkg/policy"
+)
+
+func sessionLimitedPolicy(maxLifetime, idleTimeout string) *policypkg.Document {
+	policy := headerPolicy()
+	policy.Session.MaxLifetime = maxLifetime
+	policy.Session.IdleTimeout = idleTimeout
+	return policy
+}
+
+func TestMemorySessionActivityStoreKeepsIdleSessionsExpired(t *testing.T) {
+	t.Parallel()
+
+	store := newMemorySessionActivityStore()
+	ctx := context.Background()
+	start := time.Unix(1_700_000_000, 0)
+	binding := policypkg.Binding{Name: "s"}
+
+	if _, existed, err := store.Touch(ctx, binding, start, time.Minute, time.Time{}); err != nil || existed {
+		t.Fatalf("first Touch() existed = %v, err = %v; want new session", existed, err)
+	}
+	activity, existed, _ := store.Touch(ctx, binding, start.Add(30*time.Second), time.Minute, time.Time{})
+	if !existed || !activity.LastActiveAt.Equal(start) || !activity.CreatedAt.Equal(start) {
+		t.Fatalf("second Touch() = %#v, %v; want the first activity", activity, existed)
+	}
+
+	late := start.Add(30*time.Second + 2*time.Minute)
+	for i := 0; i < 2; i++ {
+		activity, _, _ = store.Touch(ctx, binding, late.Add(time.Duration(i)*time.Second), time.Minute, time.Time{})
+		if !activity.LastActiveAt.Equal(start.Add(30 * time.Second)) {
+			t.Fatalf("Touch() after idle = %#v, want last activity frozen at the idle point", activity)
+		}
+	}
+}
+
+func TestMemorySessionActivityStoreEvictsEndedAndUnboundSessions(t *testing.T) {
+	t.Parallel()
+
+	store := newMemorySessionActivityStore()
+	ctx := context.Background()
+	start := time.Unix(1_700_000_000, 0)
+	ending := policypkg.Binding{Name: "ending"}
+	unbounded := policypkg.Binding{Name: "unbounded"}
+	unbound := policypkg.Binding{Name: "unbound"}
+
+	_, _, _ = store.Touch(ctx, ending, start, time.Hour, start.Add(time.Hour))
+	_, _, _ = store.Touch(ctx, unbounded, start, time.Hour, time.Time{})
+	_, _, _ = store.Touch(ctx, unbound, start, time.Hour, time.Time{})
+	// The next touch after the session ended sweeps it.
+	_, _, _ = store.Touch(ctx, unbounded, start.Add(2*time.Hour), time.Hour, time.Time{})
+	if _, ok := store.sessions[sessionActivityKey(ending)]; ok || len(store.sessions) != 2 {
+		t.Fatalf("sessions = %#v, want the ended session swept", store.sessions)
+	}
+
+	store.Retain([]policypkg.Binding{ending, unbounded})
+	if _, ok := store.sessions[sessionActivityKey(unbounded)]; !ok || len(store.sessions) != 1 {
+		t.Fatalf("sessions = %#v, want only the bound session kept", store.sessions)
+	}
+}
+
+func TestNewSessionActivityStoreRejectsUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	if store, err := newSessionActivityStore("", "", nil); err != nil {
+		t.Fatalf("newSessionActivityStore() error = %v", err)
+	} else if _, ok := store.(*memorySessionActivityStore); !ok {
+		t.Fatalf("newSessionActivityStore() = %T, want the memory store without an operator URL", store)
+	}
+	if store, err := newSessionActivityStore("", "http://operator/session-activity", nil); err != nil {
+		t.Fatalf("newSessionActivityStore() error = %v", err)
+	} else if _, ok := store.(*operatorSessionActivityStore); !ok {
+		t.Fatalf("newSessionActivityStore() = %T, want the operator store with an operator URL", store)
+	}
+	if _, err := newSessionActivityStore("memory", "http://operator/session-activity", nil); err != nil {
+		t.Fatalf("newSessionActivityStore(memory) error = %v", err)
+	}
+	if _, err := newSessionActivityStore("operator", "", nil); err == nil {
+		t.Fatal("newSessionActivityStore(operator) error = nil, want the missing URL reported")
+	}
+	if _, err := newSessionActivityStore("etcd", "", nil); err == nil {
+		t.Fatal("newSessionActivityStore(etcd) error = nil, want unsupported store")
+	}
+}
+
+func TestApplySessionLimitsDeniesIdleAndExpiredSessions(t *testing.T) {
+	t.Parallel()
+
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedSession = "session-1"
+	start := time.Unix(1_700_000_000, 0)
+
+	cases := []struct {
+		name   string
+		policy *policypkg.Document
+		steps  []time.Duration
+		reason string
+	}{
+		{"idle", sessionLimitedPolicy("", "1h"), []time.Duration{0, 30 * time.Minute, 2 * time.Hour}, "session_idle_timeout"},
+		{"max lifetime", sessionLimitedPolicy("2h", "1h"), []time.Duration{0, 50 * time.Minute, 100 * time.Minute, 150 * time.Minute}, "session_max_lifetime"},
+	}
+	for _, tc := range cases {
+		server := &gatewayServer{sessionActivity: newMemorySessionActivityStore()}
+		var decision policypkg.Decision
+		for i, step := range tc.steps {
+			decision = server.applySessionLimits(context.Background(), tc.policy, allowed, start.Add(step))
+			if last := i == len(tc.steps)-1; !last && !decision.Allowed {
+				t.Fatalf("%s: step %d decision = %#v, want allowed", tc.name, i, decision)
+			}
+		}
+		if decision.Allowed || decision.Reason != tc.reason || decision.Status != http.StatusUnauthorized {
+			t.Fatalf("%s: decision = %#v, want %s 401", tc.name, decision, tc.reason)
+		}
+		if decision.MatchedSession != "session-1" {
+			t.Fatalf("%s: MatchedSession = %q, want attribution kept", tc.name, decision.MatchedSession)
+		}
+		// A retry does not revive the session.
+		if retry := server.applySessionLimits(context.Background(), tc.policy, allowed, start.Add(tc.steps[len(tc.steps)-1]+time.Second)); retry.Reason != tc.reason {
+			t.Fatalf("%s: retry decision = %#v, want %s", tc.name, retry, tc.reason)
+		}
+	}
+}
+
+func TestApplySessionLimitsStartsReissuedBindingFresh(t *testing.T) {
+	t.Parallel()
+
+	policy := sessionLimitedPolicy("", "1h")
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedSession = "session-1"
+	server := &gatewayServer{sessionActivity: newMemorySessionActivityStore()}
+	start := time.Unix(1_700_000_000, 0)
+
+	server.applySessionLimits(context.Background(), policy, allowed, start)
+	policy.Sessions[0].ExpiresAt = start.Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	if decision := server.applySessionLimits(context.Background(), policy, allowed, start.Add(3*time.Hour)); !decision.Allowed {
+		t.Fatalf("decision = %#v, want a reissued binding to start a new session", decision)
+	}
+}
+
+func TestApplySessionLimitsMeasuresMaxLifetimeFromBindingCreation(t *testing.T) {
+	t.Parallel()
+
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedSession = "session-1"
+	now := time.Unix(1_700_000_000, 0)
+	policy := sessionLimitedPolicy("2h", "")
+
+	// A replica that never saw the session still ends it on time, and
+	// needs no activity store to do so.
+	server := &gatewayServer{}
+	policy.Sessions[0].CreatedAt = now.Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	if decision := server.applySessionLimits(context.Background(), policy, allowed, now); decision.Reason != "session_max_lifetime" {
+		t.Fatalf("decision = %#v, want session_max_lifetime for a session created 3h ago", decision)
+	}
+	policy.Sessions[0].CreatedAt = now.Add(-time.Hour).UTC().Format(time.RFC3339)
+	if decision := server.applySessionLimits(context.Background(), policy, allowed, now); !decision.Allowed {
+		t.Fatalf("decision = %#v, want a session created 1h ago allowed", decision)
+	}
+}
+
+// fakeSessionActivityOperator records activity the way the operator's
+// session activity endpoint does.
+type fakeSessionActivityOperator struct {
+	mu           sync.Mutex
+	now          time.Time
+	lastActiveAt time.Time
+	calls        int
+}
+
+func (f *fakeSessionActivityOperator) report(_ context.Context, _ policypkg.Binding, idleTimeout time.Duration) (time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	previous := f.lastActiveAt
+	if previous.IsZero() || (f.now.Sub(previous) >= time.Minute && f.now.Sub(previous) <= idleTimeout) {
+		f.lastActiveAt = f.now
+	}
+	return previous, nil
+}
+
+func TestOperatorSessionActivityStoreSharesActivityAcrossReplicas(t *testing.T) {
+	t.Parallel()
+
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedSession = "session-1"
+	policy := sessionLimitedPolicy("", "1h")
+	start := time.Unix(1_700_000_000, 0)
+	operator := &fakeSessionActivityOperator{}
+	replica := func() *gatewayServer {
+		store, err := newSessionActivityStore("", "http://operator/session-activity", operator.report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &gatewayServer{sessionActivity: store}
+	}
+	a, b := replica(), replica()
+	call := func(server *gatewayServer, at time.Duration) policypkg.Decision {
+		operator.mu.Lock()
+		operator.now = start.Add(at)
+		operator.mu.Unlock()
+		return server.applySessionLimits(context.Background(), policy, allowed, start.Add(at))
+	}
+
+	// Both replicas see the session, then only a serves it for two hours.
+	for _, step := range []struct {
+		server *gatewayServer
+		at     time.Duration
+	}{{a, 0}, {b, 0}, {a, 20 * time.Second}, {a, 40 * time.Minute}, {a, 80 * time.Minute}, {a, 120 * time.Minute}} {
+		if decision := call(step.server, step.at); !decision.Allowed {
+			t.Fatalf("call at %v decision = %#v, want allowed", step.at, decision)
+		}
+	}
+	if operator.calls != 5 {
+		t.Fatalf("operator calls = %d, want one per replica and sync interval", operator.calls)
+	}
+	// b last saw the session 2h ago, but a kept it active.
+	if decision := call(b, 130*time.Minute); !decision.Allowed {
+		t.Fatalf("decision = %#v, want the activity on the other replica to count", decision)
+	}
+	// Once it is idle for longer than the timeout, every replica ends it.
+	if decision := call(a, 4*time.Hour); decision.Reason != "session_idle_timeout" {
+		t.Fatalf("decision = %#v, want session_idle_timeout", decision)
+	}
+	if decision := call(b, 4*time.Hour+time.Minute); decision.Reason != "session_idle_timeout" {
+		t.Fatalf("decision = %#v, want session_idle_timeout on the other replica", decision)
+	}
+}
+
+func TestReportSessionActivityAuthenticatesAsGateway(t *testing.T) {
+	t.Parallel()
+
+	var request sessionActivityRequest
+	var authorization string
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(sessionActivityResponse{LastActiveAt: "2026-01-01T12:00:00Z"})
+	}))
+	t.Cleanup(endpoint.Close)
+	server := &gatewayServer{
+		httpClient:         endpoint.Client(),
+		serverName:         "payments",
+		serverNamespace:    "servers",
+		sessionActivityURL: endpoint.URL,
+		operatorTokenFile:  filepath.Join(t.TempDir(), "token"),
+	}
+	if err := os.WriteFile(server.operatorTokenFile, []byte("projected-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	lastActiveAt, err := server.reportSessionActivity(context.Background(), policypkg.Binding{Name: "sess-1", Namespace: "team-a"}, time.Hour)
+	if err != nil {
+		t.Fatalf("reportSessionActivity() error = %v", err)
+	}
+	if !lastActiveAt.Equal(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("last activity = %v", lastActiveAt)
+	}
+	want := sessionActivityRequest{Namespace: "servers", Server: "payments", SessionNamespace: "team-a", Session: "sess-1", IdleTimeoutSeconds: 3600}
+	if request != want || authorization != "Bearer projected-token" {
+		t.Fatalf("request = %+v with %q, want %+v as the gateway", request, authorization, want)
+	}
+}
+
+func TestApplySessionLimitsFailsClosedWithoutStore(t *testing.T) {
+	t.Parallel()
+
+	allowed := policypkg.Allow("allowed", "test-policy")
+	allowed.MatchedSession = "session-1"
+
+	decision := (&gatewayServer{}).applySessionLimits(context.Background(), sessionLimitedPolicy("24h", "1h"), allowed, time.Now())
+	if decision.Allowed || decision.Reason != "session_store_unavailable" || decision.Status != http.StatusServiceUnavailable {
+		t.Fatalf("decision = %#v, want session_store_unavailable 503", decision)
+	}
+}
+
+func TestHandleGatewayDeniesIdleSessionAndCountsIt(t *testing.T) {
+	t.Parallel()
+
+	proxy := newTestGatewayServer(t, sessionLimitedPolicy("24h", "1h"), okUpstream)
+	store := newMemorySessionActivityStore()
+	proxy.sessionActivity = store
+
+	recorder := httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("first call status = %d, want 200", recorder.Code)
+	}
+
+	// Age the recorded activity as another replica sharing the store would
+	// have seen it.
+	store.mu.Lock()
+	for key, activity := range store.sessions {
+		activity.LastActiveAt = activity.LastActiveAt.Add(-2 * time.Hour)
+		store.sessions[key] = activity
+	}
+	store.mu.Unlock()
+
+	recorder = httptest.NewRecorder()
+	proxy.handleGateway(recorder, newBatchRequest(echoToolCall))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("idle call status = %d, want 401", recorder.Code)
+	}
+	var response map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", recorder.Body.String(), err)
+	}
+	if response["error"] != "session_idle_timeout" {
+		t.Fatalf("response = %s, want session_idle_timeout", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	proxy.handleConfigStatus(recorder, httptest.NewRequest(http.MethodGet, "/config/status", nil))
+	var status configStatus
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", recorder.Body.String(), err)
+	}
+	if status.SessionLimits.IdleTimeoutDenials != 1 || status.SessionLimits.MaxLifetimeDenials != 0 {
+		t.Fatalf("session_limits = %#v, want one idle timeout denial", status.SessionLimits)
+	}
+}
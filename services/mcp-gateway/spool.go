@@ -0,0 +1,611 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSpoolMaxBytes caps the segment bytes a spool keeps on disk when
+	// ANALYTICS_SPOOL_MAX_BYTES is unset.
+	defaultSpoolMaxBytes = 256 << 20
+	// spoolSegmentMaxBytes caps one segment file. Segments are deleted once
+	// every record in them is delivered, so smaller segments free space sooner.
+	spoolSegmentMaxBytes = 8 << 20
+	// spoolSyncInterval is how often the interval fsync policy flushes the
+	// active segment.
+	spoolSyncInterval = time.Second
+	// spoolLeaseTTL is how long a slot lock stays valid without a refresh.
+	// A gateway that stopped without releasing its slot leaves it to another
+	// gateway on the same volume after this long.
+	spoolLeaseTTL = time.Minute
+	// spoolMaxSlots bounds the slot directories gateways sharing one volume
+	// can claim.
+	spoolMaxSlots = 64
+	// spoolReadBatch is how many records the sender reads per batch; the
+	// cursor is persisted after each batch.
+	spoolReadBatch = 64
+
+	spoolSegmentSuffix = ".seg"
+	spoolCursorFile    = "cursor"
+	spoolLockFile      = "owner.lock"
+)
+
+// Fsync policies for ANALYTICS_SPOOL_FSYNC.
+const (
+	// spoolFsyncAlways syncs every record before the request that produced
+	// it returns.
+	spoolFsyncAlways = "always"
+	// spoolFsyncInterval syncs the active segment every spoolSyncInterval;
+	// a node crash loses at most that window.
+	spoolFsyncInterval = "interval"
+	// spoolFsyncNever leaves flushing to the kernel. Records still survive a
+	// gateway crash, not a node crash.
+	spoolFsyncNever = "never"
+)
+
+var (
+	errSpoolFull   = errors.New("analytics spool is full")
+	errSpoolClosed = errors.New("analytics spool is closed")
+)
+
+// eventSpool is a write-ahead log of analytics events in one slot directory
+// of the spool volume. Events are appended to numbered segment files as
+// newline-delimited JSON, read back in order by the sender, and acknowledged
+// once the ingest service accepted or rejected them. The cursor file records
+// the first unacknowledged record, so events appended before a restart are
+// replayed by the next gateway that claims the slot. Delivery is
+// at-least-once: events delivered after the last persisted cursor are sent
+// again after a crash.
+type eventSpool struct {
+	dir          string
+	owner        string
+	maxBytes     int64
+	segmentBytes int64
+	fsync        string
+
+	mu sync.Mutex
+	// segments are ordered by sequence; the first holds the cursor and the
+	// last is the active segment while active is open.
+	segments []spoolSegment
+	active   *os.File
+	dirty    bool
+	readSeq  uint64
+	readOff  int64
+	// lastSeq is the highest segment sequence used, so a new segment never
+	// sorts before the cursor.
+	lastSeq uint64
+	// bytes is the size of all segment files, events the number of
+	// unacknowledged records.
+	bytes  int64
+	events int64
+	closed bool
+	// wake is signalled on every append so an idle sender reads again.
+	wake chan struct{}
+	// reader caches the open segment the sender reads from. It is only
+	// used by read, which like ack and commit is called from the sender
+	// goroutine alone.
+	reader    *os.File
+	readerSeq uint64
+}
+
+type spoolSegment struct {
+	seq  uint64
+	size int64
+}
+
+// spoolEntry is one record read from the spool. Corrupt records cannot be
+// decoded; they are acknowledged and dropped without delivery.
+type spoolEntry struct {
+	event   analyticsEvent
+	corrupt bool
+	seq     uint64
+	end     int64
+	size    int64
+}
+
+// openEventSpool claims a free slot directory under root and opens its spool.
+// A slot this host held before a restart is reclaimed first so its backlog
+// is replayed by the same pod when the volume is an emptyDir.
+func openEventSpool(root string, maxBytes int64, fsync string) (*eventSpool, error) {
+	switch fsync {
+	case spoolFsyncAlways, spoolFsyncInterval, spoolFsyncNever:
+	default:
+		return nil, fmt.Errorf("fsync policy must be %s, %s, or %s", spoolFsyncAlways, spoolFsyncInterval, spoolFsyncNever)
+	}
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("max bytes must be positive")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	owner, err := os.Hostname()
+	if err != nil || owner == "" {
+		owner = "mcp-gateway-" + strconv.Itoa(os.Getpid())
+	}
+	for _, reclaim := range []bool{true, false} {
+		for slot := 0; slot < spoolMaxSlots; slot++ {
+			dir := filepath.Join(root, "slot-"+strconv.Itoa(slot))
+			if !claimSpoolSlot(dir, owner, reclaim) {
+				continue
+			}
+			spool, err := loadEventSpool(dir, owner, maxBytes, fsync)
+			if err != nil {
+				_ = os.Remove(filepath.Join(dir, spoolLockFile))
+				return nil, err
+			}
+			return spool, nil
+		}
+	}
+	return nil, fmt.Errorf("no free spool slot in %s", root)
+}
+
+// adopt claims a slot another gateway on the volume left behind with events
+// still in it, so its backlog is delivered. It returns nil when there is
+// none.
+func (s *eventSpool) adopt() *eventSpool {
+	root := filepath.Dir(s.dir)
+	for slot := 0; slot < spoolMaxSlots; slot++ {
+		dir := filepath.Join(root, "slot-"+strconv.Itoa(slot))
+		if dir == s.dir || !spoolHasSegments(dir) || !claimSpoolSlot(dir, s.owner, false) {
+			continue
+		}
+		spool, err := loadEventSpool(dir, s.owner, s.maxBytes, s.fsync)
+		if err != nil {
+			_ = os.Remove(filepath.Join(dir, spoolLockFile))
+			continue
+		}
+		return spool
+	}
+	return nil
+}
+
+// claimSpoolSlot takes the slot lock in dir. With reclaim set it only takes
+// a lock this owner already holds; otherwise it takes a free lock or one
+// whose lease expired.
+func claimSpoolSlot(dir, owner string, reclaim bool) bool {
+	lockPath := filepath.Join(dir, spoolLockFile)
+	if reclaim {
+		current, err := os.ReadFile(lockPath)
+		if err != nil || strings.TrimSpace(string(current)) != owner {
+			return false
+		}
+		now := time.Now()
+		return os.Chtimes(lockPath, now, now) == nil
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return false
+	}
+	if info, err := os.Stat(lockPath); err == nil {
+		if time.Since(info.ModTime()) < spoolLeaseTTL {
+			return false
+		}
+		_ = os.Remove(lockPath)
+	}
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return false
+	}
+	_, err = lock.WriteString(owner + "\n")
+	if closeErr := lock.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false
+	}
+	// Two gateways can both find the same lock stale; only the one whose
+	// lock survived keeps the slot.
+	current, err := os.ReadFile(lockPath)
+	return err == nil && strings.TrimSpace(string(current)) == owner
+}
+
+func spoolHasSegments(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*"+spoolSegmentSuffix))
+	return len(matches) > 0
+}
+
+// loadEventSpool opens the spool in a claimed slot directory. Segments before
+// the cursor are deleted and a torn record at the end of a segment, left by a
+// crash mid-write, is truncated. New records go to a fresh segment.
+func loadEventSpool(dir, owner string, maxBytes int64, fsync string) (*eventSpool, error) {
+	s := &eventSpool{
+		dir:          dir,
+		owner:        owner,
+		maxBytes:     maxBytes,
+		segmentBytes: min(int64(spoolSegmentMaxBytes), max(maxBytes/4, 1)),
+		fsync:        fsync,
+		wake:         make(chan struct{}, 1),
+	}
+	seqs, err := s.segmentSeqs()
+	if err != nil {
+		return nil, err
+	}
+	s.readSeq, s.readOff = s.loadCursor()
+	s.lastSeq = s.readSeq
+	for _, seq := range seqs {
+		s.lastSeq = max(s.lastSeq, seq)
+		if seq < s.readSeq {
+			if err := os.Remove(s.segmentPath(seq)); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+		size, records, err := repairSpoolSegment(s.segmentPath(seq), s.readOffsetFor(seq))
+		if err != nil {
+			return nil, err
+		}
+		s.segments = append(s.segments, spoolSegment{seq: seq, size: size})
+		s.bytes += size
+		s.events += records
+	}
+	if len(s.segments) == 0 || s.segments[0].seq != s.readSeq {
+		s.readOff = 0
+		if len(s.segments) > 0 {
+			s.readSeq = s.segments[0].seq
+		}
+	} else if s.readOff > s.segments[0].size {
+		s.readOff = s.segments[0].size
+	}
+	analyticsSpoolBacklogBytes.Add(float64(s.backlogBytesLocked()))
+	analyticsSpoolBacklogEvents.Add(float64(s.events))
+	return s, nil
+}
+
+func (s *eventSpool) readOffsetFor(seq uint64) int64 {
+	if seq == s.readSeq {
+		return s.readOff
+	}
+	return 0
+}
+
+// repairSpoolSegment truncates a partial trailing record and counts the
+// complete records at or after offset.
+func repairSpoolSegment(path string, offset int64) (int64, int64, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = file.Close() }()
+	var (
+		buf      = make([]byte, 64<<10)
+		pos      int64
+		complete int64
+		records  int64
+	)
+	for {
+		n, err := file.Read(buf)
+		chunk := buf[:n]
+		for len(chunk) > 0 {
+			i := bytes.IndexByte(chunk, '\n')
+			if i < 0 {
+				pos += int64(len(chunk))
+				break
+			}
+			pos += int64(i + 1)
+			chunk = chunk[i+1:]
+			complete = pos
+			if pos > offset {
+				records++
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if pos != complete {
+		if err := file.Truncate(complete); err != nil {
+			return 0, 0, err
+		}
+	}
+	return complete, records, nil
+}
+
+func (s *eventSpool) segmentSeqs() ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), spoolSegmentSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		seq, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+func (s *eventSpool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolSegmentSuffix))
+}
+
+func (s *eventSpool) loadCursor() (uint64, int64) {
+	data, err := os.ReadFile(filepath.Join(s.dir, spoolCursorFile))
+	if err != nil {
+		return 0, 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	seq, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	offset, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || offset < 0 {
+		return 0, 0
+	}
+	return seq, offset
+}
+
+// append writes event to the active segment, rotating it when full. It
+// returns errSpoolFull when the spool reached its size cap.
+func (s *eventSpool) append(event analyticsEvent) error {
+	record, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	record = append(record, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errSpoolClosed
+	}
+	if s.bytes+int64(len(record)) > s.maxBytes {
+		return errSpoolFull
+	}
+	if s.active == nil || s.segments[len(s.segments)-1].size+int64(len(record)) > s.segmentBytes {
+		if err := s.rotateLocked(); err != nil {
+			return err
+		}
+	}
+	segment := &s.segments[len(s.segments)-1]
+	if _, err := s.active.Write(record); err != nil {
+		// Drop the partial record so the segment stays line aligned.
+		_ = s.active.Truncate(segment.size)
+		return err
+	}
+	if s.fsync == spoolFsyncAlways {
+		if err := s.active.Sync(); err != nil {
+			return err
+		}
+	} else {
+		s.dirty = true
+	}
+	segment.size += int64(len(record))
+	s.bytes += int64(len(record))
+	s.events++
+	analyticsSpoolBacklogBytes.Add(float64(len(record)))
+	analyticsSpoolBacklogEvents.Inc()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// rotateLocked seals the active segment and starts the next one.
+func (s *eventSpool) rotateLocked() error {
+	if s.active != nil {
+		if err := s.syncActiveLocked(); err != nil {
+			return err
+		}
+		if err := s.active.Close(); err != nil {
+			return err
+		}
+		s.active = nil
+	}
+	seq := s.lastSeq + 1
+	file, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	if s.fsync != spoolFsyncNever {
+		syncSpoolDir(s.dir)
+	}
+	s.active = file
+	s.lastSeq = seq
+	s.segments = append(s.segments, spoolSegment{seq: seq})
+	if len(s.segments) == 1 {
+		s.readSeq, s.readOff = seq, 0
+	}
+	return nil
+}
+
+func (s *eventSpool) syncActiveLocked() error {
+	if s.active == nil || !s.dirty || s.fsync == spoolFsyncNever {
+		return nil
+	}
+	s.dirty = false
+	return s.active.Sync()
+}
+
+// read returns up to limit records after the cursor. It reads only whole
+// records already appended, and moves past segments that are fully read and
+// sealed, deleting them.
+func (s *eventSpool) read(limit int) ([]spoolEntry, error) {
+	s.mu.Lock()
+	for len(s.segments) > 0 && s.readOff >= s.segments[0].size && (len(s.segments) > 1 || s.active == nil) {
+		if err := s.dropSegmentLocked(); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+	if len(s.segments) == 0 || s.readOff >= s.segments[0].size {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	seq, offset, size := s.readSeq, s.readOff, s.segments[0].size
+	s.mu.Unlock()
+
+	if s.reader == nil || s.readerSeq != seq {
+		if s.reader != nil {
+			_ = s.reader.Close()
+		}
+		file, err := os.Open(s.segmentPath(seq))
+		if err != nil {
+			s.reader = nil
+			return nil, err
+		}
+		s.reader, s.readerSeq = file, seq
+	}
+	lines := bufio.NewReader(io.NewSectionReader(s.reader, offset, size-offset))
+	var entries []spoolEntry
+	for len(entries) < limit {
+		line, err := lines.ReadBytes('\n')
+		if len(line) == 0 || err != nil {
+			if err != nil && !errors.Is(err, io.EOF) {
+				return entries, err
+			}
+			break
+		}
+		offset += int64(len(line))
+		entry := spoolEntry{seq: seq, end: offset, size: int64(len(line))}
+		if json.Unmarshal(line, &entry.event) != nil {
+			entry.corrupt = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// dropSegmentLocked deletes the fully read first segment and moves the
+// cursor to the next one.
+func (s *eventSpool) dropSegmentLocked() error {
+	first := s.segments[0]
+	if s.reader != nil && s.readerSeq == first.seq {
+		_ = s.reader.Close()
+		s.reader = nil
+	}
+	if err := os.Remove(s.segmentPath(first.seq)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.segments = s.segments[1:]
+	s.bytes -= first.size
+	s.readSeq, s.readOff = first.seq+1, 0
+	if len(s.segments) > 0 {
+		s.readSeq = s.segments[0].seq
+	}
+	return s.saveCursorLocked()
+}
+
+// ack moves the cursor past entry. The cursor is persisted by commit.
+func (s *eventSpool) ack(entry spoolEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry.seq != s.readSeq || entry.end <= s.readOff {
+		return
+	}
+	s.readOff = entry.end
+	s.events--
+	analyticsSpoolBacklogBytes.Sub(float64(entry.size))
+	analyticsSpoolBacklogEvents.Dec()
+}
+
+// commit persists the cursor.
+func (s *eventSpool) commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveCursorLocked()
+}
+
+func (s *eventSpool) saveCursorLocked() error {
+	path := filepath.Join(s.dir, spoolCursorFile)
+	tmp := path + ".tmp"
+	data := []byte(strconv.FormatUint(s.readSeq, 10) + " " + strconv.FormatInt(s.readOff, 10) + "\n")
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// backlog reports the bytes and records not yet acknowledged.
+func (s *eventSpool) backlog() (int64, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backlogBytesLocked(), s.events
+}
+
+func (s *eventSpool) backlogBytesLocked() int64 {
+	return s.bytes - s.readOff
+}
+
+// maintain runs the interval fsync and refreshes the slot lease.
+func (s *eventSpool) maintain(refreshLease bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.fsync == spoolFsyncInterval {
+		if err := s.syncActiveLocked(); err != nil {
+			s.dirty = true
+		}
+	}
+	if refreshLease {
+		now := time.Now()
+		_ = os.Chtimes(filepath.Join(s.dir, spoolLockFile), now, now)
+	}
+}
+
+// close syncs and closes the spool and releases its slot. With discard set
+// the remaining segments and the cursor are deleted as well, for an adopted
+// slot that was drained.
+func (s *eventSpool) close(discard bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	var errs []error
+	if s.active != nil {
+		errs = append(errs, s.syncActiveLocked(), s.active.Close())
+		s.active = nil
+	}
+	if s.reader != nil {
+		_ = s.reader.Close()
+		s.reader = nil
+	}
+	errs = append(errs, s.saveCursorLocked())
+	analyticsSpoolBacklogBytes.Sub(float64(s.backlogBytesLocked()))
+	analyticsSpoolBacklogEvents.Sub(float64(s.events))
+	if discard && s.events == 0 {
+		for _, segment := range s.segments {
+			_ = os.Remove(s.segmentPath(segment.seq))
+		}
+		_ = os.Remove(filepath.Join(s.dir, spoolCursorFile))
+	}
+	errs = append(errs, os.Remove(filepath.Join(s.dir, spoolLockFile)))
+	return errors.Join(errs...)
+}
+
+// syncSpoolDir flushes directory entries so a new segment survives a crash.
+func syncSpoolDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"mcp-runtime/pkg/events"
+)
+
+func spoolTestEvent(source string) analyticsEvent {
+	return analyticsEvent{Envelope: events.Envelope{Source: source, EventType: "mcp.request", Payload: json.RawMessage(`{}`)}}
+}
+
+func TestEventSpoolReplaysAfterReopen(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	spool, err := openEventSpool(root, 1<<20, spoolFsyncAlways)
+	if err != nil {
+		t.Fatalf("openEventSpool() error = %v", err)
+	}
+	for _, source := range []string{"a", "b", "c"} {
+		if err := spool.append(spoolTestEvent(source)); err != nil {
+			t.Fatalf("append(%s) error = %v", source, err)
+		}
+	}
+	entries, err := spool.read(1)
+	if err != nil || len(entries) != 1 || entries[0].event.Envelope.Source != "a" {
+		t.Fatalf("read(1) = %+v, %v, want event a", entries, err)
+	}
+	spool.ack(entries[0])
+	if err := spool.commit(); err != nil {
+		t.Fatalf("commit() error = %v", err)
+	}
+	segment := spool.segmentPath(entries[0].seq)
+	if err := spool.close(false); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+
+	// A crash mid-write leaves a partial record at the end of the segment.
+	file, err := os.OpenFile(segment, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.WriteString(`{"envelope":{"source":"torn"`)
+	_ = file.Close()
+
+	reopened, err := openEventSpool(root, 1<<20, spoolFsyncAlways)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	t.Cleanup(func() { _ = reopened.close(false) })
+	if reopened.dir != spool.dir {
+		t.Fatalf("reopened slot %s, want the slot this host held (%s)", reopened.dir, spool.dir)
+	}
+	if _, backlogEvents := reopened.backlog(); backlogEvents != 2 {
+		t.Fatalf("backlog events = %d, want 2", backlogEvents)
+	}
+	if err := reopened.append(spoolTestEvent("d")); err != nil {
+		t.Fatalf("append(d) error = %v", err)
+	}
+	var sources []string
+	for {
+		entries, err := reopened.read(spoolReadBatch)
+		if err != nil {
+			t.Fatalf("read() error = %v", err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			if entry.corrupt {
+				t.Fatalf("entry at %d is corrupt, want the torn record truncated", entry.end)
+			}
+			sources = append(sources, entry.event.Envelope.Source)
+			reopened.ack(entry)
+		}
+	}
+	if got := filepath.Base(segment); len(sources) != 3 || sources[0] != "b" || sources[1] != "c" || sources[2] != "d" {
+		t.Fatalf("replayed %v from %s, want [b c d]", sources, got)
+	}
+	if _, err := os.Stat(segment); !os.IsNotExist(err) {
+		t.Fatalf("consumed segment still on disk: %v", err)
+	}
+}
+
+func TestEventSpoolDropsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	spool, err := openEventSpool(t.TempDir(), 256, spoolFsyncNever)
+	if err != nil {
+		t.Fatalf("openEventSpool() error = %v", err)
+	}
+	proxy := &gatewayServer{analyticsURL: "http://analytics.example.com", spool: spool}
+	before := testutil.ToFloat64(analyticsEventsDroppedTotal.WithLabelValues("spool_full"))
+	for i := 0; i < 10; i++ {
+		proxy.emitIfEnabled(t.Context(), events.Envelope{Source: "gateway", EventType: "mcp.request", Payload: json.RawMessage(`{}`)})
+	}
+	proxy.stopAnalyticsDispatcher()
+
+	if spool.bytes > 256 {
+		t.Fatalf("spool holds %d bytes, want at most its 256 byte cap", spool.bytes)
+	}
+	if proxy.analyticsDropped.Load() == 0 || testutil.ToFloat64(analyticsEventsDroppedTotal.WithLabelValues("spool_full"))-before == 0 {
+		t.Fatal("no spool_full drops recorded for a full spool")
+	}
+	if err := spool.append(spoolTestEvent("late")); !errors.Is(err, errSpoolClosed) {
+		t.Fatalf("append after stop error = %v, want errSpoolClosed", err)
+	}
+}
+
+func TestSpoolSenderRetriesUntilIngestAccepts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+		received []string
+	)
+	ingest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event events.Envelope
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case event.Source == "invalid":
+			w.WriteHeader(http.StatusBadRequest)
+		case attempts < 2:
+			attempts++
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			received = append(received, event.Source)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	t.Cleanup(ingest.Close)
+
+	spool, err := openEventSpool(t.TempDir(), 1<<20, spoolFsyncInterval)
+	if err != nil {
+		t.Fatalf("openEventSpool() error = %v", err)
+	}
+	proxy := &gatewayServer{analyticsURL: ingest.URL, httpClient: ingest.Client(), spool: spool}
+	proxy.startAnalyticsDispatcher()
+	for _, source := range []string{"first", "invalid", "second"} {
+		proxy.emitIfEnabled(t.Context(), events.Envelope{Source: source, EventType: "mcp.request", Payload: json.RawMessage(`{}`)})
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, backlogEvents := spool.backlog(); backlogEvents == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("spool backlog was not delivered")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	proxy.stopAnalyticsDispatcher()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0] != "first" || received[1] != "second" {
+		t.Fatalf("ingest received %v, want [first second] in order", received)
+	}
+	if attempts != 2 {
+		t.Fatalf("failed attempts = %d, want 2 retried deliveries", attempts)
+	}
+}
+
+func TestEventSpoolAdoptsAbandonedSlot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	abandonedDir := filepath.Join(root, "slot-3")
+	if !claimSpoolSlot(abandonedDir, "gateway-gone", false) {
+		t.Fatal("claimSpoolSlot() = false for a free slot")
+	}
+	abandoned, err := loadEventSpool(abandonedDir, "gateway-gone", 1<<20, spoolFsyncNever)
+	if err != nil {
+		t.Fatalf("loadEventSpool() error = %v", err)
+	}
+	if err := abandoned.append(spoolTestEvent("orphan")); err != nil {
+		t.Fatalf("append() error = %v", err)
+	}
+	_ = abandoned.active.Close()
+
+	spool, err := openEventSpool(root, 1<<20, spoolFsyncNever)
+	if err != nil {
+		t.Fatalf("openEventSpool() error = %v", err)
+	}
+	t.Cleanup(func() { _ = spool.close(false) })
+	if adopted := spool.adopt(); adopted != nil {
+		t.Fatalf("adopted %s while its owner's lease is live", adopted.dir)
+	}
+
+	stale := time.Now().Add(-2 * spoolLeaseTTL)
+	if err := os.Chtimes(filepath.Join(abandonedDir, spoolLockFile), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	adopted := spool.adopt()
+	if adopted == nil {
+		t.Fatal("adopt() = nil, want the slot whose lease expired")
+	}
+	entries, err := adopted.read(spoolReadBatch)
+	if err != nil || len(entries) != 1 || entries[0].event.Envelope.Source != "orphan" {
+		t.Fatalf("adopted read = %+v, %v, want the orphaned event", entries, err)
+	}
+	adopted.ack(entries[0])
+	if entries, _ := adopted.read(spoolReadBatch); len(entries) != 0 {
+		t.Fatalf("adopted read after ack = %+v, want none", entries)
+	}
+	if err := adopted.close(true); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+	if spoolHasSegments(abandonedDir) {
+		t.Fatal("drained adopted slot still has segments")
+	}
+}
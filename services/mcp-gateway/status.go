@@ -4,18 +4,30 @@ import (
 	"net/http"
 	"time"
 
+	policypkg "mcp-runtime/pkg/policy"
 	"mcp-runtime/pkg/serviceutil"
 )
 
 // configStatus is the sanitized view of the active policy snapshot exposed at
 // /config/status. It deliberately omits the policy body (grants, sessions,
-// identities) and reports only contract metadata and reload state.
+// identities) and reports only contract metadata, reload state, and session
+// limit counters.
 type configStatus struct {
-	Ready           bool   `json:"ready"`
-	SchemaVersion   string `json:"schema_version,omitempty"`
-	Revision        string `json:"revision,omitempty"`
-	LoadedAt        string `json:"loaded_at,omitempty"`
-	LastReloadError string `json:"last_reload_error,omitempty"`
+	Ready           bool                `json:"ready"`
+	SchemaVersion   string              `json:"schema_version,omitempty"`
+	Revision        string              `json:"revision,omitempty"`
+	LoadedAt        string              `json:"loaded_at,omitempty"`
+	LastReloadError string              `json:"last_reload_error,omitempty"`
+	SessionLimits   sessionLimitsStatus `json:"session_limits"`
+}
+
+// sessionLimitsStatus reports the session limits in effect and how many
+// requests this replica denied because a session exceeded them.
+type sessionLimitsStatus struct {
+	MaxLifetime        string `json:"max_lifetime,omitempty"`
+	IdleTimeout        string `json:"idle_timeout,omitempty"`
+	IdleTimeoutDenials uint64 `json:"idle_timeout_denials"`
+	MaxLifetimeDenials uint64 `json:"max_lifetime_denials"`
 }
 
 // handleReady is a readiness probe that succeeds only after the first valid
@@ -36,15 +48,27 @@ func (s *gatewayServer) handleReady(w http.ResponseWriter, _ *http.Request) {
 }
 
 // handleConfigStatus reports the sanitized applied schema version, revision,
-// load timestamp, and last reload error for the active policy snapshot.
+// load timestamp, and last reload error for the active policy snapshot, along
+// with the session limit counters.
 func (s *gatewayServer) handleConfigStatus(w http.ResponseWriter, _ *http.Request) {
 	snapshot := s.loadPolicySnapshot()
 	status := configStatus{
 		Ready:    snapshot.Ready,
 		Revision: snapshot.Revision,
+		SessionLimits: sessionLimitsStatus{
+			IdleTimeoutDenials: s.sessionIdleTimeoutDenials.Load(),
+			MaxLifetimeDenials: s.sessionMaxLifetimeDenials.Load(),
+		},
 	}
 	if snapshot.Policy != nil {
 		status.SchemaVersion = snapshot.Policy.SchemaVersion
+		maxLifetime, idleTimeout := policypkg.SessionLimits(snapshot.Policy)
+		if maxLifetime > 0 {
+			status.SessionLimits.MaxLifetime = maxLifetime.String()
+		}
+		if idleTimeout > 0 {
+			status.SessionLimits.IdleTimeout = idleTimeout.String()
+		}
 	}
 	if !snapshot.LoadedAt.IsZero() {
 		status.LoadedAt = snapshot.LoadedAt.UTC().Format(time.RFC3339)
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -40,15 +42,48 @@ type policySnapshot struct {
 }
 
 type rpcInspection struct {
-	Method        string
-	ToolName      string
-	ToolCall      bool
+	// ID is the raw JSON-RPC id of a single request, or empty for a
+	// notification or batch.
+	ID       json.RawMessage
+	Method   string
+	ToolName string
+	// Arguments is the decoded tools/call params.arguments object, used to
+	// evaluate tool rule argument constraints.
+	Arguments map[string]any
+	// RawArguments is params.arguments exactly as the caller sent it. The
+	// approval digest covers these bytes, since decoding turns numbers into
+	// float64 and would round large integers.
+	RawArguments json.RawMessage
+	// ResourceURI is params.uri of resources/read and resources/subscribe.
+	ResourceURI string
+	ToolCall    bool
+	// Governed is true for methods authorized against grants: tool calls,
+	// prompts/get, resources/read, and resources/subscribe.
+	Governed      bool
 	Indeterminate bool
 	FailureReason string
 	// IsRPCAttempt is true when the request had application/json content-type
 	// (or no content-type) and is therefore a genuine MCP client attempt that
 	// should be audited even when Method could not be extracted.
 	IsRPCAttempt bool
+	// Batch holds the entries of a JSON-RPC batch request in order. It is
+	// non-empty only when Method is rpcBatchMethod; ToolCall and Governed are
+	// then true when any entry is.
+	Batch []rpcBatchEntry
+}
+
+// rpcBatchEntry is one inspected entry of a JSON-RPC batch request.
+type rpcBatchEntry struct {
+	// ID is the raw JSON-RPC id, or empty for a notification.
+	ID          json.RawMessage
+	Method      string
+	ToolName    string
+	Arguments   map[string]any
+	ResourceURI string
+	ToolCall    bool
+	Governed    bool
+	// Raw is the entry exactly as the caller sent it.
+	Raw json.RawMessage
 }
 
 type oauthProvider struct {
@@ -68,8 +103,8 @@ type oauthAuthResult struct {
 }
 
 type analyticsEvent struct {
-	Envelope     events.Envelope
-	TraceContext map[string]string
+	Envelope     events.Envelope   `json:"envelope"`
+	TraceContext map[string]string `json:"trace_context,omitempty"`
 }
 
 type gatewayServer struct {
@@ -104,12 +139,41 @@ type gatewayServer struct {
 	oauthMu               sync.Mutex
 	oauthProviders        map[string]*oauthProvider
 	policyState           atomic.Value
+	rateLimits            rateLimitStore
+	sessionActivity       sessionActivityStore
+	// spool, when set, is the on-disk write-ahead log analytics events go
+	// through instead of analyticsQueue; see ANALYTICS_SPOOL_DIR.
+	spool        *eventSpool
+	spoolStop    chan struct{}
+	spoolDone    chan struct{}
+	spoolCancel  context.CancelFunc
+	spoolAdopted atomic.Pointer[eventSpool]
+	// approvalURL is the operator endpoint tools/calls that require approval
+	// are recorded and resolved at; empty answers them approval_unavailable.
+	approvalURL string
+	// sessionActivityURL is the operator endpoint the operator session
+	// activity store reports to; see SESSION_ACTIVITY_URL.
+	sessionActivityURL string
+	// rateLimitURL is the operator endpoint the operator rate limit store
+	// counts at; see RATE_LIMIT_URL.
+	rateLimitURL string
+	// operatorTokenFile is the projected ServiceAccount token approval,
+	// session activity, and rate limit requests authenticate with; see
+	// OPERATOR_TOKEN_FILE.
+	operatorTokenFile string
+	// sessionIdleTimeoutDenials and sessionMaxLifetimeDenials count requests
+	// this replica denied for an expired session; see /config/status.
+	sessionIdleTimeoutDenials atomic.Uint64
+	sessionMaxLifetimeDenials atomic.Uint64
 }
 
 type statusRecorder struct {
 	http.ResponseWriter
 	status int
 	bytes  int
+	// capture, when set by upstreamFilter, keeps a bounded copy of the
+	// upstream response so the audit stage can record its JSON-RPC outcome.
+	capture *responseCapture
 }
 
 const (
@@ -48,6 +48,12 @@ func ValidSideEffect(sideEffect sentinelaccess.ToolSideEffect) bool {
 	}
 }
 
+// ValidToolDecision reports whether decision is valid on a tool rule, which
+// additionally accepts requires_approval.
+func ValidToolDecision(decision sentinelaccess.PolicyDecision) bool {
+	return decision == sentinelaccess.DecisionRequiresApproval || ValidDecision(decision)
+}
+
 func ValidDecision(decision sentinelaccess.PolicyDecision) bool {
 	switch decision {
 	case "allow", "deny":
@@ -0,0 +1,230 @@
+package runtimeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	runtimeaccess "mcp-runtime-api/internal/runtimeapi/access"
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	sentinelaccess "mcp-runtime/pkg/access"
+	"mcp-runtime/pkg/k8sclient"
+	"mcp-runtime/pkg/serviceutil"
+)
+
+type approvalDecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+// HandleRuntimeApprovals lists MCPToolApproval resources on servers the caller administers.
+func (s *AccessService) HandleRuntimeApprovals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("allow", "GET")
+		writeAPIError(w, http.StatusMethodNotAllowed, "method_not_allowed")
+		return
+	}
+	if s == nil || s.accessMgr == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "kubernetes not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	namespace, err := s.scopedNamespaceForPrincipal(r.Context(), r.URL.Query().Get("namespace"))
+	if err != nil {
+		writeAPIError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	approvals, err := s.accessMgr.ListApprovals(ctx, namespace)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "failed to list approvals")
+		return
+	}
+
+	phase := strings.TrimSpace(r.URL.Query().Get("phase"))
+	p, filterByPrincipal := principalFromContext(ctx)
+	filterByPrincipal = filterByPrincipal && p.Role != roleAdmin
+	summaries := make([]sentinelaccess.ApprovalSummary, 0, len(approvals.Items))
+	for _, approval := range approvals.Items {
+		summary := sentinelaccess.ToApprovalSummary(approval)
+		if phase != "" && !strings.EqualFold(summary.Phase, phase) {
+			continue
+		}
+		if filterByPrincipal {
+			allowed, err := s.canAdministerAccessServerRef(ctx, approval.Namespace, approval.Spec.ServerRef)
+			if err != nil {
+				log.Printf("runtime approval list: authz lookup for %s/%s failed: %v", approval.Namespace, approval.Name, err)
+				writeAPIError(w, http.StatusInternalServerError, "failed to inspect server references")
+				return
+			}
+			if !allowed {
+				continue
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"approvals": summaries})
+}
+
+// HandleApprovalItemPath handles GET for one MCPToolApproval and POST
+// /api/runtime/approvals/{namespace}/{name}/approve|deny.
+func (s *AccessService) HandleApprovalItemPath(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ns, name, err := runtimeaccess.ExtractNamespacedPath(r.URL.Path, "/api/runtime/approvals/", 2)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.handleApprovalGet(w, r, ns, name)
+	case http.MethodPost:
+		path := strings.TrimSuffix(serviceutil.NormalizePublicAPIPath(r.URL.Path), "/")
+		action := path[strings.LastIndex(path, "/")+1:]
+		var decision sentinelaccess.ApprovalDecision
+		switch action {
+		case "approve":
+			decision = sentinelaccess.ApprovalApproved
+		case "deny":
+			decision = sentinelaccess.ApprovalDenied
+		default:
+			writeAPIError(w, http.StatusBadRequest, "expected {namespace}/{name}/approve or {namespace}/{name}/deny")
+			return
+		}
+		ns, name, err := runtimeaccess.ExtractNamespacedPath(strings.TrimSuffix(path, "/"+action), "/api/runtime/approvals/", 2)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.handleApprovalDecide(w, r, ns, name, decision)
+	default:
+		w.Header().Set("allow", "GET, POST")
+		writeAPIError(w, http.StatusMethodNotAllowed, "method_not_allowed")
+	}
+}
+
+func (s *AccessService) handleApprovalGet(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	if s.accessMgr == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "kubernetes not available")
+		return
+	}
+	namespace, err := s.scopedNamespaceForPrincipal(r.Context(), namespace)
+	if err != nil {
+		writeAPIError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	approval, err := s.accessMgr.GetApproval(ctx, name, namespace)
+	if err != nil {
+		code, msg := k8sclient.HTTPStatusFromK8sError(err)
+		writeAPIError(w, code, msg)
+		return
+	}
+	allowed, err := s.canAdministerAccessServerRef(ctx, namespace, approval.Spec.ServerRef)
+	if err != nil {
+		log.Printf("get approval %s/%s authz lookup failed: %v", namespace, name, err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to verify server reference")
+		return
+	}
+	if !allowed {
+		writeAPIError(w, http.StatusForbidden, "forbidden server")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"approval": sentinelaccess.ToApprovalSummary(*approval)})
+}
+
+// handleApprovalDecide approves or denies a pending tool call. Only owners of
+// the approval's server may decide, and every attempt is audited with the
+// approver's identity.
+func (s *AccessService) handleApprovalDecide(w http.ResponseWriter, r *http.Request, namespace, name string, decision sentinelaccess.ApprovalDecision) {
+	if s.accessMgr == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "kubernetes not available")
+		return
+	}
+	p, _ := principalFromContext(r.Context())
+	action := "tool_approval_" + string(decision)
+	namespace, nsErr := s.scopedAccessWriteNamespaceForPrincipal(r.Context(), namespace)
+	if nsErr != nil {
+		s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "denied", name, namespace, "", nsErr.Error()))
+		writeAPIError(w, http.StatusForbidden, nsErr.Error())
+		return
+	}
+
+	var req approvalDecisionRequest
+	r.Body = http.MaxBytesReader(w, r.Body, accessApplyMaxBytes)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeBodyDecodeError(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	approval, err := s.accessMgr.GetApproval(ctx, name, namespace)
+	if err != nil {
+		code, msg := k8sclient.HTTPStatusFromK8sError(err)
+		writeAPIError(w, code, msg)
+		return
+	}
+	server := string(approval.Spec.ServerRef.Name)
+	allowed, err := s.canAdministerAccessServerRef(ctx, namespace, approval.Spec.ServerRef)
+	if err != nil {
+		log.Printf("decide approval %s/%s authz lookup failed: %v", namespace, name, err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to verify server reference")
+		return
+	}
+	if !allowed {
+		s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "denied", name, namespace, server, "forbidden server"))
+		writeAPIError(w, http.StatusForbidden, "forbidden server")
+		return
+	}
+
+	approver := approverIdentity(p)
+	if requester := string(approval.Spec.Subject.HumanID); requester != "" && (requester == p.Email || requester == p.UserID()) {
+		s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "denied", name, namespace, server, "approver made the call"))
+		writeAPIError(w, http.StatusForbidden, "approvers cannot decide their own tool calls")
+		return
+	}
+	decided, err := s.accessMgr.DecideApproval(ctx, name, namespace, decision, approver, strings.TrimSpace(req.Comment))
+	if err != nil {
+		if errors.Is(err, sentinelaccess.ErrApprovalClosed) {
+			s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "denied", name, namespace, server, "approval is no longer pending"))
+			writeAPIError(w, http.StatusConflict, "approval is no longer pending")
+			return
+		}
+		code, msg := k8sclient.HTTPStatusFromK8sError(err)
+		log.Printf("decide approval %s/%s failed (status=%d): %v", namespace, name, code, err)
+		s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "error", name, namespace, server, msg))
+		writeAPIError(w, code, "failed to record decision")
+		return
+	}
+	s.writeAudit(r.Context(), approvalAuditEvent(r, p, action, "success", name, namespace, server,
+		"tool "+approval.Spec.Tool+" "+string(decision)+" by "+approver))
+	writeJSON(w, http.StatusOK, map[string]any{"approval": sentinelaccess.ToApprovalSummary(*decided)})
+}
+
+// approverIdentity names the caller recorded as decidedBy on an approval.
+func approverIdentity(p principal) string {
+	return firstNonEmpty(p.Email, p.UserID(), auditIdentityLabel(p), "runtime-api")
+}
+
+func approvalAuditEvent(r *http.Request, p principal, action, status, name, namespace, server, message string) auditEvent {
+	return auditEvent{
+		UserID:       p.UserID(),
+		Action:       action,
+		Resource:     mcpv1alpha1.MCPToolApprovalResource + "/" + strings.TrimSpace(name),
+		Namespace:    strings.TrimSpace(namespace),
+		Status:       status,
+		Message:      strings.TrimSpace(message),
+		ActorIP:      requestIP(r),
+		Source:       auditSource(r, p),
+		AuthIdentity: auditIdentityLabel(p),
+		ServerName:   strings.TrimSpace(server),
+	}
+}
@@ -0,0 +1,122 @@
+package runtimeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	sentinelaccess "mcp-runtime/pkg/access"
+)
+
+func testToolApproval(name, humanID string, decision mcpv1alpha1.ApprovalDecision) *mcpv1alpha1.MCPToolApproval {
+	return &mcpv1alpha1.MCPToolApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "user-1"},
+		Spec: mcpv1alpha1.MCPToolApprovalSpec{
+			ServerRef: mcpv1alpha1.ServerReference{Name: "demo"},
+			Subject:   mcpv1alpha1.SubjectRef{HumanID: humanID},
+			Tool:      "refund",
+			Decision:  decision,
+			ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)},
+		},
+	}
+}
+
+func decideApprovalAs(t *testing.T, objects []runtime.Object, p principal, name, action string) (*httptest.ResponseRecorder, *fakeAuditWriter, *sentinelaccess.Manager) {
+	t.Helper()
+	audit := &fakeAuditWriter{}
+	accessMgr := newTestAccessManagerWithObjects(t, objects...)
+	server := &RuntimeServer{accessMgr: accessMgr, audit: audit}
+	request := httptest.NewRequest(http.MethodPost, "/api/runtime/approvals/user-1/"+name+"/"+action, strings.NewReader(`{"comment":"checked"}`))
+	request = request.WithContext(withPrincipal(request.Context(), p))
+	recorder := httptest.NewRecorder()
+	server.Access().HandleApprovalItemPath(recorder, request)
+	return recorder, audit, accessMgr
+}
+
+func approvalTestPrincipal(subject string) principal {
+	return principal{
+		Role:              roleUser,
+		Subject:           subject,
+		Email:             subject + "@example.com",
+		Namespace:         "user-1",
+		AllowedNamespaces: []string{"user-1"},
+	}
+}
+
+func TestApprovalDecideRecordsOwnerDecision(t *testing.T) {
+	objects := []runtime.Object{ownedTestMCPServer("demo", "user-1", "user-1"), testToolApproval("demo-a1", "agent-caller", "")}
+
+	recorder, audit, _ := decideApprovalAs(t, objects, approvalTestPrincipal("user-1"), "demo-a1", "approve")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d body = %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), `"decidedBy":"user-1@example.com"`) {
+		t.Fatalf("body = %s, want the decision recorded with the approver's identity", recorder.Body.String())
+	}
+	if len(audit.events) != 1 || audit.events[0].Status != "success" || audit.events[0].Action != "tool_approval_"+string(sentinelaccess.ApprovalApproved) {
+		t.Fatalf("audit = %+v, want one successful approval", audit.events)
+	}
+}
+
+func TestApprovalDecideRejectsNonOwner(t *testing.T) {
+	objects := []runtime.Object{ownedTestMCPServer("demo", "user-1", "user-1"), testToolApproval("demo-a1", "agent-caller", "")}
+
+	recorder, audit, accessMgr := decideApprovalAs(t, objects, approvalTestPrincipal("user-2"), "demo-a1", "approve")
+
+	if recorder.Code != http.StatusForbidden || !strings.Contains(recorder.Body.String(), "forbidden server") {
+		t.Fatalf("status = %d body = %s, want 403 forbidden server", recorder.Code, recorder.Body.String())
+	}
+	if len(audit.events) != 1 || audit.events[0].Status != "denied" {
+		t.Fatalf("audit = %+v, want the attempt audited as denied", audit.events)
+	}
+	assertApprovalUndecided(t, accessMgr, "demo-a1")
+}
+
+func TestApprovalDecideRejectsSelfApproval(t *testing.T) {
+	for _, humanID := range []string{"user-1", "user-1@example.com"} {
+		t.Run(humanID, func(t *testing.T) {
+			objects := []runtime.Object{ownedTestMCPServer("demo", "user-1", "user-1"), testToolApproval("demo-a1", humanID, "")}
+
+			recorder, audit, accessMgr := decideApprovalAs(t, objects, approvalTestPrincipal("user-1"), "demo-a1", "approve")
+
+			if recorder.Code != http.StatusForbidden || !strings.Contains(recorder.Body.String(), "cannot decide their own tool calls") {
+				t.Fatalf("status = %d body = %s, want 403 for a self-approval", recorder.Code, recorder.Body.String())
+			}
+			if len(audit.events) != 1 || audit.events[0].Message != "approver made the call" {
+				t.Fatalf("audit = %+v, want the self-approval audited", audit.events)
+			}
+			assertApprovalUndecided(t, accessMgr, "demo-a1")
+		})
+	}
+}
+
+func TestApprovalDecideRejectsClosedApproval(t *testing.T) {
+	objects := []runtime.Object{ownedTestMCPServer("demo", "user-1", "user-1"), testToolApproval("demo-a1", "agent-caller", mcpv1alpha1.ApprovalDecisionDenied)}
+
+	recorder, audit, _ := decideApprovalAs(t, objects, approvalTestPrincipal("user-1"), "demo-a1", "approve")
+
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "no longer pending") {
+		t.Fatalf("status = %d body = %s, want 409 for a decided approval", recorder.Code, recorder.Body.String())
+	}
+	if len(audit.events) != 1 || audit.events[0].Status != "denied" {
+		t.Fatalf("audit = %+v, want the attempt audited as denied", audit.events)
+	}
+}
+
+func assertApprovalUndecided(t *testing.T, accessMgr *sentinelaccess.Manager, name string) {
+	t.Helper()
+	approval, err := accessMgr.GetApproval(context.Background(), name, "user-1")
+	if err != nil {
+		t.Fatalf("GetApproval: %v", err)
+	}
+	if approval.Spec.Decision != "" {
+		t.Fatalf("approval %s decided as %q", name, approval.Spec.Decision)
+	}
+}
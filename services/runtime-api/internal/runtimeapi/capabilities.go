@@ -27,6 +27,13 @@ func (s *DeploymentService) purgeExpiredRegistryPushTransfers(ctx context.Contex
 	(&RegistryPushService{k8sClients: s.k8sClients}).purgeExpiredRegistryPushTransfers(ctx)
 }
 
+func (s *AccessService) writeAudit(ctx context.Context, ev auditEvent) {
+	if s == nil || s.audit == nil {
+		return
+	}
+	s.audit.WriteAudit(ctx, ev)
+}
+
 func (s *AccessService) controlPlane() *controlplane.Manager {
 	if s == nil || s.k8sClients == nil {
 		return nil
@@ -71,12 +71,69 @@ func validateGrantRequest(req *accessGrantRequest) error {
 		if req.ToolRules[i].Name == "" {
 			return fmt.Errorf("toolRules[%d].name is required", i)
 		}
-		if !runtimeaccess.ValidDecision(req.ToolRules[i].Decision) {
-			return fmt.Errorf("toolRules[%d].decision must be allow or deny", i)
+		if !runtimeaccess.ValidToolDecision(req.ToolRules[i].Decision) {
+			return fmt.Errorf("toolRules[%d].decision must be allow, deny, or requires_approval", i)
 		}
 		if req.ToolRules[i].RequiredTrust != "" && !runtimeaccess.ValidTrust(req.ToolRules[i].RequiredTrust) {
 			return fmt.Errorf("toolRules[%d].requiredTrust must be low, medium, or high", i)
 		}
+		if len(req.ToolRules[i].ArgumentConstraints) > 0 && req.ToolRules[i].Decision == sentinelaccess.DecisionDeny {
+			return fmt.Errorf("toolRules[%d].argumentConstraints only apply to allow rules", i)
+		}
+		for j, constraint := range req.ToolRules[i].ArgumentConstraints {
+			if strings.TrimSpace(constraint.Argument) == "" {
+				return fmt.Errorf("toolRules[%d].argumentConstraints[%d].argument is required", i, j)
+			}
+			if len(constraint.OneOf) == 0 && constraint.Pattern == "" && constraint.Minimum == nil && constraint.Maximum == nil {
+				return fmt.Errorf("toolRules[%d].argumentConstraints[%d] must set oneOf, pattern, minimum, or maximum", i, j)
+			}
+			if constraint.Minimum != nil && constraint.Maximum != nil && *constraint.Minimum > *constraint.Maximum {
+				return fmt.Errorf("toolRules[%d].argumentConstraints[%d].minimum must not exceed maximum", i, j)
+			}
+		}
+	}
+	for i := range req.PromptRules {
+		req.PromptRules[i].Name = strings.TrimSpace(req.PromptRules[i].Name)
+		req.PromptRules[i].Decision = sentinelaccess.PolicyDecision(strings.TrimSpace(string(req.PromptRules[i].Decision)))
+		req.PromptRules[i].RequiredTrust = runtimeaccess.NormalizeTrust(req.PromptRules[i].RequiredTrust)
+		if req.PromptRules[i].Name == "" {
+			return fmt.Errorf("promptRules[%d].name is required", i)
+		}
+		if !runtimeaccess.ValidDecision(req.PromptRules[i].Decision) {
+			return fmt.Errorf("promptRules[%d].decision must be allow or deny", i)
+		}
+		if req.PromptRules[i].RequiredTrust != "" && !runtimeaccess.ValidTrust(req.PromptRules[i].RequiredTrust) {
+			return fmt.Errorf("promptRules[%d].requiredTrust must be low, medium, or high", i)
+		}
+	}
+	for i := range req.ResourceRules {
+		req.ResourceRules[i].URI = strings.TrimSpace(req.ResourceRules[i].URI)
+		req.ResourceRules[i].Decision = sentinelaccess.PolicyDecision(strings.TrimSpace(string(req.ResourceRules[i].Decision)))
+		req.ResourceRules[i].RequiredTrust = runtimeaccess.NormalizeTrust(req.ResourceRules[i].RequiredTrust)
+		if req.ResourceRules[i].URI == "" {
+			return fmt.Errorf("resourceRules[%d].uri is required", i)
+		}
+		if !runtimeaccess.ValidDecision(req.ResourceRules[i].Decision) {
+			return fmt.Errorf("resourceRules[%d].decision must be allow or deny", i)
+		}
+		if req.ResourceRules[i].RequiredTrust != "" && !runtimeaccess.ValidTrust(req.ResourceRules[i].RequiredTrust) {
+			return fmt.Errorf("resourceRules[%d].requiredTrust must be low, medium, or high", i)
+		}
+	}
+	for i := range req.RateLimits {
+		req.RateLimits[i].Tool = strings.TrimSpace(req.RateLimits[i].Tool)
+		if req.RateLimits[i].SideEffect != "" {
+			req.RateLimits[i].SideEffect = runtimeaccess.NormalizeSideEffect(req.RateLimits[i].SideEffect)
+			if !runtimeaccess.ValidSideEffect(req.RateLimits[i].SideEffect) {
+				return fmt.Errorf("rateLimits[%d].sideEffect must be read, write, or destructive", i)
+			}
+		}
+		if req.RateLimits[i].CallsPerMinute < 0 || req.RateLimits[i].CallsPerDay < 0 {
+			return fmt.Errorf("rateLimits[%d] must not be negative", i)
+		}
+		if req.RateLimits[i].CallsPerMinute == 0 && req.RateLimits[i].CallsPerDay == 0 {
+			return fmt.Errorf("rateLimits[%d] requires callsPerMinute or callsPerDay", i)
+		}
 	}
 	return nil
 }
@@ -201,6 +258,9 @@ func (s *AccessService) handleRuntimeGrantApply(w http.ResponseWriter, r *http.R
 			PolicyVersion:      runtimeaccess.DefaultPolicyVersion(req.PolicyVersion),
 			Disabled:           disabled,
 			ToolRules:          req.ToolRules,
+			PromptRules:        req.PromptRules,
+			ResourceRules:      req.ResourceRules,
+			RateLimits:         req.RateLimits,
 		},
 	}
 	applied, err := s.accessMgr.ApplyGrant(ctx, grant)
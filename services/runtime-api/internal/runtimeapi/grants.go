@@ -25,6 +25,9 @@ type accessGrantRequest struct {
 	PolicyVersion      string                          `json:"policyVersion"`
 	Disabled           *bool                           `json:"disabled,omitempty"`
 	ToolRules          []sentinelaccess.ToolRule       `json:"toolRules"`
+	PromptRules        []sentinelaccess.PromptRule     `json:"promptRules,omitempty"`
+	ResourceRules      []sentinelaccess.ResourceRule   `json:"resourceRules,omitempty"`
+	RateLimits         []sentinelaccess.RateLimit      `json:"rateLimits,omitempty"`
 }
 
 type accessGrantPatchRequest struct {
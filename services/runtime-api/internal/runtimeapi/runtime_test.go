@@ -77,11 +77,31 @@ func TestValidateGrantRequestRejectsInvalidToolRule(t *testing.T) {
 	}
 
 	err := validateGrantRequest(req)
-	if err == nil || !strings.Contains(err.Error(), "decision must be allow or deny") {
+	if err == nil || !strings.Contains(err.Error(), "decision must be allow, deny, or requires_approval") {
 		t.Fatalf("validateGrantRequest error = %v, want invalid decision", err)
 	}
 }
 
+func TestValidateGrantRequestAcceptsRequiresApprovalOnToolRules(t *testing.T) {
+	req := &accessGrantRequest{
+		Name:               "grant-a",
+		ServerRef:          sentinelaccess.ServerReference{Name: "demo"},
+		Subject:            sentinelaccess.SubjectRef{HumanID: "user-1"},
+		AllowedSideEffects: []sentinelaccess.ToolSideEffect{"write"},
+		ToolRules: []sentinelaccess.ToolRule{
+			{Name: "refund", Decision: sentinelaccess.DecisionRequiresApproval},
+		},
+	}
+	if err := validateGrantRequest(req); err != nil {
+		t.Fatalf("validateGrantRequest error = %v, want requires_approval accepted on a tool rule", err)
+	}
+
+	req.PromptRules = []sentinelaccess.PromptRule{{Name: "summary", Decision: sentinelaccess.DecisionRequiresApproval}}
+	if err := validateGrantRequest(req); err == nil || !strings.Contains(err.Error(), "promptRules[0].decision must be allow or deny") {
+		t.Fatalf("validateGrantRequest error = %v, want requires_approval rejected on a prompt rule", err)
+	}
+}
+
 func TestValidateGrantRequestRequiresAllowedSideEffect(t *testing.T) {
 	req := &accessGrantRequest{
 		Name:      "grant-a",
@@ -128,6 +128,9 @@ func (rr runtimeRoutes) registerRoutes(mux *http.ServeMux) {
 	rr.mount("/runtime/sessions", rr.auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		runtimehandlers.HandleRuntimeSessions(accessService, w, r)
 	})))
+	rr.mount("/runtime/approvals", rr.auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runtimehandlers.HandleRuntimeApprovals(accessService, w, r)
+	})))
 	rr.mount("/runtime/adapter/sessions", rr.auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		runtimehandlers.HandleAdapterSession(accessService, w, r)
 	})))
@@ -154,6 +157,9 @@ func (rr runtimeRoutes) registerRoutes(mux *http.ServeMux) {
 	rr.mount("/runtime/sessions/", rr.auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		runtimehandlers.HandleSessionItemPath(accessService, w, r)
 	})))
+	rr.mount("/runtime/approvals/", rr.auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runtimehandlers.HandleApprovalItemPath(accessService, w, r)
+	})))
 	rr.mount("/user/api-keys", rr.auth(http.HandlerFunc(rr.handleUserAPIKeys)))
 	rr.mount("/user/api-keys/", rr.auth(http.HandlerFunc(rr.handleUserAPIKeyItem)))
 }
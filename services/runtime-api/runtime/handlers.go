@@ -61,6 +61,11 @@ func HandleRuntimeSessions(service *runtimeapi.AccessService, w http.ResponseWri
 	service.HandleRuntimeSessions(w, r)
 }
 
+// HandleRuntimeApprovals routes runtime tool call approval listing through the access service.
+func HandleRuntimeApprovals(service *runtimeapi.AccessService, w http.ResponseWriter, r *http.Request) {
+	service.HandleRuntimeApprovals(w, r)
+}
+
 // HandleAdapterSession routes adapter session requests through the access service.
 func HandleAdapterSession(service *runtimeapi.AccessService, w http.ResponseWriter, r *http.Request) {
 	service.HandleAdapterSession(w, r)
@@ -100,3 +105,8 @@ func HandleGrantItemPath(service *runtimeapi.AccessService, w http.ResponseWrite
 func HandleSessionItemPath(service *runtimeapi.AccessService, w http.ResponseWriter, r *http.Request) {
 	service.HandleSessionItemPath(w, r)
 }
+
+// HandleApprovalItemPath routes approval item requests and decisions through the access service.
+func HandleApprovalItemPath(service *runtimeapi.AccessService, w http.ResponseWriter, r *http.Request) {
+	service.HandleApprovalItemPath(w, r)
+}
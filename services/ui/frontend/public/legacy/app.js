@@ -9,6 +9,7 @@ let authenticated = null;
 let authPrincipal = null;
 let grantsCache = [];
 let sessionsCache = [];
+let approvalsCache = [];
 let userAPIKeysCache = [];
 let teamsCache = [];
 let teamMembersCache = [];
@@ -339,6 +340,7 @@ function initTabs() {
       } else if (target === "governance") {
         loadGrants();
         loadSessions();
+        loadApprovals();
         if (isAdminUser()) {
           loadGovernanceDecisionAnalytics();
           loadEvents();
@@ -1070,6 +1072,7 @@ function loadActiveTab() {
   } else if (active === "governance") {
     loadGrants();
     loadSessions();
+    loadApprovals();
     if (isAdminUser()) {
       loadGovernanceDecisionAnalytics();
       loadEvents();
@@ -1391,6 +1394,7 @@ function renderUserAnalyticsError() {
 function resetGovernance() {
   grantsCache = [];
   sessionsCache = [];
+  approvalsCache = [];
   setInlineError("grant-form-error");
   renderGovernanceSummary();
   const grantsBody = document.getElementById("grants-body");
@@ -1401,6 +1405,10 @@ function resetGovernance() {
   if (sessionsBody) {
     sessionsBody.innerHTML = '<tr><td colspan="6" class="empty">No sessions found.</td></tr>';
   }
+  const approvalsBody = document.getElementById("approvals-body");
+  if (approvalsBody) {
+    approvalsBody.innerHTML = '<tr><td colspan="6" class="empty">No pending approvals.</td></tr>';
+  }
 }
 
 async function loadServers() {
@@ -2767,6 +2775,78 @@ async function toggleSession(namespace, name, currentlyRevoked) {
   }
 }
 
+// Governance - Tool call approvals
+async function loadApprovals() {
+  try {
+    const data = await fetchJSON(scopedPath("/runtime/approvals?phase=Pending"));
+    approvalsCache = Array.isArray(data.approvals) ? data.approvals : [];
+    renderApprovals();
+  } catch (err) {
+    if (isUnauthorizedError(err)) return;
+    console.error("Failed to load approvals:", err);
+    approvalsCache = [];
+    document.getElementById("approvals-body").innerHTML =
+      '<tr><td colspan="6" class="empty">Error loading approvals.</td></tr>';
+  }
+}
+
+function renderApprovals() {
+  const tbody = document.getElementById("approvals-body");
+  if (!tbody) return;
+
+  if (approvalsCache.length === 0) {
+    tbody.innerHTML = '<tr><td colspan="6" class="empty">No pending approvals.</td></tr>';
+    return;
+  }
+
+  tbody.innerHTML = "";
+  const fragment = document.createDocumentFragment();
+
+  approvalsCache.forEach((approval) => {
+    const namespace = approval.namespace || activeScopeNamespace();
+    const row = document.createElement("tr");
+    row.appendChild(createIdentityCell(approval.name || "-", namespace));
+    row.appendChild(createIdentityCell(approval.serverRef?.name || "-", approval.riskLevel || ""));
+    row.appendChild(createSubjectCell(approval.subject));
+    row.appendChild(createIdentityCell(approval.tool || "-", approval.arguments || ""));
+    row.appendChild(createTextCell(formatDateTime(approval.expiresAt)));
+
+    const actions = createActionCell("Approve", () =>
+      decideApproval(namespace, approval.name || "", "approve")
+    );
+    const deny = document.createElement("button");
+    deny.type = "button";
+    deny.className = "ghost action-btn";
+    deny.textContent = "Deny";
+    deny.addEventListener("click", () => decideApproval(namespace, approval.name || "", "deny"));
+    actions.appendChild(deny);
+    row.appendChild(actions);
+    fragment.appendChild(row);
+  });
+
+  tbody.appendChild(fragment);
+}
+
+async function decideApproval(namespace, name, action) {
+  if (!(await confirmModal(`${action === "approve" ? "Approve" : "Deny"} tool call "${name}"?`))) return;
+
+  try {
+    await fetchJSON(
+      `/runtime/approvals/${encodePathSegment(namespace)}/${encodePathSegment(name)}/${action}`,
+      {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({}),
+      }
+    );
+    showToast(`Tool call ${action === "approve" ? "approved" : "denied"}`);
+    loadApprovals();
+  } catch (err) {
+    if (isUnauthorizedError(err)) return;
+    showToast(`Failed to ${action} tool call: ${err.message}`, "error");
+  }
+}
+
 async function applySession(event) {
   event.preventDefault();
   const submit = event.submitter;
@@ -2890,6 +2970,7 @@ function initGovernance() {
 
   document.getElementById("refresh-grants")?.addEventListener("click", loadGrants);
   document.getElementById("refresh-sessions")?.addEventListener("click", loadSessions);
+  document.getElementById("refresh-approvals")?.addEventListener("click", loadApprovals);
   document.getElementById("show-grant-form")?.addEventListener("click", () => {
     setInlineError("grant-form-error");
     document.getElementById("grant-form")?.classList.toggle("hidden");